@@ -21,6 +21,10 @@ pushd /workdir/go/oasis-test-runner/scenario/pluginsigner/example_signer_plugin
     buildkite-agent artifact upload example_signer_plugin
 popd
 
+pushd /workdir/go/oasis-test-runner/scenario/pluginsigner/mock_ledger_signer_plugin
+    buildkite-agent artifact upload mock_ledger_signer_plugin
+popd
+
 # Net runner.
 pushd /workdir/go/oasis-net-runner
     buildkite-agent artifact upload oasis-net-runner
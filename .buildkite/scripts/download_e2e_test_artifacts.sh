@@ -22,6 +22,7 @@ download_artifact oasis-test-runner go/oasis-test-runner 755
 download_artifact oasis-test-runner.test go/oasis-test-runner 755
 download_artifact oasis-remote-signer go/oasis-remote-signer 755
 download_artifact example_signer_plugin go/oasis-test-runner/scenario/pluginsigner/example_signer_plugin 755
+download_artifact mock_ledger_signer_plugin go/oasis-test-runner/scenario/pluginsigner/mock_ledger_signer_plugin 755
 
 # Upgrade test runners.
 download_artifact oasis-test-pre-upgrade tests/upgrade/pre 755
@@ -58,6 +58,9 @@ ${test_runner_binary} \
     --remote-signer.binary ${WORKDIR}/go/oasis-remote-signer/oasis-remote-signer \
     --plugin-signer.name example \
     --plugin-signer.binary ${WORKDIR}/go/oasis-test-runner/scenario/pluginsigner/example_signer_plugin/example_signer_plugin \
+    --plugin-signer/ledger.name ledger \
+    --plugin-signer/ledger.binary ${WORKDIR}/go/oasis-test-runner/scenario/pluginsigner/mock_ledger_signer_plugin/mock_ledger_signer_plugin \
+    --plugin-signer/ledger.node.binary ${node_binary} \
     --log.level debug \
     ${BUILDKITE_PARALLEL_JOB_COUNT:+--parallel.job_count ${BUILDKITE_PARALLEL_JOB_COUNT}} \
     ${BUILDKITE_PARALLEL_JOB:+--parallel.job_index ${BUILDKITE_PARALLEL_JOB}} \
@@ -3,6 +3,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -139,6 +140,10 @@ type ConsensusParameters struct {
 
 	// VRFParameters are the beacon parameters for the VRF backend.
 	VRFParameters *VRFParameters `json:"vrf_parameters,omitempty"`
+
+	// ExternalEntropy are the optional parameters for mixing externally submitted
+	// entropy into the epoch entropy. If not set, external entropy is not accepted.
+	ExternalEntropy *ExternalEntropyParameters `json:"external_entropy,omitempty"`
 }
 
 // Interval returns the epoch interval (in blocks).
@@ -159,6 +164,51 @@ type InsecureParameters struct {
 	Interval int64 `json:"interval,omitempty"`
 }
 
+// ConsensusParameterChanges are allowed beacon consensus parameter changes.
+//
+// Changes of the epoch interval only affect epochs that have not yet been scheduled, so
+// the new interval takes effect at the next epoch boundary. Debonding periods and node
+// registration expirations are expressed in epochs, so any in-flight ones remain valid
+// and only their duration in blocks changes.
+type ConsensusParameterChanges struct {
+	// Interval is the new epoch interval (in blocks).
+	Interval *int64 `json:"interval,omitempty"`
+
+	// ProofSubmissionDelay is the new VRF proof submission delay (in blocks).
+	ProofSubmissionDelay *int64 `json:"proof_delay,omitempty"`
+
+	// ExternalEntropy are the new external entropy parameters, including the set of submitters.
+	ExternalEntropy *ExternalEntropyParameters `json:"external_entropy,omitempty"`
+}
+
+// Apply applies changes to the given consensus parameters.
+func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.Interval != nil || c.ProofSubmissionDelay != nil {
+		if params.Backend != BackendVRF || params.VRFParameters == nil {
+			// The insecure backend derives transition heights from epoch numbers, so changing
+			// the interval would move the next epoch transition into the past.
+			return fmt.Errorf("VRF parameter changes are only supported by the VRF backend")
+		}
+		if params.DebugMockBackend {
+			return fmt.Errorf("VRF parameter changes are not supported by the mock backend")
+		}
+
+		vrfParams := *params.VRFParameters
+		if c.Interval != nil {
+			vrfParams.Interval = *c.Interval
+		}
+		if c.ProofSubmissionDelay != nil {
+			vrfParams.ProofSubmissionDelay = *c.ProofSubmissionDelay
+		}
+		params.VRFParameters = &vrfParams
+	}
+	if c.ExternalEntropy != nil {
+		params.ExternalEntropy = c.ExternalEntropy
+	}
+
+	return nil
+}
+
 // EpochEvent is the epoch event.
 type EpochEvent struct {
 	// Epoch is the new epoch.
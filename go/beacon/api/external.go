@@ -0,0 +1,58 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// GasOpSubmitExternalEntropy is the gas operation identifier for external entropy submission.
+const GasOpSubmitExternalEntropy transaction.Op = "submit_external_entropy"
+
+// MaxExternalEntropySize is the maximum size of externally submitted entropy.
+const MaxExternalEntropySize = 256
+
+var (
+	// MethodSubmitExternalEntropy is the method name for external entropy submission.
+	MethodSubmitExternalEntropy = transaction.NewMethodName(ModuleName, "SubmitExternalEntropy", ExternalEntropy{})
+
+	// DefaultExternalEntropyGasCosts are the default gas costs for external entropy operations.
+	DefaultExternalEntropyGasCosts = transaction.Costs{
+		GasOpSubmitExternalEntropy: 1000,
+	}
+)
+
+// ExternalEntropyParameters are the parameters for mixing externally submitted entropy
+// (e.g., drand beacons) into the epoch entropy.
+type ExternalEntropyParameters struct {
+	// Submitters are the public keys of the signers that may submit external entropy.
+	Submitters []signature.PublicKey `json:"submitters"`
+
+	// GasCosts are the external entropy submission gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+}
+
+// ExternalEntropy is an external entropy submission transaction payload.
+//
+// Entropy submitted during an epoch is mixed into the beacon generated at the transition
+// to the next epoch.
+type ExternalEntropy struct {
+	// Epoch is the epoch during which the entropy is submitted.
+	Epoch EpochTime `json:"epoch"`
+
+	// Entropy is the externally generated entropy.
+	Entropy []byte `json:"entropy"`
+}
+
+// NewSubmitExternalEntropyTx creates a new external entropy submission transaction.
+func NewSubmitExternalEntropyTx(nonce uint64, fee *transaction.Fee, ee *ExternalEntropy) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodSubmitExternalEntropy, ee)
+}
+
+// ExternalEntropyState is the state of the external entropy submissions.
+type ExternalEntropyState struct {
+	// Epoch is the epoch during which the entropy was submitted.
+	Epoch EpochTime `json:"epoch"`
+
+	// Entropy is the submitted entropy, keyed by submitter.
+	Entropy map[signature.PublicKey][]byte `json:"entropy,omitempty"`
+}
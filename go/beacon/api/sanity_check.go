@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 )
 
@@ -53,6 +54,10 @@ func (p *ConsensusParameters) SanityCheck() error {
 		return fmt.Errorf("unknown backend: '%s'", p.Backend)
 	}
 
+	if err := p.ExternalEntropy.SanityCheck(); err != nil {
+		return err
+	}
+
 	unsafeFlags := p.DebugMockBackend
 	if unsafeFlags && !flags.DebugDontBlameOasis() {
 		return fmt.Errorf("one or more unsafe debug flags set")
@@ -60,3 +65,39 @@ func (p *ConsensusParameters) SanityCheck() error {
 
 	return nil
 }
+
+// SanityCheck performs a sanity check on the consensus parameter changes.
+func (c *ConsensusParameterChanges) SanityCheck() error {
+	if c.Interval == nil &&
+		c.ProofSubmissionDelay == nil &&
+		c.ExternalEntropy == nil {
+		return fmt.Errorf("consensus parameter changes should not be empty")
+	}
+	if c.ExternalEntropy != nil {
+		if err := c.ExternalEntropy.SanityCheck(); err != nil {
+			return fmt.Errorf("external entropy: %w", err)
+		}
+	}
+	return nil
+}
+
+// SanityCheck performs a sanity check on the external entropy parameters.
+func (p *ExternalEntropyParameters) SanityCheck() error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Submitters) == 0 {
+		return fmt.Errorf("external entropy enabled without submitters")
+	}
+	seen := make(map[signature.PublicKey]struct{}, len(p.Submitters))
+	for _, pk := range p.Submitters {
+		if !pk.IsValid() {
+			return fmt.Errorf("invalid external entropy submitter: %s", pk)
+		}
+		if _, ok := seen[pk]; ok {
+			return fmt.Errorf("duplicate external entropy submitter: %s", pk)
+		}
+		seen[pk] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+var (
+	// KeyRotationSignatureContext is the context used for signing key rotation records.
+	KeyRotationSignatureContext = NewContext("oasis-core/signature: key rotation")
+
+	// KeyRevocationSignatureContext is the context used for signing key revocations.
+	KeyRevocationSignatureContext = NewContext("oasis-core/signature: key revocation")
+
+	// ErrInvalidKeyRotation is the error returned when a key rotation record is malformed or does
+	// not continue the key rotation chain.
+	ErrInvalidKeyRotation = errors.New("signature: invalid key rotation")
+
+	// ErrKeyRevoked is the error returned when a key has been revoked.
+	ErrKeyRevoked = errors.New("signature: key revoked")
+)
+
+// KeyRole is the role of a rotatable key.
+//
+// Only keys of roles that are not tracked by the consensus layer can be rotated via key rotation
+// records. Consensus-tracked keys are rotated by updating the corresponding registry descriptors.
+type KeyRole uint8
+
+const (
+	// KeyRoleP2P is the role of the node P2P link key.
+	KeyRoleP2P KeyRole = 1
+	// KeyRoleTLS is the role of the node TLS key.
+	KeyRoleTLS KeyRole = 2
+)
+
+// String returns the string representation of a KeyRole.
+func (r KeyRole) String() string {
+	switch r {
+	case KeyRoleP2P:
+		return "p2p"
+	case KeyRoleTLS:
+		return "tls"
+	default:
+		return "[unknown key role]"
+	}
+}
+
+// IsValid returns true iff the key role is valid.
+func (r KeyRole) IsValid() bool {
+	switch r {
+	case KeyRoleP2P, KeyRoleTLS:
+		return true
+	default:
+		return false
+	}
+}
+
+// KeyRotation is a key rotation record certifying that the old key of the given role has been
+// replaced by the new key.
+type KeyRotation struct {
+	// Role is the role of the rotated key.
+	Role KeyRole `json:"role"`
+	// Sequence is the sequence number of the rotation. Each rotation in a chain of rotations
+	// must increment the sequence number by one.
+	Sequence uint64 `json:"sequence"`
+	// Old is the key being rotated away from.
+	Old PublicKey `json:"old"`
+	// New is the key replacing the old key.
+	New PublicKey `json:"new"`
+}
+
+// ValidateBasic performs basic key rotation record validity checks.
+func (r *KeyRotation) ValidateBasic() error {
+	if !r.Role.IsValid() {
+		return fmt.Errorf("%w: invalid role: %d", ErrInvalidKeyRotation, r.Role)
+	}
+	if !r.Old.IsValid() || !r.New.IsValid() {
+		return fmt.Errorf("%w: invalid key", ErrInvalidKeyRotation)
+	}
+	if r.Old.Equal(r.New) {
+		return fmt.Errorf("%w: old and new keys are the same", ErrInvalidKeyRotation)
+	}
+	return nil
+}
+
+// SignedKeyRotation is a key rotation record signed by both the old key, authorizing the
+// rotation, and the new key, proving its possession.
+type SignedKeyRotation struct {
+	MultiSigned
+}
+
+// Open first verifies that the key rotation record is signed by exactly the old and the new key,
+// and then unmarshals and validates the record.
+func (s *SignedKeyRotation) Open(r *KeyRotation) error {
+	// The signatures can only be checked against the keys in the record, so decode it first.
+	var untrusted KeyRotation
+	if err := cbor.Unmarshal(s.Blob, &untrusted); err != nil {
+		return fmt.Errorf("%w: malformed record: %w", ErrInvalidKeyRotation, err)
+	}
+	if !s.IsOnlySignedBy([]PublicKey{untrusted.Old, untrusted.New}) {
+		return fmt.Errorf("%w: not signed by the old and the new key", ErrInvalidKeyRotation)
+	}
+	if err := s.MultiSigned.Open(KeyRotationSignatureContext, r); err != nil {
+		return err
+	}
+	return r.ValidateBasic()
+}
+
+// SignKeyRotation signs a key rotation record with both the old and the new key.
+func SignKeyRotation(oldSigner, newSigner Signer, r *KeyRotation) (*SignedKeyRotation, error) {
+	if !oldSigner.Public().Equal(r.Old) || !newSigner.Public().Equal(r.New) {
+		return nil, ErrPublicKeyMismatch
+	}
+	if err := r.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	ms, err := SignMultiSigned([]Signer{oldSigner, newSigner}, KeyRotationSignatureContext, r)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedKeyRotation{*ms}, nil
+}
+
+// KeyRevocation is a key revocation certifying that the given key must no longer be trusted.
+type KeyRevocation struct {
+	// Role is the role of the revoked key.
+	Role KeyRole `json:"role"`
+	// Key is the revoked key.
+	Key PublicKey `json:"key"`
+}
+
+// SignedKeyRevocation is a key revocation signed by the revoked key.
+type SignedKeyRevocation struct {
+	Signed
+}
+
+// Open first verifies the blob signature, and then unmarshals the blob and makes sure that the
+// revocation is signed by the revoked key.
+func (s *SignedKeyRevocation) Open(r *KeyRevocation) error {
+	if err := s.Signed.Open(KeyRevocationSignatureContext, r); err != nil {
+		return err
+	}
+	if !r.Role.IsValid() {
+		return fmt.Errorf("%w: %d", ErrInvalidRole, r.Role)
+	}
+	if !s.Signature.PublicKey.Equal(r.Key) {
+		return ErrPublicKeyMismatch
+	}
+	return nil
+}
+
+// SignKeyRevocation revokes the signer's key of the given role.
+func SignKeyRevocation(signer Signer, role KeyRole) (*SignedKeyRevocation, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRole, role)
+	}
+
+	signed, err := SignSigned(signer, KeyRevocationSignatureContext, &KeyRevocation{
+		Role: role,
+		Key:  signer.Public(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SignedKeyRevocation{*signed}, nil
+}
+
+// RevocationList is a list of revoked keys.
+type RevocationList struct {
+	sync.RWMutex
+
+	revoked map[KeyRole]map[PublicKey]*SignedKeyRevocation
+}
+
+// Add verifies the given key revocation and adds the revoked key to the list.
+func (l *RevocationList) Add(s *SignedKeyRevocation) error {
+	var r KeyRevocation
+	if err := s.Open(&r); err != nil {
+		return err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.revoked == nil {
+		l.revoked = make(map[KeyRole]map[PublicKey]*SignedKeyRevocation)
+	}
+	if l.revoked[r.Role] == nil {
+		l.revoked[r.Role] = make(map[PublicKey]*SignedKeyRevocation)
+	}
+	l.revoked[r.Role][r.Key] = s
+	return nil
+}
+
+// IsRevoked returns true iff the given key of the given role has been revoked.
+func (l *RevocationList) IsRevoked(role KeyRole, pk PublicKey) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	_, revoked := l.revoked[role][pk]
+	return revoked
+}
+
+// Revocations returns all key revocations in the list so that they can be propagated to other
+// nodes.
+func (l *RevocationList) Revocations() []*SignedKeyRevocation {
+	l.RLock()
+	defer l.RUnlock()
+
+	var revocations []*SignedKeyRevocation
+	for _, keys := range l.revoked {
+		for _, s := range keys {
+			revocations = append(revocations, s)
+		}
+	}
+	return revocations
+}
+
+// VerifyKeyRotations verifies a chain of key rotations of the given role, starting with the
+// given trusted key, and returns the current key.
+//
+// Since a compromised key can be used to forge rotations, verification fails in case any of
+// the keys in the chain has been revoked. Trust in a new key must then be re-established out
+// of band.
+func VerifyKeyRotations(role KeyRole, trusted PublicKey, rotations []*SignedKeyRotation, revoked *RevocationList) (PublicKey, error) {
+	isRevoked := func(pk PublicKey) bool {
+		return revoked != nil && revoked.IsRevoked(role, pk)
+	}
+	if isRevoked(trusted) {
+		return PublicKey{}, fmt.Errorf("%w: %s", ErrKeyRevoked, trusted)
+	}
+
+	current := trusted
+	seen := map[PublicKey]bool{trusted: true}
+	var sequence uint64
+	for i, s := range rotations {
+		var r KeyRotation
+		if err := s.Open(&r); err != nil {
+			return PublicKey{}, fmt.Errorf("rotation %d: %w", i, err)
+		}
+
+		switch {
+		case r.Role != role:
+			return PublicKey{}, fmt.Errorf("%w: rotation %d: role mismatch (expected: %s got: %s)",
+				ErrInvalidKeyRotation, i, role, r.Role,
+			)
+		case !r.Old.Equal(current):
+			return PublicKey{}, fmt.Errorf("%w: rotation %d: does not rotate the current key %s",
+				ErrInvalidKeyRotation, i, current,
+			)
+		case i > 0 && r.Sequence != sequence+1:
+			return PublicKey{}, fmt.Errorf("%w: rotation %d: unexpected sequence number (expected: %d got: %d)",
+				ErrInvalidKeyRotation, i, sequence+1, r.Sequence,
+			)
+		case seen[r.New]:
+			return PublicKey{}, fmt.Errorf("%w: rotation %d: key %s reused", ErrInvalidKeyRotation, i, r.New)
+		case isRevoked(r.New):
+			return PublicKey{}, fmt.Errorf("%w: %s", ErrKeyRevoked, r.New)
+		}
+
+		current = r.New
+		sequence = r.Sequence
+		seen[current] = true
+	}
+	return current, nil
+}
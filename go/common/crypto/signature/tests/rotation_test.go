@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestKeyRotation(t *testing.T) {
+	require := require.New(t)
+
+	key1 := memorySigner.NewTestSigner("oasis key rotation 1")
+	key2 := memorySigner.NewTestSigner("oasis key rotation 2")
+	key3 := memorySigner.NewTestSigner("oasis key rotation 3")
+	role := signature.KeyRoleP2P
+
+	rotate := func(oldSigner, newSigner signature.Signer, seq uint64) *signature.SignedKeyRotation {
+		s, err := signature.SignKeyRotation(oldSigner, newSigner, &signature.KeyRotation{
+			Role:     role,
+			Sequence: seq,
+			Old:      oldSigner.Public(),
+			New:      newSigner.Public(),
+		})
+		require.NoError(err, "SignKeyRotation")
+		return s
+	}
+	rot12 := rotate(key1, key2, 1)
+	rot23 := rotate(key2, key3, 2)
+
+	var r signature.KeyRotation
+	require.NoError(rot12.Open(&r), "Open")
+	require.Equal(key1.Public(), r.Old)
+	require.Equal(key2.Public(), r.New)
+
+	// Signing with mismatched or equal keys should fail.
+	_, err := signature.SignKeyRotation(key1, key3, &signature.KeyRotation{
+		Role: role,
+		Old:  key1.Public(),
+		New:  key2.Public(),
+	})
+	require.ErrorIs(err, signature.ErrPublicKeyMismatch, "SignKeyRotation with mismatched signer")
+	_, err = signature.SignKeyRotation(key1, key1, &signature.KeyRotation{
+		Role: role,
+		Old:  key1.Public(),
+		New:  key1.Public(),
+	})
+	require.ErrorIs(err, signature.ErrInvalidKeyRotation, "SignKeyRotation with same keys")
+
+	// A rotation signed only by the old key should be rejected.
+	ms, err := signature.SignMultiSigned([]signature.Signer{key1}, signature.KeyRotationSignatureContext, &signature.KeyRotation{
+		Role:     role,
+		Sequence: 1,
+		Old:      key1.Public(),
+		New:      key3.Public(),
+	})
+	require.NoError(err, "SignMultiSigned")
+	require.ErrorIs((&signature.SignedKeyRotation{MultiSigned: *ms}).Open(&r), signature.ErrInvalidKeyRotation)
+
+	// A tampered rotation should be rejected.
+	tampered := *rot12
+	tampered.Blob = cbor.Marshal(&signature.KeyRotation{
+		Role:     role,
+		Sequence: 2,
+		Old:      key1.Public(),
+		New:      key2.Public(),
+	})
+	require.ErrorIs(tampered.Open(&r), signature.ErrVerifyFailed)
+
+	// Verify chains.
+	current, err := signature.VerifyKeyRotations(role, key1.Public(), nil, nil)
+	require.NoError(err, "VerifyKeyRotations with no rotations")
+	require.Equal(key1.Public(), current)
+
+	current, err = signature.VerifyKeyRotations(role, key1.Public(), []*signature.SignedKeyRotation{rot12, rot23}, nil)
+	require.NoError(err, "VerifyKeyRotations")
+	require.Equal(key3.Public(), current)
+
+	current, err = signature.VerifyKeyRotations(role, key2.Public(), []*signature.SignedKeyRotation{rot23}, nil)
+	require.NoError(err, "VerifyKeyRotations starting at an intermediate key")
+	require.Equal(key3.Public(), current)
+
+	_, err = signature.VerifyKeyRotations(role, key1.Public(), []*signature.SignedKeyRotation{rot23}, nil)
+	require.ErrorIs(err, signature.ErrInvalidKeyRotation, "VerifyKeyRotations with broken chain")
+
+	_, err = signature.VerifyKeyRotations(signature.KeyRoleTLS, key1.Public(), []*signature.SignedKeyRotation{rot12}, nil)
+	require.ErrorIs(err, signature.ErrInvalidKeyRotation, "VerifyKeyRotations with role mismatch")
+
+	_, err = signature.VerifyKeyRotations(role, key1.Public(), []*signature.SignedKeyRotation{rot12, rotate(key2, key3, 3)}, nil)
+	require.ErrorIs(err, signature.ErrInvalidKeyRotation, "VerifyKeyRotations with sequence gap")
+
+	_, err = signature.VerifyKeyRotations(role, key1.Public(), []*signature.SignedKeyRotation{rot12, rotate(key2, key1, 2)}, nil)
+	require.ErrorIs(err, signature.ErrInvalidKeyRotation, "VerifyKeyRotations with reused key")
+
+	// Revocations.
+	var revoked signature.RevocationList
+	rev, err := signature.SignKeyRevocation(key2, role)
+	require.NoError(err, "SignKeyRevocation")
+
+	var kr signature.KeyRevocation
+	require.NoError(rev.Open(&kr), "Open")
+	require.Equal(key2.Public(), kr.Key)
+
+	forged := *rev
+	forged.Blob = cbor.Marshal(&signature.KeyRevocation{Role: role, Key: key3.Public()})
+	require.Error(revoked.Add(&forged), "adding a forged revocation should fail")
+
+	require.False(revoked.IsRevoked(role, key2.Public()))
+	require.NoError(revoked.Add(rev), "Add")
+	require.True(revoked.IsRevoked(role, key2.Public()))
+	require.False(revoked.IsRevoked(signature.KeyRoleTLS, key2.Public()), "revocations should be per role")
+	require.Len(revoked.Revocations(), 1)
+
+	_, err = signature.VerifyKeyRotations(role, key1.Public(), []*signature.SignedKeyRotation{rot12, rot23}, &revoked)
+	require.ErrorIs(err, signature.ErrKeyRevoked, "VerifyKeyRotations through a revoked key")
+
+	_, err = signature.VerifyKeyRotations(role, key2.Public(), nil, &revoked)
+	require.ErrorIs(err, signature.ErrKeyRevoked, "VerifyKeyRotations with a revoked trusted key")
+
+	current, err = signature.VerifyKeyRotations(role, key3.Public(), nil, &revoked)
+	require.NoError(err, "VerifyKeyRotations with a non-revoked key")
+	require.Equal(key3.Public(), current)
+}
@@ -52,6 +52,11 @@ type Entity struct { // nolint: maligned
 	// will sign the descriptor with the node signing key rather than the
 	// entity signing key.
 	Nodes []signature.PublicKey `json:"nodes,omitempty"`
+
+	// Multisig is an optional threshold signing policy that allows consensus
+	// transactions on behalf of the entity to be authorized by multiple
+	// signers.
+	Multisig *MultisigPolicy `json:"multisig,omitempty"`
 }
 
 // UnmarshalCBOR is a custom deserializer that handles both v1 and v2 Entity
@@ -116,6 +121,11 @@ func (e *Entity) ValidateBasic(strictVersion bool) error {
 			)
 		}
 	}
+	if e.Multisig != nil {
+		if err := e.Multisig.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid entity multisig policy: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -195,6 +205,7 @@ func GenerateWithSigner(baseDir string, signer signature.Signer, template *Entit
 	}
 	if template != nil {
 		ent.Nodes = template.Nodes
+		ent.Multisig = template.Multisig
 	}
 
 	if err := ent.Save(baseDir); err != nil {
@@ -58,3 +58,46 @@ func TestEntityDescriptorVersioning(t *testing.T) {
 	require.EqualValues(ev2.Nodes, uv2t1.Nodes)
 	require.EqualValues(cbor.NewVersioned(2), uv2t1.Versioned)
 }
+
+func TestMultisigPolicy(t *testing.T) {
+	require := require.New(t)
+
+	s1 := memorySigner.NewTestSigner("test multisig signer 1").Public()
+	s2 := memorySigner.NewTestSigner("test multisig signer 2").Public()
+	s3 := memorySigner.NewTestSigner("test multisig signer 3").Public()
+	other := memorySigner.NewTestSigner("test multisig other").Public()
+
+	for _, tc := range []struct {
+		policy MultisigPolicy
+		valid  bool
+		msg    string
+	}{
+		{MultisigPolicy{Signers: []signature.PublicKey{s1, s2, s3}, Threshold: 2}, true, "2-of-3 policy should be valid"},
+		{MultisigPolicy{Signers: []signature.PublicKey{s1}, Threshold: 1}, true, "1-of-1 policy should be valid"},
+		{MultisigPolicy{Threshold: 1}, false, "policy without signers should be invalid"},
+		{MultisigPolicy{Signers: []signature.PublicKey{s1, s2}}, false, "zero threshold should be invalid"},
+		{MultisigPolicy{Signers: []signature.PublicKey{s1, s2}, Threshold: 3}, false, "threshold above signer count should be invalid"},
+		{MultisigPolicy{Signers: []signature.PublicKey{s1, s1}, Threshold: 1}, false, "duplicate signers should be invalid"},
+	} {
+		err := tc.policy.ValidateBasic()
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.Error(err, tc.msg)
+		}
+	}
+
+	policy := MultisigPolicy{Signers: []signature.PublicKey{s1, s2, s3}, Threshold: 2}
+	require.True(policy.IsSigner(s2), "IsSigner should return true for policy signers")
+	require.False(policy.IsSigner(other), "IsSigner should return false for non-signers")
+
+	require.NoError(policy.Verify([]signature.PublicKey{s1, s3}), "threshold of signers should satisfy the policy")
+	require.NoError(policy.Verify([]signature.PublicKey{s1, s2, s3}), "all signers should satisfy the policy")
+	err := policy.Verify([]signature.PublicKey{s1})
+	require.ErrorIs(err, ErrMultisigThresholdNotMet, "too few signers should not satisfy the policy")
+	err = policy.Verify([]signature.PublicKey{s1, s1})
+	require.ErrorIs(err, ErrMultisigThresholdNotMet, "duplicate signers should only count once")
+	err = policy.Verify([]signature.PublicKey{s1, s2, other})
+	require.ErrorIs(err, ErrMultisigThresholdNotMet, "non-signers should not satisfy the policy")
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// MaxMultisigSigners is the maximum number of signers in a multisig policy.
+const MaxMultisigSigners = 16
+
+// ErrMultisigThresholdNotMet is the error returned when the signers of a transaction do not
+// satisfy the entity's multisig policy.
+var ErrMultisigThresholdNotMet = errors.New("entity: multisig threshold not met")
+
+// MultisigPolicy is the threshold signing policy of an entity.
+//
+// Consensus transactions on behalf of an entity with a multisig policy may be
+// authorized by a threshold of the policy signers instead of by the entity
+// signing key.
+type MultisigPolicy struct {
+	// Signers are the public keys that may co-sign transactions on behalf of
+	// the entity.
+	Signers []signature.PublicKey `json:"signers"`
+
+	// Threshold is the number of distinct signers required to authorize a
+	// transaction.
+	Threshold uint8 `json:"threshold"`
+}
+
+// ValidateBasic performs basic multisig policy validity checks.
+func (p *MultisigPolicy) ValidateBasic() error {
+	if len(p.Signers) == 0 {
+		return fmt.Errorf("multisig policy has no signers")
+	}
+	if len(p.Signers) > MaxMultisigSigners {
+		return fmt.Errorf("multisig policy has too many signers (%d > %d)", len(p.Signers), MaxMultisigSigners)
+	}
+	if p.Threshold == 0 {
+		return fmt.Errorf("multisig policy threshold must be at least one")
+	}
+	if int(p.Threshold) > len(p.Signers) {
+		return fmt.Errorf("multisig policy threshold exceeds the number of signers (%d > %d)", p.Threshold, len(p.Signers))
+	}
+
+	signers := make(map[signature.PublicKey]struct{})
+	for _, pk := range p.Signers {
+		if !pk.IsValid() {
+			return fmt.Errorf("multisig policy has malformed signer: %s", pk)
+		}
+		if _, ok := signers[pk]; ok {
+			return fmt.Errorf("multisig policy has duplicate signer: %s", pk)
+		}
+		signers[pk] = struct{}{}
+	}
+	return nil
+}
+
+// IsSigner returns true iff the given public key is one of the policy signers.
+func (p *MultisigPolicy) IsSigner(pk signature.PublicKey) bool {
+	for _, v := range p.Signers {
+		if v.Equal(pk) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify makes sure that the given (already verified) signers satisfy the
+// policy. All of the signers must be policy signers.
+func (p *MultisigPolicy) Verify(signers []signature.PublicKey) error {
+	seen := make(map[signature.PublicKey]struct{})
+	for _, pk := range signers {
+		if !p.IsSigner(pk) {
+			return fmt.Errorf("%w: %s is not a policy signer", ErrMultisigThresholdNotMet, pk)
+		}
+		seen[pk] = struct{}{}
+	}
+	if len(seen) < int(p.Threshold) {
+		return fmt.Errorf("%w: %d of %d signatures", ErrMultisigThresholdNotMet, len(seen), p.Threshold)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// rateLimiterIdleTimeout is the time after which the rate limiter state of an idle client is
+// discarded.
+const rateLimiterIdleTimeout = 5 * time.Minute
+
+// methodFilter is a server interceptor which only allows calls to a configured set of methods.
+type methodFilter struct {
+	methods  map[string]struct{}
+	services []string
+}
+
+// newMethodFilter creates a new method filter allowing the given methods.
+//
+// Each entry is either a full method name (e.g., "/oasis-core.Consensus/GetStatus") or a service
+// prefix ending with a slash (e.g., "/oasis-core.Staking/") which allows all methods of that
+// service.
+func newMethodFilter(allowed []string) *methodFilter {
+	f := &methodFilter{
+		methods: make(map[string]struct{}),
+	}
+	for _, m := range allowed {
+		if strings.HasSuffix(m, "/") {
+			f.services = append(f.services, m)
+			continue
+		}
+		f.methods[m] = struct{}{}
+	}
+	return f
+}
+
+func (f *methodFilter) isAllowed(method string) bool {
+	if _, ok := f.methods[method]; ok {
+		return true
+	}
+	for _, svc := range f.services {
+		if strings.HasPrefix(method, svc) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *methodFilter) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !f.isAllowed(info.FullMethod) {
+		return nil, status.Errorf(codes.PermissionDenied, "method not allowed: %s", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+func (f *methodFilter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if !f.isAllowed(info.FullMethod) {
+		return status.Errorf(codes.PermissionDenied, "method not allowed: %s", info.FullMethod)
+	}
+	return handler(srv, ss)
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter is a server interceptor which limits the rate of calls per client address.
+type rateLimiter struct {
+	sync.Mutex
+
+	limit rate.Limit
+	burst int
+
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+// newRateLimiter creates a new rate limiter allowing each client to perform the given number of
+// calls per second, with the given burst.
+func newRateLimiter(limit float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		limit:     rate.Limit(limit),
+		burst:     burst,
+		clients:   make(map[string]*clientLimiter),
+		lastPrune: time.Now(),
+	}
+}
+
+func (r *rateLimiter) allow(ctx context.Context) bool {
+	client := clientAddress(ctx)
+	now := time.Now()
+
+	r.Lock()
+	defer r.Unlock()
+
+	// Discard state of clients that have been idle for a while.
+	if now.Sub(r.lastPrune) > rateLimiterIdleTimeout {
+		for addr, cl := range r.clients {
+			if now.Sub(cl.lastSeen) > rateLimiterIdleTimeout {
+				delete(r.clients, addr)
+			}
+		}
+		r.lastPrune = now
+	}
+
+	cl, ok := r.clients[client]
+	if !ok {
+		cl = &clientLimiter{
+			limiter: rate.NewLimiter(r.limit, r.burst),
+		}
+		r.clients[client] = cl
+	}
+	cl.lastSeen = now
+
+	return cl.limiter.AllowN(now, 1)
+}
+
+func (r *rateLimiter) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !r.allow(ctx) {
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded: %s", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+func (r *rateLimiter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if !r.allow(ss.Context()) {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded: %s", info.FullMethod)
+	}
+	return handler(srv, ss)
+}
+
+// clientAddress returns the address (without the port) of the client performing the call.
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// streamLimiter is a server interceptor which limits the number of concurrent streams per client
+// address.
+//
+// Unlike the rate limiter, which only limits how fast streams are opened, this bounds the number
+// of long-lived streams (e.g., watches) that a single client can keep open at once.
+type streamLimiter struct {
+	sync.Mutex
+
+	maxStreams int
+	streams    map[string]int
+}
+
+// newStreamLimiter creates a new stream limiter allowing each client to have the given number of
+// concurrent streams open.
+func newStreamLimiter(maxStreams int) *streamLimiter {
+	return &streamLimiter{
+		maxStreams: maxStreams,
+		streams:    make(map[string]int),
+	}
+}
+
+// acquire reserves a stream for the client performing the call, returning false if the client
+// has too many streams open.
+func (l *streamLimiter) acquire(ctx context.Context) (string, bool) {
+	client := clientAddress(ctx)
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.streams[client] >= l.maxStreams {
+		return client, false
+	}
+	l.streams[client]++
+	return client, true
+}
+
+// release releases a stream previously reserved for the given client.
+func (l *streamLimiter) release(client string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.streams[client]--
+	if l.streams[client] <= 0 {
+		delete(l.streams, client)
+	}
+}
+
+func (l *streamLimiter) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	client, ok := l.acquire(ss.Context())
+	if !ok {
+		return status.Errorf(codes.ResourceExhausted, "too many concurrent streams: %s", info.FullMethod)
+	}
+	defer l.release(client)
+
+	return handler(srv, ss)
+}
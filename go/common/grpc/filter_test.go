@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestMethodFilter(t *testing.T) {
+	require := require.New(t)
+
+	f := newMethodFilter([]string{
+		"/oasis-core.Staking/",
+		"/oasis-core.Consensus/GetStatus",
+	})
+
+	require.True(f.isAllowed("/oasis-core.Staking/Account"), "all methods of allowed service")
+	require.True(f.isAllowed("/oasis-core.Consensus/GetStatus"), "allowed method")
+	require.False(f.isAllowed("/oasis-core.Consensus/SubmitTx"), "other method of allowed method's service")
+	require.False(f.isAllowed("/oasis-core.StakingExtra/Account"), "service with allowed service as prefix")
+	require.False(f.isAllowed("/oasis-core.NodeController/RequestShutdown"), "other service")
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	peerCtx := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+		})
+	}
+
+	r := newRateLimiter(0.001, 2)
+
+	ctxA := peerCtx("192.0.2.1")
+	require.True(r.allow(ctxA))
+	require.True(r.allow(ctxA))
+	require.False(r.allow(ctxA), "calls over burst should be rejected")
+
+	// Clients are limited independently of the source port.
+	ctxA2 := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 4321},
+	})
+	require.False(r.allow(ctxA2), "calls from same address should share the limit")
+
+	// Other clients should not be affected.
+	ctxB := peerCtx("192.0.2.2")
+	require.True(r.allow(ctxB))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (ss *testServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+func TestStreamLimiter(t *testing.T) {
+	require := require.New(t)
+
+	peerCtx := func(ip string, port int) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: port},
+		})
+	}
+
+	l := newStreamLimiter(2)
+	info := &grpc.StreamServerInfo{FullMethod: "/oasis-core.Consensus/WatchBlocks"}
+
+	// Open streams which are kept open until released.
+	releaseCh := make(chan struct{})
+	startedCh := make(chan struct{})
+	openStream := func(ctx context.Context) <-chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.streamInterceptor(nil, &testServerStream{ctx: ctx}, info, func(interface{}, grpc.ServerStream) error {
+				startedCh <- struct{}{}
+				<-releaseCh
+				return nil
+			})
+		}()
+		return errCh
+	}
+
+	errCh1 := openStream(peerCtx("192.0.2.1", 1234))
+	<-startedCh
+	errCh2 := openStream(peerCtx("192.0.2.1", 4321))
+	<-startedCh
+
+	// Streams over the limit should be rejected, regardless of the source port.
+	err := <-openStream(peerCtx("192.0.2.1", 5678))
+	require.Equal(codes.ResourceExhausted, status.Code(err), "streams over the limit should be rejected")
+
+	// Other clients should not be affected.
+	errCh3 := openStream(peerCtx("192.0.2.2", 1234))
+	<-startedCh
+
+	// Closed streams should free up the slots.
+	close(releaseCh)
+	require.NoError(<-errCh1)
+	require.NoError(<-errCh2)
+	require.NoError(<-errCh3)
+	require.Empty(l.streams, "all streams should be released")
+
+	client, ok := l.acquire(peerCtx("192.0.2.1", 1234))
+	require.True(ok, "streams should be allowed after others are closed")
+	l.release(client)
+}
@@ -443,6 +443,20 @@ type ServerConfig struct { // nolint: maligned
 	ClientCommonName string
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
+	// AllowedMethods is the list of methods that may be invoked on the server. Entries ending
+	// with a slash allow all methods of the given service. If empty, all methods are allowed.
+	AllowedMethods []string
+	// RateLimit is the number of calls per second that each client address may perform. If zero,
+	// calls are not rate limited.
+	RateLimit float64
+	// RateLimitBurst is the maximum number of calls that each client address may perform at once.
+	RateLimitBurst int
+	// MaxStreamsPerClient is the maximum number of concurrent streams that each client address
+	// may have open. If zero, the number of streams is not limited.
+	MaxStreamsPerClient int
+	// MaxConcurrentStreams is the maximum number of concurrent streams on each connection. If
+	// zero, the gRPC default is used.
+	MaxConcurrentStreams uint32
 }
 
 type listenerConfig struct {
@@ -621,13 +635,27 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		logAdapter.unaryLogger,
 		serverUnaryErrorMapper,
-		auth.UnaryServerInterceptor(config.AuthFunc),
 	}
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
-		auth.StreamServerInterceptor(config.AuthFunc),
 	}
+	if config.RateLimit > 0 {
+		limiter := newRateLimiter(config.RateLimit, config.RateLimitBurst)
+		unaryInterceptors = append(unaryInterceptors, limiter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	}
+	if config.MaxStreamsPerClient > 0 {
+		limiter := newStreamLimiter(config.MaxStreamsPerClient)
+		streamInterceptors = append(streamInterceptors, limiter.streamInterceptor)
+	}
+	if len(config.AllowedMethods) > 0 {
+		filter := newMethodFilter(config.AllowedMethods)
+		unaryInterceptors = append(unaryInterceptors, filter.unaryInterceptor)
+		streamInterceptors = append(streamInterceptors, filter.streamInterceptor)
+	}
+	unaryInterceptors = append(unaryInterceptors, auth.UnaryServerInterceptor(config.AuthFunc))
+	streamInterceptors = append(streamInterceptors, auth.StreamServerInterceptor(config.AuthFunc))
 	if config.InstallWrapper {
 		wrapper = newWrapper()
 		unaryInterceptors = append(unaryInterceptors, wrapper.unaryInterceptor)
@@ -641,6 +669,9 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		grpc.KeepaliveParams(serverKeepAliveParams),
 		grpc.ForceServerCodec(&CBORCodec{}),
 	}
+	if config.MaxConcurrentStreams > 0 {
+		sOpts = append(sOpts, grpc.MaxConcurrentStreams(config.MaxConcurrentStreams))
+	}
 	if config.Identity != nil && config.Identity.TLSCertificate != nil {
 		tlsConfig := &tls.Config{
 			ClientAuth: clientAuthType,
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"bytes"
+	"sync"
+)
+
+// RingBuffer is a writer that retains the most recent log lines written to it.
+type RingBuffer struct {
+	mu sync.Mutex
+
+	lines   []string
+	next    int
+	full    bool
+	partial []byte
+}
+
+// Write implements io.Writer.
+func (rb *RingBuffer) Write(p []byte) (int, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	data := p
+	for len(data) > 0 {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			rb.partial = append(rb.partial, data...)
+			break
+		}
+
+		line := data[:idx]
+		if len(rb.partial) > 0 {
+			line = append(rb.partial, line...)
+			rb.partial = nil
+		}
+		rb.appendLocked(string(line))
+		data = data[idx+1:]
+	}
+	return len(p), nil
+}
+
+func (rb *RingBuffer) appendLocked(line string) {
+	if len(rb.lines) == 0 {
+		return
+	}
+	rb.lines[rb.next] = line
+	rb.next = (rb.next + 1) % len(rb.lines)
+	if rb.next == 0 {
+		rb.full = true
+	}
+}
+
+// Lines returns the retained log lines, ordered from the oldest to the most recent.
+func (rb *RingBuffer) Lines() []string {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if !rb.full {
+		return append([]string{}, rb.lines[:rb.next]...)
+	}
+
+	lines := make([]string, 0, len(rb.lines))
+	lines = append(lines, rb.lines[rb.next:]...)
+	lines = append(lines, rb.lines[:rb.next]...)
+	return lines
+}
+
+// NewRingBuffer creates a new log ring buffer retaining at most the given number of lines.
+func NewRingBuffer(size int) *RingBuffer {
+	return &RingBuffer{
+		lines: make([]string, size),
+	}
+}
@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingBuffer(t *testing.T) {
+	require := require.New(t)
+
+	rb := NewRingBuffer(3)
+	require.Empty(rb.Lines())
+
+	_, _ = rb.Write([]byte("line 1\nline 2\n"))
+	require.Equal([]string{"line 1", "line 2"}, rb.Lines())
+
+	// Partial lines should only be retained once complete.
+	_, _ = rb.Write([]byte("line"))
+	require.Equal([]string{"line 1", "line 2"}, rb.Lines())
+	_, _ = rb.Write([]byte(" 3\n"))
+	require.Equal([]string{"line 1", "line 2", "line 3"}, rb.Lines())
+
+	// Oldest lines should be evicted.
+	_, _ = rb.Write([]byte("line 4\nline 5\n"))
+	require.Equal([]string{"line 3", "line 4", "line 5"}, rb.Lines())
+}
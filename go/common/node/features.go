@@ -0,0 +1,133 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// ErrInvalidFeature is the error returned when a node feature is invalid.
+var ErrInvalidFeature = errors.New("node: invalid feature")
+
+// FeaturesMask is Oasis node declared features bitmask.
+//
+// Features are optional capabilities that nodes declare in their descriptors, so that the
+// scheduler and clients can select nodes that support them.
+type FeaturesMask uint32
+
+const (
+	// FeatureEmpty is the features bitmask that specifies no features.
+	FeatureEmpty FeaturesMask = 0
+	// FeatureCheckpointServing is the feature of nodes serving storage checkpoints to peers.
+	FeatureCheckpointServing FeaturesMask = 1 << 0
+	// FeatureDCAP is the feature of nodes capable of producing DCAP-based SGX attestations.
+	FeatureDCAP FeaturesMask = 1 << 1
+	// FeatureArchival is the feature of nodes retaining the full history.
+	FeatureArchival FeaturesMask = 1 << 2
+
+	// FeatureReserved are all the bits of the Oasis node features bitmask
+	// that are reserved and must not be used.
+	FeatureReserved FeaturesMask = ((1 << 32) - 1) & ^((FeatureArchival << 1) - 1)
+
+	// Human friendly feature names:
+
+	FeatureCheckpointServingName = "checkpoint-serving"
+	FeatureDCAPName              = "dcap"
+	FeatureArchivalName          = "archival"
+
+	featuresMaskStringSep = ","
+)
+
+// Features returns a list of available valid features.
+func Features() []FeaturesMask {
+	return []FeaturesMask{
+		FeatureCheckpointServing,
+		FeatureDCAP,
+		FeatureArchival,
+	}
+}
+
+func (m FeaturesMask) String() string {
+	if m&FeatureReserved != 0 {
+		return "[invalid features]"
+	}
+
+	var ret []string
+	if m&FeatureCheckpointServing != 0 {
+		ret = append(ret, FeatureCheckpointServingName)
+	}
+	if m&FeatureDCAP != 0 {
+		ret = append(ret, FeatureDCAPName)
+	}
+	if m&FeatureArchival != 0 {
+		ret = append(ret, FeatureArchivalName)
+	}
+
+	return strings.Join(ret, featuresMaskStringSep)
+}
+
+// MarshalText encodes a FeaturesMask into text form.
+func (m FeaturesMask) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a text slice into a FeaturesMask.
+func (m *FeaturesMask) UnmarshalText(text []byte) error {
+	*m = FeatureEmpty
+	if len(text) == 0 {
+		return nil
+	}
+
+	for _, feature := range strings.Split(string(text), featuresMaskStringSep) {
+		var f FeaturesMask
+		switch feature {
+		case FeatureCheckpointServingName:
+			f = FeatureCheckpointServing
+		case FeatureDCAPName:
+			f = FeatureDCAP
+		case FeatureArchivalName:
+			f = FeatureArchival
+		default:
+			return fmt.Errorf("%w: '%s'", ErrInvalidFeature, feature)
+		}
+		if *m&f != 0 {
+			return fmt.Errorf("%w: duplicate feature '%s'", ErrInvalidFeature, feature)
+		}
+		*m |= f
+	}
+	return nil
+}
+
+// HasFeatures checks if the node declares all of the specified features.
+func (n *Node) HasFeatures(f FeaturesMask) bool {
+	return n.Features&f == f
+}
+
+// VerifyFeatures verifies that the declared features are backed by the node descriptor.
+//
+// Declaring the DCAP feature requires at least one runtime with an SGX attestation that is
+// verified via PCS.
+func (n *Node) VerifyFeatures() error {
+	if n.HasFeatures(FeatureDCAP) && !n.hasDCAPAttestation() {
+		return fmt.Errorf("%w: '%s' declared without a DCAP attestation", ErrInvalidFeature, FeatureDCAPName)
+	}
+	return nil
+}
+
+func (n *Node) hasDCAPAttestation() bool {
+	for _, rt := range n.Runtimes {
+		if rt == nil || rt.Capabilities.TEE == nil || rt.Capabilities.TEE.Hardware != TEEHardwareIntelSGX {
+			continue
+		}
+		var sa SGXAttestation
+		if err := cbor.Unmarshal(rt.Capabilities.TEE.Attestation, &sa); err != nil {
+			continue
+		}
+		if sa.Quote.PCS != nil {
+			return true
+		}
+	}
+	return false
+}
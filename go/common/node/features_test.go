@@ -0,0 +1,87 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
+)
+
+func TestFeaturesMask(t *testing.T) {
+	require := require.New(t)
+
+	for _, v := range []struct {
+		text     string
+		mask     FeaturesMask
+		valid    bool
+		errMsg   string
+		reencode bool
+	}{
+		{"", FeatureEmpty, true, "", true},
+		{"checkpoint-serving", FeatureCheckpointServing, true, "", true},
+		{"dcap", FeatureDCAP, true, "", true},
+		{"archival", FeatureArchival, true, "", true},
+		{"checkpoint-serving,archival", FeatureCheckpointServing | FeatureArchival, true, "", true},
+		{"archival,dcap", FeatureDCAP | FeatureArchival, true, "", false},
+		{"archival,archival", 0, false, "node: invalid feature: duplicate feature 'archival'", false},
+		{"sgx", 0, false, "node: invalid feature: 'sgx'", false},
+	} {
+		var mask FeaturesMask
+		err := mask.UnmarshalText([]byte(v.text))
+		if !v.valid {
+			require.EqualError(err, v.errMsg, "UnmarshalText(%s)", v.text)
+			continue
+		}
+		require.NoError(err, "UnmarshalText(%s)", v.text)
+		require.Equal(v.mask, mask, "UnmarshalText(%s)", v.text)
+
+		if v.reencode {
+			text, err := mask.MarshalText()
+			require.NoError(err, "MarshalText")
+			require.Equal(v.text, string(text), "MarshalText")
+		}
+	}
+
+	require.Equal("[invalid features]", (FeatureArchival << 1).String())
+
+	n := Node{Features: FeatureCheckpointServing | FeatureArchival}
+	require.True(n.HasFeatures(FeatureEmpty))
+	require.True(n.HasFeatures(FeatureArchival))
+	require.True(n.HasFeatures(FeatureCheckpointServing | FeatureArchival))
+	require.False(n.HasFeatures(FeatureDCAP | FeatureArchival))
+}
+
+func TestVerifyFeatures(t *testing.T) {
+	require := require.New(t)
+
+	newRuntime := func(q quote.Quote) *Runtime {
+		sa := SGXAttestation{
+			Versioned: cbor.NewVersioned(LatestSGXAttestationVersion),
+			Quote:     q,
+		}
+		return &Runtime{
+			Capabilities: Capabilities{
+				TEE: &CapabilityTEE{
+					Hardware:    TEEHardwareIntelSGX,
+					Attestation: cbor.Marshal(&sa),
+				},
+			},
+		}
+	}
+
+	n := &Node{Features: FeatureArchival}
+	require.NoError(n.VerifyFeatures(), "features without verification should be accepted")
+
+	n = &Node{Features: FeatureDCAP}
+	require.ErrorIs(n.VerifyFeatures(), ErrInvalidFeature, "DCAP without runtimes should be rejected")
+
+	n.Runtimes = []*Runtime{{}, newRuntime(quote.Quote{IAS: &ias.AVRBundle{}})}
+	require.ErrorIs(n.VerifyFeatures(), ErrInvalidFeature, "DCAP with an EPID attestation should be rejected")
+
+	n.Runtimes = append(n.Runtimes, newRuntime(quote.Quote{PCS: &pcs.QuoteBundle{}}))
+	require.NoError(n.VerifyFeatures(), "DCAP with a PCS attestation should be accepted")
+}
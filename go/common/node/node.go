@@ -63,6 +63,10 @@ const (
 	maxNodeDescriptorVersion = LatestNodeDescriptorVersion
 
 	nodeSoftwareVersionMaxLength = 128
+
+	// MaxAdditionalTEECapabilities is the maximum number of additional TEE capabilities that a
+	// node may advertise for a single runtime version.
+	MaxAdditionalTEECapabilities = 4
 )
 
 // Node represents public connectivity information about an Oasis node.
@@ -101,6 +105,9 @@ type Node struct { // nolint: maligned
 
 	// SoftwareVersion is the node's oasis-node software version.
 	SoftwareVersion SoftwareVersion `json:"software_version,omitempty"`
+
+	// Features is a bitmask representing the node's declared features.
+	Features FeaturesMask `json:"features,omitempty"`
 }
 
 // nodeV2 represents (to be deprecated) V2 version of node descriptors.
@@ -365,6 +372,11 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 		return fmt.Errorf("invalid role specified")
 	}
 
+	// Make sure that only valid features are declared.
+	if n.Features&FeatureReserved != 0 {
+		return fmt.Errorf("invalid feature specified")
+	}
+
 	return nil
 }
 
@@ -503,6 +515,60 @@ type VRFInfo struct {
 type Capabilities struct {
 	// TEE is the capability of a node executing batches in a TEE.
 	TEE *CapabilityTEE `json:"tee,omitempty"`
+
+	// AdditionalTEEs are additional TEE capabilities of the node (e.g., attestations using a
+	// different attestation scheme or for a different enclave identity). These can only be
+	// present together with the primary TEE capability.
+	AdditionalTEEs []*CapabilityTEE `json:"additional_tees,omitempty"`
+}
+
+// TEEs returns all TEE capabilities, starting with the primary one.
+func (c *Capabilities) TEEs() []*CapabilityTEE {
+	if c.TEE == nil {
+		return nil
+	}
+	tees := make([]*CapabilityTEE, 0, 1+len(c.AdditionalTEEs))
+	tees = append(tees, c.TEE)
+	tees = append(tees, c.AdditionalTEEs...)
+	return tees
+}
+
+// HasRAK returns true iff any of the TEE capabilities uses the given runtime attestation key.
+func (c *Capabilities) HasRAK(rak signature.PublicKey) bool {
+	for _, tee := range c.TEEs() {
+		if tee.RAK.Equal(rak) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs basic capability validity checks.
+func (c *Capabilities) ValidateBasic() error {
+	if len(c.AdditionalTEEs) == 0 {
+		return nil
+	}
+	if c.TEE == nil {
+		return fmt.Errorf("additional TEE capabilities without a primary TEE capability")
+	}
+	if len(c.AdditionalTEEs) > MaxAdditionalTEECapabilities {
+		return fmt.Errorf("too many additional TEE capabilities (%d > %d)",
+			len(c.AdditionalTEEs),
+			MaxAdditionalTEECapabilities,
+		)
+	}
+	for _, tee := range c.AdditionalTEEs {
+		if tee == nil {
+			return fmt.Errorf("nil additional TEE capability")
+		}
+		if tee.Hardware != c.TEE.Hardware {
+			return fmt.Errorf("additional TEE capability hardware mismatch (expected: %s got: %s)",
+				c.TEE.Hardware,
+				tee.Hardware,
+			)
+		}
+	}
+	return nil
 }
 
 // TEEHardware is a TEE hardware implementation.
@@ -562,6 +628,9 @@ type CapabilityTEE struct {
 
 	// Attestation.
 	Attestation []byte `json:"attestation"`
+
+	// Details are the optional structured TEE hardware details.
+	Details *TEEDetails `json:"details,omitempty"`
 }
 
 // HashRAK computes the expected report data hash bound to a given public RAK.
@@ -585,6 +654,16 @@ func (c *CapabilityTEE) Verify(teeCfg *TEEFeatures, ts time.Time, height uint64,
 			return fmt.Errorf("node: malformed SGX attestation: %w", err)
 		}
 
+		// Validate TEE hardware details, if any.
+		if c.Details != nil {
+			if err := c.Details.ValidateBasic(c.Hardware); err != nil {
+				return fmt.Errorf("node: malformed TEE details: %w", err)
+			}
+			if err := c.Details.SGX.VerifyQuote(&sa.Quote); err != nil {
+				return fmt.Errorf("node: invalid TEE details: %w", err)
+			}
+		}
+
 		// Parse SGX constraints.
 		var sc SGXConstraints
 		if err := cbor.Unmarshal(constraints, &sc); err != nil {
@@ -480,3 +480,43 @@ func TestNodeSoftwareVersion(t *testing.T) {
 	sw = SoftwareVersion(strings.Repeat("a", 1000))
 	require.Error(sw.ValidateBasic(), "invalid software version")
 }
+
+func TestCapabilitiesAdditionalTEEs(t *testing.T) {
+	require := require.New(t)
+
+	rak1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	rak2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	rak3 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000003")
+
+	var c Capabilities
+	require.NoError(c.ValidateBasic(), "no TEE capabilities should be valid")
+	require.Nil(c.TEEs(), "no TEE capabilities")
+	require.False(c.HasRAK(rak1))
+
+	c.AdditionalTEEs = []*CapabilityTEE{{Hardware: TEEHardwareIntelSGX, RAK: rak2}}
+	require.Error(c.ValidateBasic(), "additional TEEs without a primary TEE should be invalid")
+	require.Nil(c.TEEs(), "additional TEEs without a primary TEE are ignored")
+	require.False(c.HasRAK(rak2))
+
+	c.TEE = &CapabilityTEE{Hardware: TEEHardwareIntelSGX, RAK: rak1}
+	require.NoError(c.ValidateBasic(), "additional TEEs with a primary TEE should be valid")
+	require.Len(c.TEEs(), 2)
+	require.Equal(c.TEE, c.TEEs()[0], "primary TEE should come first")
+	require.True(c.HasRAK(rak1))
+	require.True(c.HasRAK(rak2))
+	require.False(c.HasRAK(rak3))
+
+	c.AdditionalTEEs = append(c.AdditionalTEEs, nil)
+	require.Error(c.ValidateBasic(), "nil additional TEE should be invalid")
+
+	c.AdditionalTEEs = []*CapabilityTEE{{Hardware: TEEHardwareInvalid, RAK: rak2}}
+	require.Error(c.ValidateBasic(), "additional TEE hardware mismatch should be invalid")
+
+	c.AdditionalTEEs = nil
+	for i := 0; i <= MaxAdditionalTEECapabilities; i++ {
+		c.AdditionalTEEs = append(c.AdditionalTEEs, &CapabilityTEE{Hardware: TEEHardwareIntelSGX, RAK: rak2})
+	}
+	require.Error(c.ValidateBasic(), "too many additional TEEs should be invalid")
+	c.AdditionalTEEs = c.AdditionalTEEs[:MaxAdditionalTEECapabilities]
+	require.NoError(c.ValidateBasic(), "maximum number of additional TEEs should be valid")
+}
@@ -129,6 +129,102 @@ func (sc *SGXConstraints) ContainsEnclave(eid sgx.EnclaveIdentity) bool {
 	return false
 }
 
+// SGXAttestationType is the Intel SGX remote attestation type supported by a platform.
+type SGXAttestationType uint8
+
+// Intel SGX remote attestation types.
+const (
+	// SGXAttestationTypeInvalid is an invalid (not set) attestation type.
+	SGXAttestationTypeInvalid SGXAttestationType = 0
+	// SGXAttestationTypeEPID is the EPID-based attestation type (verified via IAS).
+	SGXAttestationTypeEPID SGXAttestationType = 1
+	// SGXAttestationTypeDCAP is the ECDSA-based attestation type (verified via PCS).
+	SGXAttestationTypeDCAP SGXAttestationType = 2
+
+	sgxAttestationTypeInvalid = "invalid"
+	sgxAttestationTypeEPID    = "epid"
+	sgxAttestationTypeDCAP    = "dcap"
+)
+
+// String returns the string representation of an SGX attestation type.
+func (t SGXAttestationType) String() string {
+	switch t {
+	case SGXAttestationTypeInvalid:
+		return sgxAttestationTypeInvalid
+	case SGXAttestationTypeEPID:
+		return sgxAttestationTypeEPID
+	case SGXAttestationTypeDCAP:
+		return sgxAttestationTypeDCAP
+	default:
+		return "[unsupported SGXAttestationType]"
+	}
+}
+
+// MarshalText encodes an SGX attestation type into text form.
+func (t SGXAttestationType) MarshalText() ([]byte, error) {
+	switch t {
+	case SGXAttestationTypeEPID, SGXAttestationTypeDCAP:
+		return []byte(t.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid SGX attestation type: %d", t)
+	}
+}
+
+// UnmarshalText decodes a text slice into an SGX attestation type.
+func (t *SGXAttestationType) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case sgxAttestationTypeEPID:
+		*t = SGXAttestationTypeEPID
+	case sgxAttestationTypeDCAP:
+		*t = SGXAttestationTypeDCAP
+	default:
+		return fmt.Errorf("invalid SGX attestation type: %s", string(text))
+	}
+	return nil
+}
+
+// SGXDetails are the Intel SGX-specific TEE hardware details.
+type SGXDetails struct {
+	// AttestationType is the remote attestation type used by the platform.
+	AttestationType SGXAttestationType `json:"attestation_type"`
+
+	// FLC is true iff the platform supports Flexible Launch Control.
+	FLC bool `json:"flc,omitempty"`
+
+	// EPCSize is the size of the enclave page cache in bytes (zero if unknown).
+	EPCSize uint64 `json:"epc_size,omitempty"`
+}
+
+// ValidateBasic performs basic structure validity checks.
+func (d *SGXDetails) ValidateBasic() error {
+	switch d.AttestationType {
+	case SGXAttestationTypeEPID:
+	case SGXAttestationTypeDCAP:
+		// ECDSA-based attestation requires Flexible Launch Control.
+		if !d.FLC {
+			return fmt.Errorf("DCAP attestation type requires FLC support")
+		}
+	default:
+		return fmt.Errorf("invalid SGX attestation type: %d", d.AttestationType)
+	}
+	return nil
+}
+
+// VerifyQuote verifies that the SGX details are consistent with the given quote.
+func (d *SGXDetails) VerifyQuote(q *quote.Quote) error {
+	var expected SGXAttestationType
+	switch {
+	case q.IAS != nil:
+		expected = SGXAttestationTypeEPID
+	case q.PCS != nil:
+		expected = SGXAttestationTypeDCAP
+	}
+	if d.AttestationType != expected {
+		return fmt.Errorf("SGX attestation type mismatch (expected: %s got: %s)", expected, d.AttestationType)
+	}
+	return nil
+}
+
 const (
 	// LatestSGXAttestationVersion is the latest SGX attestation structure version that should be
 	// used for all new descriptors.
@@ -132,6 +132,47 @@ func TestHashAttestation(t *testing.T) {
 	require.EqualValues("9a288bd33ba7a4c2eefdee68e4c08c1a34c369302ef8176a3bfdb4fedcec333e", hex.EncodeToString(h))
 }
 
+func TestSGXDetails(t *testing.T) {
+	require := require.New(t)
+
+	details := TEEDetails{
+		SGX: &SGXDetails{
+			AttestationType: SGXAttestationTypeDCAP,
+			FLC:             true,
+			EPCSize:         128 << 20,
+		},
+	}
+	require.NoError(details.ValidateBasic(TEEHardwareIntelSGX), "ValidateBasic")
+	require.Error(details.ValidateBasic(TEEHardwareInvalid), "ValidateBasic with non-SGX hardware")
+
+	// Attestation type must be consistent with the quote.
+	require.NoError(details.SGX.VerifyQuote(&quote.Quote{PCS: &pcs.QuoteBundle{}}), "VerifyQuote with PCS quote")
+	require.Error(details.SGX.VerifyQuote(&quote.Quote{IAS: &ias.AVRBundle{}}), "VerifyQuote with IAS quote")
+
+	// DCAP requires FLC support.
+	details.SGX.FLC = false
+	require.Error(details.ValidateBasic(TEEHardwareIntelSGX), "ValidateBasic DCAP without FLC")
+
+	details.SGX.AttestationType = SGXAttestationTypeEPID
+	require.NoError(details.ValidateBasic(TEEHardwareIntelSGX), "ValidateBasic EPID without FLC")
+	require.NoError(details.SGX.VerifyQuote(&quote.Quote{IAS: &ias.AVRBundle{}}), "VerifyQuote with IAS quote")
+
+	details.SGX.AttestationType = SGXAttestationTypeInvalid
+	require.Error(details.ValidateBasic(TEEHardwareIntelSGX), "ValidateBasic with invalid attestation type")
+
+	details.SGX = nil
+	require.Error(details.ValidateBasic(TEEHardwareIntelSGX), "ValidateBasic without SGX details")
+
+	// Text serialization.
+	var at SGXAttestationType
+	require.NoError(at.UnmarshalText([]byte("dcap")), "UnmarshalText")
+	require.Equal(SGXAttestationTypeDCAP, at)
+	text, err := SGXAttestationTypeEPID.MarshalText()
+	require.NoError(err, "MarshalText")
+	require.Equal("epid", string(text))
+	require.Error(at.UnmarshalText([]byte("invalid")), "UnmarshalText with invalid type")
+}
+
 func FuzzSGXConstraints(f *testing.F) {
 	// Add some V0 constraints.
 	raw, err := os.ReadFile("testdata/sgx_constraints_v0.bin")
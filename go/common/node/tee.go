@@ -1,6 +1,10 @@
 package node
 
-import "github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
+)
 
 // TEEFeatures are the supported TEE features as advertised by the consensus layer.
 type TEEFeatures struct {
@@ -10,6 +14,14 @@ type TEEFeatures struct {
 	// FreshnessProofs is a feature flag specifying whether ProveFreshness transactions are
 	// supported and processed, or ignored and handled as non-existing transactions.
 	FreshnessProofs bool `json:"freshness_proofs"`
+
+	// MultipleCapabilities is a feature flag specifying whether nodes may advertise multiple TEE
+	// capabilities for a single runtime version.
+	MultipleCapabilities bool `json:"multiple_capabilities,omitempty"`
+
+	// HardwareDetails is a feature flag specifying whether nodes may report structured TEE
+	// hardware details in their TEE capabilities.
+	HardwareDetails bool `json:"hardware_details,omitempty"`
 }
 
 // TEEFeaturesSGX are the supported Intel SGX-specific TEE features.
@@ -52,3 +64,22 @@ func (fs *TEEFeaturesSGX) ApplyDefaultConstraints(sc *SGXConstraints) {
 		sc.MaxAttestationAge = fs.DefaultMaxAttestationAge
 	}
 }
+
+// TEEDetails are structured details about the TEE hardware used by a node.
+type TEEDetails struct {
+	// SGX contains the Intel SGX-specific hardware details.
+	SGX *SGXDetails `json:"sgx,omitempty"`
+}
+
+// ValidateBasic performs basic structure validity checks for the given TEE hardware.
+func (d *TEEDetails) ValidateBasic(hw TEEHardware) error {
+	switch hw {
+	case TEEHardwareIntelSGX:
+		if d.SGX == nil {
+			return fmt.Errorf("missing SGX details")
+		}
+		return d.SGX.ValidateBasic()
+	default:
+		return ErrInvalidTEEHardware
+	}
+}
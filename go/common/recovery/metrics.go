@@ -0,0 +1,31 @@
+package recovery
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	recoveredPanics = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_recovered_panics",
+			Help: "Number of panics recovered in background goroutines.",
+		},
+	)
+	recoveryCollectors = []prometheus.Collector{
+		recoveredPanics,
+	}
+
+	metricsOnce sync.Once
+)
+
+// InitMetrics registers the metrics collectors.
+//
+// Collectors are registered independently of crash reporting, so that the metrics are
+// always exported.
+func InitMetrics() {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(recoveryCollectors...)
+	})
+}
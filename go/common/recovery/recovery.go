@@ -0,0 +1,227 @@
+// Package recovery implements panic recovery and crash reporting for
+// background goroutines.
+//
+// Goroutines started via Go (or that defer Recover) have any panics
+// intercepted. Instead of crashing the process and losing context, a crash
+// report containing the stack trace, the most recent log lines and the node
+// status is written to disk (and optionally submitted to a webhook), after
+// which a clean shutdown is requested.
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// ReportsDir is the name of the directory (relative to the data directory) where crash
+	// reports are stored.
+	ReportsDir = "crash-reports"
+
+	statusTimeout  = 5 * time.Second
+	webhookTimeout = 10 * time.Second
+)
+
+var (
+	mu       sync.Mutex
+	reporter *Config
+	crashed  bool
+
+	logger = logging.GetLogger("common/recovery")
+)
+
+// Config is the crash reporting configuration.
+type Config struct {
+	// Dir is the directory where crash reports are written to.
+	Dir string
+	// Webhook is an optional URL that crash reports are POSTed to.
+	Webhook string
+	// Logs is an optional buffer of recent log lines to include in crash reports.
+	Logs *logging.RingBuffer
+	// Status is an optional function that returns the current node status to include in
+	// crash reports.
+	Status func(context.Context) (interface{}, error)
+	// Shutdown is a function that requests a clean shutdown of the node.
+	Shutdown func()
+}
+
+// Report is a crash report.
+type Report struct {
+	// Component is the name of the component that panicked.
+	Component string `json:"component"`
+	// Time is the time of the panic.
+	Time time.Time `json:"time"`
+	// Panic is the panic value.
+	Panic string `json:"panic"`
+	// Stack is the stack trace of the panicking goroutine.
+	Stack string `json:"stack"`
+	// Logs are the most recent log lines.
+	Logs []string `json:"logs,omitempty"`
+	// Status is the node status at the time of the panic.
+	Status interface{} `json:"status,omitempty"`
+	// StatusError is the error encountered while obtaining the node status.
+	StatusError string `json:"status_error,omitempty"`
+}
+
+// Initialize enables crash reporting with the given configuration.
+//
+// Until crash reporting is enabled, recovered panics are propagated as usual.
+func Initialize(cfg *Config) error {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return fmt.Errorf("recovery: failed to create crash report directory: %w", err)
+	}
+
+	InitMetrics()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	reporter = cfg
+	return nil
+}
+
+// Crashed returns true iff a panic has been recovered since crash reporting was enabled.
+func Crashed() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return crashed
+}
+
+// Go runs the given function in a new goroutine, recovering from any panics.
+func Go(component string, fn func()) {
+	go func() {
+		defer Recover(component)
+		fn()
+	}()
+}
+
+// Recover recovers from a panic in the calling goroutine, generates a crash report and
+// requests a clean shutdown.
+//
+// It must be called directly via defer. In case crash reporting is not enabled, the panic
+// is propagated.
+func Recover(component string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+
+	mu.Lock()
+	cfg := reporter
+	first := !crashed
+	if cfg != nil {
+		crashed = true
+	}
+	mu.Unlock()
+
+	if cfg == nil {
+		panic(r)
+	}
+	recoveredPanics.Inc()
+
+	logger.Error("recovered from panic",
+		"component", component,
+		"panic", r,
+		"stack", string(stack),
+	)
+
+	report := &Report{
+		Component: component,
+		Time:      time.Now(),
+		Panic:     fmt.Sprintf("%v", r),
+		Stack:     string(stack),
+	}
+	if cfg.Logs != nil {
+		report.Logs = cfg.Logs.Lines()
+	}
+	if cfg.Status != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+		status, err := cfg.Status(ctx)
+		cancel()
+		switch err {
+		case nil:
+			report.Status = status
+		default:
+			report.StatusError = err.Error()
+		}
+	}
+
+	path, err := writeReport(cfg.Dir, report)
+	switch err {
+	case nil:
+		logger.Error("crash report written",
+			"path", path,
+		)
+	default:
+		logger.Error("failed to write crash report",
+			"err", err,
+		)
+	}
+
+	if cfg.Webhook != "" {
+		if err = submitReport(cfg.Webhook, report); err != nil {
+			logger.Error("failed to submit crash report",
+				"err", err,
+			)
+		}
+	}
+
+	// Only the first panic needs to trigger the shutdown.
+	if first && cfg.Shutdown != nil {
+		logger.Error("requesting shutdown due to panic")
+		cfg.Shutdown()
+	}
+}
+
+func writeReport(dir string, report *Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	fn := fmt.Sprintf("crash-%s.json", report.Time.UTC().Format("20060102T150405.000000000Z"))
+	path := filepath.Join(dir, fn)
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func submitReport(url string, report *Report) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected webhook response status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package recovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func TestRecover(t *testing.T) {
+	require := require.New(t)
+
+	webhookCh := make(chan *Report, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var report Report
+		if err := json.NewDecoder(r.Body).Decode(&report); err == nil {
+			webhookCh <- &report
+		}
+	}))
+	defer srv.Close()
+
+	logs := logging.NewRingBuffer(10)
+	_, _ = logs.Write([]byte("something happened\n"))
+
+	shutdownCh := make(chan struct{})
+	dir := t.TempDir()
+	err := Initialize(&Config{
+		Dir:     dir,
+		Webhook: srv.URL,
+		Logs:    logs,
+		Status: func(context.Context) (interface{}, error) {
+			return map[string]string{"status": "ok"}, nil
+		},
+		Shutdown: func() {
+			close(shutdownCh)
+		},
+	})
+	require.NoError(err, "Initialize")
+	require.False(Crashed(), "Crashed should be false before any panic")
+
+	Go("test", func() {
+		panic("test panic")
+	})
+
+	select {
+	case <-shutdownCh:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("failed to receive shutdown request")
+	}
+	require.True(Crashed(), "Crashed should be true after a panic")
+
+	files, err := filepath.Glob(filepath.Join(dir, "crash-*.json"))
+	require.NoError(err)
+	require.Len(files, 1, "crash report should be written")
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(err)
+	var report Report
+	require.NoError(json.Unmarshal(data, &report))
+	require.Equal("test", report.Component)
+	require.Equal("test panic", report.Panic)
+	require.Contains(report.Stack, "recovery_test.go")
+	require.Equal([]string{"something happened"}, report.Logs)
+	require.EqualValues(map[string]interface{}{"status": "ok"}, report.Status)
+
+	select {
+	case submitted := <-webhookCh:
+		require.Equal("test panic", submitted.Panic, "webhook should receive the crash report")
+	case <-time.After(10 * time.Second):
+		t.Fatalf("failed to receive crash report via webhook")
+	}
+}
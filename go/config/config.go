@@ -13,6 +13,7 @@ import (
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/config"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/config"
 	common "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/config"
+	grpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc/config"
 	metrics "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics/config"
 	pprof "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof/config"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/config"
@@ -78,6 +79,7 @@ type Config struct {
 	IAS       ias.Config     `yaml:"ias,omitempty"`
 	Pprof     pprof.Config   `yaml:"pprof,omitempty"`
 	Metrics   metrics.Config `yaml:"metrics,omitempty"`
+	GRPC      grpc.Config    `yaml:"grpc,omitempty"`
 
 	Registration workerRegistration.Config `yaml:"registration,omitempty"`
 	Keymanager   workerKM.Config           `yaml:"keymanager,omitempty"`
@@ -137,6 +139,9 @@ func (c *Config) Validate() error {
 	if err = c.Metrics.Validate(); err != nil {
 		return fmt.Errorf("metrics: %w", err)
 	}
+	if err = c.GRPC.Validate(); err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
 
 	return nil
 }
@@ -157,6 +162,7 @@ func DefaultConfig() Config {
 		IAS:          ias.DefaultConfig(),
 		Pprof:        pprof.DefaultConfig(),
 		Metrics:      metrics.DefaultConfig(),
+		GRPC:         grpc.DefaultConfig(),
 	}
 }
 
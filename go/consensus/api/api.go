@@ -125,6 +125,10 @@ type ClientBackend interface {
 	// included in a block and returns a proof of inclusion.
 	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error)
 
+	// SubmitMultiSignedTx submits a consensus transaction multi-signed on behalf of an entity
+	// and waits for the transaction to be included in a block.
+	SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error
+
 	// StateToGenesis returns the genesis state at the specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error)
 
@@ -385,6 +389,38 @@ type Backend interface {
 
 	// RegisterP2PService registers the P2P service used for light client state sync.
 	RegisterP2PService(p2pAPI.Service) error
+
+	// GetPeers returns information about connected consensus peers together with any configured
+	// persistent and sentry upstream peers that are currently not connected.
+	GetPeers(ctx context.Context) ([]*PeerInfo, error)
+}
+
+// PeerInfo is information about a consensus peer.
+type PeerInfo struct {
+	// ID is the peer identifier.
+	ID string `json:"id"`
+
+	// Address is the remote address of the peer if connected, otherwise the configured address.
+	Address string `json:"address"`
+
+	// Connected is true iff the peer is currently connected.
+	Connected bool `json:"connected"`
+
+	// Outbound is true iff the connection was initiated by the local node.
+	Outbound bool `json:"outbound,omitempty"`
+
+	// Persistent is true iff the peer is a configured persistent peer (e.g., a sentry node).
+	Persistent bool `json:"persistent,omitempty"`
+
+	// SentryUpstream is true iff the peer is an upstream node for which the local node acts as a
+	// sentry.
+	SentryUpstream bool `json:"sentry_upstream,omitempty"`
+
+	// ConnectedSince is the time at which the connection was established.
+	ConnectedSince time.Time `json:"connected_since,omitempty"`
+
+	// LastActivity is the time at which data was last sent to or received from the peer.
+	LastActivity time.Time `json:"last_activity,omitempty"`
 }
 
 // HaltHook is a function that gets called when consensus needs to halt for some reason.
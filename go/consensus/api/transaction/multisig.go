@@ -0,0 +1,131 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+)
+
+var (
+	// MultisigSignatureContext is the context used for signing multisig transactions.
+	MultisigSignatureContext = signature.NewContext("oasis-core/consensus: multisig tx", signature.WithChainSeparation())
+
+	_ prettyprint.PrettyPrinter = (*MultiSignedTransaction)(nil)
+)
+
+// MultisigTransaction is a consensus transaction authorized on behalf of an
+// entity by a threshold of the signers in the entity's multisig policy.
+type MultisigTransaction struct {
+	// Entity is the entity on whose behalf the transaction is authorized.
+	Entity signature.PublicKey `json:"entity"`
+	// Transaction is the transaction.
+	Transaction Transaction `json:"tx"`
+}
+
+// SanityCheck performs a basic sanity check on the multisig transaction.
+func (t *MultisigTransaction) SanityCheck() error {
+	if !t.Entity.IsValid() {
+		return fmt.Errorf("transaction: malformed multisig entity")
+	}
+	return t.Transaction.SanityCheck()
+}
+
+// MultiSignedTransaction is a multi-signed MultisigTransaction.
+type MultiSignedTransaction struct {
+	signature.MultiSigned
+}
+
+// Hash returns the cryptographic hash of the encoded transaction.
+func (s *MultiSignedTransaction) Hash() hash.Hash {
+	return hash.NewFrom(s)
+}
+
+// Signers returns the public keys of all signers.
+//
+// Note: This does not verify the signatures.
+func (s *MultiSignedTransaction) Signers() []signature.PublicKey {
+	signers := make([]signature.PublicKey, 0, len(s.Signatures))
+	for _, sig := range s.Signatures {
+		signers = append(signers, sig.PublicKey)
+	}
+	return signers
+}
+
+// Combine adds the signatures of another partially signed copy of the same
+// transaction, skipping any signers that have already signed.
+func (s *MultiSignedTransaction) Combine(other *MultiSignedTransaction) error {
+	if !bytes.Equal(s.Blob, other.Blob) {
+		return fmt.Errorf("transaction: cannot combine signatures over different transactions")
+	}
+
+	for _, sig := range other.Signatures {
+		if s.IsSignedBy(sig.PublicKey) {
+			continue
+		}
+		s.Signatures = append(s.Signatures, sig)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of the type
+// to the given writer.
+func (s MultiSignedTransaction) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sHash: %s\n", prefix, s.Hash())
+
+	fmt.Fprintf(w, "%sSigners:\n", prefix)
+	for _, sig := range s.Signatures {
+		fmt.Fprintf(w, "%s  %s\n", prefix, sig.PublicKey)
+		fmt.Fprintf(w, "%s    (signature: %s)\n", prefix, sig.Signature)
+
+		// Check if signature is valid.
+		if !sig.Verify(MultisigSignatureContext, s.Blob) {
+			fmt.Fprintf(w, "%s    [INVALID SIGNATURE]\n", prefix)
+		}
+	}
+
+	// Display the blob even if signature verification failed as it may
+	// be useful to look into it regardless.
+	var tx MultisigTransaction
+	fmt.Fprintf(w, "%sContent:\n", prefix)
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		fmt.Fprintf(w, "%s  <error: %s>\n", prefix, err)
+		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(s.Blob))
+		return
+	}
+
+	fmt.Fprintf(w, "%s  Entity: %s\n", prefix, tx.Entity)
+	tx.Transaction.PrettyPrint(ctx, prefix+"  ", w)
+}
+
+// PrettyType returns a representation of the type that can be used for pretty printing.
+func (s MultiSignedTransaction) PrettyType() (interface{}, error) {
+	var tx MultisigTransaction
+	if err := cbor.Unmarshal(s.Blob, &tx); err != nil {
+		return nil, fmt.Errorf("malformed signed blob: %w", err)
+	}
+	return signature.NewPrettyMultiSigned(s.MultiSigned, tx)
+}
+
+// Open first verifies the blob signatures and then unmarshals the blob.
+func (s *MultiSignedTransaction) Open(tx *MultisigTransaction) error { // nolint: interfacer
+	return s.MultiSigned.Open(MultisigSignatureContext, tx)
+}
+
+// SignMultisig partially signs a multisig transaction with a single signer.
+//
+// Partial signatures from multiple signers can be combined via Combine.
+func SignMultisig(signer signature.Signer, tx *MultisigTransaction) (*MultiSignedTransaction, error) {
+	signed, err := signature.SignMultiSigned([]signature.Signer{signer}, MultisigSignatureContext, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiSignedTransaction{MultiSigned: *signed}, nil
+}
@@ -30,6 +30,9 @@ var (
 	// ErrMethodNotSupported is the error returned if transaction method is not supported.
 	ErrMethodNotSupported = errors.New(moduleName, 5, "transaction: method not supported")
 
+	// ErrMultisigNotSupported is the error returned if multi-signed transactions are not supported.
+	ErrMultisigNotSupported = errors.New(moduleName, 6, "transaction: multi-signed transactions not supported")
+
 	// SignatureContext is the context used for signing transactions.
 	SignatureContext = signature.NewContext("oasis-core/consensus: tx", signature.WithChainSeparation())
 
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
 type testMethodBodyNormal struct{}
@@ -24,3 +28,42 @@ func TestMethodMetadata(t *testing.T) {
 	require.False(methodNormal.IsCritical())
 	require.True(methodCritical.IsCritical())
 }
+
+func TestMultiSignedTransaction(t *testing.T) {
+	require := require.New(t)
+
+	signature.SetChainContext("test: oasis-core tests")
+
+	s1 := memorySigner.NewTestSigner("test multisig tx signer 1")
+	s2 := memorySigner.NewTestSigner("test multisig tx signer 2")
+	ent := memorySigner.NewTestSigner("test multisig tx entity")
+
+	mtx := MultisigTransaction{
+		Entity:      ent.Public(),
+		Transaction: *NewTransaction(1, nil, NewMethodName("test", "Multisig", testMethodBodyNormal{}), nil),
+	}
+	require.NoError(mtx.SanityCheck(), "SanityCheck")
+
+	sigTx1, err := SignMultisig(s1, &mtx)
+	require.NoError(err, "SignMultisig")
+	sigTx2, err := SignMultisig(s2, &mtx)
+	require.NoError(err, "SignMultisig")
+
+	require.NoError(sigTx1.Combine(sigTx2), "Combine")
+	require.NoError(sigTx1.Combine(sigTx2), "Combine should skip existing signers")
+	require.ElementsMatch([]signature.PublicKey{s1.Public(), s2.Public()}, sigTx1.Signers())
+
+	var opened MultisigTransaction
+	require.NoError(sigTx1.Open(&opened), "Open")
+	require.EqualValues(mtx, opened)
+
+	other := mtx
+	other.Transaction.Nonce = 2
+	sigTx3, err := SignMultisig(s2, &other)
+	require.NoError(err, "SignMultisig")
+	require.Error(sigTx1.Combine(sigTx3), "Combine should fail for different transactions")
+
+	// A multi-signed transaction must never be mistaken for a single-signed one.
+	var sigTx SignedTransaction
+	require.Error(cbor.Unmarshal(cbor.Marshal(sigTx1), &sigTx), "multi-signed transaction should not decode as single-signed")
+}
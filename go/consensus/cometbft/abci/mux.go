@@ -167,6 +167,17 @@ func (a *ApplicationServer) TransactionAuthHandler() api.TransactionAuthHandler
 	return a.mux.state.txAuthHandler
 }
 
+// SetMultisigAuthHandler configures the multisig transaction authorization
+// handler for the ABCI multiplexer.
+func (a *ApplicationServer) SetMultisigAuthHandler(handler api.MultisigAuthHandler) error {
+	if a.mux.state.multisigAuthHandler != nil {
+		return fmt.Errorf("mux: multisig authorization handler already configured")
+	}
+
+	a.mux.state.multisigAuthHandler = handler
+	return nil
+}
+
 // WatchInvalidatedTx adds a watcher for when/if the transaction with given
 // hash becomes invalid due to a failed re-check.
 func (a *ApplicationServer) WatchInvalidatedTx(txHash hash.Hash) (<-chan error, pubsub.ClosableSubscription, error) {
@@ -800,6 +811,8 @@ func (mux *abciMux) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 		Version: &cmtproto.VersionParams{
 			App: version.CometBFTAppVersion,
 		},
+		// Update evidence parameters in case they were changed by any application.
+		Evidence: api.EvidenceParamsUpdate(ctx),
 	}
 
 	// Validate system transactions included by the proposer.
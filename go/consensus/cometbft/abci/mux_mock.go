@@ -29,6 +29,12 @@ func (mux *MockABCIMux) MockSetTransactionAuthHandler(handler api.TransactionAut
 	mux.state.txAuthHandler = handler
 }
 
+// MockSetMultisigAuthHandler sets the multisig transaction authorization
+// handler used by this muxer when testing.
+func (mux *MockABCIMux) MockSetMultisigAuthHandler(handler api.MultisigAuthHandler) {
+	mux.state.multisigAuthHandler = handler
+}
+
 // MockClose cleans up the muxer's state; it must be called once the muxer is no longer needed.
 func (mux *MockABCIMux) MockClose() {
 	mux.doCleanup()
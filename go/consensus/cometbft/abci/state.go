@@ -160,7 +160,8 @@ type applicationState struct { // nolint: maligned
 	blockCtx    *api.BlockContext
 	blockParams *consensusGenesis.Parameters
 
-	txAuthHandler api.TransactionAuthHandler
+	txAuthHandler       api.TransactionAuthHandler
+	multisigAuthHandler api.MultisigAuthHandler
 
 	timeSource beacon.Backend
 
@@ -14,11 +14,17 @@ import (
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 )
 
-func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, *transaction.SignedTransaction, error) {
+// decodeTx decodes and verifies the given raw transaction, returning the transaction together
+// with its authenticated signer.
+//
+// Both single-signed transactions and transactions multi-signed on behalf of an entity are
+// supported. In the latter case the signer is the entity on whose behalf the transaction has
+// been authorized.
+func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Transaction, signature.PublicKey, error) {
 	params := mux.state.ConsensusParameters()
 	if params == nil {
 		ctx.Logger().Debug("decodeTx: state not yet initialized")
-		return nil, nil, consensus.ErrNoCommittedBlocks
+		return nil, signature.PublicKey{}, consensus.ErrNoCommittedBlocks
 	}
 
 	if params.MaxTxSize > 0 && uint64(len(rawTx)) > params.MaxTxSize {
@@ -27,32 +33,78 @@ func (mux *abciMux) decodeTx(ctx *api.Context, rawTx []byte) (*transaction.Trans
 		ctx.Logger().Debug("received oversized transaction",
 			"tx_size", len(rawTx),
 		)
-		return nil, nil, consensus.ErrOversizedTx
+		return nil, signature.PublicKey{}, consensus.ErrOversizedTx
 	}
 
 	// Unmarshal envelope and verify transaction.
 	var sigTx transaction.SignedTransaction
 	if err := cbor.Unmarshal(rawTx, &sigTx); err != nil {
+		// Not a single-signed transaction, try a multi-signed one.
+		var multiSigTx transaction.MultiSignedTransaction
+		if merr := cbor.Unmarshal(rawTx, &multiSigTx); merr == nil {
+			return mux.decodeMultiSignedTx(ctx, rawTx, &multiSigTx)
+		}
+
 		ctx.Logger().Debug("failed to unmarshal signed transaction",
 			"tx", base64.StdEncoding.EncodeToString(rawTx),
 		)
-		return nil, nil, err
+		return nil, signature.PublicKey{}, err
 	}
 	var tx transaction.Transaction
 	if err := sigTx.Open(&tx); err != nil {
 		ctx.Logger().Debug("failed to verify transaction signature",
 			"tx", base64.StdEncoding.EncodeToString(rawTx),
 		)
-		return nil, nil, err
+		return nil, signature.PublicKey{}, err
 	}
 	if err := tx.SanityCheck(); err != nil {
 		ctx.Logger().Debug("bad transaction",
 			"tx", base64.StdEncoding.EncodeToString(rawTx),
 		)
-		return nil, nil, err
+		return nil, signature.PublicKey{}, err
+	}
+
+	return &tx, sigTx.Signature.PublicKey, nil
+}
+
+func (mux *abciMux) decodeMultiSignedTx(
+	ctx *api.Context,
+	rawTx []byte,
+	multiSigTx *transaction.MultiSignedTransaction,
+) (*transaction.Transaction, signature.PublicKey, error) {
+	handler := mux.state.multisigAuthHandler
+	if handler == nil {
+		ctx.Logger().Debug("multi-signed transactions are not supported",
+			"tx", base64.StdEncoding.EncodeToString(rawTx),
+		)
+		return nil, signature.PublicKey{}, transaction.ErrMultisigNotSupported
+	}
+
+	var mtx transaction.MultisigTransaction
+	if err := multiSigTx.Open(&mtx); err != nil {
+		ctx.Logger().Debug("failed to verify multi-signed transaction signatures",
+			"tx", base64.StdEncoding.EncodeToString(rawTx),
+		)
+		return nil, signature.PublicKey{}, err
+	}
+	if err := mtx.SanityCheck(); err != nil {
+		ctx.Logger().Debug("bad multi-signed transaction",
+			"tx", base64.StdEncoding.EncodeToString(rawTx),
+		)
+		return nil, signature.PublicKey{}, err
+	}
+
+	// Make sure that the signers are authorized to act on behalf of the entity.
+	if err := handler.AuthorizeMultisigTx(ctx, mtx.Entity, multiSigTx.Signers()); err != nil {
+		ctx.Logger().Debug("unauthorized multi-signed transaction",
+			"tx", base64.StdEncoding.EncodeToString(rawTx),
+			"entity", mtx.Entity,
+			"err", err,
+		)
+		return nil, signature.PublicKey{}, err
 	}
 
-	return &tx, &sigTx, nil
+	return &mtx.Transaction, mtx.Entity, nil
 }
 
 func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txSize int) error {
@@ -126,13 +178,13 @@ func (mux *abciMux) processTx(ctx *api.Context, tx *transaction.Transaction, txS
 }
 
 func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
-	tx, sigTx, err := mux.decodeTx(ctx, rawTx)
+	tx, signer, err := mux.decodeTx(ctx, rawTx)
 	if err != nil {
 		return err
 	}
 
 	// Set authenticated transaction signer.
-	ctx.SetTxSigner(sigTx.Signature.PublicKey)
+	ctx.SetTxSigner(signer)
 
 	// If we are in CheckTx mode and there is a pending upgrade in this block, make sure to reject
 	// any transactions before processing as they may potentially query incompatible state.
@@ -211,6 +211,10 @@ type Backend interface {
 	// ABCI multiplexer.
 	SetTransactionAuthHandler(TransactionAuthHandler) error
 
+	// SetMultisigAuthHandler configures the multisig transaction authorization
+	// handler for the ABCI multiplexer.
+	SetMultisigAuthHandler(MultisigAuthHandler) error
+
 	// GetBlock returns the CometBFT block at the specified height.
 	GetCometBFTBlock(ctx context.Context, height int64) (*cmttypes.Block, error)
 
@@ -270,6 +274,14 @@ type TransactionAuthHandler interface {
 	PostExecuteTx(ctx *Context, tx *transaction.Transaction) error
 }
 
+// MultisigAuthHandler is the interface for ABCI applications that handle
+// authorizing multi-signed transactions on behalf of entities.
+type MultisigAuthHandler interface {
+	// AuthorizeMultisigTx makes sure that the given (already verified)
+	// signers satisfy the multisig policy of the given entity.
+	AuthorizeMultisigTx(ctx *Context, entity signature.PublicKey, signers []signature.PublicKey) error
+}
+
 // ServiceEvent is a CometBFT-specific consensus.ServiceEvent.
 type ServiceEvent struct {
 	Block *cmttypes.EventDataNewBlockHeader `json:"block,omitempty"`
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -26,6 +27,17 @@ type GenesisProvider interface {
 	GetCometBFTGenesisDocument() (*cmttypes.GenesisDoc, error)
 }
 
+// EvidenceParams returns the CometBFT evidence parameters which make sure that evidence is
+// accepted for the whole debonding interval, given the current epoch interval.
+func EvidenceParams(params *consensusGenesis.Parameters, debondingInterval, epochInterval int64) cmttypes.EvidenceParams {
+	maxAgeNumBlocks := debondingInterval * epochInterval
+	return cmttypes.EvidenceParams{
+		MaxBytes:        int64(params.MaxEvidenceSize),
+		MaxAgeNumBlocks: maxAgeNumBlocks,
+		MaxAgeDuration:  time.Duration(maxAgeNumBlocks) * (params.TimeoutCommit + 1*time.Second),
+	}
+}
+
 // GetCometBFTGenesisDocument returns the CometBFT genesis document corresponding to the Oasis
 // genesis document specified by the given genesis provider.
 func GetCometBFTGenesisDocument(provider genesis.Provider) (*cmttypes.GenesisDoc, error) {
@@ -107,10 +119,7 @@ func genesisToCometBFT(d *genesis.Document) (*cmttypes.GenesisDoc, error) {
 		return nil, fmt.Errorf("cometbft: unable to determine epoch interval")
 	}
 
-	var evCfg cmttypes.EvidenceParams
-	evCfg.MaxBytes = int64(d.Consensus.Parameters.MaxEvidenceSize)
-	evCfg.MaxAgeNumBlocks = debondingInterval * epochInterval
-	evCfg.MaxAgeDuration = time.Duration(evCfg.MaxAgeNumBlocks) * (d.Consensus.Parameters.TimeoutCommit + 1*time.Second)
+	evCfg := EvidenceParams(&d.Consensus.Parameters, debondingInterval, epochInterval)
 
 	doc := cmttypes.GenesisDoc{
 		ChainID:       CometBFTChainID(d.ChainContext()),
@@ -0,0 +1,24 @@
+package api
+
+import (
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+)
+
+// evidenceParamsUpdateKey is the block context key for pending evidence parameter updates.
+type evidenceParamsUpdateKey struct{}
+
+func (evidenceParamsUpdateKey) NewDefault() interface{} {
+	return (*cmtproto.EvidenceParams)(nil)
+}
+
+// SetEvidenceParamsUpdate schedules an update of the CometBFT evidence parameters at the end
+// of the current block.
+func SetEvidenceParamsUpdate(ctx *Context, params *cmtproto.EvidenceParams) {
+	ctx.BlockContext().Set(evidenceParamsUpdateKey{}, params)
+}
+
+// EvidenceParamsUpdate returns the CometBFT evidence parameters that should be updated at the
+// end of the current block (if any).
+func EvidenceParamsUpdate(ctx *Context) *cmtproto.EvidenceParams {
+	return ctx.BlockContext().Get(evidenceParamsUpdateKey{}).(*cmtproto.EvidenceParams)
+}
@@ -35,6 +35,7 @@ var (
 	Methods = []transaction.MethodName{
 		MethodSetEpoch,
 		beacon.MethodVRFProve,
+		beacon.MethodSubmitExternalEntropy,
 	}
 )
 
@@ -90,7 +90,7 @@ func (impl *backendInsecure) OnBeginBlock(
 	impl.app.doEmitEpochEvent(ctx, future.Epoch)
 
 	// Generate the beacon
-	return impl.onEpochChangeBeacon(ctx, future.Epoch)
+	return impl.onEpochChangeBeacon(ctx, state, future.Epoch)
 }
 
 func (impl *backendInsecure) scheduleEpochTransitionBlock(
@@ -106,15 +106,18 @@ func (impl *backendInsecure) scheduleEpochTransitionBlock(
 
 func (impl *backendInsecure) onEpochChangeBeacon(
 	ctx *api.Context,
+	state *beaconState.MutableState,
 	epoch beacon.EpochTime,
 ) error {
-	var entropy []byte
-
 	entropyCtx := prodEntropyCtx
 	// Use the block hash for entropy. This is insecure, and is vulnerable to adversarial
 	// manipulation.  If this is a problem, don't use this backend.
 	ctx.Logger().Debug("onBeaconEpochChange: using block hash as entropy")
-	entropy = insecureBlockEntropy(ctx)
+	external, err := takeExternalEntropy(ctx, state)
+	if err != nil {
+		return err
+	}
+	entropy := mixExternalEntropy(insecureBlockEntropy(ctx), external)
 
 	b := GetBeacon(epoch, entropyCtx, entropy)
 
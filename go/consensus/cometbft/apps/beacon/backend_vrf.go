@@ -192,6 +192,13 @@ func (impl *backendVRF) OnBeginBlock(
 	}
 	impl.app.doEmitEpochEvent(ctx, future.Epoch)
 
+	// Take any external entropy submitted during the ending epoch so that it can be mixed into
+	// the new alpha and the debug entropy.
+	external, err := takeExternalEntropy(ctx, state)
+	if err != nil {
+		return err
+	}
+
 	// Generate a new alpha, and update the rest of the state.
 	vrfState.PrevState = &beacon.PrevVRFState{
 		Pi:                 vrfState.Pi,
@@ -202,7 +209,7 @@ func (impl *backendVRF) OnBeginBlock(
 	vrfState.SubmitAfter = height + params.VRFParameters.ProofSubmissionDelay
 	if vrfState.AlphaIsHighQuality {
 		// New alpha has enough proofs to allow elections.
-		vrfState.Alpha = impl.newHighQualityAlpha(ctx, vrfState)
+		vrfState.Alpha = impl.newHighQualityAlpha(ctx, vrfState, external)
 	} else {
 		// New alpha has insufficient proofs to allow elections.
 		vrfState.Alpha = impl.newLowQualityAlpha(ctx, vrfState.Epoch)
@@ -216,10 +223,13 @@ func (impl *backendVRF) OnBeginBlock(
 	//  * All elections with DebugDeterminstic set.
 	//  * Tie-breaks for validator elections if insufficient proofs (unlikely).
 	//
+	// Any external entropy submitted during the ending epoch is mixed in as well.
+	//
 	// Instead of just using the block hash (which is probably ok),
 	// this could consider aggregating all of the beta values from
 	// VRF proofs, though that is also merely "probably ok".
-	entropy := GetBeacon(future.Epoch, prodEntropyCtx, insecureBlockEntropy(ctx))
+	entropy := mixExternalEntropy(insecureBlockEntropy(ctx), external)
+	entropy = GetBeacon(future.Epoch, prodEntropyCtx, entropy)
 	if err = impl.app.onNewBeacon(ctx, entropy); err != nil {
 		return fmt.Errorf("beacon: failed to generate debug entropy")
 	}
@@ -443,6 +453,7 @@ func (impl *backendVRF) initAlphaCommon(
 func (impl *backendVRF) newHighQualityAlpha(
 	ctx *api.Context,
 	vrfState *beacon.VRFState,
+	external []byte,
 ) []byte {
 	sorted := make([]signature.PublicKey, 0, len(vrfState.Pi))
 	for mk := range vrfState.Pi {
@@ -458,6 +469,11 @@ func (impl *backendVRF) newHighQualityAlpha(
 		beta := pi.UnsafeToHash() // Ok because invalid proofs don't get stored.
 		_, _ = h.Write(beta)
 	}
+	// Mix in any external entropy, so that elections based on proofs for the new alpha also
+	// depend on it.
+	if len(external) > 0 {
+		_, _ = h.Write(external)
+	}
 	return h.Sum(nil)
 }
 
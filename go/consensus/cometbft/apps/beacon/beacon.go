@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
 )
 
 var (
@@ -48,8 +49,12 @@ func (app *beaconApplication) Dependencies() []string {
 	return nil
 }
 
-func (app *beaconApplication) OnRegister(state api.ApplicationState, _ api.MessageDispatcher) {
+func (app *beaconApplication) OnRegister(state api.ApplicationState, md api.MessageDispatcher) {
 	app.state = state
+
+	// Subscribe to messages emitted by other apps.
+	md.Subscribe(governanceApi.MessageChangeParameters, app)
+	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
 }
 
 func (app *beaconApplication) OnCleanup() {
@@ -70,8 +75,18 @@ func (app *beaconApplication) BeginBlock(ctx *api.Context) error {
 	return app.backend.OnBeginBlock(ctx, state, params)
 }
 
-func (app *beaconApplication) ExecuteMessage(*api.Context, interface{}, interface{}) (interface{}, error) {
-	return nil, fmt.Errorf("beacon: unexpected message")
+func (app *beaconApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
+	switch kind {
+	case governanceApi.MessageValidateParameterChanges:
+		// A change parameters proposal is about to be submitted. Validate changes.
+		return app.changeParameters(ctx, msg, false)
+	case governanceApi.MessageChangeParameters:
+		// A change parameters proposal has just been accepted and closed. Validate and apply
+		// changes.
+		return app.changeParameters(ctx, msg, true)
+	default:
+		return nil, fmt.Errorf("beacon: unexpected message")
+	}
 }
 
 func (app *beaconApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transaction) error {
@@ -90,7 +105,12 @@ func (app *beaconApplication) ExecuteTx(ctx *api.Context, tx *transaction.Transa
 
 	ctx.SetPriority(AppPriority)
 
-	return app.backend.ExecuteTx(ctx, state, params, tx)
+	switch tx.Method {
+	case beacon.MethodSubmitExternalEntropy:
+		return app.submitExternalEntropy(ctx, state, params, tx)
+	default:
+		return app.backend.ExecuteTx(ctx, state, params, tx)
+	}
 }
 
 func (app *beaconApplication) EndBlock(*api.Context) (types.ResponseEndBlock, error) {
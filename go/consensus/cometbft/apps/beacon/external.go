@@ -0,0 +1,156 @@
+package beacon
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+)
+
+var externalEntropyCtx = []byte("oasis-core/beacon: external entropy")
+
+func (app *beaconApplication) submitExternalEntropy(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ConsensusParameters,
+	tx *transaction.Transaction,
+) error {
+	eeParams := params.ExternalEntropy
+	if eeParams == nil {
+		return fmt.Errorf("beacon: external entropy is disabled via consensus")
+	}
+
+	if err := ctx.Gas().UseGas(1, beacon.GasOpSubmitExternalEntropy, eeParams.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Ensure the tx is from a whitelisted submitter.
+	submitter := ctx.TxSigner()
+	if !slices.Contains(eeParams.Submitters, submitter) {
+		return fmt.Errorf("beacon: tx not from an external entropy submitter")
+	}
+
+	// Deserialize the tx.
+	var ee beacon.ExternalEntropy
+	if err := cbor.Unmarshal(tx.Body, &ee); err != nil {
+		return beacon.ErrInvalidArgument
+	}
+	if len(ee.Entropy) == 0 || len(ee.Entropy) > beacon.MaxExternalEntropySize {
+		return fmt.Errorf("%w: malformed external entropy", beacon.ErrInvalidArgument)
+	}
+
+	epoch, _, err := state.GetEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to get current epoch: %w", err)
+	}
+	if ee.Epoch != epoch {
+		return fmt.Errorf("beacon: external entropy for invalid epoch: %d", ee.Epoch)
+	}
+
+	eeState, err := state.ExternalEntropyState(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to get external entropy state: %w", err)
+	}
+	if eeState == nil || eeState.Epoch != epoch {
+		eeState = &beacon.ExternalEntropyState{
+			Epoch: epoch,
+		}
+	}
+	if eeState.Entropy == nil {
+		eeState.Entropy = make(map[signature.PublicKey][]byte)
+	}
+	// Only allow a single submission per epoch to limit the ability to grind the beacon.
+	if _, ok := eeState.Entropy[submitter]; ok {
+		return fmt.Errorf("beacon: external entropy already submitted for epoch %d", epoch)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	eeState.Entropy[submitter] = ee.Entropy
+	if err = state.SetExternalEntropyState(ctx, eeState); err != nil {
+		return fmt.Errorf("beacon: failed to update external entropy state: %w", err)
+	}
+
+	ctx.Logger().Debug("processed SubmitExternalEntropy tx",
+		"epoch", epoch,
+		"submitter", submitter,
+	)
+
+	return nil
+}
+
+// takeExternalEntropy returns a digest of the external entropy submitted during the ending
+// epoch and clears the submissions.
+//
+// If no external entropy has been submitted, nil is returned.
+func takeExternalEntropy(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+) ([]byte, error) {
+	eeState, err := state.ExternalEntropyState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to get external entropy state: %w", err)
+	}
+	if eeState == nil {
+		return nil, nil
+	}
+	if err = state.ClearExternalEntropyState(ctx); err != nil {
+		return nil, fmt.Errorf("beacon: failed to clear external entropy state: %w", err)
+	}
+	if len(eeState.Entropy) == 0 {
+		return nil, nil
+	}
+
+	submitters := make([]signature.PublicKey, 0, len(eeState.Entropy))
+	for pk := range eeState.Entropy {
+		submitters = append(submitters, pk)
+	}
+	sort.Slice(submitters, func(i, j int) bool {
+		return bytes.Compare(submitters[i][:], submitters[j][:]) < 0
+	})
+
+	h := sha3.New256()
+	_, _ = h.Write(externalEntropyCtx)
+	for _, pk := range submitters {
+		_, _ = h.Write(pk[:])
+		_, _ = h.Write(eeState.Entropy[pk])
+	}
+
+	ctx.Logger().Debug("took external entropy",
+		"epoch", eeState.Epoch,
+		"num_submissions", len(submitters),
+	)
+
+	return h.Sum(nil), nil
+}
+
+// mixExternalEntropy mixes the given external entropy digest into the given entropy.
+//
+// If there is no external entropy, the entropy is returned unchanged.
+func mixExternalEntropy(entropy, external []byte) []byte {
+	if len(external) == 0 {
+		return entropy
+	}
+
+	h := sha3.New256()
+	_, _ = h.Write(externalEntropyCtx)
+	_, _ = h.Write(entropy)
+	_, _ = h.Write(external)
+	return h.Sum(nil)
+}
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+)
+
+func TestExternalEntropy(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer ctx.Close()
+
+	app := &beaconApplication{
+		state: appState,
+	}
+	state := beaconState.NewMutableState(ctx.State())
+	err := state.SetEpoch(ctx, 5, 1)
+	require.NoError(err, "SetEpoch")
+
+	submitter := memorySigner.NewTestSigner("external entropy submitter")
+	outsider := memorySigner.NewTestSigner("external entropy outsider")
+
+	params := &beacon.ConsensusParameters{}
+	submit := func(ee *beacon.ExternalEntropy) error {
+		tx := beacon.NewSubmitExternalEntropyTx(0, nil, ee)
+		return app.submitExternalEntropy(ctx, state, params, tx)
+	}
+
+	// Mixing without any submissions should not change the entropy.
+	entropy := []byte("block entropy")
+	external, err := takeExternalEntropy(ctx, state)
+	require.NoError(err, "takeExternalEntropy")
+	require.Nil(external, "there should be no external entropy without submissions")
+	require.Equal(entropy, mixExternalEntropy(entropy, external), "entropy should not change without submissions")
+
+	// External entropy is disabled by default.
+	ctx.SetTxSigner(submitter.Public())
+	err = submit(&beacon.ExternalEntropy{Epoch: 5, Entropy: []byte("drand")})
+	require.Error(err, "external entropy should be disabled by default")
+
+	params.ExternalEntropy = &beacon.ExternalEntropyParameters{
+		Submitters: []signature.PublicKey{submitter.Public()},
+	}
+
+	ctx.SetTxSigner(outsider.Public())
+	err = submit(&beacon.ExternalEntropy{Epoch: 5, Entropy: []byte("drand")})
+	require.Error(err, "submissions from non-whitelisted signers should be rejected")
+
+	ctx.SetTxSigner(submitter.Public())
+	err = submit(&beacon.ExternalEntropy{Epoch: 4, Entropy: []byte("drand")})
+	require.Error(err, "submissions for other epochs should be rejected")
+	err = submit(&beacon.ExternalEntropy{Epoch: 5})
+	require.Error(err, "empty submissions should be rejected")
+
+	err = submit(&beacon.ExternalEntropy{Epoch: 5, Entropy: []byte("drand")})
+	require.NoError(err, "valid submission should succeed")
+	err = submit(&beacon.ExternalEntropy{Epoch: 5, Entropy: []byte("other drand")})
+	require.Error(err, "only one submission per epoch should be allowed")
+
+	// Taking the submissions should clear them and change the entropy.
+	external, err = takeExternalEntropy(ctx, state)
+	require.NoError(err, "takeExternalEntropy")
+	require.NotEmpty(external, "external entropy should be taken")
+	require.NotEqual(entropy, mixExternalEntropy(entropy, external), "entropy should change with submissions")
+
+	eeState, err := state.ExternalEntropyState(ctx)
+	require.NoError(err, "ExternalEntropyState")
+	require.Nil(eeState, "submissions should be cleared after taking them")
+
+	// External entropy should also be mixed into the next VRF alpha.
+	initCtx := appState.NewContext(abciAPI.ContextInitChain)
+	defer initCtx.Close()
+	err = abciState.NewMutableState(initCtx.State()).SetChainContext(initCtx, "test chain context")
+	require.NoError(err, "SetChainContext")
+	impl := &backendVRF{app: app}
+	vrfState := &beacon.VRFState{Epoch: 6}
+	alpha := impl.newHighQualityAlpha(initCtx, vrfState, nil)
+	require.NotEqual(alpha, impl.newHighQualityAlpha(initCtx, vrfState, external), "alpha should change with external entropy")
+}
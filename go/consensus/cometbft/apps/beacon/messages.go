@@ -0,0 +1,118 @@
+package beacon
+
+import (
+	"fmt"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+)
+
+func (app *beaconApplication) changeParameters(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	// Unmarshal changes and check if they should be applied to this module.
+	proposal, ok := msg.(*governance.ChangeParametersProposal)
+	if !ok {
+		return nil, fmt.Errorf("beacon: failed to type assert change parameters proposal")
+	}
+
+	if proposal.Module != beacon.ModuleName {
+		return nil, nil
+	}
+
+	var changes beacon.ConsensusParameterChanges
+	if err := cbor.Unmarshal(proposal.Changes, &changes); err != nil {
+		return nil, fmt.Errorf("beacon: failed to unmarshal consensus parameter changes: %w", err)
+	}
+
+	// Validate changes against current parameters.
+	state := beaconState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to load consensus parameters: %w", err)
+	}
+	if err = changes.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("beacon: failed to validate consensus parameter changes: %w", err)
+	}
+	var oldInterval int64
+	if params.VRFParameters != nil {
+		oldInterval = params.VRFParameters.Interval
+	}
+	if err = changes.Apply(params); err != nil {
+		return nil, fmt.Errorf("beacon: failed to apply consensus parameter changes: %w", err)
+	}
+	if err = params.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("beacon: failed to validate consensus parameters: %w", err)
+	}
+
+	// Apply changes. The next epoch transition has already been scheduled using the old
+	// interval, so the new interval only takes effect at the next epoch boundary.
+	if apply {
+		if err = state.SetConsensusParameters(ctx, params); err != nil {
+			return nil, fmt.Errorf("beacon: failed to update consensus parameters: %w", err)
+		}
+
+		// Longer epochs mean that the debonding interval spans more blocks, so evidence needs
+		// to be accepted for longer. Evidence parameters are never decreased as the epochs
+		// preceding the change still need to be covered, so they are only updated when the
+		// interval exceeds the largest interval that they have been configured for.
+		if params.VRFParameters != nil {
+			if err = maybeUpdateEvidenceParams(ctx, state, oldInterval, params.VRFParameters.Interval); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Non-nil response signals that changes are valid and were successfully applied (if required).
+	return struct{}{}, nil
+}
+
+func maybeUpdateEvidenceParams(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	oldInterval int64,
+	newInterval int64,
+) error {
+	maxInterval, err := state.EvidenceEpochInterval(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to load evidence epoch interval: %w", err)
+	}
+	// Evidence parameters have been configured for the old interval unless they have already
+	// been configured for a larger one.
+	maxInterval = max(maxInterval, oldInterval)
+	if newInterval > maxInterval {
+		if err = updateEvidenceParams(ctx, newInterval); err != nil {
+			return err
+		}
+		maxInterval = newInterval
+	}
+	if err = state.SetEvidenceEpochInterval(ctx, maxInterval); err != nil {
+		return fmt.Errorf("beacon: failed to set evidence epoch interval: %w", err)
+	}
+	return nil
+}
+
+func updateEvidenceParams(ctx *api.Context, epochInterval int64) error {
+	debondingInterval, err := stakingState.NewMutableState(ctx.State()).DebondingInterval(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to load debonding interval: %w", err)
+	}
+	if debondingInterval == 0 {
+		// Debonding can only be disabled in debug mode, in which case the genesis evidence
+		// parameters use a debonding interval of 1 epoch.
+		debondingInterval = 1
+	}
+
+	evCfg := api.EvidenceParams(ctx.AppState().ConsensusParameters(), int64(debondingInterval), epochInterval)
+	api.SetEvidenceParamsUpdate(ctx, &cmtproto.EvidenceParams{
+		MaxAgeNumBlocks: evCfg.MaxAgeNumBlocks,
+		MaxAgeDuration:  evCfg.MaxAgeDuration,
+		MaxBytes:        evCfg.MaxBytes,
+	})
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func newTestGenesis() *genesis.Document {
+	return &genesis.Document{
+		Consensus: consensusGenesis.Genesis{
+			Parameters: consensusGenesis.Parameters{
+				TimeoutCommit:   time.Second,
+				MaxEvidenceSize: 1024,
+			},
+		},
+	}
+}
+
+func TestChangeParameters(t *testing.T) {
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		Genesis: newTestGenesis(),
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	state := beaconState.NewMutableState(ctx.State())
+	app := &beaconApplication{
+		state: appState,
+	}
+	err := stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 2,
+	})
+	require.NoError(t, err, "setting staking consensus parameters should succeed")
+	params := &beacon.ConsensusParameters{
+		Backend: beacon.BackendVRF,
+		VRFParameters: &beacon.VRFParameters{
+			AlphaHighQualityThreshold: 1,
+			Interval:                  100,
+			ProofSubmissionDelay:      20,
+		},
+	}
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(t, err, "setting consensus parameters should succeed")
+
+	// Prepare proposal.
+	interval := int64(200)
+	changes := beacon.ConsensusParameterChanges{
+		Interval: &interval,
+	}
+	proposal := governance.ChangeParametersProposal{
+		Module:  beacon.ModuleName,
+		Changes: cbor.Marshal(changes),
+	}
+
+	// Run sub-tests.
+	t.Run("happy path - validate only", func(t *testing.T) {
+		require := require.New(t)
+
+		res, err := app.changeParameters(ctx, &proposal, false)
+		require.NoError(err, "validation of consensus parameter changes should succeed")
+		require.Equal(struct{}{}, res)
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(params.VRFParameters.Interval, state.VRFParameters.Interval, "consensus parameters shouldn't change")
+		require.Nil(abciAPI.EvidenceParamsUpdate(ctx), "evidence parameters shouldn't change")
+	})
+	t.Run("happy path - apply changes", func(t *testing.T) {
+		require := require.New(t)
+
+		res, err := app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "changing consensus parameters should succeed")
+		require.Equal(struct{}{}, res)
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(interval, state.VRFParameters.Interval, "consensus parameters should change")
+		require.Equal(params.VRFParameters.ProofSubmissionDelay, state.VRFParameters.ProofSubmissionDelay, "other parameters shouldn't change")
+
+		evParams := abciAPI.EvidenceParamsUpdate(ctx)
+		require.NotNil(evParams, "evidence parameters should change")
+		require.EqualValues(2*interval, evParams.MaxAgeNumBlocks, "evidence should be accepted for the whole debonding interval")
+	})
+	t.Run("invalid interval", func(t *testing.T) {
+		require := require.New(t)
+
+		interval := int64(10)
+		proposal := governance.ChangeParametersProposal{
+			Module: beacon.ModuleName,
+			Changes: cbor.Marshal(beacon.ConsensusParameterChanges{
+				Interval: &interval,
+			}),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "beacon: failed to validate consensus parameters: submission delay must be < epoch interval")
+	})
+	t.Run("invalid proposal", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := app.changeParameters(ctx, "proposal", true)
+		require.EqualError(err, "beacon: failed to type assert change parameters proposal")
+	})
+	t.Run("different module", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module: "module",
+		}
+		res, err := app.changeParameters(ctx, &proposal, true)
+		require.Nil(res, "changes for other modules should be ignored")
+		require.NoError(err, "changes for other modules should be ignored without error")
+	})
+	t.Run("empty changes", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.ChangeParametersProposal{
+			Module: beacon.ModuleName,
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "beacon: failed to validate consensus parameter changes: consensus parameter changes should not be empty")
+	})
+	t.Run("insecure backend", func(t *testing.T) {
+		require := require.New(t)
+
+		err := state.SetConsensusParameters(ctx, &beacon.ConsensusParameters{
+			Backend: beacon.BackendInsecure,
+			InsecureParameters: &beacon.InsecureParameters{
+				Interval: 100,
+			},
+		})
+		require.NoError(err, "setting consensus parameters should succeed")
+
+		_, err = app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "beacon: failed to apply consensus parameter changes: VRF parameter changes are only supported by the VRF backend")
+	})
+	t.Run("external entropy", func(t *testing.T) {
+		require := require.New(t)
+
+		submitter := signature.NewPublicKey("1234567890000000000000000000000000000000000000000000000000000000")
+		eeParams := &beacon.ExternalEntropyParameters{
+			Submitters: []signature.PublicKey{submitter},
+		}
+		proposal := governance.ChangeParametersProposal{
+			Module: beacon.ModuleName,
+			Changes: cbor.Marshal(beacon.ConsensusParameterChanges{
+				ExternalEntropy: eeParams,
+			}),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "enabling external entropy should succeed")
+
+		state, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.Equal(eeParams, state.ExternalEntropy, "external entropy should be enabled")
+		require.Equal(beacon.BackendInsecure, state.Backend, "other parameters shouldn't change")
+
+		proposal.Changes = cbor.Marshal(beacon.ConsensusParameterChanges{
+			ExternalEntropy: &beacon.ExternalEntropyParameters{},
+		})
+		_, err = app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "beacon: failed to validate consensus parameter changes: external entropy: external entropy enabled without submitters")
+	})
+}
+
+func TestChangeIntervalAcrossEpochBoundary(t *testing.T) {
+	require := require.New(t)
+
+	// The change is applied in the block preceding the epoch transition block.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: 998,
+		Genesis:     newTestGenesis(),
+	})
+	initCtx := appState.NewContext(abciAPI.ContextInitChain)
+	defer initCtx.Close()
+	err := abciState.NewMutableState(initCtx.State()).SetChainContext(initCtx, "test chain context")
+	require.NoError(err, "SetChainContext")
+
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	err = stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 2,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	state := beaconState.NewMutableState(ctx.State())
+	app := &beaconApplication{
+		state: appState,
+	}
+	err = state.SetConsensusParameters(ctx, &beacon.ConsensusParameters{
+		Backend: beacon.BackendVRF,
+		VRFParameters: &beacon.VRFParameters{
+			AlphaHighQualityThreshold: 1,
+			Interval:                  100,
+			ProofSubmissionDelay:      20,
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = state.SetEpoch(ctx, 9, 900)
+	require.NoError(err, "SetEpoch")
+	err = state.SetFutureEpoch(ctx, 10, 1000)
+	require.NoError(err, "SetFutureEpoch")
+	err = state.SetVRFState(ctx, &beacon.VRFState{Epoch: 9, SubmitAfter: 920})
+	require.NoError(err, "SetVRFState")
+
+	// Increase the interval.
+	interval := int64(300)
+	proposal := governance.ChangeParametersProposal{
+		Module: beacon.ModuleName,
+		Changes: cbor.Marshal(beacon.ConsensusParameterChanges{
+			Interval: &interval,
+		}),
+	}
+	_, err = app.changeParameters(ctx, &proposal, true)
+	require.NoError(err, "changeParameters")
+
+	evParams := abciAPI.EvidenceParamsUpdate(ctx)
+	require.NotNil(evParams, "evidence parameters should be updated")
+	require.Equal(abciAPI.EvidenceParams(&newTestGenesis().Consensus.Parameters, 2, interval).MaxAgeNumBlocks, evParams.MaxAgeNumBlocks)
+	require.EqualValues(1024, evParams.MaxBytes)
+	require.Equal(time.Duration(evParams.MaxAgeNumBlocks)*2*time.Second, evParams.MaxAgeDuration)
+
+	// The already scheduled epoch transition still uses the old interval, while the
+	// following one uses the new interval.
+	appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: 999,
+		Genesis:     newTestGenesis(),
+	})
+	bbCtx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer bbCtx.Close()
+
+	err = app.BeginBlock(bbCtx)
+	require.NoError(err, "BeginBlock")
+	epoch, height, err := state.GetEpoch(bbCtx)
+	require.NoError(err, "GetEpoch")
+	require.EqualValues(10, epoch, "epoch should transition at the previously scheduled height")
+	require.EqualValues(1000, height, "epoch should transition at the previously scheduled height")
+	future, err := state.GetFutureEpoch(bbCtx)
+	require.NoError(err, "GetFutureEpoch")
+	require.EqualValues(1000+interval, future.Height, "next epoch transition should use the new interval")
+
+	// Evidence from the first block of the new epoch must be accepted until the debonding
+	// interval, which now consists of longer epochs, passes.
+	debondingEnd := future.Height + interval
+	require.GreaterOrEqual(evParams.MaxAgeNumBlocks, debondingEnd-height, "evidence should not expire before debonding ends")
+
+	// Decreasing the interval should not decrease the evidence parameters.
+	ctx2 := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx2.Close()
+	abciAPI.SetEvidenceParamsUpdate(ctx2, nil)
+
+	interval = 50
+	proposal.Changes = cbor.Marshal(beacon.ConsensusParameterChanges{
+		Interval: &interval,
+	})
+	_, err = app.changeParameters(ctx2, &proposal, true)
+	require.NoError(err, "changeParameters")
+	require.Nil(abciAPI.EvidenceParamsUpdate(ctx2), "evidence parameters should not be decreased")
+
+	// Increasing the interval by less than the previous decrease should not decrease the
+	// evidence parameters either.
+	ctx3 := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx3.Close()
+	abciAPI.SetEvidenceParamsUpdate(ctx3, nil)
+
+	interval = 200
+	proposal.Changes = cbor.Marshal(beacon.ConsensusParameterChanges{
+		Interval: &interval,
+	})
+	_, err = app.changeParameters(ctx3, &proposal, true)
+	require.NoError(err, "changeParameters")
+	require.Nil(abciAPI.EvidenceParamsUpdate(ctx3), "evidence parameters should not be decreased")
+
+	// Increasing the interval beyond the largest one should increase the evidence parameters.
+	ctx4 := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx4.Close()
+	abciAPI.SetEvidenceParamsUpdate(ctx4, nil)
+
+	interval = 400
+	proposal.Changes = cbor.Marshal(beacon.ConsensusParameterChanges{
+		Interval: &interval,
+	})
+	_, err = app.changeParameters(ctx4, &proposal, true)
+	require.NoError(err, "changeParameters")
+	evParams = abciAPI.EvidenceParamsUpdate(ctx4)
+	require.NotNil(evParams, "evidence parameters should be updated")
+	require.EqualValues(2*interval, evParams.MaxAgeNumBlocks)
+}
@@ -34,6 +34,11 @@ var (
 	//
 	// Value is CBOR-serialized beacon.ConsensusParameters.
 	parametersKeyFmt = consensus.KeyFormat.New(0x43)
+	// evidenceEpochIntervalKeyFmt is the key format used for the largest epoch interval that
+	// the evidence parameters have been configured for.
+	//
+	// Value is CBOR-serialized epoch interval.
+	evidenceEpochIntervalKeyFmt = consensus.KeyFormat.New(0x48)
 )
 
 // ImmutableState is the immutable beacon state wrapper.
@@ -110,6 +115,24 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*beacon.Conse
 	return &params, nil
 }
 
+// EvidenceEpochInterval returns the largest epoch interval that the evidence parameters have
+// been configured for. Zero is returned if the interval has never been changed.
+func (s *ImmutableState) EvidenceEpochInterval(ctx context.Context) (int64, error) {
+	data, err := s.is.Get(ctx, evidenceEpochIntervalKeyFmt.Encode())
+	if err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return 0, nil
+	}
+
+	var interval int64
+	if err = cbor.Unmarshal(data, &interval); err != nil {
+		return 0, abciAPI.UnavailableStateError(err)
+	}
+	return interval, nil
+}
+
 func (s *ImmutableState) PendingMockEpoch(ctx context.Context) (*beacon.EpochTime, error) {
 	data, err := s.is.Get(ctx, epochPendingMockKeyFmt.Encode())
 	if err != nil {
@@ -196,6 +219,13 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *beaco
 	return abciAPI.UnavailableStateError(err)
 }
 
+// SetEvidenceEpochInterval sets the largest epoch interval that the evidence parameters have
+// been configured for.
+func (s *MutableState) SetEvidenceEpochInterval(ctx context.Context, interval int64) error {
+	err := s.ms.Insert(ctx, evidenceEpochIntervalKeyFmt.Encode(), cbor.Marshal(interval))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable beacon state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
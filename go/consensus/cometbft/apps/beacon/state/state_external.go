@@ -0,0 +1,44 @@
+package state
+
+import (
+	"context"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// externalEntropyKeyFmt is the external entropy state key format.
+//
+// Value is CBOR-serialized external entropy state.
+var externalEntropyKeyFmt = consensus.KeyFormat.New(0x47)
+
+// ExternalEntropyState returns the external entropy submitted during the current epoch.
+func (s *ImmutableState) ExternalEntropyState(ctx context.Context) (*beacon.ExternalEntropyState, error) {
+	data, err := s.is.Get(ctx, externalEntropyKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var state beacon.ExternalEntropyState
+	if err = cbor.Unmarshal(data, &state); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &state, nil
+}
+
+// SetExternalEntropyState sets the external entropy state.
+func (s *MutableState) SetExternalEntropyState(ctx context.Context, state *beacon.ExternalEntropyState) error {
+	err := s.ms.Insert(ctx, externalEntropyKeyFmt.Encode(), cbor.Marshal(state))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// ClearExternalEntropyState clears the external entropy state.
+func (s *MutableState) ClearExternalEntropyState(ctx context.Context) error {
+	err := s.ms.Remove(ctx, externalEntropyKeyFmt.Encode())
+	return abciAPI.UnavailableStateError(err)
+}
@@ -13,3 +13,8 @@ var MessageChangeParameters = messageKind(0)
 // successful and with error otherwise. Other modules should ignore the message and return a nil
 // response.
 var MessageValidateParameterChanges = messageKind(1)
+
+// MessagePauseRuntime is the message kind for when the runtime pause proposal closes as accepted.
+// The message is the runtime pause proposal. The registry application should respond with an
+// empty struct if the runtime was paused or resumed and with error otherwise.
+var MessagePauseRuntime = messageKind(2)
@@ -303,6 +303,34 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 			ctx.Logger().Debug("governance: no module applied change parameters proposal")
 			return governance.ErrInvalidArgument
 		}
+	case proposal.Content.Signal != nil:
+		// Signal proposals have no on-chain effects, passing them only records the outcome.
+	case proposal.Content.PauseRuntime != nil:
+		// To not violate the consensus, runtime pause proposals should be ignored when disabled.
+		params, err := state.ConsensusParameters(ctx)
+		if err != nil {
+			ctx.Logger().Error("failed to query consensus parameters",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		if !params.EnablePauseRuntimeProposal {
+			ctx.Logger().Debug("runtime pause proposals are disabled")
+			return governance.ErrInvalidArgument
+		}
+
+		// Notify the registry application about the runtime pause proposal.
+		res, err := app.md.Publish(ctx, governanceApi.MessagePauseRuntime, proposal.Content.PauseRuntime)
+		if err != nil {
+			ctx.Logger().Debug("failed to dispatch runtime pause proposal message",
+				"err", err,
+			)
+			return err
+		}
+		if res == nil {
+			ctx.Logger().Debug("governance: no module applied runtime pause proposal")
+			return governance.ErrInvalidArgument
+		}
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -469,13 +497,15 @@ func (app *governanceApplication) closeProposal(
 		}
 	}
 
+	stakeThreshold := params.StakeThresholdFor(proposal.Content.Kind())
+
 	ctx.Logger().Debug("close proposal",
 		"total_voting_state", totalVotingStake,
 		"results", proposal.Results,
 		"invalid_votes", proposal.InvalidVotes,
-		"stake_threshold", params.StakeThreshold,
+		"stake_threshold", stakeThreshold,
 	)
-	return proposal.CloseProposal(totalVotingStake, params.StakeThreshold)
+	return proposal.CloseProposal(totalVotingStake, stakeThreshold)
 }
 
 func addShares(validatorVoteShares map[governance.Vote]quantity.Quantity, vote governance.Vote, amount quantity.Quantity) error {
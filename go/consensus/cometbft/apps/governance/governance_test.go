@@ -230,6 +230,11 @@ func TestCloseProposal(t *testing.T) {
 		VotingPeriod:              beacon.EpochTime(50),
 	}
 
+	kindConsParams := *baseConsParams
+	kindConsParams.StakeThresholds = map[governance.ProposalKind]uint8{
+		governance.ProposalKindSignal: 60,
+	}
+
 	baseValidatorEntitiesEscrow := map[staking.Address]*staking.SharePool{
 		addr1: {
 			Balance: *quantity.NewFromUint64(100),
@@ -430,6 +435,54 @@ func TestCloseProposal(t *testing.T) {
 				governance.VoteAbstain: *quantity.NewFromUint64(1),            // 1 share of addr1.
 			},
 		},
+		{
+			"should pass if proposal kind threshold reached",
+			&kindConsParams,
+			quantity.NewFromUint64(195),
+			baseValidatorEntitiesEscrow,
+			&governance.Proposal{
+				ID:    9,
+				State: governance.StateActive,
+				Content: governance.ProposalContent{
+					Signal: &governance.SignalProposal{},
+				},
+			},
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteYes},
+				{Voter: addr2, Vote: governance.VoteNo},
+				{Voter: addr3, Vote: governance.VoteYes},
+			},
+			governance.StatePassed,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(100 + 35), // 100% of addr1 shares + 100% addr3 shares.
+				governance.VoteNo:  *quantity.NewFromUint64(60),
+			},
+		},
+		{
+			"should be rejected if default threshold not reached for other proposal kinds",
+			&kindConsParams,
+			quantity.NewFromUint64(195),
+			baseValidatorEntitiesEscrow,
+			&governance.Proposal{
+				ID:    10,
+				State: governance.StateActive,
+				Content: governance.ProposalContent{
+					CancelUpgrade: &governance.CancelUpgradeProposal{},
+				},
+			},
+			[]*governance.VoteEntry{
+				{Voter: addr1, Vote: governance.VoteYes},
+				{Voter: addr2, Vote: governance.VoteNo},
+				{Voter: addr3, Vote: governance.VoteYes},
+			},
+			governance.StateRejected,
+			0,
+			map[governance.Vote]quantity.Quantity{
+				governance.VoteYes: *quantity.NewFromUint64(100 + 35), // 100% of addr1 shares + 100% addr3 shares.
+				governance.VoteNo:  *quantity.NewFromUint64(60),
+			},
+		},
 	} {
 		err = state.SetConsensusParameters(ctx, tc.params)
 		require.NoError(err, "setting governance consensus parameters should not error")
@@ -48,6 +48,12 @@ func (app *governanceApplication) submitProposal(
 	if proposalContent.ChangeParameters != nil && !params.EnableChangeParametersProposal {
 		return nil, governance.ErrInvalidArgument
 	}
+	if proposalContent.Signal != nil && !params.EnableSignalProposal {
+		return nil, governance.ErrInvalidArgument
+	}
+	if proposalContent.PauseRuntime != nil && !params.EnablePauseRuntimeProposal {
+		return nil, governance.ErrInvalidArgument
+	}
 
 	// Charge gas for this transaction.
 	if err = ctx.Gas().UseGas(1, governance.GasOpSubmitProposal, params.GasCosts); err != nil {
@@ -156,6 +162,18 @@ func (app *governanceApplication) submitProposal(
 			ctx.Logger().Debug("governance: no module interested in change parameters proposal")
 			return nil, governance.ErrInvalidArgument
 		}
+	case proposalContent.Signal != nil:
+		// Signal proposals are not executable, so no further validation is needed.
+	case proposalContent.PauseRuntime != nil:
+		// Ensure the runtime exists.
+		regState := registryState.NewMutableState(ctx.State())
+		if _, err = regState.AnyRuntime(ctx, proposalContent.PauseRuntime.RuntimeID); err != nil {
+			ctx.Logger().Debug("governance: runtime pause proposal for unknown runtime",
+				"runtime_id", proposalContent.PauseRuntime.RuntimeID,
+				"err", err,
+			)
+			return nil, governance.ErrInvalidArgument
+		}
 	default:
 		return nil, governance.ErrInvalidArgument
 	}
@@ -151,7 +151,7 @@ func suspendRuntimes(ctx *tmapi.Context) error {
 			"account", *acctAddr,
 		)
 
-		if err := regState.SuspendRuntime(ctx, rt.ID); err != nil {
+		if err := regState.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonInsufficientStake); err != nil {
 			return err
 		}
 	}
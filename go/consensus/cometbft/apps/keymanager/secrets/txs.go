@@ -50,6 +50,13 @@ func (ext *secretsExt) updatePolicy(
 	if err = secrets.SanityCheckSignedPolicySGX(oldStatus.Policy, sigPol); err != nil {
 		return err
 	}
+	kmParams, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if err = sigPol.Policy.VerifyFeatures(kmParams); err != nil {
+		return fmt.Errorf("%w: %s", secrets.ErrInvalidArgument, err)
+	}
 
 	// Return early if this is a CheckTx context.
 	if ctx.IsCheckOnly() {
@@ -57,10 +64,6 @@ func (ext *secretsExt) updatePolicy(
 	}
 
 	// Charge gas for this operation.
-	kmParams, err := state.ConsensusParameters(ctx)
-	if err != nil {
-		return err
-	}
 	if err = ctx.Gas().UseGas(1, secrets.GasOpUpdatePolicy, kmParams.GasCosts); err != nil {
 		return err
 	}
@@ -148,6 +151,11 @@ func (ext *secretsExt) publishMasterSecret(
 		return fmt.Errorf("keymanager: master secret can be published only by the key manager committee")
 	}
 
+	// Reject if the signer is not authorized by the policy to generate master secrets.
+	if !kmStatus.IsMasterSecretGenerator(ctx.TxSigner()) {
+		return fmt.Errorf("keymanager: master secret can be published only by designated generators")
+	}
+
 	// Reject if the master secret has been proposed in this epoch.
 	lastSecret, err := state.MasterSecret(ctx, secret.Secret.ID)
 	if err != nil && err != secrets.ErrNoSuchMasterSecret {
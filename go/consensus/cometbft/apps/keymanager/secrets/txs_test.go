@@ -15,6 +15,7 @@ import (
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
@@ -259,3 +260,94 @@ func TestPublishEphemeralSecret(t *testing.T) {
 		require.EqualError(t, err, "keymanager: ephemeral secret can be proposed once per epoch")
 	})
 }
+
+func TestUpdatePolicy(t *testing.T) {
+	// Prepare key manager app.
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ext := secretsExt{
+		state: appState,
+	}
+
+	// Prepare abci contexts.
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+
+	// Prepare states.
+	kmState := secretsState.NewMutableState(ctx.State())
+	regState := registryState.NewMutableState(ctx.State())
+
+	// Set up consensus parameters.
+	err := kmState.SetConsensusParameters(ctx, &secrets.ConsensusParameters{})
+	require.NoError(t, err, "api.SetConsensusParameters")
+	err = regState.SetConsensusParameters(ctx, &registryAPI.ConsensusParameters{})
+	require.NoError(t, err, "registry.SetConsensusParameters")
+
+	// Register a key manager runtime.
+	entitySigner := memorySigner.NewTestSigner("entity signer")
+	var kmID common.Namespace
+	err = kmID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err, "failed to unmarshal keymanager id")
+	kmRt := registryAPI.Runtime{
+		ID:          kmID,
+		EntityID:    entitySigner.Public(),
+		Kind:        registryAPI.KindKeyManager,
+		TEEHardware: node.TEEHardwareIntelSGX,
+	}
+	err = regState.SetRuntime(ctx, &kmRt, false)
+	require.NoError(t, err, "registry.SetRuntime")
+
+	txCtx.SetTxSigner(entitySigner.Public())
+
+	t.Run("master secret generators", func(t *testing.T) {
+		sigPol := &secrets.SignedPolicySGX{
+			Policy: secrets.PolicySGX{
+				Serial:                 1,
+				ID:                     kmID,
+				MasterSecretGenerators: []signature.PublicKey{memorySigner.NewTestSigner("node signer").Public()},
+			},
+		}
+
+		// Policies designating generators should be rejected unless enabled.
+		err = ext.updatePolicy(txCtx, kmState, sigPol)
+		require.ErrorIs(t, err, secrets.ErrInvalidArgument, "designating generators should fail when disabled")
+
+		err = kmState.SetConsensusParameters(ctx, &secrets.ConsensusParameters{
+			EnableMasterSecretGenerators: true,
+		})
+		require.NoError(t, err, "api.SetConsensusParameters")
+
+		err = ext.updatePolicy(txCtx, kmState, sigPol)
+		require.NoError(t, err, "designating generators should succeed when enabled")
+	})
+	t.Run("key derivation contexts", func(t *testing.T) {
+		var enclave sgx.EnclaveIdentity
+		sigPol := &secrets.SignedPolicySGX{
+			Policy: secrets.PolicySGX{
+				Serial: 2,
+				ID:     kmID,
+				Enclaves: map[sgx.EnclaveIdentity]*secrets.EnclavePolicySGX{
+					enclave: {
+						MayQueryContexts: map[sgx.EnclaveIdentity][]string{
+							enclave: {"context"},
+						},
+					},
+				},
+			},
+		}
+
+		// Policies restricting contexts should be rejected unless enabled.
+		err = ext.updatePolicy(txCtx, kmState, sigPol)
+		require.ErrorIs(t, err, secrets.ErrInvalidArgument, "restricting contexts should fail when disabled")
+
+		err = kmState.SetConsensusParameters(ctx, &secrets.ConsensusParameters{
+			EnableKeyDerivationContexts: true,
+		})
+		require.NoError(t, err, "api.SetConsensusParameters")
+
+		err = ext.updatePolicy(txCtx, kmState, sigPol)
+		require.NoError(t, err, "restricting contexts should succeed when enabled")
+	})
+}
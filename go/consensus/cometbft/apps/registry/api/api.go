@@ -20,4 +20,13 @@ var (
 	// MessageRuntimeResumed is the message kind for suspended runtime resumptions. The message is
 	// the runtime descriptor of the runtime that has been resumed.
 	MessageRuntimeResumed = messageKind(2)
+
+	// MessageEntityDeregistered is the message kind for entity deregistrations. The message is
+	// the entity descriptor of the entity that has been deregistered. Any errors returned from the
+	// handler will prevent the entity deregistration from taking place.
+	MessageEntityDeregistered = messageKind(3)
+
+	// MessageRuntimePaused is the message kind for explicit runtime pauses. The message is the
+	// runtime descriptor of the runtime that has been paused.
+	MessageRuntimePaused = messageKind(4)
 )
@@ -88,10 +88,24 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, _ types.RequestI
 			)
 			return fmt.Errorf("registry: genesis suspended runtime registration failure: %w", err)
 		}
-		if err := state.SuspendRuntime(ctx, rt.ID); err != nil {
+		if err := state.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonUnknown); err != nil {
 			return fmt.Errorf("registry: failed to suspend runtime at genesis: %w", err)
 		}
 	}
+	for id, status := range st.RuntimeSuspensions {
+		if status == nil {
+			return fmt.Errorf("registry: genesis suspension status of runtime %s is nil", id)
+		}
+		if _, err := state.AnyRuntime(ctx, id); err != nil {
+			return fmt.Errorf("registry: genesis suspension status of unknown runtime %s: %w", id, err)
+		}
+		if err := state.SetRuntimeSuspension(ctx, id, status); err != nil {
+			ctx.Logger().Error("InitChain: failed to set runtime suspension status",
+				"err", err,
+			)
+			return fmt.Errorf("registry: genesis runtime suspension status set failure: %w", err)
+		}
+	}
 	for i, v := range st.Nodes {
 		if v == nil {
 			return fmt.Errorf("registry: genesis node index %d is nil", i)
@@ -139,6 +153,10 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 	if err != nil {
 		return nil, err
 	}
+	runtimeSuspensions, err := rq.state.RuntimeSuspensions(ctx)
+	if err != nil {
+		return nil, err
+	}
 	signedNodes, err := rq.state.SignedNodes(ctx)
 	if err != nil {
 		return nil, err
@@ -176,12 +194,13 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 	}
 
 	gen := registry.Genesis{
-		Parameters:        *params,
-		Entities:          signedEntities,
-		Runtimes:          runtimes,
-		SuspendedRuntimes: suspendedRuntimes,
-		Nodes:             validatorNodes,
-		NodeStatuses:      nodeStatuses,
+		Parameters:         *params,
+		Entities:           signedEntities,
+		Runtimes:           runtimes,
+		SuspendedRuntimes:  suspendedRuntimes,
+		RuntimeSuspensions: runtimeSuspensions,
+		Nodes:              validatorNodes,
+		NodeStatuses:       nodeStatuses,
 	}
 	return &gen, nil
 }
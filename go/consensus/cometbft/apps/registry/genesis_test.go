@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	requirePkg "github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestGenesisRuntimeSuspensions(t *testing.T) {
+	require := requirePkg.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	state := registryState.NewMutableState(ctx.State())
+	params := &registry.ConsensusParameters{
+		EnableRuntimeSuspensionHistory: true,
+	}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: genesis signer")
+	rt := &registry.Runtime{
+		ID:       common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: genesis runtime"), 0),
+		EntityID: entitySigner.Public(),
+	}
+	err = state.SetRuntime(ctx, rt, false)
+	require.NoError(err, "SetRuntime")
+	err = state.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonInsufficientStake)
+	require.NoError(err, "SuspendRuntime")
+
+	// Suspension statuses should be exported.
+	q := registryQuerier{state: state.ImmutableState}
+	gen, err := q.Genesis(ctx)
+	require.NoError(err, "Genesis")
+	require.Len(gen.SuspendedRuntimes, 1, "suspended runtime should be exported")
+	require.Len(gen.RuntimeSuspensions, 1, "suspension status should be exported")
+	status := gen.RuntimeSuspensions[rt.ID]
+	require.NotNil(status, "suspension status should be exported")
+	require.Len(status.History, 1)
+	require.Equal(registry.SuspensionReasonInsufficientStake, status.Current().Reason)
+
+	// Suspension statuses should be imported.
+	app := registryApplication{appState, &abciAPI.NoopMessageDispatcher{}}
+	initCtx := appState.NewContext(abciAPI.ContextInitChain)
+	defer initCtx.Close()
+
+	// Runtimes are registered in InitChain before suspension statuses are set, so set it up
+	// directly as runtime registration requires much more state.
+	initState := registryState.NewMutableState(initCtx.State())
+	err = initState.SetRuntime(initCtx, rt, true)
+	require.NoError(err, "SetRuntime")
+
+	doc := &genesis.Document{
+		Registry: registry.Genesis{
+			Parameters:         *params,
+			RuntimeSuspensions: gen.RuntimeSuspensions,
+		},
+	}
+	err = app.InitChain(initCtx, types.RequestInitChain{}, doc)
+	require.NoError(err, "InitChain")
+
+	imported, err := initState.RuntimeSuspension(initCtx, rt.ID)
+	require.NoError(err, "RuntimeSuspension")
+	require.Equal(status, imported, "suspension status should be imported")
+
+	// Suspension statuses of unknown runtimes should be rejected.
+	doc.Registry.RuntimeSuspensions = map[common.Namespace]*registry.RuntimeSuspensionStatus{
+		{1}: status,
+	}
+	err = app.InitChain(initCtx, types.RequestInitChain{}, doc)
+	require.Error(err, "InitChain should fail for unknown runtimes")
+}
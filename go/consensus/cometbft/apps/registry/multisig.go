@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+var _ api.MultisigAuthHandler = (*registryApplication)(nil)
+
+// Implements api.MultisigAuthHandler.
+func (app *registryApplication) AuthorizeMultisigTx(ctx *api.Context, id signature.PublicKey, signers []signature.PublicKey) error {
+	state := registryState.NewMutableState(ctx.State())
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.EnableEntityMultisig {
+		return transaction.ErrMultisigNotSupported
+	}
+
+	ent, err := state.Entity(ctx, id)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchEntity:
+		return fmt.Errorf("%w: entity not registered", registry.ErrMultisigUnauthorized)
+	default:
+		return err
+	}
+
+	if ent.Multisig == nil {
+		return fmt.Errorf("%w: entity has no multisig policy", registry.ErrMultisigUnauthorized)
+	}
+	if err = ent.Multisig.Verify(signers); err != nil {
+		return fmt.Errorf("%w: %w", registry.ErrMultisigUnauthorized, err)
+	}
+	return nil
+}
@@ -21,8 +21,10 @@ type Query interface {
 	NodeByConsensusAddress(context.Context, []byte) (*node.Node, error)
 	NodeStatus(context.Context, signature.PublicKey) (*registry.NodeStatus, error)
 	Nodes(context.Context) ([]*node.Node, error)
+	NodeExpirations(context.Context) ([]*registry.NodeExpirationEvent, error)
 	Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error)
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
+	RuntimeSuspension(context.Context, common.Namespace) (*registry.RuntimeSuspensionStatus, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 	ConsensusParameters(context.Context) (*registry.ConsensusParameters, error)
 }
@@ -103,6 +105,39 @@ func (rq *registryQuerier) Nodes(ctx context.Context) ([]*node.Node, error) {
 	return filteredNodes, nil
 }
 
+func (rq *registryQuerier) NodeExpirations(ctx context.Context) ([]*registry.NodeExpirationEvent, error) {
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	nodes, err := rq.state.Nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*registry.NodeExpirationEvent
+	for _, n := range nodes {
+		switch {
+		case n.IsExpired(uint64(epoch)):
+			// Only notify about nodes that expired in this epoch as expired nodes are kept
+			// around for the debonding period.
+			if n.Expiration+1 != uint64(epoch) {
+				continue
+			}
+		case n.Expiration-uint64(epoch) >= registry.NodeExpirationNoticeEpochs:
+			continue
+		}
+
+		events = append(events, &registry.NodeExpirationEvent{
+			Node:    n,
+			Epoch:   epoch,
+			Expired: n.IsExpired(uint64(epoch)),
+		})
+	}
+	return events, nil
+}
+
 func (rq *registryQuerier) Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error) {
 	if includeSuspended {
 		return rq.state.AnyRuntime(ctx, id)
@@ -117,6 +152,10 @@ func (rq *registryQuerier) Runtimes(ctx context.Context, includeSuspended bool)
 	return rq.state.Runtimes(ctx)
 }
 
+func (rq *registryQuerier) RuntimeSuspension(ctx context.Context, id common.Namespace) (*registry.RuntimeSuspensionStatus, error) {
+	return rq.state.RuntimeSuspension(ctx, id)
+}
+
 func (rq *registryQuerier) ConsensusParameters(ctx context.Context) (*registry.ConsensusParameters, error) {
 	return rq.state.ConsensusParameters(ctx)
 }
@@ -18,6 +18,7 @@ import (
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	stakingapp "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -58,6 +59,7 @@ func (app *registryApplication) OnRegister(state api.ApplicationState, md api.Me
 	md.Subscribe(roothashApi.RuntimeMessageRegistry, app)
 	md.Subscribe(governanceApi.MessageChangeParameters, app)
 	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
+	md.Subscribe(governanceApi.MessagePauseRuntime, app)
 }
 
 func (app *registryApplication) OnCleanup() {
@@ -79,6 +81,9 @@ func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg inter
 		case m.UpdateRuntime != nil:
 			state := registryState.NewMutableState(ctx.State())
 			return app.registerRuntime(ctx, state, m.UpdateRuntime)
+		case m.UpdateRuntimeParameters != nil:
+			state := registryState.NewMutableState(ctx.State())
+			return app.updateRuntimeParameters(ctx, state, m.UpdateRuntimeParameters)
 		default:
 			return nil, registry.ErrInvalidArgument
 		}
@@ -89,6 +94,18 @@ func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg inter
 		// A change parameters proposal has just been accepted and closed. Validate and apply
 		// changes.
 		return app.changeParameters(ctx, msg, true)
+	case governanceApi.MessagePauseRuntime:
+		// A runtime pause proposal has just been accepted and closed. Pause or resume the runtime.
+		proposal := msg.(*governance.PauseRuntimeProposal)
+		state := registryState.NewMutableState(ctx.State())
+		rt, err := state.AnyRuntime(ctx, proposal.RuntimeID)
+		if err != nil {
+			return nil, err
+		}
+		if err = app.setRuntimePaused(ctx, state, rt, proposal.Resume, registry.PauseAuthorityGovernance); err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
 	default:
 		return nil, registry.ErrInvalidArgument
 	}
@@ -148,6 +165,13 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 		return nil
 
+	case registry.MethodPauseRuntime, registry.MethodResumeRuntime:
+		var req registry.PauseRuntime
+		if err := cbor.Unmarshal(tx.Body, &req); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.pauseRuntime(ctx, state, &req, tx.Method == registry.MethodResumeRuntime)
+
 	default:
 		return registry.ErrInvalidArgument
 	}
@@ -249,6 +273,10 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		}
 	}
 
+	if err = app.applyKeyManagerChanges(ctx, regState, registryEpoch); err != nil {
+		return fmt.Errorf("registry: onRegistryEpochChanged: %w", err)
+	}
+
 	// Emit the expired node event for all expired nodes.
 	for _, expiredNode := range expiredNodes {
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.NodeEvent{Node: expiredNode, IsRegistration: false}))
@@ -259,6 +287,56 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 	return nil
 }
 
+// applyKeyManagerChanges switches compute runtimes with scheduled key manager changes that
+// became active in the given epoch to their new key managers.
+//
+// Scheduled key manager changes are not applied while they are disabled.
+func (app *registryApplication) applyKeyManagerChanges(ctx *api.Context, state *registryState.MutableState, epoch beacon.EpochTime) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if !params.EnableKeyManagerChange {
+		return nil
+	}
+
+	for _, suspended := range []bool{false, true} {
+		fetchRuntimes := state.Runtimes
+		if suspended {
+			fetchRuntimes = state.SuspendedRuntimes
+		}
+		runtimes, err := fetchRuntimes(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get runtimes: %w", err)
+		}
+
+		for _, rt := range runtimes {
+			kmc := rt.KeyManagerChange
+			if kmc == nil || kmc.Epoch > epoch {
+				continue
+			}
+
+			ctx.Logger().Info("switching runtime to new key manager",
+				"runtime_id", rt.ID,
+				"old_key_manager", rt.KeyManager,
+				"new_key_manager", kmc.KeyManager,
+				"epoch", epoch,
+			)
+
+			rt.KeyManager = &kmc.KeyManager
+			rt.KeyManagerChange = nil
+			if err = state.SetRuntime(ctx, rt, suspended); err != nil {
+				return fmt.Errorf("failed to set runtime: %w", err)
+			}
+
+			if !suspended {
+				ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.RuntimeStartedEvent{Runtime: rt}))
+			}
+		}
+	}
+	return nil
+}
+
 // New constructs a new registry application instance.
 func New() api.Application {
 	return &registryApplication{}
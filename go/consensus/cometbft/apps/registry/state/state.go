@@ -76,6 +76,11 @@ var (
 	//
 	// Value is empty.
 	runtimeByEntityKeyFmt = consensus.KeyFormat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// runtimeSuspensionKeyFmt is the key format used for runtime suspension
+	// statuses.
+	//
+	// Value is CBOR-serialized runtime suspension status.
+	runtimeSuspensionKeyFmt = consensus.KeyFormat.New(0x1a, keyformat.H(&common.Namespace{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -307,6 +312,73 @@ func (s *ImmutableState) AnyRuntime(ctx context.Context, id common.Namespace) (r
 	return
 }
 
+// RuntimeSuspension returns the suspension status of a registered runtime.
+func (s *ImmutableState) RuntimeSuspension(ctx context.Context, id common.Namespace) (*registry.RuntimeSuspensionStatus, error) {
+	_, err := s.Runtime(ctx, id)
+	var suspended bool
+	switch err {
+	case nil:
+	case registry.ErrNoSuchRuntime:
+		if _, err = s.SuspendedRuntime(ctx, id); err != nil {
+			return nil, err
+		}
+		suspended = true
+	default:
+		return nil, err
+	}
+
+	status, err := s.runtimeSuspension(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Runtimes suspended before suspension statuses were tracked have no
+	// suspension records, so make sure the status reflects the actual state.
+	status.Suspended = suspended
+	return status, nil
+}
+
+func (s *ImmutableState) runtimeSuspension(ctx context.Context, id common.Namespace) (*registry.RuntimeSuspensionStatus, error) {
+	value, err := s.is.Get(ctx, runtimeSuspensionKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	var status registry.RuntimeSuspensionStatus
+	if value == nil {
+		return &status, nil
+	}
+	if err = cbor.Unmarshal(value, &status); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &status, nil
+}
+
+// RuntimeSuspensions returns the suspension statuses of all runtimes that have any.
+func (s *ImmutableState) RuntimeSuspensions(ctx context.Context) (map[common.Namespace]*registry.RuntimeSuspensionStatus, error) {
+	suspensions := make(map[common.Namespace]*registry.RuntimeSuspensionStatus)
+	for _, keyFmt := range []*keyformat.KeyFormat{runtimeKeyFmt, suspendedRuntimeKeyFmt} {
+		err := s.iterateRuntimes(ctx, keyFmt, func(rt *registry.Runtime) error {
+			value, err := s.is.Get(ctx, runtimeSuspensionKeyFmt.Encode(&rt.ID))
+			if err != nil {
+				return abciAPI.UnavailableStateError(err)
+			}
+			if value == nil {
+				return nil
+			}
+
+			var status registry.RuntimeSuspensionStatus
+			if err = cbor.Unmarshal(value, &status); err != nil {
+				return abciAPI.UnavailableStateError(err)
+			}
+			suspensions[rt.ID] = &status
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return suspensions, nil
+}
+
 func (s *ImmutableState) iterateRuntimes(
 	ctx context.Context,
 	keyFmt *keyformat.KeyFormat,
@@ -661,7 +733,7 @@ func (s *MutableState) SetRuntime(ctx context.Context, rt *registry.Runtime, sus
 }
 
 // SuspendRuntime marks a runtime as suspended.
-func (s *MutableState) SuspendRuntime(ctx *abciAPI.Context, id common.Namespace) error {
+func (s *MutableState) SuspendRuntime(ctx *abciAPI.Context, id common.Namespace, reason registry.RuntimeSuspensionReason) error {
 	data, err := s.ms.RemoveExisting(ctx, runtimeKeyFmt.Encode(&id))
 	if err != nil {
 		return abciAPI.UnavailableStateError(err)
@@ -670,15 +742,73 @@ func (s *MutableState) SuspendRuntime(ctx *abciAPI.Context, id common.Namespace)
 		return registry.ErrNoSuchRuntime
 	}
 
+	if err = s.updateRuntimeSuspensionHistory(ctx, id, func(status *registry.RuntimeSuspensionStatus) bool {
+		status.RecordSuspension(reason, ctx.BlockHeight())
+		return reason == registry.SuspensionReasonPaused
+	}); err != nil {
+		return err
+	}
+
 	if !ctx.IsCheckOnly() {
-		ctx.EmitEvent(api.NewEventBuilder(AppName).TypedAttribute(&registry.RuntimeSuspendedEvent{RuntimeID: id}))
+		ctx.EmitEvent(api.NewEventBuilder(AppName).TypedAttribute(&registry.RuntimeSuspendedEvent{
+			RuntimeID: id,
+			Reason:    reason,
+		}))
 	}
 	err = s.ms.Insert(ctx, suspendedRuntimeKeyFmt.Encode(&id), data)
 	return abciAPI.UnavailableStateError(err)
 }
 
+// PauseRuntime marks a runtime as explicitly paused by the given authority.
+//
+// If the runtime is not suspended, it is suspended. If the runtime is already suspended for a
+// different reason, it remains suspended for that reason and is additionally marked as paused.
+// A runtime paused by its owner can be taken over by governance, but not vice versa.
+func (s *MutableState) PauseRuntime(ctx *abciAPI.Context, id common.Namespace, authority registry.RuntimePauseAuthority) error {
+	status, err := s.RuntimeSuspension(ctx, id)
+	if err != nil {
+		return err
+	}
+	if status.IsPaused() && (status.Pause.Authority == authority || status.Pause.Authority == registry.PauseAuthorityGovernance) {
+		return nil
+	}
+	if !status.Suspended {
+		if err = s.SuspendRuntime(ctx, id, registry.SuspensionReasonPaused); err != nil {
+			return err
+		}
+		if status, err = s.runtimeSuspension(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	status.Pause = &registry.RuntimePause{
+		Authority: authority,
+		PausedAt:  ctx.BlockHeight(),
+	}
+	return s.SetRuntimeSuspension(ctx, id, status)
+}
+
+// UnpauseRuntime clears the explicit pause of a runtime paused by the given authority.
+//
+// The runtime remains suspended and needs to be resumed separately.
+func (s *MutableState) UnpauseRuntime(ctx *abciAPI.Context, id common.Namespace, authority registry.RuntimePauseAuthority) error {
+	status, err := s.RuntimeSuspension(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !status.IsPaused() {
+		return registry.ErrRuntimeNotPaused
+	}
+	if status.Pause.Authority != authority {
+		return registry.ErrForbidden
+	}
+
+	status.Pause = nil
+	return s.SetRuntimeSuspension(ctx, id, status)
+}
+
 // ResumeRuntime resumes a previously suspended runtime.
-func (s *MutableState) ResumeRuntime(ctx context.Context, id common.Namespace) error {
+func (s *MutableState) ResumeRuntime(ctx *abciAPI.Context, id common.Namespace) error {
 	data, err := s.ms.RemoveExisting(ctx, suspendedRuntimeKeyFmt.Encode(&id))
 	if err != nil {
 		return abciAPI.UnavailableStateError(err)
@@ -686,10 +816,49 @@ func (s *MutableState) ResumeRuntime(ctx context.Context, id common.Namespace) e
 	if data == nil {
 		return registry.ErrNoSuchRuntime
 	}
+
+	if err = s.updateRuntimeSuspensionHistory(ctx, id, func(status *registry.RuntimeSuspensionStatus) bool {
+		current := status.Current()
+		status.RecordResumption(ctx.BlockHeight())
+		return current != nil && current.Reason == registry.SuspensionReasonPaused
+	}); err != nil {
+		return err
+	}
+
+	if !ctx.IsCheckOnly() {
+		ctx.EmitEvent(api.NewEventBuilder(AppName).TypedAttribute(&registry.RuntimeResumedEvent{RuntimeID: id}))
+	}
 	err = s.ms.Insert(ctx, runtimeKeyFmt.Encode(&id), data)
 	return abciAPI.UnavailableStateError(err)
 }
 
+// updateRuntimeSuspensionHistory applies the given update to the suspension status of a runtime.
+//
+// The updated status is only persisted if the runtime suspension history is enabled or if the
+// update returns true, which is the case for suspensions due to explicit pauses as resuming
+// paused runtimes depends on them.
+func (s *MutableState) updateRuntimeSuspensionHistory(ctx context.Context, id common.Namespace, fn func(*registry.RuntimeSuspensionStatus) bool) error {
+	params, err := s.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := s.runtimeSuspension(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !fn(status) && !params.EnableRuntimeSuspensionHistory {
+		return nil
+	}
+	return s.SetRuntimeSuspension(ctx, id, status)
+}
+
+// SetRuntimeSuspension sets the suspension status of a runtime.
+func (s *MutableState) SetRuntimeSuspension(ctx context.Context, id common.Namespace, status *registry.RuntimeSuspensionStatus) error {
+	err := s.ms.Insert(ctx, runtimeSuspensionKeyFmt.Encode(&id), cbor.Marshal(status))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetNodeStatus sets a status for a registered node.
 func (s *MutableState) SetNodeStatus(ctx context.Context, id signature.PublicKey, status *registry.NodeStatus) error {
 	err := s.ms.Insert(ctx, nodeStatusKeyFmt.Encode(&id), cbor.Marshal(status))
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
@@ -158,3 +159,163 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestRuntimeSuspension(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+	err := s.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	var rtID common.Namespace
+	_, err = s.RuntimeSuspension(ctx, rtID)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "RuntimeSuspension should fail for unknown runtimes")
+
+	rt := registry.Runtime{
+		ID:       rtID,
+		EntityID: entitySigner.Public(),
+	}
+	err = s.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	status, err := s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.False(status.Suspended, "runtime should not be suspended")
+	require.Empty(status.History, "suspension history should be empty")
+
+	// Suspensions should not be recorded unless the history is enabled.
+	err = s.SuspendRuntime(ctx, rtID, registry.SuspensionReasonNoCommittee)
+	require.NoError(err, "SuspendRuntime")
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.True(status.Suspended, "runtime should be suspended")
+	require.Empty(status.History, "suspension history should be empty")
+	require.Nil(status.Current(), "unrecorded suspension should have no current suspension")
+	err = s.ResumeRuntime(ctx, rtID)
+	require.NoError(err, "ResumeRuntime")
+	suspensions, err := s.RuntimeSuspensions(ctx)
+	require.NoError(err, "RuntimeSuspensions")
+	require.Empty(suspensions, "no suspension statuses should be stored")
+
+	err = s.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableRuntimeSuspensionHistory: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = s.SuspendRuntime(ctx, rtID, registry.SuspensionReasonNoCommittee)
+	require.NoError(err, "SuspendRuntime")
+	err = s.SuspendRuntime(ctx, rtID, registry.SuspensionReasonNoCommittee)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "SuspendRuntime should fail for suspended runtimes")
+
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.True(status.Suspended, "runtime should be suspended")
+	require.Len(status.History, 1, "suspension history should have one record")
+	require.EqualValues(registry.SuspensionReasonNoCommittee, status.Current().Reason)
+
+	err = s.ResumeRuntime(ctx, rtID)
+	require.NoError(err, "ResumeRuntime")
+
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.False(status.Suspended, "runtime should not be suspended")
+	require.Len(status.History, 1, "suspension history should have one record")
+
+	suspensions, err = s.RuntimeSuspensions(ctx)
+	require.NoError(err, "RuntimeSuspensions")
+	require.Len(suspensions, 1, "suspension status should be stored")
+	require.Len(suspensions[rtID].History, 1, "suspension history should have one record")
+
+	evs := ctx.GetEvents()
+	require.Len(evs, 4, "suspension and resumption events should be emitted")
+}
+
+func TestRuntimePause(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Suspensions due to pauses should be recorded even without the suspension history.
+	s := NewMutableState(ctx.State())
+	err := s.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	var rtID common.Namespace
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "PauseRuntime should fail for unknown runtimes")
+
+	rt := registry.Runtime{
+		ID:       rtID,
+		EntityID: entitySigner.Public(),
+	}
+	err = s.SetRuntime(ctx, &rt, false)
+	require.NoError(err, "SetRuntime")
+
+	err = s.UnpauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.ErrorIs(err, registry.ErrRuntimeNotPaused, "UnpauseRuntime should fail for runtimes that are not paused")
+
+	// Pausing an active runtime should suspend it.
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.NoError(err, "PauseRuntime")
+	status, err := s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.True(status.Suspended, "runtime should be suspended")
+	require.True(status.IsPaused(), "runtime should be paused")
+	require.Equal(registry.PauseAuthorityOwner, status.Pause.Authority, "runtime should be paused by its owner")
+	require.Equal(registry.SuspensionReasonPaused, status.Current().Reason)
+	_, err = s.Runtime(ctx, rtID)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "paused runtime should not be active")
+
+	// Pausing a paused runtime should be a no-op.
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.NoError(err, "PauseRuntime")
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.Len(status.History, 1, "suspension history should have one record")
+
+	// Governance should be able to take over the pause, but not vice versa.
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityGovernance)
+	require.NoError(err, "PauseRuntime")
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.NoError(err, "PauseRuntime")
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.Equal(registry.PauseAuthorityGovernance, status.Pause.Authority, "runtime should be paused by governance")
+
+	// Only the pausing authority should be able to unpause the runtime.
+	err = s.UnpauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.ErrorIs(err, registry.ErrForbidden, "owner should not be able to lift a governance pause")
+	err = s.UnpauseRuntime(ctx, rtID, registry.PauseAuthorityGovernance)
+	require.NoError(err, "UnpauseRuntime")
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.False(status.IsPaused(), "runtime should not be paused")
+	require.True(status.Suspended, "unpaused runtime should remain suspended until resumed")
+
+	// Pausing a runtime suspended for a different reason should keep the original reason.
+	err = s.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableRuntimeSuspensionHistory: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+	err = s.ResumeRuntime(ctx, rtID)
+	require.NoError(err, "ResumeRuntime")
+	err = s.SuspendRuntime(ctx, rtID, registry.SuspensionReasonInsufficientStake)
+	require.NoError(err, "SuspendRuntime")
+	err = s.PauseRuntime(ctx, rtID, registry.PauseAuthorityOwner)
+	require.NoError(err, "PauseRuntime")
+	status, err = s.RuntimeSuspension(ctx, rtID)
+	require.NoError(err, "RuntimeSuspension")
+	require.True(status.IsPaused(), "runtime should be paused")
+	require.Equal(registry.SuspensionReasonInsufficientStake, status.Current().Reason, "suspension reason should be kept")
+	require.Len(status.History, 2, "suspension history should have two records")
+}
@@ -38,6 +38,9 @@ func (app *registryApplication) registerEntity(
 		)
 		return err
 	}
+	if ent.Multisig != nil && !params.EnableEntityMultisig {
+		return fmt.Errorf("%w: entity multisig policies not enabled", registry.ErrInvalidArgument)
+	}
 	if err = ctx.Gas().UseGas(1, registry.GasOpRegisterEntity, params.GasCosts); err != nil {
 		return err
 	}
@@ -113,6 +116,22 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 		return err
 	}
 
+	stakeState := stakingState.NewMutableState(ctx.State())
+	stakeParams, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("DeregisterEntity: failed to fetch staking consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+
+	// Releasing the escrow does the same work as reclaiming it, so charge for it as well.
+	if params.EnableEntityEscrowRelease {
+		if err = ctx.Gas().UseGas(1, staking.GasOpReclaimEscrow, stakeParams.GasCosts); err != nil {
+			return err
+		}
+	}
+
 	// Return early if simulating since this is just estimating gas.
 	if ctx.IsSimulation() {
 		return nil
@@ -158,15 +177,6 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 		return fmt.Errorf("DeregisterEntity: failed to remove entity: %w", err)
 	}
 
-	stakeState := stakingState.NewMutableState(ctx.State())
-	stakeParams, err := stakeState.ConsensusParameters(ctx)
-	if err != nil {
-		ctx.Logger().Error("DeregisterEntity: failed to fetch staking consensus parameters",
-			"err", err,
-		)
-		return err
-	}
-
 	if !stakeParams.DebugBypassStake {
 		acctAddr := staking.NewAddress(id)
 		if err = stakingState.RemoveStakeClaim(ctx, acctAddr, registry.StakeClaimRegisterEntity); err != nil {
@@ -174,11 +184,39 @@ func (app *registryApplication) deregisterEntity(ctx *api.Context, state *regist
 		}
 	}
 
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityEvent{Entity: removedEntity, IsRegistration: false}))
+
+	if !params.EnableEntityEscrowRelease {
+		ctx.Logger().Debug("DeregisterEntity: complete",
+			"entity_id", id,
+		)
+		return nil
+	}
+
+	// Notify other interested applications about the deregistered entity so that its escrow
+	// is scheduled for release.
+	deregEvent := registry.EntityDeregisteredEvent{ID: id}
+	res, err := app.md.Publish(ctx, registryApi.MessageEntityDeregistered, removedEntity)
+	switch err {
+	case nil, api.ErrNoSubscribers:
+	default:
+		ctx.Logger().Error("DeregisterEntity: failed to dispatch entity deregistration message",
+			"err", err,
+		)
+		return err
+	}
+	if reclaim, ok := res.(*staking.ReclaimEscrowResult); ok {
+		deregEvent.Amount = reclaim.Amount
+		deregEvent.DebondEndTime = reclaim.DebondEndTime
+	}
+
 	ctx.Logger().Debug("DeregisterEntity: complete",
 		"entity_id", id,
+		"released_escrow", deregEvent.Amount,
+		"debond_end_time", deregEvent.DebondEndTime,
 	)
 
-	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityEvent{Entity: removedEntity, IsRegistration: false}))
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&deregEvent))
 
 	return nil
 }
@@ -425,6 +463,15 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 	// If a runtime was previously suspended and this node now paid maintenance
 	// fees for it, resume the runtime.
 	for _, rt := range paidRuntimes {
+		// Never resume explicitly paused runtimes.
+		suspension, serr := state.RuntimeSuspension(ctx, rt.ID)
+		if serr != nil {
+			return fmt.Errorf("failed to fetch runtime suspension status %s: %w", rt.ID, serr)
+		}
+		if suspension.IsPaused() {
+			continue
+		}
+
 		// Only resume a runtime if the entity has enough stake to avoid having the runtime be
 		// suspended again on the next epoch transition.
 		if !stakeParams.DebugBypassStake && rt.GovernanceModel != registry.GovernanceConsensus {
@@ -760,6 +807,56 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	return rt, nil
 }
 
+func (app *registryApplication) updateRuntimeParameters(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	update *registry.RuntimeParametersUpdate,
+) (*registry.Runtime, error) {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("UpdateRuntimeParameters: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	// Handle as an unknown message to avoid breaking consensus before the activation
+	// proposal passes.
+	if !params.EnableRuntimeParameterUpdates {
+		return nil, registry.ErrInvalidArgument
+	}
+
+	if err = update.ValidateBasic(); err != nil {
+		ctx.Logger().Debug("UpdateRuntimeParameters: invalid update",
+			"err", err,
+		)
+		return nil, fmt.Errorf("%w: %s", registry.ErrInvalidArgument, err)
+	}
+
+	existingRt, err := state.AnyRuntime(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Partial updates are only supported for runtimes that govern themselves.
+	if existingRt.GovernanceModel != registry.GovernanceRuntime {
+		ctx.Logger().Debug("UpdateRuntimeParameters: runtime does not use runtime governance",
+			"runtime_id", update.ID,
+			"governance_model", existingRt.GovernanceModel,
+		)
+		return nil, registry.ErrForbidden
+	}
+
+	rt, err := update.Apply(existingRt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Caller authorization and update verification are performed as part of the regular
+	// runtime registration.
+	return app.registerRuntime(ctx, state, rt)
+}
+
 func (app *registryApplication) proveFreshness(
 	ctx *api.Context,
 	state *registryState.MutableState,
@@ -788,3 +885,165 @@ func (app *registryApplication) proveFreshness(
 
 	return nil
 }
+
+func (app *registryApplication) pauseRuntime(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	req *registry.PauseRuntime,
+	resume bool,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("PauseRuntime: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if !params.EnableRuntimePause {
+		return fmt.Errorf("%w: pausing runtimes is disabled", registry.ErrForbidden)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	if err = ctx.Gas().UseGas(1, registry.GasOpPauseRuntime, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	rt, err := state.AnyRuntime(ctx, req.RuntimeID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure that the request was signed by the runtime owner. Runtimes with consensus-layer
+	// or runtime governance can only be paused via governance.
+	expectedAddr := rt.StakingAddress()
+	if expectedAddr == nil || rt.GovernanceModel != registry.GovernanceEntity {
+		ctx.Logger().Debug("PauseRuntime: only entity governed runtimes can be paused by their owner",
+			"runtime_id", rt.ID,
+		)
+		return registry.ErrForbidden
+	}
+	if !ctx.CallerAddress().Equal(*expectedAddr) {
+		ctx.Logger().Debug("PauseRuntime: transaction must be signed by controlling entity")
+		return registry.ErrIncorrectTxSigner
+	}
+
+	return app.setRuntimePaused(ctx, state, rt, resume, registry.PauseAuthorityOwner)
+}
+
+// setRuntimePaused pauses or resumes the given runtime on behalf of the given authority.
+func (app *registryApplication) setRuntimePaused(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	rt *registry.Runtime,
+	resume bool,
+	authority registry.RuntimePauseAuthority,
+) error {
+	if !resume {
+		if err := state.PauseRuntime(ctx, rt.ID, authority); err != nil {
+			return err
+		}
+
+		ctx.Logger().Debug("runtime paused",
+			"runtime_id", rt.ID,
+			"authority", authority,
+		)
+
+		// Notify other interested applications about the paused runtime.
+		if _, err := app.md.Publish(ctx, registryApi.MessageRuntimePaused, rt); err != nil {
+			ctx.Logger().Error("failed to dispatch runtime pause message",
+				"err", err,
+			)
+			return err
+		}
+		return nil
+	}
+
+	status, err := state.RuntimeSuspension(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	if !status.IsPaused() {
+		return registry.ErrRuntimeNotPaused
+	}
+	if status.Pause.Authority != authority {
+		ctx.Logger().Debug("runtime can only be resumed by the authority that paused it",
+			"runtime_id", rt.ID,
+			"authority", authority,
+			"pause_authority", status.Pause.Authority,
+		)
+		return registry.ErrForbidden
+	}
+
+	// A runtime that was also suspended for a different reason remains suspended until it is
+	// resumed automatically once that reason no longer applies.
+	current := status.Current()
+	resumeSuspended := current != nil && current.Reason == registry.SuspensionReasonPaused
+
+	// Only resume a runtime if the entity has enough stake to avoid having the runtime be
+	// suspended again on the next epoch transition.
+	if resumeSuspended {
+		if err = checkRuntimeStake(ctx, rt); err != nil {
+			ctx.Logger().Debug("insufficient stake to resume paused runtime",
+				"err", err,
+				"runtime_id", rt.ID,
+			)
+			return err
+		}
+	}
+
+	if err = state.UnpauseRuntime(ctx, rt.ID, authority); err != nil {
+		return err
+	}
+	if !resumeSuspended {
+		ctx.Logger().Debug("runtime unpaused, but remains suspended",
+			"runtime_id", rt.ID,
+		)
+		return nil
+	}
+	if err = state.ResumeRuntime(ctx, rt.ID); err != nil {
+		return err
+	}
+
+	ctx.Logger().Debug("runtime resumed",
+		"runtime_id", rt.ID,
+	)
+
+	// Notify other interested applications about the resumed runtime.
+	if _, err = app.md.Publish(ctx, registryApi.MessageRuntimeResumed, rt); err != nil {
+		ctx.Logger().Error("failed to dispatch runtime resumption message",
+			"err", err,
+		)
+		return err
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.RuntimeStartedEvent{Runtime: rt}))
+
+	return nil
+}
+
+// checkRuntimeStake checks whether the runtime owner has enough stake to cover its claims.
+func checkRuntimeStake(ctx *api.Context, rt *registry.Runtime) error {
+	stakeParams, err := stakingState.NewMutableState(ctx.State()).ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch staking consensus parameters: %w", err)
+	}
+	if stakeParams.DebugBypassStake || rt.GovernanceModel == registry.GovernanceConsensus {
+		return nil
+	}
+
+	acctAddr := rt.StakingAddress()
+	if acctAddr == nil {
+		// This should never happen.
+		return fmt.Errorf("unknown runtime governance model on runtime %s: %s", rt.ID, rt.GovernanceModel)
+	}
+	return stakingState.CheckStakeClaims(ctx, *acctAddr)
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
@@ -893,3 +895,323 @@ func TestProofFreshness(t *testing.T) {
 		require.Equal(registry.ErrInvalidArgument, err)
 	})
 }
+
+type testMessageDispatcher struct {
+	abciAPI.NoopMessageDispatcher
+
+	published []interface{}
+	result    interface{}
+}
+
+func (md *testMessageDispatcher) Publish(_ *abciAPI.Context, _, msg interface{}) (interface{}, error) {
+	md.published = append(md.published, msg)
+	return md.result, nil
+}
+
+func TestDeregisterEntity(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	md := testMessageDispatcher{
+		result: &staking.ReclaimEscrowResult{
+			Amount:        *quantity.NewFromUint64(1000),
+			DebondEndTime: 15,
+		},
+	}
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebugBypassStake: true,
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: deregister entity signer")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	deregister := func() *registry.EntityDeregisteredEvent {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(entitySigner.Public())
+
+		err = app.deregisterEntity(txCtx, state)
+		require.NoError(err, "entity deregistration should succeed")
+
+		_, err = state.Entity(txCtx, ent.ID)
+		require.ErrorIs(err, registry.ErrNoSuchEntity, "entity should be removed")
+
+		var deregEvent *registry.EntityDeregisteredEvent
+		for _, ev := range txCtx.GetEvents() {
+			for _, pair := range ev.GetAttributes() {
+				if !eventsAPI.IsAttributeKind(pair.GetKey(), &registry.EntityDeregisteredEvent{}) {
+					continue
+				}
+				deregEvent = new(registry.EntityDeregisteredEvent)
+				err = eventsAPI.DecodeValue(pair.GetValue(), deregEvent)
+				require.NoError(err, "DecodeValue")
+			}
+		}
+		return deregEvent
+	}
+
+	// Escrow should not be released unless enabled.
+	deregEvent := deregister()
+	require.Empty(md.published, "entity deregistration should not be published")
+	require.Nil(deregEvent, "entity deregistered event should not be emitted")
+
+	err = state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableEntityEscrowRelease: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	deregEvent = deregister()
+	require.Len(md.published, 1, "entity deregistration should be published")
+	require.EqualValues(&ent, md.published[0])
+	require.NotNil(deregEvent, "entity deregistered event should be emitted")
+	require.Equal(ent.ID, deregEvent.ID)
+	require.Equal(*quantity.NewFromUint64(1000), deregEvent.Amount)
+	require.EqualValues(15, deregEvent.DebondEndTime)
+
+	// Deregistering a non-existent entity should fail.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	err = app.deregisterEntity(txCtx, state)
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "deregistering a removed entity should fail")
+}
+
+func TestEntityMultisig(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := registryApplication{appState, &testMessageDispatcher{}}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	params := &registry.ConsensusParameters{}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebugBypassStake: true,
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: multisig entity signer")
+	coSigner1 := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: multisig co-signer 1")
+	coSigner2 := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: multisig co-signer 2")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Multisig: &entity.MultisigPolicy{
+			Signers:   []signature.PublicKey{coSigner1.Public(), coSigner2.Public()},
+			Threshold: 2,
+		},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	signers := []signature.PublicKey{coSigner1.Public(), coSigner2.Public()}
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+
+	// Multisig policies should be rejected when not enabled.
+	err = app.registerEntity(txCtx, state, sigEnt)
+	require.ErrorIs(err, registry.ErrInvalidArgument, "entity multisig registration should fail when disabled")
+	err = app.AuthorizeMultisigTx(txCtx, ent.ID, signers)
+	require.ErrorIs(err, transaction.ErrMultisigNotSupported, "multisig transactions should fail when disabled")
+
+	// Enable multisig policies.
+	params.EnableEntityMultisig = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	err = app.registerEntity(txCtx, state, sigEnt)
+	require.NoError(err, "entity multisig registration should succeed when enabled")
+	err = app.AuthorizeMultisigTx(txCtx, ent.ID, signers)
+	require.NoError(err, "multisig transactions should succeed when enabled")
+	err = app.AuthorizeMultisigTx(txCtx, ent.ID, signers[:1])
+	require.ErrorIs(err, registry.ErrMultisigUnauthorized, "multisig transactions below threshold should fail")
+}
+
+func TestPauseRuntime(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{
+		BlockHeight: 10,
+	}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := registryApplication{appState, &testMessageDispatcher{}}
+	state := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableRuntimeSuspensionHistory: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		Thresholds: map[staking.ThresholdKind]quantity.Quantity{
+			staking.KindRuntimeCompute: *quantity.NewFromUint64(1000),
+		},
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: pause runtime signer")
+	rt := &registry.Runtime{
+		ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: pause runtime"), 0),
+		EntityID:        entitySigner.Public(),
+		Kind:            registry.KindCompute,
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err = state.SetRuntime(ctx, rt, false)
+	require.NoError(err, "SetRuntime")
+
+	// Make sure the runtime owner has enough stake for the runtime.
+	addr := staking.NewAddress(entitySigner.Public())
+	setStake := func(amount uint64) {
+		var acct staking.Account
+		acct.Escrow.Active.Balance = *quantity.NewFromUint64(amount)
+		acct.Escrow.StakeAccumulator.AddClaimUnchecked(registry.StakeClaimForRuntime(rt.ID), registry.StakeThresholdsForRuntime(rt))
+		err = stakeState.SetAccount(ctx, addr, &acct)
+		require.NoError(err, "SetAccount")
+	}
+	setStake(1000)
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(entitySigner.Public())
+	req := &registry.PauseRuntime{RuntimeID: rt.ID}
+
+	requireSuspension := func(suspended, paused bool) *registry.RuntimeSuspensionStatus {
+		status, serr := state.RuntimeSuspension(ctx, rt.ID)
+		require.NoError(serr, "RuntimeSuspension")
+		require.Equal(suspended, status.Suspended, "runtime suspension")
+		require.Equal(paused, status.IsPaused(), "runtime pause")
+		return status
+	}
+
+	// Pausing should fail unless enabled.
+	err = app.pauseRuntime(txCtx, state, req, false)
+	require.ErrorIs(err, registry.ErrForbidden, "pausing should fail when disabled")
+	requireSuspension(false, false)
+	err = state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableRuntimeSuspensionHistory: true,
+		EnableRuntimePause:             true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	// Only the runtime owner should be able to pause the runtime.
+	otherCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer otherCtx.Close()
+	otherCtx.SetTxSigner(memorySigner.NewTestSigner("consensus/cometbft/apps/registry: pause runtime other signer").Public())
+	err = app.pauseRuntime(otherCtx, state, req, false)
+	require.ErrorIs(err, registry.ErrIncorrectTxSigner, "pausing by others should fail")
+
+	// The owner should not be able to lift a governance pause.
+	err = app.pauseRuntime(txCtx, state, req, false)
+	require.NoError(err, "pausing by the owner should succeed")
+	requireSuspension(true, true)
+	err = app.setRuntimePaused(ctx, state, rt, false, registry.PauseAuthorityGovernance)
+	require.NoError(err, "pausing via governance should succeed")
+	status := requireSuspension(true, true)
+	require.Equal(registry.PauseAuthorityGovernance, status.Pause.Authority)
+	err = app.pauseRuntime(txCtx, state, req, true)
+	require.ErrorIs(err, registry.ErrForbidden, "owner should not be able to lift a governance pause")
+	requireSuspension(true, true)
+	err = app.setRuntimePaused(ctx, state, rt, true, registry.PauseAuthorityGovernance)
+	require.NoError(err, "resuming via governance should succeed")
+	requireSuspension(false, false)
+	err = app.pauseRuntime(txCtx, state, req, true)
+	require.ErrorIs(err, registry.ErrRuntimeNotPaused, "resuming a runtime that is not paused should fail")
+
+	// Resuming should re-run the stake check.
+	err = app.pauseRuntime(txCtx, state, req, false)
+	require.NoError(err, "pausing by the owner should succeed")
+	setStake(100)
+	err = app.pauseRuntime(txCtx, state, req, true)
+	require.ErrorIs(err, staking.ErrInsufficientStake, "resuming without enough stake should fail")
+	requireSuspension(true, true)
+	setStake(1000)
+	err = app.pauseRuntime(txCtx, state, req, true)
+	require.NoError(err, "resuming with enough stake should succeed")
+	requireSuspension(false, false)
+
+	// Pausing a runtime suspended due to insufficient stake should keep the stake suspension.
+	setStake(100)
+	err = state.SuspendRuntime(ctx, rt.ID, registry.SuspensionReasonInsufficientStake)
+	require.NoError(err, "SuspendRuntime")
+	err = app.pauseRuntime(txCtx, state, req, false)
+	require.NoError(err, "pausing by the owner should succeed")
+	status = requireSuspension(true, true)
+	require.Equal(registry.SuspensionReasonInsufficientStake, status.Current().Reason)
+	err = app.pauseRuntime(txCtx, state, req, true)
+	require.NoError(err, "resuming by the owner should succeed")
+	status = requireSuspension(true, false)
+	require.Equal(registry.SuspensionReasonInsufficientStake, status.Current().Reason)
+	_, err = state.Runtime(ctx, rt.ID)
+	require.ErrorIs(err, registry.ErrNoSuchRuntime, "runtime should remain suspended")
+}
+
+func TestUpdateRuntimeParameters(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := registryApplication{appState, &testMessageDispatcher{}}
+	state := registryState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	rt := &registry.Runtime{
+		ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: update runtime parameters"), 0),
+		Kind:            registry.KindCompute,
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err = state.SetRuntime(ctx, rt, false)
+	require.NoError(err, "SetRuntime")
+
+	update := &registry.RuntimeParametersUpdate{
+		ID:       rt.ID,
+		Executor: &registry.ExecutorParameters{GroupSize: 3},
+	}
+
+	// Updates should be handled as unknown messages unless enabled.
+	_, err = app.updateRuntimeParameters(ctx, state, update)
+	require.Equal(registry.ErrInvalidArgument, err, "updates should fail when disabled")
+
+	err = state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		EnableRuntimeParameterUpdates: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+
+	// Only runtimes that govern themselves should be able to emit updates.
+	_, err = app.updateRuntimeParameters(ctx, state, update)
+	require.ErrorIs(err, registry.ErrForbidden, "updates by non-runtime-governed runtimes should fail")
+}
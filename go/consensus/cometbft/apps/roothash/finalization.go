@@ -78,6 +78,14 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 	}
 	livenessStats := rtState.LivenessStatistics
 
+	if timeout {
+		ctx.EmitEvent(
+			tmapi.NewEventBuilder(app.Name()).
+				TypedAttribute(&roothash.RoundTimeoutEvent{Round: round}).
+				TypedAttribute(&roothash.RuntimeIDAttribute{ID: rtState.Runtime.ID}),
+		)
+	}
+
 	sc, err := pool.ProcessCommitments(rtState.Committee, rtState.Runtime.Executor.AllowedStragglers, timeout)
 	switch err {
 	case commitment.ErrDiscrepancyDetected:
@@ -276,7 +284,12 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 	}
 
 	// Generate the final block.
-	return app.finalizeBlock(ctx, rtState, block.Normal, &sc.Commitment.Header.Header)
+	if err = app.finalizeBlock(ctx, rtState, block.Normal, &sc.Commitment.Header.Header); err != nil {
+		return err
+	}
+
+	// Replace the highest-ranked scheduler in case it keeps missing proposals.
+	return maybePromoteStandbyWorker(ctx, rtState, firstSchedulerIdx)
 }
 
 func (app *rootHashApplication) finalizeBlock(ctx *tmapi.Context, rtState *roothash.RuntimeState, hdrType block.HeaderType, hdr *commitment.ComputeResultsHeader) error {
@@ -357,5 +370,5 @@ func (app *rootHashApplication) failRound(
 		return fmt.Errorf("failed to emit empty block: %w", err)
 	}
 
-	return nil
+	return maybePromoteStandbyWorker(ctx, rtState, firstSchedulerIdx)
 }
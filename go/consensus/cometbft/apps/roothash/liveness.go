@@ -3,6 +3,7 @@ package roothash
 import (
 	"fmt"
 	"math"
+	"slices"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
@@ -42,12 +43,18 @@ func processLivenessStatistics(ctx *tmapi.Context, epoch beacon.EpochTime, rtSta
 		"slash_amount", slashParams.Amount,
 	)
 
-	// Penalize worker nodes that were not live enough.
+	// Penalize worker nodes (including the ones replaced by standby workers) that were not
+	// live enough.
 	regState := registryState.NewMutableState(ctx.State())
 	for i, n := range rtState.Committee.Members {
-		if n.Role != api.RoleWorker {
-			// Workers are listed before backup workers.
-			break
+		switch n.Role {
+		case api.RoleWorker:
+		case api.RoleStandbyWorker:
+			if !slices.Contains(rtState.DemotedWorkers, n.PublicKey) {
+				continue
+			}
+		default:
+			continue
 		}
 
 		status, err := regState.NodeStatus(ctx, n.PublicKey)
@@ -17,6 +17,7 @@ type Query interface {
 	GenesisBlock(context.Context, common.Namespace) (*block.Block, error)
 	RuntimeState(context.Context, common.Namespace) (*roothash.RuntimeState, error)
 	LastRoundResults(context.Context, common.Namespace) (*roothash.RoundResults, error)
+	RuntimeStateProof(context.Context, common.Namespace) (*roothash.RuntimeStateProof, error)
 	RoundRoots(context.Context, common.Namespace, uint64) (*roothash.RoundRoots, error)
 	PastRoundRoots(context.Context, common.Namespace) (map[uint64]roothash.RoundRoots, error)
 	IncomingMessageQueueMeta(context.Context, common.Namespace) (*message.IncomingMessageQueueMeta, error)
@@ -67,6 +68,10 @@ func (rq *rootHashQuerier) LastRoundResults(ctx context.Context, id common.Names
 	return rq.state.LastRoundResults(ctx, id)
 }
 
+func (rq *rootHashQuerier) RuntimeStateProof(ctx context.Context, id common.Namespace) (*roothash.RuntimeStateProof, error) {
+	return rq.state.RuntimeStateProof(ctx, id)
+}
+
 func (rq *rootHashQuerier) RoundRoots(ctx context.Context, id common.Namespace, round uint64) (*roothash.RoundRoots, error) {
 	return rq.state.RoundRoots(ctx, id, round)
 }
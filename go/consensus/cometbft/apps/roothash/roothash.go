@@ -62,6 +62,7 @@ func (app *rootHashApplication) OnRegister(state tmapi.ApplicationState, md tmap
 	md.Subscribe(registryApi.MessageNewRuntimeRegistered, app)
 	md.Subscribe(registryApi.MessageRuntimeUpdated, app)
 	md.Subscribe(registryApi.MessageRuntimeResumed, app)
+	md.Subscribe(registryApi.MessageRuntimePaused, app)
 	md.Subscribe(roothashApi.RuntimeMessageNoop, app)
 	md.Subscribe(schedulerApi.MessageBeforeSchedule, app)
 	md.Subscribe(governanceApi.MessageChangeParameters, app)
@@ -184,7 +185,11 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *ro
 				"epoch", epoch,
 			)
 
-			if err = regState.SuspendRuntime(ctx, rt.ID); err != nil {
+			reason := registry.SuspensionReasonInsufficientStake
+			if committee == nil {
+				reason = registry.SuspensionReasonNoCommittee
+			}
+			if err = regState.SuspendRuntime(ctx, rt.ID, reason); err != nil {
 				return err
 			}
 
@@ -213,8 +218,9 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *ro
 			rtState.Committee = committee
 		}
 
-		// Clear liveness statistics.
+		// Clear liveness statistics and standby worker promotions.
 		rtState.LivenessStatistics = nil
+		rtState.DemotedWorkers = nil
 		// Update the runtime descriptor to the latest per-epoch value.
 		rtState.Runtime = rt
 
@@ -251,6 +257,9 @@ func (app *rootHashApplication) ExecuteMessage(ctx *tmapi.Context, kind, msg int
 	case registryApi.MessageRuntimeResumed:
 		// A previously suspended runtime has been resumed.
 		return nil, nil
+	case registryApi.MessageRuntimePaused:
+		// A runtime has been explicitly paused.
+		return nil, app.onRuntimePaused(ctx, msg.(*registry.Runtime))
 	case roothashApi.RuntimeMessageNoop:
 		// Noop message always succeeds.
 		return nil, nil
@@ -270,6 +279,40 @@ func (app *rootHashApplication) ExecuteMessage(ctx *tmapi.Context, kind, msg int
 	}
 }
 
+func (app *rootHashApplication) onRuntimePaused(ctx *tmapi.Context, rt *registry.Runtime) error {
+	state := roothashState.NewMutableState(ctx.State())
+
+	rtState, err := state.RuntimeState(ctx, rt.ID)
+	switch err {
+	case nil:
+	case roothash.ErrInvalidRuntime:
+		// Runtime has no state yet, nothing to do.
+		return nil
+	default:
+		return fmt.Errorf("failed to fetch runtime state: %w", err)
+	}
+	if rtState.Suspended {
+		return nil
+	}
+
+	ctx.Logger().Debug("suspending paused runtime",
+		"runtime_id", rt.ID,
+	)
+
+	// Emit an empty block signalling that the runtime was suspended.
+	if err = app.finalizeBlock(ctx, rtState, block.Suspended, nil); err != nil {
+		return fmt.Errorf("failed to emit empty block: %w", err)
+	}
+
+	rtState.Suspended = true
+	rtState.Committee = nil
+
+	if err = state.SetRuntimeState(ctx, rtState); err != nil {
+		return fmt.Errorf("failed to set runtime state: %w", err)
+	}
+	return nil
+}
+
 func (app *rootHashApplication) verifyRuntimeUpdate(ctx *tmapi.Context, rt *registry.Runtime) error {
 	state := roothashState.NewMutableState(ctx.State())
 
@@ -0,0 +1,85 @@
+package roothash
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// maybePromoteStandbyWorker replaces the worker at the given committee index with a standby
+// worker in case the worker has missed too many proposals in the current epoch.
+//
+// The demoted worker and the promoted standby worker swap places in the committee, preserving
+// the scheduling order of the other workers. Their liveness statistics are swapped as well so
+// that the demoted worker is still evaluated at the end of the epoch. The updated committee is
+// also stored in the scheduler state so that all committee consumers observe the promotion.
+func maybePromoteStandbyWorker(ctx *tmapi.Context, rtState *roothash.RuntimeState, idx int) error {
+	threshold := uint64(rtState.Runtime.Executor.StandbyPromotionThreshold)
+	if threshold == 0 || rtState.Committee == nil || rtState.LivenessStatistics == nil {
+		return nil
+	}
+	stats := rtState.LivenessStatistics
+	if stats.MissedProposals[idx] < threshold {
+		return nil
+	}
+
+	standbyIdx, ok := findStandbyWorker(rtState.Committee, rtState.DemotedWorkers)
+	if !ok {
+		ctx.Logger().Warn("worker missed too many proposals, but no standby worker available",
+			"runtime_id", rtState.Runtime.ID,
+			"node_id", rtState.Committee.Members[idx].PublicKey,
+			"missed_proposals", stats.MissedProposals[idx],
+		)
+		return nil
+	}
+
+	worker := rtState.Committee.Members[idx]
+	standby := rtState.Committee.Members[standbyIdx]
+
+	ctx.Logger().Info("promoting standby worker",
+		"runtime_id", rtState.Runtime.ID,
+		"demoted_node_id", worker.PublicKey,
+		"promoted_node_id", standby.PublicKey,
+		"missed_proposals", stats.MissedProposals[idx],
+		logging.LogEvent, roothash.LogEventStandbyWorkerPromoted,
+	)
+
+	worker.PublicKey, standby.PublicKey = standby.PublicKey, worker.PublicKey
+	rtState.DemotedWorkers = append(rtState.DemotedWorkers, standby.PublicKey)
+
+	stats.LiveRounds[idx], stats.LiveRounds[standbyIdx] = stats.LiveRounds[standbyIdx], stats.LiveRounds[idx]
+	stats.FinalizedProposals[idx], stats.FinalizedProposals[standbyIdx] = stats.FinalizedProposals[standbyIdx], stats.FinalizedProposals[idx]
+	stats.MissedProposals[idx], stats.MissedProposals[standbyIdx] = stats.MissedProposals[standbyIdx], stats.MissedProposals[idx]
+
+	// The promoted worker could not participate in the previous rounds, so make sure it is not
+	// penalized for them.
+	stats.LiveRounds[idx] = stats.TotalRounds
+
+	// Keep the scheduler committee in sync with the roothash committee.
+	if err := schedulerState.NewMutableState(ctx.State()).PutCommittee(ctx, rtState.Committee); err != nil {
+		return fmt.Errorf("failed to update executor committee: %w", err)
+	}
+
+	return nil
+}
+
+// findStandbyWorker returns the index of the first standby worker in the committee that has not
+// yet been used to replace a worker, i.e. is not one of the given demoted workers.
+func findStandbyWorker(committee *api.Committee, demoted []signature.PublicKey) (int, bool) {
+	for i, n := range committee.Members {
+		if n.Role != api.RoleStandbyWorker {
+			continue
+		}
+		if slices.Contains(demoted, n.PublicKey) {
+			continue
+		}
+		return i, true
+	}
+	return 0, false
+}
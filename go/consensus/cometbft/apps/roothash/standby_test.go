@@ -0,0 +1,103 @@
+package roothash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestStandbyWorkerPromotion(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	worker1 := memorySigner.NewTestSigner("standby test: worker 1").Public()
+	worker2 := memorySigner.NewTestSigner("standby test: worker 2").Public()
+	backup := memorySigner.NewTestSigner("standby test: backup").Public()
+	standby1 := memorySigner.NewTestSigner("standby test: standby 1").Public()
+	standby2 := memorySigner.NewTestSigner("standby test: standby 2").Public()
+
+	rtState := &roothash.RuntimeState{
+		Runtime: &registry.Runtime{
+			Executor: registry.ExecutorParameters{
+				GroupStandbySize:          2,
+				StandbyPromotionThreshold: 2,
+			},
+		},
+		Committee: &scheduler.Committee{
+			Kind: scheduler.KindComputeExecutor,
+			Members: []*scheduler.CommitteeNode{
+				{Role: scheduler.RoleWorker, PublicKey: worker1},
+				{Role: scheduler.RoleWorker, PublicKey: worker2},
+				{Role: scheduler.RoleBackupWorker, PublicKey: backup},
+				{Role: scheduler.RoleStandbyWorker, PublicKey: standby1},
+				{Role: scheduler.RoleStandbyWorker, PublicKey: standby2},
+			},
+		},
+		LivenessStatistics: &roothash.LivenessStatistics{
+			TotalRounds:        10,
+			LiveRounds:         []uint64{9, 10, 0, 0, 0},
+			FinalizedProposals: []uint64{3, 5, 0, 0, 0},
+			MissedProposals:    []uint64{1, 0, 0, 0, 0},
+		},
+	}
+
+	schedState := schedulerState.NewMutableState(ctx.State())
+	err := schedState.PutCommittee(ctx, rtState.Committee)
+	require.NoError(err, "PutCommittee")
+
+	// Workers below the threshold should not be replaced.
+	err = maybePromoteStandbyWorker(ctx, rtState, 0)
+	require.NoError(err, "maybePromoteStandbyWorker")
+	require.Equal(worker1, rtState.Committee.Members[0].PublicKey)
+	require.Empty(rtState.DemotedWorkers)
+
+	// Workers reaching the threshold should be replaced by the first standby worker.
+	rtState.LivenessStatistics.MissedProposals[0]++
+	err = maybePromoteStandbyWorker(ctx, rtState, 0)
+	require.NoError(err, "maybePromoteStandbyWorker")
+	require.Equal(standby1, rtState.Committee.Members[0].PublicKey)
+	require.Equal(scheduler.RoleWorker, rtState.Committee.Members[0].Role)
+	require.Equal(worker1, rtState.Committee.Members[3].PublicKey)
+	require.Equal(scheduler.RoleStandbyWorker, rtState.Committee.Members[3].Role)
+	require.True(rtState.Committee.IsWorker(standby1))
+	require.True(rtState.Committee.IsStandbyWorker(worker1))
+	require.EqualValues([]signature.PublicKey{worker1}, rtState.DemotedWorkers)
+
+	// The scheduler committee should be updated as well.
+	schedCommittee, err := schedState.Committee(ctx, scheduler.KindComputeExecutor, rtState.Runtime.ID)
+	require.NoError(err, "Committee")
+	require.True(schedCommittee.IsWorker(standby1))
+	require.True(schedCommittee.IsStandbyWorker(worker1))
+
+	// Liveness statistics should follow the nodes.
+	stats := rtState.LivenessStatistics
+	require.EqualValues([]uint64{10, 10, 0, 9, 0}, stats.LiveRounds)
+	require.EqualValues([]uint64{0, 5, 0, 3, 0}, stats.FinalizedProposals)
+	require.EqualValues([]uint64{0, 0, 0, 2, 0}, stats.MissedProposals)
+
+	// Demoted workers should not be promoted again, even without any recorded statistics.
+	stats.LiveRounds[3], stats.FinalizedProposals[3], stats.MissedProposals[3] = 0, 0, 0
+	stats.MissedProposals[1] = 2
+	err = maybePromoteStandbyWorker(ctx, rtState, 1)
+	require.NoError(err, "maybePromoteStandbyWorker")
+	require.Equal(standby2, rtState.Committee.Members[1].PublicKey)
+	require.Equal(worker2, rtState.Committee.Members[4].PublicKey)
+	require.EqualValues([]signature.PublicKey{worker1, worker2}, rtState.DemotedWorkers)
+
+	// Nothing should happen when no standby workers are left.
+	stats.MissedProposals[0] = 2
+	err = maybePromoteStandbyWorker(ctx, rtState, 0)
+	require.NoError(err, "maybePromoteStandbyWorker")
+	require.Equal(standby1, rtState.Committee.Members[0].PublicKey)
+}
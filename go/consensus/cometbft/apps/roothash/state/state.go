@@ -13,6 +13,7 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 var (
@@ -278,6 +279,90 @@ func (s *ImmutableState) IncomingMessageQueue(ctx context.Context, runtimeID com
 	return msgs, nil
 }
 
+// RuntimeStateProof returns a proof of the latest state and I/O roots of the given runtime against
+// the consensus state root.
+func (s *ImmutableState) RuntimeStateProof(ctx context.Context, id common.Namespace) (*roothash.RuntimeStateProof, error) {
+	tree, ok := s.is.ImmutableKeyValueTree.(mkvs.Tree)
+	if !ok {
+		return nil, fmt.Errorf("roothash: state proofs not supported")
+	}
+	session, err := tree.NewReadSession(syncer.LatestProofVersion)
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+
+	// Perform all reads in the same session so a single proof covers both roots.
+	getRoot := func(kf *keyformat.KeyFormat) (hash.Hash, error) {
+		raw, err := session.Get(ctx, kf.Encode(&id))
+		if err != nil {
+			return hash.Hash{}, api.UnavailableStateError(err)
+		}
+		if raw == nil {
+			return hash.Hash{}, roothash.ErrInvalidRuntime
+		}
+
+		var h hash.Hash
+		if err = h.UnmarshalBinary(raw); err != nil {
+			return hash.Hash{}, api.UnavailableStateError(err)
+		}
+		return h, nil
+	}
+
+	stateRoot, err := getRoot(stateRootKeyFmt)
+	if err != nil {
+		return nil, err
+	}
+	ioRoot, err := getRoot(ioRootKeyFmt)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := session.Proof(ctx)
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+
+	return &roothash.RuntimeStateProof{
+		Height:    int64(session.Root().Version),
+		StateRoot: stateRoot,
+		IORoot:    ioRoot,
+		Proof:     *proof,
+	}, nil
+}
+
+// VerifyRuntimeStateProof verifies that the given proof proves the included state and I/O roots of
+// the given runtime against the given consensus state root.
+func VerifyRuntimeStateProof(ctx context.Context, id common.Namespace, stateRoot hash.Hash, proof *roothash.RuntimeStateProof) error {
+	var pv syncer.ProofVerifier
+	wl, err := pv.VerifyProofToWriteLog(ctx, stateRoot, &proof.Proof)
+	if err != nil {
+		return fmt.Errorf("roothash: invalid runtime state proof: %w", err)
+	}
+
+	expected := map[string]hash.Hash{
+		string(stateRootKeyFmt.Encode(&id)): proof.StateRoot,
+		string(ioRootKeyFmt.Encode(&id)):    proof.IORoot,
+	}
+	for _, entry := range wl {
+		root, ok := expected[string(entry.Key)]
+		if !ok {
+			continue
+		}
+
+		var h hash.Hash
+		if err = h.UnmarshalBinary(entry.Value); err != nil {
+			return fmt.Errorf("roothash: malformed root in runtime state proof: %w", err)
+		}
+		if !h.Equal(&root) {
+			return fmt.Errorf("roothash: runtime state proof root mismatch (expected: %s got: %s)", root, h)
+		}
+		delete(expected, string(entry.Key))
+	}
+	if len(expected) > 0 {
+		return fmt.Errorf("roothash: runtime state proof is missing roots")
+	}
+	return nil
+}
+
 // RoundRoots returns the state and I/O roots for the given runtime ID and round.
 //
 // If no roots are present for the given runtime and round, nil is returned.
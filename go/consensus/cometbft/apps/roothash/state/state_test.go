@@ -1,15 +1,18 @@
 package state
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 )
 
 func TestEvidence(t *testing.T) {
@@ -305,3 +308,69 @@ func TestPastRuntimeRoots(t *testing.T) {
 	require.EqualValues(0, len(roots))
 	require.EqualValues(len(roots), st.PastRoundRootsCount(ctx, runtime.ID))
 }
+
+func TestRuntimeStateProof(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+
+	initCtx := appState.NewContext(abciAPI.ContextInitChain)
+	st := NewMutableState(initCtx.State())
+	err := st.SetConsensusParameters(initCtx, &api.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+	initCtx.Close()
+
+	abciCtx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer abciCtx.Close()
+	st = NewMutableState(abciCtx.State())
+
+	var runtime registry.Runtime
+	err = runtime.ID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(err, "UnmarshalHex")
+
+	blk := block.NewGenesisBlock(runtime.ID, 0)
+	err = blk.Header.StateRoot.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(err, "UnmarshalHex")
+	err = blk.Header.IORoot.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(err, "UnmarshalHex")
+	err = st.SetRuntimeState(abciCtx, &api.RuntimeState{
+		Runtime:      &runtime,
+		GenesisBlock: blk,
+		LastBlock:    blk,
+	})
+	require.NoError(err, "SetRuntimeState")
+
+	// Proofs are generated against committed state.
+	ctx := context.Background()
+	tree := abciCtx.State().(mkvs.Tree)
+	_, stateRoot, err := tree.Commit(ctx, common.Namespace{}, 1)
+	require.NoError(err, "Commit")
+
+	is := &ImmutableState{&abciAPI.ImmutableState{ImmutableKeyValueTree: tree}}
+	proof, err := is.RuntimeStateProof(ctx, runtime.ID)
+	require.NoError(err, "RuntimeStateProof")
+	require.EqualValues(1, proof.Height)
+	require.EqualValues(blk.Header.StateRoot, proof.StateRoot)
+	require.EqualValues(blk.Header.IORoot, proof.IORoot)
+
+	err = VerifyRuntimeStateProof(ctx, runtime.ID, stateRoot, proof)
+	require.NoError(err, "VerifyRuntimeStateProof")
+
+	// Proofs for unknown runtimes should not be available.
+	var otherID common.Namespace
+	_, err = is.RuntimeStateProof(ctx, otherID)
+	require.ErrorIs(err, api.ErrInvalidRuntime, "RuntimeStateProof for unknown runtime")
+
+	// Verification must fail for other runtimes, state roots or tampered roots.
+	err = VerifyRuntimeStateProof(ctx, otherID, stateRoot, proof)
+	require.Error(err, "VerifyRuntimeStateProof for other runtime")
+
+	var otherRoot hash.Hash
+	otherRoot.Empty()
+	err = VerifyRuntimeStateProof(ctx, runtime.ID, otherRoot, proof)
+	require.Error(err, "VerifyRuntimeStateProof with other state root")
+
+	proof.StateRoot = blk.Header.IORoot
+	err = VerifyRuntimeStateProof(ctx, runtime.ID, stateRoot, proof)
+	require.Error(err, "VerifyRuntimeStateProof with tampered state root")
+}
@@ -2,16 +2,25 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 // Query is the scheduler query interface.
 type Query interface {
 	Validators(context.Context) ([]*scheduler.Validator, error)
+	ValidatorSetStatus(context.Context) (*scheduler.ValidatorSetStatus, error)
 	AllCommittees(context.Context) ([]*scheduler.Committee, error)
 	KindsCommittees(context.Context, []scheduler.CommitteeKind) ([]*scheduler.Committee, error)
 	Genesis(context.Context) (*scheduler.Genesis, error)
@@ -36,12 +45,24 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 		return nil, err
 	}
 
-	return &schedulerQuerier{state, regState}, nil
+	// The validator set status query needs access to the beacon and staking state.
+	beaconState, err := beaconState.NewImmutableState(ctx, sf.state, height)
+	if err != nil {
+		return nil, err
+	}
+	stakingState, err := stakingState.NewImmutableState(ctx, sf.state, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedulerQuerier{state, regState, beaconState, stakingState}, nil
 }
 
 type schedulerQuerier struct {
-	state    *schedulerState.ImmutableState
-	regState *registryState.ImmutableState
+	state        *schedulerState.ImmutableState
+	regState     *registryState.ImmutableState
+	beaconState  *beaconState.ImmutableState
+	stakingState *stakingState.ImmutableState
 }
 
 func (sq *schedulerQuerier) Validators(ctx context.Context) ([]*scheduler.Validator, error) {
@@ -58,6 +79,116 @@ func (sq *schedulerQuerier) Validators(ctx context.Context) ([]*scheduler.Valida
 	return ret, nil
 }
 
+func (sq *schedulerQuerier) ValidatorSetStatus(ctx context.Context) (*scheduler.ValidatorSetStatus, error) {
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	epoch, _, err := sq.beaconState.GetEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: failed to query current epoch: %w", err)
+	}
+	weakEntropy, err := sq.beaconState.Beacon(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: couldn't get beacon: %w", err)
+	}
+	var thresholds map[staking.ThresholdKind]quantity.Quantity
+	if !params.DebugBypassStake {
+		if thresholds, err = sq.stakingState.Thresholds(ctx); err != nil {
+			return nil, fmt.Errorf("cometbft/scheduler: failed to query thresholds: %w", err)
+		}
+	}
+	nodes, err := sq.regState.Nodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: couldn't get nodes: %w", err)
+	}
+
+	// Filter the node list the same way as the validator election does.
+	entities := make(map[staking.Address]bool)
+	entityNodes := make(map[staking.Address][]*node.Node)
+	balances := make(escrowBalances)
+	ineligible := make(map[staking.Address]bool)
+	for _, n := range nodes {
+		if !n.HasRoles(node.RoleValidator) || n.IsExpired(uint64(epoch)) {
+			continue
+		}
+		var nodeStatus *registry.NodeStatus
+		nodeStatus, err = sq.regState.NodeStatus(ctx, n.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cometbft/scheduler: couldn't get node status: %w", err)
+		}
+		if nodeStatus.IsFrozen() {
+			continue
+		}
+
+		entAddr := staking.NewAddress(n.EntityID)
+		if !params.DebugBypassStake {
+			if _, ok := balances[entAddr]; !ok {
+				var acct *staking.Account
+				acct, err = sq.stakingState.Account(ctx, entAddr)
+				if err != nil {
+					return nil, fmt.Errorf("cometbft/scheduler: failed to fetch account %s: %w", entAddr, err)
+				}
+				balances[entAddr] = acct.Escrow.Active.Balance.Clone()
+				ineligible[entAddr] = acct.Escrow.CheckStakeClaims(thresholds) != nil
+			}
+			if ineligible[entAddr] {
+				continue
+			}
+		}
+
+		entities[entAddr] = true
+		entityNodes[entAddr] = append(entityNodes[entAddr], n)
+	}
+
+	var balanceSrc escrowBalanceSource
+	if !params.DebugBypassStake {
+		balanceSrc = balances
+	}
+	sortedEntities, err := stakingAddressMapToSliceByStake(entities, balanceSrc, weakEntropy)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := sq.state.CurrentValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	votingPower := make(map[signature.PublicKey]int64, len(current))
+	for _, v := range current {
+		votingPower[v.ID] = v.VotingPower
+	}
+
+	status := &scheduler.ValidatorSetStatus{
+		MaxValidators:          params.MaxValidators,
+		MaxValidatorsPerEntity: params.MaxValidatorsPerEntity,
+	}
+	var numElected int
+	for rank, entAddr := range sortedEntities {
+		for i, n := range entityNodes[entAddr] {
+			candidate := &scheduler.ValidatorCandidate{
+				ID:          n.ID,
+				EntityID:    n.EntityID,
+				Rank:        uint64(rank),
+				VotingPower: votingPower[n.ID],
+				Elected:     i < params.MaxValidatorsPerEntity && numElected < params.MaxValidators,
+			}
+			if bal := balances[entAddr]; bal != nil {
+				candidate.Stake = *bal
+			}
+			if candidate.Elected {
+				numElected++
+				if numElected == params.MaxValidators && !params.DebugBypassStake {
+					status.Threshold = candidate.Stake.Clone()
+				}
+			}
+			status.Candidates = append(status.Candidates, candidate)
+		}
+	}
+
+	return status, nil
+}
+
 func (sq *schedulerQuerier) AllCommittees(ctx context.Context) ([]*scheduler.Committee, error) {
 	return sq.state.AllCommittees(ctx)
 }
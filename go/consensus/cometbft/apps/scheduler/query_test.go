@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestQueryValidatorSetStatus(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	beaconState := beaconState.NewMutableState(ctx.State())
+	err := beaconState.DebugForceSetBeacon(ctx, []byte("mock random beacon mock random beacon mock random beacon!!"))
+	require.NoError(err, "DebugForceSetBeacon")
+	err = beaconState.SetEpoch(ctx, 1, 1)
+	require.NoError(err, "SetEpoch")
+
+	err = stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	schedState := schedulerState.NewMutableState(ctx.State())
+	err = schedState.SetConsensusParameters(ctx, &scheduler.ConsensusParameters{
+		MinValidators:          1,
+		MaxValidators:          2,
+		MaxValidatorsPerEntity: 1,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	regState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	// Register entities with different amounts of stake, each running validator nodes.
+	var nodeIDs [][]signature.PublicKey
+	for i, tc := range []struct {
+		stake    uint64
+		numNodes int
+	}{
+		{300, 2},
+		{200, 1},
+		{100, 1},
+	} {
+		entitySigner := memorySigner.NewTestSigner("query test entity " + string(rune('A'+i)))
+		err = stakeState.SetAccount(ctx, staking.NewAddress(entitySigner.Public()), &staking.Account{
+			Escrow: staking.EscrowAccount{
+				Active: staking.SharePool{
+					Balance:     *quantity.NewFromUint64(tc.stake),
+					TotalShares: *quantity.NewFromUint64(tc.stake),
+				},
+			},
+		})
+		require.NoError(err, "SetAccount")
+
+		var ids []signature.PublicKey
+		for j := 0; j < tc.numNodes; j++ {
+			nodeSigner := memorySigner.NewTestSigner("query test node " + string(rune('A'+i)) + string(rune('0'+j)))
+			nod := &node.Node{
+				Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+				ID:         nodeSigner.Public(),
+				EntityID:   entitySigner.Public(),
+				Expiration: 10,
+				Consensus:  node.ConsensusInfo{ID: nodeSigner.Public()},
+				Roles:      node.RoleValidator,
+			}
+			sigNode, nErr := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+			require.NoError(nErr, "MultiSignNode")
+			err = regState.SetNode(ctx, nil, nod, sigNode)
+			require.NoError(err, "SetNode")
+			err = regState.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{})
+			require.NoError(err, "SetNodeStatus")
+
+			ids = append(ids, nod.ID)
+		}
+		nodeIDs = append(nodeIDs, ids)
+	}
+
+	// Make the node of the second entity a current validator.
+	err = schedState.PutCurrentValidators(ctx, map[signature.PublicKey]*scheduler.Validator{
+		nodeIDs[1][0]: {ID: nodeIDs[1][0], VotingPower: 42},
+	})
+	require.NoError(err, "PutCurrentValidators")
+
+	q, err := NewQueryFactory(appState).QueryAt(ctx, 1)
+	require.NoError(err, "QueryAt")
+	status, err := q.ValidatorSetStatus(ctx)
+	require.NoError(err, "ValidatorSetStatus")
+
+	require.Equal(2, status.MaxValidators)
+	require.Equal(1, status.MaxValidatorsPerEntity)
+	require.NotNil(status.Threshold, "threshold should be set when the validator set is full")
+	require.EqualValues(quantity.NewFromUint64(200), status.Threshold)
+	require.Len(status.Candidates, 4, "all validator nodes should be candidates")
+
+	elected := make(map[uint64]int)
+	for _, c := range status.Candidates {
+		switch c.Rank {
+		case 0:
+			require.Contains(nodeIDs[0], c.ID)
+			require.EqualValues(quantity.NewFromUint64(300), &c.Stake)
+		case 1:
+			require.Equal(nodeIDs[1][0], c.ID)
+			require.EqualValues(42, c.VotingPower, "current voting power should be reported")
+		case 2:
+			require.Equal(nodeIDs[2][0], c.ID)
+		default:
+			require.Fail("unexpected rank", "rank: %d", c.Rank)
+		}
+		if c.Elected {
+			elected[c.Rank]++
+		}
+	}
+	require.Equal(map[uint64]int{0: 1, 1: 1}, elected, "only the top two entities should be elected, one node each")
+}
@@ -40,8 +40,9 @@ var (
 	RNGContextValidators = []byte("EkS-ABCI-Validators")
 	RNGContextEntities   = []byte("EkS-ABCI-Entities")
 
-	RNGContextRoleWorker       = []byte("Worker")
-	RNGContextRoleBackupWorker = []byte("Backup-Worker")
+	RNGContextRoleWorker        = []byte("Worker")
+	RNGContextRoleBackupWorker  = []byte("Backup-Worker")
+	RNGContextRoleStandbyWorker = []byte("Standby-Worker")
 )
 
 type schedulerApplication struct {
@@ -375,25 +376,44 @@ func (app *schedulerApplication) isSuitableExecutorWorker(
 			if nrt.Capabilities.TEE == nil {
 				return false
 			}
-			if nrt.Capabilities.TEE.Hardware != rt.TEEHardware {
-				return false
+			if len(nrt.Capabilities.AdditionalTEEs) > 0 {
+				if teeCfg := registryParams.TEEFeatures; teeCfg == nil || !teeCfg.MultipleCapabilities {
+					return false
+				}
+				if err := nrt.Capabilities.ValidateBasic(); err != nil {
+					return false
+				}
 			}
-			if err := nrt.Capabilities.TEE.Verify(
-				registryParams.TEEFeatures,
-				ctx.Now(),
-				uint64(ctx.BlockHeight()),
-				activeDeployment.TEE,
-				n.node.ID,
-			); err != nil {
-				ctx.Logger().Warn("failed to verify node TEE attestation",
+			// During attestation transitions nodes may also advertise capabilities that are not
+			// valid for the active deployment, so only require one of the capabilities matching
+			// the runtime's TEE hardware to verify and ignore the others.
+			for _, tee := range nrt.Capabilities.TEEs() {
+				if tee.Hardware != rt.TEEHardware {
+					continue
+				}
+				err := tee.Verify(
+					registryParams.TEEFeatures,
+					ctx.Now(),
+					uint64(ctx.BlockHeight()),
+					activeDeployment.TEE,
+					n.node.ID,
+				)
+				if err == nil {
+					return true
+				}
+				ctx.Logger().Debug("failed to verify node TEE capability",
 					"err", err,
 					"node_id", n.node.ID,
-					"timestamp", ctx.Now(),
 					"runtime", rt.ID,
+					"rak", tee.RAK,
 				)
-				return false
 			}
-			return true
+			ctx.Logger().Warn("failed to verify node TEE attestation",
+				"node_id", n.node.ID,
+				"timestamp", ctx.Now(),
+				"runtime", rt.ID,
+			)
+			return false
 		}
 	}
 	return false
@@ -477,7 +497,11 @@ func (app *schedulerApplication) electValidators(
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/scheduler: couldn't get beacon: %w", err)
 	}
-	sortedEntities, err := stakingAddressMapToSliceByStake(entities, stakeAcc, weakEntropy)
+	var balances escrowBalanceSource
+	if stakeAcc != nil {
+		balances = stakeAcc
+	}
+	sortedEntities, err := stakingAddressMapToSliceByStake(entities, balances, weakEntropy)
 	if err != nil {
 		return nil, err
 	}
@@ -572,9 +596,26 @@ electLoop:
 	return validatorEntities, nil
 }
 
+// escrowBalanceSource is a source of escrow account balances.
+type escrowBalanceSource interface {
+	// GetEscrowBalance returns a given account's escrow balance.
+	GetEscrowBalance(addr staking.Address) (*quantity.Quantity, error)
+}
+
+// escrowBalances is an escrow balance source backed by a map of balances.
+type escrowBalances map[staking.Address]*quantity.Quantity
+
+func (eb escrowBalances) GetEscrowBalance(addr staking.Address) (*quantity.Quantity, error) {
+	bal, ok := eb[addr]
+	if !ok {
+		return nil, fmt.Errorf("no escrow balance for account %s", addr)
+	}
+	return bal, nil
+}
+
 func stakingAddressMapToSliceByStake(
 	entMap map[staking.Address]bool,
-	stakeAcc *stakingState.StakeAccumulatorCache,
+	balances escrowBalanceSource,
 	beacon []byte,
 ) ([]staking.Address, error) {
 	// Convert the map of entity's stake account addresses to a lexicographically
@@ -593,19 +634,19 @@ func stakingAddressMapToSliceByStake(
 		entities[i], entities[j] = entities[j], entities[i]
 	})
 
-	if stakeAcc == nil {
+	if balances == nil {
 		return entities, nil
 	}
 
 	// Stable-sort the shuffled slice by descending escrow balance.
 	var balanceErr error
 	sort.SliceStable(entities, func(i, j int) bool {
-		iBal, err := stakeAcc.GetEscrowBalance(entities[i])
+		iBal, err := balances.GetEscrowBalance(entities[i])
 		if err != nil {
 			balanceErr = err
 			return false
 		}
-		jBal, err := stakeAcc.GetEscrowBalance(entities[j])
+		jBal, err := balances.GetEscrowBalance(entities[j])
 		if err != nil {
 			balanceErr = err
 			return false
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -815,3 +816,118 @@ func TestElectCommittee(t *testing.T) {
 		require.NotNil(c, "Committee should have been elected (%s)", tc.msg)
 	}
 }
+
+func TestElectCommitteeStaggeredRotation(t *testing.T) {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock)
+	defer ctx.Close()
+
+	app := &schedulerApplication{
+		state: appState,
+	}
+
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	beaconState := beaconState.NewMutableState(ctx.State())
+	beaconParameters := &beacon.ConsensusParameters{
+		Backend: beacon.BackendInsecure,
+	}
+	registryParameters := &registry.ConsensusParameters{
+		EnableCommitteeRotation: true,
+	}
+
+	rtID := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
+	rt := registry.Runtime{
+		ID:   rtID,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:       4,
+			RotationPercent: 50,
+		},
+		Deployments: []*registry.VersionInfo{
+			{},
+		},
+	}
+
+	var nodes []*nodeWithStatus
+	for i := 0; i < 16; i++ {
+		var id signature.PublicKey
+		id[0] = byte(i + 1)
+		nodes = append(nodes, &nodeWithStatus{
+			node: &node.Node{
+				ID:       id,
+				Runtimes: []*node.Runtime{{ID: rtID}},
+				Roles:    node.RoleComputeWorker,
+			},
+			status: &registry.NodeStatus{},
+		})
+	}
+
+	var prev map[signature.PublicKey]bool
+	for epoch := beacon.EpochTime(1); epoch < 10; epoch++ {
+		err := beaconState.DebugForceSetBeacon(ctx, []byte(fmt.Sprintf("mock random beacon for epoch %d mock random beacon", epoch)))
+		require.NoError(err, "DebugForceSetBeacon")
+		err = beaconState.SetEpoch(ctx, epoch, int64(epoch))
+		require.NoError(err, "SetEpoch")
+
+		err = app.electCommittee(
+			ctx,
+			&scheduler.ConsensusParameters{},
+			beaconState,
+			beaconParameters,
+			registryParameters,
+			nil,
+			nil,
+			nil,
+			&rt,
+			nodes,
+			scheduler.KindComputeExecutor,
+		)
+		require.NoError(err, "committee election should not fail")
+
+		c, err := schedulerState.Committee(ctx, scheduler.KindComputeExecutor, rtID)
+		require.NoError(err, "Committee")
+		require.NotNil(c, "committee should have been elected")
+		require.Len(c.Members, 4)
+
+		members := make(map[signature.PublicKey]bool)
+		var retained int
+		for _, m := range c.Members {
+			members[m.PublicKey] = true
+			if prev[m.PublicKey] {
+				retained++
+			}
+		}
+		if prev != nil {
+			require.GreaterOrEqual(retained, 2, "half of the committee should be retained")
+		}
+		prev = members
+	}
+}
+
+func TestRetainPreviousMembers(t *testing.T) {
+	require := require.New(t)
+
+	var nodeList []*node.Node
+	for i := 0; i < 6; i++ {
+		var id signature.PublicKey
+		id[0] = byte(i + 1)
+		nodeList = append(nodeList, &node.Node{ID: id})
+	}
+	prevMembers := map[signature.PublicKey]bool{
+		nodeList[1].ID: true,
+		nodeList[3].ID: true,
+		nodeList[4].ID: true,
+	}
+	idxs := []int{5, 4, 0, 3, 2, 1}
+
+	// Rotate a quarter of four nodes, retaining three previous members.
+	require.Equal([]int{4, 3, 1, 5, 0, 2}, retainPreviousMembers(idxs, nodeList, prevMembers, 4, 25))
+	// Rotate half of four nodes, retaining two previous members in election order.
+	require.Equal([]int{4, 3, 5, 0, 2, 1}, retainPreviousMembers(idxs, nodeList, prevMembers, 4, 50))
+	// Rounding favors rotation.
+	require.Equal([]int{4, 5, 0, 3, 2, 1}, retainPreviousMembers(idxs, nodeList, prevMembers, 3, 50))
+	// Rotating everything keeps the election order.
+	require.Equal(idxs, retainPreviousMembers(idxs, nodeList, prevMembers, 4, 100))
+}
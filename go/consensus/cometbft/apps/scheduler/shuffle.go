@@ -164,11 +164,12 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		return nil
 	}
 
-	// Workers must be listed before backup workers, as other parts of the code depend on this
-	// order for better performance.
+	// Workers must be listed before backup and standby workers, as other parts of the code
+	// depend on this order for better performance.
 	committeeRoles := []scheduler.Role{
 		scheduler.RoleWorker,
 		scheduler.RoleBackupWorker,
+		scheduler.RoleStandbyWorker,
 	}
 
 	// Figure out the when (epoch) and how (beacon backend).
@@ -213,6 +214,10 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		isSuitableFn = app.isSuitableExecutorWorker
 		groupSizes[scheduler.RoleWorker] = int(rt.Executor.GroupSize)
 		groupSizes[scheduler.RoleBackupWorker] = int(rt.Executor.GroupBackupSize)
+		// Standby workers are not elected while they are disabled.
+		if registryParameters.EnableStandbyWorkers {
+			groupSizes[scheduler.RoleStandbyWorker] = int(rt.Executor.GroupStandbySize)
+		}
 	default:
 		return fmt.Errorf("cometbft/scheduler: invalid committee type: %v", kind)
 	}
@@ -285,6 +290,12 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				}
 			}
 
+			// Node features constraint, which is ignored while node features are disabled.
+			if fc := cs[role].Features; fc != nil && registryParameters.EnableNodeFeatures && !n.node.HasFeatures(fc.Required) {
+				// Not eligible if the node does not support all required features.
+				continue
+			}
+
 			nodeLists[role] = append(nodeLists[role], n.node)
 			eligible = true
 		}
@@ -297,13 +308,37 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		}
 	}
 
+	// If only a fraction of the committee should be rotated, fetch the previous committee so
+	// that some of its members can be retained. Rotation is ignored while it is disabled.
+	var prevMembers map[scheduler.Role]map[signature.PublicKey]bool
+	if rp := rt.Executor.RotationPercent; rp > 0 && rp < 100 && registryParameters.EnableCommitteeRotation {
+		var prevCommittee *scheduler.Committee
+		if prevCommittee, err = schedulerState.NewMutableState(ctx.State()).Committee(ctx, kind, rt.ID); err != nil {
+			return fmt.Errorf("cometbft/scheduler: failed to query previous committee: %w", err)
+		}
+		if prevCommittee != nil {
+			prevMembers = make(map[scheduler.Role]map[signature.PublicKey]bool)
+			for _, m := range prevCommittee.Members {
+				if prevMembers[m.Role] == nil {
+					prevMembers[m.Role] = make(map[signature.PublicKey]bool)
+				}
+				prevMembers[m.Role][m.PublicKey] = true
+			}
+		}
+	}
+
 	// Perform election.
 	var members []*scheduler.CommitteeNode
+	workers := make(map[signature.PublicKey]bool)
 	for _, role := range committeeRoles {
 		if groupSizes[role] == 0 {
 			continue
 		}
 
+		// Standby workers are elected on a best-effort basis, as the committee is functional
+		// even without them.
+		bestEffort := role == scheduler.RoleStandbyWorker
+
 		// Enforce the maximum node per-entity prior to doing the actual
 		// election to reduce "more nodes = more better" problems.  This
 		// will ensure fairness if the constraint is set to 1 (as is the
@@ -367,6 +402,9 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		}
 
 		wantedNodes := groupSizes[role]
+		if bestEffort {
+			wantedNodes = min(wantedNodes, nrNodes)
+		}
 		if wantedNodes > nrNodes {
 			ctx.Logger().Error("committee size exceeds available nodes",
 				"kind", kind,
@@ -395,6 +433,8 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				rngCtx = append(rngCtx, RNGContextRoleWorker...)
 			case scheduler.RoleBackupWorker:
 				rngCtx = append(rngCtx, RNGContextRoleBackupWorker...)
+			case scheduler.RoleStandbyWorker:
+				rngCtx = append(rngCtx, RNGContextRoleStandbyWorker...)
 			default:
 				return fmt.Errorf("cometbft/scheduler: unsupported role: %v", role)
 			}
@@ -425,6 +465,12 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			)
 		}
 
+		// If the committee is only partially rotated, make sure that eligible members
+		// of the previous committee are considered first.
+		if prev := prevMembers[role]; len(prev) > 0 {
+			idxs = retainPreviousMembers(idxs, nodeList, prev, wantedNodes, rt.Executor.RotationPercent)
+		}
+
 		// If the election is rigged for testing purposes, force-elect the
 		// nodes if possible.
 		ok, elected, forceState := app.debugForceElect(
@@ -456,6 +502,10 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				// Already elected to the committee by the debug forcing option.
 				continue
 			}
+			if role == scheduler.RoleStandbyWorker && workers[n.ID] {
+				// Standby workers must be able to replace workers.
+				continue
+			}
 
 			// Check election-time scheduling constraints.  In theory this
 			// is pre-enforced by restricting the number of eligible candidates
@@ -482,7 +532,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			})
 		}
 
-		if len(elected) != wantedNodes {
+		if len(elected) != wantedNodes && !bestEffort {
 			ctx.Logger().Error("insufficient nodes that satisfy constraints to elect",
 				"kind", kind,
 				"role", role,
@@ -509,6 +559,12 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			return nil
 		}
 
+		if role == scheduler.RoleWorker {
+			for _, n := range elected {
+				workers[n.PublicKey] = true
+			}
+		}
+
 		members = append(members, elected...)
 	}
 
@@ -524,6 +580,35 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	return nil
 }
 
+// retainPreviousMembers reorders the election order so that the members of the previous
+// committee that should be retained come first. The number of retained members is such that
+// at least the given percentage of the wanted nodes gets rotated. Which of the previous
+// members are retained is determined by their position in the election order.
+func retainPreviousMembers(
+	idxs []int,
+	nodeList []*node.Node,
+	prevMembers map[signature.PublicKey]bool,
+	wantedNodes int,
+	rotationPercent uint8,
+) []int {
+	rotated := (wantedNodes*int(rotationPercent) + 99) / 100
+	keep := wantedNodes - rotated
+	if keep <= 0 {
+		return idxs
+	}
+
+	retained := make([]int, 0, keep)
+	others := make([]int, 0, len(idxs))
+	for _, idx := range idxs {
+		if len(retained) < keep && prevMembers[nodeList[idx].ID] {
+			retained = append(retained, idx)
+			continue
+		}
+		others = append(others, idx)
+	}
+	return append(retained, others...)
+}
+
 func committeeVRFBetaIndexes(
 	prevState *beacon.PrevVRFState,
 	baseHasher *tuplehash.Hasher,
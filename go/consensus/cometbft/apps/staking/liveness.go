@@ -0,0 +1,109 @@
+package staking
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cometbft/cometbft/abci/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// updateValidatorLiveness tracks validators that did not sign the previous block and penalizes
+// the ones that missed too many blocks in the current liveness window.
+func (app *stakingApplication) updateValidatorLiveness(
+	ctx *abciAPI.Context,
+	regState *registryState.MutableState,
+	stakeState *stakingState.MutableState,
+	lastCommitInfo types.CommitInfo,
+) error {
+	params, err := stakeState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("loading consensus parameters: %w", err)
+	}
+	if params.ValidatorLivenessWindow == 0 {
+		// Liveness tracking is disabled. Any leftover state is cleared when the window changes.
+		return nil
+	}
+	if len(lastCommitInfo.Votes) == 0 {
+		return nil
+	}
+
+	// Resolve validator nodes that did not sign the previous block.
+	var missing []signature.PublicKey
+	nodes := make(map[signature.PublicKey]*node.Node)
+	for _, a := range lastCommitInfo.Votes {
+		if a.SignedLastBlock {
+			continue
+		}
+		valAddr := a.Validator.Address
+
+		n, err := regState.NodeByConsensusAddress(ctx, valAddr)
+		switch err {
+		case nil:
+		case registry.ErrNoSuchNode:
+			ctx.Logger().Warn("failed to get validator node",
+				"err", err,
+				"address", hex.EncodeToString(valAddr),
+			)
+			continue
+		default:
+			return err
+		}
+
+		missing = append(missing, n.ID)
+		nodes[n.ID] = n
+	}
+
+	liveness, err := stakeState.ValidatorLiveness(ctx)
+	if err != nil {
+		return fmt.Errorf("loading validator liveness info: %w", err)
+	}
+
+	exceeded, err := liveness.Update(missing, params.ValidatorLivenessMaxMissed)
+	if err != nil {
+		return err
+	}
+
+	// Only penalize validators in case a liveness penalty is configured.
+	if _, ok := params.Slashing[staking.SlashConsensusLiveness]; !ok {
+		exceeded = nil
+	}
+
+	for _, nodeID := range exceeded {
+		n := nodes[nodeID]
+		slashed, err := slashValidator(ctx, staking.SlashConsensusLiveness, n)
+		if err != nil {
+			return err
+		}
+		if !slashed {
+			continue
+		}
+
+		ctx.EmitEvent(abciAPI.NewEventBuilder(app.Name()).TypedAttribute(&staking.LivenessViolationEvent{
+			NodeID:       n.ID,
+			Owner:        staking.NewAddress(n.EntityID),
+			MissedBlocks: liveness.MissedByNode[nodeID],
+		}))
+	}
+
+	// Start a new window once the current one is complete.
+	if liveness.Total >= params.ValidatorLivenessWindow {
+		if err = stakeState.ClearValidatorLiveness(ctx); err != nil {
+			return fmt.Errorf("failed to clear validator liveness: %w", err)
+		}
+		return nil
+	}
+
+	if err = stakeState.SetValidatorLiveness(ctx, liveness); err != nil {
+		return fmt.Errorf("failed to set validator liveness: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package staking
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	tmcrypto "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestValidatorLiveness(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 42,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	regState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	// Add entity.
+	ent, entitySigner, _ := entity.TestEntity()
+	sigEntity, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, ent)
+	require.NoError(err, "SignEntity")
+	err = regState.SetEntity(ctx, ent, sigEntity)
+	require.NoError(err, "SetEntity")
+
+	// Add validator nodes.
+	var (
+		nodes []*node.Node
+		votes []types.VoteInfo
+	)
+	for _, name := range []string{"liveness test node 1", "liveness test node 2"} {
+		nodeSigner := memorySigner.NewTestSigner(name)
+		consensusID := memorySigner.NewTestSigner(name + " consensus").Public()
+		nod := &node.Node{
+			Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:        nodeSigner.Public(),
+			EntityID:  ent.ID,
+			Consensus: node.ConsensusInfo{
+				ID: consensusID,
+			},
+		}
+		sigNode, nerr := node.MultiSignNode([]signature.Signer{nodeSigner}, registry.RegisterNodeSignatureContext, nod)
+		require.NoError(nerr, "MultiSignNode")
+		err = regState.SetNode(ctx, nil, nod, sigNode)
+		require.NoError(err, "SetNode")
+		err = regState.SetNodeStatus(ctx, nod.ID, &registry.NodeStatus{})
+		require.NoError(err, "SetNodeStatus")
+
+		nodes = append(nodes, nod)
+		votes = append(votes, types.VoteInfo{
+			Validator: types.Validator{
+				Address: tmcrypto.PublicKeyToCometBFT(&consensusID).Address(),
+			},
+		})
+	}
+
+	// Give the entity some stake.
+	addr := staking.NewAddress(ent.ID)
+	err = stakeState.SetAccount(ctx, addr, &staking.Account{
+		Escrow: staking.EscrowAccount{
+			Active: staking.SharePool{
+				Balance:     *quantity.NewFromUint64(1000),
+				TotalShares: *quantity.NewFromUint64(1000),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		ValidatorLivenessWindow:    4,
+		ValidatorLivenessMaxMissed: 1,
+		Slashing: map[staking.SlashReason]staking.Slash{
+			staking.SlashConsensusLiveness: {
+				Amount:         *quantity.NewFromUint64(100),
+				FreezeInterval: 1,
+			},
+		},
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// The first node misses every block while the second node misses only one.
+	commitInfo := func(secondSigned bool) types.CommitInfo {
+		v := append([]types.VoteInfo{}, votes...)
+		v[1].SignedLastBlock = secondSigned
+		return types.CommitInfo{Votes: v}
+	}
+
+	err = app.updateValidatorLiveness(ctx, regState, stakeState, commitInfo(false))
+	require.NoError(err, "updateValidatorLiveness")
+	status, err := regState.NodeStatus(ctx, nodes[0].ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should not be frozen below the threshold")
+
+	err = app.updateValidatorLiveness(ctx, regState, stakeState, commitInfo(true))
+	require.NoError(err, "updateValidatorLiveness")
+
+	// The first node should be slashed and frozen.
+	status, err = regState.NodeStatus(ctx, nodes[0].ID)
+	require.NoError(err, "NodeStatus")
+	require.True(status.IsFrozen(), "node should be frozen after missing too many blocks")
+	require.EqualValues(43, status.FreezeEndTime)
+	status, err = regState.NodeStatus(ctx, nodes[1].ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should not be frozen below the threshold")
+
+	acct, err := stakeState.Account(ctx, addr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(900), acct.Escrow.Active.Balance, "entity stake should be slashed once")
+
+	var found bool
+	for _, ev := range ctx.GetEvents() {
+		for _, pair := range ev.GetAttributes() {
+			if string(pair.GetKey()) == (&staking.LivenessViolationEvent{}).EventKind() {
+				found = true
+			}
+		}
+	}
+	require.True(found, "liveness violation event should be emitted")
+
+	// Complete the window, the counters should be reset.
+	for i := 0; i < 2; i++ {
+		err = app.updateValidatorLiveness(ctx, regState, stakeState, commitInfo(true))
+		require.NoError(err, "updateValidatorLiveness")
+	}
+	liveness, err := stakeState.ValidatorLiveness(ctx)
+	require.NoError(err, "ValidatorLiveness")
+	require.EqualValues(0, liveness.Total, "liveness window should be reset")
+	require.Empty(liveness.MissedByNode, "liveness window should be reset")
+
+	// Stake should not be slashed again while the node is frozen.
+	acct, err = stakeState.Account(ctx, addr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(900), acct.Escrow.Active.Balance, "frozen validators should not be slashed")
+
+	// Without a liveness penalty, no violations should be reported.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		ValidatorLivenessWindow:    4,
+		ValidatorLivenessMaxMissed: 1,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	numEvents := countLivenessViolations(ctx)
+	for i := 0; i < 2; i++ {
+		err = app.updateValidatorLiveness(ctx, regState, stakeState, commitInfo(false))
+		require.NoError(err, "updateValidatorLiveness")
+	}
+	require.Equal(numEvents, countLivenessViolations(ctx), "liveness violation should not be reported without a penalty")
+	status, err = regState.NodeStatus(ctx, nodes[1].ID)
+	require.NoError(err, "NodeStatus")
+	require.False(status.IsFrozen(), "node should not be frozen without a penalty")
+
+	// Disabled liveness tracking should not touch the state.
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = app.updateValidatorLiveness(ctx, regState, stakeState, commitInfo(false))
+	require.NoError(err, "updateValidatorLiveness")
+	disabled, err := stakeState.ValidatorLiveness(ctx)
+	require.NoError(err, "ValidatorLiveness")
+	require.EqualValues(2, disabled.Total, "liveness state should not be updated while disabled")
+}
+
+func countLivenessViolations(ctx *abciAPI.Context) int {
+	var n int
+	for _, ev := range ctx.GetEvents() {
+		for _, pair := range ev.GetAttributes() {
+			if string(pair.GetKey()) == (&staking.LivenessViolationEvent{}).EventKind() {
+				n++
+			}
+		}
+	}
+	return n
+}
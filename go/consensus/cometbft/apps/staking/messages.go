@@ -5,6 +5,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -95,6 +96,13 @@ func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{
 		}
 	}
 
+	// Start a new liveness window whenever its size changes.
+	if changes.ValidatorLivenessWindow != nil && apply {
+		if err = state.ClearValidatorLiveness(ctx); err != nil {
+			return nil, fmt.Errorf("staking: failed to clear validator liveness: %w", err)
+		}
+	}
+
 	// Apply changes.
 	if apply {
 		if err = state.SetConsensusParameters(ctx, params); err != nil {
@@ -105,3 +113,31 @@ func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{
 	// Non-nil response signals that changes are valid and were successfully applied (if required).
 	return struct{}{}, nil
 }
+
+func (app *stakingApplication) releaseEntityEscrow(ctx *api.Context, msg interface{}) (interface{}, error) {
+	ent, ok := msg.(*entity.Entity)
+	if !ok {
+		return nil, fmt.Errorf("staking: failed to type assert deregistered entity")
+	}
+
+	state := stakingState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed to load consensus parameters: %w", err)
+	}
+
+	addr := staking.NewAddress(ent.ID)
+	delegation, err := state.Delegation(ctx, addr, addr)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed to fetch self-delegation: %w", err)
+	}
+	if delegation.Shares.IsZero() {
+		// Nothing to release.
+		return nil, nil
+	}
+
+	return app.startDebonding(ctx, state, params, addr, &staking.ReclaimEscrow{
+		Account: addr,
+		Shares:  delegation.Shares,
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
@@ -113,3 +115,58 @@ func TestChangeParameters(t *testing.T) {
 		require.EqualError(err, "staking: failed to validate consensus parameters: fee split proportions are all zero")
 	})
 }
+
+func TestReleaseEntityEscrow(t *testing.T) {
+	require := require.New(t)
+
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	state := stakingState.NewMutableState(ctx.State())
+	app := &stakingApplication{
+		state: appState,
+	}
+	err := state.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 5,
+	})
+	require.NoError(err, "setting consensus parameters should succeed")
+
+	ent := &entity.Entity{
+		ID: signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+	}
+	addr := staking.NewAddress(ent.ID)
+
+	// Entity without any escrow.
+	res, err := app.releaseEntityEscrow(ctx, ent)
+	require.NoError(err, "releasing empty escrow should succeed")
+	require.Nil(res, "releasing empty escrow should not start debonding")
+
+	// Entity with self-delegated escrow.
+	var acct staking.Account
+	var delegation staking.Delegation
+	_, err = acct.Escrow.Active.Deposit(&delegation.Shares, quantity.NewFromUint64(1000), quantity.NewFromUint64(1000))
+	require.NoError(err, "Deposit")
+	require.NoError(state.SetAccount(ctx, addr, &acct), "SetAccount")
+	require.NoError(state.SetDelegation(ctx, addr, addr, &delegation), "SetDelegation")
+
+	res, err = app.releaseEntityEscrow(ctx, ent)
+	require.NoError(err, "releasing escrow should succeed")
+	result, ok := res.(*staking.ReclaimEscrowResult)
+	require.True(ok, "result should be a reclaim escrow result")
+	require.Equal(*quantity.NewFromUint64(1000), result.Amount, "whole escrow should start debonding")
+	require.True(result.RemainingShares.IsZero(), "no shares should remain")
+	require.EqualValues(15, result.DebondEndTime, "escrow should be released after the debonding interval")
+
+	deb, err := state.DebondingDelegation(ctx, addr, addr, result.DebondEndTime)
+	require.NoError(err, "DebondingDelegation")
+	require.Equal(result.DebondingShares, deb.Shares, "debonding delegation should be scheduled")
+
+	// Invalid message.
+	_, err = app.releaseEntityEscrow(ctx, "entity")
+	require.EqualError(err, "staking: failed to type assert deregistered entity")
+}
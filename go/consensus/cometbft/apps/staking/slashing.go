@@ -8,6 +8,7 @@ import (
 	cmtcrypto "github.com/cometbft/cometbft/crypto"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
@@ -21,7 +22,6 @@ func onEvidenceByzantineConsensus(
 	addr cmtcrypto.Address,
 ) error {
 	regState := registryState.NewMutableState(ctx.State())
-	stakeState := stakingState.NewMutableState(ctx.State())
 
 	// Resolve consensus node. Note that in order for this to work even in light
 	// of node expirations, the node descriptor must be available for at least
@@ -35,13 +35,27 @@ func onEvidenceByzantineConsensus(
 		return nil
 	}
 
+	_, err = slashValidator(ctx, reason, node)
+	return err
+}
+
+// slashValidator slashes and freezes the given validator node according to the slashing
+// penalty configured for the given reason. It returns true iff the validator has been slashed.
+func slashValidator(
+	ctx *abciAPI.Context,
+	reason staking.SlashReason,
+	node *node.Node,
+) (bool, error) {
+	regState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+
 	nodeStatus, err := regState.NodeStatus(ctx, node.ID)
 	if err != nil {
 		ctx.Logger().Warn("failed to get validator node status",
 			"err", err,
 			"node_id", node.ID,
 		)
-		return nil
+		return false, nil
 	}
 
 	// Do not slash a frozen validator.
@@ -51,7 +65,7 @@ func onEvidenceByzantineConsensus(
 			"entity_id", node.EntityID,
 			"freeze_end_time", nodeStatus.FreezeEndTime,
 		)
-		return nil
+		return false, nil
 	}
 
 	// Retrieve the slash procedure.
@@ -60,7 +74,7 @@ func onEvidenceByzantineConsensus(
 		ctx.Logger().Error("failed to get slashing table entry",
 			"err", err,
 		)
-		return err
+		return false, err
 	}
 
 	penalty := st[reason]
@@ -71,7 +85,7 @@ func onEvidenceByzantineConsensus(
 		var epoch beacon.EpochTime
 		epoch, err = ctx.AppState().GetEpoch(context.Background(), ctx.BlockHeight()+1)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		// Check for overflow.
@@ -91,7 +105,7 @@ func onEvidenceByzantineConsensus(
 			"node_id", node.ID,
 			"entity_id", node.EntityID,
 		)
-		return err
+		return false, err
 	}
 
 	if err = regState.SetNodeStatus(ctx, node.ID, nodeStatus); err != nil {
@@ -100,7 +114,7 @@ func onEvidenceByzantineConsensus(
 			"node_id", node.ID,
 			"entity_id", node.EntityID,
 		)
-		return err
+		return false, err
 	}
 
 	ctx.Logger().Warn("slashed validator",
@@ -109,5 +123,5 @@ func onEvidenceByzantineConsensus(
 		"entity_id", node.EntityID,
 	)
 
-	return nil
+	return true, nil
 }
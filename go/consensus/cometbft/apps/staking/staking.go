@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
+	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
@@ -54,6 +55,7 @@ func (app *stakingApplication) OnRegister(state api.ApplicationState, md api.Mes
 	md.Subscribe(roothashApi.RuntimeMessageStaking, app)
 	md.Subscribe(governanceApi.MessageChangeParameters, app)
 	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
+	md.Subscribe(registryApi.MessageEntityDeregistered, app)
 }
 
 func (app *stakingApplication) OnCleanup() {
@@ -97,6 +99,11 @@ func (app *stakingApplication) BeginBlock(ctx *api.Context) error {
 		return fmt.Errorf("staking: failed to update epoch signing info: %w", err)
 	}
 
+	// Track validator liveness.
+	if err = app.updateValidatorLiveness(ctx, regState, stakeState, lastCommitInfo); err != nil {
+		return fmt.Errorf("staking: failed to update validator liveness: %w", err)
+	}
+
 	// Iterate over any submitted evidence of a validator misbehaving. Note that
 	// the actual evidence has already been verified by CometBFT to be valid.
 	for _, evidence := range ctx.BlockContext().ValidatorMisbehavior {
@@ -145,6 +152,9 @@ func (app *stakingApplication) ExecuteMessage(ctx *api.Context, kind, msg interf
 		// A change parameters proposal has just been accepted and closed. Validate and apply
 		// changes.
 		return app.changeParameters(ctx, msg, true)
+	case registryApi.MessageEntityDeregistered:
+		// An entity has been deregistered. Schedule the release of its escrow.
+		return app.releaseEntityEscrow(ctx, msg)
 	default:
 		return nil, staking.ErrInvalidArgument
 	}
@@ -209,6 +219,27 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.withdraw(ctx, state, &withdraw)
 		return err
+	case staking.MethodBatchEscrow:
+		var batch staking.BatchEscrow
+		if err := cbor.Unmarshal(tx.Body, &batch); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.batchEscrow(ctx, state, &batch)
+	case staking.MethodVestingTransfer:
+		var xfer staking.VestingTransfer
+		if err := cbor.Unmarshal(tx.Body, &xfer); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.vestingTransfer(ctx, state, &xfer)
+	case staking.MethodAllowVesting:
+		var allow staking.AllowVesting
+		if err := cbor.Unmarshal(tx.Body, &allow); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.allowVesting(ctx, state, &allow)
 	default:
 		return staking.ErrInvalidArgument
 	}
@@ -90,6 +90,16 @@ func AuthenticateAndPayFees(
 		return staking.ErrBalanceTooLow
 	}
 
+	// Fees cannot be paid from balance that is still locked by the vesting schedule.
+	if err = EnsureUnlocked(ctx, account, &fee.Amount); err != nil {
+		logger.Error("account balance locked",
+			"account_addr", addr,
+			"fee_amount", fee.Amount,
+			"err", err,
+		)
+		return err
+	}
+
 	if ctx.IsCheckOnly() {
 		// Configure gas accountant on the context so that we can report gas wanted.
 		ctx.SetGasAccountant(abciAPI.NewGasAccountant(fee.Gas))
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestAuthenticateAndPayFeesVesting(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+	err := s.SetConsensusParameters(ctx, &staking.ConsensusParameters{})
+	require.NoError(err, "SetConsensusParameters")
+
+	signer := memorySigner.NewTestSigner("consensus/cometbft/apps/staking/state: vesting fee signer")
+	addr := staking.NewAddress(signer.Public())
+	err = s.SetAccount(ctx, addr, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(1_000),
+			Vesting: &staking.VestingSchedule{
+				Total: *quantity.NewFromUint64(1_000),
+				Steps: []staking.VestingStep{{Epoch: 20, Amount: *quantity.NewFromUint64(1_000)}},
+			},
+		},
+	})
+	require.NoError(err, "SetAccount")
+
+	fee := &transaction.Fee{Amount: *quantity.NewFromUint64(100)}
+	for _, mode := range []abciAPI.ContextMode{abciAPI.ContextCheckTx, abciAPI.ContextDeliverTx} {
+		txCtx := appState.NewContext(mode)
+		err = AuthenticateAndPayFees(txCtx, signer.Public(), 0, fee)
+		require.ErrorIs(err, staking.ErrBalanceLocked, "paying fees from a fully locked account should fail")
+		txCtx.Close()
+	}
+
+	// Once the balance unlocks, fees can be paid.
+	appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 20,
+	})
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	err = AuthenticateAndPayFees(txCtx, signer.Public(), 0, fee)
+	require.NoError(err, "paying fees from unlocked balance should succeed")
+
+	acct, err := s.Account(ctx, addr)
+	require.NoError(err, "Account")
+	require.EqualValues(*quantity.NewFromUint64(900), acct.General.Balance, "fee should be paid")
+	require.EqualValues(1, acct.General.Nonce, "nonce should be incremented")
+}
@@ -80,6 +80,11 @@ var (
 	// Value is empty.
 	commissionScheduleAddressesKeyFmt = consensus.KeyFormat.New(0x5B, &staking.Address{})
 
+	// validatorLivenessKeyFmt is the key format for validator liveness information.
+	//
+	// Value is CBOR-serialized ValidatorLiveness.
+	validatorLivenessKeyFmt = consensus.KeyFormat.New(0x5C)
+
 	logger = logging.GetLogger("cometbft/staking")
 )
 
@@ -585,6 +590,63 @@ func (s *ImmutableState) EpochSigning(ctx context.Context) (*EpochSigning, error
 	return &es, nil
 }
 
+// ValidatorLiveness is the validator liveness information for the current liveness window.
+type ValidatorLiveness struct {
+	// Total is the number of blocks in the current window.
+	Total uint64
+	// MissedByNode is the number of blocks each validator node did not sign in the current
+	// window.
+	MissedByNode map[signature.PublicKey]uint64
+}
+
+// Update records a new block and increments the missed block counters of the given validator
+// nodes. It returns the nodes whose counters exceeded the given maximum with this update.
+func (vl *ValidatorLiveness) Update(missingNodes []signature.PublicKey, maxMissed uint64) ([]signature.PublicKey, error) {
+	oldTotal := vl.Total
+	vl.Total = oldTotal + 1
+	if vl.Total <= oldTotal {
+		return nil, fmt.Errorf("incrementing total blocks count: overflow, old_total=%d", oldTotal)
+	}
+
+	var exceeded []signature.PublicKey
+	for _, nodeID := range missingNodes {
+		oldCount := vl.MissedByNode[nodeID]
+		vl.MissedByNode[nodeID] = oldCount + 1
+		if vl.MissedByNode[nodeID] <= oldCount {
+			return nil, fmt.Errorf("incrementing count for node %s: overflow, old_count=%d", nodeID, oldCount)
+		}
+		// Only report crossing the threshold so each node is penalized at most once per window.
+		if vl.MissedByNode[nodeID] == maxMissed+1 {
+			exceeded = append(exceeded, nodeID)
+		}
+	}
+
+	return exceeded, nil
+}
+
+// ValidatorLiveness returns the validator liveness information for the current liveness window.
+func (s *ImmutableState) ValidatorLiveness(ctx context.Context) (*ValidatorLiveness, error) {
+	value, err := s.is.Get(ctx, validatorLivenessKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if value == nil {
+		// Not present means zero everything.
+		return &ValidatorLiveness{
+			MissedByNode: make(map[signature.PublicKey]uint64),
+		}, nil
+	}
+
+	var vl ValidatorLiveness
+	if err = cbor.Unmarshal(value, &vl); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if vl.MissedByNode == nil {
+		vl.MissedByNode = make(map[signature.PublicKey]uint64)
+	}
+	return &vl, nil
+}
+
 func NewImmutableState(ctx context.Context, state abciAPI.ApplicationQueryState, version int64) (*ImmutableState, error) {
 	is, err := abciAPI.NewImmutableState(ctx, state, version)
 	if err != nil {
@@ -752,6 +814,16 @@ func (s *MutableState) ClearEpochSigning(ctx context.Context) error {
 	return abciAPI.UnavailableStateError(err)
 }
 
+func (s *MutableState) SetValidatorLiveness(ctx context.Context, vl *ValidatorLiveness) error {
+	err := s.ms.Insert(ctx, validatorLivenessKeyFmt.Encode(), cbor.Marshal(vl))
+	return abciAPI.UnavailableStateError(err)
+}
+
+func (s *MutableState) ClearValidatorLiveness(ctx context.Context) error {
+	err := s.ms.Remove(ctx, validatorLivenessKeyFmt.Encode())
+	return abciAPI.UnavailableStateError(err)
+}
+
 func (s *MutableState) SetGovernanceDeposits(ctx context.Context, q *quantity.Quantity) error {
 	err := s.ms.Insert(ctx, governanceDepositsKeyFmt.Encode(), cbor.Marshal(q))
 	return abciAPI.UnavailableStateError(err)
@@ -861,6 +933,9 @@ func (s *MutableState) Transfer(ctx *abciAPI.Context, fromAddr, toAddr staking.A
 		return err
 	}
 
+	if err = EnsureUnlocked(ctx, from, amount); err != nil {
+		return err
+	}
 	if err = quantity.Move(&to.General.Balance, &from.General.Balance, amount); err != nil {
 		return staking.ErrInsufficientBalance
 	}
@@ -905,6 +980,27 @@ func (s *MutableState) Transfer(ctx *abciAPI.Context, fromAddr, toAddr staking.A
 	return nil
 }
 
+// EnsureUnlocked returns ErrBalanceLocked in case debiting the given amount from the general
+// balance of the given account would spend balance that is still locked by its vesting schedule.
+func EnsureUnlocked(ctx *abciAPI.Context, acct *staking.Account, amount *quantity.Quantity) error {
+	if acct.General.Vesting == nil {
+		return nil
+	}
+	// Let the caller report insufficient balance as usual.
+	if acct.General.Balance.Cmp(amount) < 0 {
+		return nil
+	}
+
+	epoch, err := ctx.AppState().GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return fmt.Errorf("cometbft/staking: failed to get current epoch: %w", err)
+	}
+	if acct.General.Transferable(epoch).Cmp(amount) < 0 {
+		return staking.ErrBalanceLocked
+	}
+	return nil
+}
+
 // Computes commission for a given rate and total amount. Returns the commission and the remaining amount.
 func (s *MutableState) computeCommission(ctx context.Context, rate *quantity.Quantity, total *quantity.Quantity) (*quantity.Quantity, *quantity.Quantity, error) {
 	if rate == nil {
@@ -1079,6 +1175,9 @@ func (s *MutableState) TransferToGovernanceDeposits(
 		return fmt.Errorf("cometbft/staking: failed to query governance deposit for deposit %w", err)
 	}
 
+	if err = EnsureUnlocked(ctx, from, amount); err != nil {
+		return err
+	}
 	if err = quantity.Move(deposits, &from.General.Balance, amount); err != nil {
 		return fmt.Errorf("cometbft/staking: failed to transfer to governance deposits, from: %s: %w", fromAddr, err)
 	}
@@ -1191,14 +1290,8 @@ func (s *MutableState) AddRewards(
 	if err != nil {
 		return err
 	}
-	var activeStep *staking.RewardStep
-	for i, step := range steps {
-		if time < step.Until {
-			activeStep = &steps[i]
-			break
-		}
-	}
-	if activeStep == nil {
+	scale := staking.RewardScale(steps, time)
+	if scale == nil {
 		// We're past the end of the schedule.
 		return nil
 	}
@@ -1208,6 +1301,10 @@ func (s *MutableState) AddRewards(
 		return fmt.Errorf("cometbft/staking: loading common pool: %w", err)
 	}
 
+	var (
+		total      quantity.Quantity
+		recipients uint64
+	)
 	for _, addr := range addresses {
 		var ent *staking.Account
 		ent, err = s.Account(ctx, addr)
@@ -1220,7 +1317,7 @@ func (s *MutableState) AddRewards(
 		if err = q.Mul(factor); err != nil {
 			return fmt.Errorf("cometbft/staking: failed multiplying by reward factor: %w", err)
 		}
-		if err = q.Mul(&activeStep.Scale); err != nil {
+		if err = q.Mul(scale); err != nil {
 			return fmt.Errorf("cometbft/staking: failed multiplying by reward step scale: %w", err)
 		}
 		if err = q.Quo(staking.RewardAmountDenominator); err != nil {
@@ -1235,6 +1332,10 @@ func (s *MutableState) AddRewards(
 			// Not enough balance left in common pool, skip.
 			continue
 		}
+		if err = total.Add(q); err != nil {
+			return fmt.Errorf("cometbft/staking: failed to add reward to total: %w", err)
+		}
+		recipients++
 
 		rate := ent.Escrow.CommissionSchedule.CurrentRate(time)
 		var com *quantity.Quantity
@@ -1299,6 +1400,16 @@ func (s *MutableState) AddRewards(
 		return fmt.Errorf("cometbft/staking: failed to set common pool: %w", err)
 	}
 
+	if recipients > 0 {
+		ctx.EmitEvent(abciAPI.NewEventBuilder(AppName).TypedAttribute(&staking.RewardDisbursementEvent{
+			Epoch:      time,
+			Factor:     *factor,
+			Scale:      *scale,
+			Amount:     total,
+			Recipients: recipients,
+		}))
+	}
+
 	return nil
 }
 
@@ -275,27 +275,27 @@ func TestRewardsWithoutEnoughInCommonPool(t *testing.T) {
 
 	require.NoError(s.AddRewards(ctx, 10, mustInitQuantityP(t, 100_000), escrowAddrAsList), "add rewards 1")
 	evs := ctx.GetEvents()
-	require.Len(evs, 3, "adding rewards should emit 3 events")
+	require.Len(evs, 4, "adding rewards should emit 3 escrow and transfer events and a reward disbursement event")
 
 	require.NoError(s.AddRewards(ctx, 30, mustInitQuantityP(t, 100_000), escrowAddrAsList), "add rewards 2")
 	evs = ctx.GetEvents()
-	require.Len(evs, 6, "adding rewards should emit 3 new events")
+	require.Len(evs, 8, "adding rewards should emit 3 new escrow and transfer events and a new reward disbursement event")
 
 	require.NoError(s.AddRewards(ctx, 35, mustInitQuantityP(t, 100_000), escrowAddrAsList), "add rewards 3")
 	evs = ctx.GetEvents()
-	require.Len(evs, 6, "adding rewards with not enough in common pool should not emit any new events")
+	require.Len(evs, 8, "adding rewards with not enough in common pool should not emit any new events")
 
 	require.NoError(s.AddRewards(ctx, 38, mustInitQuantityP(t, 80_000), escrowAddrAsList), "add rewards 4")
 	evs = ctx.GetEvents()
-	require.Len(evs, 6, "adding rewards with not enough in common pool should not emit any new events")
+	require.Len(evs, 8, "adding rewards with not enough in common pool should not emit any new events")
 
 	require.NoError(s.AddRewardSingleAttenuated(ctx, 10, mustInitQuantityP(t, 10_000), 5, 10, escrowAddr), "add rewards attenuated 1")
 	evs = ctx.GetEvents()
-	require.Len(evs, 9, "adding attenuated rewards should emit 3 new events")
+	require.Len(evs, 11, "adding attenuated rewards should emit 3 new escrow and transfer events and no reward disbursement event")
 
 	require.NoError(s.AddRewardSingleAttenuated(ctx, 10, mustInitQuantityP(t, 100_000), 5, 10, escrowAddr), "add rewards attenuated 2")
 	evs = ctx.GetEvents()
-	require.Len(evs, 9, "adding attenuated rewards with not enough in common pool should not emit any new events")
+	require.Len(evs, 11, "adding attenuated rewards with not enough in common pool should not emit any new events")
 }
 
 func TestRewardAndSlash(t *testing.T) {
@@ -390,7 +390,7 @@ func TestRewardAndSlash(t *testing.T) {
 
 	// Adding rewards should emit the correct events.
 	evs := ctx.GetEvents()
-	require.Len(evs, 3, "adding rewards should emit 3 events")
+	require.Len(evs, 4, "adding rewards should emit 4 events")
 	for _, ev := range evs {
 		require.Equal(abciAPI.EventTypeForApp(AppName), ev.Type, "all emitted events should be staking events")
 		require.Len(ev.Attributes, 1, "each event should have a single attribute")
@@ -404,6 +404,13 @@ func TestRewardAndSlash(t *testing.T) {
 			var v staking.TransferEvent
 			err = events.DecodeValue(ev.Attributes[0].Value, &v)
 			require.NoError(err, "malformed transfer event")
+		case "reward_disbursement":
+			var v staking.RewardDisbursementEvent
+			err = events.DecodeValue(ev.Attributes[0].Value, &v)
+			require.NoError(err, "malformed reward disbursement event")
+			require.EqualValues(10, v.Epoch, "reward disbursement event epoch")
+			require.Equal(mustInitQuantity(t, 100), v.Amount, "reward disbursement event amount")
+			require.EqualValues(1, v.Recipients, "reward disbursement event recipients")
 		default:
 			t.Fatalf("unexpected event key: %+v", ev.Attributes[0].Key)
 		}
@@ -411,6 +418,7 @@ func TestRewardAndSlash(t *testing.T) {
 	require.Equal("add_escrow", evs[0].Attributes[0].Key, "first event should be an add escrow event")
 	require.Equal("transfer", evs[1].Attributes[0].Key, "second event should be a transfer event")
 	require.Equal("add_escrow", evs[2].Attributes[0].Key, "second event should be an add escrow event")
+	require.Equal("reward_disbursement", evs[3].Attributes[0].Key, "last event should be a reward disbursement event")
 
 	// 100% gain.
 	delegatorAccount, err = s.Account(ctx, delegatorAddr)
@@ -22,9 +22,37 @@ func isTransferPermitted(params *staking.ConsensusParameters, fromAddr staking.A
 	return
 }
 
+// checkMinTransferAmount checks that the given amount is at least the minimum transfer amount.
+//
+// It is used to reject dust transfers already in CheckTx so that they never enter the mempool.
+func checkMinTransferAmount(ctx *api.Context, state *stakingState.MutableState, amount *quantity.Quantity) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if amount.Cmp(&params.MinTransferAmount) < 0 {
+		return staking.ErrUnderMinTransferAmount
+	}
+	return nil
+}
+
+// checkMinDelegationAmount checks that the given amount is at least the minimum delegation amount.
+//
+// It is used to reject dust delegations already in CheckTx so that they never enter the mempool.
+func checkMinDelegationAmount(ctx *api.Context, state *stakingState.MutableState, amount *quantity.Quantity) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if amount.Cmp(&params.MinDelegationAmount) < 0 {
+		return staking.ErrUnderMinDelegationAmount
+	}
+	return nil
+}
+
 func (app *stakingApplication) transfer(ctx *api.Context, state *stakingState.MutableState, xfer *staking.Transfer) (*staking.TransferResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil, nil
+		return nil, checkMinTransferAmount(ctx, state, &xfer.Amount)
 	}
 
 	// Charge gas for this transaction.
@@ -120,6 +148,9 @@ func (app *stakingApplication) transferImpl(
 		if err != nil {
 			return fmt.Errorf("failed to fetch account: %w", err)
 		}
+		if err = stakingState.EnsureUnlocked(ctx, from, &xfer.Amount); err != nil {
+			return err
+		}
 		if err = quantity.Move(&to.General.Balance, &from.General.Balance, &xfer.Amount); err != nil {
 			ctx.Logger().Debug("Transfer: failed to move balance",
 				"err", err,
@@ -162,7 +193,7 @@ func (app *stakingApplication) transferImpl(
 
 func (app *stakingApplication) burn(ctx *api.Context, state *stakingState.MutableState, burn *staking.Burn) error {
 	if ctx.IsCheckOnly() {
-		return nil
+		return checkMinTransferAmount(ctx, state, &burn.Amount)
 	}
 
 	// Charge gas for this transaction.
@@ -209,6 +240,9 @@ func (app *stakingApplication) burnImpl(
 		return fmt.Errorf("failed to fetch account: %w", err)
 	}
 
+	if err = stakingState.EnsureUnlocked(ctx, from, amount); err != nil {
+		return err
+	}
 	if err = from.General.Balance.Sub(amount); err != nil {
 		ctx.Logger().Error("Burn: failed to burn stake",
 			"err", err,
@@ -257,7 +291,7 @@ func (app *stakingApplication) burnImpl(
 
 func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.MutableState, escrow *staking.Escrow) (*staking.AddEscrowResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil, nil
+		return nil, checkMinDelegationAmount(ctx, state, &escrow.Amount)
 	}
 
 	// Charge gas for this transaction.
@@ -279,16 +313,28 @@ func (app *stakingApplication) addEscrow(ctx *api.Context, state *stakingState.M
 		return nil, nil
 	}
 
-	// Check if sender provided at least a minimum amount of stake.
-	if escrow.Amount.Cmp(&params.MinDelegationAmount) < 0 {
-		return nil, staking.ErrUnderMinDelegationAmount
-	}
-
 	fromAddr := ctx.CallerAddress()
 	if fromAddr.IsReserved() {
 		return nil, staking.ErrForbidden
 	}
 
+	return app.depositEscrow(ctx, state, params, fromAddr, escrow)
+}
+
+// depositEscrow moves the given amount of stake from the owner's general balance into the active
+// escrow pool of the escrow account.
+func (app *stakingApplication) depositEscrow(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+	fromAddr staking.Address,
+	escrow *staking.Escrow,
+) (*staking.AddEscrowResult, error) {
+	// Check if sender provided at least a minimum amount of stake.
+	if escrow.Amount.Cmp(&params.MinDelegationAmount) < 0 {
+		return nil, staking.ErrUnderMinDelegationAmount
+	}
+
 	from, err := state.Account(ctx, fromAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
@@ -408,6 +454,18 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 		return nil, staking.ErrForbidden
 	}
 
+	return app.startDebonding(ctx, state, params, toAddr, reclaim)
+}
+
+// startDebonding moves the given delegation shares of the owner from the active escrow pool into
+// the debonding pool, scheduling the stake release after the debonding interval.
+func (app *stakingApplication) startDebonding(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+	toAddr staking.Address,
+	reclaim *staking.ReclaimEscrow,
+) (*staking.ReclaimEscrowResult, error) {
 	to, err := state.Account(ctx, toAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
@@ -531,6 +589,89 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 	}, nil
 }
 
+func (app *stakingApplication) batchEscrow(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	batch *staking.BatchEscrow,
+) error {
+	if err := batch.ValidateBasic(); err != nil {
+		return err
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+
+	// Batches are disabled in case max batch escrow operations is zero. Check the number of
+	// operations before charging gas for each of them.
+	if params.MaxBatchEscrowOperations == 0 {
+		return staking.ErrForbidden
+	}
+	if len(batch.Operations) > int(params.MaxBatchEscrowOperations) {
+		return staking.ErrTooManyEscrowOperations
+	}
+
+	if ctx.IsCheckOnly() {
+		for i, op := range batch.Operations {
+			if op.AddEscrow == nil {
+				continue
+			}
+			if err := checkMinDelegationAmount(ctx, state, &op.AddEscrow.Amount); err != nil {
+				return fmt.Errorf("operation %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	// Charge gas for each operation of this transaction.
+	for _, op := range batch.Operations {
+		gasOp := staking.GasOpAddEscrow
+		if op.ReclaimEscrow != nil {
+			gasOp = staking.GasOpReclaimEscrow
+		}
+		if err = ctx.Gas().UseGas(1, gasOp, params.GasCosts); err != nil {
+			return err
+		}
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	addr := ctx.CallerAddress()
+	if addr.IsReserved() {
+		return staking.ErrForbidden
+	}
+
+	// Start a new transaction and rollback in case any of the operations fails.
+	ctx = ctx.NewTransaction()
+	defer ctx.Close()
+
+	state = stakingState.NewMutableState(ctx.State())
+
+	for i, op := range batch.Operations {
+		switch {
+		case op.AddEscrow != nil:
+			_, err = app.depositEscrow(ctx, state, params, addr, op.AddEscrow)
+		case op.ReclaimEscrow != nil:
+			_, err = app.startDebonding(ctx, state, params, addr, op.ReclaimEscrow)
+		}
+		if err != nil {
+			ctx.Logger().Debug("BatchEscrow: escrow operation failed",
+				"err", err,
+				"operation", i,
+			)
+			return err
+		}
+	}
+
+	ctx.Commit()
+
+	return nil
+}
+
 func (app *stakingApplication) amendCommissionSchedule(
 	ctx *api.Context,
 	state *stakingState.MutableState,
@@ -705,7 +846,7 @@ func (app *stakingApplication) withdraw(
 	withdraw *staking.Withdraw,
 ) (*staking.WithdrawResult, error) {
 	if ctx.IsCheckOnly() {
-		return nil, nil
+		return nil, checkMinTransferAmount(ctx, state, &withdraw.Amount)
 	}
 
 	// Charge gas for this transaction.
@@ -792,6 +933,9 @@ func (app *stakingApplication) withdraw(
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
 	}
 
+	if err = stakingState.EnsureUnlocked(ctx, from, &withdraw.Amount); err != nil {
+		return nil, err
+	}
 	if err = quantity.Move(&to.General.Balance, &from.General.Balance, &withdraw.Amount); err != nil {
 		return nil, staking.ErrInsufficientBalance
 	}
@@ -844,3 +988,235 @@ func (app *stakingApplication) withdraw(
 		AmountChange: withdraw.Amount,
 	}, nil
 }
+
+func (app *stakingApplication) vestingTransfer(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	xfer *staking.VestingTransfer,
+) error {
+	if err := staking.ValidateVestingSteps(xfer.Schedule); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		amount, err := xfer.Amount()
+		if err != nil {
+			return staking.ErrInvalidArgument
+		}
+		return checkMinTransferAmount(ctx, state, amount)
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpVestingTransfer, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Vesting transfers are disabled in case max vesting steps is zero.
+	if params.MaxVestingSteps == 0 {
+		return staking.ErrForbidden
+	}
+
+	// Validate addresses -- if either is reserved or both are equal, the method should fail.
+	fromAddr := ctx.CallerAddress()
+	if fromAddr.IsReserved() || xfer.To.IsReserved() || !isTransferPermitted(params, fromAddr) {
+		return staking.ErrForbidden
+	}
+	if fromAddr.Equal(xfer.To) {
+		return staking.ErrInvalidArgument
+	}
+
+	// All steps must unlock in the future.
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return err
+	}
+	if xfer.Schedule[0].Epoch <= epoch {
+		return fmt.Errorf("%w: vesting step unlocks in the past", staking.ErrInvalidArgument)
+	}
+
+	amount, err := xfer.Amount()
+	if err != nil {
+		return staking.ErrInvalidArgument
+	}
+	if amount.Cmp(&params.MinTransferAmount) < 0 {
+		return staking.ErrUnderMinTransferAmount
+	}
+
+	from, err := state.Account(ctx, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+	to, err := state.Account(ctx, xfer.To)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	// The destination account must allow the source account to transfer the given amount.
+	allowance, ok := to.General.VestingAllowances[fromAddr]
+	if !ok || allowance.Cmp(amount) < 0 {
+		return staking.ErrVestingNotAllowed
+	}
+	if err = allowance.Sub(amount); err != nil {
+		return fmt.Errorf("failed to subtract vesting allowance: %w", err)
+	}
+	if allowance.IsZero() {
+		delete(to.General.VestingAllowances, fromAddr)
+	} else {
+		to.General.VestingAllowances[fromAddr] = allowance
+	}
+
+	if err = stakingState.EnsureUnlocked(ctx, from, amount); err != nil {
+		return err
+	}
+	if err = quantity.Move(&to.General.Balance, &from.General.Balance, amount); err != nil {
+		return staking.ErrInsufficientBalance
+	}
+
+	// Lock the transferred amount at the destination.
+	if to.General.Vesting == nil {
+		to.General.Vesting = &staking.VestingSchedule{}
+	}
+	to.General.Vesting.Prune(epoch)
+	if err = to.General.Vesting.Add(xfer.Schedule); err != nil {
+		return fmt.Errorf("failed to update vesting schedule: %w", err)
+	}
+	if len(to.General.Vesting.Steps) > int(params.MaxVestingSteps) {
+		return staking.ErrTooManyVestingSteps
+	}
+
+	// Check against minimum balance.
+	if from.General.Balance.Cmp(&params.MinTransactBalance) < 0 {
+		ctx.Logger().Debug("after vesting transfer source account balance too low",
+			"account_addr", fromAddr,
+			"account_balance", from.General.Balance,
+			"min_transact_balance", params.MinTransactBalance,
+		)
+		return errors.WithContext(staking.ErrBalanceTooLow, "source account")
+	}
+	if to.General.Balance.Cmp(&params.MinTransactBalance) < 0 {
+		ctx.Logger().Debug("after vesting transfer dest account balance too low",
+			"account_addr", xfer.To,
+			"account_balance", to.General.Balance,
+			"min_transact_balance", params.MinTransactBalance,
+		)
+		return errors.WithContext(staking.ErrBalanceTooLow, "dest account")
+	}
+
+	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+	if err = state.SetAccount(ctx, xfer.To, to); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+
+	ctx.Logger().Debug("VestingTransfer: executed vesting transfer",
+		"from", fromAddr,
+		"to", xfer.To,
+		"amount", amount,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+		From:   fromAddr,
+		To:     xfer.To,
+		Amount: *amount,
+	}))
+
+	return nil
+}
+
+func (app *stakingApplication) allowVesting(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	allow *staking.AllowVesting,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpAllowVesting, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Vesting allowances are disabled in case either vesting transfers or allowances are disabled.
+	if params.MaxVestingSteps == 0 || params.MaxAllowances == 0 {
+		return staking.ErrForbidden
+	}
+
+	// Validate addresses -- if either is reserved or both are equal, the method should fail.
+	addr := ctx.CallerAddress()
+	if addr.IsReserved() || allow.Sender.IsReserved() {
+		return staking.ErrForbidden
+	}
+	if addr.Equal(allow.Sender) {
+		return staking.ErrInvalidArgument
+	}
+
+	acct, err := state.Account(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	if acct.General.VestingAllowances == nil {
+		acct.General.VestingAllowances = make(map[staking.Address]quantity.Quantity)
+	}
+	allowance := acct.General.VestingAllowances[allow.Sender]
+	switch allow.Negative {
+	case false:
+		// Add.
+		if err = allowance.Add(&allow.AmountChange); err != nil {
+			return fmt.Errorf("failed to add vesting allowance: %w", err)
+		}
+	case true:
+		// Subtract.
+		if _, err = allowance.SubUpTo(&allow.AmountChange); err != nil {
+			return fmt.Errorf("failed to subtract vesting allowance: %w", err)
+		}
+	}
+
+	// Fail if the new allowance is greater than total supply.
+	totalSupply, err := state.TotalSupply(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load total supply: %w", err)
+	}
+	if allowance.Cmp(totalSupply) > 0 {
+		return staking.ErrAllowanceGreaterThanSupply
+	}
+
+	if allowance.IsZero() {
+		// In case the new allowance is equal to zero, remove it.
+		delete(acct.General.VestingAllowances, allow.Sender)
+	} else {
+		// Otherwise update the allowance.
+		acct.General.VestingAllowances[allow.Sender] = allowance
+	}
+
+	// If updating vesting allowances would go past the maximum number of allowances, fail.
+	if uint32(len(acct.General.VestingAllowances)) > params.MaxAllowances {
+		return staking.ErrTooManyAllowances
+	}
+
+	if err = state.SetAccount(ctx, addr, acct); err != nil {
+		return fmt.Errorf("failed to set account: %w", err)
+	}
+
+	return nil
+}
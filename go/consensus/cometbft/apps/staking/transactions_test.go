@@ -1,6 +1,7 @@
 package staking
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -120,6 +122,87 @@ func TestReservedAddresses(t *testing.T) {
 	require.Nil(withdrawResult, "withdraw result should be nil on error")
 }
 
+func TestCheckTxMinAmounts(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		MinTransferAmount:        *quantity.NewFromUint64(100),
+		MinDelegationAmount:      *quantity.NewFromUint64(1_000),
+		MaxBatchEscrowOperations: 10,
+	})
+	require.NoError(err, "setting staking consensus parameters should not error")
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	txCtx := appState.NewContext(abciAPI.ContextCheckTx)
+	defer txCtx.Close()
+
+	dst := staking.NewAddress(signature.NewPublicKey("dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd"))
+	below := *quantity.NewFromUint64(99)
+	above := *quantity.NewFromUint64(1_000)
+
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: dst, Amount: below})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "transfer below minimum should fail in CheckTx")
+	_, err = app.transfer(txCtx, stakeState, &staking.Transfer{To: dst, Amount: above})
+	require.NoError(err, "transfer above minimum should pass CheckTx")
+
+	err = app.burn(txCtx, stakeState, &staking.Burn{Amount: below})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "burn below minimum should fail in CheckTx")
+	err = app.burn(txCtx, stakeState, &staking.Burn{Amount: above})
+	require.NoError(err, "burn above minimum should pass CheckTx")
+
+	_, err = app.withdraw(txCtx, stakeState, &staking.Withdraw{From: dst, Amount: below})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "withdraw below minimum should fail in CheckTx")
+	_, err = app.withdraw(txCtx, stakeState, &staking.Withdraw{From: dst, Amount: above})
+	require.NoError(err, "withdraw above minimum should pass CheckTx")
+
+	_, err = app.addEscrow(txCtx, stakeState, &staking.Escrow{Account: dst, Amount: *quantity.NewFromUint64(999)})
+	require.ErrorIs(err, staking.ErrUnderMinDelegationAmount, "escrow below minimum should fail in CheckTx")
+	_, err = app.addEscrow(txCtx, stakeState, &staking.Escrow{Account: dst, Amount: above})
+	require.NoError(err, "escrow above minimum should pass CheckTx")
+
+	err = app.batchEscrow(txCtx, stakeState, &staking.BatchEscrow{
+		Operations: []staking.EscrowOperation{
+			{AddEscrow: &staking.Escrow{Account: dst, Amount: above}},
+			{AddEscrow: &staking.Escrow{Account: dst, Amount: *quantity.NewFromUint64(999)}},
+		},
+	})
+	require.ErrorIs(err, staking.ErrUnderMinDelegationAmount, "batch escrow below minimum should fail in CheckTx")
+	err = app.batchEscrow(txCtx, stakeState, &staking.BatchEscrow{
+		Operations: []staking.EscrowOperation{
+			{AddEscrow: &staking.Escrow{Account: dst, Amount: above}},
+			{ReclaimEscrow: &staking.ReclaimEscrow{Account: dst, Shares: *quantity.NewFromUint64(1)}},
+		},
+	})
+	require.NoError(err, "batch escrow above minimum should pass CheckTx")
+
+	err = app.vestingTransfer(txCtx, stakeState, &staking.VestingTransfer{
+		To: dst,
+		Schedule: []staking.VestingStep{
+			{Epoch: 10, Amount: *quantity.NewFromUint64(50)},
+			{Epoch: 20, Amount: *quantity.NewFromUint64(49)},
+		},
+	})
+	require.ErrorIs(err, staking.ErrUnderMinTransferAmount, "vesting transfer below minimum should fail in CheckTx")
+	err = app.vestingTransfer(txCtx, stakeState, &staking.VestingTransfer{
+		To: dst,
+		Schedule: []staking.VestingStep{
+			{Epoch: 10, Amount: *quantity.NewFromUint64(50)},
+			{Epoch: 20, Amount: *quantity.NewFromUint64(50)},
+		},
+	})
+	require.NoError(err, "vesting transfer above minimum should pass CheckTx")
+}
+
 func TestAllow(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -763,6 +846,164 @@ func TestAddEscrow(t *testing.T) {
 	}
 }
 
+func TestBatchEscrow(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	reservedPK := signature.NewPublicKey("badaabffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	_ = staking.NewReservedAddress(reservedPK)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+
+	add := func(to staking.Address, amount uint64) staking.EscrowOperation {
+		return staking.EscrowOperation{AddEscrow: &staking.Escrow{
+			Account: to,
+			Amount:  *quantity.NewFromUint64(amount),
+		}}
+	}
+	reclaim := func(from staking.Address, shares uint64) staking.EscrowOperation {
+		return staking.EscrowOperation{ReclaimEscrow: &staking.ReclaimEscrow{
+			Account: from,
+			Shares:  *quantity.NewFromUint64(shares),
+		}}
+	}
+	enabledParams := &staking.ConsensusParameters{
+		DebondingInterval:        1,
+		MaxBatchEscrowOperations: 4,
+	}
+
+	for _, tc := range []struct {
+		msg      string
+		params   *staking.ConsensusParameters
+		txSigner signature.PublicKey
+		batch    *staking.BatchEscrow
+		err      error
+	}{
+		{
+			"should fail with an empty batch",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail with a malformed operation",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{{}}},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when batches are disabled",
+			&staking.ConsensusParameters{},
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{add(addr2, 1000)}},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail with too many operations",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{
+				add(addr2, 1000), add(addr2, 1000), add(addr2, 1000), add(addr2, 1000), add(addr2, 1000),
+			}},
+			staking.ErrTooManyEscrowOperations,
+		},
+		{
+			"should fail when using reserved address",
+			enabledParams,
+			reservedPK,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{add(addr2, 1000)}},
+			staking.ErrForbidden,
+		},
+		{
+			"should succeed when escrowing to multiple accounts",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{add(addr2, 10_000), add(addr3, 20_000)}},
+			nil,
+		},
+		{
+			"should fail atomically when any of the operations fails",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{add(addr2, 10_000), add(addr3, 1_000_000)}},
+			quantity.ErrInsufficientBalance,
+		},
+		{
+			"should succeed when rebalancing between accounts",
+			enabledParams,
+			pk1,
+			&staking.BatchEscrow{Operations: []staking.EscrowOperation{reclaim(addr3, 5_000), add(addr2, 5_000)}},
+			nil,
+		},
+	} {
+		err = stakeState.SetConsensusParameters(ctx, tc.params)
+		require.NoError(err, "setting staking consensus parameters should not error")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(tc.txSigner)
+
+		err = app.batchEscrow(txCtx, stakeState, tc.batch)
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+
+	// Make sure that oversized batches are rejected before gas is charged for each operation.
+	err = stakeState.SetConsensusParameters(ctx, enabledParams)
+	require.NoError(err, "setting staking consensus parameters should not error")
+	oversized := &staking.BatchEscrow{Operations: []staking.EscrowOperation{
+		add(addr2, 1000), add(addr2, 1000), add(addr2, 1000), add(addr2, 1000), add(addr2, 1000),
+	}}
+	for _, kind := range []abciAPI.ContextMode{abciAPI.ContextCheckTx, abciAPI.ContextSimulateTx} {
+		txCtx := appState.NewContext(kind)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+		gas := abciAPI.NewGasAccountant(transaction.Gas(math.MaxUint64))
+		txCtx.SetGasAccountant(gas)
+
+		err = app.batchEscrow(txCtx, stakeState, oversized)
+		require.ErrorIs(err, staking.ErrTooManyEscrowOperations, "oversized batch should be rejected")
+		require.Zero(gas.GasUsed(), "no gas should be charged for an oversized batch")
+	}
+
+	// Make sure that only the successful batches have been applied.
+	acct1, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account1")
+	require.EqualValues(*quantity.NewFromUint64(65_000), acct1.General.Balance, "general balance should be correct")
+
+	acct2, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account2")
+	require.EqualValues(*quantity.NewFromUint64(15_000), acct2.Escrow.Active.Balance, "active escrow should be correct")
+
+	acct3, err := stakeState.Account(ctx, addr3)
+	require.NoError(err, "Account3")
+	require.EqualValues(*quantity.NewFromUint64(15_000), acct3.Escrow.Active.Balance, "active escrow should be correct")
+	require.EqualValues(*quantity.NewFromUint64(5_000), acct3.Escrow.Debonding.Balance, "debonding escrow should be correct")
+}
+
 func TestAllowEscrowMessages(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -1114,3 +1355,306 @@ func TestAmendCommissionSchedule(t *testing.T) {
 	}}))
 	require.NoError(app.amendCommissionSchedule(txCtx, stakeState, amendment), "amending commission schedule for address with enough stake should work")
 }
+
+func TestVestingTransfer(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 10,
+	})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	reservedPK := signature.NewPublicKey("badaacffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	reservedAddr := staking.NewReservedAddress(reservedPK)
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+
+	step := func(epoch beacon.EpochTime, amount uint64) staking.VestingStep {
+		return staking.VestingStep{Epoch: epoch, Amount: *quantity.NewFromUint64(amount)}
+	}
+	enabledParams := &staking.ConsensusParameters{
+		MaxVestingSteps: 2,
+		MaxAllowances:   1,
+	}
+
+	// The destination account allows vesting transfers from the source account.
+	err = stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(10_000_000))
+	require.NoError(err, "SetTotalSupply")
+	err = stakeState.SetConsensusParameters(ctx, enabledParams)
+	require.NoError(err, "SetConsensusParameters")
+	allowCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer allowCtx.Close()
+	allowCtx.SetTxSigner(pk2)
+	err = app.allowVesting(allowCtx, stakeState, &staking.AllowVesting{Sender: addr1, AmountChange: *quantity.NewFromUint64(2_000_000)})
+	require.NoError(err, "allowing vesting transfers should succeed")
+
+	for _, tc := range []struct {
+		msg      string
+		params   *staking.ConsensusParameters
+		txSigner signature.PublicKey
+		xfer     *staking.VestingTransfer
+		err      error
+	}{
+		{
+			"should fail with an empty schedule",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail with unsorted steps",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(20, 1000), step(15, 1000)}},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when vesting transfers are disabled",
+			&staking.ConsensusParameters{},
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(15, 1000)}},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when using reserved address",
+			enabledParams,
+			reservedPK,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(15, 1000)}},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when transferring to reserved address",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: reservedAddr, Schedule: []staking.VestingStep{step(15, 1000)}},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when transferring to self",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr1, Schedule: []staking.VestingStep{step(15, 1000)}},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when a step unlocks in the past",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(10, 1000)}},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail without a vesting allowance",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr3, Schedule: []staking.VestingStep{step(15, 1000)}},
+			staking.ErrVestingNotAllowed,
+		},
+		{
+			"should fail when exceeding the vesting allowance",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(15, 3_000_000)}},
+			staking.ErrVestingNotAllowed,
+		},
+		{
+			"should fail with insufficient balance",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(15, 1_000_000)}},
+			staking.ErrInsufficientBalance,
+		},
+		{
+			"should succeed",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(15, 10_000), step(20, 20_000)}},
+			nil,
+		},
+		{
+			"should succeed when merging steps",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(20, 5_000)}},
+			nil,
+		},
+		{
+			"should fail with too many vesting steps",
+			enabledParams,
+			pk1,
+			&staking.VestingTransfer{To: addr2, Schedule: []staking.VestingStep{step(25, 5_000)}},
+			staking.ErrTooManyVestingSteps,
+		},
+	} {
+		err = stakeState.SetConsensusParameters(ctx, tc.params)
+		require.NoError(err, "setting staking consensus parameters should not error")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(tc.txSigner)
+
+		err = app.vestingTransfer(txCtx, stakeState, tc.xfer)
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+
+	acct1, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account1")
+	require.EqualValues(*quantity.NewFromUint64(65_000), acct1.General.Balance, "general balance should be correct")
+
+	acct2, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account2")
+	require.EqualValues(*quantity.NewFromUint64(35_000), acct2.General.Balance, "general balance should be correct")
+	require.NotNil(acct2.General.Vesting, "vesting schedule should be set")
+	require.EqualValues(*quantity.NewFromUint64(35_000), acct2.General.Vesting.Total, "vesting total should be correct")
+	require.Equal([]staking.VestingStep{step(15, 10_000), step(20, 25_000)}, acct2.General.Vesting.Steps)
+	require.EqualValues(*quantity.NewFromUint64(1_965_000), acct2.General.VestingAllowances[addr1], "vesting allowance should be consumed")
+
+	err = stakeState.SetConsensusParameters(ctx, enabledParams)
+	require.NoError(err, "SetConsensusParameters")
+
+	transfer := func(amount uint64) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk2)
+
+		_, err := app.transfer(txCtx, stakeState, &staking.Transfer{To: addr3, Amount: *quantity.NewFromUint64(amount)})
+		return err
+	}
+
+	// Locked balance cannot be transferred.
+	err = transfer(1_000)
+	require.ErrorIs(err, staking.ErrBalanceLocked, "transferring locked balance should fail")
+
+	// Locked balance can be escrowed.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk2)
+	_, err = app.addEscrow(txCtx, stakeState, &staking.Escrow{Account: addr3, Amount: *quantity.NewFromUint64(5_000)})
+	require.NoError(err, "escrowing locked balance should succeed")
+
+	// After the first step unlocks, only the unlocked part can be transferred.
+	appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 15,
+	})
+	err = transfer(10_000)
+	require.ErrorIs(err, staking.ErrBalanceLocked, "transferring locked balance should fail")
+	err = transfer(5_000)
+	require.NoError(err, "transferring unlocked balance should succeed")
+
+	// After the last step unlocks, everything can be transferred.
+	appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		CurrentEpoch: 20,
+	})
+	err = transfer(25_000)
+	require.NoError(err, "transferring unlocked balance should succeed")
+}
+
+func TestAllowVesting(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	err = stakeState.SetTotalSupply(ctx, quantity.NewFromUint64(1_000))
+	require.NoError(err, "SetTotalSupply")
+
+	enabledParams := &staking.ConsensusParameters{
+		MaxVestingSteps: 2,
+		MaxAllowances:   1,
+	}
+
+	for _, tc := range []struct {
+		msg    string
+		params *staking.ConsensusParameters
+		allow  *staking.AllowVesting
+		err    error
+	}{
+		{
+			"should fail when vesting transfers are disabled",
+			&staking.ConsensusParameters{MaxAllowances: 1},
+			&staking.AllowVesting{Sender: addr2, AmountChange: *quantity.NewFromUint64(10)},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when allowing self",
+			enabledParams,
+			&staking.AllowVesting{Sender: addr1, AmountChange: *quantity.NewFromUint64(10)},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when exceeding total supply",
+			enabledParams,
+			&staking.AllowVesting{Sender: addr2, AmountChange: *quantity.NewFromUint64(10_000)},
+			staking.ErrAllowanceGreaterThanSupply,
+		},
+		{
+			"should succeed",
+			enabledParams,
+			&staking.AllowVesting{Sender: addr2, AmountChange: *quantity.NewFromUint64(100)},
+			nil,
+		},
+		{
+			"should fail with too many vesting allowances",
+			enabledParams,
+			&staking.AllowVesting{Sender: addr3, AmountChange: *quantity.NewFromUint64(100)},
+			staking.ErrTooManyAllowances,
+		},
+		{
+			"should succeed when subtracting",
+			enabledParams,
+			&staking.AllowVesting{Sender: addr2, Negative: true, AmountChange: *quantity.NewFromUint64(40)},
+			nil,
+		},
+	} {
+		err = stakeState.SetConsensusParameters(ctx, tc.params)
+		require.NoError(err, "setting staking consensus parameters should not error")
+
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(pk1)
+
+		err = app.allowVesting(txCtx, stakeState, tc.allow)
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+
+	acct, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account")
+	require.Len(acct.General.VestingAllowances, 1, "only one vesting allowance should be set")
+	require.EqualValues(*quantity.NewFromUint64(60), acct.General.VestingAllowances[addr2], "vesting allowance should be correct")
+}
@@ -51,6 +51,9 @@ type Config struct {
 	// Supplementary sanity checks configuration.
 	SupplementarySanity SupplementarySanityConfig `yaml:"supplementary_sanity,omitempty"`
 
+	// Staking account event index configuration.
+	StakingEventIndex StakingEventIndexConfig `yaml:"staking_event_index,omitempty"`
+
 	// Enable CometBFT debug logs (very verbose).
 	LogDebug bool `yaml:"log_debug,omitempty"`
 
@@ -134,6 +137,12 @@ type SupplementarySanityConfig struct {
 	Interval uint64 `yaml:"interval"`
 }
 
+// StakingEventIndexConfig is the staking account event index configuration structure.
+type StakingEventIndexConfig struct {
+	// Enable indexing of staking events by account.
+	Enabled bool `yaml:"enabled"`
+}
+
 // DebugConfig is the debug configuration structure.
 type DebugConfig struct {
 	// Allow non-routable addresses in P2P address book.
@@ -146,6 +155,12 @@ type DebugConfig struct {
 
 	// Disable populating seed node address book with genesis validators.
 	DisableAddrBookFromGenesis bool `yaml:"disable_addr_book_from_genesis,omitempty"`
+
+	// Offset of the clock used to timestamp signed votes and proposals.
+	ClockOffset time.Duration `yaml:"clock_offset,omitempty"`
+	// Rate at which the clock used to timestamp signed votes and proposals runs
+	// relative to the local clock (0 means the same rate).
+	ClockDriftRate float64 `yaml:"clock_drift_rate,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -180,6 +195,10 @@ func (c *Config) Validate() error {
 	if c.SupplementarySanity.Enabled && c.SupplementarySanity.Interval < 1 {
 		return fmt.Errorf("supplementary_sanity.interval must be >= 1")
 	}
+
+	if c.Debug.ClockDriftRate < 0 {
+		return fmt.Errorf("debug.clock_drift_rate must be >= 0")
+	}
 	return nil
 }
 
@@ -228,6 +247,9 @@ func DefaultConfig() Config {
 			Enabled:  false,
 			Interval: 10,
 		},
+		StakingEventIndex: StakingEventIndexConfig{
+			Enabled: false,
+		},
 		LogDebug: false,
 		Debug: DebugConfig{
 			P2PAddrBookLenient:              false,
@@ -0,0 +1,97 @@
+package crypto
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	cmtcrypto "github.com/cometbft/cometbft/crypto"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+// clockSkewPrivVal is a private validator that signs votes and proposals with
+// timestamps taken from a skewed clock.
+//
+// CometBFT derives block times from the timestamps of the signed precommits, so
+// skewing them is equivalent to skewing the node's consensus time source.
+type clockSkewPrivVal struct {
+	sync.Mutex
+
+	inner cmttypes.PrivValidator
+
+	start     time.Time
+	offset    time.Duration
+	driftRate float64
+
+	lastTimestamp time.Time
+}
+
+func (pv *clockSkewPrivVal) GetPubKey() (cmtcrypto.PubKey, error) {
+	return pv.inner.GetPubKey()
+}
+
+func (pv *clockSkewPrivVal) SignVote(chainID string, vote *cmtproto.Vote) error {
+	pv.Lock()
+	defer pv.Unlock()
+
+	vote.Timestamp = pv.now()
+	if err := pv.inner.SignVote(chainID, vote); err != nil {
+		return err
+	}
+	pv.lastTimestamp = vote.Timestamp
+
+	return nil
+}
+
+func (pv *clockSkewPrivVal) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	pv.Lock()
+	defer pv.Unlock()
+
+	proposal.Timestamp = pv.now()
+	if err := pv.inner.SignProposal(chainID, proposal); err != nil {
+		return err
+	}
+	pv.lastTimestamp = proposal.Timestamp
+
+	return nil
+}
+
+// now returns the current time of the skewed clock, which never goes back.
+//
+// The timestamp provided by CometBFT is ignored, as it is bounded from below by the
+// (already skewed) time of the last block and would otherwise be skewed repeatedly.
+func (pv *clockSkewPrivVal) now() time.Time {
+	now := time.Now()
+
+	elapsed := now.Sub(pv.start)
+	if pv.driftRate > 0 {
+		elapsed = time.Duration(float64(elapsed) * pv.driftRate)
+	}
+	ts := pv.start.Add(pv.offset + elapsed)
+
+	if !ts.After(pv.lastTimestamp) {
+		ts = pv.lastTimestamp.Add(time.Millisecond)
+	}
+
+	// Use the canonical time representation, as CometBFT does.
+	return ts.Round(0).UTC()
+}
+
+// NewClockSkewPrivVal wraps the given private validator so that all signed votes and
+// proposals carry timestamps from a clock that is offset by the given duration and runs
+// at the given rate relative to the local clock (0 means the same rate).
+//
+// This is only meant for testing how the network copes with skewed clocks.
+func NewClockSkewPrivVal(pv cmttypes.PrivValidator, offset time.Duration, driftRate float64) (cmttypes.PrivValidator, error) {
+	if driftRate < 0 {
+		return nil, fmt.Errorf("cometbft/crypto: clock drift rate must be non-negative")
+	}
+
+	return &clockSkewPrivVal{
+		inner:     pv,
+		start:     time.Now(),
+		offset:    offset,
+		driftRate: driftRate,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewPrivVal(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewClockSkewPrivVal(cmttypes.NewMockPV(), 0, -1)
+	require.Error(err, "negative drift rate should be rejected")
+
+	offset := time.Hour
+	pv, err := NewClockSkewPrivVal(cmttypes.NewMockPV(), offset, 0)
+	require.NoError(err, "NewClockSkewPrivVal")
+
+	// Timestamps provided by CometBFT are replaced with skewed ones.
+	vote := &cmtproto.Vote{
+		Type:      cmtproto.PrevoteType,
+		Height:    1,
+		Timestamp: time.Now(),
+	}
+	before := time.Now()
+	err = pv.SignVote("test", vote)
+	require.NoError(err, "SignVote")
+	require.WithinDuration(before.Add(offset), vote.Timestamp, time.Second)
+
+	proposal := &cmtproto.Proposal{
+		Type:      cmtproto.ProposalType,
+		Height:    2,
+		Timestamp: time.Now(),
+	}
+	err = pv.SignProposal("test", proposal)
+	require.NoError(err, "SignProposal")
+	require.True(proposal.Timestamp.After(vote.Timestamp), "timestamps should be monotonic")
+
+	// Negative offsets never make the clock go back.
+	pv, err = NewClockSkewPrivVal(cmttypes.NewMockPV(), -offset, 0)
+	require.NoError(err, "NewClockSkewPrivVal")
+	var last time.Time
+	for h := int64(1); h <= 3; h++ {
+		vote = &cmtproto.Vote{
+			Type:   cmtproto.PrecommitType,
+			Height: h,
+		}
+		err = pv.SignVote("test", vote)
+		require.NoError(err, "SignVote")
+		require.WithinDuration(time.Now().Add(-offset), vote.Timestamp, time.Second)
+		require.True(vote.Timestamp.After(last), "timestamps should be monotonic")
+		last = vote.Timestamp
+	}
+
+	// Drift makes the clock run faster.
+	pv, err = NewClockSkewPrivVal(cmttypes.NewMockPV(), 0, 1000)
+	require.NoError(err, "NewClockSkewPrivVal")
+	time.Sleep(10 * time.Millisecond)
+	vote = &cmtproto.Vote{
+		Type:   cmtproto.PrevoteType,
+		Height: 1,
+	}
+	err = pv.SignVote("test", vote)
+	require.NoError(err, "SignVote")
+	require.True(vote.Timestamp.After(time.Now().Add(9*time.Second)), "clock should drift")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 
@@ -233,8 +234,12 @@ func (n *commonNode) initialize() error {
 	n.serviceClients = append(n.serviceClients, scRegistry)
 	n.svcMgr.RegisterCleanupOnly(n.registry, "registry backend")
 
+	var stakingEventIndexPath string
+	if config.GlobalConfig.Consensus.StakingEventIndex.Enabled {
+		stakingEventIndexPath = filepath.Join(n.dataDir, common.StateDir, "data", tmstaking.EventIndexDBName)
+	}
 	var scStaking tmstaking.ServiceClient
-	if scStaking, err = tmstaking.New(n.parentNode); err != nil {
+	if scStaking, err = tmstaking.New(n.parentNode, stakingEventIndexPath); err != nil {
 		n.Logger.Error("staking: failed to initialize staking backend",
 			"err", err,
 		)
@@ -486,6 +491,11 @@ func (n *commonNode) SetTransactionAuthHandler(handler api.TransactionAuthHandle
 	return n.mux.SetTransactionAuthHandler(handler)
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SetMultisigAuthHandler(handler api.MultisigAuthHandler) error {
+	return n.mux.SetMultisigAuthHandler(handler)
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) TransactionAuthHandler() consensusAPI.TransactionAuthHandler {
 	return n.mux.TransactionAuthHandler()
@@ -945,6 +955,11 @@ func (n *commonNode) SubmitTxWithProof(context.Context, *transaction.SignedTrans
 	return nil, consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SubmitMultiSignedTx(context.Context, *transaction.MultiSignedTransaction) error {
+	return consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetUnconfirmedTransactions(context.Context) ([][]byte, error) {
 	return nil, consensusAPI.ErrUnsupported
@@ -965,6 +980,11 @@ func (n *commonNode) RegisterP2PService(p2pAPI.Service) error {
 	return consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetPeers(context.Context) ([]*consensusAPI.PeerInfo, error) {
+	return nil, consensusAPI.ErrUnsupported
+}
+
 func newCommonNode(
 	ctx context.Context,
 	dataDir string,
@@ -221,9 +221,20 @@ func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.Sig
 	}, nil
 }
 
+// Implements consensusAPI.Backend.
+func (t *fullService) SubmitMultiSignedTx(ctx context.Context, tx *transaction.MultiSignedTransaction) error {
+	if _, err := t.submitTxRaw(ctx, cbor.Marshal(tx)); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransaction) (*cmttypes.EventDataTx, error) {
+	return t.submitTxRaw(ctx, cbor.Marshal(tx))
+}
+
+func (t *fullService) submitTxRaw(ctx context.Context, data []byte) (*cmttypes.EventDataTx, error) {
 	// Subscribe to the transaction being included in a block.
-	data := cbor.Marshal(tx)
 	query := cmttypes.EventQueryTxFor(data)
 	subID := t.newSubscriberID()
 	txSub, err := t.subscribe(subID, query)
@@ -476,6 +487,77 @@ func (t *fullService) RegisterP2PService(p2p p2pAPI.Service) error {
 	return nil
 }
 
+// Implements consensusAPI.Backend.
+func (t *fullService) GetPeers(context.Context) ([]*consensusAPI.PeerInfo, error) {
+	if !t.started() {
+		return nil, fmt.Errorf("cometbft: not yet started")
+	}
+
+	// Collect configured peers so they are also reported when not connected.
+	persistentPeers, err := tmcommon.ConsensusAddressesToCometBFT(config.GlobalConfig.Consensus.P2P.PersistentPeer)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to convert persistent peer addresses: %w", err)
+	}
+	sentryUpstreamAddrs, err := tmcommon.ConsensusAddressesToCometBFT(config.GlobalConfig.Consensus.SentryUpstreamAddresses)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to convert sentry upstream addresses: %w", err)
+	}
+
+	var configured []*consensusAPI.PeerInfo
+	configuredByID := make(map[string]*consensusAPI.PeerInfo)
+	addConfigured := func(addrs []string, upstream bool) {
+		for _, addr := range addrs {
+			id, address, _ := strings.Cut(addr, "@")
+			info, ok := configuredByID[id]
+			if !ok {
+				info = &consensusAPI.PeerInfo{
+					ID:      id,
+					Address: address,
+				}
+				configuredByID[id] = info
+				configured = append(configured, info)
+			}
+			info.Persistent = info.Persistent || !upstream
+			info.SentryUpstream = info.SentryUpstream || upstream
+		}
+	}
+	addConfigured(persistentPeers, false)
+	addConfigured(sentryUpstreamAddrs, true)
+
+	now := time.Now()
+	tmpeers := t.node.Switch().Peers().List()
+	peers := make([]*consensusAPI.PeerInfo, 0, len(tmpeers)+len(configured))
+	for _, tmpeer := range tmpeers {
+		status := tmpeer.Status()
+		idle := min(status.SendMonitor.Idle, status.RecvMonitor.Idle)
+
+		info := &consensusAPI.PeerInfo{
+			ID:             string(tmpeer.ID()),
+			Address:        tmpeer.RemoteAddr().String(),
+			Connected:      true,
+			Outbound:       tmpeer.IsOutbound(),
+			Persistent:     tmpeer.IsPersistent(),
+			ConnectedSince: now.Add(-status.Duration),
+			LastActivity:   now.Add(-idle),
+		}
+		if cfg, ok := configuredByID[info.ID]; ok {
+			info.Persistent = info.Persistent || cfg.Persistent
+			info.SentryUpstream = cfg.SentryUpstream
+			delete(configuredByID, info.ID)
+		}
+		peers = append(peers, info)
+	}
+
+	// Report configured peers that are currently not connected.
+	for _, info := range configured {
+		if _, ok := configuredByID[info.ID]; ok {
+			peers = append(peers, info)
+		}
+	}
+
+	return peers, nil
+}
+
 // Implements consensusAPI.Backend.
 func (t *fullService) WatchBlocks(ctx context.Context) (<-chan *consensusAPI.Block, pubsub.ClosableSubscription, error) {
 	ch, sub, err := t.WatchCometBFTBlocks()
@@ -636,6 +718,16 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	if err != nil {
 		return err
 	}
+	if debugCfg := config.GlobalConfig.Consensus.Debug; (debugCfg.ClockOffset != 0 || debugCfg.ClockDriftRate != 0) && cmflags.DebugDontBlameOasis() {
+		t.Logger.Warn("skewing consensus clock, this is only for testing",
+			"offset", debugCfg.ClockOffset,
+			"drift_rate", debugCfg.ClockDriftRate,
+		)
+
+		if cometbftPV, err = crypto.NewClockSkewPrivVal(cometbftPV, debugCfg.ClockOffset, debugCfg.ClockDriftRate); err != nil {
+			return err
+		}
+	}
 
 	tmGenDoc, err := api.GetCometBFTGenesisDocument(t.genesisProvider)
 	if err != nil {
@@ -43,6 +43,8 @@ type serviceClient struct {
 	nodeListNotifier *pubsub.Broker
 	runtimeNotifier  *pubsub.Broker
 	eventNotifier    *pubsub.Broker
+
+	nodeExpirationNotifier *pubsub.Broker
 }
 
 // NodeListEpochInternalEvent is the per-epoch node list event.
@@ -107,6 +109,19 @@ func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return q.Nodes(ctx)
 }
 
+func (sc *serviceClient) QueryNodes(ctx context.Context, query *api.NodesQuery) ([]*node.Node, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := q.Nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return query.Apply(nodes), nil
+}
+
 func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *api.ConsensusAddressQuery) (*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -132,6 +147,14 @@ func (sc *serviceClient) WatchNodeList(context.Context) (<-chan *api.NodeList, p
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchNodeExpirations(context.Context) (<-chan *api.NodeExpirationEvent, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.NodeExpirationEvent)
+	sub := sc.nodeExpirationNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.GetRuntimeQuery) (*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -141,6 +164,15 @@ func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.GetRuntimeQu
 	return q.Runtime(ctx, query.ID, query.IncludeSuspended)
 }
 
+func (sc *serviceClient) GetRuntimeSuspension(ctx context.Context, query *api.NamespaceQuery) (*api.RuntimeSuspensionStatus, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeSuspension(ctx, query.ID)
+}
+
 func (sc *serviceClient) WatchRuntimes(_ context.Context) (<-chan *api.Runtime, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Runtime)
 	sub := sc.runtimeNotifier.Subscribe()
@@ -157,7 +189,11 @@ func (sc *serviceClient) GetRuntimes(ctx context.Context, query *api.GetRuntimes
 	if err != nil {
 		return nil, err
 	}
-	return q.Runtimes(ctx, query.IncludeSuspended)
+	runtimes, err := q.Runtimes(ctx, query.IncludeSuspended)
+	if err != nil {
+		return nil, err
+	}
+	return query.Apply(runtimes), nil
 }
 
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
@@ -260,6 +296,13 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx cmtt
 			continue
 		}
 		sc.nodeListNotifier.Broadcast(nl)
+
+		if err = sc.notifyNodeExpirations(ctx, height); err != nil {
+			sc.logger.Error("failed to notify node expirations",
+				"height", ev.Height,
+				"err", err,
+			)
+		}
 	}
 
 	// Notify subscribers of events.
@@ -328,6 +371,15 @@ func EventsFromCometBFT(
 				}
 
 				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeSuspendedEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.RuntimeResumedEvent{}):
+				// Runtime resumed event.
+				var e api.RuntimeResumedEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt RuntimeResumed event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeResumedEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.EntityEvent{}):
 				// Entity event.
 				var e api.EntityEvent
@@ -337,6 +389,15 @@ func EventsFromCometBFT(
 				}
 
 				events = append(events, &api.Event{Height: height, TxHash: txHash, EntityEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.EntityDeregisteredEvent{}):
+				// Entity deregistered event.
+				var e api.EntityDeregisteredEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt EntityDeregistered event: %w", err))
+					continue
+				}
+
+				events = append(events, &api.Event{Height: height, TxHash: txHash, EntityDeregisteredEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.NodeEvent{}):
 				// Node event.
 				var e api.NodeEvent
@@ -379,6 +440,23 @@ func (sc *serviceClient) getNodeList(ctx context.Context, height int64) (*api.No
 	}, nil
 }
 
+func (sc *serviceClient) notifyNodeExpirations(ctx context.Context, height int64) error {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	evs, err := q.NodeExpirations(ctx)
+	if err != nil {
+		return fmt.Errorf("registry: failed to query node expirations: %w", err)
+	}
+
+	for _, ev := range evs {
+		sc.nodeExpirationNotifier.Broadcast(ev)
+	}
+	return nil
+}
+
 // New constructs a new CometBFT backed registry Backend instance.
 func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 	// Initialize and register the CometBFT service component.
@@ -387,6 +465,11 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 		return nil, err
 	}
 
+	// Configure the registry application as a multisig transaction authorization handler.
+	if err := backend.SetMultisigAuthHandler(a.(tmapi.MultisigAuthHandler)); err != nil {
+		return nil, err
+	}
+
 	sc := &serviceClient{
 		logger:         logging.GetLogger("cometbft/registry"),
 		backend:        backend,
@@ -394,6 +477,8 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 		entityNotifier: pubsub.NewBroker(false),
 		nodeNotifier:   pubsub.NewBroker(false),
 		eventNotifier:  pubsub.NewBroker(false),
+
+		nodeExpirationNotifier: pubsub.NewBroker(false),
 	}
 	sc.nodeListNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		wr := ch.In()
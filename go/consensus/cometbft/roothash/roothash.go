@@ -72,6 +72,7 @@ type serviceClient struct {
 	querier *app.QueryFactory
 
 	allBlockNotifier *pubsub.Broker
+	allEventNotifier *pubsub.Broker
 	runtimeNotifiers map[common.Namespace]*runtimeBrokers
 	genesisBlocks    map[common.Namespace]*block.Block
 
@@ -145,6 +146,15 @@ func (sc *serviceClient) GetLastRoundResults(ctx context.Context, request *api.R
 	return q.LastRoundResults(ctx, request.RuntimeID)
 }
 
+func (sc *serviceClient) GetRuntimeStateProof(ctx context.Context, request *api.RuntimeRequest) (*api.RuntimeStateProof, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeStateProof(ctx, request.RuntimeID)
+}
+
 func (sc *serviceClient) GetRoundRoots(ctx context.Context, request *api.RoundRootsRequest) (*api.RoundRoots, error) {
 	q, err := sc.querier.QueryAt(ctx, request.Height)
 	if err != nil {
@@ -240,6 +250,14 @@ func (sc *serviceClient) WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscrip
 	return ch, sub
 }
 
+func (sc *serviceClient) WatchAllEvents() (<-chan *api.Event, *pubsub.Subscription) {
+	sub := sc.allEventNotifier.Subscribe()
+	ch := make(chan *api.Event)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) WatchEvents(_ context.Context, id common.Namespace) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	notifiers := sc.getRuntimeNotifiers(id)
@@ -596,6 +614,8 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx cmtt
 	}
 
 	for _, ev := range events {
+		sc.allEventNotifier.Broadcast(ev)
+
 		// Notify non-finalized events.
 		if ev.Finalized == nil {
 			notifiers := sc.getRuntimeNotifiers(ev.RuntimeID)
@@ -814,6 +834,15 @@ EventLoop:
 				}
 
 				ev = &api.Event{InMsgProcessed: &e}
+			case eventsAPI.IsAttributeKind(key, &api.RoundTimeoutEvent{}):
+				// Round timeout event.
+				var e api.RoundTimeoutEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("roothash: corrupt RoundTimeout event: %w", err))
+					continue EventLoop
+				}
+
+				ev = &api.Event{RoundTimeout: &e}
 			case eventsAPI.IsAttributeKind(key, &api.RuntimeIDAttribute{}):
 				if runtimeID != nil {
 					errs = errors.Join(errs, fmt.Errorf("roothash: duplicate runtime ID attribute"))
@@ -892,6 +921,7 @@ func New(
 		logger:           logging.GetLogger("cometbft/roothash"),
 		backend:          backend,
 		allBlockNotifier: pubsub.NewBroker(false),
+		allEventNotifier: pubsub.NewBroker(false),
 		runtimeNotifiers: make(map[common.Namespace]*runtimeBrokers),
 		genesisBlocks:    make(map[common.Namespace]*block.Block),
 		queryCh:          make(chan cmtpubsub.Query, runtimeRegistry.MaxRuntimeCount),
@@ -62,6 +62,15 @@ func (sc *serviceClient) GetValidators(ctx context.Context, height int64) ([]*ap
 	return q.Validators(ctx)
 }
 
+func (sc *serviceClient) GetValidatorSetStatus(ctx context.Context, height int64) (*api.ValidatorSetStatus, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ValidatorSetStatus(ctx)
+}
+
 func (sc *serviceClient) GetCommittees(ctx context.Context, request *api.GetCommitteesRequest) ([]*api.Committee, error) {
 	q, err := sc.querier.QueryAt(ctx, request.Height)
 	if err != nil {
@@ -0,0 +1,282 @@
+package staking
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/options"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// EventIndexDBName is the name of the account event index database.
+	EventIndexDBName = "staking_events.badger.db"
+
+	eventIndexVersion = 1
+
+	// cursorSize is the size of an encoded account events cursor.
+	cursorSize = 8 + 4
+)
+
+var (
+	// keyFormat is the namespace for the staking event index key formats.
+	keyFormat = keyformat.NewNamespace("staking event index")
+
+	// metadataKeyFmt is the metadata key format.
+	//
+	// Value is CBOR-serialized eventIndexMetadata.
+	metadataKeyFmt = keyFormat.New(0x01)
+	// eventKeyFmt is the account event key format.
+	//
+	// Key format is: 0x02 <address> <height (uint64)> <index in block (uint32)>.
+	//
+	// Value is CBOR-serialized api.Event.
+	eventKeyFmt = keyFormat.New(0x02, &api.Address{}, uint64(0), uint32(0))
+)
+
+type eventIndexMetadata struct {
+	// Version is the database schema version.
+	Version uint64 `json:"version"`
+
+	// FirstHeight is the first indexed height.
+	FirstHeight int64 `json:"first_height"`
+	// LastHeight is the last indexed height.
+	LastHeight int64 `json:"last_height"`
+}
+
+// eventIndex is the index of staking events by account.
+type eventIndex struct {
+	logger *logging.Logger
+
+	db *badger.DB
+	gc *cmnBadger.GCWorker
+}
+
+func newEventIndex(fn string) (*eventIndex, error) {
+	logger := logging.GetLogger("cometbft/staking/index").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithSyncWrites(false)
+	opts = opts.WithCompression(options.Snappy)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("staking: failed to open event index: %w", err)
+	}
+
+	idx := &eventIndex{
+		logger: logger,
+		db:     db,
+		gc:     cmnBadger.NewGCWorker(logger, db),
+	}
+
+	if err = idx.ensureMetadata(); err != nil {
+		idx.close()
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (idx *eventIndex) queryGetMetadata(tx *badger.Txn) (*eventIndexMetadata, error) {
+	item, err := tx.Get(metadataKeyFmt.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var meta eventIndexMetadata
+	err = item.Value(func(val []byte) error {
+		return cbor.Unmarshal(val, &meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (idx *eventIndex) ensureMetadata() error {
+	return idx.db.Update(func(tx *badger.Txn) error {
+		meta, err := idx.queryGetMetadata(tx)
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			// Create new metadata section.
+			meta := eventIndexMetadata{
+				Version: eventIndexVersion,
+			}
+			return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+		default:
+			return err
+		}
+
+		// Verify metadata section.
+		if meta.Version != eventIndexVersion {
+			return fmt.Errorf("staking: unsupported event index version (expected: %d got: %d)",
+				eventIndexVersion,
+				meta.Version,
+			)
+		}
+		return nil
+	})
+}
+
+func (idx *eventIndex) metadata() (*eventIndexMetadata, error) {
+	var meta *eventIndexMetadata
+	err := idx.db.View(func(tx *badger.Txn) error {
+		var err error
+		meta, err = idx.queryGetMetadata(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// commit indexes all staking events emitted at the given height.
+//
+// Heights must be committed in order, with the exception of the first committed height (and any
+// height following a gap that can no longer be indexed, see reset).
+func (idx *eventIndex) commit(height int64, events []*api.Event) error {
+	return idx.db.Update(func(tx *badger.Txn) error {
+		meta, err := idx.queryGetMetadata(tx)
+		if err != nil {
+			return err
+		}
+
+		if meta.LastHeight != 0 && height != meta.LastHeight+1 {
+			return fmt.Errorf("staking: event index commit at non-consecutive height (last: %d wanted: %d)",
+				meta.LastHeight,
+				height,
+			)
+		}
+
+		for i, ev := range events {
+			for _, addr := range ev.Accounts() {
+				if err = tx.Set(eventKeyFmt.Encode(&addr, uint64(height), uint32(i)), cbor.Marshal(ev)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if meta.FirstHeight == 0 {
+			meta.FirstHeight = height
+		}
+		meta.LastHeight = height
+		return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+// reset restarts indexing at the given height, marking all previously indexed heights as
+// unavailable.
+func (idx *eventIndex) reset(height int64) error {
+	return idx.db.Update(func(tx *badger.Txn) error {
+		meta := eventIndexMetadata{
+			Version:     eventIndexVersion,
+			FirstHeight: height,
+			LastHeight:  height - 1,
+		}
+		return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+// accountEvents returns a page of events related to the given account.
+func (idx *eventIndex) accountEvents(query *api.AccountEventsQuery) (*api.AccountEvents, error) {
+	meta, err := idx.metadata()
+	if err != nil {
+		return nil, err
+	}
+
+	fromHeight, toHeight := query.FromHeight, query.ToHeight
+	if fromHeight == 0 {
+		fromHeight = meta.FirstHeight
+	}
+	if toHeight == 0 || toHeight > meta.LastHeight {
+		toHeight = meta.LastHeight
+	}
+	if meta.FirstHeight == 0 || fromHeight < meta.FirstHeight {
+		return nil, fmt.Errorf("%w: heights before %d are not indexed", api.ErrEventIndexUnavailable, meta.FirstHeight)
+	}
+
+	limit := int(query.Limit)
+	if limit == 0 || limit > api.MaxAccountEventsLimit {
+		limit = api.MaxAccountEventsLimit
+	}
+
+	startHeight, startIndex := uint64(fromHeight), uint32(0)
+	if len(query.Cursor) > 0 {
+		if len(query.Cursor) != cursorSize {
+			return nil, fmt.Errorf("%w: malformed cursor", api.ErrInvalidArgument)
+		}
+		startHeight = binary.BigEndian.Uint64(query.Cursor[:8])
+		startIndex = binary.BigEndian.Uint32(query.Cursor[8:])
+	}
+	if startHeight < uint64(fromHeight) {
+		startHeight, startIndex = uint64(fromHeight), 0
+	}
+
+	result := api.AccountEvents{
+		Events: []*api.Event{},
+	}
+	if startHeight > uint64(toHeight) {
+		return &result, nil
+	}
+
+	err = idx.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{Prefix: eventKeyFmt.Encode(&query.Owner)})
+		defer it.Close()
+
+		for it.Seek(eventKeyFmt.Encode(&query.Owner, startHeight, startIndex)); it.Valid(); it.Next() {
+			var (
+				addr   api.Address
+				height uint64
+				index  uint32
+			)
+			if !eventKeyFmt.Decode(it.Item().Key(), &addr, &height, &index) {
+				break
+			}
+			if height > uint64(toHeight) {
+				break
+			}
+
+			if len(result.Events) == limit {
+				result.NextCursor = make([]byte, cursorSize)
+				binary.BigEndian.PutUint64(result.NextCursor[:8], height)
+				binary.BigEndian.PutUint32(result.NextCursor[8:], index)
+				break
+			}
+
+			var ev api.Event
+			if err := it.Item().Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &ev)
+			}); err != nil {
+				return err
+			}
+			result.Events = append(result.Events, &ev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (idx *eventIndex) close() {
+	idx.gc.Close()
+
+	if err := idx.db.Close(); err != nil {
+		idx.logger.Error("failed to close event index",
+			"err", err,
+		)
+	}
+}
@@ -0,0 +1,106 @@
+package staking
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+
+	idx, err := newEventIndex(filepath.Join(t.TempDir(), EventIndexDBName))
+	require.NoError(err, "newEventIndex")
+	defer idx.close()
+
+	addr1 := api.NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := api.NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr3 := api.NewAddress(signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	transfer := func(height int64, from, to api.Address, amount uint64) *api.Event {
+		return &api.Event{
+			Height: height,
+			Transfer: &api.TransferEvent{
+				From:   from,
+				To:     to,
+				Amount: *quantity.NewFromUint64(amount),
+			},
+		}
+	}
+
+	_, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr1})
+	require.ErrorIs(err, api.ErrEventIndexUnavailable, "querying an empty index should fail")
+
+	// Index heights 10-14, each with a transfer from addr1 to addr2 and one from addr2 to addr3.
+	for height := int64(10); height < 15; height++ {
+		err = idx.commit(height, []*api.Event{
+			transfer(height, addr1, addr2, 1),
+			transfer(height, addr2, addr3, 2),
+		})
+		require.NoError(err, "commit")
+	}
+	err = idx.commit(16, nil)
+	require.Error(err, "commit at non-consecutive height should fail")
+
+	// All events of an account.
+	page, err := idx.accountEvents(&api.AccountEventsQuery{Owner: addr2})
+	require.NoError(err, "accountEvents")
+	require.Len(page.Events, 10)
+	require.Empty(page.NextCursor)
+
+	// Height range.
+	page, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr3, FromHeight: 11, ToHeight: 12})
+	require.NoError(err, "accountEvents")
+	require.Len(page.Events, 2)
+	require.EqualValues(11, page.Events[0].Height)
+	require.EqualValues(12, page.Events[1].Height)
+	require.EqualValues(*quantity.NewFromUint64(2), page.Events[0].Transfer.Amount)
+
+	// Pagination.
+	var events []*api.Event
+	query := api.AccountEventsQuery{Owner: addr2, FromHeight: 11, Limit: 3}
+	for {
+		page, err = idx.accountEvents(&query)
+		require.NoError(err, "accountEvents")
+		require.LessOrEqual(len(page.Events), 3)
+		events = append(events, page.Events...)
+		if len(page.NextCursor) == 0 {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	require.Len(events, 8)
+	for i, ev := range events {
+		require.EqualValues(11+i/2, ev.Height, "events should be ordered by height")
+	}
+
+	// Unrelated accounts.
+	page, err = idx.accountEvents(&api.AccountEventsQuery{Owner: api.CommonPoolAddress})
+	require.NoError(err, "accountEvents")
+	require.Empty(page.Events)
+
+	// Heights before the first indexed height.
+	_, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr1, FromHeight: 5})
+	require.ErrorIs(err, api.ErrEventIndexUnavailable)
+
+	// Malformed cursors.
+	_, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr1, Cursor: []byte{1, 2, 3}})
+	require.ErrorIs(err, api.ErrInvalidArgument)
+
+	// Restarting the index makes previous heights unavailable.
+	err = idx.reset(100)
+	require.NoError(err, "reset")
+	_, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr1, FromHeight: 10})
+	require.ErrorIs(err, api.ErrEventIndexUnavailable)
+	err = idx.commit(100, []*api.Event{transfer(100, addr1, addr2, 1)})
+	require.NoError(err, "commit")
+	page, err = idx.accountEvents(&api.AccountEventsQuery{Owner: addr1})
+	require.NoError(err, "accountEvents")
+	require.Len(page.Events, 1)
+	require.EqualValues(100, page.Events[0].Height)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking"
@@ -36,6 +37,7 @@ type serviceClient struct {
 	querier *app.QueryFactory
 
 	eventNotifier *pubsub.Broker
+	eventIndex    *eventIndex
 }
 
 func (sc *serviceClient) TokenSymbol(ctx context.Context, height int64) (string, error) {
@@ -202,6 +204,28 @@ func (sc *serviceClient) DebondingDelegationsTo(ctx context.Context, query *api.
 	return q.DebondingDelegationsTo(ctx, query.Owner)
 }
 
+func (sc *serviceClient) DelegationStatusFor(ctx context.Context, query *api.OwnerQuery) (*api.DelegationStatus, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := q.DelegationInfosFor(ctx, query.Owner)
+	if err != nil {
+		return nil, err
+	}
+	debondingDelegations, err := q.DebondingDelegationInfosFor(ctx, query.Owner)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := sc.backend.Beacon().GetEpoch(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewDelegationStatus(epoch, delegations, debondingDelegations)
+}
+
 func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuery) (*quantity.Quantity, error) {
 	acct, err := sc.Account(ctx, &api.OwnerQuery{
 		Height: query.Height,
@@ -215,6 +239,45 @@ func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuer
 	return &allowance, nil
 }
 
+func (sc *serviceClient) Vesting(ctx context.Context, query *api.OwnerQuery) (*api.VestingStatus, error) {
+	acct, err := sc.Account(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := sc.backend.Beacon().GetEpoch(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewVestingStatus(acct.General.Vesting, epoch), nil
+}
+
+func (sc *serviceClient) ProjectedRewards(ctx context.Context, query *api.ProjectedRewardsQuery) ([]*api.ProjectedReward, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := q.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acct, err := q.Account(ctx, query.Owner)
+	if err != nil {
+		return nil, err
+	}
+	commonPool, err := q.CommonPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	epoch, err := sc.backend.Beacon().GetEpoch(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ProjectRewards(params, &acct.Escrow.Active.Balance, commonPool, epoch, query.Epochs)
+}
+
 func (sc *serviceClient) StateToGenesis(ctx context.Context, height int64) (*api.Genesis, error) {
 	// Query the staking genesis state.
 	q, err := sc.querier.QueryAt(ctx, height)
@@ -291,6 +354,13 @@ func (sc *serviceClient) GetEvents(ctx context.Context, height int64) ([]*api.Ev
 	return events, nil
 }
 
+func (sc *serviceClient) GetAccountEvents(_ context.Context, query *api.AccountEventsQuery) (*api.AccountEvents, error) {
+	if sc.eventIndex == nil {
+		return nil, fmt.Errorf("%w: event index disabled", api.ErrEventIndexUnavailable)
+	}
+	return sc.eventIndex.accountEvents(query)
+}
+
 func (sc *serviceClient) WatchEvents(context.Context) (<-chan *api.Event, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Event)
 	sub := sc.eventNotifier.Subscribe()
@@ -309,6 +379,9 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 }
 
 func (sc *serviceClient) Cleanup() {
+	if sc.eventIndex != nil {
+		sc.eventIndex.close()
+	}
 }
 
 // Implements api.ServiceClient.
@@ -316,6 +389,55 @@ func (sc *serviceClient) ServiceDescriptor() tmapi.ServiceDescriptor {
 	return tmapi.NewStaticServiceDescriptor(api.ModuleName, app.EventType, []cmtpubsub.Query{app.QueryApp})
 }
 
+// Implements api.ServiceClient.
+func (sc *serviceClient) DeliverBlock(ctx context.Context, height int64) error {
+	if sc.eventIndex == nil {
+		return nil
+	}
+
+	meta, err := sc.eventIndex.metadata()
+	if err != nil {
+		return fmt.Errorf("staking: failed to fetch event index metadata: %w", err)
+	}
+
+	// Index all heights since the last indexed height, starting at the current height in case
+	// nothing has been indexed yet.
+	fromHeight := meta.LastHeight + 1
+	if meta.LastHeight == 0 {
+		fromHeight = height
+	}
+	if fromHeight < height {
+		// Make sure the skipped heights are still available, otherwise restart indexing.
+		var status *consensus.Status
+		if status, err = sc.backend.GetStatus(ctx); err != nil {
+			return fmt.Errorf("staking: failed to fetch consensus status: %w", err)
+		}
+		if fromHeight < status.LastRetainedHeight {
+			sc.logger.Warn("skipped heights no longer available, restarting event index",
+				"last_indexed_height", meta.LastHeight,
+				"last_retained_height", status.LastRetainedHeight,
+			)
+
+			fromHeight = status.LastRetainedHeight
+			if err = sc.eventIndex.reset(fromHeight); err != nil {
+				return fmt.Errorf("staking: failed to reset event index: %w", err)
+			}
+		}
+	}
+
+	for h := fromHeight; h <= height; h++ {
+		events, err := sc.GetEvents(ctx, h)
+		if err != nil {
+			return fmt.Errorf("staking: failed to fetch events at height %d: %w", h, err)
+		}
+		if err = sc.eventIndex.commit(h, events); err != nil {
+			return fmt.Errorf("staking: failed to index events at height %d: %w", h, err)
+		}
+	}
+
+	return nil
+}
+
 // Implements api.ServiceClient.
 func (sc *serviceClient) DeliverEvent(_ context.Context, height int64, tx cmttypes.Tx, ev *cmtabcitypes.Event) error {
 	events, err := EventsFromCometBFT(tx, height, []cmtabcitypes.Event{*ev})
@@ -428,6 +550,26 @@ func EventsFromCometBFT(
 
 				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
 				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.LivenessViolationEvent{}):
+				// Liveness violation event.
+				var e api.LivenessViolationEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt LivenessViolation event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, LivenessViolation: &e}
+				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.RewardDisbursementEvent{}):
+				// Reward disbursement event.
+				var e api.RewardDisbursementEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt RewardDisbursement event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, RewardDisbursement: &e}
+				events = append(events, evt)
 			default:
 				errs = errors.Join(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -438,7 +580,10 @@ func EventsFromCometBFT(
 }
 
 // New constructs a new CometBFT backed staking Backend instance.
-func New(backend tmapi.Backend) (ServiceClient, error) {
+//
+// In case eventIndexPath is non-empty, the account event index is maintained in the database at
+// the given path.
+func New(backend tmapi.Backend, eventIndexPath string) (ServiceClient, error) {
 	// Initialize and register the CometBFT service component.
 	a := app.New()
 	if err := backend.RegisterApplication(a); err != nil {
@@ -450,10 +595,19 @@ func New(backend tmapi.Backend) (ServiceClient, error) {
 		return nil, err
 	}
 
+	var idx *eventIndex
+	if eventIndexPath != "" {
+		var err error
+		if idx, err = newEventIndex(eventIndexPath); err != nil {
+			return nil, err
+		}
+	}
+
 	return &serviceClient{
 		logger:        logging.GetLogger("cometbft/staking"),
 		backend:       backend,
 		querier:       a.QueryFactory().(*app.QueryFactory),
 		eventNotifier: pubsub.NewBroker(false),
+		eventIndex:    idx,
 	}, nil
 }
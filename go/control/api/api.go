@@ -60,6 +60,17 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// GetKeymanagerCacheStats returns the key manager worker cache statistics.
+	GetKeymanagerCacheStats(ctx context.Context) (*keymanagerWorker.CacheStats, error)
+
+	// FlushKeymanagerCaches flushes the key manager worker caches (enclave key caches,
+	// cached policy and access lists), forcing them to be rebuilt from the latest
+	// consensus state.
+	FlushKeymanagerCaches(ctx context.Context) error
+
+	// GetPeers returns information about the node's consensus, runtime P2P and sentry peers.
+	GetPeers(ctx context.Context) (*PeersStatus, error)
 }
 
 // Status is the current status overview.
@@ -101,6 +112,18 @@ type Status struct {
 	Seed *SeedStatus `json:"seed,omitempty"`
 }
 
+// PeersStatus is the current overview of the node's peers.
+type PeersStatus struct {
+	// Consensus are the consensus peers, including configured peers that are not connected.
+	Consensus []*consensus.PeerInfo `json:"consensus,omitempty"`
+
+	// Runtimes are the connected committee P2P peers for each runtime supported by the node.
+	Runtimes map[common.Namespace][]*p2p.PeerInfo `json:"runtimes,omitempty"`
+
+	// Sentries are the consensus peers configured as persistent peers or sentry upstreams.
+	Sentries []*consensus.PeerInfo `json:"sentries,omitempty"`
+}
+
 // DebugStatus is the current node debug status, listing the various node
 // debug options if enabled.
 type DebugStatus struct {
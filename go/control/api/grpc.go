@@ -7,6 +7,7 @@ import (
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
 
 var (
@@ -29,6 +30,12 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetKeymanagerCacheStats is the GetKeymanagerCacheStats method.
+	methodGetKeymanagerCacheStats = serviceName.NewMethod("GetKeymanagerCacheStats", nil)
+	// methodFlushKeymanagerCaches is the FlushKeymanagerCaches method.
+	methodFlushKeymanagerCaches = serviceName.NewMethod("FlushKeymanagerCaches", nil)
+	// methodGetPeers is the GetPeers method.
+	methodGetPeers = serviceName.NewMethod("GetPeers", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -67,6 +74,18 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetKeymanagerCacheStats.ShortName(),
+				Handler:    handlerGetKeymanagerCacheStats,
+			},
+			{
+				MethodName: methodFlushKeymanagerCaches.ShortName(),
+				Handler:    handlerFlushKeymanagerCaches,
+			},
+			{
+				MethodName: methodGetPeers.ShortName(),
+				Handler:    handlerGetPeers,
+			},
 		},
 		Streams: []grpc.StreamDesc{},
 	}
@@ -236,6 +255,63 @@ func handlerGetStatus(
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetKeymanagerCacheStats(
+	srv interface{},
+	ctx context.Context,
+	_ func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetKeymanagerCacheStats(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetKeymanagerCacheStats.FullName(),
+	}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return srv.(NodeController).GetKeymanagerCacheStats(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerFlushKeymanagerCaches(
+	srv interface{},
+	ctx context.Context,
+	_ func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return nil, srv.(NodeController).FlushKeymanagerCaches(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodFlushKeymanagerCaches.FullName(),
+	}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).FlushKeymanagerCaches(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
+func handlerGetPeers(
+	srv interface{},
+	ctx context.Context,
+	_ func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(NodeController).GetPeers(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetPeers.FullName(),
+	}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return srv.(NodeController).GetPeers(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -289,6 +365,26 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) GetKeymanagerCacheStats(ctx context.Context) (*keymanagerWorker.CacheStats, error) {
+	var rsp keymanagerWorker.CacheStats
+	if err := c.conn.Invoke(ctx, methodGetKeymanagerCacheStats.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) FlushKeymanagerCaches(ctx context.Context) error {
+	return c.conn.Invoke(ctx, methodFlushKeymanagerCaches.FullName(), nil, nil)
+}
+
+func (c *nodeControllerClient) GetPeers(ctx context.Context) (*PeersStatus, error) {
+	var rsp PeersStatus
+	if err := c.conn.Invoke(ctx, methodGetPeers.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
@@ -311,6 +311,18 @@ func TestGenesisSanityCheck(t *testing.T) {
 	}
 	require.Error(d.SanityCheck(), "invalid epoch interval should be rejected")
 
+	d = testDoc()
+	d.Beacon.Parameters.ExternalEntropy = &beacon.ExternalEntropyParameters{
+		Submitters: []signature.PublicKey{validPK},
+	}
+	require.NoError(d.SanityCheck(), "valid external entropy submitter should pass")
+
+	d = testDoc()
+	d.Beacon.Parameters.ExternalEntropy = &beacon.ExternalEntropyParameters{
+		Submitters: []signature.PublicKey{invalidPK},
+	}
+	require.Error(d.SanityCheck(), "invalid external entropy submitter should be rejected")
+
 	// Test keymanager genesis checks.
 	d = testDoc()
 	d.KeyManager = keymanager.Genesis{
@@ -7,8 +7,10 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/url"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
@@ -54,9 +56,27 @@ var (
 	_ prettyprint.PrettyPrinter = (*UpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ChangeParametersProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*SignalProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*PauseRuntimeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
 )
 
+// ProposalKind is a kind of a governance proposal.
+type ProposalKind string
+
+const (
+	// ProposalKindUpgrade is the kind of upgrade proposals.
+	ProposalKindUpgrade ProposalKind = "upgrade"
+	// ProposalKindCancelUpgrade is the kind of upgrade cancellation proposals.
+	ProposalKindCancelUpgrade ProposalKind = "cancel_upgrade"
+	// ProposalKindChangeParameters is the kind of change parameters proposals.
+	ProposalKindChangeParameters ProposalKind = "change_parameters"
+	// ProposalKindSignal is the kind of signal proposals.
+	ProposalKindSignal ProposalKind = "signal"
+	// ProposalKindPauseRuntime is the kind of runtime pause and resume proposals.
+	ProposalKindPauseRuntime ProposalKind = "pause_runtime"
+)
+
 // ProposalContent is a consensus layer governance proposal content.
 type ProposalContent struct {
 	// Metadata contains optional proposal metadata which is ignored during proposal execution.
@@ -65,6 +85,8 @@ type ProposalContent struct {
 	Upgrade          *UpgradeProposal          `json:"upgrade,omitempty"`
 	CancelUpgrade    *CancelUpgradeProposal    `json:"cancel_upgrade,omitempty"`
 	ChangeParameters *ChangeParametersProposal `json:"change_parameters,omitempty"`
+	Signal           *SignalProposal           `json:"signal,omitempty"`
+	PauseRuntime     *PauseRuntimeProposal     `json:"pause_runtime,omitempty"`
 }
 
 // ValidateBasic performs basic proposal content validity checks.
@@ -95,6 +117,12 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 	if p.ChangeParameters != nil {
 		numProposals++
 	}
+	if p.Signal != nil {
+		numProposals++
+	}
+	if p.PauseRuntime != nil {
+		numProposals++
+	}
 
 	switch {
 	case numProposals > 1:
@@ -109,6 +137,12 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 		if err := p.ChangeParameters.ValidateBasic(); err != nil {
 			return fmt.Errorf("change parameters proposal validation failed: %w", err)
 		}
+	case p.Signal != nil:
+		if err := p.Signal.ValidateBasic(); err != nil {
+			return fmt.Errorf("signal proposal validation failed: %w", err)
+		}
+	case p.PauseRuntime != nil:
+		// No validation at this time.
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -116,6 +150,26 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 	return nil
 }
 
+// Kind returns the kind of the proposal.
+//
+// Note: this assumes a valid proposal with exactly one field set.
+func (p *ProposalContent) Kind() ProposalKind {
+	switch {
+	case p.Upgrade != nil:
+		return ProposalKindUpgrade
+	case p.CancelUpgrade != nil:
+		return ProposalKindCancelUpgrade
+	case p.ChangeParameters != nil:
+		return ProposalKindChangeParameters
+	case p.Signal != nil:
+		return ProposalKindSignal
+	case p.PauseRuntime != nil:
+		return ProposalKindPauseRuntime
+	default:
+		return ""
+	}
+}
+
 // Equals checks if proposal contents are equal.
 //
 // Note: this assumes valid proposals where each proposals will have
@@ -136,6 +190,12 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 	if !p.ChangeParameters.Equals(other.ChangeParameters) {
 		return false
 	}
+	if !p.Signal.Equals(other.Signal) {
+		return false
+	}
+	if !p.PauseRuntime.Equals(other.PauseRuntime) {
+		return false
+	}
 	return true
 }
 
@@ -157,6 +217,14 @@ func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 		fmt.Fprintf(w, "%sChange Parameters:\n", prefix)
 		p.ChangeParameters.PrettyPrint(ctx, prefix+"  ", w)
 	}
+	if p.Signal != nil {
+		fmt.Fprintf(w, "%sSignal:\n", prefix)
+		p.Signal.PrettyPrint(ctx, prefix+"  ", w)
+	}
+	if p.PauseRuntime != nil {
+		fmt.Fprintf(w, "%sPause Runtime:\n", prefix)
+		p.PauseRuntime.PrettyPrint(ctx, prefix+"  ", w)
+	}
 }
 
 // PrettyType returns a representation of ProposalContent that can be used for
@@ -170,6 +238,8 @@ const (
 	MinProposalTitleLength = 3
 	// MaxProposalTitleLength is the maximum length of a proposal's title.
 	MaxProposalTitleLength = 100
+	// MaxSignalMetadataURLLength is the maximum length of a signal proposal's metadata URL.
+	MaxSignalMetadataURLLength = 256
 )
 
 // ProposalMetadata contains metadata about a proposal.
@@ -332,6 +402,102 @@ func (p *ChangeParametersProposal) ValidateBasic() error {
 	return nil
 }
 
+// SignalProposal is a non-executable proposal used for signaling votes.
+//
+// Passing a signal proposal has no on-chain effects.
+type SignalProposal struct {
+	// DescriptionHash is the hash of the full (off-chain) proposal description.
+	DescriptionHash hash.Hash `json:"description_hash"`
+	// MetadataURL is an optional URL of the off-chain proposal metadata.
+	MetadataURL string `json:"metadata_url,omitempty"`
+}
+
+// Equals checks if signal proposals are equal.
+func (p *SignalProposal) Equals(other *SignalProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if !p.DescriptionHash.Equal(&other.DescriptionHash) {
+		return false
+	}
+	if p.MetadataURL != other.MetadataURL {
+		return false
+	}
+	return true
+}
+
+// PrettyPrint writes a pretty-printed representation of SignalProposal to the given writer.
+func (p SignalProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sDescription Hash: %s\n", prefix, p.DescriptionHash)
+	if len(p.MetadataURL) > 0 {
+		fmt.Fprintf(w, "%sMetadata URL: %s\n", prefix, p.MetadataURL)
+	}
+}
+
+// PrettyType returns a representation of SignalProposal that can be used for pretty printing.
+func (p SignalProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
+// ValidateBasic performs a basic validation on the signal proposal.
+func (p *SignalProposal) ValidateBasic() error {
+	var zero hash.Hash
+	if p.DescriptionHash.Equal(&zero) || p.DescriptionHash.IsEmpty() {
+		return fmt.Errorf("invalid description hash: hash should not be empty")
+	}
+	if len(p.MetadataURL) == 0 {
+		return nil
+	}
+	if len(p.MetadataURL) > MaxSignalMetadataURLLength {
+		return fmt.Errorf("invalid metadata URL: URL too long")
+	}
+	u, err := url.Parse(p.MetadataURL)
+	if err != nil {
+		return fmt.Errorf("invalid metadata URL: %w", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("invalid metadata URL: unsupported scheme '%s'", u.Scheme)
+	}
+	return nil
+}
+
+// PauseRuntimeProposal is a proposal to pause or resume a runtime.
+//
+// While paused, the roothash service does not accept commitments for the runtime and the
+// scheduler does not elect any committees for it.
+type PauseRuntimeProposal struct {
+	// RuntimeID is the identifier of the runtime to pause or resume.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Resume is true iff the runtime should be resumed instead of paused.
+	Resume bool `json:"resume,omitempty"`
+}
+
+// Equals checks if runtime pause proposals are equal.
+func (p *PauseRuntimeProposal) Equals(other *PauseRuntimeProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	return p.RuntimeID.Equal(&other.RuntimeID) && p.Resume == other.Resume
+}
+
+// PrettyPrint writes a pretty-printed representation of PauseRuntimeProposal to the given writer.
+func (p PauseRuntimeProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sRuntime ID: %s\n", prefix, p.RuntimeID)
+	fmt.Fprintf(w, "%sResume: %t\n", prefix, p.Resume)
+}
+
+// PrettyType returns a representation of PauseRuntimeProposal that can be used for pretty
+// printing.
+func (p PauseRuntimeProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -431,6 +597,10 @@ type ConsensusParameters struct {
 	// proposal to be accepted.  This value has a lower bound of 67.
 	StakeThreshold uint8 `json:"stake_threshold,omitempty"`
 
+	// StakeThresholds are the stake thresholds for specific proposal kinds which override the
+	// default StakeThreshold.
+	StakeThresholds map[ProposalKind]uint8 `json:"stake_thresholds,omitempty"`
+
 	// UpgradeMinEpochDiff is the minimum number of epochs between the current
 	// epoch and the proposed upgrade epoch for the upgrade proposal to be valid.
 	// This is also the minimum number of epochs between two pending upgrades.
@@ -448,6 +618,20 @@ type ConsensusParameters struct {
 
 	// AllowProposalMetadata is true iff proposals are allowed to contain metadata.
 	AllowProposalMetadata bool `json:"allow_proposal_metadata,omitempty"`
+
+	// EnableSignalProposal is true iff signal proposals are allowed.
+	EnableSignalProposal bool `json:"enable_signal_proposal,omitempty"`
+
+	// EnablePauseRuntimeProposal is true iff runtime pause and resume proposals are allowed.
+	EnablePauseRuntimeProposal bool `json:"enable_pause_runtime_proposal,omitempty"`
+}
+
+// StakeThresholdFor returns the stake threshold for proposals of the given kind.
+func (p *ConsensusParameters) StakeThresholdFor(kind ProposalKind) uint8 {
+	if threshold, ok := p.StakeThresholds[kind]; ok {
+		return threshold
+	}
+	return p.StakeThreshold
 }
 
 // ConsensusParameterChanges are allowed governance consensus parameter changes.
@@ -464,6 +648,10 @@ type ConsensusParameterChanges struct {
 	// StakeThreshold is the new stake threshold.
 	StakeThreshold *uint8 `json:"stake_threshold,omitempty"`
 
+	// StakeThresholds are the new stake thresholds for specific proposal kinds. If set, they
+	// replace all existing per-kind stake thresholds, so setting an empty map clears them.
+	StakeThresholds *map[ProposalKind]uint8 `json:"stake_thresholds,omitempty"`
+
 	// UpgradeMinEpochDiff is the new minimal epoch difference between two pending upgrades.
 	UpgradeMinEpochDiff *beacon.EpochTime `json:"upgrade_min_epoch_diff,omitempty"`
 
@@ -473,6 +661,12 @@ type ConsensusParameterChanges struct {
 
 	// EnableChangeParametersProposal is the new enable change parameters proposal flag.
 	EnableChangeParametersProposal *bool `json:"enable_change_parameters_proposal,omitempty"`
+
+	// EnableSignalProposal is the new enable signal proposal flag.
+	EnableSignalProposal *bool `json:"enable_signal_proposal,omitempty"`
+
+	// EnablePauseRuntimeProposal is the new enable runtime pause proposal flag.
+	EnablePauseRuntimeProposal *bool `json:"enable_pause_runtime_proposal,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -489,6 +683,13 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.StakeThreshold != nil {
 		params.StakeThreshold = *c.StakeThreshold
 	}
+	if c.StakeThresholds != nil {
+		// An empty set of thresholds removes all per-kind thresholds.
+		params.StakeThresholds = *c.StakeThresholds
+		if len(params.StakeThresholds) == 0 {
+			params.StakeThresholds = nil
+		}
+	}
 	if c.UpgradeMinEpochDiff != nil {
 		params.UpgradeMinEpochDiff = *c.UpgradeMinEpochDiff
 	}
@@ -498,6 +699,12 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.EnableChangeParametersProposal != nil {
 		params.EnableChangeParametersProposal = *c.EnableChangeParametersProposal
 	}
+	if c.EnableSignalProposal != nil {
+		params.EnableSignalProposal = *c.EnableSignalProposal
+	}
+	if c.EnablePauseRuntimeProposal != nil {
+		params.EnablePauseRuntimeProposal = *c.EnablePauseRuntimeProposal
+	}
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
@@ -81,6 +82,33 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "signal proposal without description hash should fail",
+			p: &ProposalContent{
+				Signal: &SignalProposal{},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "signal proposal with invalid metadata URL should fail",
+			p: &ProposalContent{
+				Signal: &SignalProposal{
+					DescriptionHash: hash.NewFromBytes([]byte("description")),
+					MetadataURL:     "ftp://example.com/proposal.json",
+				},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "signal proposal with valid content should not fail",
+			p: &ProposalContent{
+				Signal: &SignalProposal{
+					DescriptionHash: hash.NewFromBytes([]byte("description")),
+					MetadataURL:     "https://example.com/proposal.json",
+				},
+			},
+			shouldErr: false,
+		},
 	} {
 		err := tc.p.ValidateBasic(&tc.params) //nolint: gosec
 		if tc.shouldErr {
@@ -197,6 +225,15 @@ func TestProposalContentPrettyPrint(t *testing.T) {
 				},
 			},
 		},
+		{
+			expRegex: "^Signal:",
+			p: &ProposalContent{
+				Signal: &SignalProposal{
+					DescriptionHash: hash.NewFromBytes([]byte("description")),
+					MetadataURL:     "https://example.com/proposal.json",
+				},
+			},
+		},
 	} {
 		var actualPrettyPrint bytes.Buffer
 		tc.p.PrettyPrint(context.Background(), "", &actualPrettyPrint)
@@ -268,6 +305,14 @@ func TestProposalContentSerialization(t *testing.T) {
 				},
 			}, "oXFjaGFuZ2VfcGFyYW1ldGVyc6JmbW9kdWxla3Rlc3QtbW9kdWxlZ2NoYW5nZXOhbXZvdGluZ19wZXJpb2QYew==",
 		},
+		{
+			ProposalContent{
+				Signal: &SignalProposal{
+					DescriptionHash: hash.NewFromBytes([]byte("description")),
+					MetadataURL:     "https://example.com/proposal.json",
+				},
+			}, "oWZzaWduYWyibG1ldGFkYXRhX3VybHghaHR0cHM6Ly9leGFtcGxlLmNvbS9wcm9wb3NhbC5qc29ucGRlc2NyaXB0aW9uX2hhc2hYIJTiVDiHkZ9jS7WEGJ5e5Zgx6PMQGgeG73D5g2gToQW2",
+		},
 	} {
 		enc := cbor.Marshal(tc.content)
 		require.Equal(tc.expectedBase64, base64.StdEncoding.EncodeToString(enc), "serialization should match")
@@ -278,3 +323,72 @@ func TestProposalContentSerialization(t *testing.T) {
 		require.EqualValues(tc.content, dec, "Proposal content serialization should round-trip")
 	}
 }
+
+func TestStakeThresholds(t *testing.T) {
+	require := require.New(t)
+
+	params := ConsensusParameters{
+		StakeThreshold:            90,
+		UpgradeMinEpochDiff:       100,
+		UpgradeCancelMinEpochDiff: 100,
+		VotingPeriod:              50,
+	}
+	require.NoError(params.SanityCheck(), "parameters without per-kind thresholds should be valid")
+	require.EqualValues(90, params.StakeThresholdFor(ProposalKindSignal))
+
+	params.StakeThresholds = map[ProposalKind]uint8{
+		ProposalKindUpgrade: 95,
+		ProposalKindSignal:  50,
+	}
+	require.NoError(params.SanityCheck(), "valid per-kind thresholds should be accepted")
+	require.EqualValues(95, params.StakeThresholdFor(ProposalKindUpgrade))
+	require.EqualValues(50, params.StakeThresholdFor(ProposalKindSignal))
+	require.EqualValues(90, params.StakeThresholdFor(ProposalKindChangeParameters))
+
+	for _, thresholds := range []map[ProposalKind]uint8{
+		{ProposalKindChangeParameters: 50},
+		{ProposalKindSignal: 0},
+		{ProposalKindSignal: 101},
+		{"unknown": 90},
+	} {
+		params.StakeThresholds = thresholds
+		require.Error(params.SanityCheck(), "invalid per-kind thresholds should be rejected: %v", thresholds)
+	}
+}
+
+func TestStakeThresholdsChanges(t *testing.T) {
+	require := require.New(t)
+
+	params := ConsensusParameters{
+		StakeThreshold: 90,
+	}
+
+	// Setting per-kind thresholds should replace the existing ones.
+	thresholds := map[ProposalKind]uint8{
+		ProposalKindSignal: 50,
+	}
+	changes := ConsensusParameterChanges{
+		StakeThresholds: &thresholds,
+	}
+	require.NoError(changes.SanityCheck(), "changes setting per-kind thresholds should be valid")
+	require.NoError(changes.Apply(&params), "Apply")
+	require.EqualValues(thresholds, params.StakeThresholds)
+
+	// Changes not touching per-kind thresholds should keep them.
+	threshold := uint8(80)
+	require.NoError((&ConsensusParameterChanges{StakeThreshold: &threshold}).Apply(&params), "Apply")
+	require.EqualValues(thresholds, params.StakeThresholds)
+
+	// An empty map should survive serialization and clear the per-kind thresholds.
+	changes = ConsensusParameterChanges{
+		StakeThresholds: &map[ProposalKind]uint8{},
+	}
+	var dec ConsensusParameterChanges
+	err := cbor.Unmarshal(cbor.Marshal(changes), &dec)
+	require.NoError(err, "Unmarshal")
+	require.NotNil(dec.StakeThresholds, "empty per-kind thresholds should round-trip")
+	require.NoError(dec.SanityCheck(), "changes clearing per-kind thresholds should be valid")
+	require.NoError(dec.Apply(&params), "Apply")
+	require.Nil(params.StakeThresholds, "per-kind thresholds should be cleared")
+	require.EqualValues(80, params.StakeThresholdFor(ProposalKindSignal))
+}
@@ -14,13 +14,11 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if !p.MinProposalDeposit.IsValid() {
 		return fmt.Errorf("min_proposal_deposit has invalid value")
 	}
-	// StakeThreshold must be less than or equal to 100.
-	if int64(p.StakeThreshold) > 100 {
-		return fmt.Errorf("stake threshold must be less than or equal to 100")
+	if err := sanityCheckStakeThreshold(p.StakeThreshold, minStakeThreshold); err != nil {
+		return err
 	}
-	// StakeThreshold must be greater than 66.
-	if int64(p.StakeThreshold) <= 66 {
-		return fmt.Errorf("stake threshold must be greater than 66")
+	if err := sanityCheckStakeThresholds(p.StakeThresholds); err != nil {
+		return err
 	}
 	// Voting_period must be less than upgrade_min_epoch_diff.
 	if p.VotingPeriod >= p.UpgradeMinEpochDiff {
@@ -33,15 +31,61 @@ func (p *ConsensusParameters) SanityCheck() error {
 	return nil
 }
 
+// minStakeThreshold is the exclusive lower bound for stake thresholds of executable proposals.
+const minStakeThreshold = 66
+
+func sanityCheckStakeThreshold(threshold uint8, lowerBound uint8) error {
+	// StakeThreshold must be less than or equal to 100.
+	if threshold > 100 {
+		return fmt.Errorf("stake threshold must be less than or equal to 100")
+	}
+	// StakeThreshold must be greater than the lower bound.
+	if threshold <= lowerBound {
+		return fmt.Errorf("stake threshold must be greater than %d", lowerBound)
+	}
+	return nil
+}
+
+func sanityCheckStakeThresholds(thresholds map[ProposalKind]uint8) error {
+	// Iterate in a deterministic order so that the reported error is the same on all nodes.
+	kinds := make([]ProposalKind, 0, len(thresholds))
+	for kind := range thresholds {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+
+	for _, kind := range kinds {
+		threshold := thresholds[kind]
+
+		var err error
+		switch kind {
+		case ProposalKindUpgrade, ProposalKindCancelUpgrade, ProposalKindChangeParameters, ProposalKindPauseRuntime:
+			err = sanityCheckStakeThreshold(threshold, minStakeThreshold)
+		case ProposalKindSignal:
+			// Signal proposals have no on-chain effects, so lower thresholds are allowed.
+			err = sanityCheckStakeThreshold(threshold, 0)
+		default:
+			err = fmt.Errorf("unknown proposal kind")
+		}
+		if err != nil {
+			return fmt.Errorf("stake threshold for %s proposals: %w", kind, err)
+		}
+	}
+	return nil
+}
+
 // SanityCheck performs a sanity check on the consensus parameter changes.
 func (c *ConsensusParameterChanges) SanityCheck() error {
 	if c.GasCosts == nil &&
 		c.MinProposalDeposit == nil &&
 		c.VotingPeriod == nil &&
 		c.StakeThreshold == nil &&
+		c.StakeThresholds == nil &&
 		c.UpgradeMinEpochDiff == nil &&
 		c.UpgradeCancelMinEpochDiff == nil &&
-		c.EnableChangeParametersProposal == nil {
+		c.EnableChangeParametersProposal == nil &&
+		c.EnableSignalProposal == nil &&
+		c.EnablePauseRuntimeProposal == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -8,6 +8,7 @@ import (
 	"os"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -117,6 +118,41 @@ func main() {
 				}
 			}
 
+			// Generate signal proposal transactions.
+			for _, tc := range []struct {
+				metadataURL string
+				valid       bool
+			}{
+				{"", true},
+				{"https://example.com/proposal.json", true},
+				{"ftp://example.com/proposal.json", false},
+			} {
+				for _, tx := range []*transaction.Transaction{
+					governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+						Signal: &governance.SignalProposal{
+							DescriptionHash: hash.NewFromBytes([]byte("signal proposal description")),
+							MetadataURL:     tc.metadataURL,
+						},
+					}),
+				} {
+					vectors = append(vectors, testvectors.MakeTestVector("SubmitProposal", tx, tc.valid))
+				}
+			}
+
+			// Generate runtime pause proposal transactions.
+			for _, resume := range []bool{false, true} {
+				for _, tx := range []*transaction.Transaction{
+					governance.NewSubmitProposalTx(nonce, fee, &governance.ProposalContent{
+						PauseRuntime: &governance.PauseRuntimeProposal{
+							RuntimeID: common.NewTestNamespaceFromSeed([]byte("pause runtime proposal"), 0),
+							Resume:    resume,
+						},
+					}),
+				} {
+					vectors = append(vectors, testvectors.MakeTestVector("SubmitProposal", tx, true))
+				}
+			}
+
 			// Generate cast vote transactions.
 			for _, id := range []uint64{0, 1000, 10_000_000, math.MaxUint64} {
 				for _, vote := range []governance.Vote{
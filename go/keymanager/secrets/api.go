@@ -92,6 +92,12 @@ var (
 	// RPCMethodLoadEphemeralSecret is the name of the `load_ephemeral_secret` RPC method.
 	RPCMethodLoadEphemeralSecret = "load_ephemeral_secret"
 
+	// RPCMethodKeyCacheStats is the name of the `key_cache_stats` RPC method.
+	RPCMethodKeyCacheStats = "key_cache_stats"
+
+	// RPCMethodFlushKeyCache is the name of the `flush_key_cache` RPC method.
+	RPCMethodFlushKeyCache = "flush_key_cache"
+
 	// initResponseSignatureContext is the context used to sign key manager init responses.
 	initResponseSignatureContext = signature.NewContext("oasis-core/keymanager: init response")
 )
@@ -150,6 +156,17 @@ type Status struct {
 	RSK *signature.PublicKey `json:"rsk,omitempty"`
 }
 
+// IsMasterSecretGenerator returns true iff the policy authorizes the given node to generate
+// master secrets.
+//
+// Note that the node must also be a member of the key manager committee.
+func (s *Status) IsMasterSecretGenerator(id signature.PublicKey) bool {
+	if s.Policy == nil {
+		return true
+	}
+	return s.Policy.Policy.IsMasterSecretGenerator(id)
+}
+
 // NextGeneration returns the generation of the next master secret.
 func (s *Status) NextGeneration() uint64 {
 	if len(s.Checksum) == 0 {
@@ -195,6 +212,10 @@ type Backend interface {
 	// WatchStatuses returns a channel that produces a stream of messages
 	// containing the key manager statuses as it changes over time.
 	//
+	// Statuses are pushed as soon as they change, either on epoch transitions
+	// or when a policy update transaction is executed, so subscribers should
+	// not poll for status changes.
+	//
 	// Upon subscription the current status is sent immediately.
 	WatchStatuses() (<-chan *Status, *pubsub.Subscription)
 
@@ -233,6 +254,10 @@ func NewPublishEphemeralSecretTx(nonce uint64, fee *transaction.Fee, sigSec *Sig
 // enclave.
 type InitRequest struct {
 	Status Status `json:"status,omitempty"`
+
+	// ReplicationQuorum is the number of distinct key manager enclaves from which each
+	// replicated master secret must be obtained.
+	ReplicationQuorum uint64 `json:"replication_quorum,omitempty"`
 }
 
 // InitResponse is the initialization RPC response, returned as part of a
@@ -281,6 +306,7 @@ type LongTermKeyRequest struct {
 	ID         common.Namespace `json:"runtime_id"`
 	KeyPairID  KeyPairID        `json:"key_pair_id"`
 	Generation uint64           `json:"generation"`
+	Context    string           `json:"context,omitempty"`
 }
 
 // EphemeralKeyRequest is the ephemeral key RPC request, sent to the key manager
@@ -290,6 +316,7 @@ type EphemeralKeyRequest struct {
 	ID        common.Namespace `json:"runtime_id"`
 	KeyPairID KeyPairID        `json:"key_pair_id"`
 	Epoch     beacon.EpochTime `json:"epoch"`
+	Context   string           `json:"context,omitempty"`
 }
 
 // SignedPublicKey is the RPC response, returned as part of
@@ -338,6 +365,27 @@ type LoadEphemeralSecretRequest struct {
 	SignedSecret SignedEncryptedEphemeralSecret `json:"signed_secret"`
 }
 
+// KeyCacheStats are the statistics of a key cache in the key manager enclave.
+type KeyCacheStats struct {
+	// Size is the number of cached keys.
+	Size uint64 `json:"size"`
+	// Capacity is the maximum number of cached keys.
+	Capacity uint64 `json:"capacity"`
+	// Hits is the number of requests served from the cache since the enclave started.
+	Hits uint64 `json:"hits"`
+	// Misses is the number of requests that required key derivation since the enclave started.
+	Misses uint64 `json:"misses"`
+}
+
+// KeyCacheStatsResponse is the key cache statistics RPC response, returned
+// from the key manager enclave.
+type KeyCacheStatsResponse struct {
+	// LongTermKeys are the long-term private key cache statistics.
+	LongTermKeys KeyCacheStats `json:"longterm_keys"`
+	// EphemeralKeys are the ephemeral private key cache statistics.
+	EphemeralKeys KeyCacheStats `json:"ephemeral_keys"`
+}
+
 // Genesis is the key manager management genesis state for secrets.
 type Genesis struct {
 	// Parameters are the consensus parameters for secrets.
@@ -350,12 +398,26 @@ type Genesis struct {
 // ConsensusParameters are the key manager consensus parameters.
 type ConsensusParameters struct {
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// EnableMasterSecretGenerators is true iff key manager policies are allowed to designate
+	// the nodes that may generate master secrets.
+	EnableMasterSecretGenerators bool `json:"enable_master_secret_generators,omitempty"`
+
+	// EnableKeyDerivationContexts is true iff key manager policies are allowed to restrict
+	// the key derivation contexts under which enclaves may query keys.
+	EnableKeyDerivationContexts bool `json:"enable_key_derivation_contexts,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed key manager consensus parameter changes.
 type ConsensusParameterChanges struct {
 	// GasCosts are the new gas costs.
 	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+
+	// EnableMasterSecretGenerators is the new enable master secret generators flag.
+	EnableMasterSecretGenerators *bool `json:"enable_master_secret_generators,omitempty"`
+
+	// EnableKeyDerivationContexts is the new enable key derivation contexts flag.
+	EnableKeyDerivationContexts *bool `json:"enable_key_derivation_contexts,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -363,6 +425,12 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.GasCosts != nil {
 		params.GasCosts = c.GasCosts
 	}
+	if c.EnableMasterSecretGenerators != nil {
+		params.EnableMasterSecretGenerators = *c.EnableMasterSecretGenerators
+	}
+	if c.EnableKeyDerivationContexts != nil {
+		params.EnableKeyDerivationContexts = *c.EnableKeyDerivationContexts
+	}
 	return nil
 }
 
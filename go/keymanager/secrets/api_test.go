@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
@@ -30,6 +32,31 @@ func TestSignVerify(t *testing.T) {
 	require.Error(err, "verification with different public key should fail")
 }
 
+func TestInitRequest(t *testing.T) {
+	require := require.New(t)
+
+	status := Status{
+		IsInitialized: true,
+		Checksum:      []byte{1, 2, 3},
+		Generation:    5,
+	}
+
+	// Requests without a replication quorum should be encoded as before.
+	type legacyInitRequest struct {
+		Status Status `json:"status,omitempty"`
+	}
+	raw := cbor.Marshal(InitRequest{Status: status})
+	require.Equal(cbor.Marshal(legacyInitRequest{Status: status}), raw)
+
+	// The replication quorum should be preserved.
+	raw = cbor.Marshal(InitRequest{Status: status, ReplicationQuorum: 3})
+	var req InitRequest
+	err := cbor.Unmarshal(raw, &req)
+	require.NoError(err, "Unmarshal")
+	require.EqualValues(3, req.ReplicationQuorum)
+	require.Equal(status.Checksum, req.Status.Checksum)
+}
+
 func TestStatus(t *testing.T) {
 	require := require.New(t)
 
@@ -45,3 +72,24 @@ func TestStatus(t *testing.T) {
 	s.Generation = 9
 	require.Equal(uint64(10), s.NextGeneration())
 }
+
+func TestMasterSecretGenerators(t *testing.T) {
+	require := require.New(t)
+
+	node1 := memorySigner.NewTestSigner("node1").Public()
+	node2 := memorySigner.NewTestSigner("node2").Public()
+
+	// Key manager without a policy.
+	var s Status
+	require.True(s.IsMasterSecretGenerator(node1))
+
+	// Policy without designated generators.
+	s.Policy = &SignedPolicySGX{}
+	require.True(s.IsMasterSecretGenerator(node1))
+	require.True(s.IsMasterSecretGenerator(node2))
+
+	// Policy with designated generators.
+	s.Policy.Policy.MasterSecretGenerators = []signature.PublicKey{node1}
+	require.True(s.IsMasterSecretGenerator(node1))
+	require.False(s.IsMasterSecretGenerator(node2))
+}
@@ -2,6 +2,7 @@ package secrets
 
 import (
 	"fmt"
+	"slices"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -31,6 +32,34 @@ type PolicySGX struct {
 
 	// MaxEphemeralSecretAge is the maximum age of an ephemeral secret in the number of epochs.
 	MaxEphemeralSecretAge beacon.EpochTime `json:"max_ephemeral_secret_age,omitempty"`
+
+	// MasterSecretGenerators is the list of key manager node IDs that may generate and publish
+	// master secrets. If empty, any node in the key manager committee may do so.
+	MasterSecretGenerators []signature.PublicKey `json:"master_secret_generators,omitempty"`
+}
+
+// IsMasterSecretGenerator returns true iff the given node may generate master secrets.
+func (p *PolicySGX) IsMasterSecretGenerator(id signature.PublicKey) bool {
+	if len(p.MasterSecretGenerators) == 0 {
+		return true
+	}
+	return slices.Contains(p.MasterSecretGenerators, id)
+}
+
+// VerifyFeatures verifies that the policy only uses features enabled by the given consensus
+// parameters.
+func (p *PolicySGX) VerifyFeatures(params *ConsensusParameters) error {
+	if len(p.MasterSecretGenerators) > 0 && !params.EnableMasterSecretGenerators {
+		return fmt.Errorf("master secret generators not enabled")
+	}
+	if !params.EnableKeyDerivationContexts {
+		for _, enclavePol := range p.Enclaves {
+			if enclavePol != nil && len(enclavePol.MayQueryContexts) > 0 {
+				return fmt.Errorf("key derivation contexts not enabled")
+			}
+		}
+	}
+	return nil
 }
 
 // EnclavePolicySGX is the per-SGX key manager enclave ID access control policy.
@@ -48,6 +77,13 @@ type EnclavePolicySGX struct {
 	// NOTE: Each enclave ID may always implicitly replicate from other
 	// instances of itself.
 	MayReplicate []sgx.EnclaveIdentity `json:"may_replicate"`
+
+	// MayQueryContexts is the map of enclave IDs to the vector of key derivation
+	// context labels under which they may query private key material.
+	//
+	// NOTE: Each enclave ID may always query keys under the default (empty)
+	// context, subject to MayQuery.
+	MayQueryContexts map[sgx.EnclaveIdentity][]string `json:"may_query_contexts,omitempty"`
 }
 
 // SignedPolicySGX is a signed SGX key manager access control policy.
@@ -69,6 +105,20 @@ func SanityCheckSignedPolicySGX(currentSigPol, newSigPol *SignedPolicySGX) error
 		}
 	}
 
+	newPol := newSigPol.Policy
+	for _, enclavePol := range newPol.Enclaves {
+		if enclavePol == nil {
+			continue
+		}
+		for _, contexts := range enclavePol.MayQueryContexts {
+			for _, context := range contexts {
+				if context == "" {
+					return fmt.Errorf("keymanager: sanity check failed: SGX policy key derivation context must not be empty")
+				}
+			}
+		}
+	}
+
 	// If a prior version of the policy is not provided, then there is nothing
 	// more to check.  Even with a prior version of the document, since policy
 	// updates can happen independently of a new version of the enclave, it's
@@ -77,7 +127,7 @@ func SanityCheckSignedPolicySGX(currentSigPol, newSigPol *SignedPolicySGX) error
 		return nil
 	}
 
-	currentPol, newPol := currentSigPol.Policy, newSigPol.Policy
+	currentPol := currentSigPol.Policy
 	if !newPol.ID.Equal(&currentPol.ID) {
 		return fmt.Errorf("keymanager: sanity check failed: SGX policy runtime ID changed from %s to %s", currentPol.ID, newPol.ID)
 	}
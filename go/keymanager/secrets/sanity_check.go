@@ -45,7 +45,9 @@ func (p *ConsensusParameters) SanityCheck() error {
 
 // SanityCheck performs a sanity check on the consensus parameter changes.
 func (c *ConsensusParameterChanges) SanityCheck() error {
-	if c.GasCosts == nil {
+	if c.GasCosts == nil &&
+		c.EnableMasterSecretGenerators == nil &&
+		c.EnableKeyDerivationContexts == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
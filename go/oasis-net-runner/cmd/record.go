@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	cfgRecord = "record"
+
+	// rawCodecName is the name reported by the raw codec. It matches the CBOR codec used by
+	// oasis-node so that forwarded calls are accepted as-is.
+	rawCodecName = "cbor"
+)
+
+// scriptHeader describes the network a script was recorded against. It is stored as the first
+// line of the script.
+type scriptHeader struct {
+	// ChainContext is the chain context of the network.
+	ChainContext string `json:"chain_context"`
+	// GenesisTime is the genesis time of the network.
+	GenesisTime time.Time `json:"genesis_time"`
+}
+
+// scriptEntry is a single recorded call performed against a network.
+type scriptEntry struct {
+	// Offset is the time since the start of the recording at which the call was made.
+	Offset time.Duration `json:"offset"`
+	// Method is the full gRPC method name.
+	Method string `json:"method"`
+	// Request is the raw CBOR-encoded request.
+	Request []byte `json:"request"`
+	// Responses is the number of responses received before the call completed.
+	Responses int `json:"responses"`
+	// Streaming is true when the call was a long-lived stream that was closed by the client.
+	Streaming bool `json:"streaming,omitempty"`
+	// Code is the status code the call completed with.
+	Code codes.Code `json:"code"`
+}
+
+// readScript reads a recorded script, ordering the calls by the time they were made.
+func readScript(r io.Reader) (*scriptHeader, []*scriptEntry, error) {
+	dec := json.NewDecoder(r)
+
+	var hdr scriptHeader
+	if err := dec.Decode(&hdr); err != nil {
+		return nil, nil, fmt.Errorf("malformed script header: %w", err)
+	}
+	if hdr.ChainContext == "" {
+		return nil, nil, fmt.Errorf("malformed script header: missing chain context")
+	}
+
+	var entries []*scriptEntry
+	for {
+		var e scriptEntry
+		err := dec.Decode(&e)
+		switch {
+		case errors.Is(err, io.EOF):
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].Offset < entries[j].Offset })
+			return &hdr, entries, nil
+		case err != nil:
+			return nil, nil, fmt.Errorf("malformed script entry %d: %w", len(entries), err)
+		}
+		if e.Method == "" {
+			return nil, nil, fmt.Errorf("malformed script entry %d: missing method", len(entries))
+		}
+		entries = append(entries, &e)
+	}
+}
+
+// scriptWriter appends recorded calls to a script.
+type scriptWriter struct {
+	sync.Mutex
+
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newScriptWriter(w io.Writer) *scriptWriter {
+	bw := bufio.NewWriter(w)
+	return &scriptWriter{
+		w:   bw,
+		enc: json.NewEncoder(bw),
+	}
+}
+
+func (sw *scriptWriter) write(e interface{}) error {
+	sw.Lock()
+	defer sw.Unlock()
+
+	if err := sw.enc.Encode(e); err != nil {
+		return err
+	}
+	// Flush after each call so that the script is usable even if the runner is killed.
+	return sw.w.Flush()
+}
+
+// rawCodec is a gRPC codec which passes already encoded messages through unchanged.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("raw codec: unsupported message type %T", v)
+	}
+	return *b, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("raw codec: unsupported message type %T", v)
+	}
+	*b = append([]byte{}, data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return rawCodecName
+}
+
+// dialRaw creates a client connection to the given node internal socket which sends and receives
+// raw messages.
+func dialRaw(socketPath string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(
+		"unix:"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.ForceCodec(rawCodec{}),
+			grpc.WaitForReady(true),
+		),
+	)
+}
+
+// recorder is a gRPC proxy which forwards all calls to a node and records them into a script.
+//
+// All oasis-node methods take a single request, so each call is forwarded as a (possibly
+// server-streaming) call with exactly one request message.
+type recorder struct {
+	conn   *grpc.ClientConn
+	server *grpc.Server
+	script *scriptWriter
+	start  time.Time
+
+	logger *logging.Logger
+}
+
+func (r *recorder) handleStream(_ interface{}, ss grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(ss)
+	if !ok {
+		return status.Error(codes.Internal, "failed to determine method")
+	}
+	offset := time.Since(r.start)
+
+	var req []byte
+	if err := ss.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	entry := &scriptEntry{
+		Offset:  offset,
+		Method:  method,
+		Request: req,
+	}
+	err := r.forward(ss, entry)
+	entry.Code = status.Code(err)
+
+	if werr := r.script.write(entry); werr != nil {
+		r.logger.Error("failed to record call",
+			"err", werr,
+			"method", method,
+		)
+	}
+	return err
+}
+
+func (r *recorder) forward(ss grpc.ServerStream, entry *scriptEntry) error {
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md.Copy())
+	}
+
+	cs, err := r.conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, entry.Method)
+	if err != nil {
+		return err
+	}
+	if err = cs.SendMsg(&entry.Request); err != nil {
+		return err
+	}
+	if err = cs.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		var resp []byte
+		if err = cs.RecvMsg(&resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if ss.Context().Err() != nil {
+				// Client has closed the call.
+				entry.Streaming = true
+			}
+			return err
+		}
+		if err = ss.SendMsg(&resp); err != nil {
+			entry.Streaming = true
+			return err
+		}
+		entry.Responses++
+	}
+}
+
+// startRecorder starts a recording proxy listening on the given socket path which forwards calls
+// to the node listening on the given internal socket path.
+func startRecorder(proxyPath, nodePath string, script io.Writer, hdr *scriptHeader) (*recorder, error) {
+	sw := newScriptWriter(script)
+	if err := sw.write(hdr); err != nil {
+		return nil, fmt.Errorf("failed to write script header: %w", err)
+	}
+
+	conn, err := dialRaw(nodePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial node: %w", err)
+	}
+
+	_ = os.Remove(proxyPath)
+	ln, err := net.Listen("unix", proxyPath)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", proxyPath, err)
+	}
+
+	r := &recorder{
+		conn:   conn,
+		script: sw,
+		start:  time.Now(),
+		logger: logging.GetLogger("net-runner/recorder"),
+	}
+	r.server = grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(r.handleStream),
+	)
+	go func() {
+		if serr := r.server.Serve(ln); serr != nil {
+			r.logger.Error("recording proxy terminated",
+				"err", serr,
+			)
+		}
+	}()
+
+	return r, nil
+}
+
+// Stop stops the recording proxy.
+func (r *recorder) Stop() {
+	r.server.Stop()
+	r.conn.Close()
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startEchoServer starts a test server which echoes requests to "/test.Echo/Echo", fails calls to
+// "/test.Echo/Fail" and streams responses to "/test.Echo/Watch" until the client goes away.
+func startEchoServer(t *testing.T, socketPath string) {
+	ln, err := net.Listen("unix", socketPath)
+	require.NoError(t, err, "Listen")
+
+	srv := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(func(_ interface{}, ss grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(ss)
+
+			var req []byte
+			if err := ss.RecvMsg(&req); err != nil {
+				return err
+			}
+
+			switch method {
+			case "/test.Echo/Echo":
+				return ss.SendMsg(&req)
+			case "/test.Echo/Watch":
+				for {
+					if err := ss.SendMsg(&req); err != nil {
+						return err
+					}
+					select {
+					case <-ss.Context().Done():
+						return ss.Context().Err()
+					case <-time.After(10 * time.Millisecond):
+					}
+				}
+			default:
+				return status.Error(codes.InvalidArgument, "failed")
+			}
+		}),
+	)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(srv.Stop)
+}
+
+func TestRecordReplay(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	nodePath := filepath.Join(dir, "node.sock")
+	proxyPath := filepath.Join(dir, "proxy.sock")
+	startEchoServer(t, nodePath)
+
+	// Record a session through the proxy.
+	scriptPath := filepath.Join(dir, "script.jsonl")
+	script, err := os.Create(scriptPath)
+	require.NoError(err, "Create")
+	defer script.Close()
+	hdr := &scriptHeader{
+		ChainContext: "test chain context",
+		GenesisTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	rec, err := startRecorder(proxyPath, nodePath, script, hdr)
+	require.NoError(err, "startRecorder")
+
+	conn, err := dialRaw(proxyPath)
+	require.NoError(err, "dialRaw")
+	defer conn.Close()
+
+	ctx := context.Background()
+	req := []byte{0x01, 0x02}
+	var resp []byte
+	err = conn.Invoke(ctx, "/test.Echo/Echo", &req, &resp)
+	require.NoError(err, "Echo")
+	require.Equal(req, resp, "Echo response should be forwarded")
+
+	err = conn.Invoke(ctx, "/test.Echo/Fail", &req, &resp)
+	require.Equal(codes.InvalidArgument, status.Code(err), "Fail status should be forwarded")
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	cs, err := conn.NewStream(watchCtx, &grpc.StreamDesc{ServerStreams: true}, "/test.Echo/Watch")
+	require.NoError(err, "Watch")
+	require.NoError(cs.SendMsg(&req))
+	require.NoError(cs.CloseSend())
+	for i := 0; i < 3; i++ {
+		require.NoError(cs.RecvMsg(&resp), "Watch RecvMsg")
+	}
+	cancel()
+
+	// Wait for the proxy to record the closed stream.
+	var (
+		readHdr *scriptHeader
+		entries []*scriptEntry
+	)
+	require.Eventually(func() bool {
+		data, rerr := os.ReadFile(scriptPath)
+		if rerr != nil {
+			return false
+		}
+		readHdr, entries, rerr = readScript(bytes.NewReader(data))
+		return rerr == nil && len(entries) == 3
+	}, 5*time.Second, 10*time.Millisecond, "all calls should be recorded")
+	rec.Stop()
+
+	require.Equal(hdr.ChainContext, readHdr.ChainContext, "header should be recorded")
+	require.True(hdr.GenesisTime.Equal(readHdr.GenesisTime), "header should be recorded")
+	require.Len(entries, 3)
+	require.Equal("/test.Echo/Echo", entries[0].Method)
+	require.Equal(req, entries[0].Request)
+	require.Equal(1, entries[0].Responses)
+	require.Equal(codes.OK, entries[0].Code)
+	require.False(entries[0].Streaming)
+	require.Equal("/test.Echo/Fail", entries[1].Method)
+	require.Equal(codes.InvalidArgument, entries[1].Code)
+	require.Equal("/test.Echo/Watch", entries[2].Method)
+	require.True(entries[2].Streaming, "closed stream should be marked as streaming")
+	require.GreaterOrEqual(entries[2].Responses, 3)
+
+	// Replay the session directly against the node.
+	nodeConn, err := dialRaw(nodePath)
+	require.NoError(err, "dialRaw")
+	defer nodeConn.Close()
+
+	errs := replayScript(ctx, nodeConn, entries, false, 5*time.Second)
+	require.Empty(errs, "replay should succeed")
+
+	// A call completing with a different status should be reported.
+	entries[1].Code = codes.OK
+	errs = replayScript(ctx, nodeConn, entries, false, 5*time.Second)
+	require.Len(errs, 1, "status mismatch should be reported")
+}
+
+func TestReadScript(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := readScript(bytes.NewBufferString(`{"method":"/test.Echo/Echo"}`))
+	require.Error(err, "scripts without a header should be rejected")
+
+	_, entries, err := readScript(bytes.NewBufferString(`{"chain_context":"test"}
+{"offset":2,"method":"/oasis-core.Consensus/SubmitTx"}
+{"offset":1,"method":"/test.Echo/Echo"}
+{"offset":3,"method":"/oasis-core.Consensus/SubmitTxWithProof"}
+`))
+	require.NoError(err, "readScript")
+	require.Len(entries, 3)
+	require.Equal("/test.Echo/Echo", entries[0].Method, "entries should be ordered by offset")
+
+	// Transaction submissions should be skipped when replaying against a different chain.
+	filtered, skipped := filterTxSubmissions(entries)
+	require.Equal(2, skipped)
+	require.Len(filtered, 1)
+	require.Equal("/test.Echo/Echo", filtered[0].Method)
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/oasis-net-runner/fixtures"
+)
+
+const (
+	cfgReplayScript         = "replay.script"
+	cfgReplayPreserveTiming = "replay.preserve_timing"
+	cfgReplayCallTimeout    = "replay.call_timeout"
+)
+
+var (
+	replayCmd = &cobra.Command{
+		Use:   "replay",
+		Short: "replay a recorded script against a fresh network",
+		Long: `Starts a fresh network using the configured fixture and re-executes all calls
+from a script previously recorded via the --record flag against its client node.
+
+Each replayed call must complete with the same status as the recorded one, which
+makes it possible to turn manual debugging sessions into regression scripts.
+
+The network is started with the genesis time of the recorded one. Transactions are
+signed for a specific chain context, so if the chain context of the fresh network
+still differs (e.g. because the fixture does not use deterministic identities),
+recorded transaction submissions are skipped.`,
+		RunE: runReplay,
+	}
+
+	replayFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	// txSubmissionMethods are the methods submitting signed consensus transactions, which can
+	// only be replayed against a network with the same chain context.
+	txSubmissionMethods = map[string]bool{
+		"/oasis-core.Consensus/SubmitTx":          true,
+		"/oasis-core.Consensus/SubmitTxNoWait":    true,
+		"/oasis-core.Consensus/SubmitTxWithProof": true,
+	}
+)
+
+// filterTxSubmissions returns the entries that do not submit consensus transactions and the
+// number of entries that were removed.
+func filterTxSubmissions(entries []*scriptEntry) ([]*scriptEntry, int) {
+	var filtered []*scriptEntry
+	for _, e := range entries {
+		if txSubmissionMethods[e.Method] {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered, len(entries) - len(filtered)
+}
+
+// replayCall re-executes a single recorded call and checks that it completes with the recorded
+// status.
+func replayCall(ctx context.Context, conn grpc.ClientConnInterface, e *scriptEntry, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := func() error {
+		cs, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, e.Method)
+		if err != nil {
+			return err
+		}
+		if err = cs.SendMsg(&e.Request); err != nil {
+			return err
+		}
+		if err = cs.CloseSend(); err != nil {
+			return err
+		}
+
+		for n := 0; ; n++ {
+			if e.Streaming && n >= e.Responses {
+				// The recorded stream was closed by the client after receiving this many
+				// responses, do the same.
+				return nil
+			}
+
+			var resp []byte
+			if err = cs.RecvMsg(&resp); err != nil {
+				return err
+			}
+		}
+	}()
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if e.Streaming && err == nil {
+		return nil
+	}
+
+	if code := status.Code(err); code != e.Code {
+		return fmt.Errorf("%s: expected status %s, got %s (err: %v)", e.Method, e.Code, code, err)
+	}
+	return nil
+}
+
+// replayScript re-executes all recorded calls in order. Streaming calls are performed in the
+// background so that they do not block subsequent calls.
+func replayScript(ctx context.Context, conn grpc.ClientConnInterface, entries []*scriptEntry, preserveTiming bool, timeout time.Duration) []error {
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs []error
+	)
+	recordErr := func(err error) {
+		if err == nil {
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		errs = append(errs, err)
+	}
+
+	start := time.Now()
+	for _, e := range entries {
+		if preserveTiming {
+			select {
+			case <-ctx.Done():
+				recordErr(ctx.Err())
+				return errs
+			case <-time.After(time.Until(start.Add(e.Offset))):
+			}
+		}
+
+		if !e.Streaming {
+			recordErr(replayCall(ctx, conn, e, timeout))
+			continue
+		}
+
+		wg.Add(1)
+		go func(e *scriptEntry) {
+			defer wg.Done()
+			recordErr(replayCall(ctx, conn, e, timeout))
+		}(e)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func runReplay(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	f, err := os.Open(viper.GetString(cfgReplayScript))
+	if err != nil {
+		return fmt.Errorf("replay: failed to open script: %w", err)
+	}
+	hdr, entries, err := readScript(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("replay: failed to read script: %w", err)
+	}
+
+	// Initialize the base dir, logging, etc.
+	rootEnv, err := initRootEnv(cmd)
+	if err != nil {
+		return err
+	}
+	defer rootEnv.Cleanup()
+	logger := logging.GetLogger("net-runner/replay")
+
+	childEnv, err := rootEnv.NewChild("net-runner", nil)
+	if err != nil {
+		return fmt.Errorf("replay: failed to setup child environment: %w", err)
+	}
+
+	net, err := startNetwork(childEnv, logger, hdr.GenesisTime)
+	if err != nil {
+		return err
+	}
+	if len(net.Clients()) == 0 {
+		return fmt.Errorf("replay: replaying requires a client node")
+	}
+
+	netHdr, err := networkScriptHeader(net)
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	var skipped int
+	if netHdr.ChainContext != hdr.ChainContext {
+		logger.Warn("chain context differs from the recorded one, skipping transaction submissions",
+			"recorded", hdr.ChainContext,
+			"current", netHdr.ChainContext,
+		)
+		entries, skipped = filterTxSubmissions(entries)
+	}
+
+	conn, err := dialRaw(net.Clients()[0].SocketPath())
+	if err != nil {
+		return fmt.Errorf("replay: failed to dial client node: %w", err)
+	}
+	defer conn.Close()
+
+	logger.Info("replaying script",
+		"calls", len(entries),
+		"skipped", skipped,
+	)
+
+	errs := replayScript(
+		cmd.Context(),
+		conn,
+		entries,
+		viper.GetBool(cfgReplayPreserveTiming),
+		viper.GetDuration(cfgReplayCallTimeout),
+	)
+	for _, err = range errs {
+		logger.Error("replayed call failed",
+			"err", err,
+		)
+	}
+	fmt.Printf("Replayed %d calls, %d skipped, %d failed.\n", len(entries), skipped, len(errs))
+
+	if len(errs) > 0 {
+		return fmt.Errorf("replay: %d calls failed", len(errs))
+	}
+	return nil
+}
+
+func init() {
+	replayFlags.String(cfgReplayScript, "", "path to the recorded script")
+	replayFlags.Bool(cfgReplayPreserveTiming, false, "preserve the recorded delays between calls")
+	replayFlags.Duration(cfgReplayCallTimeout, 5*time.Minute, "timeout for each replayed call")
+	_ = viper.BindPFlags(replayFlags)
+
+	replayCmd.Flags().AddFlagSet(replayFlags)
+	replayCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
+	replayCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -13,10 +14,12 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	"github.com/oasisprotocol/oasis-core/go/oasis-net-runner/fixtures"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 )
 
 const (
@@ -40,7 +43,8 @@ var (
 		Run:   doDumpFixture,
 	}
 
-	rootFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	rootFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	recordFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	cfgFile string
 )
@@ -105,6 +109,60 @@ func initRootEnv(cmd *cobra.Command) (*env.Env, error) {
 	return env, nil
 }
 
+// startNetwork instantiates the configured fixture and starts the network.
+//
+// If the given genesis time is not zero, it is used instead of the current time.
+func startNetwork(childEnv *env.Env, logger *logging.Logger, genesisTime time.Time) (*oasis.Network, error) {
+	fixture, err := fixtures.GetFixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Instantiate fixture.
+	logger.Debug("instantiating fixture")
+	net, err := fixture.Create(childEnv)
+	if err != nil {
+		logger.Error("failed to instantiate fixture",
+			"err", err,
+		)
+		return nil, fmt.Errorf("root: failed to instantiate fixture: %w", err)
+	}
+
+	// Set logging level and format for all spawned nodes.
+	net.Config().NodeLogLevel = viper.GetString(cfgLogLevel)
+	net.Config().NodeLogFormat = viper.GetString(cfgLogFmt)
+	if !genesisTime.IsZero() {
+		net.Config().GenesisTime = genesisTime
+	}
+
+	// Start the network.
+	if err = net.Start(); err != nil {
+		logger.Error("failed to start network",
+			"err", err,
+		)
+		return nil, fmt.Errorf("root: failed to start network: %w", err)
+	}
+
+	return net, nil
+}
+
+// networkScriptHeader returns the script header describing the given network.
+func networkScriptHeader(net *oasis.Network) (*scriptHeader, error) {
+	genesisProvider, err := genesisFile.NewFileProvider(net.GenesisPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load genesis file: %w", err)
+	}
+	genesisDoc, err := genesisProvider.GetGenesisDocument()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve genesis document: %w", err)
+	}
+
+	return &scriptHeader{
+		ChainContext: genesisDoc.ChainContext(),
+		GenesisTime:  genesisDoc.Time,
+	}, nil
+}
+
 func runRoot(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true
 
@@ -124,37 +182,46 @@ func runRoot(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("root: failed to setup child environment: %w", err)
 	}
 
-	fixture, err := fixtures.GetFixture()
+	// Start the network and keep it running.
+	net, err := startNetwork(childEnv, logger, time.Time{})
 	if err != nil {
 		return err
 	}
 
-	// Instantiate fixture.
-	logger.Debug("instantiating fixture")
-	net, err := fixture.Create(childEnv)
-	if err != nil {
-		logger.Error("failed to instantiate fixture",
-			"err", err,
+	// Display information about where the client node socket is.
+	if len(net.Clients()) > 0 {
+		logger.Info("client node socket available",
+			"path", net.Clients()[0].SocketPath(),
 		)
-		return fmt.Errorf("root: failed to instantiate fixture: %w", err)
 	}
 
-	// Set logging level and format for all spawned nodes.
-	net.Config().NodeLogLevel = viper.GetString(cfgLogLevel)
-	net.Config().NodeLogFormat = viper.GetString(cfgLogFmt)
+	// Record interactions with the client node if configured.
+	if scriptFile := viper.GetString(cfgRecord); scriptFile != "" {
+		if len(net.Clients()) == 0 {
+			return fmt.Errorf("root: recording requires a client node")
+		}
 
-	// Start the network and keep it running.
-	if err = net.Start(); err != nil {
-		logger.Error("failed to start network",
-			"err", err,
-		)
-		return fmt.Errorf("root: failed to start network: %w", err)
-	}
+		var f *os.File
+		if f, err = os.OpenFile(scriptFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600); err != nil {
+			return fmt.Errorf("root: failed to create script file: %w", err)
+		}
+		defer f.Close()
 
-	// Display information about where the client node socket is.
-	if len(net.Clients()) > 0 {
-		logger.Info("client node socket available",
-			"path", net.Clients()[0].SocketPath(),
+		var hdr *scriptHeader
+		if hdr, err = networkScriptHeader(net); err != nil {
+			return fmt.Errorf("root: %w", err)
+		}
+
+		proxyPath := filepath.Join(env.GetRootDir().String(), "record.sock")
+		var rec *recorder
+		if rec, err = startRecorder(proxyPath, net.Clients()[0].SocketPath(), f, hdr); err != nil {
+			return fmt.Errorf("root: failed to start recording proxy: %w", err)
+		}
+		defer rec.Stop()
+
+		logger.Info("recording proxy socket available",
+			"path", proxyPath,
+			"script", scriptFile,
 		)
 	}
 
@@ -196,13 +263,19 @@ func init() {
 	rootFlags.Bool(cfgLogNoStdout, false, "do not mutiplex logs to stdout")
 	_ = viper.BindPFlags(rootFlags)
 
+	recordFlags.String(cfgRecord, "", "record calls made through the recording proxy socket into the given script file")
+	_ = viper.BindPFlags(recordFlags)
+
 	rootCmd.PersistentFlags().AddFlagSet(rootFlags)
 	rootCmd.PersistentFlags().AddFlagSet(env.Flags)
 	rootCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.Flags().AddFlagSet(fixtures.FileFixtureFlags)
+	rootCmd.Flags().AddFlagSet(recordFlags)
 
 	dumpFixtureCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.AddCommand(dumpFixtureCmd)
+	rootCmd.AddCommand(workloadCmd)
+	rootCmd.AddCommand(replayCmd)
 
 	cobra.OnInitialize(func() {
 		if cfgFile != "" {
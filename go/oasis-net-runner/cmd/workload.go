@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	kvRuntime "github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e/runtime"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+const (
+	cfgWorkloadRuntimeID         = "workload.runtime_id"
+	cfgWorkloadRate              = "workload.rate"
+	cfgWorkloadPayloadSize       = "workload.payload_size"
+	cfgWorkloadEncryptedFraction = "workload.encrypted_fraction"
+	cfgWorkloadDuration          = "workload.duration"
+	cfgWorkloadConcurrency       = "workload.concurrency"
+	cfgWorkloadSeed              = "workload.seed"
+
+	payloadAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+var (
+	workloadCmd = &cobra.Command{
+		Use:   "workload",
+		Short: "run a key/value runtime workload against a running network",
+		Long: `Submits key/value runtime insert transactions to a client node of a running
+network at a fixed rate and reports latency and throughput once done.
+
+The client node address can be obtained from the "client node socket available"
+log message emitted by oasis-net-runner after the network has started.`,
+		Run: doWorkload,
+	}
+
+	workloadFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// workloadTx is a single workload transaction.
+type workloadTx struct {
+	nonce     uint64
+	encrypted bool
+	call      kvRuntime.InsertCall
+}
+
+// workloadStats are the workload statistics.
+type workloadStats struct {
+	sync.Mutex
+
+	submitted uint64
+	failed    uint64
+	dropped   uint64
+	latencies []time.Duration
+}
+
+func (s *workloadStats) record(latency time.Duration, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err != nil {
+		s.failed++
+		return
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+// percentile returns the given percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p/100*float64(len(sorted)) + 0.5)
+	if idx > 0 {
+		idx--
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// report writes a human readable summary of the workload statistics to the given writer.
+func (s *workloadStats) report(w io.Writer, elapsed time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	sorted := append([]time.Duration{}, s.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	var mean time.Duration
+	if len(sorted) > 0 {
+		mean = total / time.Duration(len(sorted))
+	}
+	var throughput float64
+	if elapsed > 0 {
+		throughput = float64(len(sorted)) / elapsed.Seconds()
+	}
+
+	fmt.Fprintf(w, "Duration:   %s\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Submitted:  %d\n", s.submitted)
+	fmt.Fprintf(w, "Succeeded:  %d\n", len(sorted))
+	fmt.Fprintf(w, "Failed:     %d\n", s.failed)
+	fmt.Fprintf(w, "Dropped:    %d\n", s.dropped)
+	fmt.Fprintf(w, "Throughput: %.2f tx/s\n", throughput)
+	if len(sorted) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Latency:\n")
+	fmt.Fprintf(w, "  min:  %s\n", sorted[0].Round(time.Millisecond))
+	fmt.Fprintf(w, "  mean: %s\n", mean.Round(time.Millisecond))
+	fmt.Fprintf(w, "  p50:  %s\n", percentile(sorted, 50).Round(time.Millisecond))
+	fmt.Fprintf(w, "  p90:  %s\n", percentile(sorted, 90).Round(time.Millisecond))
+	fmt.Fprintf(w, "  p99:  %s\n", percentile(sorted, 99).Round(time.Millisecond))
+	fmt.Fprintf(w, "  max:  %s\n", sorted[len(sorted)-1].Round(time.Millisecond))
+}
+
+func submitWorkloadTx(ctx context.Context, client runtimeClient.RuntimeClient, runtimeID common.Namespace, tx *workloadTx) error {
+	method := "insert"
+	if tx.encrypted {
+		method = "enc_insert"
+	}
+
+	resp, err := client.SubmitTxMeta(ctx, &runtimeClient.SubmitTxRequest{
+		RuntimeID: runtimeID,
+		Data: cbor.Marshal(&kvRuntime.TxnCall{
+			Nonce:  tx.nonce,
+			Method: method,
+			Args:   tx.call,
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit tx: %w", err)
+	}
+	if resp.CheckTxError != nil {
+		return fmt.Errorf("check tx failed: %s", resp.CheckTxError.Message)
+	}
+
+	var out kvRuntime.TxnOutput
+	if err = cbor.Unmarshal(resp.Output, &out); err != nil {
+		return fmt.Errorf("malformed tx output from runtime: %w", err)
+	}
+	if out.Error != nil {
+		return fmt.Errorf("runtime tx failed: %s", *out.Error)
+	}
+	return nil
+}
+
+func validateWorkloadFlags() error {
+	if rate := viper.GetFloat64(cfgWorkloadRate); rate <= 0 {
+		return fmt.Errorf("workload: rate must be positive")
+	}
+	if viper.GetInt(cfgWorkloadPayloadSize) < 0 {
+		return fmt.Errorf("workload: payload size must be non-negative")
+	}
+	if f := viper.GetFloat64(cfgWorkloadEncryptedFraction); f < 0 || f > 1 {
+		return fmt.Errorf("workload: encrypted fraction must be between 0 and 1")
+	}
+	if viper.GetDuration(cfgWorkloadDuration) <= 0 {
+		return fmt.Errorf("workload: duration must be positive")
+	}
+	if viper.GetInt(cfgWorkloadConcurrency) <= 0 {
+		return fmt.Errorf("workload: concurrency must be positive")
+	}
+	return nil
+}
+
+func doWorkload(cmd *cobra.Command, _ []string) {
+	if err := validateWorkloadFlags(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(viper.GetString(cfgWorkloadRuntimeID)); err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("workload: malformed runtime ID: %w", err))
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(fmt.Errorf("workload: failed to establish connection with node: %w", err))
+	}
+	defer conn.Close()
+	client := runtimeClient.NewRuntimeClient(conn)
+
+	var (
+		rate        = viper.GetFloat64(cfgWorkloadRate)
+		payloadSize = viper.GetInt(cfgWorkloadPayloadSize)
+		encFraction = viper.GetFloat64(cfgWorkloadEncryptedFraction)
+		duration    = viper.GetDuration(cfgWorkloadDuration)
+		concurrency = viper.GetInt(cfgWorkloadConcurrency)
+		rng         = rand.New(rand.NewSource(viper.GetInt64(cfgWorkloadSeed))) // nolint: gosec
+	)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	fmt.Printf("Running workload against runtime %s for %s at %.2f tx/s...\n", runtimeID, duration, rate)
+
+	var (
+		stats workloadStats
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer ticker.Stop()
+
+	start := time.Now()
+Loop:
+	for n := 0; ; n++ {
+		select {
+		case <-ctx.Done():
+			break Loop
+		case <-ticker.C:
+		}
+
+		payload := make([]byte, payloadSize)
+		for i := range payload {
+			payload[i] = payloadAlphabet[rng.Intn(len(payloadAlphabet))]
+		}
+		tx := &workloadTx{
+			nonce:     rng.Uint64(),
+			encrypted: rng.Float64() < encFraction,
+			call: kvRuntime.InsertCall{
+				Key:   fmt.Sprintf("workload-%d", n),
+				Value: string(payload),
+			},
+		}
+
+		// Keep the submission rate constant and drop transactions in case too many are
+		// already in flight.
+		select {
+		case slots <- struct{}{}:
+		default:
+			stats.Lock()
+			stats.dropped++
+			stats.Unlock()
+			continue
+		}
+
+		stats.Lock()
+		stats.submitted++
+		stats.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			txStart := time.Now()
+			err := submitWorkloadTx(ctx, client, runtimeID, tx)
+			stats.record(time.Since(txStart), err)
+		}()
+	}
+	wg.Wait()
+
+	stats.report(os.Stdout, time.Since(start))
+}
+
+func init() {
+	workloadFlags.String(cfgWorkloadRuntimeID, "8000000000000000000000000000000000000000000000000000000000000000", "key/value runtime ID")
+	workloadFlags.Float64(cfgWorkloadRate, 10, "transaction submission rate (tx/s)")
+	workloadFlags.Int(cfgWorkloadPayloadSize, 128, "size of inserted values (bytes)")
+	workloadFlags.Float64(cfgWorkloadEncryptedFraction, 0, "fraction of transactions using encrypted inserts (requires a key manager)")
+	workloadFlags.Duration(cfgWorkloadDuration, time.Minute, "workload duration")
+	workloadFlags.Int(cfgWorkloadConcurrency, 64, "maximum number of transactions in flight")
+	workloadFlags.Int64(cfgWorkloadSeed, 1, "seed used to generate transactions")
+	_ = viper.BindPFlags(workloadFlags)
+
+	workloadCmd.Flags().AddFlagSet(workloadFlags)
+	workloadCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+}
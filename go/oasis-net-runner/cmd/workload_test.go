@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloadStats(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(0, percentile(nil, 50), "percentile of no latencies should be zero")
+
+	var stats workloadStats
+	for i := 1; i <= 100; i++ {
+		stats.submitted++
+		stats.record(time.Duration(i)*time.Millisecond, nil)
+	}
+	stats.submitted++
+	stats.record(time.Second, fmt.Errorf("failed"))
+
+	sorted := stats.latencies
+	require.Equal(1*time.Millisecond, percentile(sorted, 0))
+	require.Equal(50*time.Millisecond, percentile(sorted, 50))
+	require.Equal(90*time.Millisecond, percentile(sorted, 90))
+	require.Equal(99*time.Millisecond, percentile(sorted, 99))
+	require.Equal(100*time.Millisecond, percentile(sorted, 100))
+
+	var buf bytes.Buffer
+	stats.report(&buf, 10*time.Second)
+	out := buf.String()
+	require.Contains(out, "Submitted:  101\n")
+	require.Contains(out, "Succeeded:  100\n")
+	require.Contains(out, "Failed:     1\n")
+	require.Contains(out, "Throughput: 10.00 tx/s\n")
+	require.Contains(out, "  p50:  50ms\n")
+	require.Contains(out, "  max:  100ms\n")
+}
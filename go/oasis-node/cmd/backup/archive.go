@@ -0,0 +1,330 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmtCommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	storageConfig "github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+const (
+	// manifestName is the name of the archive entry holding the backup manifest.
+	manifestName = "backup.json"
+
+	// manifestVersion is the current backup manifest version.
+	manifestVersion = 1
+)
+
+var (
+	// identityGlobs are the node identity keys and certificates.
+	identityGlobs = []string{
+		"*.pem",
+	}
+
+	// stateGlobs are the node-local state locations.
+	stateGlobs = []string{
+		"persistent-store.*.db",
+		cmtCommon.StateDir,
+		runtimeRegistry.RuntimesDir,
+		// Encrypted node databases are useless without the storage encryption key.
+		storageConfig.DefaultEncryptionKeyFile,
+	}
+)
+
+// manifest is the backup manifest, stored as the first entry of each backup archive.
+type manifest struct {
+	// Version is the manifest version.
+	Version uint16 `json:"version"`
+	// Created is the time the backup was created.
+	Created time.Time `json:"created"`
+	// Identity is true iff the backup contains the node identity keys.
+	Identity bool `json:"identity"`
+}
+
+// globs returns the data directory globs covered by a backup.
+func globs(identity bool) []string {
+	globs := append([]string{}, stateGlobs...)
+	if identity {
+		globs = append(globs, identityGlobs...)
+	}
+	return globs
+}
+
+// matchPaths returns the paths in the data directory matching the given globs.
+func matchPaths(dataDir string, globs []string) ([]string, error) {
+	var paths []string
+	for _, v := range globs {
+		matches, err := filepath.Glob(filepath.Join(dataDir, v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern '%s': %w", v, err)
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// createArchive writes a compressed archive of the node-local state in the given data
+// directory to w.
+func createArchive(w io.Writer, dataDir string, identity bool) error {
+	paths, err := matchPaths(dataDir, globs(identity))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no node state found in '%s'", dataDir)
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	rawManifest, err := json.Marshal(&manifest{
+		Version:  manifestVersion,
+		Created:  time.Now().UTC(),
+		Identity: identity,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     manifestName,
+		Mode:     0o600,
+		Size:     int64(len(rawManifest)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write manifest header: %w", err)
+	}
+	if _, err = tw.Write(rawManifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	for _, path := range paths {
+		if err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return addArchiveEntry(tw, dataDir, path, d)
+		}); err != nil {
+			return fmt.Errorf("failed to archive '%s': %w", path, err)
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err = gw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return nil
+}
+
+func addArchiveEntry(tw *tar.Writer, dataDir, path string, d fs.DirEntry) error {
+	// Skip sockets and other special files, they are recreated by the node.
+	if !d.IsDir() && !d.Type().IsRegular() {
+		return nil
+	}
+
+	fi, err := d.Info()
+	if err != nil {
+		return err
+	}
+	name, err := filepath.Rel(dataDir, path)
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(name)
+	if d.IsDir() {
+		hdr.Name += "/"
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if d.IsDir() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err = io.CopyN(tw, f, hdr.Size); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readManifest reads the manifest of the backup archive from r.
+func readManifest(tr *tar.Reader) (*manifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a node backup archive")
+	}
+
+	var m manifest
+	if err = json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, fmt.Errorf("malformed manifest: %w", err)
+	}
+	if m.Version != manifestVersion {
+		return nil, fmt.Errorf("unsupported backup version: %d", m.Version)
+	}
+	return &m, nil
+}
+
+// restoreArchive restores the node-local state from the archive read from r into the given
+// data directory.
+//
+// Existing state covered by the backup is replaced iff force is set, otherwise the restore
+// fails if any such state exists. The archive is fully extracted and verified in a staging
+// directory first, so existing state is only replaced once the archive is known to be valid.
+func restoreArchive(r io.Reader, dataDir string, force bool) (*manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	m, err := readManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := matchPaths(dataDir, globs(m.Identity))
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 && !force {
+		return nil, fmt.Errorf("'%s' already contains node state", dataDir)
+	}
+
+	if err = common.Mkdir(dataDir); err != nil {
+		return nil, err
+	}
+
+	// Stage the restore in the data directory so that it can be moved into place by renaming.
+	stagingDir, err := os.MkdirTemp(dataDir, ".restore-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if !isArchived(hdr.Name, m.Identity) {
+			return nil, fmt.Errorf("unexpected archive entry '%s'", hdr.Name)
+		}
+		if err = extractArchiveEntry(tr, stagingDir, hdr); err != nil {
+			return nil, fmt.Errorf("failed to restore '%s': %w", hdr.Name, err)
+		}
+	}
+	// Read the rest of the compressed stream to verify its checksum.
+	if _, err = io.Copy(io.Discard, gr); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	if err = replaceState(dataDir, stagingDir, existing); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// replaceState moves the restored state from the staging directory into the data directory,
+// replacing the given existing state.
+func replaceState(dataDir, stagingDir string, existing []string) error {
+	restored, err := os.ReadDir(stagingDir)
+	if err != nil {
+		return fmt.Errorf("failed to read staging directory: %w", err)
+	}
+
+	// Move the existing state aside first, so that it is kept if moving the restored state
+	// into place fails.
+	oldDir, err := os.MkdirTemp(dataDir, ".replaced-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	for _, path := range existing {
+		if err = os.Rename(path, filepath.Join(oldDir, filepath.Base(path))); err != nil {
+			return fmt.Errorf("failed to move existing node state to '%s': %w", oldDir, err)
+		}
+	}
+
+	for _, entry := range restored {
+		if err = os.Rename(filepath.Join(stagingDir, entry.Name()), filepath.Join(dataDir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to move restored node state into place, existing node state was moved to '%s': %w", oldDir, err)
+		}
+	}
+
+	if err = os.RemoveAll(oldDir); err != nil {
+		return fmt.Errorf("failed to remove existing node state: %w", err)
+	}
+	return nil
+}
+
+func extractArchiveEntry(tr *tar.Reader, dataDir string, hdr *tar.Header) error {
+	name := filepath.FromSlash(hdr.Name)
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("invalid path")
+	}
+	path := filepath.Join(dataDir, name)
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(path, hdr.FileInfo().Mode().Perm()|0o700)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if _, err = io.CopyN(f, tr, hdr.Size); err != nil {
+			return err
+		}
+		return f.Sync()
+	default:
+		return fmt.Errorf("unsupported entry type: %c", hdr.Typeflag)
+	}
+}
+
+// isArchived returns true iff the given archive entry name is covered by a backup.
+func isArchived(name string, identity bool) bool {
+	for _, glob := range globs(identity) {
+		first, _, _ := strings.Cut(name, "/")
+		if ok, _ := filepath.Match(glob, first); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupArchive(t *testing.T) {
+	require := require.New(t)
+
+	files := map[string]string{
+		"identity.pem":                                  "identity key",
+		"persistent-store.badger.db/000001.vlog":        "persistent store",
+		"consensus/data/blockstore.db/000001.sst":       "blockstore",
+		"consensus/config/node_key.json":                "node key",
+		"runtimes/8000/mkvs_storage.pathbadger.db/MANI": "node database",
+		"runtimes/8000/history.db/000001.vlog":          "history",
+		"storage_encryption.key":                        "storage encryption key",
+	}
+	newDataDir := func() string {
+		dir := t.TempDir()
+		require.NoError(os.Chmod(dir, 0o700))
+		return dir
+	}
+
+	srcDir := newDataDir()
+	for name, data := range files {
+		path := filepath.Join(srcDir, name)
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(os.WriteFile(path, []byte(data), 0o600))
+	}
+	// Files outside of the node state should not be backed up.
+	require.NoError(os.WriteFile(filepath.Join(srcDir, "node.log"), []byte("log"), 0o600))
+
+	for _, tc := range []struct {
+		identity bool
+	}{
+		{true},
+		{false},
+	} {
+		var buf bytes.Buffer
+		err := createArchive(&buf, srcDir, tc.identity)
+		require.NoError(err, "createArchive")
+		archive := buf.Bytes()
+
+		dstDir := newDataDir()
+		m, err := restoreArchive(bytes.NewReader(archive), dstDir, false)
+		require.NoError(err, "restoreArchive")
+		require.Equal(tc.identity, m.Identity)
+
+		for name, data := range files {
+			restored, rerr := os.ReadFile(filepath.Join(dstDir, name))
+			if !tc.identity && name == "identity.pem" {
+				require.ErrorIs(rerr, os.ErrNotExist, "identity should be excluded")
+				continue
+			}
+			require.NoError(rerr, "ReadFile")
+			require.Equal(data, string(restored))
+		}
+		_, err = os.Stat(filepath.Join(dstDir, "node.log"))
+		require.ErrorIs(err, os.ErrNotExist, "unrelated files should not be backed up")
+
+		// Restoring over existing state should require force.
+		_, err = restoreArchive(bytes.NewReader(archive), dstDir, false)
+		require.Error(err, "restoreArchive should fail with existing state")
+		_, err = restoreArchive(bytes.NewReader(archive), dstDir, true)
+		require.NoError(err, "restoreArchive with force")
+
+		// Existing state should be kept if the archive turns out to be invalid.
+		require.NoError(os.WriteFile(filepath.Join(dstDir, "consensus/config/node_key.json"), []byte("current"), 0o600))
+		_, err = restoreArchive(bytes.NewReader(archive[:len(archive)-8]), dstDir, true)
+		require.Error(err, "restoreArchive should fail with a truncated archive")
+		current, err := os.ReadFile(filepath.Join(dstDir, "consensus/config/node_key.json"))
+		require.NoError(err, "existing state should be kept")
+		require.Equal("current", string(current))
+
+		// No staging directories should be left behind.
+		entries, err := filepath.Glob(filepath.Join(dstDir, ".re*"))
+		require.NoError(err)
+		require.Empty(entries)
+	}
+
+	_, err := restoreArchive(bytes.NewReader([]byte("garbage")), newDataDir(), false)
+	require.Error(err, "restoreArchive should fail with a malformed archive")
+}
@@ -0,0 +1,157 @@
+// Package backup implements the backup sub-commands.
+package backup
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+	storageConfig "github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+// CfgExcludeIdentity excludes the node identity keys from the created backup.
+const CfgExcludeIdentity = "exclude_identity"
+
+var (
+	backupCreateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "node-local state backup utilities",
+	}
+
+	backupCreateCmd = &cobra.Command{
+		Use:   "create <archive>",
+		Args:  cobra.ExactArgs(1),
+		Short: "back up node-local state",
+		Long: "Writes the consensus state, the runtime node databases and histories, the " +
+			"persistent stores, the storage encryption key and (unless excluded) the node " +
+			"identity keys into a single compressed archive. The node must be stopped.",
+		RunE: doCreate,
+	}
+
+	backupRestoreCmd = &cobra.Command{
+		Use:   "restore <archive>",
+		Args:  cobra.ExactArgs(1),
+		Short: "restore node-local state from a backup",
+		Long: "Restores node-local state from an archive created by the create sub-command. " +
+			"The node must be stopped. Existing node state is only replaced when --force is given.",
+		RunE: doRestore,
+	}
+
+	logger = logging.GetLogger("cmd/backup")
+)
+
+// ensureNodeStopped returns an error if a node is serving the internal socket in the data
+// directory, as its state could change while being backed up or restored.
+func ensureNodeStopped() error {
+	conn, err := net.Dial("unix", cmdCommon.InternalSocketPath())
+	if err != nil {
+		return nil
+	}
+	conn.Close()
+	return fmt.Errorf("node is running, stop it first")
+}
+
+func doCreate(_ *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return fmt.Errorf("data directory must be set")
+	}
+	if err := ensureNodeStopped(); err != nil {
+		return err
+	}
+
+	dst := args[0]
+	identity := !viper.GetBool(CfgExcludeIdentity)
+
+	// Only the storage encryption key at the default location is part of the node state.
+	if config.GlobalConfig.Storage.Encryption.Enabled {
+		keyPath := workerStorage.EncryptionMasterKeyPath()
+		if keyPath != filepath.Join(dataDir, storageConfig.DefaultEncryptionKeyFile) {
+			logger.Warn("storage encryption key is not included in the backup, back it up separately",
+				"path", keyPath,
+			)
+		}
+	}
+
+	// Write into a temporary file first so that an interrupted backup does not leave a
+	// truncated archive behind.
+	f, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err = createArchive(f, dataDir, identity); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync archive: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+	if err = os.Rename(f.Name(), dst); err != nil {
+		return fmt.Errorf("failed to move archive: %w", err)
+	}
+
+	logger.Info("backup created",
+		"path", dst,
+		"identity", identity,
+	)
+	return nil
+}
+
+func doRestore(_ *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return fmt.Errorf("data directory must be set")
+	}
+	if err := ensureNodeStopped(); err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	m, err := restoreArchive(f, dataDir, cmdFlags.Force())
+	if err != nil {
+		return err
+	}
+
+	logger.Info("backup restored",
+		"path", args[0],
+		"created", m.Created,
+		"identity", m.Identity,
+	)
+	return nil
+}
+
+// Register registers the backup sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	backupCreateCmd.Flags().AddFlagSet(backupCreateFlags)
+	backupRestoreCmd.Flags().AddFlagSet(cmdFlags.ForceFlags)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	parentCmd.AddCommand(backupCmd)
+}
+
+func init() {
+	backupCreateFlags.Bool(CfgExcludeIdentity, false, "exclude the node identity keys from the backup")
+	_ = viper.BindPFlags(backupCreateFlags)
+}
@@ -1,6 +1,8 @@
 // Package config implements global configuration options.
 package config
 
+import "fmt"
+
 // Config is the common configuration structure.
 type Config struct {
 	// Node's data directory.
@@ -9,6 +11,8 @@ type Config struct {
 	InternalSocketPath string `yaml:"internal_socket_path,omitempty"`
 	// Logging configuration options.
 	Log LogConfig `yaml:"log,omitempty"`
+	// Crash reporting configuration options.
+	CrashReport CrashReportConfig `yaml:"crash_report,omitempty"`
 	// Debug configuration options (do not use).
 	Debug DebugConfig `yaml:"debug,omitempty"`
 }
@@ -23,6 +27,16 @@ type LogConfig struct {
 	Level map[string]string `yaml:"level,omitempty"`
 }
 
+// CrashReportConfig is the common crash reporting configuration structure.
+type CrashReportConfig struct {
+	// Recover from panics in background workers, write a crash report and shut down cleanly.
+	Enabled bool `yaml:"enabled"`
+	// Number of most recent log lines included in crash reports.
+	LogLines int `yaml:"log_lines,omitempty"`
+	// URL of an optional webhook that crash reports are submitted to.
+	Webhook string `yaml:"webhook,omitempty"`
+}
+
 // DebugConfig is the common debug configuration structure.
 type DebugConfig struct {
 	// Allow running the node as root.
@@ -33,6 +47,9 @@ type DebugConfig struct {
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if c.CrashReport.LogLines < 0 {
+		return fmt.Errorf("crash_report.log_lines must be non-negative")
+	}
 	return nil
 }
 
@@ -50,6 +67,11 @@ func DefaultConfig() Config {
 				"mkvs/db":           "info",  // Debug logs are too verbose and not very useful.
 			},
 		},
+		CrashReport: CrashReportConfig{
+			Enabled:  false,
+			LogLines: 1000,
+			Webhook:  "",
+		},
 		Debug: DebugConfig{
 			AllowRoot: false,
 			Rlimit:    0,
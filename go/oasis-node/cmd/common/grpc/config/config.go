@@ -0,0 +1,153 @@
+// Package config implements global gRPC configuration options.
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Config is the gRPC configuration structure.
+type Config struct {
+	// Public is the public gRPC endpoint configuration.
+	Public PublicConfig `yaml:"public,omitempty"`
+}
+
+// PublicConfig is the public gRPC endpoint configuration structure.
+//
+// The public endpoint exposes a curated set of read-only methods over TCP, while all other
+// (mutating and administrative) services remain restricted to the internal socket.
+type PublicConfig struct {
+	// Enable the public gRPC endpoint.
+	Enabled bool `yaml:"enabled"`
+	// Port on which the public gRPC endpoint listens.
+	Port uint16 `yaml:"port"`
+	// Full names of methods (or service prefixes ending with a slash) that may be invoked.
+	AllowedMethods []string `yaml:"allowed_methods"`
+	// Number of calls per second that each client address may perform (0 disables rate limiting).
+	RateLimit float64 `yaml:"rate_limit"`
+	// Maximum number of calls that each client address may perform at once.
+	RateLimitBurst int `yaml:"rate_limit_burst"`
+	// Maximum number of concurrent streams that each client address may have open (0 disables
+	// the limit).
+	MaxStreamsPerClient int `yaml:"max_streams_per_client"`
+	// Maximum number of concurrent streams on each connection (0 uses the gRPC default).
+	MaxConcurrentStreams uint32 `yaml:"max_concurrent_streams"`
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if err := c.Public.Validate(); err != nil {
+		return fmt.Errorf("public: %w", err)
+	}
+	return nil
+}
+
+// Validate validates the configuration settings.
+func (c *PublicConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Port == 0 {
+		return fmt.Errorf("port must be set")
+	}
+	if len(c.AllowedMethods) == 0 {
+		return fmt.Errorf("allowed_methods must not be empty")
+	}
+	for _, m := range c.AllowedMethods {
+		if !strings.HasPrefix(m, "/") || len(m) == 1 {
+			return fmt.Errorf("malformed allowed method: '%s'", m)
+		}
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate_limit must not be negative")
+	}
+	if c.RateLimit > 0 && c.RateLimitBurst < 1 {
+		return fmt.Errorf("rate_limit_burst must be at least 1 when rate limiting is enabled")
+	}
+	if c.MaxStreamsPerClient < 0 {
+		return fmt.Errorf("max_streams_per_client must not be negative")
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Public: PublicConfig{
+			Enabled:              false,
+			Port:                 9002,
+			AllowedMethods:       DefaultPublicMethods(),
+			RateLimit:            50,
+			RateLimitBurst:       100,
+			MaxStreamsPerClient:  16,
+			MaxConcurrentStreams: 100,
+		},
+	}
+}
+
+// DefaultPublicMethods returns the read-only methods exposed on the public endpoint by default.
+//
+// Methods which submit transactions or evidence and methods which dump the whole state
+// (StateToGenesis) are intentionally omitted.
+func DefaultPublicMethods() []string {
+	var methods []string
+	for _, svc := range []struct {
+		name    string
+		methods []string
+	}{
+		{"Consensus", []string{
+			"EstimateGas", "MinGasPrice", "GetSignerNonce", "GetBlock", "GetLightBlock",
+			"GetTransactions", "GetTransactionsWithResults", "GetTransactionsWithProofs",
+			"GetUnconfirmedTransactions", "GetGenesisDocument", "StateSyncGet",
+			"StateSyncGetPrefixes", "StateSyncIterate", "GetChainContext", "GetStatus",
+			"GetNextBlockState", "GetParameters", "WatchBlocks",
+		}},
+		{"Beacon", []string{
+			"GetBaseEpoch", "GetEpoch", "GetFutureEpoch", "GetEpochBlock", "WaitEpoch",
+			"GetBeacon", "ConsensusParameters", "WatchEpochs",
+		}},
+		{"Scheduler", []string{
+			"GetValidators", "GetValidatorSetStatus", "GetCommittees", "ConsensusParameters", "WatchCommittees",
+		}},
+		{"Registry", []string{
+			"GetEntity", "GetEntities", "GetNode", "GetNodeByConsensusAddress", "GetNodeStatus",
+			"GetNodes", "QueryNodes", "GetRuntime", "GetRuntimes", "GetRuntimeSuspension",
+			"GetEvents", "ConsensusParameters", "WatchEntities", "WatchNodes", "WatchNodeList",
+			"WatchNodeExpirations", "WatchRuntimes", "WatchEvents",
+		}},
+		{"Staking", []string{
+			"TokenSymbol", "TokenValueExponent", "TotalSupply", "CommonPool", "LastBlockFees",
+			"GovernanceDeposits", "Threshold", "Addresses", "CommissionScheduleAddresses",
+			"Account", "DelegationsFor", "DelegationInfosFor", "DelegationsTo",
+			"DebondingDelegationsFor", "DebondingDelegationInfosFor", "DebondingDelegationsTo",
+			"Allowance", "DelegationStatusFor", "Vesting", "ProjectedRewards",
+			"ConsensusParameters", "GetEvents", "GetAccountEvents", "WatchEvents",
+		}},
+		{"KeyManager", []string{
+			"GetStatus", "GetStatuses", "GetMasterSecret", "GetEphemeralSecret",
+			"WatchStatuses", "WatchMasterSecrets", "WatchEphemeralSecrets",
+		}},
+		{"KeyManager.Churp", []string{
+			"ConsensusParameters", "Status", "Statuses", "AllStatuses", "WatchStatuses",
+		}},
+		{"RootHash", []string{
+			"GetGenesisBlock", "GetLatestBlock", "GetRuntimeState", "GetLastRoundResults",
+			"GetRoundRoots", "GetPastRoundRoots", "GetIncomingMessageQueueMeta",
+			"GetIncomingMessageQueue", "ConsensusParameters", "GetEvents", "WatchBlocks",
+			"WatchEvents", "WatchExecutorCommitments",
+		}},
+		{"Governance", []string{
+			"ActiveProposals", "Proposals", "Proposal", "Votes", "PendingUpgrades",
+			"ConsensusParameters", "GetEvents", "WatchEvents",
+		}},
+		{"Vault", []string{
+			"Vaults", "Vault", "AddressState", "PendingActions", "ConsensusParameters",
+			"GetEvents", "WatchEvents",
+		}},
+	} {
+		for _, m := range svc.methods {
+			methods = append(methods, fmt.Sprintf("/oasis-core.%s/%s", svc.name, m))
+		}
+	}
+	return methods
+}
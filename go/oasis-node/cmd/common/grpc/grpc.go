@@ -16,6 +16,7 @@ import (
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 )
 
@@ -73,6 +74,30 @@ func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
 	return cmnGrpc.NewServer(config)
 }
 
+// NewServerPublic constructs a new public gRPC server service listening on
+// the configured TCP port, which only allows calls to the configured methods.
+//
+// This internally takes a snapshot of the current global tracer, so
+// make sure you initialize the global tracer before calling this.
+func NewServerPublic(ident *identity.Identity) (*cmnGrpc.Server, error) {
+	cfg := config.GlobalConfig.GRPC.Public
+	if !cfg.Enabled {
+		return nil, fmt.Errorf("public gRPC endpoint is not enabled")
+	}
+
+	srvCfg := &cmnGrpc.ServerConfig{
+		Name:                 "public",
+		Port:                 cfg.Port,
+		Identity:             ident,
+		AllowedMethods:       cfg.AllowedMethods,
+		RateLimit:            cfg.RateLimit,
+		RateLimitBurst:       cfg.RateLimitBurst,
+		MaxStreamsPerClient:  cfg.MaxStreamsPerClient,
+		MaxConcurrentStreams: cfg.MaxConcurrentStreams,
+	}
+	return cmnGrpc.NewServer(srvCfg)
+}
+
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
 	addr, _ := cmd.Flags().GetString(CfgAddress)
 
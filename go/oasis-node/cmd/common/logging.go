@@ -8,6 +8,14 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 )
 
+// recentLogs retains the most recent log lines for inclusion in crash reports.
+var recentLogs *logging.RingBuffer
+
+// RecentLogs returns the buffer of the most recent log lines if crash reporting is enabled.
+func RecentLogs() *logging.RingBuffer {
+	return recentLogs
+}
+
 func initLogging() error {
 	logFile := config.GlobalConfig.Common.Log.File
 
@@ -45,5 +53,10 @@ func initLogging() error {
 		}
 	}
 
+	if crCfg := config.GlobalConfig.Common.CrashReport; crCfg.Enabled && crCfg.LogLines > 0 {
+		recentLogs = logging.NewRingBuffer(crCfg.LogLines)
+		w = io.MultiWriter(w, recentLogs)
+	}
+
 	return logging.Initialize(w, logFmt, logLevel, moduleLevels)
 }
@@ -79,7 +79,9 @@ func doConnect(cmd *cobra.Command) (*grpc.ClientConn, consensus.ClientBackend) {
 	return conn, client
 }
 
-func loadTx() *transaction.SignedTransaction {
+// loadTx loads a pre-signed transaction which is either signed by a single signer or
+// multi-signed on behalf of an entity. Exactly one of the returned values is non-nil.
+func loadTx() (*transaction.SignedTransaction, *transaction.MultiSignedTransaction) {
 	rawTx, err := os.ReadFile(viper.GetString(cmdConsensus.CfgTxFile))
 	if err != nil {
 		logger.Error("failed to read raw serialized transaction",
@@ -88,6 +90,24 @@ func loadTx() *transaction.SignedTransaction {
 		os.Exit(1)
 	}
 
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(rawTx, &fields); err != nil {
+		logger.Error("failed to parse serialized transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if _, isMultiSigned := fields["signatures"]; isMultiSigned {
+		var tx transaction.MultiSignedTransaction
+		if err = json.Unmarshal(rawTx, &tx); err != nil {
+			logger.Error("failed to parse serialized multi-signed transaction",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		return nil, &tx
+	}
+
 	var tx transaction.SignedTransaction
 	if err = json.Unmarshal(rawTx, &tx); err != nil {
 		logger.Error("failed to parse serialized transaction",
@@ -96,7 +116,7 @@ func loadTx() *transaction.SignedTransaction {
 		os.Exit(1)
 	}
 
-	return &tx
+	return &tx, nil
 }
 
 func loadUnsignedTx() *transaction.Transaction {
@@ -127,9 +147,14 @@ func doSubmitTx(cmd *cobra.Command, _ []string) {
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
-	tx := loadTx()
-
-	if err := client.SubmitTx(context.Background(), tx); err != nil {
+	var err error
+	switch tx, multiSigTx := loadTx(); {
+	case tx != nil:
+		err = client.SubmitTx(context.Background(), tx)
+	default:
+		err = client.SubmitMultiSignedTx(context.Background(), multiSigTx)
+	}
+	if err != nil {
 		logger.Error("failed to submit transaction",
 			"err", err,
 		)
@@ -149,8 +174,12 @@ func doShowTx(*cobra.Command, []string) {
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.TokenValueExponent)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyGenesisHash, genesis.Hash())
 
-	sigTx := loadTx()
-	sigTx.PrettyPrint(ctx, "", os.Stdout)
+	switch sigTx, multiSigTx := loadTx(); {
+	case sigTx != nil:
+		sigTx.PrettyPrint(ctx, "", os.Stdout)
+	default:
+		multiSigTx.PrettyPrint(ctx, "", os.Stdout)
+	}
 }
 
 func doEstimateGas(cmd *cobra.Command, _ []string) {
@@ -214,6 +243,8 @@ func Register(parentCmd *cobra.Command) {
 		showTxCmd,
 		estimateGasCmd,
 		nextBlockStateCmd,
+		signMultisigTxCmd,
+		combineMultisigTxCmd,
 	} {
 		consensusCmd.AddCommand(v)
 	}
@@ -230,5 +261,9 @@ func Register(parentCmd *cobra.Command) {
 
 	nextBlockStateCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	signMultisigTxCmd.Flags().AddFlagSet(multisigSignFlags)
+
+	combineMultisigTxCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
+
 	parentCmd.AddCommand(consensusCmd)
 }
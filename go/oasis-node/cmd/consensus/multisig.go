@@ -0,0 +1,195 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	signerFile "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+)
+
+const (
+	// CfgMultisigEntity is the entity on whose behalf a multisig transaction is authorized.
+	CfgMultisigEntity = "consensus.multisig.entity"
+
+	// CfgMultisigUnsignedTx is the path to the unsigned transaction to be partially signed.
+	CfgMultisigUnsignedTx = "consensus.multisig.unsigned_tx"
+)
+
+var (
+	multisigSignFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	signMultisigTxCmd = &cobra.Command{
+		Use:   "sign_multisig_tx",
+		Short: "Partially sign an unsigned transaction on behalf of a multisig entity",
+		Run:   doSignMultisigTx,
+	}
+
+	combineMultisigTxCmd = &cobra.Command{
+		Use:   "combine_multisig_tx <partially-signed-tx>...",
+		Short: "Combine partially signed multisig transactions",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   doCombineMultisigTx,
+	}
+)
+
+func loadMultiSignedTx(fn string) *transaction.MultiSignedTransaction {
+	rawTx, err := os.ReadFile(fn)
+	if err != nil {
+		logger.Error("failed to read multi-signed transaction",
+			"err", err,
+			"path", fn,
+		)
+		os.Exit(1)
+	}
+
+	var tx transaction.MultiSignedTransaction
+	if err = json.Unmarshal(rawTx, &tx); err != nil {
+		logger.Error("failed to parse multi-signed transaction",
+			"err", err,
+			"path", fn,
+		)
+		os.Exit(1)
+	}
+
+	return &tx
+}
+
+func saveMultiSignedTx(tx *transaction.MultiSignedTransaction) {
+	prettyTx, err := cmdCommon.PrettyJSONMarshal(tx)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of multi-signed transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(viper.GetString(cmdConsensus.CfgTxFile), prettyTx, 0o600); err != nil {
+		logger.Error("failed to save multi-signed transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// loadMultisigSigner loads the signer of a multisig co-signer. Co-signers need not be
+// entities themselves, so only the signing key is loaded.
+func loadMultisigSigner() (signature.Signer, error) {
+	signerDir, err := cmdSigner.CLIDirOrPwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve signer dir: %w", err)
+	}
+
+	factory, err := cmdSigner.NewFactory(cmdSigner.Backend(), signerDir, signature.SignerEntity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer factory for %s: %w", cmdSigner.Backend(), err)
+	}
+
+	return factory.Load(signature.SignerEntity)
+}
+
+func doSignMultisigTx(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	var mtx transaction.MultisigTransaction
+	if err := mtx.Entity.UnmarshalText([]byte(viper.GetString(CfgMultisigEntity))); err != nil {
+		logger.Error("failed to parse multisig entity",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	rawUnsignedTx, err := os.ReadFile(viper.GetString(CfgMultisigUnsignedTx))
+	if err != nil {
+		logger.Error("failed to read raw serialized unsigned transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = cbor.Unmarshal(rawUnsignedTx, &mtx.Transaction); err != nil {
+		logger.Error("failed to parse serialized unsigned transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	signer, err := loadMultisigSigner()
+	if err != nil {
+		logger.Error("failed to load signer",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer signer.Reset()
+
+	ctx := context.Background()
+	logger.Info("signing multisig transaction",
+		"entity", mtx.Entity,
+		"signer", signer.Public(),
+	)
+	mtx.Transaction.PrettyPrint(ctx, "  ", os.Stdout)
+
+	if cmdSigner.Backend() == signerFile.SignerName && !cmdFlags.AssumeYes() {
+		if !cmdCommon.GetUserConfirmation("\nAre you sure you want to continue? (y)es/(n)o: ") {
+			os.Exit(1)
+		}
+	}
+
+	sigTx, err := transaction.SignMultisig(signer, &mtx)
+	if err != nil {
+		logger.Error("failed to sign multisig transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	saveMultiSignedTx(sigTx)
+}
+
+func doCombineMultisigTx(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	cmdConsensus.AssertTxFileOK()
+
+	tx := loadMultiSignedTx(args[0])
+	for _, fn := range args[1:] {
+		if err := tx.Combine(loadMultiSignedTx(fn)); err != nil {
+			logger.Error("failed to combine multi-signed transactions",
+				"err", err,
+				"path", fn,
+			)
+			os.Exit(1)
+		}
+	}
+
+	saveMultiSignedTx(tx)
+}
+
+func init() {
+	multisigSignFlags.String(CfgMultisigEntity, "", "entity on whose behalf the transaction is authorized")
+	multisigSignFlags.String(CfgMultisigUnsignedTx, "", "path to the unsigned transaction")
+	_ = viper.BindPFlags(multisigSignFlags)
+	multisigSignFlags.AddFlagSet(cmdConsensus.TxFileFlags)
+	multisigSignFlags.AddFlagSet(cmdSigner.Flags)
+	multisigSignFlags.AddFlagSet(cmdSigner.CLIFlags)
+	multisigSignFlags.AddFlagSet(cmdFlags.GenesisFileFlags)
+	multisigSignFlags.AddFlagSet(cmdFlags.AssumeYesFlag)
+}
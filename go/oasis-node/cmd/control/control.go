@@ -70,6 +70,24 @@ var (
 		Run:   doStatus,
 	}
 
+	controlPeersCmd = &cobra.Command{
+		Use:   "peers",
+		Short: "show consensus, runtime and sentry peers",
+		Run:   doPeers,
+	}
+
+	controlKeymanagerCacheStatsCmd = &cobra.Command{
+		Use:   "keymanager-cache-stats",
+		Short: "show key manager worker cache statistics",
+		Run:   doKeymanagerCacheStats,
+	}
+
+	controlKeymanagerFlushCachesCmd = &cobra.Command{
+		Use:   "keymanager-flush-caches",
+		Short: "flush key manager worker caches",
+		Run:   doKeymanagerFlushCaches,
+	}
+
 	controlRuntimeStatsCmd = &cobra.Command{
 		Use:        "runtime-stats <runtime-id> [<start-height> [<end-height>]]",
 		Short:      "show runtime statistics",
@@ -297,6 +315,62 @@ func doStatus(cmd *cobra.Command, _ []string) {
 	fmt.Println(string(prettyStatus))
 }
 
+func doPeers(cmd *cobra.Command, _ []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	peers, err := client.GetPeers(context.Background())
+	if err != nil {
+		logger.Error("failed to query peers",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyPeers, err := cmdCommon.PrettyJSONMarshal(peers)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of peers",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyPeers))
+}
+
+func doKeymanagerCacheStats(cmd *cobra.Command, _ []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	stats, err := client.GetKeymanagerCacheStats(context.Background())
+	if err != nil {
+		logger.Error("failed to query key manager cache statistics",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyStats, err := cmdCommon.PrettyJSONMarshal(stats)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of key manager cache statistics",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyStats))
+}
+
+func doKeymanagerFlushCaches(cmd *cobra.Command, _ []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.FlushKeymanagerCaches(context.Background()); err != nil {
+		logger.Error("failed to flush key manager caches",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
@@ -310,6 +384,9 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+	controlCmd.AddCommand(controlPeersCmd)
+	controlCmd.AddCommand(controlKeymanagerCacheStatsCmd)
+	controlCmd.AddCommand(controlKeymanagerFlushCachesCmd)
 	controlCmd.AddCommand(controlRuntimeStatsCmd)
 	parentCmd.AddCommand(controlCmd)
 }
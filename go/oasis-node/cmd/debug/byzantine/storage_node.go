@@ -99,6 +99,11 @@ func (ci *corruptIterator) Next() (bool, error) {
 	return ci.it.Next()
 }
 
+// Implements storage.WriteLogIterator.
+func (ci *corruptIterator) Seek(key []byte) error {
+	return ci.it.Seek(key)
+}
+
 // Implements storage.WriteLogIterator.
 func (ci *corruptIterator) Value() (storage.LogEntry, error) {
 	v, err := ci.it.Value()
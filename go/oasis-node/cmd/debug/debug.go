@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/roothash"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
@@ -26,6 +27,7 @@ func Register(parentCmd *cobra.Command) {
 	control.Register(debugCmd)
 	dumpdb.Register(debugCmd)
 	beacon.Register(debugCmd)
+	roothash.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
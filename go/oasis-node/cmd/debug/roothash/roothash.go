@@ -0,0 +1,186 @@
+// Package roothash implements the roothash introspection debug sub-commands.
+package roothash
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+const cfgRuntimeID = "roothash.runtime_id"
+
+var (
+	roothashCmd = &cobra.Command{
+		Use:   "roothash",
+		Short: "debug the roothash service",
+	}
+
+	roothashPoolCmd = &cobra.Command{
+		Use:   "pool",
+		Short: "query the runtime's commitment pool status",
+		Run:   doPoolStatus,
+	}
+
+	roothashPoolFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/roothash")
+)
+
+// schedulerCommitmentStatus is the status of the commitments collected for a single scheduler.
+type schedulerCommitmentStatus struct {
+	// Rank is the rank of the scheduler.
+	Rank uint64 `json:"rank"`
+	// Proposed is true when the scheduler has submitted its own commitment.
+	Proposed bool `json:"proposed"`
+	// Votes is the number of received commitments.
+	Votes int `json:"votes"`
+	// Failures is the number of received commitments indicating failure.
+	Failures int `json:"failures"`
+}
+
+// poolStatus is the status of the runtime's commitment pool.
+type poolStatus struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Round is the round the pool is collecting commitments for.
+	Round uint64 `json:"round"`
+	// Suspended is true when the runtime is suspended.
+	Suspended bool `json:"suspended"`
+	// CommitteeSize is the number of members of the executor committee.
+	CommitteeSize int `json:"committee_size"`
+	// LastBlockHeight is the consensus height at which the last runtime block was generated.
+	LastBlockHeight int64 `json:"last_block_height"`
+	// NextTimeout is the consensus height at which the round will be forcibly finalized.
+	NextTimeout int64 `json:"next_timeout,omitempty"`
+	// Discrepancy is true when a discrepancy has been detected in the current round.
+	Discrepancy bool `json:"discrepancy"`
+	// HighestRank is the rank of the highest-ranked scheduler that submitted a commitment.
+	HighestRank *uint64 `json:"highest_rank,omitempty"`
+	// Commitments is the total number of received commitments.
+	Commitments int `json:"commitments"`
+	// Schedulers are the commitments collected for each scheduler.
+	Schedulers []*schedulerCommitmentStatus `json:"schedulers,omitempty"`
+}
+
+func newPoolStatus(rs *roothash.RuntimeState) *poolStatus {
+	ps := &poolStatus{
+		RuntimeID:       rs.Runtime.ID,
+		Round:           rs.LastBlock.Header.Round + 1,
+		Suspended:       rs.Suspended,
+		LastBlockHeight: rs.LastBlockHeight,
+	}
+	if rs.NextTimeout != roothash.TimeoutNever {
+		ps.NextTimeout = rs.NextTimeout
+	}
+	if rs.Committee != nil {
+		ps.CommitteeSize = len(rs.Committee.Members)
+	}
+
+	pool := rs.CommitmentPool
+	if pool == nil {
+		return ps
+	}
+	ps.Discrepancy = pool.Discrepancy
+	if pool.HighestRank != math.MaxUint64 {
+		rank := pool.HighestRank
+		ps.HighestRank = &rank
+	}
+
+	for rank, sc := range pool.SchedulerCommitments {
+		scs := &schedulerCommitmentStatus{
+			Rank:     rank,
+			Proposed: sc.Commitment != nil,
+			Votes:    len(sc.Votes),
+		}
+		for _, vote := range sc.Votes {
+			if vote == nil {
+				scs.Failures++
+			}
+		}
+		ps.Commitments += scs.Votes
+		ps.Schedulers = append(ps.Schedulers, scs)
+	}
+	sort.Slice(ps.Schedulers, func(i, j int) bool { return ps.Schedulers[i].Rank < ps.Schedulers[j].Rank })
+
+	return ps
+}
+
+func doConnect(cmd *cobra.Command) (*grpc.ClientConn, roothash.Backend) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	client := roothash.NewRootHashClient(conn)
+
+	return conn, client
+}
+
+func doPoolStatus(cmd *cobra.Command, _ []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(viper.GetString(cfgRuntimeID)); err != nil {
+		logger.Error("failed to parse runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	rs, err := client.GetRuntimeState(context.Background(), &roothash.RuntimeRequest{
+		RuntimeID: runtimeID,
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		logger.Error("failed to query runtime state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(newPoolStatus(rs))
+	if err != nil {
+		logger.Error("failed to get pretty JSON of commitment pool status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+// Register registers the roothash sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	roothashCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
+
+	roothashPoolCmd.Flags().AddFlagSet(roothashPoolFlags)
+
+	roothashCmd.AddCommand(roothashPoolCmd)
+	parentCmd.AddCommand(roothashCmd)
+}
+
+func init() {
+	roothashPoolFlags.String(cfgRuntimeID, "", "runtime ID (hex)")
+	_ = viper.BindPFlags(roothashPoolFlags)
+}
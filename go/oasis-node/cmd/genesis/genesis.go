@@ -54,6 +54,7 @@ const (
 	cfgBlockHeight   = "height"
 	CfgChainID       = "chain.id"
 	CfgInitialHeight = "initial_height"
+	CfgGenesisTime   = "genesis_time"
 
 	// Registry config flags.
 	CfgRegistryMaxNodeExpiration                      = "registry.max_node_expiration"
@@ -65,6 +66,16 @@ const (
 	CfgRegistryTEEFeaturesSGXSignedAttestations       = "registry.tee_features.sgx.signed_attestations"
 	CfgRegistryTEEFeaturesSGXDefaultMaxAttestationAge = "registry.tee_features.sgx.default_max_attestation_age"
 	CfgRegistryTEEFeaturesFreshnessProofs             = "registry.tee_features.freshness_proofs"
+	CfgRegistryEnableNodeFeatures                     = "registry.enable_node_features"
+	CfgRegistryEnableEntityMultisig                   = "registry.enable_entity_multisig"
+	CfgRegistryEnableEntityEscrowRelease              = "registry.enable_entity_escrow_release"
+	CfgRegistryEnableRuntimeSuspensionHistory         = "registry.enable_runtime_suspension_history"
+	CfgRegistryEnableRuntimePause                     = "registry.enable_runtime_pause"
+	CfgRegistryEnableRuntimeStorageLimits             = "registry.enable_runtime_storage_limits"
+	CfgRegistryEnableStandbyWorkers                   = "registry.enable_standby_workers"
+	CfgRegistryEnableCommitteeRotation                = "registry.enable_committee_rotation"
+	CfgRegistryEnableKeyManagerChange                 = "registry.enable_key_manager_change"
+	CfgRegistryEnableRuntimeParameterUpdates          = "registry.enable_runtime_parameter_updates"
 
 	// Scheduler config flags.
 	cfgSchedulerMinValidators          = "scheduler.min_validators"
@@ -180,11 +191,22 @@ func doInitGenesis(*cobra.Command, []string) {
 		return
 	}
 
+	genesisTime := time.Now()
+	if raw := viper.GetString(CfgGenesisTime); raw != "" {
+		var err error
+		if genesisTime, err = time.Parse(time.RFC3339Nano, raw); err != nil {
+			logger.Error("failed to parse genesis time",
+				"err", err,
+			)
+			return
+		}
+	}
+
 	// Build the genesis state, if any.
 	doc := &genesis.Document{
 		Height:  viper.GetInt64(CfgInitialHeight),
 		ChainID: chainID,
-		Time:    time.Now(),
+		Time:    genesisTime,
 	}
 	entities := viper.GetStringSlice(viperEntity)
 	runtimes := viper.GetStringSlice(cfgRuntime)
@@ -348,12 +370,22 @@ func doInitGenesis(*cobra.Command, []string) {
 func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []string, l *logging.Logger) error {
 	regSt := registry.Genesis{
 		Parameters: registry.ConsensusParameters{
-			DebugAllowUnroutableAddresses: viper.GetBool(CfgRegistryDebugAllowUnroutableAddresses),
-			DebugAllowTestRuntimes:        viper.GetBool(CfgRegistryDebugAllowTestRuntimes),
-			GasCosts:                      registry.DefaultGasCosts, // TODO: Make these configurable.
-			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
-			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
-			EnableRuntimeGovernanceModels: make(map[registry.RuntimeGovernanceModel]bool),
+			DebugAllowUnroutableAddresses:  viper.GetBool(CfgRegistryDebugAllowUnroutableAddresses),
+			DebugAllowTestRuntimes:         viper.GetBool(CfgRegistryDebugAllowTestRuntimes),
+			GasCosts:                       registry.DefaultGasCosts, // TODO: Make these configurable.
+			MaxNodeExpiration:              viper.GetUint64(CfgRegistryMaxNodeExpiration),
+			DisableRuntimeRegistration:     viper.GetBool(CfgRegistryDisableRuntimeRegistration),
+			EnableRuntimeGovernanceModels:  make(map[registry.RuntimeGovernanceModel]bool),
+			EnableNodeFeatures:             viper.GetBool(CfgRegistryEnableNodeFeatures),
+			EnableEntityMultisig:           viper.GetBool(CfgRegistryEnableEntityMultisig),
+			EnableEntityEscrowRelease:      viper.GetBool(CfgRegistryEnableEntityEscrowRelease),
+			EnableRuntimeSuspensionHistory: viper.GetBool(CfgRegistryEnableRuntimeSuspensionHistory),
+			EnableRuntimePause:             viper.GetBool(CfgRegistryEnableRuntimePause),
+			EnableRuntimeStorageLimits:     viper.GetBool(CfgRegistryEnableRuntimeStorageLimits),
+			EnableStandbyWorkers:           viper.GetBool(CfgRegistryEnableStandbyWorkers),
+			EnableCommitteeRotation:        viper.GetBool(CfgRegistryEnableCommitteeRotation),
+			EnableKeyManagerChange:         viper.GetBool(CfgRegistryEnableKeyManagerChange),
+			EnableRuntimeParameterUpdates:  viper.GetBool(CfgRegistryEnableRuntimeParameterUpdates),
 		},
 		Entities: make([]*entity.SignedEntity, 0, len(entities)),
 		Runtimes: make([]*registry.Runtime, 0, len(runtimes)),
@@ -802,6 +834,7 @@ func init() {
 	initGenesisFlags.StringSlice(cfgKeyManager, nil, "path to key manager genesis status file")
 	initGenesisFlags.String(CfgChainID, "", "genesis chain id")
 	initGenesisFlags.Int64(CfgInitialHeight, 1, "initial block height")
+	initGenesisFlags.String(CfgGenesisTime, "", "genesis time in RFC 3339 format (default: current time)")
 
 	// Registry config flags.
 	initGenesisFlags.Uint64(CfgRegistryMaxNodeExpiration, 5, "maximum node registration lifespan in epochs")
@@ -813,6 +846,16 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXSignedAttestations, true, "enable SGX RAK-signed attestations")
 	initGenesisFlags.Uint64(CfgRegistryTEEFeaturesSGXDefaultMaxAttestationAge, 1200, "default max attestation age (SGX RAK-signed attestations must be enabled") // ~2 hours at 6 sec per block.
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesFreshnessProofs, true, "enable freshness proofs")
+	initGenesisFlags.Bool(CfgRegistryEnableNodeFeatures, false, "enable declared node features")
+	initGenesisFlags.Bool(CfgRegistryEnableEntityMultisig, false, "enable entity multisig policies")
+	initGenesisFlags.Bool(CfgRegistryEnableEntityEscrowRelease, false, "enable escrow release on entity deregistration")
+	initGenesisFlags.Bool(CfgRegistryEnableRuntimeSuspensionHistory, false, "enable runtime suspension history")
+	initGenesisFlags.Bool(CfgRegistryEnableRuntimePause, false, "enable pausing runtimes by their owners")
+	initGenesisFlags.Bool(CfgRegistryEnableRuntimeStorageLimits, false, "enable runtime storage limits")
+	initGenesisFlags.Bool(CfgRegistryEnableStandbyWorkers, false, "enable standby executor workers")
+	initGenesisFlags.Bool(CfgRegistryEnableCommitteeRotation, false, "enable staggered executor committee rotation")
+	initGenesisFlags.Bool(CfgRegistryEnableKeyManagerChange, false, "enable scheduled key manager changes for compute runtimes")
+	initGenesisFlags.Bool(CfgRegistryEnableRuntimeParameterUpdates, false, "enable partial runtime parameter updates by runtime-governed runtimes")
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowUnroutableAddresses)
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowTestRuntimes)
 
@@ -40,6 +40,7 @@ const (
 	CfgPolicySigFile                      = "keymanager.policy.signature.file"
 	CfgPolicyIgnoreSig                    = "keymanager.policy.ignore.signature"
 	CfgPolicyMasterSecretRotationInterval = "keymanager.policy.master_secret_rotation_interval"
+	CfgPolicyMasterSecretGenerators       = "keymanager.policy.master_secret_generators"
 
 	CfgStatusFile        = "keymanager.status.file"
 	CfgStatusID          = "keymanager.status.id"
@@ -212,11 +213,25 @@ func policyFromFlags() (*secrets.PolicySGX, error) {
 
 	rotationInterval := api.EpochTime(viper.GetUint64(CfgPolicyMasterSecretRotationInterval))
 
+	var generators []signature.PublicKey
+	for _, v := range viper.GetStringSlice(CfgPolicyMasterSecretGenerators) {
+		var generator signature.PublicKey
+		if err := generator.UnmarshalText([]byte(v)); err != nil {
+			logger.Error("failed to parse master secret generator node ID",
+				"err", err,
+				"given_generator_node_id", v,
+			)
+			return nil, err
+		}
+		generators = append(generators, generator)
+	}
+
 	return &secrets.PolicySGX{
 		Serial:                       serial,
 		ID:                           id,
 		Enclaves:                     enclaves,
 		MasterSecretRotationInterval: rotationInterval,
+		MasterSecretGenerators:       generators,
 	}, nil
 }
 
@@ -555,6 +570,7 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		cmd.Flags().StringSlice(CfgPolicyMayReplicate, []string{}, "enclave_id1,enclave_id2... list of new enclaves which are allowed to access the master secret. Requires "+CfgPolicyEnclaveID)
 		cmd.Flags().StringToString(CfgPolicyMayQuery, map[string]string{}, "runtime_id=enclave_id1,enclave_id2... sets enclave query permission for runtime_id. Requires "+CfgPolicyEnclaveID)
 		cmd.Flags().Uint64(CfgPolicyMasterSecretRotationInterval, 0, "master secret rotation interval")
+		cmd.Flags().StringSlice(CfgPolicyMasterSecretGenerators, []string{}, "node_id1,node_id2... list of key manager node IDs which are allowed to generate master secrets (all committee nodes if empty)")
 	}
 
 	cmd.Flags().AddFlagSet(policyFileFlag)
@@ -573,6 +589,7 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 		CfgPolicyMayReplicate,
 		CfgPolicyMayQuery,
 		CfgPolicyMasterSecretRotationInterval,
+		CfgPolicyMasterSecretGenerators,
 	} {
 		_ = viper.BindPFlag(v, cmd.Flags().Lookup(v))
 	}
@@ -666,6 +683,7 @@ func Register(parentCmd *cobra.Command) {
 	registerKMSignPolicyFlags(signPolicyCmd)
 	registerKMVerifyPolicyFlags(verifyPolicyCmd)
 	registerKMInitStatusFlags(initStatusCmd)
+	registerQueryCmds()
 
 	genUpdateCmd.Flags().AddFlagSet(policyFileFlag)
 	genUpdateCmd.Flags().AddFlagSet(policySigFileFlag)
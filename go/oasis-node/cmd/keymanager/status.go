@@ -0,0 +1,224 @@
+package keymanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	kmApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// CfgQueryID is the key manager runtime ID used by the status and policy query commands.
+const CfgQueryID = "keymanager.query.id"
+
+var (
+	queryFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	statusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "query key manager status from consensus",
+		Run:   doStatus,
+	}
+
+	policyCmd = &cobra.Command{
+		Use:   "policy",
+		Short: "key manager policy utilities",
+	}
+
+	policyShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "show and verify the key manager policy published in consensus",
+		Run:   doPolicyShow,
+	}
+)
+
+// policySignatureStatus is the verification status of a single policy signature.
+type policySignatureStatus struct {
+	// PublicKey is the public key of the signer.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// Valid is true iff the signature is valid for the policy.
+	Valid bool `json:"valid"`
+}
+
+// policyStatus is a decoded key manager policy together with its signature verification status.
+type policyStatus struct {
+	// Policy is the decoded key manager policy.
+	Policy *secrets.PolicySGX `json:"policy"`
+	// Signatures are the verification statuses of the policy signatures.
+	Signatures []*policySignatureStatus `json:"signatures"`
+}
+
+func newPolicyStatus(sigPol *secrets.SignedPolicySGX) *policyStatus {
+	ps := &policyStatus{
+		Policy: &sigPol.Policy,
+	}
+
+	rawPol := cbor.Marshal(sigPol.Policy)
+	for _, sig := range sigPol.Signatures {
+		ps.Signatures = append(ps.Signatures, &policySignatureStatus{
+			PublicKey: sig.PublicKey,
+			Valid:     sig.PublicKey.IsValid() && sig.Verify(secrets.PolicySGXSignatureContext, rawPol),
+		})
+	}
+
+	return ps
+}
+
+func doConnect(cmd *cobra.Command) (*grpc.ClientConn, *secrets.Client) {
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	client := kmApi.NewKeymanagerClient(conn).Secrets()
+	return conn, client
+}
+
+func queryIDFromFlags() (common.Namespace, error) {
+	var id common.Namespace
+	if err := id.UnmarshalHex(viper.GetString(CfgQueryID)); err != nil {
+		logger.Error("failed to parse key manager runtime ID",
+			"err", err,
+			"CfgQueryID", viper.GetString(CfgQueryID),
+		)
+		return id, err
+	}
+	return id, nil
+}
+
+func printPrettyJSON(v interface{}, what string) {
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of "+what,
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+func doStatus(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	// Without a runtime ID, show statuses of all key managers.
+	if viper.GetString(CfgQueryID) == "" {
+		statuses, err := client.GetStatuses(ctx, consensus.HeightLatest)
+		if err != nil {
+			logger.Error("failed to query key manager statuses",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		printPrettyJSON(statuses, "key manager statuses")
+		return
+	}
+
+	id, err := queryIDFromFlags()
+	if err != nil {
+		os.Exit(1)
+	}
+	status, err := client.GetStatus(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     id,
+	})
+	if err != nil {
+		logger.Error("failed to query key manager status",
+			"err", err,
+			"id", id,
+		)
+		os.Exit(1)
+	}
+	printPrettyJSON(status, "key manager status")
+}
+
+func doPolicyShow(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	id, err := queryIDFromFlags()
+	if err != nil {
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	status, err := client.GetStatus(context.Background(), &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     id,
+	})
+	if err != nil {
+		logger.Error("failed to query key manager status",
+			"err", err,
+			"id", id,
+		)
+		os.Exit(1)
+	}
+	if status.Policy == nil {
+		logger.Error("key manager has no policy",
+			"id", id,
+		)
+		os.Exit(1)
+	}
+
+	ps := newPolicyStatus(status.Policy)
+	printPrettyJSON(ps, "key manager policy")
+
+	for _, sig := range ps.Signatures {
+		if !sig.Valid {
+			logger.Error("key manager policy signature is not valid",
+				"id", id,
+				"public_key", sig.PublicKey,
+			)
+			os.Exit(1)
+		}
+	}
+}
+
+func registerQueryCmds() {
+	policyCmd.AddCommand(policyShowCmd)
+
+	for _, v := range []*cobra.Command{
+		statusCmd,
+		policyShowCmd,
+	} {
+		v.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+		v.Flags().AddFlagSet(queryFlags)
+	}
+
+	for _, v := range []*cobra.Command{
+		statusCmd,
+		policyCmd,
+	} {
+		keyManagerCmd.AddCommand(v)
+	}
+}
+
+func init() {
+	queryFlags.String(CfgQueryID, "", "key manager runtime ID (hex)")
+	_ = viper.BindPFlags(queryFlags)
+}
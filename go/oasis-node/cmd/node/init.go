@@ -1,13 +1,17 @@
 package node
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -149,3 +153,31 @@ func startProfilingServer(svcMgr *background.ServiceManager, logger *logging.Log
 
 	return profiling, nil
 }
+
+// initCrashReporting enables crash reporting for panics in background workers.
+func initCrashReporting(node *Node, logger *logging.Logger) error {
+	// Always export the recovery metrics, even if crash reporting is disabled.
+	recovery.InitMetrics()
+
+	cfg := config.GlobalConfig.Common.CrashReport
+	if !cfg.Enabled {
+		return nil
+	}
+
+	err := recovery.Initialize(&recovery.Config{
+		Dir:     filepath.Join(node.dataDir, recovery.ReportsDir),
+		Webhook: cfg.Webhook,
+		Logs:    cmdCommon.RecentLogs(),
+		Status: func(ctx context.Context) (interface{}, error) {
+			return node.GetStatus(ctx)
+		},
+		Shutdown: node.Stop,
+	})
+	if err != nil {
+		logger.Error("failed to initialize crash reporting",
+			"err", err,
+		)
+		return err
+	}
+	return nil
+}
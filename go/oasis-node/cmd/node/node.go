@@ -61,6 +61,7 @@ const exportsSubDir = "exports"
 type Node struct {
 	svcMgr       *background.ServiceManager
 	grpcInternal *grpc.Server
+	grpcPublic   *grpc.Server
 
 	stopOnce sync.Once
 
@@ -152,6 +153,16 @@ func (n *Node) waitReady() {
 	close(n.readyCh)
 }
 
+// consensusGrpcServers returns the gRPC servers on which the consensus services are exposed.
+//
+// Mutating and administrative services must only be registered on the internal server.
+func (n *Node) consensusGrpcServers() []*grpc.Server {
+	if n.grpcPublic == nil {
+		return []*grpc.Server{n.grpcInternal}
+	}
+	return []*grpc.Server{n.grpcInternal, n.grpcPublic}
+}
+
 // startRuntimeServices initializes and starts all the services that are required for runtime
 // support to work.
 func (n *Node) startRuntimeServices() error {
@@ -160,16 +171,18 @@ func (n *Node) startRuntimeServices() error {
 		return err
 	}
 
-	// Initialize and register the internal gRPC services.
-	grpcSrv := n.grpcInternal.Server()
-	beacon.RegisterService(grpcSrv, n.Consensus.Beacon())
-	scheduler.RegisterService(grpcSrv, n.Consensus.Scheduler())
-	registryAPI.RegisterService(grpcSrv, n.Consensus.Registry())
-	stakingAPI.RegisterService(grpcSrv, n.Consensus.Staking())
-	keymanagerAPI.RegisterService(grpcSrv, n.Consensus.KeyManager())
-	roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
-	governanceAPI.RegisterService(grpcSrv, n.Consensus.Governance())
-	vaultAPI.RegisterService(grpcSrv, n.Consensus.Vault())
+	// Initialize and register the internal (and public) gRPC services.
+	for _, srv := range n.consensusGrpcServers() {
+		grpcSrv := srv.Server()
+		beacon.RegisterService(grpcSrv, n.Consensus.Beacon())
+		scheduler.RegisterService(grpcSrv, n.Consensus.Scheduler())
+		registryAPI.RegisterService(grpcSrv, n.Consensus.Registry())
+		stakingAPI.RegisterService(grpcSrv, n.Consensus.Staking())
+		keymanagerAPI.RegisterService(grpcSrv, n.Consensus.KeyManager())
+		roothashAPI.RegisterService(grpcSrv, n.Consensus.RootHash())
+		governanceAPI.RegisterService(grpcSrv, n.Consensus.Governance())
+		vaultAPI.RegisterService(grpcSrv, n.Consensus.Vault())
+	}
 
 	// Register dump genesis halt hook.
 	n.Consensus.RegisterHaltHook(func(ctx context.Context, blockHeight int64, epoch beacon.EpochTime, _ error) {
@@ -306,6 +319,7 @@ func (n *Node) initRuntimeWorkers() error {
 		n.CommonWorker,
 		n.RegistrationWorker,
 		n.Consensus.KeyManager(),
+		n.commonStore,
 	)
 	if err != nil {
 		return err
@@ -482,6 +496,11 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Enable crash reporting for background workers.
+	if err = initCrashReporting(node, logger); err != nil {
+		return nil, err
+	}
+
 	// Generate or load the node's identity.
 	node.Identity, err = loadOrGenerateIdentity(node.dataDir, logger)
 	if err != nil {
@@ -514,6 +533,18 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	// Register the node as a node controller.
 	controlAPI.RegisterService(node.grpcInternal.Server(), node)
 
+	// Initialize the public gRPC server, if enabled.
+	if config.GlobalConfig.GRPC.Public.Enabled {
+		node.grpcPublic, err = cmdGrpc.NewServerPublic(node.Identity)
+		if err != nil {
+			logger.Error("failed to initialize public gRPC server",
+				"err", err,
+			)
+			return nil, err
+		}
+		node.svcMgr.Register(node.grpcPublic)
+	}
+
 	// Open the common node store.
 	node.commonStore, err = persistent.NewCommonStore(node.dataDir)
 	if err != nil {
@@ -551,7 +582,9 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 	node.svcMgr.Register(node.Consensus)
-	consensusAPI.RegisterService(node.grpcInternal.Server(), node.Consensus)
+	for _, srv := range node.consensusGrpcServers() {
+		consensusAPI.RegisterService(srv.Server(), node.Consensus)
+	}
 
 	// Initialize P2P network. Since libp2p host starts listening immediately when created, make
 	// sure that we don't start it if it is not needed.
@@ -618,6 +651,16 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Start the public gRPC server.
+	if node.grpcPublic != nil {
+		if err = node.grpcPublic.Start(); err != nil {
+			logger.Error("failed to start public gRPC server",
+				"err", err,
+			)
+			return nil, err
+		}
+	}
+
 	// Start the consensus backend service.
 	if err = node.Consensus.Start(); err != nil {
 		logger.Error("failed to start consensus backend service",
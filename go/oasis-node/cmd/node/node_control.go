@@ -113,6 +113,22 @@ func (n *Node) CancelUpgrade(_ context.Context, descriptor *upgrade.Descriptor)
 	return n.Upgrader.CancelUpgrade(descriptor)
 }
 
+// GetKeymanagerCacheStats implements control.NodeController.
+func (n *Node) GetKeymanagerCacheStats(ctx context.Context) (*keymanagerWorker.CacheStats, error) {
+	if n.KeymanagerWorker == nil || !n.KeymanagerWorker.Enabled() {
+		return nil, control.ErrNotImplemented
+	}
+	return n.KeymanagerWorker.GetCacheStats(ctx)
+}
+
+// FlushKeymanagerCaches implements control.NodeController.
+func (n *Node) FlushKeymanagerCaches(ctx context.Context) error {
+	if n.KeymanagerWorker == nil || !n.KeymanagerWorker.Enabled() {
+		return control.ErrNotImplemented
+	}
+	return n.KeymanagerWorker.FlushCaches(ctx)
+}
+
 // GetStatus implements control.NodeController.
 func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 	cs, err := n.getConsensusStatus(ctx)
@@ -172,6 +188,42 @@ func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 	}, nil
 }
 
+// GetPeers implements control.NodeController.
+func (n *Node) GetPeers(ctx context.Context) (*control.PeersStatus, error) {
+	var ps control.PeersStatus
+
+	peers, err := n.Consensus.GetPeers(ctx)
+	switch err {
+	case nil:
+		ps.Consensus = peers
+		ps.Sentries = filterSentryPeers(peers)
+	case consensus.ErrUnsupported:
+		// Consensus peers are not available (e.g., in archive mode).
+	default:
+		return nil, fmt.Errorf("failed to get consensus peers: %w", err)
+	}
+
+	ps.Runtimes = make(map[common.Namespace][]*p2p.PeerInfo)
+	for _, rt := range n.RuntimeRegistry.Runtimes() {
+		ps.Runtimes[rt.ID()] = n.P2P.RuntimePeers(rt.ID())
+	}
+
+	return &ps, nil
+}
+
+// filterSentryPeers returns the consensus peers that are configured as persistent peers or
+// sentry upstreams.
+func filterSentryPeers(peers []*consensus.PeerInfo) []*consensus.PeerInfo {
+	var sentries []*consensus.PeerInfo
+	for _, peer := range peers {
+		if !peer.Persistent && !peer.SentryUpstream {
+			continue
+		}
+		sentries = append(sentries, peer)
+	}
+	return sentries
+}
+
 func (n *Node) getIdentityStatus() control.IdentityStatus {
 	return control.IdentityStatus{
 		Node:      n.Identity.NodeSigner.Public(),
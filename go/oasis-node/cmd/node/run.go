@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -42,6 +43,11 @@ func Run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	defer node.Cleanup()
 	node.Wait()
+	node.Cleanup()
+
+	// Make sure the exit status reflects that the node shut down due to a crash.
+	if recovery.Crashed() {
+		os.Exit(1)
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
 
 // Assert that the seed node implements NodeController interface.
@@ -48,6 +49,21 @@ func (n *SeedNode) CancelUpgrade(context.Context, *upgrade.Descriptor) error {
 	return control.ErrNotImplemented
 }
 
+// GetKeymanagerCacheStats implements control.NodeController.
+func (n *SeedNode) GetKeymanagerCacheStats(context.Context) (*keymanagerWorker.CacheStats, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// FlushKeymanagerCaches implements control.NodeController.
+func (n *SeedNode) FlushKeymanagerCaches(context.Context) error {
+	return control.ErrNotImplemented
+}
+
+// GetPeers implements control.NodeController.
+func (n *SeedNode) GetPeers(context.Context) (*control.PeersStatus, error) {
+	return nil, control.ErrNotImplemented
+}
+
 // GetStatus implements control.NodeController.
 func (n *SeedNode) GetStatus(_ context.Context) (*control.Status, error) {
 	tmAddresses, err := n.cometbftSeed.GetAddresses()
@@ -34,6 +34,11 @@ const (
 	CfgNodeDescriptor = "entity.node.descriptor"
 	CfgReuseSigner    = "entity.reuse_signer"
 
+	// CfgMultisigSigner configures the signers of the entity's multisig policy.
+	CfgMultisigSigner = "entity.multisig.signer"
+	// CfgMultisigThreshold configures the threshold of the entity's multisig policy.
+	CfgMultisigThreshold = "entity.multisig.threshold"
+
 	entityGenesisFilename = "entity_genesis.json"
 )
 
@@ -240,6 +245,32 @@ func doUpdate(*cobra.Command, []string) {
 		ent.Nodes = append(ent.Nodes, k)
 	}
 
+	// Update the entity's multisig policy.
+	ent.Multisig = nil
+	if signers := viper.GetStringSlice(CfgMultisigSigner); len(signers) > 0 {
+		policy := entity.MultisigPolicy{
+			Threshold: uint8(viper.GetUint(CfgMultisigThreshold)),
+		}
+		for _, v := range signers {
+			var pk signature.PublicKey
+			if err = pk.UnmarshalText([]byte(v)); err != nil {
+				logger.Error("failed to parse multisig signer",
+					"err", err,
+					"signer", v,
+				)
+				os.Exit(1)
+			}
+			policy.Signers = append(policy.Signers, pk)
+		}
+		if err = policy.ValidateBasic(); err != nil {
+			logger.Error("invalid multisig policy",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		ent.Multisig = &policy
+	}
+
 	// Save the entity descriptor.
 	if err = ent.Save(dataDir); err != nil {
 		logger.Error("failed to persist entity descriptor",
@@ -442,6 +473,8 @@ func init() {
 
 	updateFlags.StringSlice(CfgNodeID, nil, "ID(s) of nodes associated with this entity")
 	updateFlags.StringSlice(CfgNodeDescriptor, nil, "Node genesis descriptor(s) of nodes associated with this entity")
+	updateFlags.StringSlice(CfgMultisigSigner, nil, "public key(s) of signers that may co-sign transactions on behalf of this entity")
+	updateFlags.Uint8(CfgMultisigThreshold, 1, "number of multisig signers required to authorize a transaction")
 	_ = viper.BindPFlags(updateFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugTestEntityFlags)
 	updateFlags.AddFlagSet(cmdFlags.DebugDontBlameOasisFlag)
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/backup"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/consensus"
@@ -73,6 +74,7 @@ func init() {
 
 	// Register all of the sub-commands.
 	for _, v := range []func(*cobra.Command){
+		backup.Register,
 		control.Register,
 		debug.Register,
 		genesis.Register,
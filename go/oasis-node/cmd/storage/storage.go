@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -19,13 +21,26 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/migrate"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
 )
 
+const (
+	// CfgMigrateFrom is the node database backend to migrate from.
+	CfgMigrateFrom = "from"
+	// CfgMigrateTo is the node database backend to migrate to. If set, the node database is
+	// migrated to a different backend instead of being upgraded in place.
+	CfgMigrateTo = "to"
+)
+
 var (
+	storageMigrateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
 	storageCmd = &cobra.Command{
 		Use:   "storage",
 		Short: "storage node utilities",
@@ -35,7 +50,10 @@ var (
 		Use:   "migrate <runtime...>",
 		Args:  cobra.MinimumNArgs(1),
 		Short: "perform node database migration",
-		RunE:  doMigrate,
+		Long: "Upgrades the node databases of the given runtimes in place or, when --to is given, " +
+			"copies all versions and roots into an empty node database of a different backend " +
+			"and verifies the final root hashes.",
+		RunE: doMigrate,
 	}
 
 	storageCheckCmd = &cobra.Command{
@@ -45,6 +63,27 @@ var (
 		RunE:  doCheck,
 	}
 
+	storageGenEncryptionKeyCmd = &cobra.Command{
+		Use:   "gen-encryption-key",
+		Args:  cobra.NoArgs,
+		Short: "generate the storage encryption master key",
+		Long: "Generates a new storage encryption master key. Generation is refused if the key " +
+			"already exists or if any runtime node databases exist, use the encrypt sub-command " +
+			"to encrypt existing node databases instead.",
+		RunE: doGenEncryptionKey,
+	}
+
+	storageEncryptCmd = &cobra.Command{
+		Use:   "encrypt <runtime...>",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "encrypt existing node databases",
+		Long: "Copies all versions and roots of the unencrypted node databases of the given " +
+			"runtimes into new node databases encrypted with the storage encryption key, " +
+			"verifies the final root hashes and replaces the unencrypted node databases. The " +
+			"storage encryption master key is generated if it does not exist yet.",
+		RunE: doEncrypt,
+	}
+
 	storageRenameNsCmd = &cobra.Command{
 		Use:   "rename-ns <src-ns> <dst-ns>",
 		Args:  cobra.ExactArgs(2),
@@ -150,7 +189,11 @@ func parseRuntimes(args []string) ([]common.Namespace, error) {
 	return runtimes, nil
 }
 
-func doMigrate(_ *cobra.Command, args []string) error {
+func doMigrate(cmd *cobra.Command, args []string) error {
+	if viper.GetString(CfgMigrateTo) != "" {
+		return doMigrateBackend(cmd, args)
+	}
+
 	dataDir := cmdCommon.DataDir()
 	ctx := context.Background()
 
@@ -174,6 +217,9 @@ func doMigrate(_ *cobra.Command, args []string) error {
 				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.Backend),
 				Namespace: rt,
 			}
+			if nodeCfg.EncryptionKey, err = workerStorage.NodeDBEncryptionKey(nodeCfg.DB, rt); err != nil {
+				return err
+			}
 
 			helper := &migrateHelper{
 				ctx:     ctx,
@@ -199,6 +245,92 @@ func doMigrate(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+func doMigrateBackend(_ *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	ctx := context.Background()
+
+	from, to := viper.GetString(CfgMigrateFrom), viper.GetString(CfgMigrateTo)
+	for _, name := range []string{from, to} {
+		if _, err := mkvsDB.GetBackendByName(name); err != nil {
+			return err
+		}
+	}
+	if from == to {
+		return fmt.Errorf("source and destination backends must differ")
+	}
+
+	runtimes, err := parseRuntimes(args)
+	cobra.CheckErr(err)
+
+	for _, rt := range runtimes {
+		if pretty {
+			fmt.Printf(" ** Migrating storage database for runtime %v from %s to %s...\n", rt, from, to)
+		}
+		err := func() error {
+			runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
+
+			srcDir := workerStorage.GetLocalBackendDBDir(runtimeDir, from)
+			if _, err := os.Stat(srcDir); err != nil {
+				return fmt.Errorf("source node database not available: %w", err)
+			}
+			srcKey, err := workerStorage.NodeDBEncryptionKey(srcDir, rt)
+			if err != nil {
+				return err
+			}
+			src, err := mkvsDB.New(from, &db.Config{
+				DB:            srcDir,
+				Namespace:     rt,
+				ReadOnly:      true,
+				EncryptionKey: srcKey,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to open source node database: %w", err)
+			}
+			defer src.Close()
+
+			dstKey, err := workerStorage.EncryptionKey(rt)
+			if err != nil {
+				return err
+			}
+			dstDir := workerStorage.GetLocalBackendDBDir(runtimeDir, to)
+			dst, err := mkvsDB.New(to, &db.Config{
+				DB:            dstDir,
+				Namespace:     rt,
+				NoFsync:       true,
+				EncryptionKey: dstKey,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to open destination node database: %w", err)
+			}
+			defer dst.Close()
+
+			checkpointDir, err := os.MkdirTemp(runtimeDir, "migrate-checkpoints")
+			if err != nil {
+				return fmt.Errorf("failed to create checkpoint directory: %w", err)
+			}
+			defer os.RemoveAll(checkpointDir)
+
+			if err = migrate.Migrate(ctx, src, dst, checkpointDir, &displayHelper{}); err != nil {
+				return fmt.Errorf("node database migrator returned error: %w", err)
+			}
+			logger.Info("successfully migrated node database",
+				"rt", rt,
+				"from", from,
+				"to", to,
+			)
+			return nil
+		}()
+		if err != nil {
+			logger.Error("error migrating runtime", "rt", rt, "err", err)
+			if pretty {
+				fmt.Printf("error migrating runtime %v: %v\n", rt, err)
+			}
+			return fmt.Errorf("error migrating runtime %v: %w", rt, err)
+		}
+	}
+	return nil
+}
+
 func doCheck(_ *cobra.Command, args []string) error {
 	dataDir := cmdCommon.DataDir()
 	ctx := context.Background()
@@ -217,10 +349,14 @@ func doCheck(_ *cobra.Command, args []string) error {
 				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.Backend),
 				Namespace: rt,
 			}
+			var err error
+			if nodeCfg.EncryptionKey, err = workerStorage.NodeDBEncryptionKey(nodeCfg.DB, rt); err != nil {
+				return err
+			}
 
 			display := &displayHelper{}
 
-			err := badger.CheckSanity(ctx, nodeCfg, display)
+			err = badger.CheckSanity(ctx, nodeCfg, display)
 			if err != nil {
 				return fmt.Errorf("node database checker returned error: %w", err)
 			}
@@ -238,6 +374,150 @@ func doCheck(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+func doGenEncryptionKey(_ *cobra.Command, _ []string) error {
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return fmt.Errorf("data directory must be set")
+	}
+
+	if err := workerStorage.GenerateEncryptionMasterKey(dataDir, false); err != nil {
+		return err
+	}
+	logger.Info("generated storage encryption master key",
+		"path", workerStorage.EncryptionMasterKeyPath(),
+	)
+	return nil
+}
+
+func doEncrypt(_ *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return fmt.Errorf("data directory must be set")
+	}
+	ctx := context.Background()
+
+	if !config.GlobalConfig.Storage.Encryption.Enabled {
+		return fmt.Errorf("storage encryption is not enabled")
+	}
+
+	runtimes, err := parseRuntimes(args)
+	cobra.CheckErr(err)
+
+	// Only generate the master key if no node database has been encrypted yet, as it would
+	// otherwise be lost.
+	keyPath := workerStorage.EncryptionMasterKeyPath()
+	if _, err = os.Stat(keyPath); errors.Is(err, os.ErrNotExist) {
+		if err = workerStorage.GenerateEncryptionMasterKey(dataDir, true); err != nil {
+			return err
+		}
+		logger.Info("generated storage encryption master key", "path", keyPath)
+	}
+
+	for _, rt := range runtimes {
+		if pretty {
+			fmt.Printf(" ** Encrypting storage database for runtime %v...\n", rt)
+		}
+		err := func() error {
+			runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
+
+			key, err := workerStorage.EncryptionKey(rt)
+			if err != nil {
+				return err
+			}
+			for _, factory := range mkvsDB.Backends {
+				if err = encryptNodeDB(ctx, runtimeDir, factory.Name(), rt, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			logger.Error("error encrypting runtime", "rt", rt, "err", err)
+			if pretty {
+				fmt.Printf("error encrypting runtime %v: %v\n", rt, err)
+			}
+			return fmt.Errorf("error encrypting runtime %v: %w", rt, err)
+		}
+	}
+	return nil
+}
+
+func encryptNodeDB(ctx context.Context, runtimeDir, backend string, rt common.Namespace, key []byte) error {
+	dbDir := workerStorage.GetLocalBackendDBDir(runtimeDir, backend)
+	if _, err := os.Stat(dbDir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	encrypted, err := workerStorage.IsEncryptedNodeDB(dbDir)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		logger.Info("node database is already encrypted", "rt", rt, "backend", backend)
+		return nil
+	}
+
+	// Remove any leftovers of an interrupted run.
+	encDir := dbDir + ".encrypted"
+	if err = os.RemoveAll(encDir); err != nil {
+		return fmt.Errorf("failed to remove stale encrypted node database: %w", err)
+	}
+
+	if err = func() error {
+		src, err := mkvsDB.New(backend, &db.Config{
+			DB:        dbDir,
+			Namespace: rt,
+			ReadOnly:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open node database: %w", err)
+		}
+		defer src.Close()
+
+		dst, err := mkvsDB.New(backend, &db.Config{
+			DB:            encDir,
+			Namespace:     rt,
+			NoFsync:       true,
+			EncryptionKey: key,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open encrypted node database: %w", err)
+		}
+		defer dst.Close()
+
+		checkpointDir, err := os.MkdirTemp(runtimeDir, "encrypt-checkpoints")
+		if err != nil {
+			return fmt.Errorf("failed to create checkpoint directory: %w", err)
+		}
+		defer os.RemoveAll(checkpointDir)
+
+		if err = migrate.Migrate(ctx, src, dst, checkpointDir, &displayHelper{}); err != nil {
+			return fmt.Errorf("node database migrator returned error: %w", err)
+		}
+		return nil
+	}(); err != nil {
+		_ = os.RemoveAll(encDir)
+		return err
+	}
+
+	// Replace the unencrypted node database, keeping it until the encrypted one is in place.
+	plainDir := dbDir + ".plaintext"
+	if err = os.Rename(dbDir, plainDir); err != nil {
+		return fmt.Errorf("failed to move unencrypted node database: %w", err)
+	}
+	if err = os.Rename(encDir, dbDir); err != nil {
+		return fmt.Errorf("failed to move encrypted node database into place, unencrypted node database was moved to '%s': %w", plainDir, err)
+	}
+	if err = os.RemoveAll(plainDir); err != nil {
+		return fmt.Errorf("failed to remove unencrypted node database: %w", err)
+	}
+
+	logger.Info("successfully encrypted node database",
+		"rt", rt,
+		"backend", backend,
+	)
+	return nil
+}
+
 func doRenameNs(_ *cobra.Command, args []string) error {
 	dataDir := cmdCommon.DataDir()
 
@@ -263,12 +543,20 @@ func doRenameNs(_ *cobra.Command, args []string) error {
 		DB:        workerStorage.GetLocalBackendDBDir(srcDir, config.GlobalConfig.Storage.Backend),
 		Namespace: srcID,
 	}
+	var err error
+	if nodeCfg.EncryptionKey, err = workerStorage.NodeDBEncryptionKey(nodeCfg.DB, srcID); err != nil {
+		return err
+	}
 
-	err := badger.RenameNamespace(nodeCfg, dstID)
-	if err != nil {
+	if err = badger.RenameNamespace(nodeCfg, dstID); err != nil {
 		return fmt.Errorf("failed to rename namespace: %w", err)
 	}
 
+	// Encryption keys are derived from the namespace, so the database needs a new key.
+	if err = workerStorage.RekeyNodeDB(nodeCfg.DB, srcID, dstID); err != nil {
+		return fmt.Errorf("failed to re-encrypt database: %w", err)
+	}
+
 	if err = os.Rename(srcDir, dstDir); err != nil {
 		return fmt.Errorf("failed to move directory: %w", err)
 	}
@@ -284,9 +572,18 @@ func doRenameNs(_ *cobra.Command, args []string) error {
 // Register registers the client sub-command and all of its children.
 func Register(parentCmd *cobra.Command) {
 	storageMigrateCmd.Flags().AddFlagSet(registry.Flags)
+	storageMigrateCmd.Flags().AddFlagSet(storageMigrateFlags)
 	storageCheckCmd.Flags().AddFlagSet(registry.Flags)
 	storageCmd.AddCommand(storageMigrateCmd)
 	storageCmd.AddCommand(storageCheckCmd)
+	storageCmd.AddCommand(storageGenEncryptionKeyCmd)
+	storageCmd.AddCommand(storageEncryptCmd)
 	storageCmd.AddCommand(storageRenameNsCmd)
 	parentCmd.AddCommand(storageCmd)
 }
+
+func init() {
+	storageMigrateFlags.String(CfgMigrateFrom, database.BackendNameBadgerDB, "node database backend to migrate from")
+	storageMigrateFlags.String(CfgMigrateTo, "", "node database backend to migrate to (if set, copies the node database to the given backend)")
+	_ = viper.BindPFlags(storageMigrateFlags)
+}
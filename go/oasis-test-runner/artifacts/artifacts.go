@@ -0,0 +1,227 @@
+// Package artifacts implements the artifact manifest used to locate the binaries (e.g., node
+// binaries of different versions, runtime binaries, signer plugins) required by test scenarios.
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultCacheDirName is the name of the directory (relative to the manifest) where
+	// downloaded artifacts are stored, unless configured otherwise.
+	DefaultCacheDirName = ".artifacts"
+
+	// NodeBinary is the name of the artifact containing the node binary used by default.
+	NodeBinary = "oasis-node"
+)
+
+// ErrNotFound is the error returned when an artifact is not present in the manifest.
+var ErrNotFound = errors.New("artifacts: artifact not found")
+
+// Artifact is an artifact descriptor.
+type Artifact struct {
+	// Path is the local path to the artifact. Relative paths are relative to the manifest.
+	Path string `yaml:"path,omitempty"`
+
+	// URL is the URL from which the artifact can be downloaded.
+	URL string `yaml:"url,omitempty"`
+
+	// SHA256 is the hex-encoded SHA-256 hash of the artifact. It is required for downloaded
+	// artifacts and optional for local ones.
+	SHA256 string `yaml:"sha256,omitempty"`
+
+	// Version is the (informative) version of the artifact.
+	Version string `yaml:"version,omitempty"`
+}
+
+// Validate validates the artifact descriptor.
+func (a *Artifact) Validate() error {
+	switch {
+	case a.Path == "" && a.URL == "":
+		return fmt.Errorf("either path or url must be set")
+	case a.Path != "" && a.URL != "":
+		return fmt.Errorf("path and url are mutually exclusive")
+	case a.URL != "" && a.SHA256 == "":
+		return fmt.Errorf("sha256 must be set for downloaded artifacts")
+	}
+	if a.SHA256 != "" {
+		h, err := hex.DecodeString(a.SHA256)
+		if err != nil || len(h) != sha256.Size {
+			return fmt.Errorf("malformed sha256: %s", a.SHA256)
+		}
+	}
+	return nil
+}
+
+// Manifest is an artifact manifest mapping artifact names to artifacts.
+type Manifest struct {
+	// CacheDir is the directory where downloaded artifacts are stored. Relative paths are
+	// relative to the manifest.
+	CacheDir string `yaml:"cache_dir,omitempty"`
+
+	// Artifacts are the artifacts, keyed by name.
+	Artifacts map[string]*Artifact `yaml:"artifacts"`
+
+	baseDir string
+
+	mu       sync.Mutex
+	resolved map[string]string
+}
+
+// Load loads and validates the artifact manifest at the given path.
+func Load(fn string) (*Manifest, error) {
+	raw, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, fmt.Errorf("artifacts: failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err = yaml.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("artifacts: failed to parse manifest: %w", err)
+	}
+	for name, a := range m.Artifacts {
+		if a == nil {
+			return nil, fmt.Errorf("artifacts: artifact %s: empty descriptor", name)
+		}
+		if err = a.Validate(); err != nil {
+			return nil, fmt.Errorf("artifacts: artifact %s: %w", name, err)
+		}
+	}
+
+	if m.baseDir, err = filepath.Abs(filepath.Dir(fn)); err != nil {
+		return nil, err
+	}
+	if m.CacheDir == "" {
+		m.CacheDir = DefaultCacheDirName
+	}
+	m.resolved = make(map[string]string)
+
+	return &m, nil
+}
+
+// Resolve returns the local path to the given artifact, downloading it first if needed.
+//
+// In case the artifact is not present in the manifest, ErrNotFound is returned.
+func (m *Manifest) Resolve(ctx context.Context, name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if fn, ok := m.resolved[name]; ok {
+		return fn, nil
+	}
+
+	a, ok := m.Artifacts[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	var (
+		fn  string
+		err error
+	)
+	switch a.Path {
+	case "":
+		fn, err = m.download(ctx, a)
+	default:
+		fn = m.absPath(a.Path)
+		if a.SHA256 != "" {
+			err = verifyHash(fn, a.SHA256)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("artifacts: artifact %s: %w", name, err)
+	}
+
+	m.resolved[name] = fn
+	return fn, nil
+}
+
+func (m *Manifest) absPath(fn string) string {
+	if filepath.IsAbs(fn) {
+		return fn
+	}
+	return filepath.Join(m.baseDir, fn)
+}
+
+func (m *Manifest) download(ctx context.Context, a *Artifact) (string, error) {
+	cacheDir := m.absPath(m.CacheDir)
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Downloaded artifacts are content-addressed, so a cached artifact can be reused as long as
+	// its hash still matches.
+	fn := filepath.Join(cacheDir, a.SHA256+"-"+path.Base(a.URL))
+	if verifyHash(fn, a.SHA256) == nil {
+		return fn, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download: %w", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download: unexpected status: %s", rsp.Status)
+	}
+
+	f, err := os.CreateTemp(cacheDir, "download-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, rsp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to download: %w", err)
+	}
+	if err = verifyHash(f.Name(), a.SHA256); err != nil {
+		return "", err
+	}
+
+	// Artifacts are mostly binaries, so make them executable.
+	if err = os.Chmod(f.Name(), 0o700); err != nil {
+		return "", err
+	}
+	if err = os.Rename(f.Name(), fn); err != nil {
+		return "", err
+	}
+	return fn, nil
+}
+
+func verifyHash(fn string, expected string) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("hash mismatch for %s (expected: %s got: %s)", fn, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	content := []byte("#!/bin/sh\necho artifact\n")
+	sum := sha256.Sum256(content)
+	contentHash := hex.EncodeToString(sum[:])
+
+	var numDownloads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oasis-node" {
+			http.NotFound(w, r)
+			return
+		}
+		numDownloads++
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dir, "simple-keyvalue"), content, 0o600))
+
+	manifestPath := filepath.Join(dir, "artifacts.yml")
+	manifest := fmt.Sprintf(`artifacts:
+  oasis-node:
+    url: %[1]s/oasis-node
+    sha256: %[2]s
+    version: 24.0.0
+  simple-keyvalue:
+    path: simple-keyvalue
+  simple-keyvalue-upgrade:
+    path: simple-keyvalue
+    sha256: %[3]s
+  missing-binary:
+    url: %[1]s/missing-binary
+    sha256: %[2]s
+`, srv.URL, contentHash, hex.EncodeToString(make([]byte, sha256.Size)))
+	require.NoError(os.WriteFile(manifestPath, []byte(manifest), 0o600))
+
+	m, err := Load(manifestPath)
+	require.NoError(err, "Load")
+
+	// Local artifacts should be resolved relative to the manifest.
+	fn, err := m.Resolve(ctx, "simple-keyvalue")
+	require.NoError(err, "Resolve")
+	require.Equal(filepath.Join(dir, "simple-keyvalue"), fn)
+
+	// Local artifacts with a hash should be verified.
+	_, err = m.Resolve(ctx, "simple-keyvalue-upgrade")
+	require.ErrorContains(err, "hash mismatch")
+
+	// Remote artifacts should be downloaded and verified.
+	fn, err = m.Resolve(ctx, NodeBinary)
+	require.NoError(err, "Resolve")
+	data, err := os.ReadFile(fn)
+	require.NoError(err)
+	require.Equal(content, data)
+	require.Equal(1, numDownloads)
+
+	// Downloaded artifacts should be cached.
+	m, err = Load(manifestPath)
+	require.NoError(err, "Load")
+	cachedFn, err := m.Resolve(ctx, NodeBinary)
+	require.NoError(err, "Resolve")
+	require.Equal(fn, cachedFn)
+	require.Equal(1, numDownloads)
+
+	// Failed downloads should be reported.
+	_, err = m.Resolve(ctx, "missing-binary")
+	require.ErrorContains(err, "unexpected status")
+
+	// Unknown artifacts should not be found.
+	_, err = m.Resolve(ctx, "unknown")
+	require.ErrorIs(err, ErrNotFound)
+}
+
+func TestManifestValidation(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		artifact string
+		err      string
+	}{
+		{"{}", "either path or url must be set"},
+		{"{path: a, url: http://localhost/a, sha256: " + hex.EncodeToString(make([]byte, sha256.Size)) + "}", "mutually exclusive"},
+		{"{url: http://localhost/a}", "sha256 must be set"},
+		{"{path: a, sha256: abcd}", "malformed sha256"},
+	} {
+		manifestPath := filepath.Join(t.TempDir(), "artifacts.yml")
+		require.NoError(os.WriteFile(manifestPath, []byte("artifacts:\n  a: "+tc.artifact+"\n"), 0o600))
+
+		_, err := Load(manifestPath)
+		require.ErrorContains(err, tc.err, tc.artifact)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/consensus"
 )
 
@@ -57,3 +60,47 @@ func (c *ConsensusHelpers) EstimateGas(txPath string, signerPub signature.Public
 	}
 	return gas, nil
 }
+
+// SignMultisigTx is a wrapper for "consensus sign_multisig_tx" subcommand.
+func (c *ConsensusHelpers) SignMultisigTx(
+	signerDir string,
+	entity signature.PublicKey,
+	unsignedTxPath string,
+	txPath string,
+) error {
+	c.logger.Info("partially signing multisig tx",
+		"entity", entity,
+		"signer_dir", signerDir,
+	)
+
+	args := []string{
+		"consensus", "sign_multisig_tx",
+		"--" + cmdConsensus.CfgMultisigEntity, entity.String(),
+		"--" + cmdConsensus.CfgMultisigUnsignedTx, unsignedTxPath,
+		"--" + consensus.CfgTxFile, txPath,
+		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + common.CfgDebugAllowTestKeys,
+		"--" + cmdSigner.CfgSigner, fileSigner.SignerName,
+		"--" + cmdSigner.CfgCLISignerDir, signerDir,
+		"--" + flags.CfgGenesisFile, c.cfg.GenesisFile,
+		"--" + flags.CfgAssumeYes,
+	}
+	if out, err := c.runSubCommandWithOutput("consensus-sign_multisig_tx", args); err != nil {
+		return fmt.Errorf("failed to run 'consensus sign_multisig_tx': error: %w output: %s", err, out.String())
+	}
+	return nil
+}
+
+// CombineMultisigTx is a wrapper for "consensus combine_multisig_tx" subcommand.
+func (c *ConsensusHelpers) CombineMultisigTx(txPath string, partialTxPaths ...string) error {
+	c.logger.Info("combining multisig tx", consensus.CfgTxFile, txPath)
+
+	args := append([]string{
+		"consensus", "combine_multisig_tx",
+		"--" + consensus.CfgTxFile, txPath,
+	}, partialTxPaths...)
+	if out, err := c.runSubCommandWithOutput("consensus-combine_multisig_tx", args); err != nil {
+		return fmt.Errorf("failed to run 'consensus combine_multisig_tx': error: %w output: %s", err, out.String())
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package oasis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+// ClockSkewFixture is a node clock skew configuration fixture.
+//
+// The skew is applied to the node's consensus time source via the debug consensus
+// configuration, so it only takes effect on validators.
+type ClockSkewFixture struct {
+	// Offset is the offset of the node's clock from the real time.
+	Offset time.Duration `json:"offset,omitempty"`
+
+	// DriftRate is the rate at which the node's clock advances relative to the real time (e.g.,
+	// 1.001 makes the clock gain a millisecond every second). Zero means no drift.
+	DriftRate float64 `json:"drift_rate,omitempty"`
+}
+
+// Validate validates the clock skew configuration.
+func (f *ClockSkewFixture) Validate() error {
+	if f.DriftRate < 0 {
+		return fmt.Errorf("clock drift rate must be non-negative")
+	}
+	return nil
+}
+
+// apply configures the node to run under the clock skew.
+func (f *ClockSkewFixture) apply(cfg *config.Config) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	cfg.Consensus.Debug.ClockOffset = f.Offset
+	cfg.Consensus.Debug.ClockDriftRate = f.DriftRate
+	return nil
+}
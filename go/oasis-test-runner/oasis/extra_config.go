@@ -0,0 +1,85 @@
+package oasis
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+// mergeExtraConfig merges the given extra configuration into the node configuration.
+//
+// Keys of the extra configuration may either be nested maps mirroring the structure of the node
+// configuration file or dot-separated paths (e.g., "consensus.state_sync.enabled"). Values of
+// the extra configuration take precedence over the values in the node configuration. Unknown
+// configuration options are rejected.
+func mergeExtraConfig(cfg *config.Config, extra map[string]interface{}) error {
+	if len(extra) == 0 {
+		return nil
+	}
+
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	merged := make(map[string]interface{})
+	if err = yaml.Unmarshal(raw, &merged); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	if err = mergeConfigMaps(merged, extra); err != nil {
+		return err
+	}
+
+	if raw, err = yaml.Marshal(merged); err != nil {
+		return fmt.Errorf("failed to marshal merged config: %w", err)
+	}
+	var mergedCfg config.Config
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err = dec.Decode(&mergedCfg); err != nil {
+		return fmt.Errorf("failed to apply extra config: %w", err)
+	}
+	*cfg = mergedCfg
+
+	return nil
+}
+
+// mergeConfigMaps recursively merges the source map into the destination map.
+func mergeConfigMaps(dst, src map[string]interface{}) error {
+	for key, value := range src {
+		path := strings.Split(key, ".")
+
+		// Descend into the destination map, creating intermediate maps as needed.
+		m := dst
+		for _, k := range path[:len(path)-1] {
+			next, ok := m[k].(map[string]interface{})
+			if !ok {
+				if v, exists := m[k]; exists && v != nil {
+					return fmt.Errorf("extra config key '%s': '%s' is not a map", key, k)
+				}
+				next = make(map[string]interface{})
+				m[k] = next
+			}
+			m = next
+		}
+
+		leaf := path[len(path)-1]
+		srcMap, ok := value.(map[string]interface{})
+		if !ok {
+			m[leaf] = value
+			continue
+		}
+		dstMap, ok := m[leaf].(map[string]interface{})
+		if !ok {
+			dstMap = make(map[string]interface{})
+			m[leaf] = dstMap
+		}
+		if err := mergeConfigMaps(dstMap, srcMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
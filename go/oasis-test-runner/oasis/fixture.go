@@ -153,6 +153,13 @@ type NodeFixture struct {
 	NoAutoStart bool `json:"no_auto_start,omitempty"`
 
 	ExtraArgs []Argument `json:"extra_args,omitempty"`
+
+	// ClockSkew optionally runs the node under a skewed clock.
+	ClockSkew *ClockSkewFixture `json:"clock_skew,omitempty"`
+
+	// ExtraConfig is additional node configuration which is merged into the generated node
+	// configuration file. Keys may be nested maps or dot-separated configuration paths.
+	ExtraConfig map[string]interface{} `json:"extra_config,omitempty"`
 }
 
 // TEEFixture is a TEE configuration fixture.
@@ -206,6 +213,8 @@ func (f *ValidatorFixture) Create(net *Network) (*Validator, error) {
 			EnableProfiling:             f.EnableProfiling,
 			Entity:                      entity,
 			ExtraArgs:                   f.ExtraArgs,
+			ClockSkew:                   f.ClockSkew,
+			ExtraConfig:                 f.ExtraConfig,
 		},
 		Sentries: sentries,
 	})
@@ -359,6 +368,8 @@ func (f *KeymanagerFixture) Create(net *Network) (*Keymanager, error) {
 			NoAutoStart:                 f.NoAutoStart,
 			Entity:                      entity,
 			ExtraArgs:                   f.ExtraArgs,
+			ClockSkew:                   f.ClockSkew,
+			ExtraConfig:                 f.ExtraConfig,
 		},
 		RuntimeProvisioner: f.RuntimeProvisioner,
 		Runtime:            runtime,
@@ -426,6 +437,8 @@ func (f *ComputeWorkerFixture) Create(net *Network) (*Compute, error) {
 			Consensus:                   f.Consensus,
 			Entity:                      entity,
 			ExtraArgs:                   f.ExtraArgs,
+			ClockSkew:                   f.ClockSkew,
+			ExtraConfig:                 f.ExtraConfig,
 		},
 		RuntimeProvisioner:      f.RuntimeProvisioner,
 		StorageBackend:          f.StorageBackend,
@@ -485,6 +498,8 @@ func (f *SentryFixture) Create(net *Network) (*Sentry, error) {
 			SupplementarySanityInterval: f.Consensus.SupplementarySanityInterval,
 			EnableProfiling:             f.EnableProfiling,
 			ExtraArgs:                   f.ExtraArgs,
+			ClockSkew:                   f.ClockSkew,
+			ExtraConfig:                 f.ExtraConfig,
 		},
 		ValidatorIndices:  f.Validators,
 		ComputeIndices:    f.ComputeWorkers,
@@ -525,6 +540,8 @@ func (f *ClientFixture) Create(net *Network) (*Client, error) {
 			SupplementarySanityInterval: f.Consensus.SupplementarySanityInterval,
 			EnableProfiling:             f.EnableProfiling,
 			ExtraArgs:                   f.ExtraArgs,
+			ClockSkew:                   f.ClockSkew,
+			ExtraConfig:                 f.ExtraConfig,
 		},
 		Runtimes:           f.Runtimes,
 		RuntimeProvisioner: f.RuntimeProvisioner,
@@ -572,6 +589,8 @@ func (f *ByzantineFixture) Create(net *Network) (*Byzantine, error) {
 			EnableProfiling:                          f.EnableProfiling,
 			AllowEarlyTermination:                    true,
 			Entity:                                   entity,
+			ClockSkew:                                f.ClockSkew,
+			ExtraConfig:                              f.ExtraConfig,
 		},
 		Script:           f.Script,
 		ExtraArgs:        f.ExtraArgs,
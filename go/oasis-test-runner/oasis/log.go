@@ -8,6 +8,7 @@ import (
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	workerKeymanager "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage/committee"
 )
 
@@ -125,3 +126,9 @@ func LogAssertDiscrepancyMajorityFailure() log.WatcherHandlerFactory {
 	return LogAssertEvent(commitment.LogEventDiscrepancyMajorityFailure,
 		"discrepancy resolution majority failure not detected")
 }
+
+// LogAssertKeymanagerAccessDenied returns a handler which checks whether a key manager
+// denied an enclave RPC request based on JSON log output.
+func LogAssertKeymanagerAccessDenied() log.WatcherHandlerFactory {
+	return LogAssertEvent(workerKeymanager.LogEventAccessDenied, "key manager access denial not detected")
+}
@@ -92,6 +92,9 @@ type MetricsCfg struct {
 	Address string `json:"address"`
 	// Push interval.
 	Interval time.Duration `json:"interval"`
+	// Pull enables pull mode metrics on all nodes so that they can be scraped directly
+	// (ignored when a Prometheus push address is set).
+	Pull bool `json:"pull,omitempty"`
 }
 
 // NetworkCfg is the Oasis test network configuration.
@@ -118,6 +121,9 @@ type NetworkCfg struct { // nolint: maligned
 	// InitialHeight is the initial block height.
 	InitialHeight int64 `json:"initial_height,omitempty"`
 
+	// GenesisTime is the genesis time. If not specified, the current time is used.
+	GenesisTime time.Time `json:"genesis_time,omitempty"`
+
 	// HaltEpoch is the halt epoch height flag.
 	HaltEpoch uint64 `json:"halt_epoch"`
 
@@ -695,17 +701,29 @@ func (net *Network) startOasisNode(
 		}
 		cfg.Common.InternalSocketPath = node.customGrpcSocketPath
 	}
+	if node.clockSkew != nil {
+		if err = node.clockSkew.apply(&cfg); err != nil {
+			return fmt.Errorf("oasis: failed to configure clock skew for node %s: %w", node.Name, err)
+		}
+	}
 	if node.consensusStateSync != nil {
 		cfg.Consensus.StateSync.Enabled = true
 		cfg.Consensus.StateSync.TrustHeight = node.consensusStateSync.TrustHeight
 		cfg.Consensus.StateSync.TrustHash = node.consensusStateSync.TrustHash
 	}
-	if net.Config().Metrics.Address != "" {
+	switch {
+	case net.Config().Metrics.Address != "":
 		cfg.Metrics.Mode = metrics.MetricsModePush
 		cfg.Metrics.Address = net.Config().Metrics.Address
 		cfg.Metrics.Interval = net.Config().Metrics.Interval
 		cfg.Metrics.JobName = node.Name
 		cfg.Metrics.Labels = metrics.GetDefaultPushLabels(net.env.ScenarioInfo())
+	case net.Config().Metrics.Pull && len(subCmd) == 0:
+		if node.metricsPort == 0 {
+			node.metricsPort = node.getProvisionedPort(nodePortMetrics)
+		}
+		cfg.Metrics.Mode = metrics.MetricsModePull
+		cfg.Metrics.Address = node.MetricsAddress()
 	}
 	args := append([]string{}, subCmd...)
 	args = append(args, baseArgs...)
@@ -725,6 +743,11 @@ func (net *Network) startOasisNode(
 	cmd.Stdout = w
 	cmd.Stderr = w
 
+	// Apply any extra configuration last so that it can override the generated configuration.
+	if err = mergeExtraConfig(&cfg, node.extraConfig); err != nil {
+		return fmt.Errorf("oasis: failed to merge extra config for node %s: %w", node.Name, err)
+	}
+
 	// Write config to file.
 	cfgString, err := yaml.Marshal(&cfg)
 	if err != nil {
@@ -792,6 +815,15 @@ func (net *Network) MakeGenesis() error {
 		"--" + genesis.CfgRegistryEnableRuntimeGovernanceModels, "entity,runtime",
 		"--" + genesis.CfgRegistryDebugAllowUnroutableAddresses, "true",
 		"--" + genesis.CfgRegistryDebugAllowTestRuntimes, "true",
+		"--" + genesis.CfgRegistryEnableEntityMultisig, "true",
+		"--" + genesis.CfgRegistryEnableEntityEscrowRelease, "true",
+		"--" + genesis.CfgRegistryEnableRuntimeSuspensionHistory, "true",
+		"--" + genesis.CfgRegistryEnableRuntimePause, "true",
+		"--" + genesis.CfgRegistryEnableRuntimeStorageLimits, "true",
+		"--" + genesis.CfgRegistryEnableStandbyWorkers, "true",
+		"--" + genesis.CfgRegistryEnableCommitteeRotation, "true",
+		"--" + genesis.CfgRegistryEnableKeyManagerChange, "true",
+		"--" + genesis.CfgRegistryEnableRuntimeParameterUpdates, "true",
 		"--" + genesis.CfgSchedulerMaxValidatorsPerEntity, strconv.Itoa(len(net.Validators())),
 		"--" + genesis.CfgConsensusGasCostsTxByte, strconv.FormatUint(uint64(net.cfg.Consensus.Parameters.GasCosts[consensusGenesis.GasOpTxByte]), 10),
 		"--" + genesis.CfgConsensusStateCheckpointInterval, strconv.FormatUint(net.cfg.Consensus.Parameters.StateCheckpointInterval, 10),
@@ -801,6 +833,9 @@ func (net *Network) MakeGenesis() error {
 		"--" + genesis.CfgStakingTokenValueExponent, strconv.FormatUint(uint64(genesisTestHelpers.TestStakingTokenValueExponent), 10),
 		"--" + genesis.CfgBeaconBackend, net.cfg.Beacon.Backend,
 	}
+	if !net.cfg.GenesisTime.IsZero() {
+		args = append(args, "--"+genesis.CfgGenesisTime, net.cfg.GenesisTime.Format(time.RFC3339Nano))
+	}
 	switch net.cfg.Beacon.Backend {
 	case beacon.BackendInsecure:
 		args = append(args, []string{
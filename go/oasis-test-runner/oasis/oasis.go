@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -55,6 +56,7 @@ const (
 	nodePortP2P       = "p2p"
 	nodePortP2PSeed   = "p2p-seed"
 	nodePortPprof     = "pprof"
+	nodePortMetrics   = "metrics"
 
 	allInterfacesAddr = "tcp://0.0.0.0"
 	localhostAddr     = "tcp://127.0.0.1"
@@ -106,6 +108,8 @@ type Node struct { // nolint: maligned
 	termErrorOk bool
 	isStopping  bool
 	noAutoStart bool
+	clockSkew   *ClockSkewFixture
+	extraConfig map[string]interface{}
 
 	crashPointsProbability      float64
 	supplementarySanityInterval uint64
@@ -117,7 +121,8 @@ type Node struct { // nolint: maligned
 	consensusStateSync   *ConsensusStateSyncCfg
 	customGrpcSocketPath string
 
-	pprofPort uint16
+	pprofPort   uint16
+	metricsPort uint16
 
 	nodeSigner signature.PublicKey
 	p2pSigner  signature.PublicKey
@@ -184,6 +189,16 @@ func (n *Node) DataDir() string {
 	return n.dir.String()
 }
 
+// MetricsAddress returns the address of the node's pull mode metrics endpoint.
+//
+// Returns an empty string if pull mode metrics are not enabled for the node.
+func (n *Node) MetricsAddress() string {
+	if n.metricsPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("127.0.0.1:%d", n.metricsPort)
+}
+
 // LoadIdentity loads the node's identity.
 func (n *Node) LoadIdentity() (*identity.Identity, error) {
 	factory, err := fileSigner.NewFactory(n.dir.String(), identity.RequiredSignerRoles...)
@@ -372,6 +387,22 @@ func (n *Node) RestartAfter(ctx context.Context, startDelay time.Duration) error
 	return n.Start()
 }
 
+// Pause suspends the node process without terminating it, simulating an unresponsive node.
+func (n *Node) Pause() error {
+	if n.cmd == nil || n.cmd.Process == nil {
+		return fmt.Errorf("oasis/node: node %s not running", n.Name)
+	}
+	return n.cmd.Process.Signal(syscall.SIGSTOP)
+}
+
+// Resume resumes a node process suspended by Pause.
+func (n *Node) Resume() error {
+	if n.cmd == nil || n.cmd.Process == nil {
+		return fmt.Errorf("oasis/node: node %s not running", n.Name)
+	}
+	return n.cmd.Process.Signal(syscall.SIGCONT)
+}
+
 // BinaryPath returns the path to the running node's process' image, or an empty string
 // if the node isn't running yet. This can be used as a replacement for NetworkCfg.NodeBinary
 // in cases where the test runner is actually using a wrapper to start the node.
@@ -481,6 +512,10 @@ type NodeCfg struct { // nolint: maligned
 	Entity *Entity
 
 	ExtraArgs []Argument
+
+	ClockSkew *ClockSkewFixture
+
+	ExtraConfig map[string]interface{}
 }
 
 // Into sets node parameters of an existing node object from the configuration.
@@ -504,6 +539,8 @@ func (cfg *NodeCfg) Into(node *Node) {
 		node.pprofPort = node.getProvisionedPort(nodePortPprof)
 	}
 	node.extraArgs = cfg.ExtraArgs
+	node.clockSkew = cfg.ClockSkew
+	node.extraConfig = cfg.ExtraConfig
 }
 
 func nodeLogPath(dir *env.Dir) string {
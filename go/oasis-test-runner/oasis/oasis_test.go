@@ -5,11 +5,13 @@ import (
 	"crypto"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
+	"github.com/oasisprotocol/oasis-core/go/config"
 )
 
 func generateDeterministicNodeKeys(t *testing.T, rawSeed string) (ed25519.PublicKey, ed25519.PrivateKey) {
@@ -39,3 +41,48 @@ func TestNodeIdentity(t *testing.T) {
 	require.Equal(t, 1, bytes.Compare(b1, c0))
 	require.Equal(t, 1, bytes.Compare(c2, b1))
 }
+
+func TestClockSkewApply(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	skew := ClockSkewFixture{Offset: time.Minute, DriftRate: 1.001}
+	require.NoError(skew.apply(&cfg))
+	require.Equal(time.Minute, cfg.Consensus.Debug.ClockOffset)
+	require.Equal(1.001, cfg.Consensus.Debug.ClockDriftRate)
+
+	skew = ClockSkewFixture{DriftRate: -1}
+	require.Error(skew.Validate(), "negative drift rate should be rejected")
+	require.Error(skew.apply(&cfg), "negative drift rate should be rejected")
+}
+
+func TestMergeExtraConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Consensus.HaltEpoch = 5
+	err := mergeExtraConfig(&cfg, map[string]interface{}{
+		"consensus.min_gas_price": 10,
+		"consensus": map[string]interface{}{
+			"upgrade_stop_delay": "15s",
+			"state_sync": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+	})
+	require.NoError(err, "mergeExtraConfig")
+	require.EqualValues(5, cfg.Consensus.HaltEpoch, "unrelated options should be preserved")
+	require.EqualValues(10, cfg.Consensus.MinGasPrice, "dot-separated keys should be applied")
+	require.Equal(15*time.Second, cfg.Consensus.UpgradeStopDelay, "nested keys should be applied")
+	require.True(cfg.Consensus.StateSync.Enabled, "deeply nested keys should be applied")
+
+	err = mergeExtraConfig(&cfg, map[string]interface{}{
+		"consensus.no_such_option": true,
+	})
+	require.Error(err, "unknown options should be rejected")
+
+	err = mergeExtraConfig(&cfg, map[string]interface{}{
+		"consensus.halt_epoch.foo": true,
+	})
+	require.Error(err, "descending into non-map options should be rejected")
+}
@@ -0,0 +1,105 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+const (
+	// clockSkewOffset is the offset of the validators' clocks.
+	clockSkewOffset = 10 * time.Minute
+	// clockSkewTolerance is the maximum allowed difference between the expected and
+	// the reported consensus time.
+	clockSkewTolerance = time.Minute
+)
+
+// ClockSkew is the scenario where validator clocks are skewed.
+var ClockSkew scenario.Scenario = &clockSkew{
+	Scenario: *NewScenario("clock-skew"),
+}
+
+type clockSkew struct {
+	Scenario
+}
+
+func (sc *clockSkew) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Skew all validators so that the consensus time follows the skewed clocks.
+	for i := range f.Validators {
+		f.Validators[i].ClockSkew = &oasis.ClockSkewFixture{
+			Offset: clockSkewOffset,
+		}
+	}
+
+	f.Network.SetInsecureBeacon()
+
+	return f, nil
+}
+
+func (sc *clockSkew) Clone() scenario.Scenario {
+	return &clockSkew{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *clockSkew) Run(ctx context.Context, _ *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return fmt.Errorf("net Start: %w", err)
+	}
+
+	sc.Logger.Info("waiting for network to come up")
+	if err := sc.Net.Controller().WaitNodesRegistered(ctx, len(sc.Net.Validators())); err != nil {
+		return fmt.Errorf("WaitNodesRegistered: %w", err)
+	}
+
+	blk, err := sc.WaitBlocks(ctx, 3)
+	if err != nil {
+		return err
+	}
+	if err = checkClockSkew(blk.Time, time.Now()); err != nil {
+		return fmt.Errorf("block %d: %w", blk.Height, err)
+	}
+
+	// Every node should report the skewed time.
+	for _, node := range sc.Net.Nodes() {
+		ctrl, err := oasis.NewController(node.SocketPath())
+		if err != nil {
+			return fmt.Errorf("failed to create controller for node %s: %w", node.Name, err)
+		}
+		status, err := ctrl.Consensus.GetStatus(ctx)
+		ctrl.Close()
+		if err != nil {
+			return fmt.Errorf("failed to get status for node %s: %w", node.Name, err)
+		}
+
+		sc.Logger.Info("node consensus time",
+			"node", node.Name,
+			"height", status.LatestHeight,
+			"latest_time", status.LatestTime,
+		)
+
+		if err = checkClockSkew(status.LatestTime, time.Now()); err != nil {
+			return fmt.Errorf("node %s: %w", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkClockSkew checks that the consensus time is skewed by the configured offset.
+func checkClockSkew(consensusTime, now time.Time) error {
+	skew := consensusTime.Sub(now)
+	if skew < clockSkewOffset-clockSkewTolerance || skew > clockSkewOffset+clockSkewTolerance {
+		return fmt.Errorf("consensus time %s is skewed by %s, expected %s", consensusTime, skew, clockSkewOffset)
+	}
+	return nil
+}
@@ -60,6 +60,11 @@ func (sc *Scenario) WaitBlocks(ctx context.Context, n int) (*consensus.Block, er
 func (sc *Scenario) WaitEpochs(ctx context.Context, n beacon.EpochTime) error {
 	sc.Logger.Info("waiting few epochs", "n", n)
 
+	if sc.MockEpochEnabled() {
+		_, err := sc.AdvanceEpochs(ctx, n)
+		return err
+	}
+
 	epoch, err := sc.Net.ClientController().Beacon.GetEpoch(ctx, consensus.HeightLatest)
 	if err != nil {
 		return err
@@ -0,0 +1,223 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+)
+
+const (
+	metricGoroutines = "go_goroutines"
+
+	// goroutineGrowthFactor is the maximum factor by which the number of goroutines of a node
+	// may grow over a run (in addition to goroutineGrowthSlack).
+	goroutineGrowthFactor = 2
+	// goroutineGrowthSlack is the number of goroutines a node may gain over a run regardless of
+	// the baseline.
+	goroutineGrowthSlack = 200
+
+	metricsScrapeTimeout = 10 * time.Second
+)
+
+// requiredMetric is a metric that must be reported by all nodes.
+type requiredMetric struct {
+	name  string
+	check func(value float64) error
+}
+
+func positive(value float64) error {
+	if value <= 0 {
+		return fmt.Errorf("expected a positive value, got %v", value)
+	}
+	return nil
+}
+
+func nonNegative(value float64) error {
+	if value < 0 {
+		return fmt.Errorf("expected a non-negative value, got %v", value)
+	}
+	return nil
+}
+
+func zero(value float64) error {
+	if value != 0 {
+		return fmt.Errorf("expected zero, got %v", value)
+	}
+	return nil
+}
+
+// requiredMetrics is the minimal set of metrics that every node running in pull mode must
+// report after a standard run:
+//
+//   - go_goroutines: number of goroutines (must be positive and must not leak).
+//   - process_resident_memory_bytes: resident memory size (must be positive).
+//   - oasis_node_cpu_utime_seconds: CPU user time (must be non-negative).
+//   - oasis_node_mem_rss_anon_bytes: anonymous resident memory (must be non-negative).
+//   - oasis_recovered_panics: number of recovered panics (must be zero).
+var requiredMetrics = []requiredMetric{
+	{metricGoroutines, positive},
+	{"process_resident_memory_bytes", positive},
+	{"oasis_node_cpu_utime_seconds", nonNegative},
+	{"oasis_node_mem_rss_anon_bytes", nonNegative},
+	{"oasis_recovered_panics", zero},
+}
+
+// MetricsBaseline is a per-node snapshot of metrics taken at the start of a run, used to detect
+// resource leaks over the run.
+type MetricsBaseline map[string]float64
+
+func scrapeMetrics(ctx context.Context, addr string) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, metricsScrapeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/metrics", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape metrics: unexpected status: %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return families, nil
+}
+
+// metricValue returns the value of the given gauge or counter metric, summed over all label
+// values.
+func metricValue(families map[string]*dto.MetricFamily, name string) (float64, error) {
+	family, ok := families[name]
+	if !ok || len(family.GetMetric()) == 0 {
+		return 0, fmt.Errorf("metric %s not reported", name)
+	}
+
+	var value float64
+	for _, m := range family.GetMetric() {
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			value += m.GetGauge().GetValue()
+		case dto.MetricType_COUNTER:
+			value += m.GetCounter().GetValue()
+		default:
+			return 0, fmt.Errorf("metric %s has unsupported type %s", name, family.GetType())
+		}
+	}
+	return value, nil
+}
+
+// MetricValue scrapes the given node, which must expose metrics in pull mode, and returns the
+// value of the given gauge or counter metric, summed over all label values.
+func (sc *Scenario) MetricValue(ctx context.Context, n *oasis.Node, name string) (float64, error) {
+	if n.MetricsAddress() == "" {
+		return 0, fmt.Errorf("%s: pull mode metrics must be enabled", n.Name)
+	}
+	families, err := scrapeMetrics(ctx, n.MetricsAddress())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", n.Name, err)
+	}
+	value, err := metricValue(families, name)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", n.Name, err)
+	}
+	return value, nil
+}
+
+// metricsNodes returns all nodes that expose metrics in pull mode.
+func (sc *Scenario) metricsNodes() ([]*oasis.Node, error) {
+	var nodes []*oasis.Node
+	for _, n := range sc.Net.Nodes() {
+		if n.MetricsAddress() == "" {
+			continue
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes expose metrics, pull mode metrics must be enabled")
+	}
+	return nodes, nil
+}
+
+// RecordMetricsBaseline scrapes all nodes that expose metrics in pull mode and records the
+// baseline used by CheckMetrics.
+func (sc *Scenario) RecordMetricsBaseline(ctx context.Context) (MetricsBaseline, error) {
+	nodes, err := sc.metricsNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := make(MetricsBaseline)
+	for _, n := range nodes {
+		families, err := scrapeMetrics(ctx, n.MetricsAddress())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n.Name, err)
+		}
+		goroutines, err := metricValue(families, metricGoroutines)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n.Name, err)
+		}
+		baseline[n.Name] = goroutines
+	}
+	return baseline, nil
+}
+
+// CheckMetrics scrapes all nodes that expose metrics in pull mode and makes sure that the
+// required metrics are reported with sane values and that the number of goroutines did not
+// grow excessively since the given baseline.
+func (sc *Scenario) CheckMetrics(ctx context.Context, baseline MetricsBaseline) error {
+	sc.Logger.Info("checking node metrics")
+
+	nodes, err := sc.metricsNodes()
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		families, err := scrapeMetrics(ctx, n.MetricsAddress())
+		if err != nil {
+			return fmt.Errorf("%s: %w", n.Name, err)
+		}
+
+		for _, rm := range requiredMetrics {
+			value, err := metricValue(families, rm.name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", n.Name, err)
+			}
+			if err = rm.check(value); err != nil {
+				return fmt.Errorf("%s: metric %s: %w", n.Name, rm.name, err)
+			}
+		}
+
+		base, ok := baseline[n.Name]
+		if !ok {
+			continue
+		}
+		goroutines, _ := metricValue(families, metricGoroutines)
+		if limit := goroutineGrowthFactor*base + goroutineGrowthSlack; goroutines > limit {
+			return fmt.Errorf("%s: goroutine leak: %v goroutines (baseline: %v, limit: %v)",
+				n.Name, goroutines, base, limit,
+			)
+		}
+
+		sc.Logger.Debug("node metrics ok",
+			"node", n.Name,
+			"goroutines", goroutines,
+			"baseline_goroutines", base,
+		)
+	}
+	return nil
+}
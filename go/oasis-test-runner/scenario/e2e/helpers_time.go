@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// MockEpochEnabled returns true iff the network uses the debug mock beacon
+// backend, in which case epoch transitions need to be triggered manually.
+func (sc *Scenario) MockEpochEnabled() bool {
+	return sc.Net.Config().Beacon.DebugMockBackend
+}
+
+// AdvanceEpochs advances time by the specified number of epochs and returns
+// the new epoch.
+//
+// When the network uses the mock beacon backend, epoch transitions are
+// triggered deterministically via the debug controller. Otherwise this waits
+// for the epochs to pass.
+func (sc *Scenario) AdvanceEpochs(ctx context.Context, n beacon.EpochTime) (beacon.EpochTime, error) {
+	epoch, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return beacon.EpochInvalid, fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	target := epoch + n
+	if err = sc.AdvanceToEpoch(ctx, target); err != nil {
+		return beacon.EpochInvalid, err
+	}
+	return target, nil
+}
+
+// AdvanceToEpoch advances time until the specified epoch is reached.
+//
+// When the network uses the mock beacon backend, epoch transitions are
+// triggered deterministically via the debug controller, one epoch at a time.
+// Otherwise this waits for the epoch to be reached.
+func (sc *Scenario) AdvanceToEpoch(ctx context.Context, epoch beacon.EpochTime) error {
+	if !sc.MockEpochEnabled() {
+		sc.Logger.Info("waiting for epoch", "epoch", epoch)
+		return sc.Net.Controller().Beacon.WaitEpoch(ctx, epoch)
+	}
+
+	current, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	for ep := current + 1; ep <= epoch; ep++ {
+		sc.Logger.Info("triggering epoch transition", "epoch", ep)
+		if err = sc.Net.Controller().SetEpoch(ctx, ep); err != nil {
+			return fmt.Errorf("failed to set epoch %d: %w", ep, err)
+		}
+	}
+	return nil
+}
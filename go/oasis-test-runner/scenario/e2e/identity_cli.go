@@ -55,7 +55,10 @@ func (sc *identityCLIImpl) Run(_ context.Context, childEnv *env.Env) error {
 		"identity", "init",
 		"--" + cmdId.CfgDataDir, sc.dataDir,
 	}
-	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
+	nodeBinary, err := sc.NodeBinary()
+	if err != nil {
+		return err
+	}
 	if err := cli.RunSubCommand(childEnv, sc.Logger, "identity-init", nodeBinary, args); err != nil {
 		return fmt.Errorf("scenario/e2e/identity_cli: failed provision node's identity: %w", err)
 	}
@@ -106,7 +109,10 @@ func (sc *identityCLIImpl) showTLSPubkey(childEnv *env.Env, sentry bool) error {
 		"identity", subCmd,
 		"--" + cmdId.CfgDataDir, sc.dataDir,
 	}
-	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
+	nodeBinary, err := sc.NodeBinary()
+	if err != nil {
+		return err
+	}
 	if out, err := cli.RunSubCommandWithOutput(childEnv, sc.Logger, subCmd, nodeBinary, args); err != nil {
 		return fmt.Errorf("failed to run %s: error: %w output: %s", subCmd, err, out.String())
 	}
@@ -121,7 +127,10 @@ func (sc *identityCLIImpl) cmtShowAddress(childEnv *env.Env, addrName string) er
 		"identity", "cometbft", subCmd,
 		"--" + cmdId.CfgDataDir, sc.dataDir,
 	}
-	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
+	nodeBinary, err := sc.NodeBinary()
+	if err != nil {
+		return err
+	}
 	if out, err := cli.RunSubCommandWithOutput(childEnv, sc.Logger, subCmd, nodeBinary, args); err != nil {
 		return fmt.Errorf("failed to get %s's CometBFT address: error: %w output: %s", addrName, err, out.String())
 	}
@@ -0,0 +1,187 @@
+package e2e
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	multisigNumSigners = 3
+	multisigThreshold  = 2
+	multisigTransfer   = 100
+)
+
+var (
+	// Multisig is the scenario that tests transactions authorized by a
+	// threshold of entity multisig signers.
+	Multisig scenario.Scenario = &multisigImpl{
+		Scenario: *NewScenario("multisig"),
+	}
+
+	// Signer of the multisig entity funded in the staking genesis fixture
+	// used in this scenario.
+	multisigEntitySigner = memorySigner.NewTestSigner("oasis multisig e2e test signer: entity")
+)
+
+type multisigImpl struct {
+	Scenario
+}
+
+func (sc *multisigImpl) Clone() scenario.Scenario {
+	return &multisigImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *multisigImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Network.StakingGenesis = &staking.Genesis{
+		TotalSupply: *quantity.NewFromUint64(1000),
+		Ledger: map[staking.Address]*staking.Account{
+			staking.NewAddress(multisigEntitySigner.Public()): {
+				General: staking.GeneralAccount{
+					Balance: *quantity.NewFromUint64(1000),
+				},
+			},
+		},
+	}
+
+	return f, nil
+}
+
+func (sc *multisigImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.Net.Start(); err != nil {
+		return fmt.Errorf("net Start: %w", err)
+	}
+
+	sc.Logger.Info("waiting for network to come up")
+	if err := sc.Net.Controller().WaitNodesRegistered(ctx, 3); err != nil {
+		return fmt.Errorf("WaitNodesRegistered: %w", err)
+	}
+
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	// Provision the multisig co-signers.
+	var (
+		signerDirs []string
+		policy     = entity.MultisigPolicy{Threshold: multisigThreshold}
+	)
+	for i := 0; i < multisigNumSigners; i++ {
+		dir := filepath.Join(childEnv.Dir(), fmt.Sprintf("multisig-signer-%d", i))
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to create signer dir: %w", err)
+		}
+		factory, err := fileSigner.NewFactory(dir, signature.SignerEntity)
+		if err != nil {
+			return fmt.Errorf("failed to create signer factory: %w", err)
+		}
+		signer, err := factory.Generate(signature.SignerEntity, rand.Reader)
+		if err != nil {
+			return fmt.Errorf("failed to generate signer: %w", err)
+		}
+		signerDirs = append(signerDirs, dir)
+		policy.Signers = append(policy.Signers, signer.Public())
+	}
+
+	// Register the multisig entity.
+	sc.Logger.Info("registering multisig entity")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        multisigEntitySigner.Public(),
+		Multisig:  &policy,
+	}
+	sigEnt, err := entity.SignEntity(multisigEntitySigner, registry.RegisterEntitySignatureContext, &ent)
+	if err != nil {
+		return fmt.Errorf("failed to sign entity: %w", err)
+	}
+	sigTx, err := transaction.Sign(multisigEntitySigner, registry.NewRegisterEntityTx(0, &transaction.Fee{Gas: 10000}, sigEnt))
+	if err != nil {
+		return fmt.Errorf("failed to sign register entity transaction: %w", err)
+	}
+	if err = sc.Net.Controller().Consensus.SubmitTx(ctx, sigTx); err != nil {
+		return fmt.Errorf("failed to register multisig entity: %w", err)
+	}
+
+	// Prepare an unsigned transfer on behalf of the multisig entity.
+	nonce, err := sc.Net.Controller().Consensus.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
+		AccountAddress: staking.NewAddress(ent.ID),
+		Height:         consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query multisig entity nonce: %w", err)
+	}
+	tx := staking.NewTransferTx(nonce, &transaction.Fee{Gas: 10000}, &staking.Transfer{
+		To:     TestEntityAccount,
+		Amount: *quantity.NewFromUint64(multisigTransfer),
+	})
+	unsignedTxPath := filepath.Join(childEnv.Dir(), "multisig_transfer_unsigned.cbor")
+	if err = os.WriteFile(unsignedTxPath, cbor.Marshal(tx), 0o600); err != nil {
+		return fmt.Errorf("failed to write unsigned transaction: %w", err)
+	}
+
+	// Collect partial signatures offline.
+	var partialTxPaths []string
+	for i, dir := range signerDirs[:multisigThreshold] {
+		txPath := filepath.Join(childEnv.Dir(), fmt.Sprintf("multisig_transfer_partial-%d.json", i))
+		if err = cli.Consensus.SignMultisigTx(dir, ent.ID, unsignedTxPath, txPath); err != nil {
+			return err
+		}
+		partialTxPaths = append(partialTxPaths, txPath)
+	}
+
+	// A single partial signature must not satisfy the policy.
+	sc.Logger.Info("submitting transaction below the multisig threshold")
+	if err = cli.Consensus.SubmitTx(partialTxPaths[0]); err == nil {
+		return fmt.Errorf("transaction below the multisig threshold should be rejected")
+	}
+
+	// Combine the partial signatures and submit.
+	sc.Logger.Info("submitting combined multisig transaction")
+	txPath := filepath.Join(childEnv.Dir(), "multisig_transfer.json")
+	if err = cli.Consensus.CombineMultisigTx(txPath, partialTxPaths...); err != nil {
+		return err
+	}
+	if err = cli.Consensus.SubmitTx(txPath); err != nil {
+		return err
+	}
+
+	// Make sure the transfer has been executed.
+	acct, err := sc.Net.Controller().Staking.Account(ctx, &staking.OwnerQuery{
+		Owner:  staking.NewAddress(ent.ID),
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query multisig entity account: %w", err)
+	}
+	expected := quantity.NewFromUint64(1000 - multisigTransfer)
+	if acct.General.Balance.Cmp(expected) != 0 {
+		return fmt.Errorf("multisig entity balance %v should be %v", acct.General.Balance, expected)
+	}
+	if acct.General.Nonce != nonce+1 {
+		return fmt.Errorf("multisig entity nonce %d should be %d", acct.General.Nonce, nonce+1)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -23,6 +24,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
+// localUpgradeTimeout is the time nodes have to update their local pending upgrades.
+const localUpgradeTimeout = 30 * time.Second
+
 var (
 	// GovernanceConsensusUpgrade is the governance consensus upgrade scenario.
 	GovernanceConsensusUpgrade scenario.Scenario = newGovernanceConsensusUpgradeImpl(true, false)
@@ -295,9 +299,54 @@ func (sc *governanceConsensusUpgradeImpl) cancelUpgrade(ctx context.Context, pro
 		return fmt.Errorf("expected no pending upgrade, got: %v", l)
 	}
 
+	// Ensure nodes have cleared the pending upgrade locally.
+	return sc.waitLocalPendingUpgrades(ctx, 0)
+}
+
+// waitLocalPendingUpgrades waits until all nodes report the given number of local pending upgrades.
+func (sc *governanceConsensusUpgradeImpl) waitLocalPendingUpgrades(ctx context.Context, expected int) error {
+	ctx, cancel := context.WithTimeout(ctx, localUpgradeTimeout)
+	defer cancel()
+
+	for _, nd := range sc.Net.Nodes() {
+		if err := sc.waitNodeLocalPendingUpgrades(ctx, nd, expected); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (sc *governanceConsensusUpgradeImpl) waitNodeLocalPendingUpgrades(ctx context.Context, nd *oasis.Node, expected int) error {
+	sc.Logger.Info("waiting for node local pending upgrades",
+		"node", nd.Name,
+		"expected", expected,
+	)
+
+	ctrl, err := oasis.NewController(nd.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to create controller for node %s: %w", nd.Name, err)
+	}
+	defer ctrl.Close()
+
+	for {
+		status, err := ctrl.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch node %s status: %w", nd.Name, err)
+		}
+		if len(status.PendingUpgrades) == expected {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node %s has %d pending upgrades, expected %d",
+				nd.Name, len(status.PendingUpgrades), expected,
+			)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
 func (sc *governanceConsensusUpgradeImpl) Run(ctx context.Context, childEnv *env.Env) error { // nolint: gocyclo
 	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
 		return err
@@ -380,6 +429,10 @@ func (sc *governanceConsensusUpgradeImpl) Run(ctx context.Context, childEnv *env
 
 	// Cancel upgrade if configured so.
 	if sc.shouldCancelUpgrade {
+		// Ensure nodes are aware of the pending upgrade before canceling it.
+		if err = sc.waitLocalPendingUpgrades(ctx, 1); err != nil {
+			return err
+		}
 		if err = sc.cancelUpgrade(ctx, proposal.ID); err != nil {
 			return fmt.Errorf("cancel upgrade failure: %w", err)
 		}
@@ -412,6 +465,17 @@ func (sc *governanceConsensusUpgradeImpl) Run(ctx context.Context, childEnv *env
 		}
 	}
 
+	if sc.shouldCancelUpgrade {
+		// Nodes should keep running past the canceled upgrade epoch.
+		for _, nd := range sc.Net.Nodes() {
+			select {
+			case err = <-nd.Exit():
+				return fmt.Errorf("node %s exited after the upgrade was canceled (err: %v)", nd.Name, err)
+			default:
+			}
+		}
+	}
+
 	if !sc.shouldCancelUpgrade {
 		// Nodes should restart.
 		sc.Logger.Info("waiting for all nodes to get restarted")
@@ -48,19 +48,27 @@ var (
 )
 
 // ResolveRuntimeBinaries returns the paths to the runtime binaries.
-func (sc *Scenario) ResolveRuntimeBinaries(baseRuntimeBinary string) map[node.TEEHardware]string {
+func (sc *Scenario) ResolveRuntimeBinaries(baseRuntimeBinary string) (map[node.TEEHardware]string, error) {
 	binaries := make(map[node.TEEHardware]string)
 	for _, tee := range []node.TEEHardware{
 		node.TEEHardwareInvalid,
 		node.TEEHardwareIntelSGX,
 	} {
-		binaries[tee] = sc.ResolveRuntimeBinary(baseRuntimeBinary, tee)
+		binary, err := sc.ResolveRuntimeBinary(baseRuntimeBinary, tee)
+		if err != nil {
+			return nil, err
+		}
+		binaries[tee] = binary
 	}
-	return binaries
+	return binaries, nil
 }
 
 // ResolveRuntimeBinary returns the path to the runtime binary.
-func (sc *Scenario) ResolveRuntimeBinary(runtimeBinary string, tee node.TEEHardware) string {
+//
+// In case the artifact manifest contains the runtime binary (e.g., simple-keyvalue or
+// simple-keyvalue.sgxs), its path is used. Otherwise, the binary is expected to be located
+// in the configured runtime binaries directory.
+func (sc *Scenario) ResolveRuntimeBinary(runtimeBinary string, tee node.TEEHardware) (string, error) {
 	var runtimeExt, path string
 	switch tee {
 	case node.TEEHardwareInvalid:
@@ -71,7 +79,7 @@ func (sc *Scenario) ResolveRuntimeBinary(runtimeBinary string, tee node.TEEHardw
 		path, _ = sc.Flags.GetString(cfgRuntimeBinaryDirIntelSGX)
 	}
 
-	return filepath.Join(path, runtimeBinary+runtimeExt)
+	return sc.ResolveArtifact(runtimeBinary+runtimeExt, filepath.Join(path, runtimeBinary+runtimeExt))
 }
 
 // BuildRuntimes builds the specified runtime binaries using the provided trust root, if given.
@@ -343,7 +351,7 @@ func (sc *Scenario) EnableRuntimeDeployment(ctx context.Context, childEnv *env.E
 		"runtime_id", rt.ID(),
 		"epoch", upgradeEpoch,
 	)
-	if err := sc.Net.Controller().Beacon.WaitEpoch(ctx, upgradeEpoch); err != nil {
+	if err := sc.AdvanceToEpoch(ctx, upgradeEpoch); err != nil {
 		return fmt.Errorf("failed to wait for epoch: %w", err)
 	}
 
@@ -365,7 +373,10 @@ func (sc *Scenario) UpgradeComputeRuntimeFixture(f *oasis.NetworkFixture) (int,
 	}
 
 	// Load the upgraded runtime binary.
-	newRuntimeBinaries := sc.ResolveRuntimeBinaries(KeyValueRuntimeUpgradeBinary)
+	newRuntimeBinaries, err := sc.ResolveRuntimeBinaries(KeyValueRuntimeUpgradeBinary)
+	if err != nil {
+		return 0, err
+	}
 
 	// Create a duplicate runtime, which will be added to the genesis.
 	f.Runtimes = append(f.Runtimes, f.Runtimes[idx])
@@ -420,7 +431,10 @@ func (sc *Scenario) UpgradeKeyManagerFixture(f *oasis.NetworkFixture) (int, erro
 	}
 
 	// Load the upgraded key manager binary.
-	newRuntimeBinaries := sc.ResolveRuntimeBinaries(KeyManagerRuntimeUpgradeBinary)
+	newRuntimeBinaries, err := sc.ResolveRuntimeBinaries(KeyManagerRuntimeUpgradeBinary)
+	if err != nil {
+		return 0, err
+	}
 
 	// Create a duplicate runtime, which will be added to the genesis latter.
 	newRt := f.Runtimes[idx]
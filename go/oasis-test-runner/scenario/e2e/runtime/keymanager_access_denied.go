@@ -0,0 +1,159 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	workerKeymanager "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+const (
+	// metricKeymanagerAccessDenied is the metric counting enclave RPC requests denied
+	// by the key manager access control.
+	metricKeymanagerAccessDenied = "oasis_worker_keymanager_access_denied_count"
+
+	// kmAccessDeniedTxTimeout is the time the runtime has to report the denial.
+	kmAccessDeniedTxTimeout = 2 * time.Minute
+)
+
+// KeymanagerAccessDenied is a scenario where a compute runtime enclave that is not covered
+// by the key manager policy requests keys, verifying that the denial is observable.
+//
+// Scenario:
+//   - Start the network and wait for the first master secret.
+//   - Update the key manager policy so that the compute runtime may no longer query keys.
+//   - Submit a runtime transaction which requires keys.
+//   - Verify that the transaction fails with an access denied error, that the key manager
+//     reports the denial in its metrics and that the denial is logged.
+var KeymanagerAccessDenied scenario.Scenario = newKmAccessDeniedImpl()
+
+type kmAccessDeniedImpl struct {
+	Scenario
+}
+
+func newKmAccessDeniedImpl() scenario.Scenario {
+	return &kmAccessDeniedImpl{
+		Scenario: *NewScenario("keymanager-access-denied", nil),
+	}
+}
+
+func (sc *kmAccessDeniedImpl) Clone() scenario.Scenario {
+	return &kmAccessDeniedImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *kmAccessDeniedImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Key manager metrics are scraped to verify that the denial was recorded.
+	f.Network.Metrics.Pull = true
+
+	// The denial must be logged by the key manager.
+	f.Keymanagers[0].LogWatcherHandlerFactories = append(
+		f.Keymanagers[0].LogWatcherHandlerFactories,
+		oasis.LogAssertKeymanagerAccessDenied(),
+	)
+
+	return f, nil
+}
+
+func (sc *kmAccessDeniedImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	// Access control is only enforced by key managers running in a TEE.
+	tee, err := sc.TEEHardware()
+	if err != nil {
+		return err
+	}
+	if tee != node.TEEHardwareIntelSGX {
+		return fmt.Errorf("scenario requires %s TEE hardware", node.TEEHardwareIntelSGX)
+	}
+
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	if err = sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+	status, err := sc.WaitMasterSecret(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	// Revoke the compute runtime's permission to query the key manager.
+	policies, err := sc.BuildEnclavePolicies()
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		delete(policy.MayQuery, KeyValueRuntimeID)
+	}
+	if err = sc.ApplyKeyManagerPolicy(ctx, childEnv, cli, 0, policies, 0); err != nil {
+		return err
+	}
+	if err = sc.waitPolicySerial(ctx, status.Policy.Policy.Serial+1); err != nil {
+		return err
+	}
+
+	// Requesting keys for a new key should now fail.
+	txCtx, cancel := context.WithTimeout(ctx, kmAccessDeniedTxTimeout)
+	defer cancel()
+
+	sc.Logger.Info("submitting transaction requiring keys")
+	_, err = sc.submitKeyValueRuntimeInsertTx(txCtx, KeyValueRuntimeID, 0, "access_denied_key", "access_denied_value", 0, 0, encryptedWithSecretsTxKind)
+	switch {
+	case err == nil:
+		return fmt.Errorf("transaction requiring keys should fail")
+	case txCtx.Err() != nil:
+		return fmt.Errorf("access denial was not reported to the runtime: %w", err)
+	case !strings.Contains(err.Error(), workerKeymanager.ErrAccessDenied.Error()):
+		return fmt.Errorf("transaction failed with unexpected error: %w", err)
+	}
+	sc.Logger.Info("transaction failed as expected", "err", err)
+
+	// The key manager should have recorded the denial.
+	km := sc.Net.Keymanagers()[0]
+	denials, err := sc.MetricValue(ctx, km.Node, metricKeymanagerAccessDenied)
+	if err != nil {
+		return err
+	}
+	if denials == 0 {
+		return fmt.Errorf("key manager did not record the access denial")
+	}
+	sc.Logger.Info("key manager recorded access denials", "count", denials)
+
+	return sc.Net.CheckLogWatchers()
+}
+
+// waitPolicySerial waits until the key manager status contains a policy with the given serial.
+func (sc *kmAccessDeniedImpl) waitPolicySerial(ctx context.Context, serial uint32) error {
+	sc.Logger.Info("waiting for key manager policy", "serial", serial)
+
+	stCh, stSub, err := sc.Net.Controller().Keymanager.Secrets().WatchStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	defer stSub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case status := <-stCh:
+			if !status.ID.Equal(&KeyManagerRuntimeID) {
+				continue
+			}
+			if status.Policy != nil && status.Policy.Policy.Serial >= serial {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+// maxReadmissionEpochs is the maximum number of epochs to wait for a re-registered node to be
+// elected into the executor committee again.
+const maxReadmissionEpochs = 10
+
+// RegistrationExpiry is the registration expiry and automatic re-registration scenario.
+var RegistrationExpiry scenario.Scenario = newRegistrationExpiryImpl()
+
+type registrationExpiryImpl struct {
+	Scenario
+}
+
+func newRegistrationExpiryImpl() scenario.Scenario {
+	return &registrationExpiryImpl{
+		Scenario: *NewScenario(
+			"registration-expiry",
+			NewTestClient().WithScenario(SimpleScenario),
+		),
+	}
+}
+
+func (sc *registrationExpiryImpl) Clone() scenario.Scenario {
+	return &registrationExpiryImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *registrationExpiryImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	f.Network.SetMockEpoch()
+
+	// Add another compute worker so that the executor committee can still be elected while one
+	// of the compute workers is not registered.
+	f.ComputeWorkers = append(f.ComputeWorkers, f.ComputeWorkers[0])
+
+	return f, nil
+}
+
+func (sc *registrationExpiryImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	fixture, err := sc.Fixture()
+	if err != nil {
+		return err
+	}
+	if _, err = sc.initialEpochTransitions(ctx, fixture); err != nil {
+		return err
+	}
+
+	compute := sc.Net.ComputeWorkers()[0]
+	if _, err = sc.Net.Controller().Registry.GetNode(ctx, &registry.IDQuery{
+		Height: consensus.HeightLatest,
+		ID:     compute.NodeID,
+	}); err != nil {
+		return fmt.Errorf("compute worker should be registered: %w", err)
+	}
+
+	// Pause the compute worker so that it stops renewing its registration.
+	sc.Logger.Info("pausing compute worker", "node", compute.Name)
+	if err = compute.Pause(); err != nil {
+		return err
+	}
+
+	// Nodes register for two epochs in advance, wait for the registration to expire.
+	if _, err = sc.AdvanceEpochs(ctx, 3); err != nil {
+		return err
+	}
+
+	_, err = sc.Net.Controller().Registry.GetNode(ctx, &registry.IDQuery{
+		Height: consensus.HeightLatest,
+		ID:     compute.NodeID,
+	})
+	if !errors.Is(err, registry.ErrNoSuchNode) {
+		return fmt.Errorf("compute worker registration should have expired (err: %w)", err)
+	}
+	isMember, err := sc.isExecutorCommitteeMember(ctx, compute)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		return fmt.Errorf("compute worker with an expired registration should not be elected")
+	}
+	sc.Logger.Info("compute worker registration expired and node was removed from committees")
+
+	// Resume the compute worker, it should re-register on its own.
+	sc.Logger.Info("resuming compute worker", "node", compute.Name)
+	if err = compute.Resume(); err != nil {
+		return err
+	}
+	if err = sc.waitReregistered(ctx, compute); err != nil {
+		return err
+	}
+
+	// Wait for the compute worker to be elected into the executor committee again.
+	for i := 0; ; i++ {
+		if i >= maxReadmissionEpochs {
+			return fmt.Errorf("compute worker was not re-admitted to the committee in %d epochs", maxReadmissionEpochs)
+		}
+		if _, err = sc.AdvanceEpochs(ctx, 1); err != nil {
+			return err
+		}
+		if isMember, err = sc.isExecutorCommitteeMember(ctx, compute); err != nil {
+			return err
+		}
+		if isMember {
+			break
+		}
+	}
+	sc.Logger.Info("compute worker re-admitted to the executor committee")
+
+	if err = compute.WaitReady(ctx); err != nil {
+		return fmt.Errorf("failed to wait for compute worker: %w", err)
+	}
+
+	// Make sure the network still works.
+	return sc.RunTestClientAndCheckLogs(ctx, childEnv)
+}
+
+// waitReregistered performs epoch transitions until the given node has renewed its registration.
+func (sc *registrationExpiryImpl) waitReregistered(ctx context.Context, n *oasis.Compute) error {
+	ctrl, err := oasis.NewController(n.SocketPath())
+	if err != nil {
+		return err
+	}
+	defer ctrl.Close()
+
+	for i := 0; i < maxReadmissionEpochs; i++ {
+		if _, err = sc.AdvanceEpochs(ctx, 1); err != nil {
+			return err
+		}
+
+		epoch, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+		if err != nil {
+			return fmt.Errorf("failed to get current epoch: %w", err)
+		}
+		status, err := ctrl.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get compute worker status: %w", err)
+		}
+		reg := status.Registration
+		if reg.Descriptor == nil || !reg.LastAttemptSuccessful || beacon.EpochTime(reg.Descriptor.Expiration) <= epoch {
+			continue
+		}
+
+		if _, err = sc.Net.Controller().Registry.GetNode(ctx, &registry.IDQuery{
+			Height: consensus.HeightLatest,
+			ID:     n.NodeID,
+		}); err != nil {
+			return fmt.Errorf("re-registered compute worker not found in registry: %w", err)
+		}
+		sc.Logger.Info("compute worker re-registered",
+			"epoch", epoch,
+			"expiration", reg.Descriptor.Expiration,
+		)
+		return nil
+	}
+	return fmt.Errorf("compute worker did not re-register in %d epochs", maxReadmissionEpochs)
+}
+
+// isExecutorCommitteeMember returns true iff the given node is a member of the current executor
+// committee of the compute runtime.
+func (sc *registrationExpiryImpl) isExecutorCommitteeMember(ctx context.Context, n *oasis.Compute) (bool, error) {
+	state, err := sc.Net.Controller().Roothash.GetRuntimeState(ctx, &roothash.RuntimeRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get runtime state: %w", err)
+	}
+	if state.Committee == nil {
+		return false, fmt.Errorf("compute runtime has no executor committee")
+	}
+	return state.Committee.IsMember(n.NodeID), nil
+}
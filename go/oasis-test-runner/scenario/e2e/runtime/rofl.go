@@ -39,9 +39,13 @@ func (sc *roflImpl) Fixture() (*oasis.NetworkFixture, error) {
 	}
 
 	// Add ROFL component.
+	roflBinaries, err := sc.ResolveRuntimeBinaries(ROFLComponentBinary)
+	if err != nil {
+		return nil, err
+	}
 	f.Runtimes[1].Deployments[0].Components = append(f.Runtimes[1].Deployments[0].Components, oasis.ComponentCfg{
 		Kind:     component.ROFL,
-		Binaries: sc.ResolveRuntimeBinaries(ROFLComponentBinary),
+		Binaries: roflBinaries,
 	})
 
 	return f, nil
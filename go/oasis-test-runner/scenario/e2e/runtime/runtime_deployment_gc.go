@@ -0,0 +1,278 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+const (
+	// cfgGCEpochs is the number of epochs to wait after the upgrade before removing
+	// the old deployment.
+	cfgGCEpochs = "gc_epochs"
+	// cfgRetainOldDeployment is the flag which retains the old deployment instead of
+	// removing it.
+	cfgRetainOldDeployment = "retain_old_deployment"
+)
+
+// RuntimeDeploymentGC is the stale runtime deployment garbage collection scenario.
+//
+// Scenario:
+//   - Upgrade the compute runtime and wait for a configurable number of epochs.
+//   - Remove the old deployment from the runtime descriptor (or explicitly retain it).
+//   - Verify that compute nodes no longer advertise nor run the retired version.
+//   - Verify that a client pinned to the retired version fails to serve queries.
+//   - Verify that re-adding the retired deployment is rejected.
+//   - Verify that the runtime keeps working.
+var RuntimeDeploymentGC = func() scenario.Scenario {
+	sc := &runtimeDeploymentGCImpl{
+		Scenario: *NewScenario(
+			"runtime-deployment-gc",
+			NewTestClient().WithScenario(InsertRemoveEncWithSecretsScenario),
+		),
+	}
+	sc.Flags.Uint64(cfgGCEpochs, 2, "number of epochs to wait after the upgrade before removing the old deployment")
+	sc.Flags.Bool(cfgRetainOldDeployment, false, "retain the old deployment instead of removing it")
+
+	return sc
+}()
+
+type runtimeDeploymentGCImpl struct {
+	Scenario
+
+	upgradedRuntimeIndex int
+	pinnedClientIndex    int
+}
+
+func (sc *runtimeDeploymentGCImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.upgradedRuntimeIndex, err = sc.UpgradeComputeRuntimeFixture(f); err != nil {
+		return nil, err
+	}
+
+	// Add a client pinned to the old version. The upgrade fixture appends a copy of the runtime
+	// which only contains the old deployment.
+	sc.pinnedClientIndex = len(f.Clients)
+	f.Clients = append(f.Clients, oasis.ClientFixture{
+		RuntimeProvisioner: f.Clients[0].RuntimeProvisioner,
+		Runtimes:           []int{len(f.Runtimes) - 1},
+	})
+
+	return f, nil
+}
+
+func (sc *runtimeDeploymentGCImpl) Clone() scenario.Scenario {
+	return &runtimeDeploymentGCImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *runtimeDeploymentGCImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+	rt := sc.Net.Runtimes()[sc.upgradedRuntimeIndex]
+	oldVersion := version.MustFromString("0.0.0")
+	newVersion := version.MustFromString("0.1.0")
+
+	gcEpochs, _ := sc.Flags.GetUint64(cfgGCEpochs)
+	retain, _ := sc.Flags.GetBool(cfgRetainOldDeployment)
+
+	// Start the network and run the test client.
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+	if err := sc.RunTestClientAndCheckLogs(ctx, childEnv); err != nil {
+		return err
+	}
+
+	// Upgrade the compute runtime.
+	if err := sc.UpgradeComputeRuntime(ctx, childEnv, cli, sc.upgradedRuntimeIndex, 0); err != nil {
+		return err
+	}
+
+	// Wait for the configured number of epochs so that the nodes re-register.
+	sc.Logger.Info("waiting before collecting stale deployments",
+		"epochs", gcEpochs,
+	)
+	if _, err := sc.AdvanceEpochs(ctx, beacon.EpochTime(gcEpochs)); err != nil {
+		return err
+	}
+
+	dsc, err := sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: consensus.HeightLatest,
+		ID:     rt.ID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get runtime descriptor: %w", err)
+	}
+	if len(dsc.Deployments) != 2 {
+		return fmt.Errorf("unexpected number of deployments before collection (expected: 2 got: %d)", len(dsc.Deployments))
+	}
+
+	nonce, err := sc.TestEntityNonce(ctx)
+	if err != nil {
+		return err
+	}
+
+	expectedDeployments := 2
+	if !retain {
+		// Remove all deployments older than the upgraded one.
+		var deployments []*registry.VersionInfo
+		for _, d := range dsc.Deployments {
+			if d.Version.ToU64() < newVersion.ToU64() {
+				continue
+			}
+			deployments = append(deployments, d)
+		}
+		dsc.Deployments = deployments
+
+		sc.Logger.Info("removing stale deployments",
+			"runtime_id", rt.ID(),
+			"retained_version", newVersion,
+		)
+		if err = sc.RegisterRuntime(childEnv, cli, *dsc, nonce); err != nil {
+			return err
+		}
+		nonce++
+		expectedDeployments = 1
+	}
+
+	if err = sc.checkDeployments(ctx, rt, expectedDeployments, newVersion); err != nil {
+		return err
+	}
+
+	if !retain {
+		// Clients pinned to the old version must not be able to bring it back.
+		sc.Logger.Info("ensuring that the retired deployment cannot be re-added")
+
+		stale := rt.ToRuntimeDescriptor().Deployments[0]
+		dsc.Deployments = append([]*registry.VersionInfo{stale}, dsc.Deployments...)
+		if err = sc.RegisterRuntime(childEnv, cli, *dsc, nonce); err == nil {
+			return fmt.Errorf("re-adding the retired deployment should fail")
+		}
+	}
+
+	// Make sure that the nodes re-register and no longer advertise the retired version.
+	if _, err = sc.AdvanceEpochs(ctx, 1); err != nil {
+		return err
+	}
+	if err = sc.EnsureActiveVersionForComputeWorkers(ctx, rt, newVersion); err != nil {
+		return err
+	}
+	if err = sc.checkComputeNodesRetiredVersion(ctx, rt, oldVersion); err != nil {
+		return err
+	}
+	if err = sc.checkPinnedClient(ctx, rt); err != nil {
+		return err
+	}
+
+	// Make sure the runtime still works.
+	sc.Logger.Info("starting a second client to check if runtime works")
+	sc.Scenario.TestClient = NewTestClient().WithSeed("seed2").WithScenario(InsertRemoveEncWithSecretsScenarioV2)
+	return sc.RunTestClientAndCheckLogs(ctx, childEnv)
+}
+
+// checkDeployments verifies the number of deployments in the registered runtime descriptor and
+// that the given version is active.
+func (sc *runtimeDeploymentGCImpl) checkDeployments(ctx context.Context, rt *oasis.Runtime, n int, active version.Version) error {
+	dsc, err := sc.Net.Controller().Registry.GetRuntime(ctx, &registry.GetRuntimeQuery{
+		Height: consensus.HeightLatest,
+		ID:     rt.ID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get runtime descriptor: %w", err)
+	}
+	if len(dsc.Deployments) != n {
+		return fmt.Errorf("unexpected number of deployments (expected: %d got: %d)", n, len(dsc.Deployments))
+	}
+
+	epoch, err := sc.Net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+	activeDeployment := dsc.ActiveDeployment(epoch)
+	if activeDeployment == nil || activeDeployment.Version != active {
+		return fmt.Errorf("unexpected active deployment (expected: %s got: %+v)", active, activeDeployment)
+	}
+	return nil
+}
+
+// checkComputeNodesRetiredVersion verifies that no compute node advertises the retired version
+// of the given runtime in its registered descriptor, nor keeps it running.
+func (sc *runtimeDeploymentGCImpl) checkComputeNodesRetiredVersion(ctx context.Context, rt *oasis.Runtime, retired version.Version) error {
+	id := rt.ID()
+	for _, n := range sc.Net.ComputeWorkers() {
+		nd, err := sc.Net.Controller().Registry.GetNode(ctx, &registry.IDQuery{
+			Height: consensus.HeightLatest,
+			ID:     n.NodeID,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: failed to get node descriptor: %w", n.Name, err)
+		}
+		for _, nrt := range nd.Runtimes {
+			if nrt.ID.Equal(&id) && nrt.Version == retired {
+				return fmt.Errorf("%s: node still advertises retired version %s", n.Name, retired)
+			}
+		}
+
+		// The runtime host should have released the retired version.
+		ctrl, err := oasis.NewController(n.SocketPath())
+		if err != nil {
+			return fmt.Errorf("%s: failed to create controller: %w", n.Name, err)
+		}
+		status, err := ctrl.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: failed to query status: %w", n.Name, err)
+		}
+		cs := status.Runtimes[id].Committee
+		if cs == nil {
+			return fmt.Errorf("%s: missing status for runtime '%s'", n.Name, id)
+		}
+		if len(cs.Host.RunningVersions) == 0 {
+			return fmt.Errorf("%s: no runtime version is running", n.Name)
+		}
+		for _, v := range cs.Host.RunningVersions {
+			if v == retired {
+				return fmt.Errorf("%s: runtime host still runs retired version %s", n.Name, retired)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPinnedClient verifies that the client pinned to the retired version of the given runtime
+// refuses to serve queries as it can no longer host the active version.
+func (sc *runtimeDeploymentGCImpl) checkPinnedClient(ctx context.Context, rt *oasis.Runtime) error {
+	sc.Logger.Info("ensuring that the client pinned to the retired version fails to serve queries")
+
+	client := sc.Net.Clients()[sc.pinnedClientIndex]
+	ctrl, err := oasis.NewController(client.SocketPath())
+	if err != nil {
+		return fmt.Errorf("%s: failed to create controller: %w", client.Name, err)
+	}
+	_, err = ctrl.RuntimeClient.Query(ctx, &runtimeClient.QueryRequest{
+		RuntimeID: rt.ID(),
+		Round:     roothash.RoundLatest,
+		Method:    "get",
+		Args:      cbor.Marshal(GetCall{Key: "my_key"}),
+	})
+	if !errors.Is(err, runtimeClient.ErrNoHostedRuntime) {
+		return fmt.Errorf("%s: unexpected query error (expected: %v got: %v)", client.Name, runtimeClient.ErrNoHostedRuntime, err)
+	}
+	return nil
+}
@@ -402,6 +402,18 @@ func (sc *runtimeDynamicImpl) Run(ctx context.Context, childEnv *env.Env) error
 			default:
 				return fmt.Errorf("unexpected error while fetching runtime %s: %w", rt.ID(), err)
 			}
+
+			var status *registry.RuntimeSuspensionStatus
+			status, err = sc.Net.Controller().Registry.GetRuntimeSuspension(ctx, &registry.NamespaceQuery{
+				Height: consensus.HeightLatest,
+				ID:     rt.ID(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to query runtime %s suspension status: %w", rt.ID(), err)
+			}
+			if status.Suspended != suspended {
+				return fmt.Errorf("runtime %s suspension status mismatch (expected: %t actual: %t)", rt.ID(), suspended, status.Suspended)
+			}
 		}
 		return nil
 	}
@@ -0,0 +1,177 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+const (
+	// soakRounds is the number of runtime rounds the soak scenario runs for.
+	soakRounds = 100
+	// soakEpochRounds is the number of runtime rounds between epoch transitions.
+	soakEpochRounds = 5
+	// soakPolicyUpdateRound is the round (relative to the start) at which the key manager
+	// policy is updated.
+	soakPolicyUpdateRound = 25
+	// soakUpgradeRound is the round (relative to the start) at which the runtime is upgraded.
+	soakUpgradeRound = 50
+	// soakRoundTimeout is the time the runtime has to produce a new block.
+	soakRoundTimeout = 2 * time.Minute
+)
+
+// RuntimeUpgradeSoak is the runtime upgrade soak scenario.
+//
+// Scenario:
+//   - Start the network and a test client which continuously submits key/value transactions.
+//   - Trigger an epoch transition every few runtime rounds.
+//   - Update the key manager policy and upgrade the compute runtime mid-way.
+//   - Verify that the test client observed no errors during the entire run.
+var RuntimeUpgradeSoak scenario.Scenario = newRuntimeUpgradeSoakImpl()
+
+type runtimeUpgradeSoakImpl struct {
+	Scenario
+
+	upgradedRuntimeIndex int
+}
+
+func newRuntimeUpgradeSoakImpl() scenario.Scenario {
+	return &runtimeUpgradeSoakImpl{
+		Scenario: *NewScenario("runtime-upgrade-soak", nil),
+	}
+}
+
+func (sc *runtimeUpgradeSoakImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	if sc.upgradedRuntimeIndex, err = sc.UpgradeComputeRuntimeFixture(f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (sc *runtimeUpgradeSoakImpl) Clone() scenario.Scenario {
+	return &runtimeUpgradeSoakImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *runtimeUpgradeSoakImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	blkCh, blkSub, err := sc.Net.ClientController().Roothash.WatchBlocks(ctx, KeyValueRuntimeID)
+	if err != nil {
+		return err
+	}
+	defer blkSub.Close()
+
+	// Start constant client traffic which runs until the end of the scenario.
+	stopCh := make(chan struct{})
+	sc.TestClient = NewTestClient().WithSeed("soak").WithScenario(newSoakTestClientScenario(stopCh))
+	if err = sc.StartTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+
+	startRound, err := sc.waitSoakRound(ctx, blkCh, 0)
+	if err != nil {
+		return err
+	}
+	sc.Logger.Info("starting soak", "start_round", startRound, "rounds", soakRounds)
+
+	var (
+		nonce         uint64
+		policyUpdated bool
+		upgraded      bool
+	)
+	for round := startRound + soakEpochRounds; round <= startRound+soakRounds; round += soakEpochRounds {
+		if round, err = sc.waitSoakRound(ctx, blkCh, round); err != nil {
+			return err
+		}
+
+		switch {
+		case !policyUpdated && round >= startRound+soakPolicyUpdateRound:
+			// Bump the key manager policy serial while keeping the enclave policies.
+			if err = sc.UpdateRotationInterval(ctx, childEnv, cli, 0, nonce); err != nil {
+				return err
+			}
+			nonce++
+			policyUpdated = true
+		case !upgraded && round >= startRound+soakUpgradeRound:
+			if err = sc.UpgradeComputeRuntime(ctx, childEnv, cli, sc.upgradedRuntimeIndex, nonce); err != nil {
+				return err
+			}
+			upgraded = true
+		default:
+			if _, err = sc.AdvanceEpochs(ctx, 1); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Stop the client and make sure it did not observe any errors.
+	close(stopCh)
+	if err = sc.WaitTestClientAndCheckLogs(); err != nil {
+		return fmt.Errorf("test client failed during soak: %w", err)
+	}
+
+	return nil
+}
+
+// waitSoakRound waits until the runtime reaches at least the given round and returns the
+// round of the latest block.
+func (sc *runtimeUpgradeSoakImpl) waitSoakRound(ctx context.Context, ch <-chan *roothash.AnnotatedBlock, round uint64) (uint64, error) {
+	sc.Logger.Info("waiting for runtime round", "round", round)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case blk, ok := <-ch:
+			if !ok {
+				return 0, fmt.Errorf("runtime block channel closed")
+			}
+			if blk.Block.Header.Round >= round {
+				return blk.Block.Header.Round, nil
+			}
+		case <-time.After(soakRoundTimeout):
+			return 0, fmt.Errorf("runtime did not reach round %d", round)
+		}
+	}
+}
+
+// newSoakTestClientScenario returns a test client scenario which continuously inserts and
+// retrieves encrypted key/value pairs until the given channel is closed.
+func newSoakTestClientScenario(stopCh <-chan struct{}) TestClientScenario {
+	return func(submit func(req interface{}) error) error {
+		for iter := 0; ; iter++ {
+			select {
+			case <-stopCh:
+				return nil
+			default:
+			}
+
+			key := fmt.Sprintf("soak_key_%d", iter)
+			value := fmt.Sprintf("soak_value_%d", iter)
+			if err := submit(InsertKeyValueTx{key, value, "", 0, 0, encryptedWithSecretsTxKind}); err != nil {
+				return err
+			}
+			if err := submit(GetKeyValueTx{key, value, 0, 0, encryptedWithSecretsTxKind}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -40,7 +40,7 @@ var (
 	Runtime scenario.Scenario = NewScenario(
 		"runtime",
 		NewTestClient().WithScenario(SimpleScenario),
-	)
+	).withMetricsCheck()
 
 	// RuntimeEncryption is the basic network + client with encryption test case.
 	RuntimeEncryption scenario.Scenario = NewScenario(
@@ -78,6 +78,10 @@ type Scenario struct {
 	// If your new test needs this, your test is bad, and you should go
 	// and rewrite it so that this option isn't set.
 	debugWeakAlphaOk bool
+
+	// checkMetrics enables pull mode metrics on all nodes and validates the reported metrics
+	// after the run.
+	checkMetrics bool
 }
 
 // NewScenario creates a new base scenario for oasis-node runtime end-to-end tests.
@@ -105,6 +109,12 @@ func NewScenario(name string, testClient *TestClient) *Scenario {
 	return sc
 }
 
+// withMetricsCheck enables validation of node metrics after the run.
+func (sc *Scenario) withMetricsCheck() *Scenario {
+	sc.checkMetrics = true
+	return sc
+}
+
 func (sc *Scenario) Clone() scenario.Scenario {
 	var testClient *TestClient
 	if sc.TestClient != nil {
@@ -115,6 +125,7 @@ func (sc *Scenario) Clone() scenario.Scenario {
 		TestClient:                testClient,
 		debugNoRandomInitialEpoch: sc.debugNoRandomInitialEpoch,
 		debugWeakAlphaOk:          sc.debugWeakAlphaOk,
+		checkMetrics:              sc.checkMetrics,
 	}
 }
 
@@ -143,6 +154,14 @@ func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 	if err = runtimeProvisioner.UnmarshalText([]byte(runtimeProvisionerRaw)); err != nil {
 		return nil, fmt.Errorf("failed to parse runtime provisioner: %w", err)
 	}
+	keyManagerBinaries, err := sc.ResolveRuntimeBinaries(KeyManagerRuntimeBinary)
+	if err != nil {
+		return nil, err
+	}
+	keyValueBinaries, err := sc.ResolveRuntimeBinaries(KeyValueRuntimeBinary)
+	if err != nil {
+		return nil, err
+	}
 
 	ff := &oasis.NetworkFixture{
 		TEE: oasis.TEEFixture{
@@ -157,6 +176,9 @@ func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 			IAS: oasis.IASCfg{
 				Mock: iasMock,
 			},
+			Metrics: oasis.MetricsCfg{
+				Pull: sc.checkMetrics,
+			},
 		},
 		Entities: []oasis.EntityCfg{
 			{IsDebugTestEntity: true},
@@ -178,7 +200,7 @@ func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 						Components: []oasis.ComponentCfg{
 							{
 								Kind:     component.RONL,
-								Binaries: sc.ResolveRuntimeBinaries(KeyManagerRuntimeBinary),
+								Binaries: keyManagerBinaries,
 							},
 						},
 					},
@@ -226,7 +248,7 @@ func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 						Components: []oasis.ComponentCfg{
 							{
 								Kind:     component.RONL,
-								Binaries: sc.ResolveRuntimeBinaries(KeyValueRuntimeBinary),
+								Binaries: keyValueBinaries,
 							},
 						},
 					},
@@ -288,10 +310,27 @@ func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
 }
 
 func (sc *Scenario) Run(ctx context.Context, childEnv *env.Env) error {
-	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+	if !sc.checkMetrics {
+		if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+			return err
+		}
+		return sc.WaitTestClientAndCheckLogs()
+	}
+
+	if err := sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return fmt.Errorf("failed to initialize network: %w", err)
+	}
+	baseline, err := sc.RecordMetricsBaseline(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record metrics baseline: %w", err)
+	}
+	if err = sc.StartTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+	if err = sc.WaitTestClientAndCheckLogs(); err != nil {
 		return err
 	}
-	return sc.WaitTestClientAndCheckLogs()
+	return sc.CheckMetrics(ctx, baseline)
 }
 
 // RegisterScenarios registers all end-to-end scenarios.
@@ -326,6 +365,7 @@ func RegisterScenarios() error {
 		StorageSyncFromRegistered,
 		StorageSyncInconsistent,
 		StorageEarlyStateSync,
+		StorageMixedBackends,
 		// Sentry test.
 		Sentry,
 		// Keymanager tests.
@@ -356,6 +396,8 @@ func RegisterScenarios() error {
 		// Node shutdown test.
 		NodeShutdown,
 		OffsetRestart,
+		// Registration expiry test.
+		RegistrationExpiry,
 		// Gas fees tests.
 		GasFeesRuntimes,
 		// Runtime prune test.
@@ -368,6 +410,8 @@ func RegisterScenarios() error {
 		LateStart,
 		// RuntimeUpgrade test.
 		RuntimeUpgrade,
+		// RuntimeDeploymentGC test.
+		RuntimeDeploymentGC,
 		// HistoryReindex test.
 		HistoryReindex,
 		// TrustRoot test.
@@ -396,6 +440,12 @@ func RegisterScenarios() error {
 		// it is identical to the txsource-multi-short, only using fewer nodes
 		// due to SGX CI instance resource constrains.
 		TxSourceMultiShortSGX,
+		// Key manager access denial test. Non-default, because key manager
+		// access control is only enforced on SGX platforms.
+		KeymanagerAccessDenied,
+		// Runtime upgrade soak test. Non-default, because it runs for a long
+		// time and is meant to be used as a guard for release candidates.
+		RuntimeUpgradeSoak,
 	} {
 		if err := cmd.RegisterNondefault(s); err != nil {
 			return err
@@ -0,0 +1,179 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+// StorageMixedBackends is the scenario where compute nodes use different storage backends.
+var StorageMixedBackends scenario.Scenario = newStorageMixedBackendsImpl()
+
+// storageMixedBackends are the storage backends used by the compute nodes in a round-robin
+// fashion.
+var storageMixedBackends = []string{
+	database.BackendNameBadgerDB,
+	database.BackendNamePathBadger,
+}
+
+type storageMixedBackendsImpl struct {
+	Scenario
+}
+
+func newStorageMixedBackendsImpl() scenario.Scenario {
+	return &storageMixedBackendsImpl{
+		Scenario: *NewScenario(
+			"storage-mixed-backends",
+			NewTestClient().WithScenario(SimpleScenario),
+		),
+	}
+}
+
+func (sc *storageMixedBackendsImpl) Clone() scenario.Scenario {
+	return &storageMixedBackendsImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *storageMixedBackendsImpl) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure that all of the backends are used by at least one compute node.
+	for len(f.ComputeWorkers) < len(storageMixedBackends) {
+		f.ComputeWorkers = append(f.ComputeWorkers, f.ComputeWorkers[0])
+	}
+	for i := range f.ComputeWorkers {
+		f.ComputeWorkers[i].StorageBackend = storageMixedBackends[i%len(storageMixedBackends)]
+	}
+
+	return f, nil
+}
+
+func (sc *storageMixedBackendsImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.RunTestClientAndCheckLogs(ctx, childEnv); err != nil {
+		return err
+	}
+
+	blk, err := sc.Net.ClientController().RuntimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Round:     runtimeClient.RoundLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	latestRound := blk.Header.Round
+
+	genesis, err := sc.Net.ClientController().RuntimeClient.GetGenesisBlock(ctx, KeyValueRuntimeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch genesis block: %w", err)
+	}
+
+	// Compare the state of all rounds across all compute nodes. Since all nodes applied identical
+	// write logs, the contents of each root must be the same regardless of the backend.
+	for round := genesis.Header.Round; round <= latestRound; round++ {
+		blk, err = sc.Net.ClientController().RuntimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+			RuntimeID: KeyValueRuntimeID,
+			Round:     round,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %d: %w", round, err)
+		}
+
+		for _, root := range blk.Header.StorageRoots() {
+			if err = sc.compareRoot(ctx, root); err != nil {
+				return fmt.Errorf("round %d: %w", round, err)
+			}
+		}
+	}
+
+	sc.Logger.Info("storage state matches across backends",
+		"rounds", latestRound-genesis.Header.Round+1,
+	)
+
+	return nil
+}
+
+// compareRoot makes sure that all compute nodes have the same contents under the given root.
+func (sc *storageMixedBackendsImpl) compareRoot(ctx context.Context, root storage.Root) error {
+	var (
+		expected     hash.Hash
+		expectedNode string
+	)
+	for i, n := range sc.Net.ComputeWorkers() {
+		digest, err := sc.rootDigest(ctx, n, root)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			expected, expectedNode = digest, n.Name
+			continue
+		}
+		if !digest.Equal(&expected) {
+			return fmt.Errorf("state mismatch for root %s between nodes %s (%s) and %s (%s)",
+				root, expectedNode, storageMixedBackends[0], n.Name, storageMixedBackends[i%len(storageMixedBackends)],
+			)
+		}
+	}
+	return nil
+}
+
+// rootDigest returns a digest of all the key/value pairs under the given root as stored by the
+// given compute node, waiting for the node to sync the root if needed.
+func (sc *storageMixedBackendsImpl) rootDigest(ctx context.Context, n *oasis.Compute, root storage.Root) (hash.Hash, error) {
+	ctrl, err := oasis.NewController(n.SocketPath())
+	if err != nil {
+		return hash.Hash{}, fmt.Errorf("failed to create controller for %s: %w", n.Name, err)
+	}
+	defer ctrl.Close()
+
+	syncCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	for {
+		digest, err := func() (hash.Hash, error) {
+			tree := mkvs.NewWithRoot(ctrl.Storage, nil, root)
+			defer tree.Close()
+
+			it := tree.NewIterator(syncCtx)
+			defer it.Close()
+
+			b := hash.NewBuilder()
+			for it.Rewind(); it.Valid(); it.Next() {
+				entry := hash.NewFromBytes(it.Key(), it.Value())
+				_, _ = b.Write(entry[:])
+			}
+			if err := it.Err(); err != nil {
+				return hash.Hash{}, err
+			}
+			return b.Build(), nil
+		}()
+		if err == nil {
+			return digest, nil
+		}
+
+		sc.Logger.Warn("compute node has not synced root yet",
+			"node", n.Name,
+			"root", root,
+			"err", err,
+		)
+
+		select {
+		case <-syncCtx.Done():
+			return hash.Hash{}, fmt.Errorf("failed to fetch root %s from %s: %w", root, n.Name, err)
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -3,12 +3,14 @@ package e2e
 
 import (
 	"context"
+	"errors"
 
 	flag "github.com/spf13/pflag"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/artifacts"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/cmd"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
@@ -18,6 +20,8 @@ import (
 const (
 	// cfgNodeBinary is the path to oasis-node executable.
 	cfgNodeBinary = "node.binary"
+	// cfgArtifactsManifest is the path to the artifact manifest.
+	cfgArtifactsManifest = "artifacts.manifest"
 )
 
 // ParamsDummyScenario is a dummy instance of E2E scenario used to register global E2E flags.
@@ -46,6 +50,7 @@ func NewScenario(name string) *Scenario {
 		Flags:  env.NewParameterFlagSet(fullName, flag.ContinueOnError),
 	}
 	sc.Flags.String(cfgNodeBinary, "oasis-node", "path to the node binary")
+	sc.Flags.String(cfgArtifactsManifest, "", "path to the artifact manifest (overrides binary paths)")
 
 	return sc
 }
@@ -85,9 +90,45 @@ func (sc *Scenario) Run(context.Context, *env.Env) error {
 	return nil
 }
 
+// ResolveArtifact returns the path to the given artifact from the artifact manifest, if one is
+// configured and contains the artifact. Otherwise, the given default path is returned.
+func (sc *Scenario) ResolveArtifact(name string, defaultPath string) (string, error) {
+	manifestPath, _ := sc.Flags.GetString(cfgArtifactsManifest)
+	if manifestPath == "" {
+		return defaultPath, nil
+	}
+
+	manifest, err := artifacts.Load(manifestPath)
+	if err != nil {
+		return "", err
+	}
+	fn, err := manifest.Resolve(context.Background(), name)
+	switch {
+	case err == nil:
+		sc.Logger.Info("using artifact from manifest",
+			"name", name,
+			"path", fn,
+		)
+		return fn, nil
+	case errors.Is(err, artifacts.ErrNotFound):
+		return defaultPath, nil
+	default:
+		return "", err
+	}
+}
+
+// NodeBinary returns the path to the node binary.
+func (sc *Scenario) NodeBinary() (string, error) {
+	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
+	return sc.ResolveArtifact(artifacts.NodeBinary, nodeBinary)
+}
+
 // Fixture implements scenario.Scenario.
 func (sc *Scenario) Fixture() (*oasis.NetworkFixture, error) {
-	nodeBinary, _ := sc.Flags.GetString(cfgNodeBinary)
+	nodeBinary, err := sc.NodeBinary()
+	if err != nil {
+		return nil, err
+	}
 
 	return &oasis.NetworkFixture{
 		Network: oasis.NetworkCfg{
@@ -145,6 +186,8 @@ func RegisterScenarios() error {
 		ConsensusStateSync,
 		// Multiple seeds test.
 		MultipleSeeds,
+		// Clock skew test.
+		ClockSkew,
 		// Seed API test.
 		SeedAPI,
 		// ValidatorEquivocation test.
@@ -155,6 +198,8 @@ func RegisterScenarios() error {
 		ByzantineVRFBeaconMissing,
 		// Minimum transact balance test.
 		MinTransactBalance,
+		// Multisig entity transactions test.
+		Multisig,
 		// Consensus governance update parameters tests.
 		ChangeParametersMinCommissionRate,
 		// Consensus governance change reward schedule test.
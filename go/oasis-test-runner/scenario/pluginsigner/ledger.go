@@ -0,0 +1,403 @@
+package pluginsigner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	pluginSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// cfgNodeBinary is the path to oasis-node executable.
+	cfgNodeBinary = "node.binary"
+
+	ledgerInitialBalance = 1_000_000
+	ledgerTransfer       = 1_000
+	ledgerBurn           = 1_000
+	ledgerEscrow         = 10_000
+	ledgerReclaim        = 5_000
+	ledgerAllowance      = 2_000
+	ledgerWithdraw       = 500
+
+	ledgerRateChangeInterval = 10
+)
+
+// Ledger is the hardware wallet (Ledger) signer test case.
+//
+// The scenario expects the Ledger signer plugin, configured to use either a
+// physical device, a simulated one (e.g., Speculos) or the mock device plugin
+// used in CI. It submits the entity and staking transactions supported by
+// hardware wallets to a network and verifies their effects.
+var Ledger scenario.Scenario = newLedgerImpl()
+
+func newLedgerImpl() *ledgerImpl {
+	sc := &ledgerImpl{
+		pluginSignerImpl: *newPluginSignerImpl("ledger"),
+	}
+	_ = sc.flags.Set(cfgPluginName, "ledger")
+	_ = sc.flags.Set(cfgPluginBinary, "ledger-signer")
+	sc.flags.String(cfgNodeBinary, "oasis-node", "path to the node binary")
+
+	return sc
+}
+
+type ledgerImpl struct {
+	pluginSignerImpl
+
+	net    *oasis.Network
+	signer signature.Signer
+}
+
+func (sc *ledgerImpl) Clone() scenario.Scenario {
+	return &ledgerImpl{
+		pluginSignerImpl: sc.pluginSignerImpl.Clone(),
+	}
+}
+
+func (sc *ledgerImpl) Fixture() (*oasis.NetworkFixture, error) {
+	// The entity key is derived on the device, so it needs to be known in advance in order
+	// to fund the entity in genesis.
+	signer, err := sc.loadSigner()
+	if err != nil {
+		return nil, err
+	}
+	nodeBinary, _ := sc.flags.GetString(cfgNodeBinary)
+
+	return &oasis.NetworkFixture{
+		Network: oasis.NetworkCfg{
+			NodeBinary: nodeBinary,
+			Consensus: consensusGenesis.Genesis{
+				Parameters: consensusGenesis.Parameters{
+					GasCosts: transaction.Costs{
+						consensusGenesis.GasOpTxByte: 1,
+					},
+				},
+			},
+			StakingGenesis: &staking.Genesis{
+				Parameters: staking.ConsensusParameters{
+					DebondingInterval: 1,
+					MaxAllowances:     16,
+					CommissionScheduleRules: staking.CommissionScheduleRules{
+						RateChangeInterval: ledgerRateChangeInterval,
+						RateBoundLead:      3 * ledgerRateChangeInterval,
+						MaxRateSteps:       4,
+						MaxBoundSteps:      4,
+					},
+				},
+				TotalSupply: *quantity.NewFromUint64(ledgerInitialBalance),
+				Ledger: map[staking.Address]*staking.Account{
+					staking.NewAddress(signer.Public()): {
+						General: staking.GeneralAccount{
+							Balance: *quantity.NewFromUint64(ledgerInitialBalance),
+						},
+					},
+				},
+			},
+		},
+		Entities: []oasis.EntityCfg{
+			{IsDebugTestEntity: true},
+			{},
+		},
+		Validators: []oasis.ValidatorFixture{
+			{Entity: 1, Consensus: oasis.ConsensusFixture{SupplementarySanityInterval: 1}},
+			{Entity: 1},
+			{Entity: 1},
+		},
+		Seeds: []oasis.SeedFixture{{}},
+	}, nil
+}
+
+func (sc *ledgerImpl) Network() *oasis.Network {
+	return sc.net
+}
+
+func (sc *ledgerImpl) Init(_ *env.Env, net *oasis.Network) error {
+	sc.net = net
+	return nil
+}
+
+// loadSigner loads the entity signer from the configured plugin.
+func (sc *ledgerImpl) loadSigner() (signature.Signer, error) {
+	if sc.signer != nil {
+		return sc.signer, nil
+	}
+
+	pluginName, _ := sc.flags.GetString(cfgPluginName)
+	pluginBinary, _ := sc.flags.GetString(cfgPluginBinary)
+	pluginConfig, _ := sc.flags.GetString(cfgPluginConfig)
+	sf, err := pluginSigner.NewFactory(
+		&pluginSigner.FactoryConfig{
+			Name:   pluginName,
+			Path:   pluginBinary,
+			Config: pluginConfig,
+		},
+		// Hardware wallets only hold the entity key.
+		signature.SignerEntity,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are derived on the device, so they can only be loaded.
+	if err = sf.EnsureRole(signature.SignerEntity); err != nil {
+		return nil, fmt.Errorf("failed to EnsureRole(%v): %w", signature.SignerEntity, err)
+	}
+	signer, err := sf.Load(signature.SignerEntity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to Load(%v): %w", signature.SignerEntity, err)
+	}
+	sc.logger.Info("entity key loaded",
+		"public_key", signer.Public(),
+		"descr", signer.String(),
+	)
+	sc.signer = signer
+
+	return signer, nil
+}
+
+func (sc *ledgerImpl) Run(ctx context.Context, _ *env.Env) error {
+	signer, err := sc.loadSigner()
+	if err != nil {
+		return err
+	}
+
+	if err = sc.net.Start(); err != nil {
+		return fmt.Errorf("net Start: %w", err)
+	}
+
+	sc.logger.Info("waiting for network to come up")
+	if err = sc.net.Controller().WaitNodesRegistered(ctx, 3); err != nil {
+		return fmt.Errorf("WaitNodesRegistered: %w", err)
+	}
+
+	if err = sc.testUnsupportedOperations(signer); err != nil {
+		return err
+	}
+	if err = sc.testEntityRegistration(ctx, signer); err != nil {
+		return err
+	}
+	if err = sc.testStakingOperations(ctx, signer); err != nil {
+		return err
+	}
+	return sc.testEntityDeregistration(ctx, signer)
+}
+
+// testUnsupportedOperations makes sure that the device refuses to sign anything that the
+// hardware wallet app does not support.
+func (sc *ledgerImpl) testUnsupportedOperations(signer signature.Signer) error {
+	sc.logger.Info("testing unsupported operations")
+
+	if _, err := signer.ContextSign(registry.RegisterNodeSignatureContext, cbor.Marshal(&node.Node{})); err == nil {
+		return fmt.Errorf("signing node descriptors should fail")
+	}
+
+	tx := governance.NewSubmitProposalTx(0, nil, &governance.ProposalContent{
+		CancelUpgrade: &governance.CancelUpgradeProposal{ProposalID: 1},
+	})
+	if _, err := transaction.Sign(signer, tx); err == nil {
+		return fmt.Errorf("signing unsupported transactions should fail")
+	}
+
+	return nil
+}
+
+func (sc *ledgerImpl) testEntityRegistration(ctx context.Context, signer signature.Signer) error {
+	sc.logger.Info("testing entity registration")
+
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        signer.Public(),
+	}
+	sigEnt, err := entity.SignEntity(signer, registry.RegisterEntitySignatureContext, &ent)
+	if err != nil {
+		return fmt.Errorf("failed to sign entity descriptor: %w", err)
+	}
+	if err = sc.submitTx(ctx, signer, registry.NewRegisterEntityTx(0, nil, sigEnt)); err != nil {
+		return err
+	}
+
+	registered, err := sc.net.Controller().Registry.GetEntity(ctx, &registry.IDQuery{
+		ID:     signer.Public(),
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query entity: %w", err)
+	}
+	if !registered.ID.Equal(ent.ID) {
+		return fmt.Errorf("registered entity mismatch")
+	}
+
+	return nil
+}
+
+func (sc *ledgerImpl) testStakingOperations(ctx context.Context, signer signature.Signer) error {
+	sc.logger.Info("testing staking operations")
+
+	ownAddr := staking.NewAddress(signer.Public())
+	otherSigner := memorySigner.NewTestSigner(sc.name + ": other account")
+	otherAddr := staking.NewAddress(otherSigner.Public())
+
+	epoch, err := sc.net.Controller().Beacon.GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query epoch: %w", err)
+	}
+	// Commission schedule changes must be aligned to the rate change interval.
+	start := (epoch/ledgerRateChangeInterval + 2) * ledgerRateChangeInterval
+
+	for _, tx := range []*transaction.Transaction{
+		staking.NewTransferTx(1, nil, &staking.Transfer{
+			To:     otherAddr,
+			Amount: *quantity.NewFromUint64(ledgerTransfer),
+		}),
+		staking.NewBurnTx(2, nil, &staking.Burn{
+			Amount: *quantity.NewFromUint64(ledgerBurn),
+		}),
+		staking.NewAddEscrowTx(3, nil, &staking.Escrow{
+			Account: ownAddr,
+			Amount:  *quantity.NewFromUint64(ledgerEscrow),
+		}),
+		staking.NewReclaimEscrowTx(4, nil, &staking.ReclaimEscrow{
+			Account: ownAddr,
+			Shares:  *quantity.NewFromUint64(ledgerReclaim),
+		}),
+		staking.NewAmendCommissionScheduleTx(5, nil, &staking.AmendCommissionSchedule{
+			Amendment: staking.CommissionSchedule{
+				Rates: []staking.CommissionRateStep{{
+					Start: start,
+					Rate:  *quantity.NewFromUint64(5_000),
+				}},
+				Bounds: []staking.CommissionRateBoundStep{{
+					Start:   start,
+					RateMin: *quantity.NewFromUint64(0),
+					RateMax: *quantity.NewFromUint64(10_000),
+				}},
+			},
+		}),
+		staking.NewAllowTx(6, nil, &staking.Allow{
+			Beneficiary:  otherAddr,
+			AmountChange: *quantity.NewFromUint64(ledgerAllowance),
+		}),
+	} {
+		if err = sc.submitTx(ctx, signer, tx); err != nil {
+			return err
+		}
+	}
+
+	// Withdraw from an allowance given by another account.
+	if err = sc.submitTx(ctx, otherSigner, staking.NewAllowTx(0, nil, &staking.Allow{
+		Beneficiary:  ownAddr,
+		AmountChange: *quantity.NewFromUint64(ledgerWithdraw),
+	})); err != nil {
+		return err
+	}
+	if err = sc.submitTx(ctx, signer, staking.NewWithdrawTx(7, nil, &staking.Withdraw{
+		From:   otherAddr,
+		Amount: *quantity.NewFromUint64(ledgerWithdraw),
+	})); err != nil {
+		return err
+	}
+
+	// Make sure all transactions have been executed.
+	acct, err := sc.account(ctx, ownAddr)
+	if err != nil {
+		return err
+	}
+	expected := quantity.NewFromUint64(ledgerInitialBalance - ledgerTransfer - ledgerBurn - ledgerEscrow + ledgerWithdraw)
+	if acct.General.Balance.Cmp(expected) != 0 {
+		return fmt.Errorf("entity balance %v should be %v", acct.General.Balance, expected)
+	}
+	expected = quantity.NewFromUint64(ledgerEscrow - ledgerReclaim)
+	if acct.Escrow.Active.Balance.Cmp(expected) != 0 {
+		return fmt.Errorf("entity active escrow balance %v should be %v", acct.Escrow.Active.Balance, expected)
+	}
+	if len(acct.Escrow.CommissionSchedule.Rates) != 1 {
+		return fmt.Errorf("entity commission schedule should be amended")
+	}
+	expected = quantity.NewFromUint64(ledgerAllowance)
+	if allowance := acct.General.Allowances[otherAddr]; allowance.Cmp(expected) != 0 {
+		return fmt.Errorf("entity allowance %v should be %v", allowance, expected)
+	}
+
+	otherAcct, err := sc.account(ctx, otherAddr)
+	if err != nil {
+		return err
+	}
+	expected = quantity.NewFromUint64(ledgerTransfer - ledgerWithdraw)
+	if otherAcct.General.Balance.Cmp(expected) != 0 {
+		return fmt.Errorf("other account balance %v should be %v", otherAcct.General.Balance, expected)
+	}
+
+	return nil
+}
+
+func (sc *ledgerImpl) testEntityDeregistration(ctx context.Context, signer signature.Signer) error {
+	sc.logger.Info("testing entity deregistration")
+
+	if err := sc.submitTx(ctx, signer, registry.NewDeregisterEntityTx(8, nil)); err != nil {
+		return err
+	}
+
+	_, err := sc.net.Controller().Registry.GetEntity(ctx, &registry.IDQuery{
+		ID:     signer.Public(),
+		Height: consensus.HeightLatest,
+	})
+	if !errors.Is(err, registry.ErrNoSuchEntity) {
+		return fmt.Errorf("entity should be deregistered (err: %v)", err)
+	}
+
+	acct, err := sc.account(ctx, staking.NewAddress(signer.Public()))
+	if err != nil {
+		return err
+	}
+	if acct.General.Nonce != 9 {
+		return fmt.Errorf("entity nonce %d should be 9", acct.General.Nonce)
+	}
+
+	return nil
+}
+
+// submitTx signs the given transaction and submits it to the network.
+func (sc *ledgerImpl) submitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	tx.Fee = &transaction.Fee{Gas: 10_000}
+
+	sigTx, err := transaction.Sign(signer, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign %s transaction: %w", tx.Method, err)
+	}
+	if err = sc.net.Controller().Consensus.SubmitTx(ctx, sigTx); err != nil {
+		return fmt.Errorf("failed to submit %s transaction: %w", tx.Method, err)
+	}
+
+	sc.logger.Info("transaction submitted",
+		"method", tx.Method,
+		"nonce", tx.Nonce,
+	)
+	return nil
+}
+
+func (sc *ledgerImpl) account(ctx context.Context, addr staking.Address) (*staking.Account, error) {
+	acct, err := sc.net.Controller().Staking.Account(ctx, &staking.OwnerQuery{
+		Owner:  addr,
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account %s: %w", addr, err)
+	}
+	return acct, nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	// claOasis is the APDU class of the Oasis app.
+	claOasis = 0x05
+
+	// Instructions supported by the Oasis app.
+	insGetVersion     = 0x00
+	insGetAddrEd25519 = 0x01
+	insSignEd25519    = 0x02
+
+	// Signing payload chunk types.
+	payloadChunkInit = 0x00
+	payloadChunkAdd  = 0x01
+	payloadChunkLast = 0x02
+
+	// maxAPDUPayloadSize is the maximum size of the APDU command payload.
+	maxAPDUPayloadSize = 250
+
+	// Status words.
+	swOK                = 0x9000
+	swWrongLength       = 0x6700
+	swDataInvalid       = 0x6984
+	swConditionsNotMet  = 0x6985
+	swCommandNotAllowed = 0x6986
+	swInsNotSupported   = 0x6d00
+	swClaNotSupported   = 0x6e00
+
+	// hardenedPathComponent marks hardened derivation path components.
+	hardenedPathComponent = 0x80000000
+)
+
+var (
+	// appVersion is the emulated Oasis app version.
+	appVersion = []byte{2, 3, 0}
+
+	// txContextPrefix is the prefix of chain domain separated transaction signature contexts.
+	txContextPrefix = string(transaction.SignatureContext) + " for chain "
+
+	// supportedMethods are the transaction methods that the Oasis app can parse and display.
+	supportedMethods = map[transaction.MethodName]bool{
+		staking.MethodTransfer:                true,
+		staking.MethodBurn:                    true,
+		staking.MethodAddEscrow:               true,
+		staking.MethodReclaimEscrow:           true,
+		staking.MethodAmendCommissionSchedule: true,
+		staking.MethodAllow:                   true,
+		staking.MethodWithdraw:                true,
+		registry.MethodRegisterEntity:         true,
+		registry.MethodDeregisterEntity:       true,
+		governance.MethodCastVote:             true,
+	}
+)
+
+// mockDevice emulates a Ledger device running the Oasis app behind an APDU transport.
+//
+// Like the real app, it derives keys from the device seed, only signs consensus transactions
+// it is able to parse and entity descriptors, and refuses everything else.
+type mockDevice struct {
+	seed []byte
+
+	// Pending multi-chunk signing request.
+	signPath []uint32
+	signData []byte
+}
+
+// Exchange sends a single APDU command to the device and returns its response, without the
+// trailing status word.
+func (d *mockDevice) Exchange(apdu []byte) ([]byte, error) {
+	resp, sw := d.handle(apdu)
+	if sw != swOK {
+		return nil, fmt.Errorf("ledger: APDU command failed with status 0x%04x", sw)
+	}
+	return resp, nil
+}
+
+func (d *mockDevice) handle(apdu []byte) ([]byte, uint16) {
+	if len(apdu) < 5 || int(apdu[4]) != len(apdu)-5 {
+		return nil, swWrongLength
+	}
+	cla, ins, p1, data := apdu[0], apdu[1], apdu[2], apdu[5:]
+	if cla != claOasis {
+		return nil, swClaNotSupported
+	}
+
+	switch ins {
+	case insGetVersion:
+		return appVersion, swOK
+	case insGetAddrEd25519:
+		path, err := decodePath(data)
+		if err != nil {
+			return nil, swDataInvalid
+		}
+		signer, err := d.deriveSigner(path)
+		if err != nil {
+			return nil, swConditionsNotMet
+		}
+		pk := signer.Public()
+		return pk[:], swOK
+	case insSignEd25519:
+		return d.handleSign(p1, data)
+	default:
+		return nil, swInsNotSupported
+	}
+}
+
+func (d *mockDevice) handleSign(p1 byte, data []byte) ([]byte, uint16) {
+	switch p1 {
+	case payloadChunkInit:
+		path, err := decodePath(data)
+		if err != nil {
+			return nil, swDataInvalid
+		}
+		d.signPath, d.signData = path, nil
+		return nil, swOK
+	case payloadChunkAdd, payloadChunkLast:
+		if d.signPath == nil {
+			return nil, swConditionsNotMet
+		}
+		d.signData = append(d.signData, data...)
+		if p1 == payloadChunkAdd {
+			return nil, swOK
+		}
+	default:
+		return nil, swDataInvalid
+	}
+
+	path, payload := d.signPath, d.signData
+	d.signPath, d.signData = nil, nil
+
+	// The payload is the length-prefixed signature context followed by the message.
+	if len(payload) < 1 || len(payload) < 1+int(payload[0]) {
+		return nil, swDataInvalid
+	}
+	rawContext, message := string(payload[1:1+payload[0]]), payload[1+payload[0]:]
+	if err := verifySignRequest(rawContext, message); err != nil {
+		return nil, swCommandNotAllowed
+	}
+
+	signer, err := d.deriveSigner(path)
+	if err != nil {
+		return nil, swConditionsNotMet
+	}
+	sig, err := signer.ContextSign(signature.Context(rawContext), message)
+	if err != nil {
+		return nil, swConditionsNotMet
+	}
+	return sig, swOK
+}
+
+// deriveSigner derives the signer for the given ADR 0008 derivation path.
+func (d *mockDevice) deriveSigner(path []uint32) (signature.Signer, error) {
+	if len(path) != 3 || path[0] != 44|hardenedPathComponent || path[1] != 474|hardenedPathComponent {
+		return nil, fmt.Errorf("ledger: unsupported derivation path")
+	}
+	for _, c := range path {
+		if c&hardenedPathComponent == 0 {
+			return nil, fmt.Errorf("ledger: only hardened derivation is supported")
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(d.seed)
+	_ = binary.Write(&buf, binary.LittleEndian, path)
+	seed := sha512.Sum512_256(buf.Bytes())
+
+	return memorySigner.NewFromSeed(seed[:])
+}
+
+// verifySignRequest checks whether the Oasis app would sign the given message.
+func verifySignRequest(rawContext string, message []byte) error {
+	switch {
+	case rawContext == string(registry.RegisterEntitySignatureContext):
+		var ent entity.Entity
+		return cbor.Unmarshal(message, &ent)
+	case strings.HasPrefix(rawContext, txContextPrefix):
+		var tx transaction.Transaction
+		if err := cbor.Unmarshal(message, &tx); err != nil {
+			return err
+		}
+		if !supportedMethods[tx.Method] {
+			return fmt.Errorf("ledger: unsupported transaction method: %s", tx.Method)
+		}
+		return nil
+	default:
+		return fmt.Errorf("ledger: unsupported signature context: %s", rawContext)
+	}
+}
+
+func encodePath(path []uint32) []byte {
+	buf := []byte{byte(len(path))}
+	for _, c := range path {
+		buf = binary.LittleEndian.AppendUint32(buf, c)
+	}
+	return buf
+}
+
+func decodePath(data []byte) ([]uint32, error) {
+	if len(data) < 1 || len(data) != 1+4*int(data[0]) {
+		return nil, fmt.Errorf("ledger: malformed derivation path")
+	}
+	path := make([]uint32, data[0])
+	for i := range path {
+		path[i] = binary.LittleEndian.Uint32(data[1+4*i:])
+	}
+	return path, nil
+}
+
+// newAPDU constructs an APDU command for the Oasis app.
+func newAPDU(ins, p1 byte, data []byte) []byte {
+	return append([]byte{claOasis, ins, p1, 0, byte(len(data))}, data...)
+}
@@ -0,0 +1,151 @@
+// Package main implements an oasis-node signer plugin backed by a mock Ledger device.
+//
+// The plugin talks to the device over APDU commands the same way the Ledger signer plugin
+// does, but the device is emulated in-process so that hardware wallet flows can be tested
+// without a physical device or a simulator.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	pluginSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
+)
+
+// defaultDeviceSeed is the seed of the mock device used if none is configured.
+const defaultDeviceSeed = "oasis-core mock ledger device"
+
+// transport is an APDU transport to a Ledger device.
+type transport interface {
+	// Exchange sends a single APDU command to the device and returns its response.
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+type mockLedgerPlugin struct {
+	device transport
+	path   []uint32
+
+	roles     []signature.SignerRole
+	publicKey *signature.PublicKey
+}
+
+// Initialize initializes the plugin.
+//
+// The configuration is a comma-separated list of key=value pairs:
+//   - seed: the seed of the mock device,
+//   - index: the account index used in the ADR 0008 derivation path.
+func (pl *mockLedgerPlugin) Initialize(config string, roles ...signature.SignerRole) error {
+	seed, index := defaultDeviceSeed, uint64(0)
+	for _, kv := range strings.Split(config, ",") {
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("ledger: malformed configuration: %s", kv)
+		}
+		switch k {
+		case "seed":
+			seed = v
+		case "index":
+			var err error
+			if index, err = strconv.ParseUint(v, 10, 31); err != nil {
+				return fmt.Errorf("ledger: malformed account index: %w", err)
+			}
+		default:
+			return fmt.Errorf("ledger: unknown configuration key: %s", k)
+		}
+	}
+
+	// Hardware wallets only hold the entity key.
+	for _, role := range roles {
+		if role != signature.SignerEntity {
+			return fmt.Errorf("ledger: unsupported role: %v", role)
+		}
+	}
+
+	pl.device = &mockDevice{seed: []byte(seed)}
+	pl.path = []uint32{
+		44 | hardenedPathComponent,
+		474 | hardenedPathComponent,
+		uint32(index) | hardenedPathComponent,
+	}
+	pl.roles = roles
+
+	if _, err := pl.device.Exchange(newAPDU(insGetVersion, 0, nil)); err != nil {
+		return fmt.Errorf("ledger: failed to connect to device: %w", err)
+	}
+
+	return nil
+}
+
+func (pl *mockLedgerPlugin) Load(role signature.SignerRole, mustGenerate bool) error {
+	if role != signature.SignerEntity {
+		return signature.ErrRoleMismatch
+	}
+	if mustGenerate {
+		return fmt.Errorf("ledger: keys can only be derived on the device")
+	}
+
+	rsp, err := pl.device.Exchange(newAPDU(insGetAddrEd25519, 0, encodePath(pl.path)))
+	if err != nil {
+		return fmt.Errorf("ledger: failed to get public key: %w", err)
+	}
+	var pk signature.PublicKey
+	if err = pk.UnmarshalBinary(rsp); err != nil {
+		return fmt.Errorf("ledger: malformed public key: %w", err)
+	}
+	pl.publicKey = &pk
+
+	return nil
+}
+
+func (pl *mockLedgerPlugin) Public(role signature.SignerRole) (signature.PublicKey, error) {
+	if role != signature.SignerEntity || pl.publicKey == nil {
+		return signature.PublicKey{}, signature.ErrNotExist
+	}
+	return *pl.publicKey, nil
+}
+
+func (pl *mockLedgerPlugin) ContextSign(role signature.SignerRole, rawContext signature.Context, message []byte) ([]byte, error) {
+	if role != signature.SignerEntity || pl.publicKey == nil {
+		return nil, signature.ErrNotExist
+	}
+	if len(rawContext) > 255 {
+		return nil, fmt.Errorf("ledger: signature context too long")
+	}
+
+	// Send the derivation path first, followed by the length-prefixed context and the message,
+	// split into chunks that fit into a single APDU command.
+	if _, err := pl.device.Exchange(newAPDU(insSignEd25519, payloadChunkInit, encodePath(pl.path))); err != nil {
+		return nil, fmt.Errorf("ledger: failed to start signing: %w", err)
+	}
+
+	payload := append([]byte{byte(len(rawContext))}, rawContext...)
+	payload = append(payload, message...)
+	for {
+		chunk, p1 := payload, byte(payloadChunkLast)
+		if len(chunk) > maxAPDUPayloadSize {
+			chunk, p1 = chunk[:maxAPDUPayloadSize], payloadChunkAdd
+		}
+		payload = payload[len(chunk):]
+
+		rsp, err := pl.device.Exchange(newAPDU(insSignEd25519, p1, chunk))
+		if err != nil {
+			return nil, fmt.Errorf("ledger: failed to sign: %w", err)
+		}
+		if p1 == payloadChunkLast {
+			return rsp, nil
+		}
+	}
+}
+
+func main() {
+	// Signer plugins use raw contexts.
+	signature.UnsafeAllowUnregisteredContexts()
+
+	var impl mockLedgerPlugin
+	pluginSigner.Serve("ledger", &impl)
+}
@@ -85,6 +85,8 @@ func RegisterScenarios() error {
 	for _, s := range []scenario.Scenario{
 		// Basic plugin signer test case.
 		Basic,
+		// Hardware wallet (Ledger) signer test case.
+		Ledger,
 	} {
 		if err := cmd.Register(s); err != nil {
 			return err
@@ -50,6 +50,21 @@ type Status struct {
 	Topics map[string]int `json:"topics"`
 }
 
+// PeerInfo is information about a connected P2P peer.
+type PeerInfo struct {
+	// ID is the peer ID.
+	ID peer.ID `json:"id"`
+
+	// Addresses is a list of remote addresses of the peer's open connections.
+	Addresses []string `json:"addresses"`
+
+	// Outbound is true when the oldest open connection to the peer was dialed by the local node.
+	Outbound bool `json:"outbound"`
+
+	// ConnectedSince is the time the oldest open connection to the peer was established.
+	ConnectedSince time.Time `json:"connected_since"`
+}
+
 // Service is a P2P node service interface.
 type Service interface {
 	service.BackgroundService
@@ -63,6 +78,9 @@ type Service interface {
 	// Peers returns a list of connected P2P peers for the given runtime.
 	Peers(runtimeID common.Namespace) []string
 
+	// RuntimePeers returns information about connected P2P peers for the given runtime.
+	RuntimePeers(runtimeID common.Namespace) []*PeerInfo
+
 	// Publish publishes the given message to the given topic.
 	Publish(ctx context.Context, topic string, msg interface{})
 
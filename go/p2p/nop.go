@@ -55,6 +55,11 @@ func (p *nopP2P) Peers(common.Namespace) []string {
 	return nil
 }
 
+// Implements api.Service.
+func (p *nopP2P) RuntimePeers(common.Namespace) []*api.PeerInfo {
+	return nil
+}
+
 // Implements api.Service.
 func (p *nopP2P) Publish(context.Context, string, interface{}) {
 }
@@ -12,6 +12,7 @@ import (
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	"github.com/multiformats/go-multiaddr"
@@ -196,17 +197,8 @@ func (p *p2p) Addresses() []node.Address {
 
 // Implements api.Service.
 func (p *p2p) Peers(runtimeID common.Namespace) []string {
-	allPeers := p.pubsub.ListPeers(protocol.NewTopicKindCommitteeID(p.chainContext, runtimeID))
-	allPeers = append(allPeers, p.pubsub.ListPeers(protocol.NewTopicKindTxID(p.chainContext, runtimeID))...)
-
 	var peers []string
-	peerMap := make(map[core.PeerID]bool)
-	for _, peerID := range allPeers {
-		if peerMap[peerID] {
-			continue
-		}
-		peerMap[peerID] = true
-
+	for _, peerID := range p.runtimePeerIDs(runtimeID) {
 		addrs := p.host.Peerstore().Addrs(peerID)
 		if len(addrs) == 0 {
 			continue
@@ -224,6 +216,48 @@ func (p *p2p) Peers(runtimeID common.Namespace) []string {
 	return peers
 }
 
+// Implements api.Service.
+func (p *p2p) RuntimePeers(runtimeID common.Namespace) []*api.PeerInfo {
+	var peers []*api.PeerInfo
+	for _, peerID := range p.runtimePeerIDs(runtimeID) {
+		conns := p.host.Network().ConnsToPeer(peerID)
+		if len(conns) == 0 {
+			continue
+		}
+
+		info := &api.PeerInfo{
+			ID: peerID,
+		}
+		for _, conn := range conns {
+			stat := conn.Stat()
+			if info.ConnectedSince.IsZero() || stat.Opened.Before(info.ConnectedSince) {
+				info.ConnectedSince = stat.Opened
+				info.Outbound = stat.Direction == network.DirOutbound
+			}
+			info.Addresses = append(info.Addresses, conn.RemoteMultiaddr().String())
+		}
+		peers = append(peers, info)
+	}
+	return peers
+}
+
+// runtimePeerIDs returns a deduplicated list of peers subscribed to any of the runtime's topics.
+func (p *p2p) runtimePeerIDs(runtimeID common.Namespace) []core.PeerID {
+	allPeers := p.pubsub.ListPeers(protocol.NewTopicKindCommitteeID(p.chainContext, runtimeID))
+	allPeers = append(allPeers, p.pubsub.ListPeers(protocol.NewTopicKindTxID(p.chainContext, runtimeID))...)
+
+	var peerIDs []core.PeerID
+	peerMap := make(map[core.PeerID]bool)
+	for _, peerID := range allPeers {
+		if peerMap[peerID] {
+			continue
+		}
+		peerMap[peerID] = true
+		peerIDs = append(peerIDs, peerID)
+	}
+	return peerIDs
+}
+
 func filterGloballyReachableAddresses(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 	ret := make([]multiaddr.Multiaddr, 0, len(addrs))
 	for _, addr := range addrs {
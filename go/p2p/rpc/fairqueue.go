@@ -0,0 +1,196 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// FairQueueConfig is the configuration of a per-peer fair request queue.
+type FairQueueConfig struct {
+	// MaxConcurrent is the maximum number of requests that can be processed
+	// concurrently.
+	MaxConcurrent int
+	// MaxQueuedPerPeer is the maximum number of requests that can be waiting
+	// in the queue for a single peer, multiplied by the peer's weight. Any
+	// further requests are rejected with ErrQueueFull until some of the queued
+	// requests are admitted.
+	MaxQueuedPerPeer int
+	// PeerWeights are the weights of specific peers. A peer with weight N gets
+	// up to N requests admitted in each round, while peers without a weight
+	// get one.
+	PeerWeights map[core.PeerID]int
+}
+
+type peerQueue struct {
+	waiting []chan struct{}
+	served  int
+}
+
+// FairQueue is a per-peer fair queue used for request admission.
+//
+// When the number of requests being processed reaches the configured limit,
+// further requests are queued per requesting peer and admitted in weighted
+// round-robin order across peers, so that a single aggressive peer cannot
+// starve all the others.
+type FairQueue struct {
+	mu sync.Mutex
+
+	cfg FairQueueConfig
+
+	active int
+	peers  map[core.PeerID]*peerQueue
+	ring   []core.PeerID
+	next   int
+}
+
+// Acquire waits until a request from the given peer can be processed.
+//
+// On success, the caller must call Release once the request has been
+// processed.
+func (q *FairQueue) Acquire(ctx context.Context, peerID core.PeerID) error {
+	q.mu.Lock()
+	if q.active < q.cfg.MaxConcurrent && len(q.ring) == 0 {
+		q.active++
+		q.mu.Unlock()
+		return nil
+	}
+
+	pq := q.peers[peerID]
+	if pq == nil {
+		pq = &peerQueue{}
+		q.peers[peerID] = pq
+	}
+	if len(pq.waiting) >= q.cfg.MaxQueuedPerPeer*q.weight(peerID) {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	ch := make(chan struct{})
+	pq.waiting = append(pq.waiting, ch)
+	if len(pq.waiting) == 1 {
+		q.ring = append(q.ring, peerID)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, wch := range pq.waiting {
+		if wch != ch {
+			continue
+		}
+		pq.waiting = append(pq.waiting[:i], pq.waiting[i+1:]...)
+		if len(pq.waiting) == 0 {
+			q.removePeerLocked(peerID)
+		}
+		return ctx.Err()
+	}
+
+	// The request has been admitted concurrently with the context being
+	// canceled, make sure to give the slot to someone else.
+	q.active--
+	q.dispatchLocked()
+	return ctx.Err()
+}
+
+// Release releases a slot previously obtained via Acquire.
+func (q *FairQueue) Release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.active--
+	q.dispatchLocked()
+}
+
+func (q *FairQueue) removePeerLocked(peerID core.PeerID) {
+	for i, id := range q.ring {
+		if id != peerID {
+			continue
+		}
+		q.ring = append(q.ring[:i], q.ring[i+1:]...)
+		if i < q.next {
+			q.next--
+		}
+		break
+	}
+	if q.next >= len(q.ring) {
+		q.next = 0
+	}
+	delete(q.peers, peerID)
+}
+
+func (q *FairQueue) dispatchLocked() {
+	for q.active < q.cfg.MaxConcurrent && len(q.ring) > 0 {
+		peerID := q.ring[q.next]
+		pq := q.peers[peerID]
+
+		ch := pq.waiting[0]
+		pq.waiting = pq.waiting[1:]
+		pq.served++
+		q.active++
+		close(ch)
+
+		if len(pq.waiting) == 0 {
+			q.removePeerLocked(peerID)
+		} else if pq.served >= q.weight(peerID) {
+			// The peer has used up its share for this round.
+			pq.served = 0
+			q.next = (q.next + 1) % len(q.ring)
+		}
+	}
+}
+
+func (q *FairQueue) weight(peerID core.PeerID) int {
+	if w := q.cfg.PeerWeights[peerID]; w > 1 {
+		return w
+	}
+	return 1
+}
+
+// NewFairQueue creates a new per-peer fair queue.
+func NewFairQueue(cfg FairQueueConfig) *FairQueue {
+	if cfg.MaxConcurrent < 1 {
+		cfg.MaxConcurrent = 1
+	}
+	return &FairQueue{
+		cfg:   cfg,
+		peers: make(map[core.PeerID]*peerQueue),
+	}
+}
+
+type fairService struct {
+	Service
+
+	queue *FairQueue
+}
+
+func (s *fairService) HandleRequest(ctx context.Context, method string, body cbor.RawMessage) (interface{}, error) {
+	peerID, _ := PeerIDFromContext(ctx)
+	if err := s.queue.Acquire(ctx, peerID); err != nil {
+		return nil, err
+	}
+	defer s.queue.Release()
+
+	return s.Service.HandleRequest(ctx, method, body)
+}
+
+// NewFairService wraps the given service so that incoming requests are
+// admitted through the given per-peer fair queue.
+//
+// The same queue may be shared by multiple services in order to bound the
+// total number of requests being processed concurrently.
+func NewFairService(srv Service, queue *FairQueue) Service {
+	return &fairService{
+		Service: srv,
+		queue:   queue,
+	}
+}
@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairQueue(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		peerA = core.PeerID("a")
+		peerB = core.PeerID("b")
+	)
+
+	q := NewFairQueue(FairQueueConfig{
+		MaxConcurrent:    1,
+		MaxQueuedPerPeer: 2,
+	})
+	ctx := context.Background()
+
+	// The first request is admitted immediately.
+	err := q.Acquire(ctx, peerA)
+	require.NoError(err, "Acquire")
+
+	// Further requests get queued.
+	admitted := make(chan core.PeerID, 4)
+	acquire := func(peerID core.PeerID, queued int) {
+		go func() {
+			if err := q.Acquire(ctx, peerID); err == nil {
+				admitted <- peerID
+			}
+		}()
+		// Make sure requests are queued in a deterministic order.
+		require.Eventually(func() bool {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			pq := q.peers[peerID]
+			return pq != nil && len(pq.waiting) == queued
+		}, time.Second, time.Millisecond)
+	}
+	acquire(peerA, 1)
+	acquire(peerA, 2)
+	acquire(peerB, 1)
+
+	// Peer A has too many queued requests.
+	err = q.Acquire(ctx, peerA)
+	require.ErrorIs(err, ErrQueueFull, "Acquire should fail when the peer queue is full")
+
+	// Requests should be admitted in round-robin order across peers.
+	var order []core.PeerID
+	for i := 0; i < 3; i++ {
+		q.Release()
+		select {
+		case peerID := <-admitted:
+			order = append(order, peerID)
+		case <-time.After(time.Second):
+			require.FailNow("request should be admitted")
+		}
+	}
+	require.EqualValues([]core.PeerID{peerA, peerB, peerA}, order, "requests should be admitted fairly")
+	q.Release()
+
+	// Canceled requests should be removed from the queue.
+	err = q.Acquire(ctx, peerA)
+	require.NoError(err, "Acquire")
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = q.Acquire(cctx, peerB)
+	require.ErrorIs(err, context.DeadlineExceeded, "Acquire should fail on canceled context")
+	require.Empty(q.ring, "canceled requests should be removed from the queue")
+	q.Release()
+	require.Equal(0, q.active, "all slots should be released")
+}
+
+func TestFairQueueWeights(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		peerA = core.PeerID("a")
+		peerB = core.PeerID("b")
+		peerC = core.PeerID("c")
+	)
+
+	q := NewFairQueue(FairQueueConfig{
+		MaxConcurrent:    1,
+		MaxQueuedPerPeer: 4,
+		PeerWeights: map[core.PeerID]int{
+			peerA: 3,
+			peerB: 1,
+		},
+	})
+	ctx := context.Background()
+
+	err := q.Acquire(ctx, peerA)
+	require.NoError(err, "Acquire")
+
+	// Queue more requests than can be served in a couple of rounds.
+	admitted := make(chan core.PeerID, 32)
+	queued := map[core.PeerID]int{peerA: 12, peerB: 4, peerC: 4}
+	for _, peerID := range []core.PeerID{peerA, peerB, peerC} {
+		for i := 1; i <= queued[peerID]; i++ {
+			go func() {
+				if err := q.Acquire(ctx, peerID); err == nil {
+					admitted <- peerID
+				}
+			}()
+			require.Eventually(func() bool {
+				q.mu.Lock()
+				defer q.mu.Unlock()
+				pq := q.peers[peerID]
+				return pq != nil && len(pq.waiting) == i
+			}, time.Second, time.Millisecond)
+		}
+	}
+
+	// The queue limit scales with the weight.
+	err = q.Acquire(ctx, peerB)
+	require.ErrorIs(err, ErrQueueFull, "Acquire should fail when the peer queue is full")
+
+	// Requests should be admitted in proportion to the peer weights.
+	var order []core.PeerID
+	for i := 0; i < 20; i++ {
+		q.Release()
+		select {
+		case peerID := <-admitted:
+			order = append(order, peerID)
+		case <-time.After(time.Second):
+			require.FailNow("request should be admitted")
+		}
+	}
+	require.EqualValues([]core.PeerID{peerA, peerA, peerA, peerB, peerC}, order[:5], "requests should be admitted by weight")
+	served := make(map[core.PeerID]int)
+	for _, peerID := range order[:15] {
+		served[peerID]++
+	}
+	require.Equal(map[core.PeerID]int{peerA: 9, peerB: 3, peerC: 3}, served, "requests should be served proportionally")
+	q.Release()
+	require.Equal(0, q.active, "all slots should be released")
+}
@@ -16,6 +16,10 @@ var (
 
 	// ErrBadRequest is an error raised when a given request is malformed.
 	ErrBadRequest = errors.New(ModuleName, 2, "rpc: bad request")
+
+	// ErrQueueFull is an error raised when there are too many requests from
+	// a given peer queued for processing.
+	ErrQueueFull = errors.New(ModuleName, 3, "rpc: request queue full")
 )
 
 // Request is a request sent by the client.
@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -120,6 +121,14 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrMultisigUnauthorized is the error returned when a multi-signed transaction is not
+	// authorized by the entity's multisig policy.
+	ErrMultisigUnauthorized = errors.New(ModuleName, 20, "registry: multisig transaction not authorized")
+
+	// ErrRuntimeNotPaused is the error returned when trying to resume a runtime that is not
+	// paused.
+	ErrRuntimeNotPaused = errors.New(ModuleName, 21, "registry: runtime is not paused")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
@@ -132,6 +141,10 @@ var (
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", Runtime{})
 	// MethodProveFreshness is the method name for freshness proofs.
 	MethodProveFreshness = transaction.NewMethodName(ModuleName, "ProveFreshness", [32]byte{})
+	// MethodPauseRuntime is the method name for pausing runtimes.
+	MethodPauseRuntime = transaction.NewMethodName(ModuleName, "PauseRuntime", PauseRuntime{})
+	// MethodResumeRuntime is the method name for resuming paused runtimes.
+	MethodResumeRuntime = transaction.NewMethodName(ModuleName, "ResumeRuntime", PauseRuntime{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
@@ -141,6 +154,8 @@ var (
 		MethodUnfreezeNode,
 		MethodRegisterRuntime,
 		MethodProveFreshness,
+		MethodPauseRuntime,
+		MethodResumeRuntime,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -185,6 +200,9 @@ type Backend interface {
 	// GetNodes gets a list of all registered nodes.
 	GetNodes(context.Context, int64) ([]*node.Node, error)
 
+	// QueryNodes gets a filtered and paginated list of registered nodes.
+	QueryNodes(context.Context, *NodesQuery) ([]*node.Node, error)
+
 	// GetNodeByConsensusAddress looks up a node by its consensus address at the
 	// specified block height. The nature and format of the consensus address depends
 	// on the specific consensus backend implementation used.
@@ -202,17 +220,26 @@ type Backend interface {
 	// order.
 	WatchNodeList(context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error)
 
+	// WatchNodeExpirations returns a channel that produces a stream of
+	// NodeExpirationEvent on each epoch transition for nodes that are
+	// about to expire or have just expired.
+	WatchNodeExpirations(context.Context) (<-chan *NodeExpirationEvent, pubsub.ClosableSubscription, error)
+
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *GetRuntimeQuery) (*Runtime, error)
 
 	// GetRuntimes returns the registered Runtimes at the specified
-	// block height.
+	// block height, optionally filtered and paginated.
 	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
 
 	// WatchRuntimes returns a stream of Runtime.  Upon subscription,
 	// all runtimes will be sent immediately.
 	WatchRuntimes(context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error)
 
+	// GetRuntimeSuspension returns the suspension status of a runtime,
+	// including the reasons for its recent suspensions.
+	GetRuntimeSuspension(context.Context, *NamespaceQuery) (*RuntimeSuspensionStatus, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 
@@ -252,6 +279,42 @@ type GetRuntimeQuery struct {
 type GetRuntimesQuery struct {
 	Height           int64 `json:"height"`
 	IncludeSuspended bool  `json:"include_suspended"`
+
+	// EntityID restricts the results to runtimes owned by the given entity.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+	// Kind restricts the results to runtimes of the given kind.
+	Kind RuntimeKind `json:"kind,omitempty"`
+
+	// Offset is the number of matching runtimes to skip.
+	Offset uint64 `json:"offset,omitempty"`
+	// Limit is the maximum number of runtimes to return. Zero means no limit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// NodesQuery is a registry query for a filtered and paginated list of nodes.
+//
+// All filters are optional and nodes must match all of the specified filters.
+type NodesQuery struct {
+	Height int64 `json:"height"`
+
+	// EntityID restricts the results to nodes owned by the given entity.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+	// Roles restricts the results to nodes that have any of the given roles.
+	Roles node.RolesMask `json:"roles,omitempty"`
+	// Features restricts the results to nodes that declare all of the given features.
+	Features node.FeaturesMask `json:"features,omitempty"`
+	// RuntimeID restricts the results to nodes that support the given runtime.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+	// MinExpiration restricts the results to nodes that expire at or after the given epoch.
+	MinExpiration uint64 `json:"min_expiration,omitempty"`
+	// MaxExpiration restricts the results to nodes that expire at or before the given epoch.
+	// Zero means no upper bound.
+	MaxExpiration uint64 `json:"max_expiration,omitempty"`
+
+	// Offset is the number of matching nodes to skip.
+	Offset uint64 `json:"offset,omitempty"`
+	// Limit is the maximum number of nodes to return. Zero means no limit.
+	Limit uint32 `json:"limit,omitempty"`
 }
 
 // ConsensusAddressQuery is a registry query by consensus address.
@@ -285,6 +348,16 @@ func NewUnfreezeNodeTx(nonce uint64, fee *transaction.Fee, unfreeze *UnfreezeNod
 	return transaction.NewTransaction(nonce, fee, MethodUnfreezeNode, unfreeze)
 }
 
+// NewPauseRuntimeTx creates a new pause runtime transaction.
+func NewPauseRuntimeTx(nonce uint64, fee *transaction.Fee, pause *PauseRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodPauseRuntime, pause)
+}
+
+// NewResumeRuntimeTx creates a new resume runtime transaction.
+func NewResumeRuntimeTx(nonce uint64, fee *transaction.Fee, resume *PauseRuntime) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodResumeRuntime, resume)
+}
+
 // NewRegisterRuntimeTx creates a new register runtime transaction.
 func NewRegisterRuntimeTx(nonce uint64, fee *transaction.Fee, rt *Runtime) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterRuntime, rt)
@@ -307,6 +380,24 @@ func (e *EntityEvent) EventKind() string {
 	return "entity"
 }
 
+// EntityDeregisteredEvent signifies the completion of an entity deregistration.
+//
+// The entity's self-delegated escrow is scheduled for release at the end of the
+// debonding interval.
+type EntityDeregisteredEvent struct {
+	// ID is the identifier of the deregistered entity.
+	ID signature.PublicKey `json:"id"`
+	// Amount is the amount of escrowed stake that started debonding.
+	Amount quantity.Quantity `json:"amount"`
+	// DebondEndTime is the epoch at which the escrowed stake will be released.
+	DebondEndTime beacon.EpochTime `json:"debond_end_time,omitempty"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *EntityDeregisteredEvent) EventKind() string {
+	return "entity_deregistered"
+}
+
 // NodeEvent is the event that is returned via WatchNodes to signify node
 // registration changes and updates.
 type NodeEvent struct {
@@ -333,7 +424,8 @@ func (e *RuntimeStartedEvent) EventKind() string {
 
 // RuntimeSuspendedEvent signifies a runtime was suspended.
 type RuntimeSuspendedEvent struct {
-	RuntimeID common.Namespace `json:"runtime_id"`
+	RuntimeID common.Namespace        `json:"runtime_id"`
+	Reason    RuntimeSuspensionReason `json:"reason,omitempty"`
 }
 
 // EventKind returns a string representation of this event's kind.
@@ -341,6 +433,18 @@ func (e *RuntimeSuspendedEvent) EventKind() string {
 	return "runtime_suspended"
 }
 
+// RuntimeResumedEvent signifies a previously suspended runtime was resumed.
+//
+// A RuntimeStartedEvent is emitted together with this event.
+type RuntimeResumedEvent struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RuntimeResumedEvent) EventKind() string {
+	return "runtime_resumed"
+}
+
 // NodeUnfrozenEvent signifies when node becomes unfrozen.
 type NodeUnfrozenEvent struct {
 	NodeID signature.PublicKey `json:"node_id"`
@@ -377,11 +481,13 @@ type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
 
-	RuntimeStartedEvent   *RuntimeStartedEvent   `json:"runtime_started,omitempty"`
-	RuntimeSuspendedEvent *RuntimeSuspendedEvent `json:"runtime_suspended,omitempty"`
-	EntityEvent           *EntityEvent           `json:"entity,omitempty"`
-	NodeEvent             *NodeEvent             `json:"node,omitempty"`
-	NodeUnfrozenEvent     *NodeUnfrozenEvent     `json:"node_unfrozen,omitempty"`
+	RuntimeStartedEvent     *RuntimeStartedEvent     `json:"runtime_started,omitempty"`
+	RuntimeSuspendedEvent   *RuntimeSuspendedEvent   `json:"runtime_suspended,omitempty"`
+	RuntimeResumedEvent     *RuntimeResumedEvent     `json:"runtime_resumed,omitempty"`
+	EntityEvent             *EntityEvent             `json:"entity,omitempty"`
+	EntityDeregisteredEvent *EntityDeregisteredEvent `json:"entity_deregistered,omitempty"`
+	NodeEvent               *NodeEvent               `json:"node,omitempty"`
+	NodeUnfrozenEvent       *NodeUnfrozenEvent       `json:"node_unfrozen,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -389,6 +495,34 @@ type NodeList struct {
 	Nodes []*node.Node `json:"nodes"`
 }
 
+// NodeExpirationNoticeEpochs is the number of epochs before a node's expiration
+// in which NodeExpirationEvent notifications start being emitted for the node.
+const NodeExpirationNoticeEpochs = 2
+
+// NodeExpirationEvent is the event that is returned via WatchNodeExpirations to
+// signify that a node is approaching or has passed its expiration epoch.
+//
+// Until the node is re-registered, a notification is emitted in each of the
+// NodeExpirationNoticeEpochs epochs preceding its expiration and once more in
+// the epoch in which it expires.
+type NodeExpirationEvent struct {
+	// Node is the node descriptor.
+	Node *node.Node `json:"node"`
+	// Epoch is the epoch in which the notification was emitted.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Expired is true iff the node has expired in this epoch.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// RemainingEpochs returns the number of epochs the node will remain registered
+// for, including the current epoch, unless it is re-registered.
+func (e *NodeExpirationEvent) RemainingEpochs() uint64 {
+	if e.Node.IsExpired(uint64(e.Epoch)) {
+		return 0
+	}
+	return e.Node.Expiration - uint64(e.Epoch) + 1
+}
+
 // NodeLookup interface implements various ways for the verification
 // functions to look-up nodes in the registry's state.
 type NodeLookup interface {
@@ -538,7 +672,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 	}
 
 	// Descriptors will always be signed by the node identity key.
-	var expectedSigners []signature.PublicKey
 	if !sigNode.MultiSigned.IsSignedBy(n.ID) {
 		logger.Debug("RegisterNode: registration not signed by node identity",
 			"signed_node", sigNode,
@@ -546,7 +679,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: registration not signed by node identity", ErrInvalidArgument)
 	}
-	expectedSigners = append(expectedSigners, n.ID)
 	if !entity.HasNode(n.ID) && (!isSanityCheck || isGenesis) {
 		logger.Debug("RegisterNode: node public key not found in entity's node list",
 			"signed_node", sigNode,
@@ -641,6 +773,24 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		}
 	}
 
+	// Validate declared features. These checks are skipped by the sanity checker as the features
+	// may have been disabled after the node has registered.
+	if n.Features != node.FeatureEmpty && !isSanityCheck {
+		if !params.EnableNodeFeatures {
+			logger.Error("RegisterNode: node features not enabled",
+				"node", n,
+			)
+			return nil, nil, fmt.Errorf("%w: node features not enabled", ErrInvalidArgument)
+		}
+		if err := n.VerifyFeatures(); err != nil {
+			logger.Error("RegisterNode: invalid node features",
+				"node", n,
+				"err", err,
+			)
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+		}
+	}
+
 	// Validate ConsensusInfo.
 	if !n.Consensus.ID.IsValid() {
 		logger.Error("RegisterNode: invalid consensus ID",
@@ -655,7 +805,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: registration not signed by consensus ID", ErrInvalidArgument)
 	}
-	expectedSigners = append(expectedSigners, n.Consensus.ID)
 	consensusAddressRequired := n.HasRoles(ConsensusAddressRequiredRoles)
 	if err := verifyAddresses(params, consensusAddressRequired, n.Consensus.Addresses); err != nil {
 		addrs, _ := json.Marshal(n.Consensus.Addresses)
@@ -680,7 +829,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: registration not signed by VRF ID", ErrInvalidArgument)
 	}
-	expectedSigners = append(expectedSigners, n.VRF.ID)
 
 	// Validate TLSInfo.
 	if !n.TLS.PubKey.IsValid() {
@@ -697,7 +845,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: registration not signed by TLS certificate key", ErrInvalidArgument)
 	}
-	expectedSigners = append(expectedSigners, n.TLS.PubKey)
 
 	// Validate P2PInfo.
 	if !n.P2P.ID.IsValid() {
@@ -713,7 +860,6 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, fmt.Errorf("%w: registration not signed by P2P ID", ErrInvalidArgument)
 	}
-	expectedSigners = append(expectedSigners, n.P2P.ID)
 	p2pAddressRequired := n.HasRoles(P2PAddressRequiredRoles)
 	switch isGenesis || isSanityCheck {
 	case true:
@@ -783,17 +929,48 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 	}
 
 	// Ensure that only the expected signatures are present, and nothing more.
-	if !sigNode.MultiSigned.IsOnlySignedBy(expectedSigners) {
+	if err := VerifyNodeKeyPossession(sigNode, &n); err != nil {
 		logger.Error("RegisterNode: unexpected number of signatures",
 			"signed_node", sigNode,
 			"node", n,
+			"err", err,
 		)
-		return nil, nil, fmt.Errorf("%w: unexpected number of signatures", ErrInvalidArgument)
+		return nil, nil, err
 	}
 
 	return &n, runtimes, nil
 }
 
+// NodeRegistrationSigners returns the public keys that must sign a node registration, proving
+// possession of every key declared in the node descriptor.
+func NodeRegistrationSigners(n *node.Node) []signature.PublicKey {
+	return []signature.PublicKey{
+		n.ID,
+		n.Consensus.ID,
+		n.VRF.ID,
+		n.TLS.PubKey,
+		n.P2P.ID,
+	}
+}
+
+// VerifyNodeKeyPossession verifies that the multi-signed node descriptor is signed by all of
+// the node's keys (identity, consensus, VRF, TLS and P2P) and by nothing else.
+//
+// Note: This does not verify the signatures themselves, which must already have been verified
+// when opening the multi-signed node descriptor.
+func VerifyNodeKeyPossession(sigNode *node.MultiSignedNode, n *node.Node) error {
+	signers := NodeRegistrationSigners(n)
+	for _, pk := range signers {
+		if !sigNode.MultiSigned.IsSignedBy(pk) {
+			return fmt.Errorf("%w: registration not signed by node key %s", ErrInvalidArgument, pk)
+		}
+	}
+	if !sigNode.MultiSigned.IsOnlySignedBy(signers) {
+		return fmt.Errorf("%w: unexpected number of signatures", ErrInvalidArgument)
+	}
+	return nil
+}
+
 // VerifyNodeRuntimeEnclaveIDs verifies TEE-specific attributes of the node's runtime.
 func VerifyNodeRuntimeEnclaveIDs(
 	logger *logging.Logger,
@@ -821,9 +998,38 @@ func VerifyNodeRuntimeEnclaveIDs(
 
 	// If no TEE available, do nothing.
 	if rt.Capabilities.TEE == nil {
+		if len(rt.Capabilities.AdditionalTEEs) > 0 {
+			return fmt.Errorf("%w: additional TEE capabilities without a primary TEE capability", ErrInvalidArgument)
+		}
 		return nil
 	}
 
+	// Validate additional TEE capabilities.
+	if len(rt.Capabilities.AdditionalTEEs) > 0 && (teeCfg == nil || !teeCfg.MultipleCapabilities) {
+		logger.Error("VerifyNodeRuntimeEnclaveIDs: multiple TEE capabilities not supported",
+			"node_id", nodeID,
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: multiple TEE capabilities not supported", ErrInvalidArgument)
+	}
+	if err := rt.Capabilities.ValidateBasic(); err != nil {
+		logger.Error("VerifyNodeRuntimeEnclaveIDs: invalid TEE capabilities",
+			"node_id", nodeID,
+			"runtime_id", rt.ID,
+			"err", err,
+		)
+		return fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	for _, tee := range rt.Capabilities.TEEs() {
+		if tee.Details != nil && (teeCfg == nil || !teeCfg.HardwareDetails) {
+			logger.Error("VerifyNodeRuntimeEnclaveIDs: TEE hardware details not supported",
+				"node_id", nodeID,
+				"runtime_id", rt.ID,
+			)
+			return fmt.Errorf("%w: TEE hardware details not supported", ErrInvalidArgument)
+		}
+	}
+
 	// Find the runtime in the descriptor corresponding to the version
 	// that is to be validated.
 	for _, rtVersionInfo := range regRt.Deployments {
@@ -831,14 +1037,18 @@ func VerifyNodeRuntimeEnclaveIDs(
 			continue
 		}
 
-		if err := rt.Capabilities.TEE.Verify(teeCfg, ts, height, rtVersionInfo.TEE, nodeID); err != nil {
-			logger.Error("VerifyNodeRuntimeEnclaveIDs: failed to validate attestation",
-				"node_id", nodeID,
-				"runtime_id", rt.ID,
-				"ts", ts,
-				"err", err,
-			)
-			return err
+		// All of the advertised TEE capabilities must be valid.
+		for _, tee := range rt.Capabilities.TEEs() {
+			if err := tee.Verify(teeCfg, ts, height, rtVersionInfo.TEE, nodeID); err != nil {
+				logger.Error("VerifyNodeRuntimeEnclaveIDs: failed to validate attestation",
+					"node_id", nodeID,
+					"runtime_id", rt.ID,
+					"rak", tee.RAK,
+					"ts", ts,
+					"err", err,
+				)
+				return err
+			}
 		}
 
 		return nil
@@ -1026,8 +1236,8 @@ func verifyRuntimeCapabilities(logger *logging.Logger, currentCaps, newCaps *nod
 		)
 		return false
 	}
-	// RAK and Attestation fields are allowed to change as they may be updated if
-	// the node and/or the runtime restarts.
+	// RAK and Attestation fields as well as additional TEE capabilities are allowed
+	// to change as they may be updated if the node and/or the runtime restarts.
 	return true
 }
 
@@ -1152,6 +1362,26 @@ func VerifyRuntime( // nolint: gocyclo
 		return err // ValidateDeployments handles wrapping, yay.
 	}
 
+	// Validate scheduling constraints on declared node features.
+	for _, roles := range rt.Constraints {
+		for _, cs := range roles {
+			if cs.Features != nil && cs.Features.Required&node.FeatureReserved != 0 {
+				logger.Error("RegisterRuntime: invalid features scheduling constraint",
+					"runtime_id", rt.ID,
+				)
+				return fmt.Errorf("%w: invalid features scheduling constraint", ErrInvalidArgument)
+			}
+		}
+	}
+
+	// Validate features gated by consensus parameters. These checks are skipped by the sanity
+	// checker as the features may have been disabled after the runtime has registered.
+	if !isSanityCheck {
+		if err := verifyRuntimeGatedFeatures(params, logger, rt); err != nil {
+			return err
+		}
+	}
+
 	// Using runtime governance for non-compute runtimes is invalid.
 	if rt.GovernanceModel == GovernanceRuntime && rt.Kind != KindCompute {
 		logger.Error("RegisterRuntime: runtime governance can only be used with compute runtimes")
@@ -1161,38 +1391,103 @@ func VerifyRuntime( // nolint: gocyclo
 	return nil
 }
 
+// verifyRuntimeGatedFeatures verifies that the runtime only uses features which are enabled by
+// the consensus parameters.
+func verifyRuntimeGatedFeatures(params *ConsensusParameters, logger *logging.Logger, rt *Runtime) error {
+	var hasFeatures bool
+	for _, roles := range rt.Constraints {
+		for _, cs := range roles {
+			hasFeatures = hasFeatures || cs.Features != nil
+		}
+	}
+	if hasFeatures && !params.EnableNodeFeatures {
+		logger.Error("RegisterRuntime: node features not enabled",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: node features not enabled", ErrForbidden)
+	}
+
+	storage := rt.Storage
+	hasStorageLimits := storage.MaxKeySize > 0 || storage.MaxValueSize > 0 || len(storage.ReservedKeyPrefixes) > 0
+	if hasStorageLimits && !params.EnableRuntimeStorageLimits {
+		logger.Error("RegisterRuntime: runtime storage limits not enabled",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: runtime storage limits not enabled", ErrForbidden)
+	}
+
+	hasStandby := rt.Executor.GroupStandbySize > 0 || rt.Executor.StandbyPromotionThreshold > 0
+	if hasStandby && !params.EnableStandbyWorkers {
+		logger.Error("RegisterRuntime: standby workers not enabled",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: standby workers not enabled", ErrForbidden)
+	}
+
+	if rt.Executor.RotationPercent > 0 && !params.EnableCommitteeRotation {
+		logger.Error("RegisterRuntime: committee rotation not enabled",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: committee rotation not enabled", ErrForbidden)
+	}
+
+	if rt.KeyManagerChange != nil && !params.EnableKeyManagerChange {
+		logger.Error("RegisterRuntime: key manager changes not enabled",
+			"runtime_id", rt.ID,
+		)
+		return fmt.Errorf("%w: key manager changes not enabled", ErrForbidden)
+	}
+
+	return nil
+}
+
 // VerifyRegisterComputeRuntimeArgs verifies compute runtime-specific arguments for RegisterRuntime.
 func VerifyRegisterComputeRuntimeArgs(ctx context.Context, logger *logging.Logger, rt *Runtime, runtimeLookup RuntimeLookup) error {
 	// Check runtime's key manager, if key manager ID is set.
 	if rt.KeyManager != nil {
-		km, err := runtimeLookup.AnyRuntime(ctx, *rt.KeyManager)
-		if err != nil {
-			logger.Error("RegisterRuntime: error when fetching the runtime's key manager from registry",
-				"runtime", rt.ID,
-				"key_manager", rt.KeyManager,
-			)
+		if err := verifyComputeRuntimeKeyManager(ctx, logger, rt, *rt.KeyManager, runtimeLookup); err != nil {
 			return err
 		}
+	}
 
-		// Key manager runtime should be valid.
-		if km.Kind != KindKeyManager {
-			logger.Error("RegisterRuntime: provided key manager runtime is not key manager",
-				"runtime", rt.ID,
-				"key_manager", rt.KeyManager,
-				"expected_kind", KindKeyManager,
-				"actual_kind", km.Kind,
-			)
-			return ErrInvalidArgument
+	// Check runtime's new key manager, if a key manager change is scheduled.
+	if rt.KeyManagerChange != nil {
+		if err := verifyComputeRuntimeKeyManager(ctx, logger, rt, rt.KeyManagerChange.KeyManager, runtimeLookup); err != nil {
+			return err
 		}
+	}
 
-		// Currently the keymanager implementation assumes SGX. Unless this is a
-		// test runtime, using a keymanager without using SGX is unsupported.
-		if !rt.ID.IsTest() && rt.TEEHardware != node.TEEHardwareIntelSGX {
-			logger.Error("RegisterRuntime: runtime without SGX using key manager",
-				"id", rt.ID,
-			)
-			return fmt.Errorf("%w: compute runtime without SGX using key manager", ErrInvalidArgument)
-		}
+	return nil
+}
+
+func verifyComputeRuntimeKeyManager(ctx context.Context, logger *logging.Logger, rt *Runtime, kmID common.Namespace, runtimeLookup RuntimeLookup) error {
+	km, err := runtimeLookup.AnyRuntime(ctx, kmID)
+	if err != nil {
+		logger.Error("RegisterRuntime: error when fetching the runtime's key manager from registry",
+			"runtime", rt.ID,
+			"key_manager", kmID,
+		)
+		return err
+	}
+
+	// Key manager runtime should be valid.
+	if km.Kind != KindKeyManager {
+		logger.Error("RegisterRuntime: provided key manager runtime is not key manager",
+			"runtime", rt.ID,
+			"key_manager", kmID,
+			"expected_kind", KindKeyManager,
+			"actual_kind", km.Kind,
+		)
+		return ErrInvalidArgument
+	}
+
+	// Currently the keymanager implementation assumes SGX. Unless this is a
+	// test runtime, using a keymanager without using SGX is unsupported.
+	if !rt.ID.IsTest() && rt.TEEHardware != node.TEEHardwareIntelSGX {
+		logger.Error("RegisterRuntime: runtime without SGX using key manager",
+			"id", rt.ID,
+		)
+		return fmt.Errorf("%w: compute runtime without SGX using key manager", ErrInvalidArgument)
 	}
 
 	return nil
@@ -1253,6 +1548,15 @@ func VerifyRuntimeUpdate(
 		)
 		return ErrRuntimeUpdateNotAllowed
 	}
+	// Key manager changes can only be scheduled for future epochs.
+	if kmc := newRt.KeyManagerChange; kmc != nil && !kmc.Equal(currentRt.KeyManagerChange) && kmc.Epoch <= now {
+		logger.Error("RegisterRuntime: trying to change key manager immediately",
+			"current_km", currentRt.KeyManager,
+			"new_km", kmc.KeyManager,
+			"epoch", kmc.Epoch,
+		)
+		return ErrRuntimeUpdateNotAllowed
+	}
 	// Check if governance model update is valid.
 	if currentRt.GovernanceModel != newRt.GovernanceModel {
 		// Transitioning from entity to runtime governance is allowed, but
@@ -1369,6 +1673,8 @@ type Genesis struct {
 	Runtimes []*Runtime `json:"runtimes,omitempty"`
 	// SuspendedRuntimes is the list of suspended runtimes.
 	SuspendedRuntimes []*Runtime `json:"suspended_runtimes,omitempty"`
+	// RuntimeSuspensions is a set of runtime suspension statuses.
+	RuntimeSuspensions map[common.Namespace]*RuntimeSuspensionStatus `json:"runtime_suspensions,omitempty"`
 
 	// Nodes is the initial list of nodes.
 	Nodes []*node.MultiSignedNode `json:"nodes,omitempty"`
@@ -1419,6 +1725,44 @@ type ConsensusParameters struct {
 
 	// MaxRuntimeDeployments is the maximum number of runtime deployments.
 	MaxRuntimeDeployments uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// EnableNodeFeatures is true iff nodes are allowed to declare features in their descriptors.
+	EnableNodeFeatures bool `json:"enable_node_features,omitempty"`
+
+	// EnableEntityMultisig is true iff entities are allowed to register multisig policies and
+	// submit multi-signed transactions.
+	EnableEntityMultisig bool `json:"enable_entity_multisig,omitempty"`
+
+	// EnableEntityEscrowRelease is true iff entity deregistration should start debonding the
+	// entity's self-delegated escrow.
+	EnableEntityEscrowRelease bool `json:"enable_entity_escrow_release,omitempty"`
+
+	// EnableRuntimeSuspensionHistory is true iff runtime suspensions and resumptions should be
+	// recorded in the runtime suspension history.
+	EnableRuntimeSuspensionHistory bool `json:"enable_runtime_suspension_history,omitempty"`
+
+	// EnableRuntimePause is true iff runtime owners are allowed to pause and resume their runtimes.
+	EnableRuntimePause bool `json:"enable_runtime_pause,omitempty"`
+
+	// EnableRuntimeStorageLimits is true iff runtimes are allowed to configure state key and
+	// value size limits and reserved key prefixes in their storage parameters.
+	EnableRuntimeStorageLimits bool `json:"enable_runtime_storage_limits,omitempty"`
+
+	// EnableStandbyWorkers is true iff runtimes are allowed to configure a standby group for
+	// their executor committees.
+	EnableStandbyWorkers bool `json:"enable_standby_workers,omitempty"`
+
+	// EnableCommitteeRotation is true iff runtimes are allowed to configure staggered rotation
+	// of their executor committees.
+	EnableCommitteeRotation bool `json:"enable_committee_rotation,omitempty"`
+
+	// EnableKeyManagerChange is true iff compute runtimes are allowed to schedule a replacement
+	// of their key manager runtime.
+	EnableKeyManagerChange bool `json:"enable_key_manager_change,omitempty"`
+
+	// EnableRuntimeParameterUpdates is true iff runtimes using the runtime governance model are
+	// allowed to emit partial runtime parameter updates.
+	EnableRuntimeParameterUpdates bool `json:"enable_runtime_parameter_updates,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed registry consensus parameter changes.
@@ -1443,6 +1787,36 @@ type ConsensusParameterChanges struct {
 
 	// MaxRuntimeDeployments is the new maximum number of runtime deployments.
 	MaxRuntimeDeployments *uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// EnableNodeFeatures is the new enable node features flag.
+	EnableNodeFeatures *bool `json:"enable_node_features,omitempty"`
+
+	// EnableEntityMultisig is the new enable entity multisig flag.
+	EnableEntityMultisig *bool `json:"enable_entity_multisig,omitempty"`
+
+	// EnableEntityEscrowRelease is the new enable entity escrow release flag.
+	EnableEntityEscrowRelease *bool `json:"enable_entity_escrow_release,omitempty"`
+
+	// EnableRuntimeSuspensionHistory is the new enable runtime suspension history flag.
+	EnableRuntimeSuspensionHistory *bool `json:"enable_runtime_suspension_history,omitempty"`
+
+	// EnableRuntimePause is the new enable runtime pause flag.
+	EnableRuntimePause *bool `json:"enable_runtime_pause,omitempty"`
+
+	// EnableRuntimeStorageLimits is the new enable runtime storage limits flag.
+	EnableRuntimeStorageLimits *bool `json:"enable_runtime_storage_limits,omitempty"`
+
+	// EnableStandbyWorkers is the new enable standby workers flag.
+	EnableStandbyWorkers *bool `json:"enable_standby_workers,omitempty"`
+
+	// EnableCommitteeRotation is the new enable committee rotation flag.
+	EnableCommitteeRotation *bool `json:"enable_committee_rotation,omitempty"`
+
+	// EnableKeyManagerChange is the new enable key manager change flag.
+	EnableKeyManagerChange *bool `json:"enable_key_manager_change,omitempty"`
+
+	// EnableRuntimeParameterUpdates is the new enable runtime parameter updates flag.
+	EnableRuntimeParameterUpdates *bool `json:"enable_runtime_parameter_updates,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1468,6 +1842,36 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxRuntimeDeployments != nil {
 		params.MaxRuntimeDeployments = *c.MaxRuntimeDeployments
 	}
+	if c.EnableNodeFeatures != nil {
+		params.EnableNodeFeatures = *c.EnableNodeFeatures
+	}
+	if c.EnableEntityMultisig != nil {
+		params.EnableEntityMultisig = *c.EnableEntityMultisig
+	}
+	if c.EnableEntityEscrowRelease != nil {
+		params.EnableEntityEscrowRelease = *c.EnableEntityEscrowRelease
+	}
+	if c.EnableRuntimeSuspensionHistory != nil {
+		params.EnableRuntimeSuspensionHistory = *c.EnableRuntimeSuspensionHistory
+	}
+	if c.EnableRuntimePause != nil {
+		params.EnableRuntimePause = *c.EnableRuntimePause
+	}
+	if c.EnableRuntimeStorageLimits != nil {
+		params.EnableRuntimeStorageLimits = *c.EnableRuntimeStorageLimits
+	}
+	if c.EnableStandbyWorkers != nil {
+		params.EnableStandbyWorkers = *c.EnableStandbyWorkers
+	}
+	if c.EnableCommitteeRotation != nil {
+		params.EnableCommitteeRotation = *c.EnableCommitteeRotation
+	}
+	if c.EnableKeyManagerChange != nil {
+		params.EnableKeyManagerChange = *c.EnableKeyManagerChange
+	}
+	if c.EnableRuntimeParameterUpdates != nil {
+		params.EnableRuntimeParameterUpdates = *c.EnableRuntimeParameterUpdates
+	}
 	return nil
 }
 
@@ -1487,6 +1891,8 @@ const (
 	GasOpRuntimeEpochMaintenance transaction.Op = "runtime_epoch_maintenance"
 	// GasOpProveFreshness is the gas operation identifier for freshness proofs.
 	GasOpProveFreshness transaction.Op = "prove_freshness"
+	// GasOpPauseRuntime is the gas operation identifier for pausing and resuming runtimes.
+	GasOpPauseRuntime transaction.Op = "pause_runtime"
 )
 
 // XXX: Define reasonable default gas costs.
@@ -1500,6 +1906,7 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpRegisterRuntime:         1000,
 	GasOpRuntimeEpochMaintenance: 1000,
 	GasOpProveFreshness:          1000,
+	GasOpPauseRuntime:            1000,
 }
 
 const (
@@ -221,6 +221,56 @@ func TestVerifyRegisterNodeArgs(t *testing.T) {
 	}
 }
 
+func TestVerifyNodeKeyPossession(t *testing.T) {
+	require := require.New(t)
+
+	nodeSigner := memorySigner.NewTestSigner("node key possession tests signer")
+	nodeConsensusSigner := memorySigner.NewTestSigner("node key possession tests consensus signer")
+	nodeP2PSigner := memorySigner.NewTestSigner("node key possession tests P2P signer")
+	nodeTLSSigner := memorySigner.NewTestSigner("node key possession tests TLS signer")
+	nodeVRFSigner := memorySigner.NewTestSigner("node key possession tests VRF signer")
+	otherSigner := memorySigner.NewTestSigner("node key possession tests other signer")
+	nodeSigners := []signature.Signer{
+		nodeSigner,
+		nodeP2PSigner,
+		nodeTLSSigner,
+		nodeVRFSigner,
+		nodeConsensusSigner,
+	}
+
+	n := node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		Consensus: node.ConsensusInfo{ID: nodeConsensusSigner.Public()},
+		TLS:       node.TLSInfo{PubKey: nodeTLSSigner.Public()},
+		P2P:       node.P2PInfo{ID: nodeP2PSigner.Public()},
+		VRF:       node.VRFInfo{ID: nodeVRFSigner.Public()},
+	}
+	require.Len(NodeRegistrationSigners(&n), len(nodeSigners))
+
+	for _, tc := range []struct {
+		signers []signature.Signer
+		valid   bool
+		msg     string
+	}{
+		{nodeSigners, true, "registration signed by all node keys should be accepted"},
+		{nodeSigners[:1], false, "registration signed only by the node identity should be rejected"},
+		{nodeSigners[:4], false, "registration missing the consensus key signature should be rejected"},
+		{append([]signature.Signer{otherSigner}, nodeSigners...), false, "registration with extra signatures should be rejected"},
+	} {
+		signedNode, err := node.MultiSignNode(tc.signers, RegisterNodeSignatureContext, &n)
+		require.NoError(err, "MultiSignNode")
+
+		err = VerifyNodeKeyPossession(signedNode, &n)
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.msg)
+		case false:
+			require.ErrorIs(err, ErrInvalidArgument, tc.msg)
+		}
+	}
+}
+
 func TestVerifyNodeUpdate(t *testing.T) {
 	logger := logging.GetLogger("registry/api/tests")
 
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+// paginate returns the page of items starting at the given offset, containing at most limit
+// items. A zero limit means no limit.
+func paginate[T any](items []T, offset uint64, limit uint32) []T {
+	if offset >= uint64(len(items)) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && uint64(limit) < uint64(len(items)) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Matches returns true iff the given node matches all of the query filters.
+func (q *NodesQuery) Matches(n *node.Node) bool {
+	if q.EntityID != nil && !n.EntityID.Equal(*q.EntityID) {
+		return false
+	}
+	if q.Roles != node.RoleEmpty && !n.HasRoles(q.Roles) {
+		return false
+	}
+	if !n.HasFeatures(q.Features) {
+		return false
+	}
+	if q.RuntimeID != nil && !n.HasRuntime(*q.RuntimeID) {
+		return false
+	}
+	if n.Expiration < q.MinExpiration {
+		return false
+	}
+	if q.MaxExpiration != 0 && n.Expiration > q.MaxExpiration {
+		return false
+	}
+	return true
+}
+
+// Apply filters and paginates the given list of nodes.
+func (q *NodesQuery) Apply(nodes []*node.Node) []*node.Node {
+	var matching []*node.Node
+	for _, n := range nodes {
+		if q.Matches(n) {
+			matching = append(matching, n)
+		}
+	}
+	return paginate(matching, q.Offset, q.Limit)
+}
+
+// Matches returns true iff the given runtime matches all of the query filters.
+//
+// Note that suspended runtimes are handled separately.
+func (q *GetRuntimesQuery) Matches(rt *Runtime) bool {
+	if q.EntityID != nil && !rt.EntityID.Equal(*q.EntityID) {
+		return false
+	}
+	if q.Kind != KindInvalid && rt.Kind != q.Kind {
+		return false
+	}
+	return true
+}
+
+// Apply filters and paginates the given list of runtimes.
+func (q *GetRuntimesQuery) Apply(runtimes []*Runtime) []*Runtime {
+	var matching []*Runtime
+	for _, rt := range runtimes {
+		if q.Matches(rt) {
+			matching = append(matching, rt)
+		}
+	}
+	return paginate(matching, q.Offset, q.Limit)
+}
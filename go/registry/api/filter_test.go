@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+func TestNodesQuery(t *testing.T) {
+	require := require.New(t)
+
+	entityA := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000000")
+	entityB := signature.NewPublicKey("2000000000000000000000000000000000000000000000000000000000000000")
+	var runtimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+
+	nodes := []*node.Node{
+		{EntityID: entityA, Roles: node.RoleValidator, Expiration: 10},
+		{EntityID: entityA, Roles: node.RoleComputeWorker | node.RoleValidator, Expiration: 20, Runtimes: []*node.Runtime{{ID: runtimeID}}},
+		{EntityID: entityB, Roles: node.RoleComputeWorker, Expiration: 30, Runtimes: []*node.Runtime{{ID: runtimeID}}, Features: node.FeatureDCAP},
+		{EntityID: entityB, Roles: node.RoleObserver, Expiration: 40, Features: node.FeatureDCAP | node.FeatureArchival},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		query    NodesQuery
+		expected []*node.Node
+	}{
+		{"NoFilters", NodesQuery{}, nodes},
+		{"Entity", NodesQuery{EntityID: &entityB}, nodes[2:]},
+		{"Roles", NodesQuery{Roles: node.RoleComputeWorker | node.RoleObserver}, nodes[1:]},
+		{"Features", NodesQuery{Features: node.FeatureDCAP | node.FeatureArchival}, nodes[3:]},
+		{"Runtime", NodesQuery{RuntimeID: &runtimeID}, nodes[1:3]},
+		{"Expiration", NodesQuery{MinExpiration: 20, MaxExpiration: 30}, nodes[1:3]},
+		{"Combined", NodesQuery{EntityID: &entityA, RuntimeID: &runtimeID}, nodes[1:2]},
+		{"Offset", NodesQuery{Offset: 1}, nodes[1:]},
+		{"Limit", NodesQuery{Limit: 2}, nodes[:2]},
+		{"OffsetLimit", NodesQuery{Offset: 1, Limit: 2}, nodes[1:3]},
+		{"FilteredPage", NodesQuery{EntityID: &entityB, Offset: 1, Limit: 5}, nodes[3:]},
+		{"OffsetPastEnd", NodesQuery{Offset: 4}, nil},
+	} {
+		require.Equal(tc.expected, tc.query.Apply(nodes), tc.name)
+	}
+}
+
+func TestGetRuntimesQuery(t *testing.T) {
+	require := require.New(t)
+
+	entityA := signature.NewPublicKey("1000000000000000000000000000000000000000000000000000000000000000")
+	entityB := signature.NewPublicKey("2000000000000000000000000000000000000000000000000000000000000000")
+
+	runtimes := []*Runtime{
+		{EntityID: entityA, Kind: KindCompute},
+		{EntityID: entityA, Kind: KindKeyManager},
+		{EntityID: entityB, Kind: KindCompute},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		query    GetRuntimesQuery
+		expected []*Runtime
+	}{
+		{"NoFilters", GetRuntimesQuery{}, runtimes},
+		{"Entity", GetRuntimesQuery{EntityID: &entityA}, runtimes[:2]},
+		{"Kind", GetRuntimesQuery{Kind: KindCompute}, []*Runtime{runtimes[0], runtimes[2]}},
+		{"Page", GetRuntimesQuery{Kind: KindCompute, Offset: 1, Limit: 1}, runtimes[2:]},
+	} {
+		require.Equal(tc.expected, tc.query.Apply(runtimes), tc.name)
+	}
+}
@@ -27,10 +27,14 @@ var (
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
 	// methodGetNodes is the GetNodes method.
 	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	// methodQueryNodes is the QueryNodes method.
+	methodQueryNodes = serviceName.NewMethod("QueryNodes", NodesQuery{})
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", GetRuntimeQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
 	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", GetRuntimesQuery{})
+	// methodGetRuntimeSuspension is the GetRuntimeSuspension method.
+	methodGetRuntimeSuspension = serviceName.NewMethod("GetRuntimeSuspension", NamespaceQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodGetEvents is the GetEvents method.
@@ -44,6 +48,8 @@ var (
 	methodWatchNodes = serviceName.NewMethod("WatchNodes", nil)
 	// methodWatchNodeList is the WatchNodeList method.
 	methodWatchNodeList = serviceName.NewMethod("WatchNodeList", nil)
+	// methodWatchNodeExpirations is the WatchNodeExpirations method.
+	methodWatchNodeExpirations = serviceName.NewMethod("WatchNodeExpirations", nil)
 	// methodWatchRuntimes is the WatchRuntimes method.
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
 	// methodWatchEvents is the WatchEvents method.
@@ -78,6 +84,10 @@ var (
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
 			},
+			{
+				MethodName: methodQueryNodes.ShortName(),
+				Handler:    handlerQueryNodes,
+			},
 			{
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
@@ -86,6 +96,10 @@ var (
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
 			},
+			{
+				MethodName: methodGetRuntimeSuspension.ShortName(),
+				Handler:    handlerGetRuntimeSuspension,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -125,6 +139,11 @@ var (
 				Handler:       handlerWatchEvents,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchNodeExpirations.ShortName(),
+				Handler:       handlerWatchNodeExpirations,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -267,6 +286,29 @@ func handlerGetNodes(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerQueryNodes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NodesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).QueryNodes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryNodes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).QueryNodes(ctx, req.(*NodesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetRuntime(
 	srv interface{},
 	ctx context.Context,
@@ -313,6 +355,29 @@ func handlerGetRuntimes(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerGetRuntimeSuspension(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeSuspension(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeSuspension.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeSuspension(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -438,6 +503,34 @@ func handlerWatchNodes(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchNodeExpirations(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchNodeExpirations(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchNodeList(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -613,6 +706,14 @@ func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return rsp, nil
 }
 
+func (c *registryClient) QueryNodes(ctx context.Context, query *NodesQuery) ([]*node.Node, error) {
+	var rsp []*node.Node
+	if err := c.conn.Invoke(ctx, methodQueryNodes.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -648,6 +749,41 @@ func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pub
 	return ch, sub, nil
 }
 
+func (c *registryClient) WatchNodeExpirations(ctx context.Context) (<-chan *NodeExpirationEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[5], methodWatchNodeExpirations.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *NodeExpirationEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev NodeExpirationEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *registryClient) WatchNodeList(ctx context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -699,6 +835,14 @@ func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuer
 	return rsp, nil
 }
 
+func (c *registryClient) GetRuntimeSuspension(ctx context.Context, query *NamespaceQuery) (*RuntimeSuspensionStatus, error) {
+	var rsp RuntimeSuspensionStatus
+	if err := c.conn.Invoke(ctx, methodGetRuntimeSuspension.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) WatchRuntimes(ctx context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -113,6 +113,19 @@ type ExecutorParameters struct {
 	// MaxLivenessFailures is the maximum number of liveness failures that are tolerated before
 	// suspending and/or slashing the node. Zero means unlimited.
 	MaxLivenessFailures uint8 `json:"max_liveness_fails,omitempty"`
+
+	// GroupStandbySize is the size of the standby group. Standby workers do not participate in
+	// rounds, but keep their state warm so that they can replace failing workers mid-epoch.
+	GroupStandbySize uint16 `json:"group_standby_size,omitempty"`
+
+	// StandbyPromotionThreshold is the number of proposals a worker can miss in an epoch before
+	// it is replaced by a standby worker for the rest of the epoch.
+	StandbyPromotionThreshold uint16 `json:"standby_promotion_threshold,omitempty"`
+
+	// RotationPercent is the percentage of each committee group that is rotated on every
+	// election, while the remaining members that are still eligible are retained. Zero means
+	// that the whole committee is re-elected every epoch.
+	RotationPercent uint8 `json:"rotation_percent,omitempty"`
 }
 
 // ValidateBasic performs basic executor parameter validity checks.
@@ -132,6 +145,14 @@ func (e *ExecutorParameters) ValidateBasic() error {
 		return fmt.Errorf("minimum live rounds percentage cannot be greater than 100")
 	}
 
+	if e.RotationPercent > 100 {
+		return fmt.Errorf("rotation percentage cannot be greater than 100")
+	}
+
+	if e.GroupStandbySize > 0 && e.StandbyPromotionThreshold == 0 {
+		return fmt.Errorf("standby promotion threshold must be set when using a standby group")
+	}
+
 	return nil
 }
 
@@ -187,6 +208,17 @@ type StorageParameters struct {
 
 	// CheckpointChunkSize is the chunk size parameter for checkpoint creation.
 	CheckpointChunkSize uint64 `json:"checkpoint_chunk_size"`
+
+	// MaxKeySize is the maximum size of state keys (in bytes) the runtime may write. Zero means
+	// no limit.
+	MaxKeySize uint64 `json:"max_key_size,omitempty"`
+
+	// MaxValueSize is the maximum size of state values (in bytes) the runtime may write. Zero
+	// means no limit.
+	MaxValueSize uint64 `json:"max_value_size,omitempty"`
+
+	// ReservedKeyPrefixes are the state key prefixes that the runtime may not write to.
+	ReservedKeyPrefixes [][]byte `json:"reserved_key_prefixes,omitempty"`
 }
 
 // ValidateBasic performs basic storage parameter validity checks.
@@ -204,9 +236,36 @@ func (s *StorageParameters) ValidateBasic() error {
 		}
 	}
 
+	for _, prefix := range s.ReservedKeyPrefixes {
+		if len(prefix) == 0 {
+			return fmt.Errorf("storage ReservedKeyPrefixes parameter contains an empty prefix")
+		}
+	}
+
 	return nil
 }
 
+// KeyManagerChange is a scheduled replacement of the key manager runtime used by a compute
+// runtime.
+type KeyManagerChange struct {
+	// KeyManager is the runtime ID of the new key manager.
+	KeyManager common.Namespace `json:"key_manager"`
+
+	// Epoch is the epoch at which the runtime switches to the new key manager.
+	Epoch beacon.EpochTime `json:"epoch"`
+}
+
+// Equal compares vs another KeyManagerChange for equality.
+func (kmc *KeyManagerChange) Equal(cmp *KeyManagerChange) bool {
+	if kmc == cmp {
+		return true
+	}
+	if kmc == nil || cmp == nil {
+		return false
+	}
+	return kmc.KeyManager.Equal(&cmp.KeyManager) && kmc.Epoch == cmp.Epoch
+}
+
 // SchedulingConstraints are the node scheduling constraints.
 //
 // Multiple fields may be set in which case the ALL the constraints must be satisfied.
@@ -214,6 +273,7 @@ type SchedulingConstraints struct {
 	ValidatorSet *ValidatorSetConstraint `json:"validator_set,omitempty"`
 	MaxNodes     *MaxNodesConstraint     `json:"max_nodes,omitempty"`
 	MinPoolSize  *MinPoolSizeConstraint  `json:"min_pool_size,omitempty"`
+	Features     *FeaturesConstraint     `json:"features,omitempty"`
 }
 
 // ValidatorSetConstraint specifies that the entity must have a node that is part of the validator
@@ -230,6 +290,11 @@ type MinPoolSizeConstraint struct {
 	Limit uint16 `json:"limit"`
 }
 
+// FeaturesConstraint specifies that the node must declare support for all of the given features.
+type FeaturesConstraint struct {
+	Required node.FeaturesMask `json:"required"`
+}
+
 // RuntimeStakingParameters are the stake-related parameters for a runtime.
 type RuntimeStakingParameters struct {
 	// Thresholds are the minimum stake thresholds for a runtime. These per-runtime thresholds are
@@ -290,6 +355,11 @@ const (
 	LatestRuntimeDescriptorVersion = 3
 
 	// Minimum and maximum descriptor versions that are allowed.
+	//
+	// Descriptors are not migrated on deserialization, as re-encoding stored descriptors
+	// would change the consensus state on existing chains. New fields are optional instead,
+	// and descriptors stored in the consensus state are upgraded by consensus upgrade
+	// handlers (see go/upgrade/migrations) when the version is bumped.
 	minRuntimeDescriptorVersion = 3
 	maxRuntimeDescriptorVersion = LatestRuntimeDescriptorVersion
 )
@@ -317,6 +387,9 @@ type Runtime struct { // nolint: maligned
 	// KeyManager is the key manager runtime ID for this runtime.
 	KeyManager *common.Namespace `json:"key_manager,omitempty"`
 
+	// KeyManagerChange is the scheduled replacement of the key manager runtime.
+	KeyManagerChange *KeyManagerChange `json:"key_manager_change,omitempty"`
+
 	// Executor stores parameters of the executor committee.
 	Executor ExecutorParameters `json:"executor,omitempty"`
 
@@ -431,6 +504,16 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 		if r.KeyManager != nil && r.ID.Equal(r.KeyManager) {
 			return fmt.Errorf("compute runtime has self as key manager")
 		}
+		if kmc := r.KeyManagerChange; kmc != nil {
+			switch {
+			case r.KeyManager == nil:
+				return fmt.Errorf("compute runtime without key manager has key manager change")
+			case r.KeyManager.Equal(&kmc.KeyManager):
+				return fmt.Errorf("compute runtime key manager change does not change key manager")
+			case r.ID.Equal(&kmc.KeyManager):
+				return fmt.Errorf("compute runtime key manager change has self as key manager")
+			}
+		}
 
 		if err := r.Executor.ValidateBasic(); err != nil {
 			return fmt.Errorf("bad executor parameters: %w", err)
@@ -446,7 +529,7 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 		if !r.ID.IsKeyManager() {
 			return fmt.Errorf("key manager runtime ID does not have the key manager flag set")
 		}
-		if r.KeyManager != nil {
+		if r.KeyManager != nil || r.KeyManagerChange != nil {
 			return fmt.Errorf("key manager runtime cannot itself have a key manager")
 		}
 
@@ -763,3 +763,230 @@ func TestDeployments(t *testing.T) {
 	})
 	require.Nil(ad)
 }
+
+func TestRuntimeParametersUpdate(t *testing.T) {
+	require := require.New(t)
+
+	var id, otherID common.Namespace
+	require.NoError(id.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(otherID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+
+	rt := &Runtime{
+		ID:       id,
+		Executor: ExecutorParameters{GroupSize: 1},
+		TxnScheduler: TxnSchedulerParameters{
+			MaxBatchSize: 10,
+		},
+		Deployments: []*VersionInfo{{ValidFrom: 0}},
+	}
+
+	update := RuntimeParametersUpdate{ID: id}
+	require.Error(update.ValidateBasic(), "update with no fields set should be invalid")
+
+	update.Executor = &ExecutorParameters{GroupSize: 3}
+	require.NoError(update.ValidateBasic())
+
+	updated, err := update.Apply(rt)
+	require.NoError(err)
+	require.EqualValues(3, updated.Executor.GroupSize, "executor parameters should be updated")
+	require.Equal(rt.TxnScheduler, updated.TxnScheduler, "unset fields should not change")
+	require.Equal(rt.Deployments, updated.Deployments, "unset fields should not change")
+	require.EqualValues(1, rt.Executor.GroupSize, "original descriptor should not change")
+
+	update.Deployments = []*VersionInfo{{ValidFrom: 0}, {Version: version.Version{Major: 1}, ValidFrom: 10}}
+	updated, err = update.Apply(rt)
+	require.NoError(err)
+	require.Len(updated.Deployments, 2, "deployments should be updated")
+
+	update.ID = otherID
+	_, err = update.Apply(rt)
+	require.ErrorIs(err, ErrInvalidArgument, "update for a different runtime should fail")
+}
+
+func TestKeyManagerChange(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID, km1, km2 common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(km1.UnmarshalHex("c000000000000000000000000000000000000000000000000000000000000001"))
+	require.NoError(km2.UnmarshalHex("c000000000000000000000000000000000000000000000000000000000000002"))
+
+	rt := Runtime{
+		Versioned: cbor.NewVersioned(LatestRuntimeDescriptorVersion),
+		ID:        runtimeID,
+		Kind:      KindCompute,
+		Executor: ExecutorParameters{
+			GroupSize:    1,
+			RoundTimeout: 5,
+			MaxMessages:  32,
+		},
+		TxnScheduler: TxnSchedulerParameters{
+			BatchFlushTimeout: time.Second,
+			MaxBatchSize:      1,
+			MaxBatchSizeBytes: 1024,
+			ProposerTimeout:   time.Second,
+		},
+		AdmissionPolicy: RuntimeAdmissionPolicy{
+			AnyNode: &AnyNodeRuntimeAdmissionPolicy{},
+		},
+		GovernanceModel: GovernanceEntity,
+		Deployments:     []*VersionInfo{{ValidFrom: 0}},
+	}
+	require.NoError(rt.ValidateBasic(true), "runtime without key manager should be valid")
+
+	rt.KeyManagerChange = &KeyManagerChange{KeyManager: km2, Epoch: 10}
+	require.Error(rt.ValidateBasic(true), "key manager change without key manager should be invalid")
+
+	rt.KeyManager = &km1
+	require.NoError(rt.ValidateBasic(true), "key manager change should be valid")
+
+	rt.KeyManagerChange.KeyManager = km1
+	require.Error(rt.ValidateBasic(true), "key manager change to the same key manager should be invalid")
+
+	rt.KeyManagerChange.KeyManager = runtimeID
+	require.Error(rt.ValidateBasic(true), "key manager change to self should be invalid")
+
+	var nilChange *KeyManagerChange
+	require.True(nilChange.Equal(nil))
+	require.False(nilChange.Equal(&KeyManagerChange{KeyManager: km2, Epoch: 10}))
+	require.True((&KeyManagerChange{KeyManager: km2, Epoch: 10}).Equal(&KeyManagerChange{KeyManager: km2, Epoch: 10}))
+	require.False((&KeyManagerChange{KeyManager: km2, Epoch: 10}).Equal(&KeyManagerChange{KeyManager: km2, Epoch: 11}))
+}
+
+// newVerifyRuntimeTest returns a valid compute runtime descriptor and registry consensus
+// parameters, together with a function verifying the runtime against the parameters.
+func newVerifyRuntimeTest() (*Runtime, *ConsensusParameters, func(bool) error) {
+	rt := &Runtime{
+		Versioned: cbor.NewVersioned(LatestRuntimeDescriptorVersion),
+		EntityID:  signature.NewPublicKey("1234567890000000000000000000000000000000000000000000000000000000"),
+		Kind:      KindCompute,
+		Deployments: []*VersionInfo{
+			{Version: version.Version{Major: 1}},
+		},
+		Executor: ExecutorParameters{
+			GroupSize:    1,
+			RoundTimeout: 5,
+			MaxMessages:  32,
+		},
+		TxnScheduler: TxnSchedulerParameters{
+			BatchFlushTimeout: time.Second,
+			MaxBatchSize:      1,
+			MaxBatchSizeBytes: 1024,
+			ProposerTimeout:   time.Second,
+		},
+		AdmissionPolicy: RuntimeAdmissionPolicy{
+			AnyNode: &AnyNodeRuntimeAdmissionPolicy{},
+		},
+		GovernanceModel: GovernanceEntity,
+	}
+	params := &ConsensusParameters{
+		EnableRuntimeGovernanceModels: map[RuntimeGovernanceModel]bool{
+			GovernanceEntity: true,
+		},
+	}
+	verify := func(isSanityCheck bool) error {
+		return VerifyRuntime(params, logging.GetLogger("runtime/tests"), rt, false, isSanityCheck, beacon.EpochTime(10))
+	}
+	return rt, params, verify
+}
+
+func TestVerifyRuntimeGatedFeatures(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rtFn     func(*Runtime)
+		enableFn func(*ConsensusParameters)
+	}{
+		{
+			"FeaturesConstraint",
+			func(rt *Runtime) {
+				rt.Constraints = map[api.CommitteeKind]map[api.Role]SchedulingConstraints{
+					api.KindComputeExecutor: {
+						api.RoleWorker: {
+							Features: &FeaturesConstraint{
+								Required: node.FeatureCheckpointServing,
+							},
+						},
+					},
+				}
+			},
+			func(params *ConsensusParameters) { params.EnableNodeFeatures = true },
+		},
+		{
+			"StorageMaxKeySize",
+			func(rt *Runtime) { rt.Storage.MaxKeySize = 64 },
+			func(params *ConsensusParameters) { params.EnableRuntimeStorageLimits = true },
+		},
+		{
+			"StorageMaxValueSize",
+			func(rt *Runtime) { rt.Storage.MaxValueSize = 1024 },
+			func(params *ConsensusParameters) { params.EnableRuntimeStorageLimits = true },
+		},
+		{
+			"StorageReservedKeyPrefixes",
+			func(rt *Runtime) { rt.Storage.ReservedKeyPrefixes = [][]byte{[]byte("__")} },
+			func(params *ConsensusParameters) { params.EnableRuntimeStorageLimits = true },
+		},
+		{
+			"StandbyWorkers",
+			func(rt *Runtime) {
+				rt.Executor.GroupStandbySize = 2
+				rt.Executor.StandbyPromotionThreshold = 3
+			},
+			func(params *ConsensusParameters) { params.EnableStandbyWorkers = true },
+		},
+		{
+			"CommitteeRotation",
+			func(rt *Runtime) { rt.Executor.RotationPercent = 50 },
+			func(params *ConsensusParameters) { params.EnableCommitteeRotation = true },
+		},
+		{
+			"KeyManagerChange",
+			func(rt *Runtime) {
+				km := common.NewTestNamespaceFromSeed([]byte("key manager"), common.NamespaceKeyManager)
+				rt.KeyManager = &km
+				rt.KeyManagerChange = &KeyManagerChange{
+					KeyManager: common.NewTestNamespaceFromSeed([]byte("key manager change"), common.NamespaceKeyManager),
+					Epoch:      10,
+				}
+			},
+			func(params *ConsensusParameters) { params.EnableKeyManagerChange = true },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rt, params, verify := newVerifyRuntimeTest()
+			require.NoError(verify(false), "runtimes without gated features should always be allowed")
+
+			// Gated features are forbidden unless enabled.
+			tc.rtFn(rt)
+			require.ErrorIs(verify(false), ErrForbidden)
+			require.NoError(verify(true), "sanity checks should not depend on the parameter")
+
+			tc.enableFn(params)
+			require.NoError(verify(false))
+		})
+	}
+}
+
+func TestVerifyRuntimeFeaturesConstraint(t *testing.T) {
+	require := require.New(t)
+
+	rt, params, verify := newVerifyRuntimeTest()
+	params.EnableNodeFeatures = true
+	rt.Constraints = map[api.CommitteeKind]map[api.Role]SchedulingConstraints{
+		api.KindComputeExecutor: {
+			api.RoleWorker: {
+				Features: &FeaturesConstraint{
+					Required: node.FeatureCheckpointServing,
+				},
+			},
+		},
+	}
+	require.NoError(verify(false))
+
+	// Unknown features are rejected.
+	rt.Constraints[api.KindComputeExecutor][api.RoleWorker].Features.Required |= node.FeatureArchival << 1
+	require.ErrorIs(verify(false), ErrInvalidArgument)
+	require.ErrorIs(verify(true), ErrInvalidArgument)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+// RuntimeParametersUpdate is a partial update of a runtime descriptor.
+//
+// It allows runtimes using the runtime governance model to update selected parts of their own
+// descriptor (e.g., to schedule upgrades) without having to reproduce the full descriptor.
+// Fields that are not set are left unchanged.
+type RuntimeParametersUpdate struct {
+	// ID is the identifier of the runtime being updated.
+	ID common.Namespace `json:"id"`
+
+	// Deployments are the new runtime deployments.
+	Deployments []*VersionInfo `json:"deployments,omitempty"`
+	// Executor are the new executor committee parameters.
+	Executor *ExecutorParameters `json:"executor,omitempty"`
+	// TxnScheduler are the new transaction scheduling parameters.
+	TxnScheduler *TxnSchedulerParameters `json:"txn_scheduler,omitempty"`
+}
+
+// ValidateBasic performs basic runtime parameters update validity checks.
+func (u *RuntimeParametersUpdate) ValidateBasic() error {
+	if u.Deployments == nil && u.Executor == nil && u.TxnScheduler == nil {
+		return fmt.Errorf("runtime parameters update has no fields set")
+	}
+	return nil
+}
+
+// Apply returns a copy of the given runtime descriptor with the update applied.
+//
+// The resulting descriptor must still be verified as a regular runtime descriptor update.
+func (u *RuntimeParametersUpdate) Apply(rt *Runtime) (*Runtime, error) {
+	if !rt.ID.Equal(&u.ID) {
+		return nil, fmt.Errorf("%w: runtime identifier mismatch", ErrInvalidArgument)
+	}
+
+	updated := *rt
+	if u.Deployments != nil {
+		updated.Deployments = u.Deployments
+	}
+	if u.Executor != nil {
+		updated.Executor = *u.Executor
+	}
+	if u.TxnScheduler != nil {
+		updated.TxnScheduler = *u.TxnScheduler
+	}
+	return &updated, nil
+}
@@ -35,7 +35,17 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.GasCosts == nil &&
 		c.MaxNodeExpiration == nil &&
 		c.EnableRuntimeGovernanceModels == nil &&
-		c.TEEFeatures == nil {
+		c.TEEFeatures == nil &&
+		c.EnableNodeFeatures == nil &&
+		c.EnableEntityMultisig == nil &&
+		c.EnableEntityEscrowRelease == nil &&
+		c.EnableRuntimeSuspensionHistory == nil &&
+		c.EnableRuntimePause == nil &&
+		c.EnableRuntimeStorageLimits == nil &&
+		c.EnableStandbyWorkers == nil &&
+		c.EnableCommitteeRotation == nil &&
+		c.EnableKeyManagerChange == nil &&
+		c.EnableRuntimeParameterUpdates == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -67,6 +77,11 @@ func (g *Genesis) SanityCheck(
 		return err
 	}
 
+	// Check runtime suspensions.
+	if err = SanityCheckRuntimeSuspensions(g.RuntimeSuspensions, runtimesLookup); err != nil {
+		return err
+	}
+
 	// Check nodes.
 	nodeLookup, err := SanityCheckNodes(logger, &g.Parameters, g.Nodes, seenEntities, runtimesLookup, true, baseEpoch, now, height)
 	if err != nil {
@@ -171,6 +186,30 @@ func SanityCheckRuntimes(
 	return lookup, nil
 }
 
+// SanityCheckRuntimeSuspensions examines the runtime suspension statuses.
+func SanityCheckRuntimeSuspensions(
+	suspensions map[common.Namespace]*RuntimeSuspensionStatus,
+	runtimesLookup RuntimeLookup,
+) error {
+	for id, status := range suspensions {
+		if status == nil {
+			return fmt.Errorf("registry: sanity check failed: suspension status of runtime %s is nil", id)
+		}
+		if _, err := runtimesLookup.AnyRuntime(context.Background(), id); err != nil {
+			return fmt.Errorf("registry: sanity check failed: suspension status of unknown runtime %s", id)
+		}
+		if len(status.History) > MaxRuntimeSuspensionHistory {
+			return fmt.Errorf("registry: sanity check failed: suspension history of runtime %s is too long", id)
+		}
+		for _, record := range status.History {
+			if record == nil {
+				return fmt.Errorf("registry: sanity check failed: suspension history of runtime %s contains a nil record", id)
+			}
+		}
+	}
+	return nil
+}
+
 // SanityCheckNodes examines the nodes table.
 // Pass lookups of entities and runtimes from SanityCheckEntities
 // and SanityCheckRuntimes for cross referencing purposes.
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+// MaxRuntimeSuspensionHistory is the maximum number of suspension records
+// kept for each runtime.
+const MaxRuntimeSuspensionHistory = 16
+
+// RuntimeSuspensionReason is the reason why a runtime was suspended.
+type RuntimeSuspensionReason uint8
+
+const (
+	// SuspensionReasonUnknown is used when the suspension reason is not known
+	// (e.g., for runtimes that were already suspended in the genesis document).
+	SuspensionReasonUnknown RuntimeSuspensionReason = 0
+	// SuspensionReasonNoCommittee is used when a runtime is suspended because
+	// no executor committee could be elected for it.
+	SuspensionReasonNoCommittee RuntimeSuspensionReason = 1
+	// SuspensionReasonInsufficientStake is used when a runtime is suspended
+	// because the runtime owner no longer has enough stake to cover the
+	// entity and runtime deposits.
+	SuspensionReasonInsufficientStake RuntimeSuspensionReason = 2
+	// SuspensionReasonPaused is used when a runtime that was not suspended
+	// before was explicitly paused by its owner or via governance.
+	SuspensionReasonPaused RuntimeSuspensionReason = 3
+
+	srUnknown           = "unknown"
+	srNoCommittee       = "no_committee"
+	srInsufficientStake = "insufficient_stake"
+	srPaused            = "paused"
+)
+
+// String returns a string representation of a runtime suspension reason.
+func (r RuntimeSuspensionReason) String() string {
+	reason, err := r.MarshalText()
+	if err != nil {
+		return "[unsupported runtime suspension reason]"
+	}
+	return string(reason)
+}
+
+// MarshalText encodes a runtime suspension reason into text form.
+func (r RuntimeSuspensionReason) MarshalText() ([]byte, error) {
+	switch r {
+	case SuspensionReasonUnknown:
+		return []byte(srUnknown), nil
+	case SuspensionReasonNoCommittee:
+		return []byte(srNoCommittee), nil
+	case SuspensionReasonInsufficientStake:
+		return []byte(srInsufficientStake), nil
+	case SuspensionReasonPaused:
+		return []byte(srPaused), nil
+	default:
+		return nil, fmt.Errorf("unsupported runtime suspension reason: %d", r)
+	}
+}
+
+// UnmarshalText decodes a text slice into a runtime suspension reason.
+func (r *RuntimeSuspensionReason) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case srUnknown:
+		*r = SuspensionReasonUnknown
+	case srNoCommittee:
+		*r = SuspensionReasonNoCommittee
+	case srInsufficientStake:
+		*r = SuspensionReasonInsufficientStake
+	case srPaused:
+		*r = SuspensionReasonPaused
+	default:
+		return fmt.Errorf("unsupported runtime suspension reason: '%s'", string(text))
+	}
+	return nil
+}
+
+// RuntimePauseAuthority is the authority that explicitly paused a runtime.
+type RuntimePauseAuthority uint8
+
+const (
+	// PauseAuthorityOwner is used when a runtime was paused by its owner.
+	PauseAuthorityOwner RuntimePauseAuthority = 1
+	// PauseAuthorityGovernance is used when a runtime was paused via governance.
+	PauseAuthorityGovernance RuntimePauseAuthority = 2
+
+	paOwner      = "owner"
+	paGovernance = "governance"
+)
+
+// String returns a string representation of a runtime pause authority.
+func (a RuntimePauseAuthority) String() string {
+	authority, err := a.MarshalText()
+	if err != nil {
+		return "[unsupported runtime pause authority]"
+	}
+	return string(authority)
+}
+
+// MarshalText encodes a runtime pause authority into text form.
+func (a RuntimePauseAuthority) MarshalText() ([]byte, error) {
+	switch a {
+	case PauseAuthorityOwner:
+		return []byte(paOwner), nil
+	case PauseAuthorityGovernance:
+		return []byte(paGovernance), nil
+	default:
+		return nil, fmt.Errorf("unsupported runtime pause authority: %d", a)
+	}
+}
+
+// UnmarshalText decodes a text slice into a runtime pause authority.
+func (a *RuntimePauseAuthority) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case paOwner:
+		*a = PauseAuthorityOwner
+	case paGovernance:
+		*a = PauseAuthorityGovernance
+	default:
+		return fmt.Errorf("unsupported runtime pause authority: '%s'", string(text))
+	}
+	return nil
+}
+
+// RuntimePause is a record of an explicit runtime pause.
+type RuntimePause struct {
+	// Authority is the authority that paused the runtime. Only the same authority can resume it.
+	Authority RuntimePauseAuthority `json:"authority"`
+	// PausedAt is the consensus height at which the runtime was paused.
+	PausedAt int64 `json:"paused_at"`
+}
+
+// RuntimeSuspension is a record of a single runtime suspension period.
+type RuntimeSuspension struct {
+	// Reason is the reason why the runtime was suspended.
+	Reason RuntimeSuspensionReason `json:"reason"`
+	// SuspendedAt is the consensus height at which the runtime was suspended.
+	SuspendedAt int64 `json:"suspended_at"`
+	// ResumedAt is the consensus height at which the runtime was resumed.
+	//
+	// A value of zero means that the runtime has not been resumed yet.
+	ResumedAt int64 `json:"resumed_at,omitempty"`
+}
+
+// RuntimeSuspensionStatus is the suspension status of a runtime.
+type RuntimeSuspensionStatus struct {
+	// Suspended is true iff the runtime is currently suspended.
+	Suspended bool `json:"suspended"`
+	// History is the suspension history of the runtime, ordered from the
+	// oldest to the most recent suspension.
+	//
+	// At most MaxRuntimeSuspensionHistory records are kept.
+	History []*RuntimeSuspension `json:"history,omitempty"`
+	// Pause is the explicit pause of the runtime, if any.
+	//
+	// Paused runtimes are always suspended and are never resumed automatically. A paused runtime
+	// may additionally be suspended for a different reason (e.g., insufficient stake), in which
+	// case it remains suspended after it is resumed until the other reason no longer applies.
+	Pause *RuntimePause `json:"pause,omitempty"`
+}
+
+// Current returns the record of the current suspension period or nil if the
+// runtime is not currently suspended or the current suspension was not recorded.
+func (s *RuntimeSuspensionStatus) Current() *RuntimeSuspension {
+	if !s.Suspended || len(s.History) == 0 {
+		return nil
+	}
+	current := s.History[len(s.History)-1]
+	if current.ResumedAt != 0 {
+		return nil
+	}
+	return current
+}
+
+// IsPaused returns true iff the runtime is currently explicitly paused.
+func (s *RuntimeSuspensionStatus) IsPaused() bool {
+	return s.Pause != nil
+}
+
+// RecordSuspension records a runtime suspension at the given height.
+func (s *RuntimeSuspensionStatus) RecordSuspension(reason RuntimeSuspensionReason, height int64) {
+	s.Suspended = true
+	s.History = append(s.History, &RuntimeSuspension{
+		Reason:      reason,
+		SuspendedAt: height,
+	})
+	if n := len(s.History); n > MaxRuntimeSuspensionHistory {
+		s.History = s.History[n-MaxRuntimeSuspensionHistory:]
+	}
+}
+
+// RecordResumption records a runtime resumption at the given height.
+func (s *RuntimeSuspensionStatus) RecordResumption(height int64) {
+	if current := s.Current(); current != nil {
+		current.ResumedAt = height
+	}
+	s.Suspended = false
+}
+
+// PauseRuntime is a request to pause or resume a runtime.
+type PauseRuntime struct {
+	// RuntimeID is the identifier of the runtime to pause or resume.
+	RuntimeID common.Namespace `json:"runtime_id"`
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeSuspensionReason(t *testing.T) {
+	require := require.New(t)
+
+	for _, reason := range []RuntimeSuspensionReason{
+		SuspensionReasonUnknown,
+		SuspensionReasonNoCommittee,
+		SuspensionReasonInsufficientStake,
+	} {
+		text, err := reason.MarshalText()
+		require.NoError(err, "MarshalText")
+
+		var decoded RuntimeSuspensionReason
+		err = decoded.UnmarshalText(text)
+		require.NoError(err, "UnmarshalText")
+		require.Equal(reason, decoded, "suspension reason should round-trip")
+	}
+
+	var reason RuntimeSuspensionReason
+	err := reason.UnmarshalText([]byte("invalid"))
+	require.Error(err, "UnmarshalText should fail on unknown reason")
+	_, err = RuntimeSuspensionReason(42).MarshalText()
+	require.Error(err, "MarshalText should fail on unknown reason")
+}
+
+func TestRuntimeSuspensionStatus(t *testing.T) {
+	require := require.New(t)
+
+	var s RuntimeSuspensionStatus
+	require.False(s.Suspended, "default status should not be suspended")
+	require.Nil(s.Current(), "default status should have no current suspension")
+
+	s.RecordSuspension(SuspensionReasonNoCommittee, 10)
+	require.True(s.Suspended, "status should be suspended")
+	require.Len(s.History, 1)
+	require.EqualValues(SuspensionReasonNoCommittee, s.Current().Reason)
+	require.EqualValues(10, s.Current().SuspendedAt)
+	require.EqualValues(0, s.Current().ResumedAt)
+
+	s.RecordResumption(20)
+	require.False(s.Suspended, "status should not be suspended after resumption")
+	require.Nil(s.Current(), "resumed status should have no current suspension")
+	require.EqualValues(20, s.History[0].ResumedAt)
+
+	// Suspensions that were not recorded should have no current suspension.
+	s.Suspended = true
+	require.Nil(s.Current(), "unrecorded suspension should have no current suspension")
+	s.Suspended = false
+
+	// Make sure history is bounded.
+	for i := 0; i < 2*MaxRuntimeSuspensionHistory; i++ {
+		s.RecordSuspension(SuspensionReasonInsufficientStake, int64(100+2*i))
+		s.RecordResumption(int64(101 + 2*i))
+	}
+	require.Len(s.History, MaxRuntimeSuspensionHistory, "history should be bounded")
+	require.EqualValues(100+2*MaxRuntimeSuspensionHistory, s.History[0].SuspendedAt, "oldest records should be pruned")
+}
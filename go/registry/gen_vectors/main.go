@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -75,6 +76,17 @@ func main() {
 				NodeID: nodeSigner.Public(),
 			})
 			vectors = append(vectors, testvectors.MakeTestVector("UnfreezeNode", tx, true))
+
+			// Generate pause and resume runtime transactions.
+			runtimeID := common.NewTestNamespaceFromSeed([]byte("oasis-core registry test vectors: PauseRuntime"), 0)
+			tx = registry.NewPauseRuntimeTx(nonce, fee, &registry.PauseRuntime{
+				RuntimeID: runtimeID,
+			})
+			vectors = append(vectors, testvectors.MakeTestVector("PauseRuntime", tx, true))
+			tx = registry.NewResumeRuntimeTx(nonce, fee, &registry.PauseRuntime{
+				RuntimeID: runtimeID,
+			})
+			vectors = append(vectors, testvectors.MakeTestVector("ResumeRuntime", tx, true))
 		}
 	}
 
@@ -468,6 +468,10 @@ func testRegistryEntityNodes( // nolint: gocyclo
 		expectedDeregEvents := len(nodes[0])
 		deregisteredNodes := make(map[signature.PublicKey]*node.Node)
 
+		expirationCh, expirationSub, err := backend.WatchNodeExpirations(ctx)
+		require.NoError(err, "WatchNodeExpirations")
+		defer expirationSub.Close()
+
 		epoch = beaconTests.MustAdvanceEpoch(t, timeSource)
 
 		var deregEvents int
@@ -518,6 +522,27 @@ func testRegistryEntityNodes( // nolint: gocyclo
 			require.EqualValues(v.UpdatedNode, n, "deregistered node")
 		}
 
+		// Make sure that WatchNodeExpirations also notifies about the expired nodes.
+		expiredNodes := make(map[signature.PublicKey]*node.Node)
+		for len(expiredNodes) < expectedDeregEvents {
+			select {
+			case ev := <-expirationCh:
+				if !ev.Expired {
+					continue
+				}
+				require.EqualValues(epoch, ev.Epoch, "expiration event epoch")
+				require.EqualValues(0, ev.RemainingEpochs(), "expired node should have no remaining epochs")
+				expiredNodes[ev.Node.ID] = ev.Node
+			case <-time.After(recvTimeout):
+				t.Fatalf("failed to receive node expiration event")
+			}
+		}
+		for _, v := range nodes[0] {
+			n, ok := expiredNodes[v.Node.ID]
+			require.True(ok, "got expiration event for node")
+			require.EqualValues(v.UpdatedNode, n, "expired node")
+		}
+
 		// Remove the expired nodes from the test driver's view of
 		// registered nodes.
 		expiredNode := nodes[0][0]
@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -21,6 +22,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 const (
@@ -44,6 +46,9 @@ const (
 	// LogEventHistoryReindexing is a log event value that signals a roothash runtime reindexing
 	// was run.
 	LogEventHistoryReindexing = "roothash/history_reindexing"
+	// LogEventStandbyWorkerPromoted is a log event value that signals a standby worker has been
+	// promoted to replace a failing worker.
+	LogEventStandbyWorkerPromoted = "roothash/standby_worker_promoted"
 )
 
 var (
@@ -130,6 +135,10 @@ type Backend interface {
 	// GetLastRoundResults returns the given runtime's last normal round results.
 	GetLastRoundResults(ctx context.Context, request *RuntimeRequest) (*RoundResults, error)
 
+	// GetRuntimeStateProof returns a proof of the given runtime's latest state and I/O roots
+	// against the consensus state root at the given height.
+	GetRuntimeStateProof(ctx context.Context, request *RuntimeRequest) (*RuntimeStateProof, error)
+
 	// GetIncomingMessageQueueMeta returns the given runtime's incoming message queue metadata.
 	GetIncomingMessageQueueMeta(ctx context.Context, request *RuntimeRequest) (*message.IncomingMessageQueueMeta, error)
 
@@ -413,6 +422,9 @@ type RuntimeState struct {
 
 	// LivenessStatistics contains the liveness statistics for the current epoch.
 	LivenessStatistics *LivenessStatistics `json:"liveness_stats,omitempty"`
+	// DemotedWorkers are the executor workers that have been replaced by standby workers in the
+	// current epoch.
+	DemotedWorkers []signature.PublicKey `json:"demoted_workers,omitempty"`
 }
 
 // AnnotatedBlock is an annotated roothash block.
@@ -451,6 +463,17 @@ func (e *ExecutionDiscrepancyDetectedEvent) EventKind() string {
 	return "execution_discrepancy"
 }
 
+// RoundTimeoutEvent is a round timeout event.
+type RoundTimeoutEvent struct {
+	// Round is the round for which the timeout was triggered.
+	Round uint64 `json:"round"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RoundTimeoutEvent) EventKind() string {
+	return "round_timeout"
+}
+
 var _ events.CustomTypedAttribute = (*RuntimeIDAttribute)(nil)
 
 // RuntimeIDAttribute is the event attribute for specifying runtime ID.
@@ -535,6 +558,7 @@ type Event struct {
 	ExecutionDiscrepancyDetected *ExecutionDiscrepancyDetectedEvent `json:"execution_discrepancy,omitempty"`
 	Finalized                    *FinalizedEvent                    `json:"finalized,omitempty"`
 	InMsgProcessed               *InMsgProcessedEvent               `json:"in_msg_processed,omitempty"`
+	RoundTimeout                 *RoundTimeoutEvent                 `json:"round_timeout,omitempty"`
 }
 
 // MetricsMonitorable is the interface exposed by backends capable of
@@ -545,6 +569,12 @@ type MetricsMonitorable interface {
 	// All blocks from all tracked runtimes will be pushed into the stream
 	// immediately as they are finalized.
 	WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscription)
+
+	// WatchAllEvents returns a channel that produces a stream of events.
+	//
+	// All events from all tracked runtimes will be pushed into the stream
+	// immediately as they are emitted.
+	WatchAllEvents() (<-chan *Event, *pubsub.Subscription)
 }
 
 // GenesisRuntimeState contains state for runtimes that are restored in a genesis block.
@@ -667,6 +697,24 @@ func VerifyRuntimeParameters(rt *registry.Runtime, params *ConsensusParameters)
 	return nil
 }
 
+// RuntimeStateProof is a proof of a runtime's latest state and I/O roots against the consensus
+// state root.
+type RuntimeStateProof struct {
+	// Height is the consensus height of the state against which the proof was generated.
+	//
+	// Note that the corresponding consensus state root is committed to in the block at the next
+	// height.
+	Height int64 `json:"height"`
+
+	// StateRoot is the runtime's latest state root.
+	StateRoot hash.Hash `json:"state_root"`
+	// IORoot is the runtime's latest I/O root.
+	IORoot hash.Hash `json:"io_root"`
+
+	// Proof is the proof of both roots against the consensus state root.
+	Proof syncer.Proof `json:"proof"`
+}
+
 // RoundRoots holds the per-round state and I/O roots that are stored in
 // consensus state.
 type RoundRoots struct {
@@ -168,7 +168,15 @@ func VerifyExecutorCommitment( // nolint: gocyclo
 				return ErrRakSigInvalid
 			}
 
-			if err = commit.Header.VerifyRAK(nodeRt.Capabilities.TEE.RAK); err != nil {
+			// The commitment may be signed by the RAK of any of the node's TEE capabilities.
+			var rakValid bool
+			for _, tee := range nodeRt.Capabilities.TEEs() {
+				if commit.Header.VerifyRAK(tee.RAK) == nil {
+					rakValid = true
+					break
+				}
+			}
+			if !rakValid {
 				return ErrRakSigInvalid
 			}
 		}
@@ -224,9 +232,10 @@ func VerifyExecutorCommitment( // nolint: gocyclo
 func (p *Pool) AddVerifiedExecutorCommitment(c *scheduler.Committee, ec *ExecutorCommitment) error {
 	// Enforce specific roles based on current discrepancy state.
 	switch {
-	case !p.Discrepancy && !c.IsMember(ec.NodeID):
+	case !p.Discrepancy && !c.IsWorker(ec.NodeID) && !c.IsBackupWorker(ec.NodeID):
 		// Discrepancy detection accepts commitments arriving in any order, e.g., a backup worker
-		// can submit a commitment even before there is a discrepancy.
+		// can submit a commitment even before there is a discrepancy. Standby workers do not
+		// participate in rounds until promoted.
 		logger.Debug("node is not in the committee",
 			"round", ec.Header.Header.Round,
 			"node_id", ec.NodeID,
@@ -25,6 +25,8 @@ var (
 	methodGetRuntimeState = serviceName.NewMethod("GetRuntimeState", RuntimeRequest{})
 	// methodGetLastRoundResults is the GetLastRoundResults method.
 	methodGetLastRoundResults = serviceName.NewMethod("GetLastRoundResults", RuntimeRequest{})
+	// methodGetRuntimeStateProof is the GetRuntimeStateProof method.
+	methodGetRuntimeStateProof = serviceName.NewMethod("GetRuntimeStateProof", RuntimeRequest{})
 	// methodGetRoundRoots is the GetRoundRoots method.
 	methodGetRoundRoots = serviceName.NewMethod("GetRoundRoots", RoundRootsRequest{})
 	// methodGetPastRoundRoots is the GetPastRoundRoots method.
@@ -68,6 +70,10 @@ var (
 				MethodName: methodGetLastRoundResults.ShortName(),
 				Handler:    handlerGetLastRoundResults,
 			},
+			{
+				MethodName: methodGetRuntimeStateProof.ShortName(),
+				Handler:    handlerGetRuntimeStateProof,
+			},
 			{
 				MethodName: methodGetRoundRoots.ShortName(),
 				Handler:    handlerGetRoundRoots,
@@ -209,6 +215,29 @@ func handlerGetLastRoundResults(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetRuntimeStateProof(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeStateProof(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStateProof.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeStateProof(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetRoundRoots(
 	srv interface{},
 	ctx context.Context,
@@ -498,6 +527,14 @@ func (c *roothashClient) GetLastRoundResults(ctx context.Context, request *Runti
 	return &rsp, nil
 }
 
+func (c *roothashClient) GetRuntimeStateProof(ctx context.Context, request *RuntimeRequest) (*RuntimeStateProof, error) {
+	var rsp RuntimeStateProof
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStateProof.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *roothashClient) GetRoundRoots(ctx context.Context, request *RoundRootsRequest) (*RoundRoots, error) {
 	var rsp RoundRoots
 	if err := c.conn.Invoke(ctx, methodGetRoundRoots.FullName(), request, &rsp); err != nil {
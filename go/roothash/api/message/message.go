@@ -86,12 +86,17 @@ func (sm *StakingMessage) ValidateBasic() error {
 type RegistryMessage struct {
 	cbor.Versioned
 
-	UpdateRuntime *registry.Runtime `json:"update_runtime,omitempty"`
+	UpdateRuntime           *registry.Runtime                 `json:"update_runtime,omitempty"`
+	UpdateRuntimeParameters *registry.RuntimeParametersUpdate `json:"update_runtime_parameters,omitempty"`
 }
 
 // ValidateBasic performs basic validation of the runtime message.
 func (rm *RegistryMessage) ValidateBasic() error {
 	switch {
+	case rm.UpdateRuntime != nil && rm.UpdateRuntimeParameters != nil:
+		return fmt.Errorf("registry runtime message has multiple fields set")
+	case rm.UpdateRuntimeParameters != nil:
+		return rm.UpdateRuntimeParameters.ValidateBasic()
 	case rm.UpdateRuntime != nil:
 		// The runtime descriptor will already be validated in registerRuntime
 		// in the registry app when it processes the message, so we don't have
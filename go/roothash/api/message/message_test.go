@@ -83,6 +83,9 @@ func TestMessageValidateBasic(t *testing.T) {
 		{"RegistryNoFieldsSet", Message{Registry: &RegistryMessage{}}, false},
 		{"RegistryInvalid", Message{Registry: &RegistryMessage{UpdateRuntime: nil}}, false},
 		{"ValidRegistry", Message{Registry: &RegistryMessage{UpdateRuntime: &registry.Runtime{}}}, true},
+		{"RegistryMultipleFieldsSet", Message{Registry: &RegistryMessage{UpdateRuntime: &registry.Runtime{}, UpdateRuntimeParameters: &registry.RuntimeParametersUpdate{Executor: &registry.ExecutorParameters{}}}}, false},
+		{"RegistryEmptyParametersUpdate", Message{Registry: &RegistryMessage{UpdateRuntimeParameters: &registry.RuntimeParametersUpdate{}}}, false},
+		{"ValidRegistryParametersUpdate", Message{Registry: &RegistryMessage{UpdateRuntimeParameters: &registry.RuntimeParametersUpdate{Executor: &registry.ExecutorParameters{}}}}, true},
 		{"GovernanceNoFieldsSet", Message{Governance: &GovernanceMessage{}}, false},
 		{"GovernanceInvalid", Message{Governance: &GovernanceMessage{CastVote: &api.ProposalVote{}, SubmitProposal: &api.ProposalContent{}}}, false},
 		{"GovernanceValid", Message{Governance: &GovernanceMessage{CastVote: &api.ProposalVote{}}}, true},
@@ -24,9 +24,49 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	rootHashExecutorCommitments = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_roothash_executor_commitments",
+			Help: "Number of processed executor commitments.",
+		},
+		[]string{"runtime"},
+	)
+	rootHashRoundCommitments = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_roothash_round_commitments",
+			Help: "Number of executor commitments received per finalized round.",
+		},
+		[]string{"runtime"},
+	)
+	rootHashExecutionDiscrepancies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_roothash_execution_discrepancies",
+			Help: "Number of detected execution discrepancies.",
+		},
+		[]string{"runtime"},
+	)
+	rootHashRoundTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_roothash_round_timeouts",
+			Help: "Number of triggered round timeouts.",
+		},
+		[]string{"runtime"},
+	)
+	rootHashBackupWorkerInvocations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_roothash_backup_worker_invocations",
+			Help: "Number of rounds in which backup workers were invoked.",
+		},
+		[]string{"runtime"},
+	)
 	rootHashCollectors = []prometheus.Collector{
 		rootHashFinalizedRounds,
 		rootHashBlockInterval,
+		rootHashExecutorCommitments,
+		rootHashRoundCommitments,
+		rootHashExecutionDiscrepancies,
+		rootHashRoundTimeouts,
+		rootHashBackupWorkerInvocations,
 	}
 
 	_ api.Backend = (*metricsWrapper)(nil)
@@ -65,6 +105,48 @@ func (w *metricsWrapper) worker() {
 	}
 }
 
+func (w *metricsWrapper) eventWorker() {
+	backend, ok := w.Backend.(api.MetricsMonitorable)
+	if !ok {
+		return
+	}
+
+	ch, sub := backend.WatchAllEvents()
+	defer sub.Close()
+
+	roundCommitments := make(map[common.Namespace]int)
+	for {
+		ev, ok := <-ch
+		if !ok {
+			break
+		}
+
+		labels := prometheus.Labels{"runtime": ev.RuntimeID.String()}
+		switch {
+		case ev.ExecutorCommitted != nil:
+			rootHashExecutorCommitments.With(labels).Inc()
+			roundCommitments[ev.RuntimeID]++
+		case ev.ExecutionDiscrepancyDetected != nil:
+			// Backup workers are invoked both on discrepancies and when the primary workers
+			// fail to submit commitments before the round timeout.
+			if !ev.ExecutionDiscrepancyDetected.Timeout {
+				rootHashExecutionDiscrepancies.With(labels).Inc()
+			}
+			rootHashBackupWorkerInvocations.With(labels).Inc()
+		case ev.RoundTimeout != nil:
+			rootHashRoundTimeouts.With(labels).Inc()
+		case ev.Finalized != nil:
+			// Skip blocks which were not generated by executors (e.g., epoch transitions).
+			n, ok := roundCommitments[ev.RuntimeID]
+			if !ok {
+				continue
+			}
+			rootHashRoundCommitments.With(labels).Observe(float64(n))
+			delete(roundCommitments, ev.RuntimeID)
+		}
+	}
+}
+
 // NewMetricsWrapper wraps a roothash backend implementation with instrumentation.
 func NewMetricsWrapper(base api.Backend) api.Backend {
 	metricsOnce.Do(func() {
@@ -73,6 +155,7 @@ func NewMetricsWrapper(base api.Backend) api.Backend {
 
 	w := &metricsWrapper{Backend: base}
 	go w.worker()
+	go w.eventWorker()
 
 	return w
 }
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// Fee is a runtime transaction fee.
+//
+// Runtime transactions are opaque to the runtime client, so the fee is only used to communicate
+// between the fee policy and the transaction builder which encodes it into the transaction.
+type Fee struct {
+	// Gas is the maximum amount of gas the transaction may use.
+	Gas uint64 `json:"gas"`
+	// GasPrice is the price paid per unit of gas.
+	GasPrice quantity.Quantity `json:"gas_price"`
+}
+
+// Amount returns the total fee amount, which is the gas multiplied by the gas price.
+func (f *Fee) Amount() (*quantity.Quantity, error) {
+	amount := f.GasPrice.Clone()
+	if err := amount.Mul(quantity.NewFromUint64(f.Gas)); err != nil {
+		return nil, err
+	}
+	return amount, nil
+}
+
+// FeePolicy is a policy that decides on the fees paid by runtime transactions submitted via
+// SubmitTxWithFeePolicy.
+type FeePolicy interface {
+	// EstimateFee returns the fee paid by the initial submission of a transaction.
+	EstimateFee(ctx context.Context, runtimeID common.Namespace) (*Fee, error)
+
+	// BumpFee returns the fee paid by the next resubmission of a transaction, given the number
+	// of failed attempts so far and the fee and the error of the last failed attempt.
+	//
+	// In case the transaction should not be resubmitted, an error should be returned.
+	BumpFee(ctx context.Context, runtimeID common.Namespace, attempts int, fee *Fee, err error) (*Fee, error)
+}
+
+// TxBuilder builds a signed runtime transaction that pays the given fee.
+type TxBuilder func(fee *Fee) ([]byte, error)
+
+// SubmitTxWithFeePolicy submits a runtime transaction built by the given builder and waits for
+// its execution results, using the given fee policy to set the fee of the transaction and to
+// bump it in case the transaction needs to be resubmitted.
+//
+// A transaction is resubmitted in case it fails the transaction check, in case it expires or in
+// case it is not executed within the given attempt timeout (zero means no timeout).
+func SubmitTxWithFeePolicy(
+	ctx context.Context,
+	client RuntimeClient,
+	runtimeID common.Namespace,
+	policy FeePolicy,
+	build TxBuilder,
+	attemptTimeout time.Duration,
+) (*SubmitTxMetaResponse, error) {
+	fee, err := policy.EstimateFee(ctx, runtimeID)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to estimate fee: %w", err)
+	}
+
+	for attempts := 1; ; attempts++ {
+		var rsp *SubmitTxMetaResponse
+		rsp, err = submitTxAttempt(ctx, client, runtimeID, build, fee, attemptTimeout)
+		if err == nil {
+			return rsp, nil
+		}
+		if ctx.Err() != nil {
+			// The caller's context was canceled, abort.
+			return nil, err
+		}
+
+		if fee, err = policy.BumpFee(ctx, runtimeID, attempts, fee, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func submitTxAttempt(
+	ctx context.Context,
+	client RuntimeClient,
+	runtimeID common.Namespace,
+	build TxBuilder,
+	fee *Fee,
+	timeout time.Duration,
+) (*SubmitTxMetaResponse, error) {
+	data, err := build(fee)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to build transaction: %w", err)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	rsp, err := client.SubmitTxMeta(ctx, &SubmitTxRequest{
+		RuntimeID: runtimeID,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.CheckTxError != nil {
+		return nil, cmnErrors.WithContext(ErrCheckTxFailed, rsp.CheckTxError.String())
+	}
+	return rsp, nil
+}
+
+// DefaultFeePolicy is a fee policy that initially pays a fixed gas price and increases it by a
+// fixed percentage on each resubmission.
+type DefaultFeePolicy struct {
+	// Gas is the maximum amount of gas a transaction may use.
+	Gas uint64
+	// GasPrice is the initial gas price.
+	GasPrice quantity.Quantity
+	// MaxGasPrice is the maximum gas price. Zero means no limit.
+	MaxGasPrice quantity.Quantity
+	// BumpPercent is the percentage by which the gas price is increased on each resubmission.
+	BumpPercent uint64
+	// MaxAttempts is the maximum number of submission attempts.
+	MaxAttempts int
+}
+
+// NewDefaultFeePolicy creates a new default fee policy that pays the given gas price, increasing
+// it by 10% on each of at most three resubmissions.
+func NewDefaultFeePolicy(gas uint64, gasPrice *quantity.Quantity) *DefaultFeePolicy {
+	return &DefaultFeePolicy{
+		Gas:         gas,
+		GasPrice:    *gasPrice.Clone(),
+		BumpPercent: 10,
+		MaxAttempts: 4,
+	}
+}
+
+// EstimateFee implements FeePolicy.
+func (p *DefaultFeePolicy) EstimateFee(context.Context, common.Namespace) (*Fee, error) {
+	return &Fee{
+		Gas:      p.Gas,
+		GasPrice: *p.GasPrice.Clone(),
+	}, nil
+}
+
+// BumpFee implements FeePolicy.
+func (p *DefaultFeePolicy) BumpFee(_ context.Context, _ common.Namespace, attempts int, fee *Fee, submitErr error) (*Fee, error) {
+	switch {
+	case errors.Is(submitErr, ErrCheckTxFailed),
+		errors.Is(submitErr, ErrTransactionExpired),
+		errors.Is(submitErr, context.DeadlineExceeded):
+	default:
+		// Other errors are not caused by the fee.
+		return nil, submitErr
+	}
+	if attempts >= p.MaxAttempts {
+		return nil, fmt.Errorf("client: giving up after %d attempts: %w", attempts, submitErr)
+	}
+
+	// Increase the gas price by the configured percentage, but at least by one.
+	bump := fee.GasPrice.Clone()
+	if err := bump.Mul(quantity.NewFromUint64(p.BumpPercent)); err != nil {
+		return nil, err
+	}
+	if err := bump.Quo(quantity.NewFromUint64(100)); err != nil {
+		return nil, err
+	}
+	if bump.IsZero() {
+		bump = quantity.NewFromUint64(1)
+	}
+	gasPrice := fee.GasPrice.Clone()
+	if err := gasPrice.Add(bump); err != nil {
+		return nil, err
+	}
+
+	if !p.MaxGasPrice.IsZero() && gasPrice.Cmp(&p.MaxGasPrice) > 0 {
+		if fee.GasPrice.Cmp(&p.MaxGasPrice) >= 0 {
+			return nil, fmt.Errorf("client: maximum gas price reached: %w", submitErr)
+		}
+		gasPrice = p.MaxGasPrice.Clone()
+	}
+
+	return &Fee{
+		Gas:      fee.Gas,
+		GasPrice: *gasPrice,
+	}, nil
+}
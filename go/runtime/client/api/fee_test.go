@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// feeTestClient is a runtime client that only accepts transactions paying at least the given gas
+// price, and never executes transactions paying exactly the stuck gas price.
+type feeTestClient struct {
+	RuntimeClient
+
+	minGasPrice   quantity.Quantity
+	stuckGasPrice quantity.Quantity
+	submitted     []Fee
+}
+
+func (c *feeTestClient) SubmitTxMeta(ctx context.Context, request *SubmitTxRequest) (*SubmitTxMetaResponse, error) {
+	var fee Fee
+	if err := cbor.Unmarshal(request.Data, &fee); err != nil {
+		return nil, err
+	}
+	c.submitted = append(c.submitted, fee)
+
+	switch {
+	case fee.GasPrice.Cmp(&c.minGasPrice) < 0:
+		return &SubmitTxMetaResponse{
+			CheckTxError: &protocol.Error{Module: "test", Code: 1, Message: "gas price too low"},
+		}, nil
+	case fee.GasPrice.Cmp(&c.stuckGasPrice) == 0:
+		<-ctx.Done()
+		return nil, ctx.Err()
+	default:
+		return &SubmitTxMetaResponse{Output: request.Data, Round: 1}, nil
+	}
+}
+
+func TestSubmitTxWithFeePolicy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("fee policy test ns"), 0)
+	build := func(fee *Fee) ([]byte, error) {
+		return cbor.Marshal(fee), nil
+	}
+	gasPrices := func(fees []Fee) []uint64 {
+		var prices []uint64
+		for _, fee := range fees {
+			prices = append(prices, fee.GasPrice.ToBigInt().Uint64())
+		}
+		return prices
+	}
+
+	// Transaction check failures and timeouts should bump the fee.
+	client := &feeTestClient{
+		minGasPrice:   *quantity.NewFromUint64(110),
+		stuckGasPrice: *quantity.NewFromUint64(110),
+	}
+	policy := NewDefaultFeePolicy(1000, quantity.NewFromUint64(100))
+	rsp, err := SubmitTxWithFeePolicy(ctx, client, runtimeID, policy, build, 10*time.Millisecond)
+	require.NoError(err, "SubmitTxWithFeePolicy")
+	require.EqualValues(1, rsp.Round)
+	require.Equal([]uint64{100, 110, 121}, gasPrices(client.submitted))
+
+	var fee Fee
+	require.NoError(cbor.Unmarshal(rsp.Output, &fee))
+	amount, err := fee.Amount()
+	require.NoError(err, "Amount")
+	require.EqualValues(*quantity.NewFromUint64(121_000), *amount)
+
+	// The number of attempts should be limited.
+	client = &feeTestClient{
+		minGasPrice: *quantity.NewFromUint64(1000),
+	}
+	_, err = SubmitTxWithFeePolicy(ctx, client, runtimeID, policy, build, 0)
+	require.ErrorIs(err, ErrCheckTxFailed)
+	require.Len(client.submitted, policy.MaxAttempts)
+
+	// The gas price should be capped.
+	client = &feeTestClient{
+		minGasPrice: *quantity.NewFromUint64(1000),
+	}
+	policy.MaxGasPrice = *quantity.NewFromUint64(115)
+	_, err = SubmitTxWithFeePolicy(ctx, client, runtimeID, policy, build, 0)
+	require.ErrorIs(err, ErrCheckTxFailed)
+	require.Equal([]uint64{100, 110, 115}, gasPrices(client.submitted))
+}
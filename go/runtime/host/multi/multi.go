@@ -144,6 +144,22 @@ func (agg *Aggregate) GetActiveVersion() (*version.Version, error) {
 	return &agg.active.version, nil
 }
 
+// GetRunningVersions returns the versions of the sub-hosts that have been started, the active
+// version first, followed by the next version if it has been started in advance.
+func (agg *Aggregate) GetRunningVersions() []version.Version {
+	agg.l.RLock()
+	defer agg.l.RUnlock()
+
+	var versions []version.Version
+	if agg.active != nil {
+		versions = append(versions, agg.active.version)
+	}
+	if agg.next != nil {
+		versions = append(versions, agg.next.version)
+	}
+	return versions
+}
+
 // GetInfo implements host.Runtime.
 func (agg *Aggregate) GetInfo(ctx context.Context) (*protocol.RuntimeInfoResponse, error) {
 	active, err := agg.getActiveHost()
@@ -0,0 +1,82 @@
+package sgx
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+const (
+	// cpuInfoPath is the path to the CPU information file.
+	cpuInfoPath = "/proc/cpuinfo"
+	// cpuFlagSGXLC is the CPU flag indicating Flexible Launch Control support.
+	cpuFlagSGXLC = "sgx_lc"
+	// epcSizeGlob is the glob matching the per-NUMA node enclave page cache size files.
+	epcSizeGlob = "/sys/devices/system/node/node*/x86/sgx_total_bytes"
+)
+
+// platformDetails returns the TEE hardware details of the local platform for the given
+// attestation type.
+//
+// Details which cannot be determined are left unset.
+func platformDetails(attestationType node.SGXAttestationType) *node.TEEDetails {
+	return &node.TEEDetails{
+		SGX: &node.SGXDetails{
+			AttestationType: attestationType,
+			// ECDSA-based attestation is only possible on platforms with FLC support.
+			FLC:     attestationType == node.SGXAttestationTypeDCAP || hasCPUFlag(cpuFlagSGXLC),
+			EPCSize: epcSize(),
+		},
+	}
+}
+
+// hasCPUFlag returns true iff the CPU reports the given flag.
+func hasCPUFlag(flag string) bool {
+	f, err := os.Open(cpuInfoPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "flags" {
+			continue
+		}
+		for _, v := range strings.Fields(value) {
+			if v == flag {
+				return true
+			}
+		}
+		// All CPUs report the same flags.
+		return false
+	}
+	return false
+}
+
+// epcSize returns the total size of the enclave page cache in bytes or zero if unknown.
+func epcSize() uint64 {
+	paths, err := filepath.Glob(epcSizeGlob)
+	if err != nil {
+		return 0
+	}
+
+	var total uint64
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0
+		}
+		size, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0
+		}
+		total += size
+	}
+	return total
+}
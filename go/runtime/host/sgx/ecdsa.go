@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/aesm"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -78,3 +79,7 @@ func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn pr
 	}
 	return sgxCommon.UpdateRuntimeQuote(ctx, conn, quoteBundle)
 }
+
+func (ec *teeStateECDSA) AttestationType() node.SGXAttestationType {
+	return node.SGXAttestationTypeDCAP
+}
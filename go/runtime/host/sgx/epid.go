@@ -208,3 +208,7 @@ func (ep *teeStateEPID) update(
 
 	return attestation, nil
 }
+
+func (ep *teeStateEPID) AttestationType() node.SGXAttestationType {
+	return node.SGXAttestationTypeEPID
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
@@ -41,3 +42,7 @@ func (ec *teeStateMock) Update(ctx context.Context, sp *sgxProvisioner, conn pro
 	}
 	return sgxCommon.UpdateRuntimeQuote(ctx, conn, quoteBundle)
 }
+
+func (ec *teeStateMock) AttestationType() node.SGXAttestationType {
+	return node.SGXAttestationTypeDCAP
+}
@@ -91,6 +91,9 @@ type teeStateImpl interface {
 
 	// Update updates the TEE state and returns a new attestation.
 	Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string) ([]byte, error)
+
+	// AttestationType returns the remote attestation type used by the TEE state.
+	AttestationType() node.SGXAttestationType
 }
 
 type teeState struct {
@@ -142,6 +145,22 @@ func (ts *teeState) updateTargetInfo(ctx context.Context, sp *sgxProvisioner) ([
 	return ts.impl.Init(ctx, sp, ts.cfg)
 }
 
+func (ts *teeState) details(ctx context.Context, sp *sgxProvisioner) (*node.TEEDetails, error) {
+	if ts.impl == nil {
+		return nil, fmt.Errorf("not initialized")
+	}
+
+	regParams, err := sp.consensus.Registry().ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine registry consensus parameters: %w", err)
+	}
+	if regParams.TEEFeatures == nil || !regParams.TEEFeatures.HardwareDetails {
+		return nil, nil
+	}
+
+	return platformDetails(ts.impl.AttestationType()), nil
+}
+
 func (ts *teeState) update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string) ([]byte, error) {
 	if ts.impl == nil {
 		return nil, fmt.Errorf("not initialized")
@@ -427,6 +446,11 @@ func (s *sgxProvisioner) updateCapabilityTEE(ctx context.Context, ts *teeState,
 		Attestation: attestation,
 	}
 
+	// Report TEE hardware details when supported by the consensus layer.
+	if capabilityTEE.Details, err = ts.details(ctx, s); err != nil {
+		return nil, err
+	}
+
 	// Endorse TEE capability to support authenticated inter-component EnclaveRPC.
 	sgxCommon.EndorseCapabilityTEE(ctx, s.identity, capabilityTEE, conn, s.logger)
 
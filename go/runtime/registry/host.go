@@ -136,6 +136,19 @@ func (n *RuntimeHostNode) GetHostedRuntimeActiveVersion() (*version.Version, err
 	return agg.GetActiveVersion()
 }
 
+// GetHostedRuntimeRunningVersions returns the versions of the hosted runtime that are running.
+func (n *RuntimeHostNode) GetHostedRuntimeRunningVersions() ([]version.Version, error) {
+	n.Lock()
+	agg := n.agg
+	n.Unlock()
+
+	if agg == nil {
+		return nil, fmt.Errorf("runtime not available")
+	}
+
+	return agg.GetRunningVersions(), nil
+}
+
 // GetHostedRuntimeCapabilityTEE returns the CapabilityTEE for the active runtime version.
 //
 // It may be nil in case the CapabilityTEE is not available or if the runtime is not running
@@ -633,8 +646,8 @@ func (n *runtimeHostNotifier) watchPolicyUpdates() {
 				n.watchKmPolicyUpdates(ctx, kmRtID)
 			}(kmRtID)
 
-			// Restart the updater if the runtime changes the key manager. This should happen
-			// at most once as runtimes are not allowed to change the manager once set.
+			// Restart the updater if the runtime changes the key manager. This happens when
+			// the key manager is first set and when a scheduled key manager change activates.
 			for {
 				select {
 				case <-n.ctx.Done():
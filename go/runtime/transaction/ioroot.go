@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+// IOArtifacts are the artifacts of a single transaction from which an I/O root is built.
+type IOArtifacts struct {
+	// Input is the transaction input.
+	Input []byte
+	// Output is the transaction output (if available).
+	Output []byte
+	// Tags are the tags emitted by the transaction (if any).
+	Tags Tags
+}
+
+// NewEmptyIORoot returns an empty I/O root for the given runtime round.
+func NewEmptyIORoot(runtimeID common.Namespace, round uint64) node.Root {
+	root := node.Root{
+		Namespace: runtimeID,
+		Version:   round,
+		Type:      node.RootTypeIO,
+	}
+	root.Hash.Empty()
+	return root
+}
+
+// BuildIORoot builds the I/O root for the given runtime round from a batch of transactions in
+// batch order, the same way as it is done by the compute nodes executing the batch.
+//
+// If transaction outputs are omitted, the resulting root is the input root of the batch.
+//
+// The I/O root is built in memory, so no storage backend is required.
+func BuildIORoot(
+	ctx context.Context,
+	runtimeID common.Namespace,
+	round uint64,
+	batch []IOArtifacts,
+) (writelog.WriteLog, hash.Hash, error) {
+	tree := NewTree(nil, NewEmptyIORoot(runtimeID, round))
+	defer tree.Close()
+
+	for idx, artifacts := range batch {
+		tx := Transaction{
+			Input:      artifacts.Input,
+			Output:     artifacts.Output,
+			BatchOrder: uint32(idx),
+		}
+		if err := tree.AddTransaction(ctx, tx, artifacts.Tags); err != nil {
+			return nil, hash.Hash{}, err
+		}
+	}
+
+	return tree.Commit(ctx)
+}
+
+// VerifyIORoot verifies that the I/O root built from the given batch of transactions matches
+// the expected I/O root hash.
+func VerifyIORoot(
+	ctx context.Context,
+	runtimeID common.Namespace,
+	round uint64,
+	batch []IOArtifacts,
+	expected hash.Hash,
+) error {
+	_, ioRoot, err := BuildIORoot(ctx, runtimeID, round, batch)
+	if err != nil {
+		return err
+	}
+	if !ioRoot.Equal(&expected) {
+		return fmt.Errorf("transaction: I/O root mismatch (expected: %s got: %s)", expected, ioRoot)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+func TestBuildIORoot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var runtimeID common.Namespace
+	const round = 42
+
+	var batch []IOArtifacts
+	for i := 0; i < 10; i++ {
+		batch = append(batch, IOArtifacts{
+			Input:  []byte(fmt.Sprintf("input %d", i)),
+			Output: []byte(fmt.Sprintf("output %d", i)),
+			Tags: Tags{
+				&Tag{Key: []byte("tag"), Value: []byte(fmt.Sprintf("value %d", i))},
+			},
+		})
+	}
+
+	// An empty batch should result in an empty root.
+	_, ioRoot, err := BuildIORoot(ctx, runtimeID, round, nil)
+	require.NoError(err, "BuildIORoot")
+	var emptyHash hash.Hash
+	emptyHash.Empty()
+	require.EqualValues(emptyHash, ioRoot, "empty batch should result in an empty root")
+
+	// The root should match the one built incrementally using the transaction tree.
+	writeLog, ioRoot, err := BuildIORoot(ctx, runtimeID, round, batch)
+	require.NoError(err, "BuildIORoot")
+	require.NotEmpty(writeLog, "write log should not be empty")
+
+	tree := NewTree(nil, NewEmptyIORoot(runtimeID, round))
+	defer tree.Close()
+	for idx, artifacts := range batch {
+		err = tree.AddTransaction(ctx, Transaction{
+			Input:      artifacts.Input,
+			Output:     artifacts.Output,
+			BatchOrder: uint32(idx),
+		}, artifacts.Tags)
+		require.NoError(err, "AddTransaction")
+	}
+	_, expectedRoot, err := tree.Commit(ctx)
+	require.NoError(err, "Commit")
+	require.EqualValues(expectedRoot, ioRoot, "I/O root should match the incrementally built root")
+
+	err = VerifyIORoot(ctx, runtimeID, round, batch, expectedRoot)
+	require.NoError(err, "VerifyIORoot")
+
+	// Reordering the batch should result in a different root.
+	batch[0], batch[1] = batch[1], batch[0]
+	err = VerifyIORoot(ctx, runtimeID, round, batch, expectedRoot)
+	require.Error(err, "VerifyIORoot should fail for a reordered batch")
+
+	// Omitting outputs should result in the input root.
+	inputs := make([]IOArtifacts, 0, len(batch))
+	for _, artifacts := range batch {
+		inputs = append(inputs, IOArtifacts{Input: artifacts.Input})
+	}
+	_, inputRoot, err := BuildIORoot(ctx, runtimeID, round, inputs)
+	require.NoError(err, "BuildIORoot")
+	require.NotEqualValues(expectedRoot, inputRoot, "input root should differ from the I/O root")
+
+	// Transactions without inputs should be rejected.
+	_, _, err = BuildIORoot(ctx, runtimeID, round, []IOArtifacts{{Output: []byte("output")}})
+	require.Error(err, "BuildIORoot should fail for transactions without inputs")
+}
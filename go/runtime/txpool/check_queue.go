@@ -8,12 +8,20 @@ import (
 )
 
 type checkTxQueue struct {
-	l sync.Mutex
+	l    sync.Mutex
+	cond *sync.Cond
 
-	txs *deque.Deque[*PendingCheckTransaction]
+	txs     *deque.Deque[*PendingCheckTransaction]
+	retries *deque.Deque[*PendingCheckTransaction]
 
 	maxSize      int
 	maxBatchSize int
+
+	// Batches are numbered in the order in which they are popped so that their results can be
+	// processed in the same order, even when they are checked concurrently.
+	nextSeq    uint64
+	turnSeq    uint64
+	retryUntil uint64
 }
 
 func (cq *checkTxQueue) add(pct *PendingCheckTransaction) error {
@@ -21,7 +29,7 @@ func (cq *checkTxQueue) add(pct *PendingCheckTransaction) error {
 	defer cq.l.Unlock()
 
 	// Check if there is room in the queue.
-	if cq.txs.Len() >= cq.maxSize {
+	if cq.txs.Len()+cq.retries.Len() >= cq.maxSize {
 		return fmt.Errorf("check queue is full")
 	}
 
@@ -30,43 +38,76 @@ func (cq *checkTxQueue) add(pct *PendingCheckTransaction) error {
 	return nil
 }
 
-func (cq *checkTxQueue) retryBatch(pcts []*PendingCheckTransaction) {
+// pop returns the next batch of transactions to check together with its sequence number.
+//
+// Every non-empty batch must be processed during its turn, see waitTurn and finishTurn.
+func (cq *checkTxQueue) pop() ([]*PendingCheckTransaction, uint64) {
 	cq.l.Lock()
 	defer cq.l.Unlock()
 
-	// NOTE: This is meant for retries so it ignores the size limit on purpose.
-	for _, pct := range pcts {
-		cq.txs.PushFront(pct)
+	// Wait for all batches that need to be retried to be queued again, as otherwise newer
+	// transactions could be checked before them.
+	if cq.turnSeq < cq.retryUntil {
+		return nil, 0
+	}
+
+	var batch []*PendingCheckTransaction
+	for _, txs := range []*deque.Deque[*PendingCheckTransaction]{cq.retries, cq.txs} {
+		for txs.Len() > 0 && len(batch) < cq.maxBatchSize {
+			batch = append(batch, txs.PopFront())
+		}
+	}
+	if len(batch) == 0 {
+		return nil, 0
 	}
+
+	seq := cq.nextSeq
+	cq.nextSeq++
+	return batch, seq
 }
 
-func (cq *checkTxQueue) pop() []*PendingCheckTransaction {
+// waitTurn waits until all batches popped before the given batch have been processed.
+//
+// If the check of the batch failed, or the check of an earlier batch failed and the batch
+// would otherwise be processed out of order, the batch is queued to be checked again before
+// any other transactions and true is returned.
+func (cq *checkTxQueue) waitTurn(seq uint64, batch []*PendingCheckTransaction, failed bool) bool {
 	cq.l.Lock()
 	defer cq.l.Unlock()
 
-	var batch []*PendingCheckTransaction
-	for {
-		if cq.txs.Len() == 0 {
-			break
-		}
+	for cq.turnSeq != seq {
+		cq.cond.Wait()
+	}
 
-		// Check if the batch already has enough transactions.
-		if len(batch) >= cq.maxBatchSize {
-			break
-		}
+	if failed {
+		// Retry all batches popped so far, so they are checked again after this one.
+		cq.retryUntil = cq.nextSeq
+	}
+	if seq >= cq.retryUntil {
+		return false
+	}
 
-		tx := cq.txs.PopFront()
-		batch = append(batch, tx)
+	// NOTE: This is meant for retries so it ignores the size limit on purpose.
+	for _, pct := range batch {
+		cq.retries.PushBack(pct)
 	}
+	return true
+}
+
+// finishTurn finishes processing of the batch whose turn it is.
+func (cq *checkTxQueue) finishTurn() {
+	cq.l.Lock()
+	defer cq.l.Unlock()
 
-	return batch
+	cq.turnSeq++
+	cq.cond.Broadcast()
 }
 
 func (cq *checkTxQueue) size() int {
 	cq.l.Lock()
 	defer cq.l.Unlock()
 
-	return cq.txs.Len()
+	return cq.txs.Len() + cq.retries.Len()
 }
 
 func (cq *checkTxQueue) clear() {
@@ -74,12 +115,16 @@ func (cq *checkTxQueue) clear() {
 	defer cq.l.Unlock()
 
 	cq.txs.Clear()
+	cq.retries.Clear()
 }
 
 func newCheckTxQueue(maxSize, maxBatchSize int) *checkTxQueue {
-	return &checkTxQueue{
+	cq := &checkTxQueue{
 		txs:          deque.New[*PendingCheckTransaction](0, 512),
+		retries:      deque.New[*PendingCheckTransaction](),
 		maxSize:      maxSize,
 		maxBatchSize: maxBatchSize,
 	}
+	cq.cond = sync.NewCond(&cq.l)
+	return cq
 }
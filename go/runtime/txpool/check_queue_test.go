@@ -3,6 +3,7 @@ package txpool
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -35,7 +36,7 @@ func TestCheckTxQueueBasic(t *testing.T) {
 
 	require.EqualValues(t, 51, queue.size(), "Size")
 
-	batch := queue.pop()
+	batch, _ := queue.pop()
 	require.EqualValues(t, 10, len(batch), "Batch size")
 	require.EqualValues(t, 41, queue.size(), "Size")
 
@@ -51,14 +52,76 @@ func TestCheckTxQueueBasic(t *testing.T) {
 func TestCheckTxQueuePop(t *testing.T) {
 	queue := newCheckTxQueue(51, 10)
 
-	batch := queue.pop()
+	batch, _ := queue.pop()
 	require.EqualValues(t, 0, len(batch), "Batch size")
 	require.EqualValues(t, 0, queue.size(), "Size")
 
 	err := queue.add(newPendingTx([]byte("hello world")))
 	require.NoError(t, err, "Add")
 
-	batch = queue.pop()
+	batch, _ = queue.pop()
 	require.EqualValues(t, 1, len(batch), "Batch size")
 	require.EqualValues(t, 0, queue.size(), "Size")
 }
+
+func TestCheckTxQueueOrder(t *testing.T) {
+	require := require.New(t)
+
+	queue := newCheckTxQueue(50, 2)
+	for i := 0; i < 8; i++ {
+		err := queue.add(newPendingTx([]byte(fmt.Sprintf("call %d", i))))
+		require.NoError(err, "Add")
+	}
+	raws := func(batch []*PendingCheckTransaction) []string {
+		var raws []string
+		for _, pct := range batch {
+			raws = append(raws, string(pct.Raw()))
+		}
+		return raws
+	}
+
+	batch0, seq0 := queue.pop()
+	batch1, seq1 := queue.pop()
+	batch2, seq2 := queue.pop()
+
+	// Later batches need to wait for earlier ones to be processed.
+	doneCh := make(chan bool)
+	go func() {
+		retry := queue.waitTurn(seq1, batch1, false)
+		queue.finishTurn()
+		doneCh <- retry
+	}()
+	select {
+	case <-doneCh:
+		require.Fail("batch should wait for its turn")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.False(queue.waitTurn(seq0, batch0, false), "successful batch should not be retried")
+	queue.finishTurn()
+	require.False(<-doneCh, "successful batch should not be retried")
+
+	// A failed batch should be retried together with all batches popped after it.
+	batch3, seq3 := queue.pop()
+	require.True(queue.waitTurn(seq2, batch2, true), "failed batch should be retried")
+	queue.finishTurn()
+
+	batch, _ := queue.pop()
+	require.Empty(batch, "no batches should be popped until retried batches are queued")
+
+	require.True(queue.waitTurn(seq3, batch3, false), "batches after a failed batch should be retried")
+	queue.finishTurn()
+
+	// Retried batches should be checked first, in the original order.
+	batch, seq := queue.pop()
+	require.Equal([]string{"call 4", "call 5"}, raws(batch))
+	require.False(queue.waitTurn(seq, batch, false))
+	queue.finishTurn()
+
+	batch, seq = queue.pop()
+	require.Equal([]string{"call 6", "call 7"}, raws(batch))
+	require.False(queue.waitTurn(seq, batch, false))
+	queue.finishTurn()
+
+	require.EqualValues(0, queue.size(), "Size")
+}
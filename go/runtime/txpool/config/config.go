@@ -11,6 +11,10 @@ type Config struct {
 	MaxLastSeenCacheSize uint64 `yaml:"schedule_tx_cache_size"`
 	// Maximum check tx batch size.
 	MaxCheckTxBatchSize uint64 `yaml:"check_tx_max_batch_size"`
+	// Number of check tx batches that may be checked concurrently. Setting it to zero (default)
+	// or one checks batches sequentially. It is limited to the number of load-balanced runtime
+	// instances.
+	CheckTxConcurrency uint64 `yaml:"check_tx_concurrency,omitempty"`
 	// Transaction recheck interval (in rounds).
 	RecheckInterval uint64 `yaml:"recheck_interval"`
 	// Republish interval.
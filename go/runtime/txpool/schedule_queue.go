@@ -12,6 +12,8 @@ import (
 var (
 	ErrReplacementTxPriorityTooLow = errors.New("txpool: replacement tx priority too low")
 	ErrQueueFull                   = errors.New("txpool: schedule queue is full")
+	ErrSenderQueueFull             = errors.New("txpool: sender queue is full")
+	ErrTooManyHeldTxs              = errors.New("txpool: too many out-of-order transactions held")
 )
 
 // priorityLessFunc is a comparison function for ordering transactions by priority.
@@ -35,52 +37,187 @@ func priorityLessFunc(tx, tx2 *MainQueueTransaction) bool {
 	return tx.FirstSeen().After(tx2.FirstSeen())
 }
 
+// maxSenderQueueSize is the maximum number of transactions queued per sender.
+const maxSenderQueueSize = 64
+
+// heldCapacityDivisor limits held transactions to a fraction of the queue capacity.
+const heldCapacityDivisor = 4
+
+// senderQueue holds the queued transactions of a single sender.
+type senderQueue struct {
+	// txs are the sender's transactions keyed by their sequence number.
+	txs map[uint64]*MainQueueTransaction
+	// ready is the sender's transaction that may currently be scheduled, if any.
+	ready *MainQueueTransaction
+	// nextSeq is the sequence number expected to be scheduled next, as observed from used
+	// transactions.
+	nextSeq uint64
+}
+
+// lowest returns the sender's transaction with the lowest sequence number.
+func (s *senderQueue) lowest() *MainQueueTransaction {
+	var lowest *MainQueueTransaction
+	for _, tx := range s.txs {
+		if lowest == nil || tx.senderSeq < lowest.senderSeq {
+			lowest = tx
+		}
+	}
+	return lowest
+}
+
+// highest returns the sender's transaction with the highest sequence number.
+func (s *senderQueue) highest() *MainQueueTransaction {
+	var highest *MainQueueTransaction
+	for _, tx := range s.txs {
+		if highest == nil || tx.senderSeq > highest.senderSeq {
+			highest = tx
+		}
+	}
+	return highest
+}
+
+// scheduleQueue is a priority queue of transactions which keeps transactions from the same
+// sender in sequence number order.
+//
+// Only the transaction with the lowest sequence number of each sender is eligible for
+// scheduling, and only once all of its predecessors have been used. Transactions received
+// out of order are held until the gap is filled instead of being rejected. As held
+// transactions cannot be scheduled, they are limited to a fraction of the queue capacity
+// and evicted before ready transactions of the same priority.
 type scheduleQueue struct {
 	l sync.Mutex
 
 	all        map[hash.Hash]*MainQueueTransaction
-	bySender   map[string]*MainQueueTransaction
+	bySender   map[string]*senderQueue
 	byPriority *btree.BTreeG[*MainQueueTransaction]
+	held       *btree.BTreeG[*MainQueueTransaction]
 
-	capacity int
+	capacity     int
+	heldCapacity int
 }
 
 func (sq *scheduleQueue) add(tx *MainQueueTransaction) error {
 	sq.l.Lock()
 	defer sq.l.Unlock()
 
-	// If a transaction from the same sender already exists, we accept a new transaction only if it
-	// has a higher priority or if the old transaction is no longer valid based on sequence numbers.
-	if etx, exists := sq.bySender[tx.sender]; exists {
-		if etx.senderSeq >= tx.senderStateSeq && tx.priority <= etx.priority {
-			return ErrReplacementTxPriorityTooLow
+	if s, exists := sq.bySender[tx.sender]; exists {
+		// Drop any transactions that are no longer valid based on sequence numbers.
+		for seq, etx := range s.txs {
+			if seq < tx.senderStateSeq {
+				sq.removeLocked(etx)
+			}
 		}
+	}
 
-		// Remove any existing transaction.
-		sq.removeLocked(etx)
+	if s, exists := sq.bySender[tx.sender]; exists {
+		// If a transaction with the same sequence number already exists, we accept a new
+		// transaction only if it has a higher priority.
+		if etx, exists := s.txs[tx.senderSeq]; exists {
+			if tx.priority <= etx.priority {
+				return ErrReplacementTxPriorityTooLow
+			}
+
+			// Remove the existing transaction.
+			sq.removeLocked(etx)
+		} else if len(s.txs) >= maxSenderQueueSize {
+			return ErrSenderQueueFull
+		}
 	}
 
 	// If the queue is full, we accept a new transaction only if it has a higher priority.
 	if len(sq.all) >= sq.capacity {
-		// Attempt eviction.
-		etx, _ := sq.byPriority.Min()
+		// Attempt eviction of the lowest priority transaction, preferring held ones.
+		etx, ok := sq.held.Min()
+		if rtx, rok := sq.byPriority.Min(); rok && (!ok || rtx.priority < etx.priority) {
+			etx, ok = rtx, rok
+		}
+		if !ok {
+			return ErrQueueFull
+		}
+		// The transaction that is actually evicted may have a higher priority than the lowest
+		// priority one, so compare against it instead.
+		etx = sq.evictionCandidateLocked(etx)
 		if tx.priority <= etx.priority {
 			return ErrQueueFull
 		}
 		sq.removeLocked(etx)
 	}
 
+	s, exists := sq.bySender[tx.sender]
+	if !exists {
+		s = &senderQueue{
+			txs: make(map[uint64]*MainQueueTransaction),
+		}
+		sq.bySender[tx.sender] = s
+	}
+	s.txs[tx.senderSeq] = tx
 	sq.all[tx.Hash()] = tx
-	sq.bySender[tx.sender] = tx
-	sq.byPriority.ReplaceOrInsert(tx)
+	sq.held.ReplaceOrInsert(tx)
+	sq.updateReadyLocked(s)
+
+	// Limit the number of held transactions by evicting the lowest priority ones. In case the
+	// new transaction does not have a higher priority than the transaction that would actually
+	// be evicted, the new transaction is rejected instead.
+	if sq.held.Len() > sq.heldCapacity {
+		etx, _ := sq.held.Min()
+		etx = sq.evictionCandidateLocked(etx)
+		if etx != tx && tx.priority <= etx.priority {
+			etx = tx
+		}
+		sq.removeLocked(etx)
+		if etx == tx {
+			return ErrTooManyHeldTxs
+		}
+	}
 
 	return nil
 }
 
+// evictionCandidateLocked returns the transaction that is evicted in place of the given
+// transaction. This is the last transaction of the given transaction's sender, as evicting
+// any other transaction would create a sequence gap.
+func (sq *scheduleQueue) evictionCandidateLocked(tx *MainQueueTransaction) *MainQueueTransaction {
+	return sq.bySender[tx.sender].highest()
+}
+
+// updateReadyLocked makes the sender's transaction with the lowest sequence number eligible for
+// scheduling once all of its predecessors have been used.
+func (sq *scheduleQueue) updateReadyLocked(s *senderQueue) {
+	var ready *MainQueueTransaction
+	if tx := s.lowest(); tx != nil && tx.senderSeq <= max(tx.senderStateSeq, s.nextSeq) {
+		ready = tx
+	}
+	if ready == s.ready {
+		return
+	}
+
+	if s.ready != nil {
+		sq.byPriority.Delete(s.ready)
+		sq.held.ReplaceOrInsert(s.ready)
+	}
+	if ready != nil {
+		sq.held.Delete(ready)
+		sq.byPriority.ReplaceOrInsert(ready)
+	}
+	s.ready = ready
+}
+
 func (sq *scheduleQueue) removeLocked(tx *MainQueueTransaction) {
 	delete(sq.all, tx.Hash())
-	delete(sq.bySender, tx.sender)
-	sq.byPriority.Delete(tx)
+
+	s := sq.bySender[tx.sender]
+	delete(s.txs, tx.senderSeq)
+	if s.ready == tx {
+		sq.byPriority.Delete(tx)
+		s.ready = nil
+	} else {
+		sq.held.Delete(tx)
+	}
+	if len(s.txs) == 0 {
+		delete(sq.bySender, tx.sender)
+		return
+	}
+	sq.updateReadyLocked(s)
 }
 
 func (sq *scheduleQueue) remove(txHashes []hash.Hash) {
@@ -93,6 +230,11 @@ func (sq *scheduleQueue) remove(txHashes []hash.Hash) {
 			continue
 		}
 
+		// Once a transaction has been used, its successor may be scheduled.
+		if s := sq.bySender[tx.sender]; s.ready == tx {
+			s.nextSeq = max(s.nextSeq, tx.senderSeq+1)
+		}
+
 		sq.removeLocked(tx)
 	}
 }
@@ -172,15 +314,18 @@ func (sq *scheduleQueue) clear() {
 	defer sq.l.Unlock()
 
 	sq.all = make(map[hash.Hash]*MainQueueTransaction)
-	sq.bySender = make(map[string]*MainQueueTransaction)
+	sq.bySender = make(map[string]*senderQueue)
 	sq.byPriority.Clear(true)
+	sq.held.Clear(true)
 }
 
 func newScheduleQueue(capacity int) *scheduleQueue {
 	return &scheduleQueue{
-		all:        make(map[hash.Hash]*MainQueueTransaction),
-		bySender:   make(map[string]*MainQueueTransaction),
-		byPriority: btree.NewG[*MainQueueTransaction](2, priorityLessFunc),
-		capacity:   capacity,
+		all:          make(map[hash.Hash]*MainQueueTransaction),
+		bySender:     make(map[string]*senderQueue),
+		byPriority:   btree.NewG[*MainQueueTransaction](2, priorityLessFunc),
+		held:         btree.NewG[*MainQueueTransaction](2, priorityLessFunc),
+		capacity:     capacity,
+		heldCapacity: max(1, capacity/heldCapacityDivisor),
 	}
 }
@@ -213,3 +213,169 @@ func TestScheduleQueueSender(t *testing.T) {
 	queue.remove([]hash.Hash{tx.Hash()})
 	require.Equal(0, queue.size())
 }
+
+func TestScheduleQueueSenderOrdering(t *testing.T) {
+	require := require.New(t)
+
+	newSenderTx := func(data string, seq, stateSeq uint64) *MainQueueTransaction {
+		tx := newTransaction(TxQueueMeta{
+			raw:       []byte(data),
+			hash:      hash.NewFromBytes([]byte(data)),
+			firstSeen: time.Now(),
+		})
+		tx.setChecked(&protocol.CheckTxMetadata{
+			Sender:         []byte("sender"),
+			SenderSeq:      seq,
+			SenderStateSeq: stateSeq,
+		})
+		return tx
+	}
+
+	queue := newScheduleQueue(10)
+
+	// Transactions received out of order should be held.
+	tx7 := newSenderTx("seq 7", 7, 5)
+	tx6 := newSenderTx("seq 6", 6, 5)
+	require.NoError(queue.add(tx7), "Add")
+	require.NoError(queue.add(tx6), "Add")
+	require.Equal(2, queue.size())
+	require.Empty(queue.getPrioritizedBatch(nil, 10), "held transactions should not be scheduled")
+
+	known, missing := queue.getKnownBatch([]hash.Hash{tx6.Hash(), tx7.Hash()})
+	require.Empty(missing, "held transactions should be known")
+	require.EqualValues([]*MainQueueTransaction{tx6, tx7}, known)
+
+	// Filling the gap should release the transactions in order.
+	tx5 := newSenderTx("seq 5", 5, 5)
+	require.NoError(queue.add(tx5), "Add")
+	require.EqualValues([]*MainQueueTransaction{tx5}, queue.getPrioritizedBatch(nil, 10))
+
+	queue.remove([]hash.Hash{tx5.Hash()})
+	require.EqualValues([]*MainQueueTransaction{tx6}, queue.getPrioritizedBatch(nil, 10))
+
+	queue.remove([]hash.Hash{tx6.Hash()})
+	require.EqualValues([]*MainQueueTransaction{tx7}, queue.getPrioritizedBatch(nil, 10))
+
+	// Transactions that are no longer valid based on sequence numbers should be dropped.
+	tx9 := newSenderTx("seq 9", 9, 8)
+	require.NoError(queue.add(tx9), "Add")
+	require.Equal(1, queue.size())
+	require.Empty(queue.getPrioritizedBatch(nil, 10), "held transactions should not be scheduled")
+
+	// Replacing a held transaction requires a higher priority.
+	err := queue.add(newSenderTx("seq 9 again", 9, 8))
+	require.Equal(ErrReplacementTxPriorityTooLow, err)
+}
+
+func TestScheduleQueueHeldEviction(t *testing.T) {
+	require := require.New(t)
+
+	newSenderTx := func(sender string, seq uint64, priority uint64) *MainQueueTransaction {
+		data := fmt.Sprintf("%s seq %d", sender, seq)
+		tx := newTransaction(TxQueueMeta{
+			raw:       []byte(data),
+			hash:      hash.NewFromBytes([]byte(data)),
+			firstSeen: time.Now(),
+		})
+		tx.setChecked(&protocol.CheckTxMetadata{
+			Priority:  priority,
+			Sender:    []byte(sender),
+			SenderSeq: seq,
+		})
+		return tx
+	}
+
+	queue := newScheduleQueue(8)
+
+	// Held transactions should be limited to a fraction of the capacity.
+	require.NoError(queue.add(newSenderTx("held1", 1, 10)), "Add")
+	require.NoError(queue.add(newSenderTx("held2", 1, 20)), "Add")
+	err := queue.add(newSenderTx("held3", 1, 5))
+	require.Equal(ErrTooManyHeldTxs, err, "lowest priority held transaction should be rejected")
+	require.NoError(queue.add(newSenderTx("held3", 1, 15)), "Add")
+	require.Equal(2, queue.size())
+	known, missing := queue.getKnownBatch([]hash.Hash{newSenderTx("held1", 1, 10).Hash()})
+	require.Equal([]*MainQueueTransaction{nil}, known)
+	require.Len(missing, 1, "lowest priority held transaction should be evicted")
+
+	// Fill up the queue with ready transactions.
+	for i := 0; i < 6; i++ {
+		require.NoError(queue.add(newSenderTx(fmt.Sprintf("ready%d", i), 0, 15)), "Add")
+	}
+	require.Equal(8, queue.size())
+
+	// Held transactions should be evicted before ready ones of the same priority.
+	require.NoError(queue.add(newSenderTx("ready6", 0, 16)), "Add")
+	require.Equal(8, queue.size())
+	known, _ = queue.getKnownBatch([]hash.Hash{newSenderTx("held3", 1, 15).Hash()})
+	require.Equal([]*MainQueueTransaction{nil}, known, "held transaction should be evicted")
+
+	// Lower priority ready transactions should be evicted before higher priority held ones.
+	require.NoError(queue.add(newSenderTx("ready7", 0, 17)), "Add")
+	require.Equal(8, queue.size())
+	known, _ = queue.getKnownBatch([]hash.Hash{newSenderTx("held2", 1, 20).Hash()})
+	require.NotNil(known[0], "higher priority held transaction should be kept")
+
+	// A queue full of held and ready transactions should still accept higher priority ones.
+	require.Len(queue.getPrioritizedBatch(nil, 10), 7)
+	require.NoError(queue.add(newSenderTx("ready8", 0, 30)), "Add")
+	require.Equal(ErrQueueFull, queue.add(newSenderTx("ready9", 0, 1)))
+}
+
+func TestScheduleQueueEvictionPriority(t *testing.T) {
+	require := require.New(t)
+
+	newSenderTx := func(sender string, seq uint64, priority uint64) *MainQueueTransaction {
+		data := fmt.Sprintf("%s seq %d", sender, seq)
+		tx := newTransaction(TxQueueMeta{
+			raw:       []byte(data),
+			hash:      hash.NewFromBytes([]byte(data)),
+			firstSeen: time.Now(),
+		})
+		tx.setChecked(&protocol.CheckTxMetadata{
+			Priority:  priority,
+			Sender:    []byte(sender),
+			SenderSeq: seq,
+		})
+		return tx
+	}
+
+	queue := newScheduleQueue(4)
+
+	// Fill up the queue so that the lowest priority transaction is followed by a higher
+	// priority one of the same sender.
+	a0 := newSenderTx("a", 0, 1)
+	a1 := newSenderTx("a", 1, 100)
+	require.NoError(queue.add(a0), "Add")
+	require.NoError(queue.add(a1), "Add")
+	require.NoError(queue.add(newSenderTx("c", 0, 60)), "Add")
+	require.NoError(queue.add(newSenderTx("d", 0, 60)), "Add")
+
+	// A new transaction should not evict a higher priority transaction, even when it has a
+	// higher priority than the lowest priority one.
+	err := queue.add(newSenderTx("b", 0, 50))
+	require.Equal(ErrQueueFull, err, "higher priority transaction should not be evicted")
+	known, missing := queue.getKnownBatch([]hash.Hash{a0.Hash(), a1.Hash()})
+	require.Empty(missing, "no transactions should be evicted")
+	require.EqualValues([]*MainQueueTransaction{a0, a1}, known)
+
+	// A new transaction with a higher priority than the evicted one should be accepted.
+	require.NoError(queue.add(newSenderTx("b", 0, 150)), "Add")
+	require.Equal(4, queue.size())
+	known, missing = queue.getKnownBatch([]hash.Hash{a0.Hash(), a1.Hash()})
+	require.EqualValues([]*MainQueueTransaction{a0, nil}, known, "last transaction of the sender should be evicted")
+	require.Len(missing, 1)
+
+	// The same should hold for held transactions.
+	queue = newScheduleQueue(8)
+	e1 := newSenderTx("e", 1, 1)
+	e2 := newSenderTx("e", 2, 100)
+	require.NoError(queue.add(e1), "Add")
+	require.NoError(queue.add(e2), "Add")
+	err = queue.add(newSenderTx("f", 1, 50))
+	require.Equal(ErrTooManyHeldTxs, err, "higher priority held transaction should not be evicted")
+	known, missing = queue.getKnownBatch([]hash.Hash{e1.Hash(), e2.Hash()})
+	require.Empty(missing, "no held transactions should be evicted")
+	require.EqualValues([]*MainQueueTransaction{e1, e2}, known)
+	require.Equal(2, queue.size())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/eapache/channels"
@@ -173,6 +174,14 @@ type txPool struct {
 	checkTxNotifier *pubsub.Broker
 	recheckTxCh     *channels.RingChannel
 
+	// checkTxLock is held for reading by in-flight checks and for writing while aborting the
+	// runtime after a check timed out.
+	checkTxLock sync.RWMutex
+	// checkTxAborts is the number of times the runtime was aborted after a check timed out.
+	checkTxAborts atomic.Uint64
+	// checkTxAbortWait is the maximum time to wait for other in-flight checks before aborting.
+	checkTxAbortWait time.Duration
+
 	drainLock sync.Mutex
 
 	usableSources        []UsableTransactionSource
@@ -480,47 +489,30 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 	}
 
 	// Pop the next batch from the queue, check it, and notify submitters.
-	batch := t.checkTxQueue.pop()
+	batch, seq := t.checkTxQueue.pop()
 	if len(batch) == 0 {
 		return
 	}
 
-	results, err := func() ([]protocol.CheckTxResult, error) {
-		checkCtx, cancelCheckCtx := context.WithTimeout(ctx, checkTxTimeout)
-		defer cancelCheckCtx()
-
-		// Check batch.
-		rawTxBatch := make([][]byte, 0, len(batch))
-		for _, pct := range batch {
-			rawTxBatch = append(rawTxBatch, pct.Raw())
-		}
-		return rr.CheckTx(checkCtx, bi.RuntimeBlock, bi.ConsensusBlock, bi.Epoch, bi.ActiveDescriptor.Executor.MaxMessages, rawTxBatch)
-	}()
-	switch {
-	case err == nil:
-	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
-		// Context was canceled while the runtime was processing a request.
-		t.logger.Error("transaction batch check aborted by context, aborting runtime")
-
-		// Abort the runtime, so we can start processing the next batch.
-		abortCtx, cancel := context.WithTimeout(ctx, abortTimeout)
-		defer cancel()
-
-		if err = rr.Abort(abortCtx, false); err != nil {
-			t.logger.Error("failed to abort the runtime",
-				"err", err,
-			)
-		}
+	// If there are more transactions to check, make sure we check them next, possibly
+	// concurrently with this batch.
+	if t.checkTxQueue.size() > 0 {
+		t.checkTxCh.In() <- struct{}{}
+	}
 
-		fallthrough
-	default:
+	results, err := t.checkTx(ctx, rr, bi, batch)
+	if err != nil {
 		t.logger.Warn("transaction batch check failed",
 			"err", err,
 		)
+	}
 
-		// Return transaction batch back to the check queue.
-		t.checkTxQueue.retryBatch(batch)
+	// Process the results in the order in which the batches were popped from the queue, so that
+	// transactions are queued in the same order as they were received.
+	retry := t.checkTxQueue.waitTurn(seq, batch, err != nil)
+	defer t.checkTxQueue.finishTurn()
 
+	if retry {
 		// Make sure that the batch check is retried later.
 		go func() {
 			time.Sleep(checkTxRetryDelay)
@@ -577,11 +569,6 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 		batchIndices = append(batchIndices, i)
 	}
 
-	// If there are more transactions to check, make sure we check them next.
-	if t.checkTxQueue.size() > 0 {
-		t.checkTxCh.In() <- struct{}{}
-	}
-
 	if len(goodPcts) == 0 {
 		return
 	}
@@ -644,6 +631,75 @@ func (t *txPool) checkTxBatch(ctx context.Context, rr host.RichRuntime) {
 	localQueueSize.With(t.getMetricLabels()).Set(float64(t.localQueue.size()))
 }
 
+// checkTx requests the runtime to check the given transaction batch.
+//
+// If the check times out, the runtime is aborted so that it can process further batches. As
+// aborting fails all other in-flight checks as well, the abort waits for them to finish first,
+// but at most for the configured time, as they may be stuck in the same hung runtime.
+func (t *txPool) checkTx(ctx context.Context, rr host.RichRuntime, bi *runtime.BlockInfo, batch []*PendingCheckTransaction) ([]protocol.CheckTxResult, error) {
+	var abortRound uint64
+	results, err := func() ([]protocol.CheckTxResult, error) {
+		t.checkTxLock.RLock()
+		defer t.checkTxLock.RUnlock()
+
+		abortRound = t.checkTxAborts.Load()
+
+		checkCtx, cancelCheckCtx := context.WithTimeout(ctx, checkTxTimeout)
+		defer cancelCheckCtx()
+
+		// Check batch.
+		rawTxBatch := make([][]byte, 0, len(batch))
+		for _, pct := range batch {
+			rawTxBatch = append(rawTxBatch, pct.Raw())
+		}
+		return rr.CheckTx(checkCtx, bi.RuntimeBlock, bi.ConsensusBlock, bi.Epoch, bi.ActiveDescriptor.Executor.MaxMessages, rawTxBatch)
+	}()
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		return results, err
+	}
+
+	// Context was canceled while the runtime was processing a request. Wait for other in-flight
+	// checks to finish before aborting. Waiting for the write lock also prevents new checks from
+	// starting, so the wait is bounded even under load.
+	lockedCh := make(chan struct{})
+	go func() {
+		t.checkTxLock.Lock()
+		close(lockedCh)
+	}()
+	select {
+	case <-lockedCh:
+		defer t.checkTxLock.Unlock()
+	case <-time.After(t.checkTxAbortWait):
+		// Other checks are stuck as well, so the runtime is most likely hung.
+		t.logger.Warn("other checks still in flight, aborting runtime anyway")
+
+		go func() {
+			<-lockedCh
+			t.checkTxLock.Unlock()
+		}()
+	}
+
+	// Only abort once in case multiple checks were stuck at the same time.
+	if !t.checkTxAborts.CompareAndSwap(abortRound, abortRound+1) {
+		t.logger.Error("transaction batch check aborted by context, runtime already aborted")
+		return nil, err
+	}
+
+	t.logger.Error("transaction batch check aborted by context, aborting runtime")
+
+	// Abort the runtime, so we can start processing the next batch.
+	abortCtx, cancel := context.WithTimeout(ctx, abortTimeout)
+	defer cancel()
+
+	if abortErr := rr.Abort(abortCtx, false); abortErr != nil {
+		t.logger.Error("failed to abort the runtime",
+			"err", abortErr,
+		)
+	}
+
+	return nil, err
+}
+
 func (t *txPool) ensureInitialized() error {
 	select {
 	case <-t.stopCh:
@@ -678,16 +734,33 @@ func (t *txPool) checkWorker() {
 		return
 	}
 
-	for {
-		select {
-		case <-t.stopCh:
-			return
-		case <-t.checkTxCh.Out():
-			t.logger.Debug("checking queued transactions")
+	// Check transaction batches using the configured number of concurrent checkers. The runtime
+	// host handles concurrent requests, distributing them among instances when load balancing.
+	concurrency := max(1, t.cfg.CheckTxConcurrency)
+	t.logger.Debug("starting transaction checkers",
+		"concurrency", concurrency,
+	)
 
-			// Check if there are any transactions to check and run the checks.
-			t.checkTxBatch(ctx, rr)
-		}
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for i := uint64(0); i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-t.stopCh:
+					return
+				case <-t.checkTxCh.Out():
+					t.logger.Debug("checking queued transactions")
+
+					// Check if there are any transactions to check and run the checks.
+					t.checkTxBatch(ctx, rr)
+				}
+			}
+		}()
 	}
 }
 
@@ -907,6 +980,7 @@ func New(
 		txPublisher:          txPublisher,
 		seenCache:            seenCache,
 		checkTxQueue:         newCheckTxQueue(maxCheckTxQueueSize, int(cfg.MaxCheckTxBatchSize)),
+		checkTxAbortWait:     checkTxTimeout,
 		checkTxCh:            channels.NewRingChannel(1),
 		checkTxNotifier:      pubsub.NewBroker(false),
 		recheckTxCh:          channels.NewRingChannel(1),
@@ -0,0 +1,126 @@
+package txpool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+type mockCheckTxRuntime struct {
+	host.RichRuntime
+
+	startedCh chan struct{}
+	releaseCh chan struct{}
+	aborts    atomic.Int32
+}
+
+func (rr *mockCheckTxRuntime) CheckTx(
+	ctx context.Context,
+	_ *block.Block,
+	_ *consensus.LightBlock,
+	_ beacon.EpochTime,
+	_ uint32,
+	batch transaction.RawBatch,
+) ([]protocol.CheckTxResult, error) {
+	rr.startedCh <- struct{}{}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-rr.releaseCh:
+		return make([]protocol.CheckTxResult, len(batch)), nil
+	}
+}
+
+func (rr *mockCheckTxRuntime) Abort(context.Context, bool) error {
+	rr.aborts.Add(1)
+	return nil
+}
+
+func TestCheckTxAbort(t *testing.T) {
+	require := require.New(t)
+
+	tp := &txPool{
+		logger:           logging.GetLogger("runtime/txpool/test"),
+		checkTxAbortWait: time.Second,
+	}
+	rr := &mockCheckTxRuntime{
+		startedCh: make(chan struct{}, 2),
+		releaseCh: make(chan struct{}),
+	}
+	bi := &runtime.BlockInfo{
+		ActiveDescriptor: &registry.Runtime{},
+	}
+	batch := []*PendingCheckTransaction{newPendingTx([]byte("tx"))}
+
+	check := func(ctx context.Context) <-chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := tp.checkTx(ctx, rr, bi, batch)
+			errCh <- err
+		}()
+		<-rr.startedCh
+		return errCh
+	}
+
+	// A check that times out while other checks are in flight should abort the runtime only
+	// once the other checks finish.
+	ctx, cancel := context.WithCancel(context.Background())
+	slowErrCh := check(ctx)
+	fastErrCh := check(context.Background())
+
+	cancel()
+	select {
+	case <-slowErrCh:
+		require.FailNow("runtime should not be aborted while other checks are in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.EqualValues(0, rr.aborts.Load(), "runtime should not be aborted")
+
+	rr.releaseCh <- struct{}{}
+	require.NoError(<-fastErrCh)
+	require.ErrorIs(<-slowErrCh, context.Canceled)
+	require.EqualValues(1, rr.aborts.Load(), "runtime should be aborted")
+
+	// A check that times out while no other checks are in flight should abort the runtime.
+	ctx, cancel = context.WithCancel(context.Background())
+	slowErrCh = check(ctx)
+
+	cancel()
+	require.ErrorIs(<-slowErrCh, context.Canceled)
+	require.EqualValues(2, rr.aborts.Load(), "runtime should be aborted")
+
+	// A check that times out while other checks are stuck as well should abort the runtime
+	// after a bounded wait, and only once.
+	tp.checkTxAbortWait = 100 * time.Millisecond
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	stuckErrCh1 := check(ctx1)
+	stuckErrCh2 := check(ctx2)
+
+	cancel1()
+	require.ErrorIs(<-stuckErrCh1, context.Canceled)
+	require.EqualValues(3, rr.aborts.Load(), "hung runtime should be aborted")
+
+	cancel2()
+	require.ErrorIs(<-stuckErrCh2, context.Canceled)
+	require.EqualValues(3, rr.aborts.Load(), "runtime should not be aborted again")
+
+	// New checks should proceed after the aborts.
+	errCh := check(context.Background())
+	rr.releaseCh <- struct{}{}
+	require.NoError(<-errCh)
+}
@@ -27,10 +27,13 @@ const (
 	RoleWorker Role = 1
 	// RoleBackupWorker indicates the node is a backup worker.
 	RoleBackupWorker Role = 2
+	// RoleStandbyWorker indicates the node is a standby worker.
+	RoleStandbyWorker Role = 3
 
-	RoleInvalidName      = "invalid"
-	RoleWorkerName       = "worker"
-	RoleBackupWorkerName = "backup-worker"
+	RoleInvalidName       = "invalid"
+	RoleWorkerName        = "worker"
+	RoleBackupWorkerName  = "backup-worker"
+	RoleStandbyWorkerName = "standby-worker"
 )
 
 // String returns a string representation of a Role.
@@ -42,6 +45,8 @@ func (r Role) String() string {
 		return RoleWorkerName
 	case RoleBackupWorker:
 		return RoleBackupWorkerName
+	case RoleStandbyWorker:
+		return RoleStandbyWorkerName
 	default:
 		return fmt.Sprintf("[unknown role: %d]", r)
 	}
@@ -56,6 +61,8 @@ func (r Role) MarshalText() ([]byte, error) {
 		return []byte(RoleWorkerName), nil
 	case RoleBackupWorker:
 		return []byte(RoleBackupWorkerName), nil
+	case RoleStandbyWorker:
+		return []byte(RoleStandbyWorkerName), nil
 	default:
 		return nil, fmt.Errorf("invalid role: %d", r)
 	}
@@ -68,6 +75,8 @@ func (r *Role) UnmarshalText(text []byte) error {
 		*r = RoleWorker
 	case RoleBackupWorkerName:
 		*r = RoleBackupWorker
+	case RoleStandbyWorkerName:
+		*r = RoleStandbyWorker
 	default:
 		return fmt.Errorf("invalid role: %s", string(text))
 	}
@@ -177,13 +186,22 @@ func (c *Committee) IsWorker(id signature.PublicKey) bool {
 
 // IsBackupWorker returns true iff the given node is a backup worker in the committee.
 func (c *Committee) IsBackupWorker(id signature.PublicKey) bool {
+	return c.hasRole(id, RoleBackupWorker)
+}
+
+// IsStandbyWorker returns true iff the given node is a standby worker in the committee.
+func (c *Committee) IsStandbyWorker(id signature.PublicKey) bool {
+	return c.hasRole(id, RoleStandbyWorker)
+}
+
+func (c *Committee) hasRole(id signature.PublicKey, role Role) bool {
 	for i := len(c.Members) - 1; i >= 0; i-- {
 		n := c.Members[i]
-		if n.Role != RoleBackupWorker {
-			// Backup workers are listed after workers.
+		if n.Role == RoleWorker {
+			// Backup and standby workers are listed after workers.
 			return false
 		}
-		if n.PublicKey == id {
+		if n.Role == role && n.PublicKey == id {
 			return true
 		}
 	}
@@ -343,12 +361,62 @@ type Validator struct {
 	VotingPower int64 `json:"voting_power"`
 }
 
+// ValidatorCandidate is a node that is eligible to be elected into the validator set.
+type ValidatorCandidate struct {
+	// ID is the candidate Oasis node identifier.
+	ID signature.PublicKey `json:"id"`
+
+	// EntityID is the candidate entity identifier.
+	EntityID signature.PublicKey `json:"entity_id"`
+
+	// Stake is the active escrow balance of the candidate entity.
+	Stake quantity.Quantity `json:"stake"`
+
+	// Rank is the zero-based position of the candidate entity among all entities running
+	// eligible validator nodes, ordered by descending stake.
+	Rank uint64 `json:"rank"`
+
+	// VotingPower is the candidate's voting power in the current validator set or zero
+	// if the candidate is not a current validator.
+	VotingPower int64 `json:"voting_power,omitempty"`
+
+	// Elected is true iff the candidate would be elected if the election was held at
+	// the queried height.
+	//
+	// Ties between entities with equal stake are broken at random during the election,
+	// as is the choice of nodes for entities running more validator nodes than allowed,
+	// so the outcome for such candidates is only indicative.
+	Elected bool `json:"elected"`
+}
+
+// ValidatorSetStatus is the status of the stake-ranked validator set election.
+type ValidatorSetStatus struct {
+	// MaxValidators is the maximum number of validators in the validator set.
+	MaxValidators int `json:"max_validators"`
+
+	// MaxValidatorsPerEntity is the maximum number of validators per entity.
+	MaxValidatorsPerEntity int `json:"max_validators_per_entity"`
+
+	// Threshold is the stake of the lowest-ranked entity that would be elected when
+	// the validator set is full. Prospective validators need more stake than this
+	// in order to be elected.
+	Threshold *quantity.Quantity `json:"threshold,omitempty"`
+
+	// Candidates are all eligible validator candidates, ordered by rank.
+	Candidates []*ValidatorCandidate `json:"candidates"`
+}
+
 // Backend is a scheduler implementation.
 type Backend interface {
 	// GetValidators returns the vector of consensus validators for
 	// a given epoch.
 	GetValidators(ctx context.Context, height int64) ([]*Validator, error)
 
+	// GetValidatorSetStatus returns the current validator set election status, which
+	// includes all validator candidates ranked by stake and whether they would be
+	// elected at the given block height.
+	GetValidatorSetStatus(ctx context.Context, height int64) (*ValidatorSetStatus, error)
+
 	// GetCommittees returns the vector of committees for a given
 	// runtime ID, at the specified block height, and optional callback
 	// for querying the beacon for a given epoch/block height.
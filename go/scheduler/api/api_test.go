@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 )
 
@@ -35,3 +37,44 @@ func TestVotingPowerDistribution(t *testing.T) {
 	require.True(t, powerS > 0, "sqrt should be greater than 0")
 	require.True(t, powerL > powerS, "linear should be greater than sqrt")
 }
+
+func TestCommitteeRoles(t *testing.T) {
+	require := require.New(t)
+
+	worker := memorySigner.NewTestSigner("committee roles test: worker").Public()
+	backup := memorySigner.NewTestSigner("committee roles test: backup").Public()
+	standby := memorySigner.NewTestSigner("committee roles test: standby").Public()
+	other := memorySigner.NewTestSigner("committee roles test: other").Public()
+
+	c := Committee{
+		Members: []*CommitteeNode{
+			{Role: RoleWorker, PublicKey: worker},
+			{Role: RoleBackupWorker, PublicKey: worker},
+			{Role: RoleBackupWorker, PublicKey: backup},
+			{Role: RoleStandbyWorker, PublicKey: standby},
+		},
+	}
+
+	for _, tc := range []struct {
+		id       signature.PublicKey
+		member   bool
+		worker   bool
+		backup   bool
+		standby  bool
+		testName string
+	}{
+		{worker, true, true, true, false, "worker"},
+		{backup, true, false, true, false, "backup worker"},
+		{standby, true, false, false, true, "standby worker"},
+		{other, false, false, false, false, "non-member"},
+	} {
+		require.Equal(tc.member, c.IsMember(tc.id), tc.testName)
+		require.Equal(tc.worker, c.IsWorker(tc.id), tc.testName)
+		require.Equal(tc.backup, c.IsBackupWorker(tc.id), tc.testName)
+		require.Equal(tc.standby, c.IsStandbyWorker(tc.id), tc.testName)
+	}
+
+	// Standby workers should not be schedulers.
+	_, ok := c.SchedulerRank(0, standby)
+	require.False(ok)
+}
@@ -15,6 +15,8 @@ var (
 
 	// methodGetValidators is the GetValidators method.
 	methodGetValidators = serviceName.NewMethod("GetValidators", int64(0))
+	// methodGetValidatorSetStatus is the GetValidatorSetStatus method.
+	methodGetValidatorSetStatus = serviceName.NewMethod("GetValidatorSetStatus", int64(0))
 	// methodGetCommittees is the GetCommittees method.
 	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
@@ -34,6 +36,10 @@ var (
 				MethodName: methodGetValidators.ShortName(),
 				Handler:    handlerGetValidators,
 			},
+			{
+				MethodName: methodGetValidatorSetStatus.ShortName(),
+				Handler:    handlerGetValidatorSetStatus,
+			},
 			{
 				MethodName: methodGetCommittees.ShortName(),
 				Handler:    handlerGetCommittees,
@@ -80,6 +86,29 @@ func handlerGetValidators(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetValidatorSetStatus(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetValidatorSetStatus(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetValidatorSetStatus.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetValidatorSetStatus(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerGetCommittees(
 	srv interface{},
 	ctx context.Context,
@@ -194,6 +223,14 @@ func (c *schedulerClient) GetValidators(ctx context.Context, height int64) ([]*V
 	return rsp, nil
 }
 
+func (c *schedulerClient) GetValidatorSetStatus(ctx context.Context, height int64) (*ValidatorSetStatus, error) {
+	var rsp ValidatorSetStatus
+	if err := c.conn.Invoke(ctx, methodGetValidatorSetStatus.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *schedulerClient) GetCommittees(ctx context.Context, request *GetCommitteesRequest) ([]*Committee, error) {
 	var rsp []*Committee
 	if err := c.conn.Invoke(ctx, methodGetCommittees.FullName(), request, &rsp); err != nil {
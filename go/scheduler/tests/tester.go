@@ -127,6 +127,19 @@ func SchedulerImplementationTests(t *testing.T, name string, identity *identity.
 	require.Len(validators, 1, "should be only one validator")
 	require.Equal(identity.NodeSigner.Public(), validators[0].ID)
 	require.EqualValues(1, validators[0].VotingPower)
+
+	status, err := backend.GetValidatorSetStatus(context.Background(), consensusAPI.HeightLatest)
+	require.NoError(err, "GetValidatorSetStatus")
+
+	var found bool
+	for _, c := range status.Candidates {
+		if c.ID.Equal(identity.NodeSigner.Public()) {
+			require.True(c.Elected, "validator should be elected")
+			require.EqualValues(1, c.VotingPower)
+			found = true
+		}
+	}
+	require.True(found, "validator should be a validator candidate")
 }
 
 func requireValidCommitteeMembers(t *testing.T, committee *api.Committee, runtime *registry.Runtime, nodes []*node.Node) {
@@ -8,6 +8,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -70,6 +71,26 @@ var (
 	// total supply value.
 	ErrAllowanceGreaterThanSupply = errors.New(ModuleName, 11, "staking: allowance greater than total supply")
 
+	// ErrTooManyEscrowOperations is the error returned when the number of operations in an
+	// escrow batch exceeds the maximum allowed number.
+	ErrTooManyEscrowOperations = errors.New(ModuleName, 12, "staking: too many escrow operations")
+
+	// ErrBalanceLocked is the error returned when an operation would spend balance that is still
+	// locked by the account's vesting schedule.
+	ErrBalanceLocked = errors.New(ModuleName, 13, "staking: balance is locked")
+
+	// ErrTooManyVestingSteps is the error returned when the number of vesting schedule steps of an
+	// account would exceed the maximum allowed number.
+	ErrTooManyVestingSteps = errors.New(ModuleName, 14, "staking: too many vesting steps")
+
+	// ErrEventIndexUnavailable is the error returned when the account event index is disabled or
+	// does not cover the requested heights.
+	ErrEventIndexUnavailable = errors.New(ModuleName, 15, "staking: event index unavailable")
+
+	// ErrVestingNotAllowed is the error returned when the destination account of a vesting
+	// transfer has not allowed the source account to transfer the given amount.
+	ErrVestingNotAllowed = errors.New(ModuleName, 16, "staking: vesting transfer not allowed")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
 	// MethodBurn is the method name for burns.
@@ -82,8 +103,14 @@ var (
 	MethodAmendCommissionSchedule = transaction.NewMethodName(ModuleName, "AmendCommissionSchedule", AmendCommissionSchedule{})
 	// MethodAllow is the method name for setting a beneficiary allowance.
 	MethodAllow = transaction.NewMethodName(ModuleName, "Allow", Allow{})
-	// MethodWithdraw is the method name for
+	// MethodWithdraw is the method name for withdrawing from an allowance.
 	MethodWithdraw = transaction.NewMethodName(ModuleName, "Withdraw", Withdraw{})
+	// MethodBatchEscrow is the method name for batched escrow operations.
+	MethodBatchEscrow = transaction.NewMethodName(ModuleName, "BatchEscrow", BatchEscrow{})
+	// MethodVestingTransfer is the method name for vesting transfers.
+	MethodVestingTransfer = transaction.NewMethodName(ModuleName, "VestingTransfer", VestingTransfer{})
+	// MethodAllowVesting is the method name for vesting allowances.
+	MethodAllowVesting = transaction.NewMethodName(ModuleName, "AllowVesting", AllowVesting{})
 
 	// Methods is the list of all methods supported by the staking backend.
 	Methods = []transaction.MethodName{
@@ -94,6 +121,9 @@ var (
 		MethodAmendCommissionSchedule,
 		MethodAllow,
 		MethodWithdraw,
+		MethodBatchEscrow,
+		MethodVestingTransfer,
+		MethodAllowVesting,
 	}
 
 	_ prettyprint.PrettyPrinter = (*Transfer)(nil)
@@ -103,6 +133,7 @@ var (
 	_ prettyprint.PrettyPrinter = (*AmendCommissionSchedule)(nil)
 	_ prettyprint.PrettyPrinter = (*Allow)(nil)
 	_ prettyprint.PrettyPrinter = (*Withdraw)(nil)
+	_ prettyprint.PrettyPrinter = (*BatchEscrow)(nil)
 	_ prettyprint.PrettyPrinter = (*SharePool)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeThreshold)(nil)
 	_ prettyprint.PrettyPrinter = (*StakeAccumulator)(nil)
@@ -170,9 +201,21 @@ type Backend interface {
 	// delegations to the given account.
 	DebondingDelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error)
 
+	// DelegationStatusFor returns the status of all (outgoing) active and
+	// debonding delegations for the given owner (delegator), including their
+	// values in base units and debonding end times.
+	DelegationStatusFor(ctx context.Context, query *OwnerQuery) (*DelegationStatus, error)
+
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
+	// Vesting returns the vested/unvested split of the given account's vesting schedule.
+	Vesting(ctx context.Context, query *OwnerQuery) (*VestingStatus, error)
+
+	// ProjectedRewards returns the projected epoch signing rewards of the given account's escrow
+	// for the requested number of epochs following the current epoch.
+	ProjectedRewards(ctx context.Context, query *ProjectedRewardsQuery) ([]*ProjectedReward, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*Genesis, error)
 
@@ -182,6 +225,11 @@ type Backend interface {
 	// GetEvents returns the events at specified block height.
 	GetEvents(ctx context.Context, height int64) ([]*Event, error)
 
+	// GetAccountEvents returns a page of historical events related to the given account.
+	//
+	// This requires the account event index to be enabled on the queried node.
+	GetAccountEvents(ctx context.Context, query *AccountEventsQuery) (*AccountEvents, error)
+
 	// WatchEvents returns a channel that produces a stream of Events.
 	WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error)
 
@@ -195,6 +243,35 @@ type ThresholdQuery struct {
 	Kind   ThresholdKind `json:"kind"`
 }
 
+// MaxAccountEventsLimit is the maximum number of events returned in a single page of account
+// events.
+const MaxAccountEventsLimit = 1000
+
+// AccountEventsQuery is an account event history query.
+type AccountEventsQuery struct {
+	// Owner is the address of the account whose events are returned.
+	Owner Address `json:"owner"`
+	// FromHeight is the first height (inclusive) of the queried range. Zero means the earliest
+	// indexed height.
+	FromHeight int64 `json:"from_height,omitempty"`
+	// ToHeight is the last height (inclusive) of the queried range. Zero means the latest
+	// indexed height.
+	ToHeight int64 `json:"to_height,omitempty"`
+	// Cursor is the cursor returned with the previous page. Empty for the first page.
+	Cursor []byte `json:"cursor,omitempty"`
+	// Limit is the maximum number of returned events. Zero means MaxAccountEventsLimit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// AccountEvents is a page of account events.
+type AccountEvents struct {
+	// Events are the account events ordered by height and by their position in the block.
+	Events []*Event `json:"events"`
+	// NextCursor is the cursor of the next page. Empty in case there are no more events in the
+	// queried range.
+	NextCursor []byte `json:"next_cursor,omitempty"`
+}
+
 // OwnerQuery is an owner query.
 type OwnerQuery struct {
 	Height int64   `json:"height"`
@@ -275,6 +352,36 @@ type Event struct {
 	Burn            *BurnEvent            `json:"burn,omitempty"`
 	Escrow          *EscrowEvent          `json:"escrow,omitempty"`
 	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
+
+	LivenessViolation  *LivenessViolationEvent  `json:"liveness_violation,omitempty"`
+	RewardDisbursement *RewardDisbursementEvent `json:"reward_disbursement,omitempty"`
+}
+
+// Accounts returns the addresses of the accounts the event relates to.
+func (e *Event) Accounts() []Address {
+	var addrs []Address
+	switch {
+	case e.Transfer != nil:
+		addrs = append(addrs, e.Transfer.From, e.Transfer.To)
+	case e.Burn != nil:
+		addrs = append(addrs, e.Burn.Owner)
+	case e.Escrow != nil && e.Escrow.Add != nil:
+		addrs = append(addrs, e.Escrow.Add.Owner, e.Escrow.Add.Escrow)
+	case e.Escrow != nil && e.Escrow.Take != nil:
+		addrs = append(addrs, e.Escrow.Take.Owner)
+	case e.Escrow != nil && e.Escrow.DebondingStart != nil:
+		addrs = append(addrs, e.Escrow.DebondingStart.Owner, e.Escrow.DebondingStart.Escrow)
+	case e.Escrow != nil && e.Escrow.Reclaim != nil:
+		addrs = append(addrs, e.Escrow.Reclaim.Owner, e.Escrow.Reclaim.Escrow)
+	case e.AllowanceChange != nil:
+		addrs = append(addrs, e.AllowanceChange.Owner, e.AllowanceChange.Beneficiary)
+	case e.LivenessViolation != nil:
+		addrs = append(addrs, e.LivenessViolation.Owner)
+	}
+	if len(addrs) == 2 && addrs[0].Equal(addrs[1]) {
+		addrs = addrs[:1]
+	}
+	return addrs
 }
 
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
@@ -420,6 +527,42 @@ func (e *AllowanceChangeEvent) ProvableRepresentation() any {
 	return e
 }
 
+// LivenessViolationEvent is the event emitted when a validator is penalized for missing too
+// many blocks in a liveness window.
+type LivenessViolationEvent struct {
+	// NodeID is the identifier of the validator node.
+	NodeID signature.PublicKey `json:"node_id"`
+	// Owner is the address of the entity owning the validator node.
+	Owner Address `json:"owner"`
+	// MissedBlocks is the number of blocks missed in the liveness window.
+	MissedBlocks uint64 `json:"missed_blocks"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *LivenessViolationEvent) EventKind() string {
+	return "liveness_violation"
+}
+
+// RewardDisbursementEvent is the event emitted when staking rewards are disbursed from the common
+// pool at the start of an epoch.
+type RewardDisbursementEvent struct {
+	// Epoch is the epoch for which the rewards have been disbursed.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Factor is the reward factor used to compute the rewards.
+	Factor quantity.Quantity `json:"factor"`
+	// Scale is the scale of the reward schedule step in effect.
+	Scale quantity.Quantity `json:"scale"`
+	// Amount is the total amount disbursed from the common pool.
+	Amount quantity.Quantity `json:"amount"`
+	// Recipients is the number of accounts that received a reward.
+	Recipients uint64 `json:"recipients"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *RewardDisbursementEvent) EventKind() string {
+	return "reward_disbursement"
+}
+
 // Transfer is a stake transfer.
 type Transfer struct {
 	To     Address           `json:"to"`
@@ -597,11 +740,84 @@ func (wt Withdraw) PrettyType() (interface{}, error) {
 	return wt, nil
 }
 
-// NewWithdrawTx creates a new beneficiary allowance configuration transaction.
+// NewWithdrawTx creates a new withdraw transaction.
 func NewWithdrawTx(nonce uint64, fee *transaction.Fee, withdraw *Withdraw) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodWithdraw, withdraw)
 }
 
+// EscrowOperation is a single operation of an escrow batch. Exactly one of the fields must be
+// set.
+type EscrowOperation struct {
+	AddEscrow     *Escrow        `json:"add_escrow,omitempty"`
+	ReclaimEscrow *ReclaimEscrow `json:"reclaim_escrow,omitempty"`
+}
+
+// ValidateBasic performs basic escrow operation validity checks.
+func (op *EscrowOperation) ValidateBasic() error {
+	switch {
+	case op.AddEscrow != nil && op.ReclaimEscrow == nil:
+	case op.AddEscrow == nil && op.ReclaimEscrow != nil:
+		// No sense if there is nothing to reclaim.
+		if op.ReclaimEscrow.Shares.IsZero() {
+			return fmt.Errorf("%w: no shares to reclaim", ErrInvalidArgument)
+		}
+	default:
+		return fmt.Errorf("%w: exactly one escrow operation must be set", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of EscrowOperation to the given writer.
+func (op EscrowOperation) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	switch {
+	case op.AddEscrow != nil:
+		fmt.Fprintf(w, "%sAdd Escrow:\n", prefix)
+		op.AddEscrow.PrettyPrint(ctx, prefix+"  ", w)
+	case op.ReclaimEscrow != nil:
+		fmt.Fprintf(w, "%sReclaim Escrow:\n", prefix)
+		op.ReclaimEscrow.PrettyPrint(ctx, prefix+"  ", w)
+	default:
+		fmt.Fprintf(w, "%s<invalid escrow operation>\n", prefix)
+	}
+}
+
+// BatchEscrow is a batch of escrow operations executed atomically in a single transaction.
+type BatchEscrow struct {
+	Operations []EscrowOperation `json:"operations"`
+}
+
+// ValidateBasic performs basic escrow batch validity checks.
+func (be *BatchEscrow) ValidateBasic() error {
+	if len(be.Operations) == 0 {
+		return fmt.Errorf("%w: empty escrow batch", ErrInvalidArgument)
+	}
+	for i := range be.Operations {
+		if err := be.Operations[i].ValidateBasic(); err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of BatchEscrow to the given writer.
+func (be BatchEscrow) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sOperations:\n", prefix)
+	for i, op := range be.Operations {
+		fmt.Fprintf(w, "%s  %d.\n", prefix, i+1)
+		op.PrettyPrint(ctx, prefix+"    ", w)
+	}
+}
+
+// PrettyType returns a representation of BatchEscrow that can be used for pretty printing.
+func (be BatchEscrow) PrettyType() (interface{}, error) {
+	return be, nil
+}
+
+// NewBatchEscrowTx creates a new batch escrow transaction.
+func NewBatchEscrowTx(nonce uint64, fee *transaction.Fee, batch *BatchEscrow) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodBatchEscrow, batch)
+}
+
 // SharePool is a combined balance of several entries, the relative sizes
 // of which are tracked through shares.
 type SharePool struct {
@@ -1011,6 +1227,22 @@ type GeneralAccount struct {
 	// Hooks is the set of hooks that should be invoked when specific actions happen to override
 	// common behavior.
 	Hooks map[HookKind]HookDestination `json:"hooks,omitempty"`
+	// Vesting is the vesting schedule locking (part of) the balance.
+	Vesting *VestingSchedule `json:"vesting,omitempty"`
+	// VestingAllowances is the set of per-sender amounts that may be transferred into the account
+	// via vesting transfers.
+	VestingAllowances map[Address]quantity.Quantity `json:"vesting_allowances,omitempty"`
+}
+
+// Transferable returns the part of the balance that is not locked by the vesting schedule at
+// the given epoch.
+func (ga *GeneralAccount) Transferable(epoch beacon.EpochTime) *quantity.Quantity {
+	transferable := ga.Balance.Clone()
+	if err := transferable.Sub(ga.Vesting.Locked(epoch)); err != nil {
+		// Locked balance has been escrowed.
+		return quantity.NewQuantity()
+	}
+	return transferable
 }
 
 // PrettyPrint writes a pretty-printed representation of GeneralAccount to the
@@ -1041,6 +1273,20 @@ func (ga GeneralAccount) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 			fmt.Fprintf(w, "%s%s%s: %s\n", prefix, prefix, kind, dst.Module)
 		}
 	}
+
+	if ga.Vesting != nil {
+		fmt.Fprintf(w, "%sVesting:\n", prefix)
+		ga.Vesting.PrettyPrint(ctx, prefix+"  ", w)
+	}
+
+	if len(ga.VestingAllowances) > 0 {
+		fmt.Fprintf(w, "%sVesting allowances:\n", prefix)
+		for sender, allowance := range ga.VestingAllowances {
+			fmt.Fprintf(w, "%s%s%s: ", prefix, prefix, sender)
+			token.PrettyPrintAmount(ctx, allowance, w)
+			fmt.Fprintln(w)
+		}
+	}
 }
 
 // PrettyType returns a representation of GeneralAccount that can be used for
@@ -1260,6 +1506,21 @@ type ConsensusParameters struct { // nolint: maligned
 	// MaxAllowances is the maximum number of allowances an account can have. Zero means disabled.
 	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 
+	// MaxBatchEscrowOperations is the maximum number of operations in an escrow batch. Zero
+	// means disabled.
+	MaxBatchEscrowOperations uint16 `json:"max_batch_escrow_operations,omitempty"`
+
+	// MaxVestingSteps is the maximum number of pending vesting schedule steps an account can
+	// have. Zero means that vesting transfers are disabled.
+	MaxVestingSteps uint32 `json:"max_vesting_steps,omitempty"`
+
+	// ValidatorLivenessWindow is the size of the window (in blocks) over which validator
+	// liveness is tracked. Zero means disabled.
+	ValidatorLivenessWindow uint64 `json:"validator_liveness_window,omitempty"`
+	// ValidatorLivenessMaxMissed is the maximum number of blocks a validator may miss signing
+	// in a liveness window before being penalized with the consensus-liveness slashing penalty.
+	ValidatorLivenessMaxMissed uint64 `json:"validator_liveness_max_missed,omitempty"`
+
 	// FeeSplitWeightPropose is the proportion of block fee portions that go to the proposer.
 	FeeSplitWeightPropose quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the proportion of block fee portions that go to the validator that votes.
@@ -1298,6 +1559,8 @@ type ConsensusParameterChanges struct {
 	MinTransactBalance *quantity.Quantity `json:"min_transact_balance"`
 	// MinCommissionRate is the new minimum commission rate.
 	MinCommissionRate *quantity.Quantity `json:"min_commission_rate"`
+	// MaxCommissionRateChange is the new maximum commission rate change per rate change interval.
+	MaxCommissionRateChange *quantity.Quantity `json:"max_commission_rate_change,omitempty"`
 
 	// DisableTransfers is the new disable transfers flag.
 	DisableTransfers *bool `json:"disable_transfers,omitempty"`
@@ -1310,6 +1573,18 @@ type ConsensusParameterChanges struct {
 	// MaxAllowances is the new maximum number of allowances.
 	MaxAllowances *uint32 `json:"max_allowances,omitempty"`
 
+	// MaxBatchEscrowOperations is the new maximum number of operations in an escrow batch.
+	MaxBatchEscrowOperations *uint16 `json:"max_batch_escrow_operations,omitempty"`
+
+	// MaxVestingSteps is the new maximum number of pending vesting schedule steps.
+	MaxVestingSteps *uint32 `json:"max_vesting_steps,omitempty"`
+
+	// ValidatorLivenessWindow is the new validator liveness window.
+	ValidatorLivenessWindow *uint64 `json:"validator_liveness_window,omitempty"`
+	// ValidatorLivenessMaxMissed is the new maximum number of missed blocks in a validator
+	// liveness window.
+	ValidatorLivenessMaxMissed *uint64 `json:"validator_liveness_max_missed,omitempty"`
+
 	// FeeSplitWeightPropose is the new propose fee split weight.
 	FeeSplitWeightPropose *quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the new vote fee split weight.
@@ -1346,6 +1621,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MinCommissionRate != nil {
 		params.CommissionScheduleRules.MinCommissionRate = *c.MinCommissionRate
 	}
+	if c.MaxCommissionRateChange != nil {
+		params.CommissionScheduleRules.MaxRateChange = *c.MaxCommissionRateChange
+	}
 	if c.DisableTransfers != nil {
 		params.DisableTransfers = *c.DisableTransfers
 	}
@@ -1358,6 +1636,18 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxAllowances != nil {
 		params.MaxAllowances = *c.MaxAllowances
 	}
+	if c.MaxBatchEscrowOperations != nil {
+		params.MaxBatchEscrowOperations = *c.MaxBatchEscrowOperations
+	}
+	if c.MaxVestingSteps != nil {
+		params.MaxVestingSteps = *c.MaxVestingSteps
+	}
+	if c.ValidatorLivenessWindow != nil {
+		params.ValidatorLivenessWindow = *c.ValidatorLivenessWindow
+	}
+	if c.ValidatorLivenessMaxMissed != nil {
+		params.ValidatorLivenessMaxMissed = *c.ValidatorLivenessMaxMissed
+	}
 	if c.FeeSplitWeightPropose != nil {
 		params.FeeSplitWeightPropose = *c.FeeSplitWeightPropose
 	}
@@ -1391,6 +1681,10 @@ const (
 	GasOpAllow transaction.Op = "allow"
 	// GasOpWithdraw is the gas operation identifier for withdraw.
 	GasOpWithdraw transaction.Op = "withdraw"
+	// GasOpVestingTransfer is the gas operation identifier for vesting transfer.
+	GasOpVestingTransfer transaction.Op = "vesting_transfer"
+	// GasOpAllowVesting is the gas operation identifier for vesting allowances.
+	GasOpAllowVesting transaction.Op = "allow_vesting"
 )
 
 // TransferResult is the result of staking transfer.
@@ -43,6 +43,11 @@ type CommissionScheduleRules struct {
 	// MinCommissionRate is the minimum commission rate an account can configure.
 	// The rate is obtained by dividing this value with the `CommissionRateDenominator`.
 	MinCommissionRate quantity.Quantity `json:"min_commission_rate"`
+
+	// MaxRateChange is the maximum amount by which the commission rate can change per commission
+	// rate change interval. The amount is obtained by dividing this value with the
+	// `CommissionRateDenominator`. Zero means that rate changes are not limited.
+	MaxRateChange quantity.Quantity `json:"max_rate_change,omitempty"`
 }
 
 // CommissionRateStep sets a commission rate and its starting time.
@@ -194,6 +199,39 @@ func (cs *CommissionSchedule) validateNondegenerate(rules *CommissionScheduleRul
 	return nil
 }
 
+// validateRateChanges detects rate steps starting at the given index that change the rate in
+// effect before them by more than allowed.
+//
+// Only the given steps are checked so that schedules which predate the rate change limit can
+// still be amended.
+func (cs *CommissionSchedule) validateRateChanges(rules *CommissionScheduleRules, first int) error {
+	if rules.MaxRateChange.IsZero() {
+		return nil
+	}
+
+	maxRateChange := rules.MaxRateChange.ToBigInt()
+	for i := max(first, 1); i < len(cs.Rates); i++ {
+		prev, step := &cs.Rates[i-1], &cs.Rates[i]
+
+		// The rate can change by the maximum amount for every elapsed rate change interval.
+		intervals := uint64(step.Start - prev.Start)
+		if rules.RateChangeInterval > 0 {
+			intervals /= uint64(rules.RateChangeInterval)
+		}
+		limit := new(big.Int).Mul(maxRateChange, new(big.Int).SetUint64(intervals))
+
+		change := new(big.Int).Sub(step.Rate.ToBigInt(), prev.Rate.ToBigInt())
+		if change.CmpAbs(limit) > 0 {
+			return fmt.Errorf("rate step %d changes rate by %v/%v which exceeds maximum change %v/%v over %d rate change intervals",
+				i, new(big.Int).Abs(change), CommissionRateDenominator,
+				limit, CommissionRateDenominator, intervals,
+			)
+		}
+	}
+
+	return nil
+}
+
 // validateAmendmentAcceptable apply policy for "when" changes can be made, for CommissionSchedules that are amendments.
 func (cs *CommissionSchedule) validateAmendmentAcceptable(rules *CommissionScheduleRules, now beacon.EpochTime, initialSchedule bool) error {
 	if len(cs.Rates) != 0 {
@@ -387,6 +425,10 @@ func (cs *CommissionSchedule) AmendAndPruneAndValidate(amendment *CommissionSche
 	if err := cs.validateComplexity(rules); err != nil {
 		return fmt.Errorf("after pruning and amending: %w", err)
 	}
+	// Amended rate steps always end up at the end of the schedule.
+	if err := cs.validateRateChanges(rules, len(cs.Rates)-len(amendment.Rates)); err != nil {
+		return fmt.Errorf("after pruning and amending: %w", err)
+	}
 	if err := cs.validateWithinBound(now); err != nil {
 		return fmt.Errorf("after pruning and amending: %w", err)
 	}
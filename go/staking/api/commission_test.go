@@ -103,6 +103,152 @@ func TestMinCommissionRate(t *testing.T) {
 	}, &rules, 0), "amend init - all rates exactly at min commission rate")
 }
 
+func TestMaxCommissionRateChange(t *testing.T) {
+	rules := CommissionScheduleRules{
+		RateChangeInterval: 10,
+		RateBoundLead:      30,
+		MaxRateSteps:       4,
+		MaxBoundSteps:      12,
+		MaxRateChange:      mustInitQuantity(t, 5_000),
+	}
+
+	cs := CommissionSchedule{}
+	require.NoError(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 10,
+				Rate:  mustInitQuantity(t, 50_000),
+			},
+			{
+				Start: 20,
+				Rate:  mustInitQuantity(t, 55_000),
+			},
+			{
+				Start: 40,
+				Rate:  mustInitQuantity(t, 45_000),
+			},
+		},
+		Bounds: []CommissionRateBoundStep{
+			{
+				Start:   10,
+				RateMin: mustInitQuantity(t, 0),
+				RateMax: mustInitQuantity(t, 100_000),
+			},
+		},
+	}, &rules, 0), "initial schedule with rate changes within limits")
+
+	// Amendments are checked against the currently active rate.
+	requireErrorShowDiagnostic(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 30,
+				Rate:  mustInitQuantity(t, 60_001),
+			},
+		},
+	}, &rules, 20), "amend rate increase over limit")
+
+	cs = CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 10,
+				Rate:  mustInitQuantity(t, 50_000),
+			},
+		},
+		Bounds: []CommissionRateBoundStep{
+			{
+				Start:   10,
+				RateMin: mustInitQuantity(t, 0),
+				RateMax: mustInitQuantity(t, 100_000),
+			},
+		},
+	}
+	requireErrorShowDiagnostic(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 20,
+				Rate:  mustInitQuantity(t, 44_999),
+			},
+		},
+	}, &rules, 10), "amend rate decrease over limit")
+
+	cs = CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 10,
+				Rate:  mustInitQuantity(t, 50_000),
+			},
+		},
+		Bounds: []CommissionRateBoundStep{
+			{
+				Start:   10,
+				RateMin: mustInitQuantity(t, 0),
+				RateMax: mustInitQuantity(t, 100_000),
+			},
+		},
+	}
+	require.NoError(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 40,
+				Rate:  mustInitQuantity(t, 65_000),
+			},
+		},
+	}, &rules, 10), "amend rate increase spread over multiple intervals")
+
+	// Legacy schedules that violate the limit can still be amended, as only the new steps are
+	// checked against the rate in effect before them.
+	cs = CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 10,
+				Rate:  mustInitQuantity(t, 10_000),
+			},
+			{
+				Start: 20,
+				Rate:  mustInitQuantity(t, 90_000),
+			},
+			{
+				Start: 30,
+				Rate:  mustInitQuantity(t, 20_000),
+			},
+		},
+		Bounds: []CommissionRateBoundStep{
+			{
+				Start:   10,
+				RateMin: mustInitQuantity(t, 0),
+				RateMax: mustInitQuantity(t, 100_000),
+			},
+		},
+	}
+	require.NoError(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 50,
+				Rate:  mustInitQuantity(t, 25_000),
+			},
+		},
+	}, &rules, 10), "amend legacy schedule violating the limit")
+	require.Len(t, cs.Rates, 4, "legacy steps should be kept")
+	requireErrorShowDiagnostic(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 40,
+				Rate:  mustInitQuantity(t, 25_001),
+			},
+		},
+	}, &rules, 10), "amend legacy schedule with new step over limit")
+
+	rules.MaxRateChange = mustInitQuantity(t, 0)
+	require.NoError(t, cs.AmendAndPruneAndValidate(&CommissionSchedule{
+		Rates: []CommissionRateStep{
+			{
+				Start: 20,
+				Rate:  mustInitQuantity(t, 100_000),
+			},
+		},
+	}, &rules, 10), "amend rate with unlimited rate changes")
+}
+
 func TestCommissionSchedule(t *testing.T) {
 	rules := CommissionScheduleRules{
 		RateChangeInterval: 10,
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// ActiveDelegationStatus is the status of an active delegation.
+type ActiveDelegationStatus struct {
+	// Escrow is the address of the escrow account the stake is delegated to.
+	Escrow Address `json:"escrow"`
+	// Shares is the number of shares of the delegation.
+	Shares quantity.Quantity `json:"shares"`
+	// Amount is the current value of the shares in base units.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// DebondingDelegationStatus is the status of a debonding delegation.
+type DebondingDelegationStatus struct {
+	// Escrow is the address of the escrow account the stake is debonding from.
+	Escrow Address `json:"escrow"`
+	// Shares is the number of debonding shares of the delegation.
+	Shares quantity.Quantity `json:"shares"`
+	// Amount is the current value of the debonding shares in base units.
+	Amount quantity.Quantity `json:"amount"`
+	// DebondEndTime is the epoch at which the stake is returned to the general balance.
+	DebondEndTime beacon.EpochTime `json:"debond_end"`
+}
+
+// DelegationStatus is the status of all (outgoing) active and debonding delegations of an account.
+type DelegationStatus struct {
+	// Epoch is the epoch at which the status was computed.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// Active are the active delegations, sorted by escrow address.
+	Active []*ActiveDelegationStatus `json:"active,omitempty"`
+	// Debonding are the debonding delegations, sorted by debonding end time and escrow address.
+	Debonding []*DebondingDelegationStatus `json:"debonding,omitempty"`
+
+	// TotalActive is the total amount of actively delegated stake in base units.
+	TotalActive quantity.Quantity `json:"total_active"`
+	// TotalDebonding is the total amount of debonding stake in base units.
+	TotalDebonding quantity.Quantity `json:"total_debonding"`
+}
+
+// NewDelegationStatus computes the delegation status from the given delegations and debonding
+// delegations of an account at the given epoch.
+func NewDelegationStatus(
+	epoch beacon.EpochTime,
+	delegations map[Address]*DelegationInfo,
+	debondingDelegations map[Address][]*DebondingDelegationInfo,
+) (*DelegationStatus, error) {
+	status := DelegationStatus{
+		Epoch: epoch,
+	}
+
+	for escrowAddr, d := range delegations {
+		amount, err := d.Pool.StakeForShares(&d.Shares)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute delegation amount for %s: %w", escrowAddr, err)
+		}
+		if err = status.TotalActive.Add(amount); err != nil {
+			return nil, err
+		}
+		status.Active = append(status.Active, &ActiveDelegationStatus{
+			Escrow: escrowAddr,
+			Shares: *d.Shares.Clone(),
+			Amount: *amount,
+		})
+	}
+	sort.Slice(status.Active, func(i, j int) bool {
+		return bytes.Compare(status.Active[i].Escrow[:], status.Active[j].Escrow[:]) < 0
+	})
+
+	for escrowAddr, dds := range debondingDelegations {
+		for _, d := range dds {
+			amount, err := d.Pool.StakeForShares(&d.Shares)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute debonding delegation amount for %s: %w", escrowAddr, err)
+			}
+			if err = status.TotalDebonding.Add(amount); err != nil {
+				return nil, err
+			}
+			status.Debonding = append(status.Debonding, &DebondingDelegationStatus{
+				Escrow:        escrowAddr,
+				Shares:        *d.Shares.Clone(),
+				Amount:        *amount,
+				DebondEndTime: d.DebondEndTime,
+			})
+		}
+	}
+	sort.SliceStable(status.Debonding, func(i, j int) bool {
+		a, b := status.Debonding[i], status.Debonding[j]
+		if a.DebondEndTime != b.DebondEndTime {
+			return a.DebondEndTime < b.DebondEndTime
+		}
+		return bytes.Compare(a.Escrow[:], b.Escrow[:]) < 0
+	})
+
+	return &status, nil
+}
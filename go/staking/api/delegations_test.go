@@ -0,0 +1,68 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestDelegationStatus(t *testing.T) {
+	require := require.New(t)
+
+	addr1 := NewAddress(signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := NewAddress(signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+
+	// Pool where each share is worth two base units.
+	pool := SharePool{
+		Balance:     *quantity.NewFromUint64(2000),
+		TotalShares: *quantity.NewFromUint64(1000),
+	}
+	// Pool where each share is worth half a base unit.
+	debondingPool := SharePool{
+		Balance:     *quantity.NewFromUint64(500),
+		TotalShares: *quantity.NewFromUint64(1000),
+	}
+
+	status, err := NewDelegationStatus(10,
+		map[Address]*DelegationInfo{
+			addr1: {Delegation: Delegation{Shares: *quantity.NewFromUint64(100)}, Pool: pool},
+			addr2: {Delegation: Delegation{Shares: *quantity.NewFromUint64(50)}, Pool: pool},
+		},
+		map[Address][]*DebondingDelegationInfo{
+			addr1: {
+				{DebondingDelegation: DebondingDelegation{Shares: *quantity.NewFromUint64(100), DebondEndTime: 20}, Pool: debondingPool},
+			},
+			addr2: {
+				{DebondingDelegation: DebondingDelegation{Shares: *quantity.NewFromUint64(40), DebondEndTime: 15}, Pool: debondingPool},
+				{DebondingDelegation: DebondingDelegation{Shares: *quantity.NewFromUint64(60), DebondEndTime: 20}, Pool: debondingPool},
+			},
+		},
+	)
+	require.NoError(err, "NewDelegationStatus")
+	require.EqualValues(10, status.Epoch)
+
+	require.Len(status.Active, 2)
+	amounts := map[Address]uint64{addr1: 200, addr2: 100}
+	for _, d := range status.Active {
+		require.EqualValues(*quantity.NewFromUint64(amounts[d.Escrow]), d.Amount, "active delegation amount")
+	}
+	require.EqualValues(*quantity.NewFromUint64(300), status.TotalActive)
+
+	require.Len(status.Debonding, 3)
+	require.EqualValues(15, status.Debonding[0].DebondEndTime, "debonding delegations should be sorted by end time")
+	require.Equal(addr2, status.Debonding[0].Escrow)
+	require.EqualValues(*quantity.NewFromUint64(20), status.Debonding[0].Amount)
+	require.EqualValues(20, status.Debonding[1].DebondEndTime)
+	require.EqualValues(20, status.Debonding[2].DebondEndTime)
+	require.EqualValues(*quantity.NewFromUint64(100), status.TotalDebonding)
+
+	// No delegations.
+	status, err = NewDelegationStatus(10, nil, nil)
+	require.NoError(err, "NewDelegationStatus")
+	require.Empty(status.Active)
+	require.Empty(status.Debonding)
+	require.True(status.TotalActive.IsZero())
+}
@@ -48,12 +48,20 @@ var (
 	methodDebondingDelegationsTo = serviceName.NewMethod("DebondingDelegationsTo", OwnerQuery{})
 	// methodAllowance is the Allowance method.
 	methodAllowance = serviceName.NewMethod("Allowance", AllowanceQuery{})
+	// methodDelegationStatusFor is the DelegationStatusFor method.
+	methodDelegationStatusFor = serviceName.NewMethod("DelegationStatusFor", OwnerQuery{})
+	// methodVesting is the Vesting method.
+	methodVesting = serviceName.NewMethod("Vesting", OwnerQuery{})
+	// methodProjectedRewards is the ProjectedRewards method.
+	methodProjectedRewards = serviceName.NewMethod("ProjectedRewards", ProjectedRewardsQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
+	// methodGetAccountEvents is the GetAccountEvents method.
+	methodGetAccountEvents = serviceName.NewMethod("GetAccountEvents", AccountEventsQuery{})
 
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
@@ -131,6 +139,18 @@ var (
 				MethodName: methodAllowance.ShortName(),
 				Handler:    handlerAllowance,
 			},
+			{
+				MethodName: methodDelegationStatusFor.ShortName(),
+				Handler:    handlerDelegationStatusFor,
+			},
+			{
+				MethodName: methodVesting.ShortName(),
+				Handler:    handlerVesting,
+			},
+			{
+				MethodName: methodProjectedRewards.ShortName(),
+				Handler:    handlerProjectedRewards,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -143,6 +163,10 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodGetAccountEvents.ShortName(),
+				Handler:    handlerGetAccountEvents,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -545,6 +569,75 @@ func handlerAllowance(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerDelegationStatusFor(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationStatusFor(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationStatusFor.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationStatusFor(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerVesting(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query OwnerQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).Vesting(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodVesting.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).Vesting(ctx, req.(*OwnerQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerProjectedRewards(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query ProjectedRewardsQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ProjectedRewards(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodProjectedRewards.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ProjectedRewards(ctx, req.(*ProjectedRewardsQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -614,6 +707,29 @@ func handlerGetEvents(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetAccountEvents(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query AccountEventsQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetAccountEvents(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAccountEvents.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetAccountEvents(ctx, req.(*AccountEventsQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -787,6 +903,30 @@ func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*
 	return &rsp, nil
 }
 
+func (c *stakingClient) DelegationStatusFor(ctx context.Context, query *OwnerQuery) (*DelegationStatus, error) {
+	var rsp DelegationStatus
+	if err := c.conn.Invoke(ctx, methodDelegationStatusFor.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) Vesting(ctx context.Context, query *OwnerQuery) (*VestingStatus, error) {
+	var rsp VestingStatus
+	if err := c.conn.Invoke(ctx, methodVesting.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *stakingClient) ProjectedRewards(ctx context.Context, query *ProjectedRewardsQuery) ([]*ProjectedReward, error) {
+	var rsp []*ProjectedReward
+	if err := c.conn.Invoke(ctx, methodProjectedRewards.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -811,6 +951,14 @@ func (c *stakingClient) GetEvents(ctx context.Context, height int64) ([]*Event,
 	return rsp, nil
 }
 
+func (c *stakingClient) GetAccountEvents(ctx context.Context, query *AccountEventsQuery) (*AccountEvents, error) {
+	var rsp AccountEvents
+	if err := c.conn.Invoke(ctx, methodGetAccountEvents.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *stakingClient) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"fmt"
 	"math/big"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 )
 
+// MaxProjectedRewardEpochs is the maximum number of epochs rewards can be projected for.
+const MaxProjectedRewardEpochs = 1000
+
 // RewardAmountDenominator is the denominator for the reward rate.
 var RewardAmountDenominator *quantity.Quantity
 
@@ -16,6 +20,94 @@ type RewardStep struct {
 	Scale quantity.Quantity `json:"scale"`
 }
 
+// RewardScale returns the scale of the reward schedule step in effect at the given epoch or nil
+// in case the epoch is past the end of the schedule.
+func RewardScale(schedule []RewardStep, epoch beacon.EpochTime) *quantity.Quantity {
+	for i, step := range schedule {
+		if epoch < step.Until {
+			return &schedule[i].Scale
+		}
+	}
+	return nil
+}
+
+// ProjectedRewardsQuery is a projected rewards query.
+type ProjectedRewardsQuery struct {
+	Height int64   `json:"height"`
+	Owner  Address `json:"owner"`
+	// Epochs is the number of epochs following the current epoch to project rewards for.
+	Epochs uint64 `json:"epochs"`
+}
+
+// ProjectedReward is the projected epoch signing reward for an escrow account.
+type ProjectedReward struct {
+	// Epoch is the epoch at whose start the reward is disbursed.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Amount is the amount of the reward.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// ProjectRewards projects the epoch signing rewards of an escrow account with the given active
+// escrow balance for the given number of epochs following the given epoch.
+//
+// The projection assumes that the account is eligible for signing rewards in every epoch, that
+// rewards are compounded, that the consensus parameters do not change and that the common pool
+// only decreases by the rewards of the given account.
+func ProjectRewards(
+	params *ConsensusParameters,
+	balance *quantity.Quantity,
+	commonPool *quantity.Quantity,
+	epoch beacon.EpochTime,
+	numEpochs uint64,
+) ([]*ProjectedReward, error) {
+	if numEpochs > MaxProjectedRewardEpochs {
+		return nil, fmt.Errorf("%w: too many epochs (max: %d)", ErrInvalidArgument, MaxProjectedRewardEpochs)
+	}
+
+	balance = balance.Clone()
+	pool := commonPool.Clone()
+
+	var rewards []*ProjectedReward
+	for i := uint64(1); i <= numEpochs; i++ {
+		rewardEpoch := epoch + beacon.EpochTime(i)
+		if rewardEpoch < epoch {
+			// Overflow.
+			break
+		}
+		scale := RewardScale(params.RewardSchedule, rewardEpoch)
+		if scale == nil {
+			// We're past the end of the schedule.
+			break
+		}
+
+		q := balance.Clone()
+		if err := q.Mul(&params.RewardFactorEpochSigned); err != nil {
+			return nil, fmt.Errorf("failed multiplying by reward factor: %w", err)
+		}
+		if err := q.Mul(scale); err != nil {
+			return nil, fmt.Errorf("failed multiplying by reward step scale: %w", err)
+		}
+		if err := q.Quo(RewardAmountDenominator); err != nil {
+			return nil, fmt.Errorf("failed dividing by reward amount denominator: %w", err)
+		}
+		if q.Cmp(pool) == 1 {
+			// Not enough balance left in common pool.
+			q = quantity.NewQuantity()
+		}
+
+		if err := quantity.Move(balance, pool, q); err != nil {
+			return nil, fmt.Errorf("failed transferring from common pool: %w", err)
+		}
+
+		rewards = append(rewards, &ProjectedReward{
+			Epoch:  rewardEpoch,
+			Amount: *q,
+		})
+	}
+
+	return rewards, nil
+}
+
 func init() {
 	// Denominated in one millionth of a percent.
 	RewardAmountDenominator = quantity.NewQuantity()
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestProjectRewards(t *testing.T) {
+	require := require.New(t)
+
+	params := &ConsensusParameters{
+		RewardSchedule: []RewardStep{
+			{Until: 12, Scale: *quantity.NewFromUint64(1_000_000)},
+			{Until: 14, Scale: *quantity.NewFromUint64(500_000)},
+		},
+		RewardFactorEpochSigned: *quantity.NewFromUint64(100),
+	}
+
+	require.Nil(RewardScale(params.RewardSchedule, 14), "RewardScale past the end of the schedule")
+	require.Equal(quantity.NewFromUint64(500_000), RewardScale(params.RewardSchedule, 12), "RewardScale")
+
+	balance := quantity.NewFromUint64(1000)
+	commonPool := quantity.NewFromUint64(1_000_000)
+	rewards, err := ProjectRewards(params, balance, commonPool, 10, 5)
+	require.NoError(err, "ProjectRewards")
+	// Rewards are compounded and stop at the end of the schedule.
+	require.Len(rewards, 3)
+	require.EqualValues(11, rewards[0].Epoch)
+	require.Equal(*quantity.NewFromUint64(1000), rewards[0].Amount)
+	require.EqualValues(12, rewards[1].Epoch)
+	require.Equal(*quantity.NewFromUint64(1000), rewards[1].Amount)
+	require.EqualValues(13, rewards[2].Epoch)
+	require.Equal(*quantity.NewFromUint64(1500), rewards[2].Amount)
+	require.Equal(quantity.NewFromUint64(1000), balance, "inputs should not be modified")
+	require.Equal(quantity.NewFromUint64(1_000_000), commonPool, "inputs should not be modified")
+
+	// Rewards exceeding the common pool balance are not paid.
+	rewards, err = ProjectRewards(params, balance, quantity.NewFromUint64(2500), 10, 3)
+	require.NoError(err, "ProjectRewards")
+	require.Len(rewards, 3)
+	require.Equal(*quantity.NewFromUint64(1000), rewards[0].Amount)
+	require.Equal(*quantity.NewFromUint64(1000), rewards[1].Amount)
+	require.True(rewards[2].Amount.IsZero(), "rewards exceeding the common pool should not be paid")
+
+	_, err = ProjectRewards(params, balance, commonPool, 10, MaxProjectedRewardEpochs+1)
+	require.ErrorIs(err, ErrInvalidArgument, "ProjectRewards with too many epochs")
+}
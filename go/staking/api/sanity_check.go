@@ -48,6 +48,17 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if p.CommissionScheduleRules.MinCommissionRate.Cmp(CommissionRateDenominator) > 0 {
 		return fmt.Errorf("minimum commission %v/%v over unity", p.CommissionScheduleRules, CommissionRateDenominator)
 	}
+	// MaxRateChange bound.
+	if !p.CommissionScheduleRules.MaxRateChange.IsValid() || p.CommissionScheduleRules.MaxRateChange.Cmp(CommissionRateDenominator) > 0 {
+		return fmt.Errorf("maximum commission rate change %v/%v over unity", p.CommissionScheduleRules.MaxRateChange, CommissionRateDenominator)
+	}
+
+	// Validator liveness.
+	if p.ValidatorLivenessWindow > 0 && p.ValidatorLivenessMaxMissed >= p.ValidatorLivenessWindow {
+		return fmt.Errorf("validator liveness max missed blocks (%d) must be smaller than the window (%d)",
+			p.ValidatorLivenessMaxMissed, p.ValidatorLivenessWindow,
+		)
+	}
 
 	// Reward schedule steps must be sequential.
 	var prevUntil beacon.EpochTime
@@ -76,10 +87,15 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.MinTransferAmount == nil &&
 		c.MinTransactBalance == nil &&
 		c.MinCommissionRate == nil &&
+		c.MaxCommissionRateChange == nil &&
 		c.DisableTransfers == nil &&
 		c.DisableDelegation == nil &&
 		c.AllowEscrowMessages == nil &&
 		c.MaxAllowances == nil &&
+		c.MaxBatchEscrowOperations == nil &&
+		c.MaxVestingSteps == nil &&
+		c.ValidatorLivenessWindow == nil &&
+		c.ValidatorLivenessMaxMissed == nil &&
 		c.FeeSplitWeightPropose == nil &&
 		c.FeeSplitWeightVote == nil &&
 		c.FeeSplitWeightNextPropose == nil &&
@@ -144,6 +160,24 @@ func SanityCheckAccount(
 		}
 	}
 
+	for sender, allowance := range acct.General.VestingAllowances {
+		if !sender.IsValid() {
+			return fmt.Errorf("staking: sanity check failed: account %s vesting allowance has invalid sender address %s", addr, sender)
+		}
+		if !allowance.IsValid() {
+			return fmt.Errorf("staking: sanity check failed: account %s vesting allowance is invalid for sender %s", addr, sender)
+		}
+		if allowance.Cmp(totalSupply) > 0 {
+			return fmt.Errorf("staking: sanity check failed: account %s vesting allowance is greater than total supply for sender %s", addr, sender)
+		}
+	}
+
+	if acct.General.Vesting != nil {
+		if err := acct.General.Vesting.SanityCheck(); err != nil {
+			return fmt.Errorf("staking: sanity check failed: vesting schedule for account %s is invalid: %w", addr, err)
+		}
+	}
+
 	return nil
 }
 
@@ -21,6 +21,8 @@ const (
 	SlashBeaconNonparticipation SlashReason = 0x03
 	// SlashConsensusLightClientAttack is slashing due to light client attacks.
 	SlashConsensusLightClientAttack SlashReason = 0x04
+	// SlashConsensusLiveness is slashing due to missing too many blocks.
+	SlashConsensusLiveness SlashReason = 0x05
 
 	// SlashRuntimeIncorrectResults is slashing due to submission of incorrect
 	// results in runtime executor commitments.
@@ -35,6 +37,8 @@ const (
 	SlashConsensusEquivocationName = "consensus-equivocation"
 	// SlashConsensusLightClientAttackName is the string representation of SlashConsensusLightClientAttack.
 	SlashConsensusLightClientAttackName = "consensus-light-client-attack"
+	// SlashConsensusLivenessName is the string representation of SlashConsensusLiveness.
+	SlashConsensusLivenessName = "consensus-liveness"
 	// SlashRuntimeIncorrectResultsName is the string representation of SlashRuntimeIncorrectResultsName.
 	SlashRuntimeIncorrectResultsName = "runtime-incorrect-results"
 	// SlashRuntimeEquivocationName is the string representation of SlashRuntimeEquivocation.
@@ -55,6 +59,8 @@ func (s SlashReason) checkedString() (string, error) {
 		return SlashConsensusEquivocationName, nil
 	case SlashConsensusLightClientAttack:
 		return SlashConsensusLightClientAttackName, nil
+	case SlashConsensusLiveness:
+		return SlashConsensusLivenessName, nil
 	case SlashRuntimeIncorrectResults:
 		return SlashRuntimeIncorrectResultsName, nil
 	case SlashRuntimeEquivocation:
@@ -83,6 +89,8 @@ func (s *SlashReason) UnmarshalText(text []byte) error {
 		*s = SlashConsensusEquivocation
 	case SlashConsensusLightClientAttackName:
 		*s = SlashConsensusLightClientAttack
+	case SlashConsensusLivenessName:
+		*s = SlashConsensusLiveness
 	case SlashRuntimeIncorrectResultsName:
 		*s = SlashRuntimeIncorrectResults
 	case SlashRuntimeEquivocationName:
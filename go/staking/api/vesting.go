@@ -0,0 +1,268 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
+)
+
+var (
+	_ prettyprint.PrettyPrinter = (*VestingStep)(nil)
+	_ prettyprint.PrettyPrinter = (*VestingSchedule)(nil)
+	_ prettyprint.PrettyPrinter = (*VestingTransfer)(nil)
+	_ prettyprint.PrettyPrinter = (*AllowVesting)(nil)
+)
+
+// VestingStep is a vesting schedule step.
+type VestingStep struct {
+	// Epoch is the epoch at whose start the amount unlocks.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Amount is the amount that unlocks.
+	Amount quantity.Quantity `json:"amount"`
+}
+
+// PrettyPrint writes a pretty-printed representation of VestingStep to the given writer.
+func (vs VestingStep) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sEpoch:  %d\n", prefix, vs.Epoch)
+
+	fmt.Fprintf(w, "%sAmount: ", prefix)
+	token.PrettyPrintAmount(ctx, vs.Amount, w)
+	fmt.Fprintln(w)
+}
+
+// PrettyType returns a representation of VestingStep that can be used for pretty printing.
+func (vs VestingStep) PrettyType() (interface{}, error) {
+	return vs, nil
+}
+
+// ValidateVestingSteps checks that the given vesting steps are sorted by strictly increasing
+// epochs and that all amounts are non-zero.
+func ValidateVestingSteps(steps []VestingStep) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("%w: no vesting steps", ErrInvalidArgument)
+	}
+	for i, step := range steps {
+		if step.Amount.IsZero() {
+			return fmt.Errorf("%w: vesting step %d: zero amount", ErrInvalidArgument, i)
+		}
+		if i > 0 && step.Epoch <= steps[i-1].Epoch {
+			return fmt.Errorf("%w: vesting step %d: epochs not strictly increasing", ErrInvalidArgument, i)
+		}
+	}
+	return nil
+}
+
+// VestingSchedule is the vesting schedule of a general account.
+//
+// Balance that is still locked by the schedule cannot be transferred, burned or withdrawn, but
+// it can be escrowed.
+type VestingSchedule struct {
+	// Total is the total amount ever placed under the vesting schedule.
+	Total quantity.Quantity `json:"total"`
+	// Steps are the vesting schedule steps that have not yet unlocked, sorted by epoch.
+	Steps []VestingStep `json:"steps,omitempty"`
+}
+
+// Locked returns the amount that is still locked at the given epoch.
+func (vs *VestingSchedule) Locked(epoch beacon.EpochTime) *quantity.Quantity {
+	locked := quantity.NewQuantity()
+	if vs == nil {
+		return locked
+	}
+	for _, step := range vs.Steps {
+		if step.Epoch > epoch {
+			_ = locked.Add(&step.Amount)
+		}
+	}
+	return locked
+}
+
+// Prune removes the steps that have already unlocked at the given epoch.
+func (vs *VestingSchedule) Prune(epoch beacon.EpochTime) {
+	var i int
+	for i < len(vs.Steps) && vs.Steps[i].Epoch <= epoch {
+		i++
+	}
+	vs.Steps = vs.Steps[i:]
+	if len(vs.Steps) == 0 {
+		vs.Steps = nil
+	}
+}
+
+// Add merges the given (validated) steps into the vesting schedule.
+func (vs *VestingSchedule) Add(steps []VestingStep) error {
+	merged := make([]VestingStep, 0, len(vs.Steps)+len(steps))
+	var i, j int
+	for i < len(vs.Steps) || j < len(steps) {
+		switch {
+		case j == len(steps) || (i < len(vs.Steps) && vs.Steps[i].Epoch < steps[j].Epoch):
+			merged = append(merged, vs.Steps[i])
+			i++
+		case i == len(vs.Steps) || steps[j].Epoch < vs.Steps[i].Epoch:
+			merged = append(merged, steps[j])
+			j++
+		default:
+			// Steps unlocking at the same epoch are combined.
+			step := VestingStep{
+				Epoch:  steps[j].Epoch,
+				Amount: *vs.Steps[i].Amount.Clone(),
+			}
+			if err := step.Amount.Add(&steps[j].Amount); err != nil {
+				return err
+			}
+			merged = append(merged, step)
+			i++
+			j++
+		}
+	}
+	for _, step := range steps {
+		if err := vs.Total.Add(&step.Amount); err != nil {
+			return err
+		}
+	}
+	vs.Steps = merged
+	return nil
+}
+
+// SanityCheck performs a sanity check on the vesting schedule.
+func (vs *VestingSchedule) SanityCheck() error {
+	if len(vs.Steps) == 0 {
+		return nil
+	}
+	if err := ValidateVestingSteps(vs.Steps); err != nil {
+		return err
+	}
+	if vs.Locked(0).Cmp(&vs.Total) > 0 {
+		return fmt.Errorf("%w: locked amount exceeds vesting total", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of VestingSchedule to the given writer.
+func (vs VestingSchedule) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTotal: ", prefix)
+	token.PrettyPrintAmount(ctx, vs.Total, w)
+	fmt.Fprintln(w)
+
+	if len(vs.Steps) == 0 {
+		fmt.Fprintf(w, "%sSteps: (none)\n", prefix)
+		return
+	}
+	fmt.Fprintf(w, "%sSteps:\n", prefix)
+	for _, step := range vs.Steps {
+		step.PrettyPrint(ctx, prefix+"  ", w)
+	}
+}
+
+// PrettyType returns a representation of VestingSchedule that can be used for pretty printing.
+func (vs VestingSchedule) PrettyType() (interface{}, error) {
+	return vs, nil
+}
+
+// VestingStatus is the vested/unvested split of an account's vesting schedule.
+type VestingStatus struct {
+	// Vested is the amount placed under the vesting schedule that has already unlocked.
+	Vested quantity.Quantity `json:"vested"`
+	// Unvested is the amount that is still locked.
+	Unvested quantity.Quantity `json:"unvested"`
+	// Steps are the vesting schedule steps that have not yet unlocked.
+	Steps []VestingStep `json:"steps,omitempty"`
+}
+
+// NewVestingStatus computes the vesting status of the given vesting schedule at the given epoch.
+func NewVestingStatus(vs *VestingSchedule, epoch beacon.EpochTime) *VestingStatus {
+	var status VestingStatus
+	if vs == nil {
+		return &status
+	}
+
+	status.Unvested = *vs.Locked(epoch)
+	status.Vested = *vs.Total.Clone()
+	_ = status.Vested.Sub(&status.Unvested)
+	for _, step := range vs.Steps {
+		if step.Epoch > epoch {
+			status.Steps = append(status.Steps, step)
+		}
+	}
+	return &status
+}
+
+// VestingTransfer is a transfer of stake that is locked at the destination according to a
+// vesting schedule.
+type VestingTransfer struct {
+	To Address `json:"to"`
+	// Schedule is the vesting schedule of the transferred amount. The transferred amount is the
+	// sum of the amounts of all steps.
+	Schedule []VestingStep `json:"schedule"`
+}
+
+// Amount returns the total amount transferred by the vesting transfer.
+func (vt *VestingTransfer) Amount() (*quantity.Quantity, error) {
+	amount := quantity.NewQuantity()
+	for _, step := range vt.Schedule {
+		if err := amount.Add(&step.Amount); err != nil {
+			return nil, err
+		}
+	}
+	return amount, nil
+}
+
+// PrettyPrint writes a pretty-printed representation of VestingTransfer to the given writer.
+func (vt VestingTransfer) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sTo:       %s\n", prefix, vt.To)
+
+	fmt.Fprintf(w, "%sSchedule:\n", prefix)
+	for _, step := range vt.Schedule {
+		step.PrettyPrint(ctx, prefix+"  ", w)
+	}
+}
+
+// PrettyType returns a representation of VestingTransfer that can be used for pretty printing.
+func (vt VestingTransfer) PrettyType() (interface{}, error) {
+	return vt, nil
+}
+
+// NewVestingTransferTx creates a new vesting transfer transaction.
+func NewVestingTransferTx(nonce uint64, fee *transaction.Fee, xfer *VestingTransfer) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodVestingTransfer, xfer)
+}
+
+// AllowVesting is a vesting allowance update.
+//
+// Vesting transfers are only accepted from senders that the destination account has allowed
+// to transfer the given amount, so that others cannot fill its vesting schedule.
+type AllowVesting struct {
+	Sender       Address           `json:"sender"`
+	Negative     bool              `json:"negative,omitempty"`
+	AmountChange quantity.Quantity `json:"amount_change"`
+}
+
+// PrettyPrint writes a pretty-printed representation of AllowVesting to the given writer.
+func (av AllowVesting) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sSender:        %s\n", prefix, av.Sender)
+
+	sign := "+"
+	if av.Negative {
+		sign = "-"
+	}
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueSign, sign)
+	fmt.Fprintf(w, "%sAmount change: ", prefix)
+	token.PrettyPrintAmount(ctx, av.AmountChange, w)
+	fmt.Fprintln(w)
+}
+
+// PrettyType returns a representation of AllowVesting that can be used for pretty printing.
+func (av AllowVesting) PrettyType() (interface{}, error) {
+	return av, nil
+}
+
+// NewAllowVestingTx creates a new vesting allowance transaction.
+func NewAllowVestingTx(nonce uint64, fee *transaction.Fee, allow *AllowVesting) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodAllowVesting, allow)
+}
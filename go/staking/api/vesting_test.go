@@ -0,0 +1,75 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+func TestVestingSchedule(t *testing.T) {
+	require := require.New(t)
+
+	step := func(epoch beacon.EpochTime, amount uint64) VestingStep {
+		return VestingStep{Epoch: epoch, Amount: *quantity.NewFromUint64(amount)}
+	}
+
+	var vs *VestingSchedule
+	require.True(vs.Locked(0).IsZero(), "nil schedule should not lock anything")
+
+	vs = &VestingSchedule{}
+	err := vs.Add([]VestingStep{step(10, 100), step(20, 200)})
+	require.NoError(err, "Add")
+	err = vs.Add([]VestingStep{step(5, 50), step(20, 20), step(30, 300)})
+	require.NoError(err, "Add")
+	require.Equal([]VestingStep{step(5, 50), step(10, 100), step(20, 220), step(30, 300)}, vs.Steps)
+	require.EqualValues(*quantity.NewFromUint64(670), vs.Total)
+	require.NoError(vs.SanityCheck(), "SanityCheck")
+
+	for _, tc := range []struct {
+		epoch  beacon.EpochTime
+		locked uint64
+	}{
+		{0, 670},
+		{5, 620},
+		{9, 620},
+		{10, 520},
+		{29, 300},
+		{30, 0},
+	} {
+		require.EqualValues(*quantity.NewFromUint64(tc.locked), *vs.Locked(tc.epoch), "locked amount at epoch %d", tc.epoch)
+	}
+
+	status := NewVestingStatus(vs, 10)
+	require.EqualValues(*quantity.NewFromUint64(150), status.Vested)
+	require.EqualValues(*quantity.NewFromUint64(520), status.Unvested)
+	require.Equal([]VestingStep{step(20, 220), step(30, 300)}, status.Steps)
+
+	vs.Prune(10)
+	require.Equal([]VestingStep{step(20, 220), step(30, 300)}, vs.Steps)
+	require.EqualValues(*quantity.NewFromUint64(670), vs.Total, "pruning should not change the total")
+	vs.Prune(30)
+	require.Nil(vs.Steps)
+
+	ga := GeneralAccount{
+		Balance: *quantity.NewFromUint64(400),
+		Vesting: &VestingSchedule{
+			Total: *quantity.NewFromUint64(500),
+			Steps: []VestingStep{step(10, 500)},
+		},
+	}
+	require.True(ga.Transferable(9).IsZero(), "locked balance should not be transferable")
+	require.EqualValues(*quantity.NewFromUint64(400), *ga.Transferable(10))
+
+	require.Error(ValidateVestingSteps(nil), "empty steps should be invalid")
+	require.Error(ValidateVestingSteps([]VestingStep{step(10, 0)}), "zero amount should be invalid")
+	require.Error(ValidateVestingSteps([]VestingStep{step(10, 1), step(10, 1)}), "duplicate epochs should be invalid")
+
+	vs = &VestingSchedule{
+		Total: *quantity.NewFromUint64(100),
+		Steps: []VestingStep{step(10, 200)},
+	}
+	require.Error(vs.SanityCheck(), "locked amount exceeding total should be invalid")
+}
@@ -143,6 +143,56 @@ func main() {
 					vectors = append(vectors, testvectors.MakeTestVector("Withdraw", tx, true))
 				}
 			}
+
+			// Generate batch escrow transactions.
+			for _, amt := range []uint64{1000, 10_000_000} {
+				for _, tx := range []*transaction.Transaction{
+					staking.NewBatchEscrowTx(nonce, fee, &staking.BatchEscrow{
+						Operations: []staking.EscrowOperation{
+							{ReclaimEscrow: &staking.ReclaimEscrow{
+								Account: escrowSrcAddr,
+								Shares:  *quantity.NewFromUint64(amt),
+							}},
+							{AddEscrow: &staking.Escrow{
+								Account: escrowDstAddr,
+								Amount:  *quantity.NewFromUint64(amt),
+							}},
+						},
+					}),
+				} {
+					vectors = append(vectors, testvectors.MakeTestVector("BatchEscrow", tx, true))
+				}
+			}
+
+			// Generate vesting transfer transactions.
+			for _, amt := range []uint64{1000, 10_000_000} {
+				for _, tx := range []*transaction.Transaction{
+					staking.NewVestingTransferTx(nonce, fee, &staking.VestingTransfer{
+						To: transferDstAddr,
+						Schedule: []staking.VestingStep{
+							{Epoch: 100, Amount: *quantity.NewFromUint64(amt)},
+							{Epoch: 200, Amount: *quantity.NewFromUint64(amt)},
+						},
+					}),
+				} {
+					vectors = append(vectors, testvectors.MakeTestVector("VestingTransfer", tx, true))
+				}
+			}
+
+			// Generate vesting allowance transactions.
+			for _, amt := range []uint64{0, 1000, 10_000_000} {
+				for _, negative := range []bool{false, true} {
+					for _, tx := range []*transaction.Transaction{
+						staking.NewAllowVestingTx(nonce, fee, &staking.AllowVesting{
+							Sender:       transferDstAddr,
+							Negative:     negative,
+							AmountChange: *quantity.NewFromUint64(amt),
+						}),
+					} {
+						vectors = append(vectors, testvectors.MakeTestVector("AllowVesting", tx, true))
+					}
+				}
+			}
 		}
 	}
 
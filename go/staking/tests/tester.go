@@ -450,6 +450,17 @@ func testDelegations(t *testing.T, state *stakingTestsState, backend api.Backend
 				)
 			}
 		}
+
+		delStatus, err := backend.DelegationStatusFor(
+			context.Background(), &api.OwnerQuery{Owner: accts.GetAddress(a), Height: consensusAPI.HeightLatest},
+		)
+		require.NoErrorf(err, "account %d - DelegationStatusFor", a)
+		require.Lenf(delStatus.Active, len(expectedDelegationsFor[a]), "account %d - number of active delegations in status", a)
+		var numDebDelegations int
+		for _, num := range expectedDebDelegationsFor[a] {
+			numDebDelegations += num
+		}
+		require.Lenf(delStatus.Debonding, numDebDelegations, "account %d - number of debonding delegations in status", a)
 	}
 }
 
@@ -39,6 +39,9 @@ var (
 	ErrUnsupported = errors.New(ModuleName, 4, "storage: method not supported by backend")
 	// ErrLimitReached means that a configured limit has been reached.
 	ErrLimitReached = errors.New(ModuleName, 5, "storage: limit reached")
+	// ErrWriteLogConstraintViolation is the error returned when a write log
+	// violates the write log constraints.
+	ErrWriteLogConstraintViolation = errors.New(ModuleName, 6, "storage: write log constraint violation")
 
 	// The following errors are reimports from NodeDB.
 
@@ -94,6 +97,10 @@ type Config struct { // nolint: maligned
 
 	// ReadOnly will make the storage read-only.
 	ReadOnly bool
+
+	// EncryptionKey is the key used to encrypt the database at rest. If empty, the database is
+	// not encrypted.
+	EncryptionKey []byte
 }
 
 // ToNodeDB converts from a Config to a node DB Config.
@@ -106,6 +113,7 @@ func (cfg *Config) ToNodeDB() *nodedb.Config {
 		MemoryOnly:       cfg.MemoryOnly,
 		ReadOnly:         cfg.ReadOnly,
 		DiscardWriteLogs: cfg.DiscardWriteLogs,
+		EncryptionKey:    cfg.EncryptionKey,
 	}
 }
 
@@ -186,6 +194,9 @@ type ApplyRequest struct {
 	DstRound  uint64           `json:"dst_round"`
 	DstRoot   hash.Hash        `json:"dst_root"`
 	WriteLog  WriteLog         `json:"writelog"`
+
+	// Constraints are optional constraints that the write log must satisfy.
+	Constraints *WriteLogConstraints `json:"constraints,omitempty"`
 }
 
 // SyncOptions are the sync options.
@@ -214,6 +225,8 @@ type Backend interface {
 
 	// GetDiff returns an iterator of write log entries that must be applied
 	// to get from the first given root to the second one.
+	//
+	// The returned iterator yields entries in ascending key order.
 	GetDiff(ctx context.Context, request *GetDiffRequest) (WriteLogIterator, error)
 
 	// Cleanup closes/cleans up the storage backend.
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// WriteLogConstraints are the constraints that write log entries must satisfy in order to be
+// applied.
+type WriteLogConstraints struct {
+	// MaxKeySize is the maximum key size (in bytes). Zero means no limit.
+	MaxKeySize uint64 `json:"max_key_size,omitempty"`
+
+	// MaxValueSize is the maximum value size (in bytes). Zero means no limit.
+	MaxValueSize uint64 `json:"max_value_size,omitempty"`
+
+	// ReservedKeyPrefixes are the key prefixes that may not be written to.
+	ReservedKeyPrefixes [][]byte `json:"reserved_key_prefixes,omitempty"`
+}
+
+// Validate checks that all write log entries satisfy the constraints.
+func (c *WriteLogConstraints) Validate(wl WriteLog) error {
+	for i, entry := range wl {
+		if c.MaxKeySize > 0 && uint64(len(entry.Key)) > c.MaxKeySize {
+			return fmt.Errorf("%w: entry %d: key size %d exceeds maximum %d",
+				ErrWriteLogConstraintViolation, i, len(entry.Key), c.MaxKeySize,
+			)
+		}
+		if c.MaxValueSize > 0 && uint64(len(entry.Value)) > c.MaxValueSize {
+			return fmt.Errorf("%w: entry %d: value size %d exceeds maximum %d",
+				ErrWriteLogConstraintViolation, i, len(entry.Value), c.MaxValueSize,
+			)
+		}
+		for _, prefix := range c.ReservedKeyPrefixes {
+			if bytes.HasPrefix(entry.Key, prefix) {
+				return fmt.Errorf("%w: entry %d: key uses reserved prefix %X",
+					ErrWriteLogConstraintViolation, i, prefix,
+				)
+			}
+		}
+	}
+	return nil
+}
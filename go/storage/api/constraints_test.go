@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteLogConstraints(t *testing.T) {
+	require := require.New(t)
+
+	c := WriteLogConstraints{
+		MaxKeySize:          4,
+		MaxValueSize:        8,
+		ReservedKeyPrefixes: [][]byte{[]byte("__")},
+	}
+
+	for _, tc := range []struct {
+		wl    WriteLog
+		valid bool
+		msg   string
+	}{
+		{nil, true, "empty write log"},
+		{WriteLog{{Key: []byte("key"), Value: []byte("value")}}, true, "valid entry"},
+		{WriteLog{{Key: []byte("key"), Value: nil}}, true, "removal"},
+		{WriteLog{{Key: []byte("long key"), Value: []byte("value")}}, false, "key too long"},
+		{WriteLog{{Key: []byte("key"), Value: []byte("long value")}}, false, "value too long"},
+		{WriteLog{{Key: []byte("_a"), Value: []byte("value")}}, true, "non-reserved prefix"},
+		{WriteLog{{Key: []byte("__a"), Value: []byte("value")}}, false, "reserved prefix"},
+		{WriteLog{{Key: []byte("a"), Value: nil}, {Key: []byte("__"), Value: nil}}, false, "reserved prefix removal"},
+	} {
+		err := c.Validate(tc.wl)
+		if tc.valid {
+			require.NoError(err, tc.msg)
+		} else {
+			require.ErrorIs(err, ErrWriteLogConstraintViolation, tc.msg)
+		}
+	}
+
+	// Zero constraints should not restrict anything.
+	var empty WriteLogConstraints
+	require.NoError(empty.Validate(WriteLog{{Key: []byte("__long key"), Value: []byte("long value")}}))
+}
@@ -143,6 +143,11 @@ func (ba *databaseBackend) Apply(ctx context.Context, request *api.ApplyRequest)
 	if ba.readOnly {
 		return fmt.Errorf("storage/database: failed to Apply: %w", api.ErrReadOnly)
 	}
+	if request.Constraints != nil {
+		if err := request.Constraints.Validate(request.WriteLog); err != nil {
+			return fmt.Errorf("storage/database: failed to Apply: %w", err)
+		}
+	}
 
 	oldRoot := api.Root{
 		Namespace: request.Namespace,
@@ -140,3 +140,41 @@ func TestAutoBackend(t *testing.T) {
 		require.Equal(filepath.Join(tmpDir, DefaultFileName("pathbadger")), cfg.DB)
 	})
 }
+
+func BenchmarkStorageDatabase(b *testing.B) {
+	for _, v := range []string{
+		BackendNameBadgerDB,
+		BackendNamePathBadger,
+	} {
+		b.Run(v, func(b *testing.B) {
+			doBenchmarkImpl(b, v)
+		})
+	}
+}
+
+func doBenchmarkImpl(b *testing.B, backend string) {
+	require := require.New(b)
+
+	testNs := common.NewTestNamespaceFromSeed([]byte("database backend benchmark ns"), 0)
+
+	var (
+		cfg = api.Config{
+			Backend:      backend,
+			Namespace:    testNs,
+			MaxCacheSize: 16 * 1024 * 1024,
+			NoFsync:      true,
+		}
+		err error
+	)
+
+	cfg.DB, err = os.MkdirTemp("", "oasis-storage-database-bench")
+	require.NoError(err, "TempDir()")
+	defer os.RemoveAll(cfg.DB)
+
+	cfg.DB = filepath.Join(cfg.DB, DefaultFileName(backend))
+	impl, err := New(&cfg)
+	require.NoError(err, "New()")
+	defer impl.Cleanup()
+
+	tests.StorageImplementationBenchmarks(b, impl, impl, testNs, 0)
+}
@@ -80,6 +80,11 @@ type Config struct { // nolint: maligned
 
 	// DiscardWriteLogs will cause all write logs to be discarded.
 	DiscardWriteLogs bool
+
+	// EncryptionKey is the key used to encrypt the database at rest. It must be 16, 24 or 32
+	// bytes long to select AES-128, AES-192 or AES-256 respectively. If empty, the database is
+	// not encrypted.
+	EncryptionKey []byte
 }
 
 // Factory is a node database factory interface that can create new databases.
@@ -133,6 +138,8 @@ type NodeDB interface {
 	GetNode(root node.Root, ptr *node.Pointer) (node.Node, error)
 
 	// GetWriteLog retrieves a write log between two storage instances from the database.
+	//
+	// The returned iterator yields entries in ascending key order.
 	GetWriteLog(ctx context.Context, startRoot, endRoot node.Root) (writelog.Iterator, error)
 
 	// GetLatestVersion returns the most recent version in the node database.
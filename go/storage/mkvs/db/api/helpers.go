@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"sort"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
@@ -37,7 +39,12 @@ func MakeHashedDBWriteLog(writeLog writelog.WriteLog, annotations writelog.Annot
 // a HashedDBWriteLog into a WriteLog.
 //
 // The provided logGetter will be called first to fetch the next write log to
-// convert. If it returns a nil write log, iteration terminates.
+// convert, in the order in which the write logs were applied. If it returns a nil
+// write log, there are no more write logs.
+//
+// The entries of all write logs are then sorted by key. In case the same key is
+// present in multiple write logs, only the entry from the write log that was
+// applied last is kept.
 //
 // Then the provided valueGetter will be called for each log entry to fetch each
 // of the values in the write log.
@@ -54,6 +61,11 @@ func ReviveHashedDBWriteLogs(
 		defer pipe.Close()
 		defer closer()
 
+		type rootedEntry struct {
+			root  node.Root
+			entry HashedDBLogEntry
+		}
+		var entries []rootedEntry
 		for {
 			// Return early if context has been cancelled.
 			if ctx.Err() != nil {
@@ -67,31 +79,51 @@ func ReviveHashedDBWriteLogs(
 				return
 			}
 			if log == nil {
-				return
+				break
 			}
 
 			for _, entry := range log {
-				var newEntry *writelog.LogEntry
-				if entry.InsertedHash == nil {
-					newEntry = &writelog.LogEntry{
-						Key:   entry.Key,
-						Value: nil,
-					}
-				} else {
-					node, err := valueGetter(root, *entry.InsertedHash)
-					if err != nil {
-						_ = pipe.PutError(err)
-						return
-					}
-					newEntry = &writelog.LogEntry{
-						Key:   entry.Key,
-						Value: node.Value,
-					}
+				entries = append(entries, rootedEntry{root: root, entry: entry})
+			}
+		}
+
+		// Sort entries by key. The sort is stable so that entries with the same key remain in
+		// the order in which they were applied.
+		sort.SliceStable(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i].entry.Key, entries[j].entry.Key) < 0
+		})
+
+		for idx, re := range entries {
+			// Return early if context has been cancelled.
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Skip entries overridden by a later write log.
+			if idx+1 < len(entries) && bytes.Equal(re.entry.Key, entries[idx+1].entry.Key) {
+				continue
+			}
+
+			var newEntry *writelog.LogEntry
+			if re.entry.InsertedHash == nil {
+				newEntry = &writelog.LogEntry{
+					Key:   re.entry.Key,
+					Value: nil,
 				}
-				if err := pipe.Put(newEntry); err != nil {
+			} else {
+				node, err := valueGetter(re.root, *re.entry.InsertedHash)
+				if err != nil {
 					_ = pipe.PutError(err)
 					return
 				}
+				newEntry = &writelog.LogEntry{
+					Key:   re.entry.Key,
+					Value: node.Value,
+				}
+			}
+			if err := pipe.Put(newEntry); err != nil {
+				_ = pipe.PutError(err)
+				return
 			}
 		}
 	}()
@@ -408,14 +408,15 @@ func (d *badgerNodeDB) GetWriteLog(ctx context.Context, startRoot, endRoot node.
 					logRoots: append(curItem.logRoots, curItem.endRootHash),
 				}
 				if nextItem.endRootHash.Equal(&startRootHash) {
-					// Path has been found, deserialize and stream write logs.
-					var index int
+					// Path has been found, deserialize and stream write logs in the order in
+					// which they were applied (the path was discovered in reverse).
+					index := len(nextItem.logKeys) - 1
 					discardTx = false
 					// Close iterator now as ReviveHashedDBWriteLogs can close the txn immediately.
 					it.Close()
 					return api.ReviveHashedDBWriteLogs(ctx,
 						func() (node.Root, api.HashedDBWriteLog, error) {
-							if index >= len(nextItem.logKeys) {
+							if index < 0 {
 								return node.Root{}, nil, nil
 							}
 
@@ -440,7 +441,7 @@ func (d *badgerNodeDB) GetWriteLog(ctx context.Context, startRoot, endRoot node.
 								return node.Root{}, nil, err
 							}
 
-							index++
+							index--
 							return root, log, nil
 						},
 						func(root node.Root, h hash.Hash) (*node.LeafNode, error) {
@@ -379,3 +379,42 @@ func TestFinalizeBasic(t *testing.T) {
 	err = ndb.Finalize([]node.Root{root2})
 	require.Errorf(err, "mkvs: root not found", "Finalize({root2-broken})")
 }
+
+func TestEncryption(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+
+	encCfg := *dbCfg
+	encCfg.MemoryOnly = false
+	encCfg.DB = t.TempDir()
+	encCfg.EncryptionKey = bytes.Repeat([]byte{0x42}, 32)
+
+	ndb, err := New(&encCfg)
+	require.NoError(err, "New()")
+	root := fillDB(ctx, require, testValues, nil, 1, 2, ndb)
+	err = ndb.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize()")
+	ndb.Close()
+
+	// Opening the database with a different or without a key should fail.
+	wrongCfg := encCfg
+	wrongCfg.EncryptionKey = bytes.Repeat([]byte{0x43}, 32)
+	_, err = New(&wrongCfg)
+	require.Error(err, "New() with wrong key")
+
+	plainCfg := encCfg
+	plainCfg.EncryptionKey = nil
+	_, err = New(&plainCfg)
+	require.Error(err, "New() without key")
+
+	// Reopening with the same key should give access to the data.
+	ndb, err = New(&encCfg)
+	require.NoError(err, "New() - reopen")
+	defer ndb.Close()
+
+	tree := mkvs.NewWithRoot(nil, ndb, root)
+	defer tree.Close()
+	value, err := tree.Get(ctx, []byte("0"))
+	require.NoError(err, "Get()")
+	require.Equal(testValues[0], value)
+}
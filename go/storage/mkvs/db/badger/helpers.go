@@ -40,6 +40,12 @@ func commonConfigToBadgerOptions(cfg *api.Config, db *badgerNodeDB) badger.Optio
 	opts = opts.WithReadOnly(cfg.ReadOnly)
 	opts = opts.WithDetectConflicts(false)
 
+	if len(cfg.EncryptionKey) > 0 {
+		// Encrypted databases require an index cache as table indices must be decrypted.
+		opts = opts.WithEncryptionKey(cfg.EncryptionKey)
+		opts = opts.WithIndexCacheSize(opts.BlockCacheSize / 4)
+	}
+
 	if cfg.MemoryOnly {
 		db.logger.Warn("using memory-only mode, data will not be persisted")
 		opts = opts.WithInMemory(true).WithDir("").WithValueDir("")
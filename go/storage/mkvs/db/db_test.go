@@ -54,6 +54,9 @@ func TestHashedWriteLog(t *testing.T) {
 
 	hashed := api.MakeHashedDBWriteLog(wl, wla)
 
+	// Revived entries are sorted by key.
+	wl.SortByKey()
+
 	var done bool
 	it, err := api.ReviveHashedDBWriteLogs(context.Background(),
 		func() (node.Root, api.HashedDBWriteLog, error) {
@@ -0,0 +1,295 @@
+// Package migrate implements migration of node databases between backends.
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+// checkpointChunkSize is the chunk size used when copying roots via checkpoints.
+const checkpointChunkSize = 8 * 1024 * 1024
+
+// DisplayHelper is the interface used to report migration progress.
+type DisplayHelper interface {
+	Display(msg string)
+	DisplayStepBegin(msg string)
+	DisplayStepEnd(msg string)
+	DisplayProgress(msg string, current, total uint64)
+}
+
+type migrator struct {
+	logger *logging.Logger
+
+	src db.NodeDB
+	dst db.NodeDB
+
+	creator  checkpoint.Creator
+	restorer checkpoint.Restorer
+}
+
+// copyRootCheckpoint copies a complete root by streaming a checkpoint of the source root
+// into the destination database. The caller must have started a multipart insert.
+func (m *migrator) copyRootCheckpoint(ctx context.Context, root node.Root) error {
+	cp, err := m.creator.CreateCheckpoint(ctx, root, checkpointChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer func() {
+		_ = m.creator.DeleteCheckpoint(ctx, cp.Version, cp.Root)
+	}()
+
+	if err = m.restorer.StartRestore(ctx, cp); err != nil {
+		return fmt.Errorf("failed to start restore: %w", err)
+	}
+	for idx := range cp.Chunks {
+		chunk, err := cp.GetChunkMetadata(uint64(idx))
+		if err != nil {
+			_ = m.restorer.AbortRestore(ctx)
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err = m.creator.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			_ = m.restorer.AbortRestore(ctx)
+			return fmt.Errorf("failed to fetch chunk %d: %w", idx, err)
+		}
+		if _, err = m.restorer.RestoreChunk(ctx, uint64(idx), &buf); err != nil {
+			_ = m.restorer.AbortRestore(ctx)
+			return fmt.Errorf("failed to restore chunk %d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// copyRootWriteLog copies a root by applying the given source write log on top of the
+// start root (which must already exist in the destination). The resulting root hash is
+// verified.
+func (m *migrator) copyRootWriteLog(ctx context.Context, startRoot, root node.Root, it writelog.Iterator) error {
+	tree := mkvs.NewWithRoot(nil, m.dst, startRoot)
+	defer tree.Close()
+
+	if err := tree.ApplyWriteLog(ctx, it); err != nil {
+		return fmt.Errorf("failed to apply write log: %w", err)
+	}
+	if _, err := tree.CommitKnown(ctx, root); err != nil {
+		return fmt.Errorf("failed to commit root: %w", err)
+	}
+	return nil
+}
+
+// getWriteLog looks up a source write log leading to the given root, trying the previous
+// root of the same type and the empty root.
+func (m *migrator) getWriteLog(ctx context.Context, root node.Root, prevRoots map[node.RootType]node.Root) (node.Root, writelog.Iterator, error) {
+	var startRoots []node.Root
+	if prevRoot, ok := prevRoots[root.Type]; ok {
+		startRoots = append(startRoots, prevRoot)
+	}
+	emptyRoot := node.Root{
+		Namespace: root.Namespace,
+		Version:   root.Version,
+		Type:      root.Type,
+	}
+	emptyRoot.Hash.Empty()
+	startRoots = append(startRoots, emptyRoot)
+
+	for _, startRoot := range startRoots {
+		it, err := m.src.GetWriteLog(ctx, startRoot, root)
+		switch {
+		case err == nil:
+			return startRoot, it, nil
+		case errors.Is(err, db.ErrWriteLogNotFound), errors.Is(err, db.ErrRootMustFollowOld):
+		default:
+			return node.Root{}, nil, err
+		}
+	}
+	return node.Root{}, nil, db.ErrWriteLogNotFound
+}
+
+// copyVersion copies and finalizes all roots of the given source version.
+//
+// Roots are copied via write logs where these are available for all roots of the version
+// and via checkpoints otherwise.
+func (m *migrator) copyVersion(ctx context.Context, version uint64, prevRoots map[node.RootType]node.Root) ([]node.Root, error) {
+	roots, err := m.src.GetRootsForVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roots for version %d: %w", version, err)
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no roots for version %d", version)
+	}
+
+	type writeLog struct {
+		root      node.Root
+		startRoot node.Root
+		it        writelog.Iterator
+	}
+	var (
+		writeLogs     []writeLog
+		useCheckpoint bool
+	)
+	for _, root := range roots {
+		if root.Hash.IsEmpty() {
+			// Nothing to copy for empty roots.
+			continue
+		}
+
+		startRoot, it, err := m.getWriteLog(ctx, root, prevRoots)
+		switch {
+		case err == nil:
+			writeLogs = append(writeLogs, writeLog{root, startRoot, it})
+		case errors.Is(err, db.ErrWriteLogNotFound):
+			useCheckpoint = true
+		default:
+			return nil, fmt.Errorf("failed to get write log for root %v: %w", root, err)
+		}
+	}
+
+	if !useCheckpoint {
+		for _, wl := range writeLogs {
+			if err = m.copyRootWriteLog(ctx, wl.startRoot, wl.root, wl.it); err != nil {
+				return nil, fmt.Errorf("failed to copy root %v: %w", wl.root, err)
+			}
+		}
+	} else {
+		// No usable write logs, copy whole roots.
+		m.logger.Debug("no usable write logs, copying version via checkpoints",
+			"version", version,
+		)
+		if err = m.dst.StartMultipartInsert(version); err != nil {
+			return nil, fmt.Errorf("failed to start multipart insert: %w", err)
+		}
+		for _, root := range roots {
+			if root.Hash.IsEmpty() {
+				continue
+			}
+			if err = m.copyRootCheckpoint(ctx, root); err != nil {
+				_ = m.dst.AbortMultipartInsert()
+				return nil, fmt.Errorf("failed to copy root %v: %w", root, err)
+			}
+		}
+	}
+
+	if err = m.dst.Finalize(roots); err != nil {
+		if useCheckpoint {
+			_ = m.dst.AbortMultipartInsert()
+		}
+		return nil, fmt.Errorf("failed to finalize version %d: %w", version, err)
+	}
+
+	return roots, nil
+}
+
+// verify makes sure that the latest roots of both databases match.
+func (m *migrator) verify(version uint64) error {
+	dstVersion, ok := m.dst.GetLatestVersion()
+	if !ok || dstVersion != version {
+		return fmt.Errorf("latest version mismatch (expected: %d got: %d)", version, dstVersion)
+	}
+
+	srcRoots, err := m.src.GetRootsForVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed to get source roots: %w", err)
+	}
+	dstRoots, err := m.dst.GetRootsForVersion(version)
+	if err != nil {
+		return fmt.Errorf("failed to get destination roots: %w", err)
+	}
+	sortRoots(srcRoots)
+	sortRoots(dstRoots)
+
+	if len(srcRoots) != len(dstRoots) {
+		return fmt.Errorf("root count mismatch (expected: %d got: %d)", len(srcRoots), len(dstRoots))
+	}
+	for i, root := range srcRoots {
+		if !root.Equal(&dstRoots[i]) {
+			return fmt.Errorf("root mismatch (expected: %v got: %v)", root, dstRoots[i])
+		}
+		if !m.dst.HasRoot(root) {
+			return fmt.Errorf("root %v missing in destination", root)
+		}
+	}
+	return nil
+}
+
+func sortRoots(roots []node.Root) {
+	sort.Slice(roots, func(i, j int) bool {
+		if roots[i].Type != roots[j].Type {
+			return roots[i].Type < roots[j].Type
+		}
+		return bytes.Compare(roots[i].Hash[:], roots[j].Hash[:]) < 0
+	})
+}
+
+// Migrate copies all versions and roots of the source node database into the (empty)
+// destination node database and verifies that the final roots match.
+//
+// The checkpoint directory is used to temporarily store checkpoints of roots that cannot
+// be copied via write logs.
+func Migrate(ctx context.Context, src, dst db.NodeDB, checkpointDir string, display DisplayHelper) error {
+	latest, ok := src.GetLatestVersion()
+	if !ok {
+		display.Display("source database is empty, nothing to migrate")
+		return nil
+	}
+	if _, ok = dst.GetLatestVersion(); ok {
+		return fmt.Errorf("destination database is not empty")
+	}
+	earliest := src.GetEarliestVersion()
+
+	creator, err := checkpoint.NewFileCreator(checkpointDir, src)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint creator: %w", err)
+	}
+	restorer, err := checkpoint.NewRestorer(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint restorer: %w", err)
+	}
+
+	m := &migrator{
+		logger:   logging.GetLogger("storage/mkvs/db/migrate"),
+		src:      src,
+		dst:      dst,
+		creator:  creator,
+		restorer: restorer,
+	}
+
+	display.DisplayStepBegin(fmt.Sprintf("copying versions %d to %d", earliest, latest))
+	prevRoots := make(map[node.RootType]node.Root)
+	for version := earliest; version <= latest; version++ {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		roots, err := m.copyVersion(ctx, version, prevRoots)
+		if err != nil {
+			return err
+		}
+
+		prevRoots = make(map[node.RootType]node.Root)
+		for _, root := range roots {
+			prevRoots[root.Type] = root
+		}
+
+		display.DisplayProgress("copied versions", version-earliest+1, latest-earliest+1)
+	}
+	display.DisplayStepEnd("done")
+
+	display.DisplayStepBegin("verifying final roots")
+	if err = m.verify(latest); err != nil {
+		display.DisplayStepEnd("failed")
+		return fmt.Errorf("verification failed: %w", err)
+	}
+	display.DisplayStepEnd("done")
+
+	return dst.Sync()
+}
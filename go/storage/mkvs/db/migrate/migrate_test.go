@@ -0,0 +1,166 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	dbApi "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+const (
+	testNumVersions = 5
+	testNumKeys     = 200
+)
+
+var testNs = common.NewTestNamespaceFromSeed([]byte("oasis mkvs migrate test ns"), 0)
+
+type nopDisplay struct{}
+
+func (nopDisplay) Display(string)                         {}
+func (nopDisplay) DisplayStepBegin(string)                {}
+func (nopDisplay) DisplayStepEnd(string)                  {}
+func (nopDisplay) DisplayProgress(string, uint64, uint64) {}
+
+func populate(t *testing.T, ndb dbApi.NodeDB) []node.Root {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var (
+		stateRoot node.Root
+		finalized []node.Root
+	)
+	stateRoot.Namespace = testNs
+	stateRoot.Type = node.RootTypeState
+	stateRoot.Hash.Empty()
+
+	for version := uint64(1); version <= testNumVersions; version++ {
+		tree := mkvs.NewWithRoot(nil, ndb, stateRoot)
+		for i := 0; i < testNumKeys; i++ {
+			key := []byte(fmt.Sprintf("key %d", i))
+			value := []byte(fmt.Sprintf("value %d at version %d", i, version))
+			if i%int(version) != 0 {
+				continue
+			}
+			require.NoError(tree.Insert(ctx, key, value), "Insert")
+		}
+		_, rootHash, err := tree.Commit(ctx, testNs, version)
+		require.NoError(err, "Commit")
+		tree.Close()
+
+		ioTree := mkvs.New(nil, ndb, node.RootTypeIO)
+		require.NoError(ioTree.Insert(ctx, []byte("io"), []byte(fmt.Sprintf("io %d", version))), "Insert")
+		_, ioRootHash, err := ioTree.Commit(ctx, testNs, version)
+		require.NoError(err, "Commit")
+		ioTree.Close()
+
+		stateRoot = node.Root{
+			Namespace: testNs,
+			Version:   version,
+			Type:      node.RootTypeState,
+			Hash:      rootHash,
+		}
+		finalized = []node.Root{
+			stateRoot,
+			{
+				Namespace: testNs,
+				Version:   version,
+				Type:      node.RootTypeIO,
+				Hash:      ioRootHash,
+			},
+		}
+		require.NoError(ndb.Finalize(finalized), "Finalize")
+	}
+	return finalized
+}
+
+func TestMigrate(t *testing.T) {
+	for _, srcFactory := range db.Backends {
+		for _, dstFactory := range db.Backends {
+			t.Run(fmt.Sprintf("%s-%s", srcFactory.Name(), dstFactory.Name()), func(t *testing.T) {
+				testMigrate(t, srcFactory, dstFactory, false)
+			})
+			t.Run(fmt.Sprintf("%s-%s/NoWriteLogs", srcFactory.Name(), dstFactory.Name()), func(t *testing.T) {
+				testMigrate(t, srcFactory, dstFactory, true)
+			})
+		}
+	}
+}
+
+func testMigrate(t *testing.T, srcFactory, dstFactory dbApi.Factory, discardWriteLogs bool) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	src, err := srcFactory.New(&dbApi.Config{
+		DB:               filepath.Join(dir, "src"),
+		Namespace:        testNs,
+		MaxCacheSize:     16 * 1024 * 1024,
+		NoFsync:          true,
+		DiscardWriteLogs: discardWriteLogs,
+	})
+	require.NoError(err, "New")
+	defer src.Close()
+
+	roots := populate(t, src)
+
+	// Prune the first version so that migration does not start at version one.
+	require.NoError(src.Prune(1), "Prune")
+
+	dst, err := dstFactory.New(&dbApi.Config{
+		DB:           filepath.Join(dir, "dst"),
+		Namespace:    testNs,
+		MaxCacheSize: 16 * 1024 * 1024,
+		NoFsync:      true,
+	})
+	require.NoError(err, "New")
+	defer dst.Close()
+
+	err = Migrate(ctx, src, dst, filepath.Join(dir, "checkpoints"), nopDisplay{})
+	require.NoError(err, "Migrate")
+
+	latest, ok := dst.GetLatestVersion()
+	require.True(ok, "destination should not be empty")
+	require.EqualValues(testNumVersions, latest, "latest version should be migrated")
+	require.EqualValues(2, dst.GetEarliestVersion(), "earliest version should be migrated")
+
+	for version := uint64(2); version <= testNumVersions; version++ {
+		srcRoots, err := src.GetRootsForVersion(version)
+		require.NoError(err, "GetRootsForVersion")
+		dstRoots, err := dst.GetRootsForVersion(version)
+		require.NoError(err, "GetRootsForVersion")
+		require.ElementsMatch(srcRoots, dstRoots, "roots should match at version %d", version)
+	}
+
+	// Make sure the migrated state is readable.
+	for _, root := range roots {
+		srcTree := mkvs.NewWithRoot(nil, src, root)
+		dstTree := mkvs.NewWithRoot(nil, dst, root)
+
+		it := srcTree.NewIterator(ctx)
+		var keys int
+		for it.Rewind(); it.Valid(); it.Next() {
+			value, err := dstTree.Get(ctx, it.Key())
+			require.NoError(err, "Get")
+			require.Equal(it.Value(), value, "migrated values should match")
+			keys++
+		}
+		require.NoError(it.Err(), "iterator")
+		require.NotZero(keys)
+		it.Close()
+
+		srcTree.Close()
+		dstTree.Close()
+	}
+
+	// Migrating into a non-empty database should fail.
+	err = Migrate(ctx, src, dst, filepath.Join(dir, "checkpoints"), nopDisplay{})
+	require.Error(err, "Migrate should fail with non-empty destination")
+}
@@ -23,6 +23,12 @@ func commonConfigToBadgerOptions(cfg *api.Config, logger *logging.Logger) badger
 	opts = opts.WithReadOnly(cfg.ReadOnly)
 	opts = opts.WithDetectConflicts(false)
 
+	if len(cfg.EncryptionKey) > 0 {
+		// Encrypted databases require an index cache as table indices must be decrypted.
+		opts = opts.WithEncryptionKey(cfg.EncryptionKey)
+		opts = opts.WithIndexCacheSize(opts.BlockCacheSize / 4)
+	}
+
 	if cfg.MemoryOnly {
 		logger.Warn("using memory-only mode, data will not be persisted")
 		opts = opts.WithInMemory(true).WithDir("").WithValueDir("")
@@ -162,5 +162,8 @@ func (d *badgerNodeDB) GetWriteLog(_ context.Context, startRoot, endRoot node.Ro
 		}
 	}
 
+	// Make sure the iteration order is deterministic.
+	wl.SortByKey()
+
 	return writelog.NewStaticIterator(wl), nil
 }
@@ -85,6 +85,11 @@ type Tree interface {
 	// starting with given prefixes.
 	PrefetchPrefixes(ctx context.Context, prefixes [][]byte, limit uint16) error
 
+	// NewReadSession creates a new read session pinned to the current (clean) tree root which
+	// produces a single combined proof, in the given proof version, for all reads performed
+	// during the session.
+	NewReadSession(proofVersion uint16) (ReadSession, error)
+
 	// ApplyWriteLog applies the operations from a write log to the current tree.
 	//
 	// The caller is responsible for calling Commit.
@@ -0,0 +1,122 @@
+package mkvs
+
+import (
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// ReadSession is a read-only view of a tree pinned to a specific root which records all nodes
+// accessed by the reads performed during the session into a single combined proof.
+//
+// Composing multiple reads in a session guarantees that all of them observe the same root and
+// results in a smaller proof than performing the reads independently as nodes shared between
+// the reads are only included once.
+type ReadSession interface {
+	// Root returns the root the session is pinned to.
+	Root() node.Root
+
+	// Get looks up an existing key.
+	Get(ctx context.Context, key []byte) ([]byte, error)
+
+	// NewIterator returns a new iterator over the tree. All nodes visited by the iterator are
+	// included in the session proof.
+	NewIterator(ctx context.Context, options ...IteratorOption) (Iterator, error)
+
+	// Proof builds a proof for all reads performed during the session so far.
+	Proof(ctx context.Context) (*syncer.Proof, error)
+}
+
+type readSession struct {
+	tree *tree
+	root node.Root
+	pb   *syncer.ProofBuilder
+}
+
+// Implements Tree.
+func (t *tree) NewReadSession(proofVersion uint16) (ReadSession, error) {
+	t.cache.Lock()
+	defer t.cache.Unlock()
+
+	if t.cache.isClosed() {
+		return nil, ErrClosed
+	}
+	if !t.cache.pendingRoot.IsClean() {
+		return nil, syncer.ErrDirtyRoot
+	}
+
+	// Always anchor the proof at the root as reads may encompass many subtrees.
+	root := t.cache.syncRoot
+	pb, err := syncer.NewProofBuilderForVersion(root.Hash, root.Hash, proofVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return &readSession{
+		tree: t,
+		root: root,
+		pb:   pb,
+	}, nil
+}
+
+// ensureRoot makes sure that the tree is still at the root the session is pinned to.
+//
+// The caller must hold the cache lock.
+func (s *readSession) ensureRoot() error {
+	if s.tree.cache.isClosed() {
+		return ErrClosed
+	}
+	if !s.root.Equal(&s.tree.cache.syncRoot) {
+		return syncer.ErrInvalidRoot
+	}
+	if !s.tree.cache.pendingRoot.IsClean() {
+		return syncer.ErrDirtyRoot
+	}
+	return nil
+}
+
+// Implements ReadSession.
+func (s *readSession) Root() node.Root {
+	return s.root
+}
+
+// Implements ReadSession.
+func (s *readSession) Get(ctx context.Context, key []byte) ([]byte, error) {
+	s.tree.cache.Lock()
+	defer s.tree.cache.Unlock()
+
+	if err := s.ensureRoot(); err != nil {
+		return nil, err
+	}
+
+	// Remember where the path from root to target node ends (will end).
+	s.tree.cache.markPosition()
+
+	return s.tree.doGet(ctx, s.tree.cache.pendingRoot, 0, key, doGetOptions{proofBuilder: s.pb}, false)
+}
+
+// Implements ReadSession.
+func (s *readSession) NewIterator(ctx context.Context, options ...IteratorOption) (Iterator, error) {
+	s.tree.cache.Lock()
+	defer s.tree.cache.Unlock()
+
+	if err := s.ensureRoot(); err != nil {
+		return nil, err
+	}
+
+	return newTreeIterator(ctx, s.tree, append(options, WithProofBuilder(s.pb))...), nil
+}
+
+// Implements ReadSession.
+func (s *readSession) Proof(ctx context.Context) (*syncer.Proof, error) {
+	s.tree.cache.Lock()
+	defer s.tree.cache.Unlock()
+
+	// Make sure that all reads were performed against the same root.
+	if err := s.ensureRoot(); err != nil {
+		return nil, err
+	}
+
+	return s.pb.Build(ctx)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -846,6 +847,85 @@ func testSyncerPrefetchPrefixes(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
 	require.EqualValues(t, 0, stats.SyncIterateCount, "SyncIterate should not be called")
 }
 
+func testReadSession(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
+	ctx := context.Background()
+	keys, values, root, tree := generatePopulatedTree(t, ndb)
+
+	sess, err := tree.NewReadSession(syncer.LatestProofVersion)
+	require.NoError(t, err, "NewReadSession")
+	require.Equal(t, root, sess.Root())
+
+	// Perform multiple reads in the same session.
+	for i := 0; i < 2; i++ {
+		var value []byte
+		value, err = sess.Get(ctx, keys[i])
+		require.NoError(t, err, "Get")
+		require.Equal(t, values[i], value)
+	}
+	it, err := sess.NewIterator(ctx)
+	require.NoError(t, err, "NewIterator")
+	defer it.Close()
+	it.Seek(keys[10])
+	for i := 0; it.Valid() && i < 3; i++ {
+		it.Next()
+	}
+	require.NoError(t, it.Err(), "iterator")
+
+	// The combined proof should cover all reads.
+	proof, err := sess.Proof(ctx)
+	require.NoError(t, err, "Proof")
+	require.Equal(t, root.Hash, proof.UntrustedRoot)
+
+	var pv syncer.ProofVerifier
+	wl, err := pv.VerifyProofToWriteLog(ctx, root.Hash, proof)
+	require.NoError(t, err, "VerifyProofToWriteLog")
+	proven := make(map[string][]byte)
+	for _, entry := range wl {
+		proven[string(entry.Key)] = entry.Value
+	}
+	for i := 0; i < 2; i++ {
+		require.Equal(t, values[i], proven[string(keys[i])], "proof should include read keys")
+	}
+	require.Contains(t, proven, string(keys[10]), "proof should include iterated keys")
+
+	// The combined proof should be smaller than independent proofs.
+	sess, err = tree.NewReadSession(syncer.LatestProofVersion)
+	require.NoError(t, err, "NewReadSession")
+	var independentSize int
+	for i := 0; i < 2; i++ {
+		_, err = sess.Get(ctx, keys[i])
+		require.NoError(t, err, "Get")
+
+		var rsp *syncer.ProofResponse
+		rsp, err = tree.SyncGet(ctx, &syncer.GetRequest{
+			Tree: syncer.TreeID{
+				Root:     root,
+				Position: root.Hash,
+			},
+			Key:          keys[i],
+			ProofVersion: syncer.LatestProofVersion,
+		})
+		require.NoError(t, err, "SyncGet")
+		independentSize += len(cbor.Marshal(rsp.Proof))
+	}
+	proof, err = sess.Proof(ctx)
+	require.NoError(t, err, "Proof")
+	require.Less(t, len(cbor.Marshal(proof)), independentSize, "combined proof should be smaller")
+
+	// Sessions should fail once the tree moves to a different root.
+	err = tree.Insert(ctx, []byte("new key"), []byte("new value"))
+	require.NoError(t, err, "Insert")
+	_, err = sess.Get(ctx, keys[0])
+	require.ErrorIs(t, err, syncer.ErrDirtyRoot)
+	_, err = tree.NewReadSession(syncer.LatestProofVersion)
+	require.ErrorIs(t, err, syncer.ErrDirtyRoot)
+
+	_, _, err = tree.Commit(ctx, testNs, 1)
+	require.NoError(t, err, "Commit")
+	_, err = sess.Proof(ctx)
+	require.ErrorIs(t, err, syncer.ErrInvalidRoot)
+}
+
 func testValueEviction(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
 	ctx := context.Background()
 	tree := New(nil, ndb, node.RootTypeState, Capacity(0, 512)).(*tree)
@@ -1234,6 +1314,49 @@ func testEmptyValueWriteLog(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
 	}
 }
 
+func testWriteLogOrder(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
+	ctx := context.Background()
+
+	emptyRoot := node.Root{
+		Namespace: testNs,
+		Version:   0,
+		Type:      node.RootTypeState,
+	}
+	emptyRoot.Hash.Empty()
+
+	tree := New(nil, ndb, node.RootTypeState)
+	keys, values := generateKeyValuePairs()
+	for i, key := range keys {
+		err := tree.Insert(ctx, key, values[i])
+		require.NoError(t, err, "Insert(%d)", i)
+	}
+	_, rootHash, err := tree.Commit(ctx, testNs, 0)
+	require.NoError(t, err, "Commit")
+
+	root := node.Root{
+		Namespace: testNs,
+		Version:   0,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+
+	// Write log entries should be sorted by key.
+	wli, err := ndb.GetWriteLog(ctx, emptyRoot, root)
+	require.NoError(t, err, "GetWriteLog")
+	wl := foldWriteLogIterator(t, wli)
+	require.Len(t, wl, len(keys))
+	require.True(t, sort.SliceIsSorted(wl, func(i, j int) bool {
+		return bytes.Compare(wl[i].Key, wl[j].Key) < 0
+	}), "write log should be sorted by key")
+
+	// Seeking should resume iteration at the given key.
+	wli, err = ndb.GetWriteLog(ctx, emptyRoot, root)
+	require.NoError(t, err, "GetWriteLog")
+	err = wli.Seek(wl[len(wl)/2].Key)
+	require.NoError(t, err, "Seek")
+	require.EqualValues(t, wl[len(wl)/2:], foldWriteLogIterator(t, wli))
+}
+
 func testBasicWriteLog(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
 	ctx := context.Background()
 
@@ -2365,6 +2488,7 @@ func testBackend(
 		{"SyncerInsert", testSyncerInsert},
 		{"SyncerNilNodes", testSyncerNilNodes},
 		{"SyncerPrefetchPrefixes", testSyncerPrefetchPrefixes},
+		{"ReadSession", testReadSession},
 		{"ValueEviction", testValueEviction},
 		{"NodeEviction", testNodeEviction},
 		{"DoubleInsertWithEviction", testDoubleInsertWithEviction},
@@ -2373,6 +2497,7 @@ func testBackend(
 		{"CommitNoPersist", testCommitNoPersist},
 		{"EmptyValueWriteLog", testEmptyValueWriteLog},
 		{"BasicWriteLog", testBasicWriteLog},
+		{"WriteLogOrder", testWriteLogOrder},
 		{"HasRoot", testHasRoot},
 		{"GetRootsForVersion", testGetRootsForVersion},
 		{"Size", testSize},
@@ -1,8 +1,10 @@
 package writelog
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"sort"
 )
 
 var (
@@ -11,6 +13,9 @@ var (
 
 	// ErrIteratorInvalid is raised when Value() is called on an iterator that finished already or hasn't started yet.
 	ErrIteratorInvalid = errors.New("mkvs: write log iterator invalid")
+	// ErrIteratorSeekBackward is raised when Seek() is called with a key that does not follow the
+	// current entry on an iterator that can only seek forward.
+	ErrIteratorSeekBackward = errors.New("mkvs: write log iterator cannot seek backward")
 )
 
 const (
@@ -19,11 +24,22 @@ const (
 )
 
 // Iterator iterates over MKVS write log entries between two different storage instances.
+//
+// Iterators returned by the node database (and thus by storage backends) yield entries in
+// ascending key order, so the iteration order for the same pair of roots is deterministic and
+// iteration can be resumed or partitioned by key using Seek.
 type Iterator interface {
 	// Next advances the iterator to the next element and returns false if there are no more elements.
 	Next() (bool, error)
 	// Value returns the log entry the iterator is currently pointing to.
 	Value() (LogEntry, error)
+	// Seek moves the iterator so that the following call to Next advances it to the first
+	// element with a key greater than or equal to the given key.
+	//
+	// Seeking assumes that elements are sorted by key. Iterators backed by a stream of elements
+	// can only seek forward and return ErrIteratorSeekBackward in case the given key does not
+	// follow the key of the current element.
+	Seek(key []byte) error
 }
 
 type staticIterator struct {
@@ -46,7 +62,16 @@ func (i *staticIterator) Value() (LogEntry, error) {
 	return i.entries[i.cursor], nil
 }
 
+func (i *staticIterator) Seek(key []byte) error {
+	i.cursor = sort.Search(len(i.entries), func(idx int) bool {
+		return bytes.Compare(i.entries[idx].Key, key) >= 0
+	}) - 1
+	return nil
+}
+
 // NewStaticIterator returns a new writelog iterator that's backed by a static in-memory array.
+//
+// Seeking requires the write log to be sorted by key.
 func NewStaticIterator(writeLog WriteLog) Iterator {
 	return &staticIterator{
 		cursor:  -1,
@@ -57,28 +82,36 @@ func NewStaticIterator(writeLog WriteLog) Iterator {
 // PipeIterator is a queue-backed writelog iterator which can be asynchronously
 // both pushed into and read from.
 type PipeIterator struct {
-	queue  chan interface{}
-	cached *LogEntry
-	ctx    context.Context
+	queue   chan interface{}
+	cached  *LogEntry
+	seekKey []byte
+	ctx     context.Context
 }
 
 func (i *PipeIterator) Next() (bool, error) {
-	select {
-	case ret, ok := <-i.queue:
-		if !ok {
-			i.cached = nil
-			return false, nil
-		}
-		switch obj := ret.(type) {
-		case error:
-			i.cached = nil
-			return false, obj
-		case *LogEntry:
-			i.cached = obj
+	for {
+		select {
+		case ret, ok := <-i.queue:
+			if !ok {
+				i.cached = nil
+				return false, nil
+			}
+			switch obj := ret.(type) {
+			case error:
+				i.cached = nil
+				return false, obj
+			case *LogEntry:
+				// Skip entries preceding the key we are seeking to.
+				if i.seekKey != nil && bytes.Compare(obj.Key, i.seekKey) < 0 {
+					continue
+				}
+				i.seekKey = nil
+				i.cached = obj
+			}
+			return true, nil
+		case <-i.ctx.Done():
+			return false, i.ctx.Err()
 		}
-		return true, nil
-	case <-i.ctx.Done():
-		return false, i.ctx.Err()
 	}
 }
 
@@ -89,6 +122,15 @@ func (i *PipeIterator) Value() (LogEntry, error) {
 	return *i.cached, nil
 }
 
+func (i *PipeIterator) Seek(key []byte) error {
+	if i.cached != nil && bytes.Compare(key, i.cached.Key) <= 0 {
+		return ErrIteratorSeekBackward
+	}
+	i.cached = nil
+	i.seekKey = key
+	return nil
+}
+
 func (i *PipeIterator) Put(logEntry *LogEntry) error {
 	select {
 	case i.queue <- logEntry:
@@ -83,3 +83,80 @@ func TestPipeIterator(t *testing.T) {
 	_, err = pipe.Value()
 	require.Error(t, err, "last pipe.Value()")
 }
+
+func TestStaticIteratorSeek(t *testing.T) {
+	require := require.New(t)
+
+	wl := makeWriteLog()
+	wl.SortByKey()
+	it := NewStaticIterator(wl)
+
+	// Seek to an existing key.
+	err := it.Seek(wl[50].Key)
+	require.NoError(err, "it.Seek()")
+	more, err := it.Next()
+	require.NoError(err, "it.Next()")
+	require.True(more)
+	val, err := it.Value()
+	require.NoError(err, "it.Value()")
+	require.Equal(wl[50], val)
+
+	// Seek backward to a key between two existing keys.
+	err = it.Seek(append(wl[10].Key, 0x00))
+	require.NoError(err, "it.Seek()")
+	more, err = it.Next()
+	require.NoError(err, "it.Next()")
+	require.True(more)
+	val, err = it.Value()
+	require.NoError(err, "it.Value()")
+	require.Equal(wl[11], val)
+
+	// Seek past the last key.
+	err = it.Seek([]byte("zzz"))
+	require.NoError(err, "it.Seek()")
+	more, err = it.Next()
+	require.NoError(err, "it.Next()")
+	require.False(more)
+}
+
+func TestPipeIteratorSeek(t *testing.T) {
+	require := require.New(t)
+
+	wl := makeWriteLog()
+	wl.SortByKey()
+	pipe := NewPipeIterator(context.Background())
+	for idx := range wl {
+		err := pipe.Put(&wl[idx])
+		require.NoError(err, "pipe.Put()")
+	}
+	pipe.Close()
+
+	// Seek forward to a key between two existing keys.
+	err := pipe.Seek(append(wl[10].Key, 0x00))
+	require.NoError(err, "pipe.Seek()")
+	more, err := pipe.Next()
+	require.NoError(err, "pipe.Next()")
+	require.True(more)
+	val, err := pipe.Value()
+	require.NoError(err, "pipe.Value()")
+	require.Equal(wl[11], val)
+
+	// Seeking backward should fail.
+	err = pipe.Seek(wl[11].Key)
+	require.ErrorIs(err, ErrIteratorSeekBackward)
+
+	// Seek forward to an existing key.
+	err = pipe.Seek(wl[50].Key)
+	require.NoError(err, "pipe.Seek()")
+	for _, ent := range wl[50:] {
+		more, err = pipe.Next()
+		require.NoError(err, "pipe.Next()")
+		require.True(more)
+		val, err = pipe.Value()
+		require.NoError(err, "pipe.Value()")
+		require.Equal(ent, val)
+	}
+	more, err = pipe.Next()
+	require.NoError(err, "last pipe.Next()")
+	require.False(more)
+}
@@ -3,6 +3,7 @@ package writelog
 import (
 	"bytes"
 	"encoding/json"
+	"sort"
 
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 )
@@ -25,6 +26,13 @@ func (wl WriteLog) Equal(cmp WriteLog) bool {
 	return true
 }
 
+// SortByKey sorts the write log entries by key.
+func (wl WriteLog) SortByKey() {
+	sort.Slice(wl, func(i, j int) bool {
+		return bytes.Compare(wl[i].Key, wl[j].Key) < 0
+	})
+}
+
 // LogEntry is a write log entry.
 type LogEntry struct {
 	_ struct{} `cbor:",toarray"` // nolint
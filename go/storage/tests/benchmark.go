@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+const (
+	// benchSmallWriteLogSize is the number of entries in small benchmark write logs.
+	benchSmallWriteLogSize = 10
+	// benchLargeWriteLogSize is the number of entries in large benchmark write logs.
+	benchLargeWriteLogSize = 1000
+	// benchValueSize is the size of values in benchmark write logs.
+	benchValueSize = 128
+	// benchSeed is the seed used to generate benchmark data, fixed so that results are
+	// comparable across runs.
+	benchSeed = 42
+)
+
+// StorageImplementationBenchmarks measures the performance of the basic operations of a
+// storage backend.
+//
+// All sub-benchmarks use deterministically generated data and report the number of processed
+// write log entries per operation in addition to the standard metrics, so that results of
+// different backends (or revisions of the same backend) can be compared with benchstat.
+func StorageImplementationBenchmarks(b *testing.B, localBackend api.LocalBackend, backend api.Backend, namespace common.Namespace, round uint64) {
+	<-backend.Initialized()
+
+	// The read benchmarks operate on a single large root.
+	wl := prepareBenchWriteLog(rand.New(rand.NewSource(benchSeed)), "read", benchLargeWriteLogSize) // nolint: gosec
+	root := applyBenchWriteLog(b, localBackend, namespace, round, wl)
+	err := localBackend.NodeDB().Finalize([]api.Root{root})
+	require.NoError(b, err, "Finalize")
+
+	// Apply benchmarks use a new version for each applied root and finalize it, as a node would,
+	// so that the number of pending roots does not grow over the run.
+	nextRound := round + 1
+	b.Run("ApplySmall", func(b *testing.B) {
+		benchmarkApply(b, localBackend, namespace, &nextRound, benchSmallWriteLogSize)
+	})
+	b.Run("ApplyLarge", func(b *testing.B) {
+		benchmarkApply(b, localBackend, namespace, &nextRound, benchLargeWriteLogSize)
+	})
+	b.Run("GetDiff", func(b *testing.B) {
+		benchmarkGetDiff(b, backend, root, len(wl))
+	})
+	b.Run("RandomReads", func(b *testing.B) {
+		benchmarkRandomReads(b, backend, root, wl)
+	})
+}
+
+func prepareBenchWriteLog(rng *rand.Rand, prefix string, size int) api.WriteLog {
+	wl := make(api.WriteLog, 0, size)
+	for i := 0; i < size; i++ {
+		value := make([]byte, benchValueSize)
+		_, _ = rng.Read(value)
+		wl = append(wl, api.LogEntry{
+			Key:   []byte(fmt.Sprintf("%s/%d", prefix, i)),
+			Value: value,
+		})
+	}
+	return wl
+}
+
+func applyBenchWriteLog(tb testing.TB, localBackend api.LocalBackend, namespace common.Namespace, round uint64, wl api.WriteLog) api.Root {
+	var emptyRoot hash.Hash
+	emptyRoot.Empty()
+
+	dstRoot := CalculateExpectedNewRoot(tb, wl, namespace, round)
+	err := localBackend.Apply(context.Background(), &api.ApplyRequest{
+		Namespace: namespace,
+		RootType:  api.RootTypeState,
+		SrcRound:  round,
+		SrcRoot:   emptyRoot,
+		DstRound:  round,
+		DstRoot:   dstRoot,
+		WriteLog:  wl,
+	})
+	require.NoError(tb, err, "Apply")
+
+	return api.Root{
+		Namespace: namespace,
+		Version:   round,
+		Type:      api.RootTypeState,
+		Hash:      dstRoot,
+	}
+}
+
+func benchmarkApply(b *testing.B, localBackend api.LocalBackend, namespace common.Namespace, nextRound *uint64, size int) {
+	rng := rand.New(rand.NewSource(benchSeed)) // nolint: gosec
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Each iteration applies a distinct write log so that no work is skipped.
+		b.StopTimer()
+		wl := prepareBenchWriteLog(rng, fmt.Sprintf("apply-%d", size), size)
+		round := *nextRound
+		*nextRound++
+		b.StartTimer()
+
+		root := applyBenchWriteLog(b, localBackend, namespace, round, wl)
+
+		b.StopTimer()
+		err := localBackend.NodeDB().Finalize([]api.Root{root})
+		require.NoError(b, err, "Finalize")
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(size), "entries/op")
+}
+
+func benchmarkGetDiff(b *testing.B, backend api.Backend, root api.Root, size int) {
+	startRoot := root
+	startRoot.Hash.Empty()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		it, err := backend.GetDiff(context.Background(), &api.GetDiffRequest{StartRoot: startRoot, EndRoot: root})
+		require.NoError(b, err, "GetDiff")
+		if diff := foldWriteLogIterator(b, it); len(diff) != size {
+			b.Fatalf("unexpected diff size (expected: %d got: %d)", size, len(diff))
+		}
+	}
+	b.ReportMetric(float64(size), "entries/op")
+}
+
+func benchmarkRandomReads(b *testing.B, backend api.Backend, root api.Root, wl api.WriteLog) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(benchSeed)) // nolint: gosec
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		entry := wl[rng.Intn(len(wl))]
+
+		// Use a fresh tree for each read so that reads are served by the backend.
+		tree := mkvs.NewWithRoot(backend, nil, root)
+		value, err := tree.Get(ctx, entry.Key)
+		tree.Close()
+		require.NoError(b, err, "Get")
+		if len(value) != len(entry.Value) {
+			b.Fatalf("unexpected value size (expected: %d got: %d)", len(entry.Value), len(value))
+		}
+	}
+	b.ReportMetric(1, "entries/op")
+}
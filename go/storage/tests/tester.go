@@ -51,7 +51,7 @@ func prepareWriteLog(values [][]byte) api.WriteLog {
 	return wl
 }
 
-func CalculateExpectedNewRoot(t *testing.T, wl api.WriteLog, namespace common.Namespace, round uint64) hash.Hash {
+func CalculateExpectedNewRoot(t testing.TB, wl api.WriteLog, namespace common.Namespace, round uint64) hash.Hash {
 	// Use in-memory MKVS tree to calculate the expected new root.
 	// Root type doesn't matter, we only need the hash.
 	tree := mkvs.New(nil, nil, api.RootTypeState)
@@ -64,7 +64,7 @@ func CalculateExpectedNewRoot(t *testing.T, wl api.WriteLog, namespace common.Na
 	return expectedNewRoot
 }
 
-func foldWriteLogIterator(t *testing.T, w api.WriteLogIterator) api.WriteLog {
+func foldWriteLogIterator(t testing.TB, w api.WriteLogIterator) api.WriteLog {
 	writeLog := api.WriteLog{}
 
 	for {
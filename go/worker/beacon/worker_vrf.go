@@ -10,6 +10,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
@@ -29,7 +30,7 @@ type vrfWorker struct {
 
 func (w *vrfWorker) Start() error {
 	if w.enabled {
-		go w.worker()
+		recovery.Go(workerName+"/vrf", w.worker)
 	}
 
 	return nil
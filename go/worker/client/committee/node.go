@@ -12,6 +12,7 @@ import (
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
@@ -54,7 +55,7 @@ func (n *Node) Name() string {
 
 // Start starts the service.
 func (n *Node) Start() error {
-	go n.worker()
+	recovery.Go("worker/client/committee", n.worker)
 	return nil
 }
 
@@ -165,6 +166,10 @@ func (n *Node) Query(ctx context.Context, round uint64, method string, args []by
 	if hrt == nil {
 		return nil, api.ErrNoHostedRuntime
 	}
+	// The hosted runtime may not support the active version, e.g. after it has been retired.
+	if _, err := n.commonNode.GetHostedRuntimeActiveVersion(); err != nil {
+		return nil, api.ErrNoHostedRuntime
+	}
 
 	// Fetch the active descriptor so we can get the current message limits.
 	n.commonNode.CrossNode.Lock()
@@ -134,6 +134,10 @@ type Status struct {
 type HostStatus struct {
 	// Versions are the locally supported versions.
 	Versions []version.Version `json:"versions"`
+
+	// RunningVersions are the versions that are currently running, the active version first,
+	// followed by the next version in case it has been started in advance.
+	RunningVersions []version.Version `json:"running_versions,omitempty"`
 }
 
 // LivenessStatus is the liveness status for the current epoch.
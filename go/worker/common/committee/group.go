@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/nodes"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -120,6 +121,15 @@ func (e *EpochSnapshot) IsExecutorWorker() bool {
 	return e.executorCommittee.HasRole(scheduler.RoleWorker)
 }
 
+// IsExecutorStandbyWorker checks if the current node is a standby worker of the executor
+// committee in the current epoch.
+func (e *EpochSnapshot) IsExecutorStandbyWorker() bool {
+	if e.executorCommittee == nil {
+		return false
+	}
+	return e.executorCommittee.HasRole(scheduler.RoleStandbyWorker)
+}
+
 // IsExecutorBackupWorker checks if the current node is a backup worker of the executor
 // committee in the current epoch.
 func (e *EpochSnapshot) IsExecutorBackupWorker() bool {
@@ -163,13 +173,69 @@ type Group struct {
 }
 
 // RoundTransition processes a round transition that just happened.
-func (g *Group) RoundTransition() {
+//
+// In case the runtime uses standby workers, the executor committee may change mid-epoch when
+// a standby worker is promoted, so the committee is refreshed from the roothash runtime state.
+func (g *Group) RoundTransition(ctx context.Context, height int64) error {
 	g.Lock()
 	defer g.Unlock()
 
 	if g.activeEpoch == nil {
-		return
+		return nil
+	}
+	if g.activeEpoch.runtime.Executor.GroupStandbySize == 0 {
+		return nil
+	}
+
+	rs, err := g.consensus.RootHash().GetRuntimeState(ctx, &roothash.RuntimeRequest{
+		RuntimeID: g.runtime.ID(),
+		Height:    height,
+	})
+	if err != nil {
+		return fmt.Errorf("group: failed to get runtime state: %w", err)
+	}
+	if rs.Committee == nil || rs.Committee.ValidFor != g.activeEpoch.executorCommittee.Committee.ValidFor {
+		return nil
+	}
+	if committeeMembersEqual(rs.Committee, g.activeEpoch.executorCommittee.Committee) {
+		return nil
+	}
+
+	// Standby worker promotions only swap members, so the set of committee nodes (and thus the
+	// watched node descriptors) remains the same.
+	prev := g.activeEpoch.executorCommittee
+	executorCommittee := &CommitteeInfo{
+		Committee:  rs.Committee,
+		PublicKeys: prev.PublicKeys,
+		Peers:      prev.Peers,
+	}
+	publicIdentity := g.identity.NodeSigner.Public()
+	for index, member := range rs.Committee.Members {
+		if member.PublicKey.Equal(publicIdentity) {
+			executorCommittee.Roles = append(executorCommittee.Roles, member.Role)
+			executorCommittee.Indices = append(executorCommittee.Indices, index)
+		}
+	}
+	g.activeEpoch.executorCommittee = executorCommittee
+
+	g.logger.Info("executor committee updated",
+		"epoch", g.activeEpoch.epochNumber,
+		"executor_roles", executorCommittee.Roles,
+	)
+
+	return nil
+}
+
+func committeeMembersEqual(a, b *scheduler.Committee) bool {
+	if len(a.Members) != len(b.Members) {
+		return false
+	}
+	for i := range a.Members {
+		if a.Members[i].Role != b.Members[i].Role || !a.Members[i].PublicKey.Equal(b.Members[i].PublicKey) {
+			return false
+		}
 	}
+	return true
 }
 
 // Suspend processes a runtime suspension that just happened.
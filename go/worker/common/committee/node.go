@@ -13,6 +13,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -78,6 +79,13 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	workerIsExecutorStandby = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_worker_executor_is_standby_worker",
+			Help: "1 if worker is currently an executor standby worker, 0 otherwise.",
+		},
+		[]string{"runtime"},
+	)
 	executorCommitteeP2PPeers = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "oasis_worker_executor_committee_p2p_peers",
@@ -115,6 +123,7 @@ var (
 		// Periodically collected metrics.
 		workerIsExecutorWorker,
 		workerIsExecutorBackup,
+		workerIsExecutorStandby,
 		executorCommitteeP2PPeers,
 		livenessTotalRounds,
 		livenessLiveRounds,
@@ -229,7 +238,7 @@ func (n *Node) Start() error {
 		return fmt.Errorf("failed to start group services: %w", err)
 	}
 
-	go n.worker()
+	recovery.Go("worker/common/committee", n.worker)
 	if cmmetrics.Enabled() {
 		go n.metricsWorker()
 	}
@@ -321,6 +330,7 @@ func (n *Node) GetStatus() (*api.Status, error) {
 	status.Peers = n.P2P.Peers(n.Runtime.ID())
 
 	status.Host.Versions = n.Runtime.HostVersions()
+	status.Host.RunningVersions, _ = n.GetHostedRuntimeRunningVersions()
 
 	return &status, nil
 }
@@ -358,6 +368,16 @@ func (n *Node) handleEpochTransitionLocked(height int64) {
 	epochNumber.With(n.getMetricLabels()).Set(float64(epoch.epochNumber))
 }
 
+// Guarded by n.CrossNode.
+func (n *Node) handleRoundTransitionLocked(height int64) {
+	// Transition group.
+	if err := n.Group.RoundTransition(n.ctx, height); err != nil {
+		n.logger.Error("unable to handle round transition",
+			"err", err,
+		)
+	}
+}
+
 // Guarded by n.CrossNode.
 func (n *Node) handleSuspendLocked(int64) {
 	n.logger.Warn("runtime has been suspended")
@@ -536,7 +556,7 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 			n.handleEpochTransitionLocked(height)
 		} else {
 			// Normal block.
-			n.Group.RoundTransition()
+			n.handleRoundTransitionLocked(height)
 		}
 	case block.RoundFailed:
 		if firstBlockReceived {
@@ -545,7 +565,7 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 		} else {
 			// Round has failed.
 			n.logger.Warn("round has failed")
-			n.Group.RoundTransition()
+			n.handleRoundTransitionLocked(height)
 
 			failedRoundCount.With(n.getMetricLabels()).Inc()
 		}
@@ -774,6 +794,7 @@ func (n *Node) updatePeriodicMetrics() {
 	executorCommitteeP2PPeers.With(labels).Set(float64(len(n.P2P.Peers(n.Runtime.ID()))))
 	workerIsExecutorWorker.With(labels).Set(boolToMetricVal(epoch.IsExecutorWorker()))
 	workerIsExecutorBackup.With(labels).Set(boolToMetricVal(epoch.IsExecutorBackupWorker()))
+	workerIsExecutorStandby.With(labels).Set(boolToMetricVal(epoch.IsExecutorStandbyWorker()))
 
 	if !epoch.IsExecutorMember() {
 		// Default to 1 if node is not in committee.
@@ -30,9 +30,15 @@ func NewConfig() (*Config, error) {
 		sentryAddresses = append(sentryAddresses, tlsAddr)
 	}
 
+	// Checking transaction batches concurrently only helps when checks are load-balanced among
+	// multiple runtime instances. A single instance would process the batches sequentially, so
+	// concurrent batches could time out while waiting for each other.
+	txPool := config.GlobalConfig.Runtime.TxPool
+	txPool.CheckTxConcurrency = min(txPool.CheckTxConcurrency, max(1, config.GlobalConfig.Runtime.LoadBalancer.NumInstances))
+
 	cfg := Config{
 		SentryAddresses: sentryAddresses,
-		TxPool:          config.GlobalConfig.Runtime.TxPool,
+		TxPool:          txPool,
 		logger:          logging.GetLogger("worker/config"),
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	p2pProtocol "github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -120,7 +121,7 @@ func (n *Node) Start() error {
 	}
 	n.storage = lsb
 
-	go n.worker()
+	recovery.Go("worker/executor/committee", n.worker)
 	return nil
 }
 
@@ -733,7 +734,8 @@ func (n *Node) proposeBatch(
 		if err != nil {
 			return err
 		}
-		// Update state root.
+		// Update state root, making sure the runtime respects the declared state constraints.
+		storageParams := n.blockInfo.ActiveDescriptor.Storage
 		err = n.storage.Apply(ctx, &storage.ApplyRequest{
 			Namespace: lastHeader.Namespace,
 			RootType:  storage.RootTypeState,
@@ -742,6 +744,11 @@ func (n *Node) proposeBatch(
 			DstRound:  lastHeader.Round + 1,
 			DstRoot:   *batch.Header.StateRoot,
 			WriteLog:  batch.StateWriteLog,
+			Constraints: &storage.WriteLogConstraints{
+				MaxKeySize:          storageParams.MaxKeySize,
+				MaxValueSize:        storageParams.MaxValueSize,
+				ReservedKeyPrefixes: storageParams.ReservedKeyPrefixes,
+			},
 		})
 		if err != nil {
 			return err
@@ -1413,6 +1420,13 @@ func (n *Node) roundWorker(ctx context.Context) {
 		)
 		return
 	}
+	if !n.epoch.IsExecutorWorker() && !n.epoch.IsExecutorBackupWorker() {
+		// Standby workers only keep their state warm until they are promoted.
+		n.logger.Debug("skipping round, standby executor member",
+			"round", round,
+		)
+		return
+	}
 
 	// This should never fail as we only register to be an executor worker
 	// once the hosted runtime is ready.
@@ -1450,6 +1464,7 @@ func (n *Node) roundWorker(ctx context.Context) {
 		"rank", n.rank,
 		"worker", n.epoch.IsExecutorWorker(),
 		"backup_worker", n.epoch.IsExecutorBackupWorker(),
+		"standby_worker", n.epoch.IsExecutorStandbyWorker(),
 	)
 
 	// Estimate the pool's highest rank to prevent committing to worse-ranked proposals
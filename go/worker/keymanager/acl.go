@@ -2,13 +2,16 @@ package keymanager
 
 import (
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core"
+	"golang.org/x/exp/maps"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
@@ -64,6 +67,20 @@ func (l *RuntimeList) Delete(runtimeID common.Namespace) {
 	delete(l.runtimes, runtimeID)
 }
 
+// List returns the runtimes in the list.
+//
+// A nil runtime list is considered empty and will always return nil.
+func (l *RuntimeList) List() []common.Namespace {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return maps.Keys(l.runtimes)
+}
+
 // Empty returns true if and only if the list contains no elements.
 func (l *RuntimeList) Empty() bool {
 	if l == nil {
@@ -83,6 +100,8 @@ type AccessList struct {
 	accessList          map[core.PeerID]*RuntimeList       // Guarded by mutex.
 	accessListByRuntime map[common.Namespace][]core.PeerID // Guarded by mutex.
 
+	notifier *pubsub.Broker
+
 	logger *logging.Logger
 }
 
@@ -93,6 +112,7 @@ func NewAccessList() *AccessList {
 	return &AccessList{
 		accessList:          make(map[core.PeerID]*RuntimeList),
 		accessListByRuntime: make(map[common.Namespace][]core.PeerID),
+		notifier:            pubsub.NewBroker(false),
 		logger:              logger,
 	}
 }
@@ -110,8 +130,10 @@ func (l *AccessList) Update(runtimeID common.Namespace, peers []core.PeerID) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	oldPeers := l.accessListByRuntime[runtimeID]
+
 	// Clear any old nodes from the access list.
-	for _, peerID := range l.accessListByRuntime[runtimeID] {
+	for _, peerID := range oldPeers {
 		rts := l.accessList[peerID]
 		rts.Delete(runtimeID)
 		if rts.Empty() {
@@ -138,6 +160,59 @@ func (l *AccessList) Update(runtimeID common.Namespace, peers []core.PeerID) {
 		"runtime_id", runtimeID,
 		"peers", peers,
 	)
+
+	// Notify subscribers about the peers that gained or lost access.
+	if update := newAccessListUpdate(runtimeID, oldPeers, peers); update != nil {
+		update.Time = time.Now()
+		l.logger.Info("client runtime access changed",
+			"runtime_id", runtimeID,
+			"added", update.Added,
+			"removed", update.Removed,
+		)
+		l.notifier.Broadcast(update)
+	}
+}
+
+// WatchUpdates returns a channel that produces a stream of access list updates.
+//
+// An update is emitted every time peers gain or lose access to the key manager
+// on behalf of a runtime.
+func (l *AccessList) WatchUpdates() (<-chan *api.AccessListUpdate, pubsub.ClosableSubscription) {
+	sub := l.notifier.Subscribe()
+	ch := make(chan *api.AccessListUpdate)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
+func newAccessListUpdate(runtimeID common.Namespace, oldPeers, newPeers []core.PeerID) *api.AccessListUpdate {
+	oldSet := make(map[core.PeerID]struct{}, len(oldPeers))
+	for _, peer := range oldPeers {
+		oldSet[peer] = struct{}{}
+	}
+	newSet := make(map[core.PeerID]struct{}, len(newPeers))
+	for _, peer := range newPeers {
+		newSet[peer] = struct{}{}
+	}
+
+	update := api.AccessListUpdate{
+		RuntimeID: runtimeID,
+	}
+	for peer := range newSet {
+		if _, ok := oldSet[peer]; !ok {
+			update.Added = append(update.Added, peer)
+		}
+	}
+	for peer := range oldSet {
+		if _, ok := newSet[peer]; !ok {
+			update.Removed = append(update.Removed, peer)
+		}
+	}
+	if len(update.Added) == 0 && len(update.Removed) == 0 {
+		return nil
+	}
+
+	return &update
 }
 
 // UpdateNodes converts node public keys to peer IDs and updates the access list
@@ -176,6 +251,17 @@ func (l *AccessList) RuntimeAccessLists() []api.RuntimeAccessList {
 	return rals
 }
 
+// Stats returns the access list statistics.
+func (l *AccessList) Stats() api.AccessListCacheStats {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return api.AccessListCacheStats{
+		Runtimes: len(l.accessListByRuntime),
+		Peers:    len(l.accessList),
+	}
+}
+
 // PeerMap is a thread-safe data structure for translating peer IDs to node IDs.
 type PeerMap struct {
 	mu    sync.RWMutex
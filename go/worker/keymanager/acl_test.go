@@ -0,0 +1,86 @@
+package keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
+)
+
+func newTestPeerID(t *testing.T, seed string) core.PeerID {
+	peerID, err := p2p.PublicKeyToPeerID(memorySigner.NewTestSigner(seed).Public())
+	require.NoError(t, err, "PublicKeyToPeerID")
+	return peerID
+}
+
+func TestNewAccessListUpdate(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: acl runtime"), 0)
+	p1 := newTestPeerID(t, "worker/keymanager: acl peer 1")
+	p2 := newTestPeerID(t, "worker/keymanager: acl peer 2")
+	p3 := newTestPeerID(t, "worker/keymanager: acl peer 3")
+
+	// No changes.
+	require.Nil(newAccessListUpdate(runtimeID, nil, nil))
+	require.Nil(newAccessListUpdate(runtimeID, []core.PeerID{p1, p2}, []core.PeerID{p2, p1}))
+
+	// Added peers only.
+	update := newAccessListUpdate(runtimeID, nil, []core.PeerID{p1, p1})
+	require.NotNil(update)
+	require.Equal(runtimeID, update.RuntimeID)
+	require.Equal([]core.PeerID{p1}, update.Added, "duplicates should be ignored")
+	require.Empty(update.Removed)
+
+	// Removed peers only.
+	update = newAccessListUpdate(runtimeID, []core.PeerID{p1, p2}, []core.PeerID{p2})
+	require.NotNil(update)
+	require.Empty(update.Added)
+	require.Equal([]core.PeerID{p1}, update.Removed)
+
+	// Added and removed peers.
+	update = newAccessListUpdate(runtimeID, []core.PeerID{p1, p2}, []core.PeerID{p2, p3})
+	require.NotNil(update)
+	require.Equal([]core.PeerID{p3}, update.Added)
+	require.Equal([]core.PeerID{p1}, update.Removed)
+}
+
+func TestAccessListWatchUpdates(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: acl runtime"), 0)
+	p1 := newTestPeerID(t, "worker/keymanager: acl peer 1")
+	p2 := newTestPeerID(t, "worker/keymanager: acl peer 2")
+
+	l := NewAccessList()
+	ch, sub := l.WatchUpdates()
+	defer sub.Close()
+
+	l.Update(runtimeID, []core.PeerID{p1})
+	select {
+	case update := <-ch:
+		require.Equal([]core.PeerID{p1}, update.Added)
+		require.False(update.Time.IsZero(), "update time should be set")
+	case <-time.After(time.Second):
+		require.FailNow("access list update should be emitted")
+	}
+	require.True(l.Runtimes(p1).Contains(runtimeID))
+
+	// Updates without changes should not be emitted.
+	l.Update(runtimeID, []core.PeerID{p1})
+	l.Update(runtimeID, []core.PeerID{p2})
+	select {
+	case update := <-ch:
+		require.Equal([]core.PeerID{p2}, update.Added)
+		require.Equal([]core.PeerID{p1}, update.Removed)
+	case <-time.After(time.Second):
+		require.FailNow("access list update should be emitted")
+	}
+	require.False(l.Runtimes(p1).Contains(runtimeID))
+	require.True(l.Runtimes(p2).Contains(runtimeID))
+}
@@ -9,12 +9,31 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
 )
 
+const (
+	// ModuleName is the key manager worker module name.
+	ModuleName = "worker/keymanager"
+
+	// LogEventAccessDenied is a log event value that signals that an enclave RPC request
+	// has been denied by the key manager access control.
+	LogEventAccessDenied = "worker/keymanager/access_denied"
+
+	// LogEventPolicyRollback is a log event value that signals that the key manager policy
+	// published by the consensus layer has a lower serial than a previously seen policy.
+	LogEventPolicyRollback = "worker/keymanager/policy_rollback"
+)
+
+// ErrAccessDenied is the error returned when an enclave RPC request is denied by the key
+// manager access control.
+var ErrAccessDenied = errors.New(ModuleName, 1, "worker/keymanager: access denied")
+
 // StatusState is the concise status state of the key manager worker.
 type StatusState uint8
 
@@ -78,6 +97,70 @@ func (s *StatusState) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// SecretsInitStage is the initialization stage of the key manager master and ephemeral
+// secrets worker.
+type SecretsInitStage uint8
+
+const (
+	// SecretsInitStageWaitingStatus is the stage in which the worker waits for the key manager
+	// status (including the policy) to be published by the consensus layer.
+	SecretsInitStageWaitingStatus SecretsInitStage = 0
+	// SecretsInitStageInitializingEnclave is the stage in which the key manager enclave is being
+	// initialized, which includes the replication of master secrets from other key managers.
+	SecretsInitStageInitializingEnclave SecretsInitStage = 1
+	// SecretsInitStageRegistering is the stage in which the enclave has been initialized and
+	// the worker waits for the node to register with the latest initialization response.
+	SecretsInitStageRegistering SecretsInitStage = 2
+	// SecretsInitStageServing is the stage in which the worker is serving requests.
+	SecretsInitStageServing SecretsInitStage = 3
+)
+
+// String returns a string representation of a secrets initialization stage.
+func (s SecretsInitStage) String() string {
+	switch s {
+	case SecretsInitStageWaitingStatus:
+		return "waiting_status"
+	case SecretsInitStageInitializingEnclave:
+		return "initializing_enclave"
+	case SecretsInitStageRegistering:
+		return "registering"
+	case SecretsInitStageServing:
+		return "serving"
+	default:
+		return "[invalid secrets init stage]"
+	}
+}
+
+// MarshalText encodes a SecretsInitStage into text form.
+func (s SecretsInitStage) MarshalText() ([]byte, error) {
+	switch s {
+	case SecretsInitStageWaitingStatus,
+		SecretsInitStageInitializingEnclave,
+		SecretsInitStageRegistering,
+		SecretsInitStageServing:
+		return []byte(s.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid SecretsInitStage: %d", s)
+	}
+}
+
+// UnmarshalText decodes a text slice into a SecretsInitStage.
+func (s *SecretsInitStage) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case SecretsInitStageWaitingStatus.String():
+		*s = SecretsInitStageWaitingStatus
+	case SecretsInitStageInitializingEnclave.String():
+		*s = SecretsInitStageInitializingEnclave
+	case SecretsInitStageRegistering.String():
+		*s = SecretsInitStageRegistering
+	case SecretsInitStageServing.String():
+		*s = SecretsInitStageServing
+	default:
+		return fmt.Errorf("invalid SecretsInitStage: %s", string(text))
+	}
+	return nil
+}
+
 // RuntimeAccessList is an access control lists for a runtime.
 type RuntimeAccessList struct {
 	// RuntimeID is the runtime ID of the runtime this access list is for.
@@ -87,6 +170,22 @@ type RuntimeAccessList struct {
 	Peers []core.PeerID `json:"peers"`
 }
 
+// AccessListUpdate is an access list update, emitted when peers gain or lose access
+// to the key manager on behalf of a runtime.
+type AccessListUpdate struct {
+	// Time is the time of the update.
+	Time time.Time `json:"time"`
+
+	// RuntimeID is the runtime ID of the runtime whose access list changed.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Added is a list of peers that were granted access.
+	Added []core.PeerID `json:"added,omitempty"`
+
+	// Removed is a list of peers whose access was revoked.
+	Removed []core.PeerID `json:"removed,omitempty"`
+}
+
 // Status is the key manager worker status.
 type Status struct {
 	// Status is a concise status of the key manager worker.
@@ -104,6 +203,9 @@ type Status struct {
 	// AccessList is per-runtime list of peers that are allowed to call protected methods.
 	AccessList []RuntimeAccessList `json:"access_list"`
 
+	// AccessListUpdates is a list of the most recent access list updates, oldest first.
+	AccessListUpdates []AccessListUpdate `json:"access_list_updates,omitempty"`
+
 	// Secrets is the master and ephemeral secrets status.
 	Secrets *SecretsStatus `json:"secrets"`
 
@@ -143,6 +245,50 @@ type SecretsWorkerStatus struct {
 
 	// PrivatePeers is a list of peers that are always allowed to call protected methods.
 	PrivatePeers []core.PeerID `json:"private_peers"`
+
+	// Init is the detailed initialization status of the worker.
+	Init SecretsInitStatus `json:"init"`
+}
+
+// SecretsInitStatus is the detailed initialization status of the key manager master and
+// ephemeral secrets worker.
+type SecretsInitStatus struct {
+	// Stage is the furthest initialization stage the worker has reached.
+	Stage SecretsInitStage `json:"stage"`
+
+	// PolicyFetched is true iff the master and ephemeral secrets policy has been fetched from
+	// the consensus layer.
+	PolicyFetched bool `json:"policy_fetched"`
+
+	// Attested is true iff the enclave initialization response has been signed by the runtime
+	// attestation key, i.e. the enclave has been attested.
+	Attested bool `json:"attested"`
+
+	// MasterSecretPresent is true iff the enclave holds at least one master secret.
+	MasterSecretPresent bool `json:"master_secret_present"`
+
+	// MasterSecretReplicated is true iff the enclave holds all master secrets published by the
+	// consensus layer at the time of the last enclave initialization.
+	MasterSecretReplicated bool `json:"master_secret_replicated"`
+
+	// Checksum is the checksum of the master secrets held by the enclave, as reported by
+	// the last successful enclave initialization.
+	Checksum []byte `json:"checksum,omitempty"`
+
+	// Generation is the latest master secret generation the enclave is known to hold, i.e.
+	// the consensus generation at the time of the last enclave initialization in which all
+	// master secrets were replicated.
+	Generation uint64 `json:"generation"`
+
+	// ReplicationNodes are the key manager nodes the enclave was allowed to replicate master
+	// secrets from during the last enclave initialization.
+	ReplicationNodes []signature.PublicKey `json:"replication_nodes,omitempty"`
+
+	// LastInitAttempt is the time of the last enclave initialization attempt.
+	LastInitAttempt time.Time `json:"last_init_attempt"`
+
+	// LastInitError is the error of the last enclave initialization attempt, if it failed.
+	LastInitError string `json:"last_init_error,omitempty"`
 }
 
 // MasterSecretStats are the master secret generation and replication stats.
@@ -187,6 +333,42 @@ type ChurpSchemeStatus struct {
 	Status *churp.Status `json:"status,omitempty"`
 }
 
+// CacheStats are the key manager worker cache statistics.
+type CacheStats struct {
+	// Keys are the statistics of the key caches in the key manager enclave.
+	//
+	// Nil in case the statistics are not available (e.g. the enclave is not running).
+	Keys *secrets.KeyCacheStatsResponse `json:"keys,omitempty"`
+
+	// Policy are the statistics of the cached master and ephemeral secrets policy.
+	Policy PolicyCacheStats `json:"policy"`
+
+	// AccessList are the statistics of the cached access lists.
+	AccessList AccessListCacheStats `json:"access_list"`
+
+	// LastFlush is the time of the last cache flush. In case the caches were never flushed,
+	// it will be the zero timestamp.
+	LastFlush time.Time `json:"last_flush"`
+}
+
+// PolicyCacheStats are the statistics of the cached master and ephemeral secrets policy.
+type PolicyCacheStats struct {
+	// Serial is the serial number of the cached policy.
+	Serial uint32 `json:"serial"`
+
+	// Checksum is the checksum of the policy loaded in the enclave.
+	Checksum []byte `json:"checksum"`
+}
+
+// AccessListCacheStats are the statistics of the cached access lists.
+type AccessListCacheStats struct {
+	// Runtimes is the number of runtimes with an access list.
+	Runtimes int `json:"runtimes"`
+
+	// Peers is the number of peers allowed to call protected methods.
+	Peers int `json:"peers"`
+}
+
 // RPCAccessController handles the authorization of enclave RPC calls.
 type RPCAccessController interface {
 	// Methods returns a list of allowed methods.
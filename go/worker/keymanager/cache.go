@@ -0,0 +1,62 @@
+package keymanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+// GetCacheStats returns the key manager worker cache statistics.
+func (w *Worker) GetCacheStats(ctx context.Context) (*api.CacheStats, error) {
+	if !w.enabled {
+		return nil, fmt.Errorf("worker/keymanager: worker disabled")
+	}
+
+	keys := &secrets.KeyCacheStatsResponse{}
+	if err := w.callEnclaveLocal(ctx, secrets.RPCMethodKeyCacheStats, nil, keys); err != nil {
+		// The enclave may not be running, so don't fail the whole request.
+		w.logger.Warn("failed to fetch enclave key cache statistics",
+			"err", err,
+		)
+		keys = nil
+	}
+
+	w.RLock()
+	lastFlush := w.lastCacheFlush
+	w.RUnlock()
+
+	return &api.CacheStats{
+		Keys:       keys,
+		Policy:     w.secretsWorker.GetPolicyCacheStats(),
+		AccessList: w.accessList.Stats(),
+		LastFlush:  lastFlush,
+	}, nil
+}
+
+// FlushCaches flushes the key manager worker caches.
+//
+// The key caches in the enclave are cleared immediately, while the cached key manager policy
+// and the access lists are rebuilt asynchronously from the latest consensus state.
+func (w *Worker) FlushCaches(ctx context.Context) error {
+	if !w.enabled {
+		return fmt.Errorf("worker/keymanager: worker disabled")
+	}
+
+	w.logger.Info("flushing key manager caches")
+
+	if err := w.callEnclaveLocal(ctx, secrets.RPCMethodFlushKeyCache, nil, nil); err != nil {
+		return fmt.Errorf("worker/keymanager: failed to flush enclave key cache: %w", err)
+	}
+
+	w.secretsWorker.FlushPolicy()
+	w.refreshAccessLists()
+
+	w.Lock()
+	w.lastCacheFlush = time.Now()
+	w.Unlock()
+
+	return nil
+}
@@ -19,6 +19,12 @@ type Config struct {
 	RuntimeID string `yaml:"runtime_id"`
 	// Base64-encoded public keys of unadvertised peers that may call protected methods.
 	PrivatePeerPubKeys []string `yaml:"private_peer_pub_keys"`
+	// Disable master secret generation on this node even if the key manager policy designates it
+	// as a master secret generator.
+	DisableMasterSecretGeneration bool `yaml:"disable_master_secret_generation,omitempty"`
+	// Number of distinct key manager enclaves from which the enclave must replicate each missing
+	// master secret before it accepts it. Zero means that a single enclave suffices.
+	ReplicationQuorum uint8 `yaml:"replication_quorum,omitempty"`
 
 	// Churp holds configuration details for the CHURP extension.
 	Churp ChurpConfig `yaml:"churp,omitempty"`
@@ -32,8 +38,10 @@ func (c *Config) Validate() error {
 // DefaultConfig returns the default configuration settings.
 func DefaultConfig() Config {
 	return Config{
-		RuntimeID:          "",
-		PrivatePeerPubKeys: []string{},
+		RuntimeID:                     "",
+		PrivatePeerPubKeys:            []string{},
+		DisableMasterSecretGeneration: false,
+		ReplicationQuorum:             0,
 		Churp: ChurpConfig{
 			Schemes: []ChurpSchemeConfig{},
 		},
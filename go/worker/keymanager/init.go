@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
@@ -21,6 +22,7 @@ func New(
 	commonWorker *workerCommon.Worker,
 	r *registration.Worker,
 	backend api.Backend,
+	store *persistent.CommonStore,
 ) (*Worker, error) {
 	var enabled bool
 	switch config.GlobalConfig.Mode {
@@ -69,8 +71,8 @@ func New(
 	if err != nil {
 		return nil, fmt.Errorf("worker/keymanager: failed to create runtime registry entry: %w", err)
 	}
-	if numVers := len(w.runtime.HostVersions()); numVers != 1 {
-		return nil, fmt.Errorf("worker/keymanager: expected a single runtime version (got %d)", numVers)
+	if len(w.runtime.HostVersions()) == 0 {
+		return nil, fmt.Errorf("worker/keymanager: no runtime versions configured")
 	}
 
 	// Prepare the runtime host node helpers.
@@ -84,7 +86,7 @@ func New(
 	w.kmRuntimeWatcher = newKmRuntimeWatcher(w.runtimeID, commonWorker.Consensus, w.accessList)
 
 	// Prepare sub-workers.
-	w.secretsWorker, err = newSecretsWorker(w.runtimeID, commonWorker, w, r, backend)
+	w.secretsWorker, err = newSecretsWorker(w.runtimeID, commonWorker, w, r, backend, store)
 	if err != nil {
 		return nil, fmt.Errorf("worker/keymanager: failed to create secrets worker: %w", err)
 	}
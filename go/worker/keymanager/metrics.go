@@ -3,7 +3,10 @@ package keymanager
 import (
 	"sync"
 
+	"github.com/libp2p/go-libp2p/core"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/keymanager/api"
 )
 
 var (
@@ -15,6 +18,38 @@ var (
 		[]string{"runtime"},
 	)
 
+	accessDeniedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_access_denied_count",
+			Help: "Number of enclave RPC requests denied by the key manager access control.",
+		},
+		[]string{"runtime", "method", "client_runtime"},
+	)
+
+	enclaveRPCAuthorizedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_authorized_count",
+			Help: "Number of authorized enclave RPC requests.",
+		},
+		[]string{"runtime", "method", "client_runtime"},
+	)
+
+	enclaveRPCFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_failures_total",
+			Help: "Number of authorized enclave RPC requests that failed to be dispatched to the enclave.",
+		},
+		[]string{"runtime", "method", "client_runtime"},
+	)
+
+	enclaveRPCLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_latency_seconds",
+			Help: "Latency of authorized enclave RPC requests in seconds.",
+		},
+		[]string{"runtime", "method"},
+	)
+
 	policyUpdateCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "oasis_worker_keymanager_policy_update_count",
@@ -23,6 +58,14 @@ var (
 		[]string{"runtime"},
 	)
 
+	policyRollbackCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_policy_rollback_count",
+			Help: "Number of ignored key manager policy updates with a serial lower than previously seen.",
+		},
+		[]string{"runtime"},
+	)
+
 	consensusEphemeralSecretEpochNumber = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "oasis_worker_keymanager_consensus_ephemeral_secret_epoch_number",
@@ -191,7 +234,12 @@ var (
 
 	keymanagerWorkerCollectors = []prometheus.Collector{
 		computeRuntimeCount,
+		accessDeniedCount,
+		enclaveRPCAuthorizedCount,
+		enclaveRPCFailures,
+		enclaveRPCLatency,
 		policyUpdateCount,
+		policyRollbackCount,
 		consensusEphemeralSecretEpochNumber,
 		consensusMasterSecretGenerationNumber,
 		consensusMasterSecretRotationEpochNumber,
@@ -224,3 +272,25 @@ func initMetrics() {
 		prometheus.MustRegister(keymanagerWorkerCollectors...)
 	})
 }
+
+// methodLabel returns the metrics label of the given enclave RPC method.
+func methodLabel(method string) string {
+	if method == api.RPCMethodConnect {
+		return "connect"
+	}
+	return method
+}
+
+// clientRuntimeLabel returns the metrics label of the runtime on behalf of which the given
+// peer calls the key manager.
+func (w *Worker) clientRuntimeLabel(peerID core.PeerID) string {
+	rts := w.accessList.Runtimes(peerID).List()
+	switch len(rts) {
+	case 0:
+		return "unknown"
+	case 1:
+		return rts[0].String()
+	default:
+		return "multiple"
+	}
+}
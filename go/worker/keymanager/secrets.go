@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"slices"
@@ -22,6 +23,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -38,11 +40,31 @@ import (
 )
 
 const (
+	// dbBucketName is the name of the database bucket for the master and ephemeral
+	// secrets worker.
+	dbBucketName = "worker/keymanager/secrets"
+
 	generateSecretMaxRetries = 5
 	loadSecretMaxRetries     = 5
 	ephemeralSecretCacheSize = 20
 )
 
+// policySerialStoreKey returns the database key under which the highest policy serial seen
+// by the worker for the given key manager runtime is stored.
+func policySerialStoreKey(runtimeID common.Namespace) []byte {
+	return append([]byte("highest policy serial/"), runtimeID[:]...)
+}
+
+var (
+	// errPolicyRollback is the error returned when a policy has a lower serial than the highest
+	// policy serial seen so far.
+	errPolicyRollback = errors.New("policy rollback detected")
+
+	// errPolicySerialNotPersisted is the error returned when the highest policy serial could not
+	// be persisted.
+	errPolicySerialNotPersisted = errors.New("failed to persist highest policy serial")
+)
+
 var insecureRPCMethods = map[string]struct{}{
 	secrets.RPCMethodGetPublicKey:          {},
 	secrets.RPCMethodGetPublicEphemeralKey: {},
@@ -70,6 +92,9 @@ type secretsWorker struct {
 
 	privatePeers map[core.PeerID]struct{}
 
+	mayGenerateMasterSecret bool
+	replicationQuorum       uint64
+
 	kmWorker     *Worker
 	commonWorker *workerCommon.Worker
 	roleProvider registration.RoleProvider
@@ -78,12 +103,18 @@ type secretsWorker struct {
 	status   workerKm.SecretsStatus // Guarded by mutex.
 	kmStatus *secrets.Status
 
+	store                 *persistent.ServiceStore
+	highestPolicySerial   uint32
+	policySerialPersisted bool
+
 	initEnclaveInProgress  bool
 	initEnclaveRequired    bool
 	initEnclaveDoneCh      chan *secrets.SignedInitResponse
 	initEnclaveRetryCh     <-chan time.Time
 	initEnclaveRetryTicker *backoff.Ticker
 
+	flushCh chan struct{}
+
 	mstSecret *secrets.SignedEncryptedMasterSecret
 
 	loadMstSecRetry     int
@@ -109,6 +140,7 @@ func newSecretsWorker(
 	kmWorker *Worker,
 	r *registration.Worker,
 	backend api.Backend,
+	store *persistent.CommonStore,
 ) (*secretsWorker, error) {
 	roleProvider, err := r.NewRuntimeRoleProvider(node.RoleKeyManager, runtimeID)
 	if err != nil {
@@ -132,6 +164,13 @@ func newSecretsWorker(
 		privatePeers[peerID] = struct{}{}
 	}
 
+	// Load the highest policy serial seen so far to detect policy rollbacks.
+	serviceStore := store.GetServiceStore(dbBucketName)
+	var highestPolicySerial uint32
+	if err = serviceStore.GetCBOR(policySerialStoreKey(runtimeID), &highestPolicySerial); err != nil && err != persistent.ErrNotFound {
+		return nil, fmt.Errorf("worker/keymanager: failed to load highest policy serial: %w", err)
+	}
+
 	var status workerKm.SecretsStatus
 	for p := range privatePeers {
 		status.Worker.PrivatePeers = append(status.Worker.PrivatePeers, p)
@@ -139,21 +178,27 @@ func newSecretsWorker(
 	status.Worker.Status = workerKm.StatusStateStopped
 
 	return &secretsWorker{
-		logger:            logging.GetLogger("worker/keymanager/secrets"),
-		initCh:            make(chan struct{}),
-		runtimeID:         runtimeID,
-		runtimeLabel:      runtimeID.String(),
-		roleProvider:      roleProvider,
-		privatePeers:      privatePeers,
-		kmWorker:          kmWorker,
-		commonWorker:      commonWorker,
-		backend:           backend,
-		initEnclaveDoneCh: make(chan *secrets.SignedInitResponse, 1),
-		genMstSecDoneCh:   make(chan bool, 1),
-		genMstSecEpoch:    math.MaxUint64,
-		genEphSecDoneCh:   make(chan bool, 1),
-		genSecHeight:      int64(math.MaxInt64),
-		status:            status,
+		logger:                  logging.GetLogger("worker/keymanager/secrets"),
+		initCh:                  make(chan struct{}),
+		runtimeID:               runtimeID,
+		runtimeLabel:            runtimeID.String(),
+		roleProvider:            roleProvider,
+		privatePeers:            privatePeers,
+		mayGenerateMasterSecret: !config.GlobalConfig.Keymanager.DisableMasterSecretGeneration,
+		replicationQuorum:       uint64(config.GlobalConfig.Keymanager.ReplicationQuorum),
+		kmWorker:                kmWorker,
+		commonWorker:            commonWorker,
+		backend:                 backend,
+		store:                   serviceStore,
+		highestPolicySerial:     highestPolicySerial,
+		policySerialPersisted:   true,
+		initEnclaveDoneCh:       make(chan *secrets.SignedInitResponse, 1),
+		genMstSecDoneCh:         make(chan bool, 1),
+		genMstSecEpoch:          math.MaxUint64,
+		genEphSecDoneCh:         make(chan bool, 1),
+		genSecHeight:            int64(math.MaxInt64),
+		flushCh:                 make(chan struct{}, 1),
+		status:                  status,
 	}, nil
 }
 
@@ -293,6 +338,31 @@ func (w *secretsWorker) GetStatus() *workerKm.SecretsStatus {
 	}
 }
 
+// GetPolicyCacheStats returns the statistics of the cached policy.
+func (w *secretsWorker) GetPolicyCacheStats() workerKm.PolicyCacheStats {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var serial uint32
+	if w.status.Worker.Policy != nil {
+		serial = w.status.Worker.Policy.Policy.Serial
+	}
+
+	return workerKm.PolicyCacheStats{
+		Serial:   serial,
+		Checksum: w.status.Worker.PolicyChecksum,
+	}
+}
+
+// FlushPolicy requests the cached key manager status and policy to be discarded
+// and fetched again from the consensus layer, re-initializing the enclave.
+func (w *secretsWorker) FlushPolicy() {
+	select {
+	case w.flushCh <- struct{}{}:
+	default:
+	}
+}
+
 func (w *secretsWorker) work(ctx context.Context, hrt host.RichRuntime) {
 	w.logger.Info("starting master and ephemeral secrets worker")
 
@@ -362,6 +432,8 @@ func (w *secretsWorker) work(ctx context.Context, hrt host.RichRuntime) {
 			w.handleStatusUpdate(ctx, kmStatus)
 		case <-w.initEnclaveRetryCh:
 			w.handleInitEnclave(ctx)
+		case <-w.flushCh:
+			w.handleFlushPolicy(ctx)
 		case rsp := <-w.initEnclaveDoneCh:
 			w.handleInitEnclaveDone(ctx, rsp)
 		case secret := <-mstCh:
@@ -460,14 +532,38 @@ func (w *secretsWorker) handleStatusUpdate(ctx context.Context, kmStatus *secret
 		"nodes", kmStatus.Nodes,
 	)
 
+	// Refuse to act upon a policy older than the one seen before, as that indicates that
+	// the consensus state has been rolled back.
+	switch err := w.checkPolicySerial(kmStatus.Policy); {
+	case err == nil:
+	case errors.Is(err, errPolicyRollback):
+		policyRollbackCount.WithLabelValues(w.runtimeLabel).Inc()
+		w.logger.Error("ignoring key manager status update",
+			"err", err,
+			logging.LogEvent, workerKm.LogEventPolicyRollback,
+		)
+		return
+	default:
+		// The serial is still tracked in memory and persisting it will be retried
+		// on the next status update, so there is no reason to drop this one.
+		w.logger.Error("failed to persist highest policy serial",
+			"err", err,
+		)
+	}
+
 	// Update metrics.
 	consensusMasterSecretGenerationNumber.WithLabelValues(w.runtimeLabel).Set(float64(kmStatus.Generation))
 	consensusMasterSecretRotationEpochNumber.WithLabelValues(w.runtimeLabel).Set(float64(kmStatus.RotationEpoch))
 
+	// A policy update may change which runtimes are allowed to query the key manager,
+	// so make sure the access lists are recomputed without waiting for node updates.
+	policyChanged := policyUpdated(w.kmStatus, kmStatus)
+
 	// Cache the latest status.
 	w.kmStatus = kmStatus
 	w.mu.Lock()
 	w.status.Status = kmStatus
+	w.status.Worker.Init.PolicyFetched = kmStatus.Policy != nil
 	w.mu.Unlock()
 
 	// (Re)Initialize the enclave.
@@ -476,6 +572,67 @@ func (w *secretsWorker) handleStatusUpdate(ctx context.Context, kmStatus *secret
 
 	// The epoch for generating the next master secret may change with the policy update.
 	w.updateGenerateMasterSecretEpoch()
+
+	if policyChanged {
+		w.logger.Info("key manager policy updated, rebuilding access lists")
+		w.kmWorker.refreshAccessLists()
+	}
+}
+
+// checkPolicySerial verifies that the serial of the given policy is not lower than the highest
+// policy serial seen so far, and persists the serial if it is higher.
+//
+// Returns errPolicyRollback if the serial is lower and errPolicySerialNotPersisted if the highest
+// serial could not be persisted.
+func (w *secretsWorker) checkPolicySerial(policy *secrets.SignedPolicySGX) error {
+	if policy == nil {
+		return nil
+	}
+
+	serial := policy.Policy.Serial
+	switch {
+	case serial < w.highestPolicySerial:
+		return fmt.Errorf("%w (serial: %d, highest serial: %d)", errPolicyRollback, serial, w.highestPolicySerial)
+	case serial > w.highestPolicySerial:
+		w.highestPolicySerial = serial
+		w.policySerialPersisted = false
+	}
+
+	if w.policySerialPersisted {
+		return nil
+	}
+	if err := w.store.PutCBOR(policySerialStoreKey(w.runtimeID), &w.highestPolicySerial); err != nil {
+		return fmt.Errorf("%w: %w", errPolicySerialNotPersisted, err)
+	}
+	w.policySerialPersisted = true
+
+	return nil
+}
+
+// policyUpdated returns true iff the policy of the new key manager status differs from
+// the policy of the old one.
+func policyUpdated(oldStatus, newStatus *secrets.Status) bool {
+	if oldStatus == nil {
+		return true
+	}
+	return !bytes.Equal(cbor.Marshal(oldStatus.Policy), cbor.Marshal(newStatus.Policy))
+}
+
+func (w *secretsWorker) handleFlushPolicy(ctx context.Context) {
+	w.logger.Info("flushing cached key manager status and policy")
+
+	kmStatus, err := w.backend.Secrets().GetStatus(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     w.runtimeID,
+	})
+	if err != nil {
+		w.logger.Error("failed to fetch key manager status",
+			"err", err,
+		)
+		return
+	}
+
+	w.handleStatusUpdate(ctx, kmStatus)
 }
 
 func (w *secretsWorker) handleInitEnclave(ctx context.Context) {
@@ -494,6 +651,13 @@ func (w *secretsWorker) handleInitEnclave(ctx context.Context) {
 	w.initEnclaveRequired = false
 	w.initEnclaveInProgress = true
 
+	w.mu.Lock()
+	w.status.Worker.Init.LastInitAttempt = time.Now()
+	if w.status.Worker.Init.Stage < workerKm.SecretsInitStageInitializingEnclave {
+		w.status.Worker.Init.Stage = workerKm.SecretsInitStageInitializingEnclave
+	}
+	w.mu.Unlock()
+
 	// Enclave initialization can take a long time (e.g. when master secrets
 	// need to be replicated), so don't block the loop.
 	initEnclave := func(kmStatus *secrets.Status) {
@@ -502,6 +666,10 @@ func (w *secretsWorker) handleInitEnclave(ctx context.Context) {
 			w.logger.Error("failed to initialize enclave",
 				"err", err,
 			)
+
+			w.mu.Lock()
+			w.status.Worker.Init.LastInitError = err.Error()
+			w.mu.Unlock()
 		}
 		w.initEnclaveDoneCh <- rsp
 	}
@@ -514,7 +682,8 @@ func (w *secretsWorker) initEnclave(ctx context.Context, kmStatus *secrets.Statu
 
 	// Initialize the key manager.
 	args := secrets.InitRequest{
-		Status: *kmStatus,
+		Status:            *kmStatus,
+		ReplicationQuorum: w.replicationQuorum,
 	}
 	var rsp secrets.SignedInitResponse
 	if err := w.kmWorker.callEnclaveLocal(ctx, secrets.RPCMethodInit, args, &rsp); err != nil {
@@ -558,6 +727,18 @@ func (w *secretsWorker) initEnclave(ctx context.Context, kmStatus *secrets.Statu
 	// Update status.
 	w.status.Worker.Policy = kmStatus.Policy
 	w.status.Worker.PolicyChecksum = rsp.InitResponse.PolicyChecksum
+	w.status.Worker.Init.Attested = true
+	w.status.Worker.Init.MasterSecretPresent = len(rsp.InitResponse.Checksum) > 0
+	w.status.Worker.Init.MasterSecretReplicated = bytes.Equal(rsp.InitResponse.Checksum, kmStatus.Checksum)
+	w.status.Worker.Init.Checksum = rsp.InitResponse.Checksum
+	w.status.Worker.Init.ReplicationNodes = kmStatus.Nodes
+	if w.status.Worker.Init.MasterSecretReplicated {
+		w.status.Worker.Init.Generation = kmStatus.Generation
+	}
+	w.status.Worker.Init.LastInitError = ""
+	if w.status.Worker.Init.Stage < workerKm.SecretsInitStageRegistering {
+		w.status.Worker.Init.Stage = workerKm.SecretsInitStageRegistering
+	}
 
 	return &rsp, nil
 }
@@ -591,10 +772,12 @@ func (w *secretsWorker) handleInitEnclaveDone(ctx context.Context, rsp *secrets.
 		return
 	}
 
-	// (Re)Register the node with the latest init response.
-	if rsp != nil {
-		w.registerNode(rsp, *version)
+	if rsp == nil {
+		return
 	}
+
+	// (Re)Register the node with the latest init response.
+	w.registerNode(rsp, *version)
 }
 
 func (w *secretsWorker) registerNode(rsp *secrets.SignedInitResponse, version version.Version) {
@@ -620,6 +803,7 @@ func (w *secretsWorker) registerNode(rsp *secrets.SignedInitResponse, version ve
 		w.mu.Lock()
 		w.status.Worker.LastRegistration = time.Now()
 		w.status.Worker.Status = workerKm.StatusStateReady
+		w.status.Worker.Init.Stage = workerKm.SecretsInitStageServing
 		w.mu.Unlock()
 
 		select {
@@ -748,7 +932,7 @@ func (w *secretsWorker) loadMasterSecret(ctx context.Context, sigSecret *secrets
 }
 
 func (w *secretsWorker) handleGenerateMasterSecret(ctx context.Context, height int64, epoch beacon.EpochTime) {
-	if w.kmStatus == nil {
+	if w.kmStatus == nil || !w.mayGenerateMasterSecret {
 		return
 	}
 	if w.genMstSecInProgress || w.genMstSecRetry > generateSecretMaxRetries {
@@ -820,6 +1004,12 @@ func (w *secretsWorker) generateMasterSecret(ctx context.Context, runtimeID comm
 		return fmt.Errorf("node not in the key manager committee")
 	}
 
+	// Skip generation if the node is not authorized by the policy to generate master secrets.
+	if !kmStatus.IsMasterSecretGenerator(id) {
+		w.logger.Info("skipping master secret generation, node not a designated generator")
+		return fmt.Errorf("node not a designated master secret generator")
+	}
+
 	// Generate master secret.
 	args := secrets.GenerateMasterSecretRequest{
 		Generation: generation,
@@ -0,0 +1,91 @@
+package keymanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+)
+
+func TestCheckPolicySerial(t *testing.T) {
+	require := require.New(t)
+
+	commonStore, err := persistent.NewCommonStore(t.TempDir())
+	require.NoError(err, "NewCommonStore")
+	defer commonStore.Close()
+	store := commonStore.GetServiceStore(dbBucketName)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: policy serial runtime"), 0)
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: policy serial other runtime"), 0)
+	newWorker := func(runtimeID common.Namespace) *secretsWorker {
+		var serial uint32
+		err := store.GetCBOR(policySerialStoreKey(runtimeID), &serial)
+		if err != persistent.ErrNotFound {
+			require.NoError(err, "GetCBOR")
+		}
+		return &secretsWorker{
+			logger:                logging.GetLogger("worker/keymanager/test"),
+			runtimeID:             runtimeID,
+			store:                 store,
+			highestPolicySerial:   serial,
+			policySerialPersisted: true,
+		}
+	}
+	policy := func(serial uint32) *secrets.SignedPolicySGX {
+		return &secrets.SignedPolicySGX{Policy: secrets.PolicySGX{Serial: serial}}
+	}
+
+	w := newWorker(runtimeID)
+	require.NoError(w.checkPolicySerial(nil), "missing policies should be ignored")
+	require.NoError(w.checkPolicySerial(policy(5)))
+	require.NoError(w.checkPolicySerial(policy(5)), "the same serial should be accepted")
+	err = w.checkPolicySerial(policy(4))
+	require.ErrorIs(err, errPolicyRollback, "lower serials should be rejected")
+
+	// The highest serial should be persisted.
+	w = newWorker(runtimeID)
+	require.EqualValues(5, w.highestPolicySerial)
+	require.Error(w.checkPolicySerial(policy(4)), "lower serials should be rejected after restart")
+	require.NoError(w.checkPolicySerial(policy(6)))
+
+	// Serials of different key manager runtimes should be independent.
+	w = newWorker(otherRuntimeID)
+	require.EqualValues(0, w.highestPolicySerial)
+	require.NoError(w.checkPolicySerial(policy(1)))
+	w = newWorker(runtimeID)
+	require.EqualValues(6, w.highestPolicySerial)
+
+	// Persistence failures should not be reported as rollbacks and should be retried.
+	commonStore.Close()
+	err = w.checkPolicySerial(policy(7))
+	require.ErrorIs(err, errPolicySerialNotPersisted, "persistence failures should be reported")
+	require.NotErrorIs(err, errPolicyRollback, "persistence failures should not be rollbacks")
+	require.EqualValues(7, w.highestPolicySerial, "the highest serial should be tracked in memory")
+	err = w.checkPolicySerial(policy(6))
+	require.ErrorIs(err, errPolicyRollback, "lower serials should be rejected when not persisted")
+	err = w.checkPolicySerial(policy(7))
+	require.ErrorIs(err, errPolicySerialNotPersisted, "persisting should be retried")
+}
+
+func TestPolicyUpdated(t *testing.T) {
+	require := require.New(t)
+
+	status := func(serial uint32) *secrets.Status {
+		return &secrets.Status{
+			Policy: &secrets.SignedPolicySGX{Policy: secrets.PolicySGX{Serial: serial}},
+		}
+	}
+
+	// The access lists should be rebuilt on the first status and on policy changes only.
+	require.True(policyUpdated(nil, status(1)))
+	require.False(policyUpdated(status(1), status(1)))
+	require.True(policyUpdated(status(1), status(2)))
+	require.True(policyUpdated(status(1), &secrets.Status{}))
+
+	noPolicy := &secrets.Status{Generation: 1}
+	require.False(policyUpdated(&secrets.Status{}, noPolicy))
+}
@@ -1,6 +1,8 @@
 package keymanager
 
 import (
+	"slices"
+
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
@@ -53,12 +55,13 @@ func (w *Worker) GetStatus() (*api.Status, error) {
 	defer w.RUnlock()
 
 	return &api.Status{
-		Status:         status,
-		ActiveVersion:  activeVersion,
-		RuntimeID:      &w.runtimeID,
-		ClientRuntimes: runtimeClients,
-		AccessList:     accessList,
-		Secrets:        secrets,
-		Churp:          churp,
+		Status:            status,
+		ActiveVersion:     activeVersion,
+		RuntimeID:         &w.runtimeID,
+		ClientRuntimes:    runtimeClients,
+		AccessList:        accessList,
+		AccessListUpdates: slices.Clone(w.accessListUpdates),
+		Secrets:           secrets,
+		Churp:             churp,
 	}, nil
 }
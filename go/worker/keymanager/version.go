@@ -0,0 +1,273 @@
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// errSoleKeyManagerNode is the error returned when the node is the only key manager node
+// holding master secrets, as nobody could replicate them to a new runtime version.
+var errSoleKeyManagerNode = errors.New("the only key manager node cannot switch between hosted runtime versions as nobody could replicate master secrets to the new version, run additional key manager nodes or host a single runtime version")
+
+// selectHostedRuntimeVersion returns the hosted runtime version that should be active
+// at the given epoch.
+//
+// Key managers hosting a single version always use the enclave version given to them
+// in the bundle, as they need to make sure that replication is possible during upgrades.
+// Key managers hosting multiple versions follow the runtime deployments and switch to
+// the new version at its activation epoch.
+func selectHostedRuntimeVersion(rt *registry.Runtime, epoch beacon.EpochTime, hostVersions []version.Version) (version.Version, error) {
+	if len(hostVersions) == 1 {
+		return hostVersions[0], nil
+	}
+
+	deployment := rt.ActiveDeployment(epoch)
+	if deployment == nil {
+		return version.Version{}, fmt.Errorf("no active deployment at epoch %d", epoch)
+	}
+	if !slices.Contains(hostVersions, deployment.Version) {
+		return version.Version{}, fmt.Errorf("active version %s is not hosted", deployment.Version)
+	}
+	return deployment.Version, nil
+}
+
+// previousHostedRuntimeVersion returns the hosted runtime version that was active before
+// the given deployment, if any.
+func previousHostedRuntimeVersion(rt *registry.Runtime, deployment *registry.VersionInfo, hostVersions []version.Version) *version.Version {
+	if deployment.ValidFrom == 0 {
+		return nil
+	}
+	prev := rt.ActiveDeployment(deployment.ValidFrom - 1)
+	if prev == nil || !slices.Contains(hostVersions, prev.Version) {
+		return nil
+	}
+	return &prev.Version
+}
+
+// mayActivateHostedRuntimeVersion returns true iff the node may switch to a new hosted
+// runtime version at the given epoch.
+//
+// Enclaves of different versions cannot unseal each other's master secrets, so a node
+// holding master secrets, i.e. one of the given key manager nodes, needs to replicate them
+// from other nodes once it switches. To prevent all nodes from switching at once and losing
+// the secrets, nodes switch one per epoch in the order of their IDs, starting at the
+// activation epoch. The first node only switches if another node can provide the secrets,
+// while the others wait until at least one node serves the new version with replicated
+// secrets.
+//
+// The only key manager node can never switch, so errSoleKeyManagerNode is returned instead.
+func mayActivateHostedRuntimeVersion(
+	nodeID signature.PublicKey,
+	nodes []signature.PublicKey,
+	upgraded map[signature.PublicKey]bool,
+	activation beacon.EpochTime,
+	epoch beacon.EpochTime,
+) (bool, error) {
+	sorted := slices.Clone(nodes)
+	slices.SortFunc(sorted, func(a, b signature.PublicKey) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	pos := slices.IndexFunc(sorted, nodeID.Equal)
+	if pos < 0 {
+		// Nodes without master secrets have nothing to lose.
+		return true, nil
+	}
+	if len(sorted) == 1 {
+		return false, errSoleKeyManagerNode
+	}
+	if epoch < activation+beacon.EpochTime(pos) {
+		return false, nil
+	}
+
+	for _, id := range sorted {
+		if id.Equal(nodeID) {
+			continue
+		}
+		if pos == 0 || upgraded[id] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// verifyHostedRuntimeVersions makes sure that the node will be able to switch between
+// the hosted runtime versions, so that it doesn't postpone switching forever.
+func (w *Worker) verifyHostedRuntimeVersions(ctx context.Context) error {
+	if len(w.runtime.HostVersions()) == 1 {
+		return nil
+	}
+
+	status, err := w.backend.Secrets().GetStatus(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     w.runtimeID,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, secrets.ErrNoSuchStatus):
+		// No master secrets have been generated yet.
+		return nil
+	default:
+		return fmt.Errorf("failed to fetch key manager status: %w", err)
+	}
+
+	if len(status.Nodes) == 1 && status.Nodes[0].Equal(w.nodeID) {
+		return errSoleKeyManagerNode
+	}
+	return nil
+}
+
+// mayActivateHostedRuntimeVersionAt fetches the key manager committee and checks whether
+// the node may switch to the given hosted runtime version at the given epoch.
+func (w *Worker) mayActivateHostedRuntimeVersionAt(ctx context.Context, v version.Version, activation beacon.EpochTime, epoch beacon.EpochTime) (bool, error) {
+	status, err := w.backend.Secrets().GetStatus(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     w.runtimeID,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, secrets.ErrNoSuchStatus):
+		// No master secrets have been generated yet.
+		return true, nil
+	default:
+		return false, fmt.Errorf("failed to fetch key manager status: %w", err)
+	}
+
+	upgraded := make(map[signature.PublicKey]bool, len(status.Nodes))
+	for _, id := range status.Nodes {
+		n, err := w.commonWorker.Consensus.Registry().GetNode(ctx, &registry.IDQuery{
+			Height: consensus.HeightLatest,
+			ID:     id,
+		})
+		switch {
+		case err == nil:
+		case errors.Is(err, registry.ErrNoSuchNode):
+			continue
+		default:
+			return false, fmt.Errorf("failed to fetch key manager node %s: %w", id, err)
+		}
+
+		// Nodes are part of the committee only if all their versions replicated the secrets.
+		upgraded[id] = n.GetRuntime(w.runtimeID, v) != nil
+	}
+
+	return mayActivateHostedRuntimeVersion(w.nodeID, status.Nodes, upgraded, activation, epoch)
+}
+
+// updateHostedRuntimeVersion activates the hosted runtime version that should be active
+// at the current epoch, if it differs from the currently active one.
+//
+// When hosting multiple versions, the switch is postponed until the master secrets can be
+// safely replicated to the new version, see mayActivateHostedRuntimeVersion.
+func (w *Worker) updateHostedRuntimeVersion(ctx context.Context, rt *registry.Runtime) error {
+	epoch, err := w.commonWorker.Consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to fetch current epoch: %w", err)
+	}
+
+	hostVersions := w.runtime.HostVersions()
+	activeVersion, err := selectHostedRuntimeVersion(rt, epoch, hostVersions)
+	if err != nil {
+		return err
+	}
+
+	current, err := w.GetHostedRuntimeActiveVersion()
+	if err == nil && *current == activeVersion {
+		return nil
+	}
+	if err != nil {
+		current = nil
+	}
+
+	if len(hostVersions) > 1 {
+		deployment := rt.ActiveDeployment(epoch)
+		ok, err := w.mayActivateHostedRuntimeVersionAt(ctx, activeVersion, deployment.ValidFrom, epoch)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			w.logger.Info("postponing key manager runtime version switch until master secrets can be replicated",
+				"version", activeVersion,
+				"epoch", epoch,
+			)
+
+			// Keep the current version, or start the previous one after a restart.
+			if current != nil {
+				return nil
+			}
+			if prev := previousHostedRuntimeVersion(rt, deployment, hostVersions); prev != nil {
+				activeVersion = *prev
+			} else {
+				// The previous deployment has been pruned (or its version is not hosted), so
+				// the new version is the only one that can be used.
+				w.logger.Warn("previous key manager runtime version is not available, activating the new version",
+					"version", activeVersion,
+					"epoch", epoch,
+				)
+			}
+		}
+	}
+
+	if err = w.SetHostedRuntimeVersion(activeVersion, nil); err != nil {
+		return fmt.Errorf("failed to activate version %s: %w", activeVersion, err)
+	}
+
+	w.logger.Info("activated key manager runtime version",
+		"version", activeVersion,
+		"epoch", epoch,
+	)
+
+	return nil
+}
+
+// watchHostedRuntimeVersion switches the hosted runtime version on epoch transitions
+// and runtime descriptor updates.
+func (w *Worker) watchHostedRuntimeVersion(ctx context.Context, rt *registry.Runtime) {
+	// A single hosted version never changes.
+	if len(w.runtime.HostVersions()) == 1 {
+		return
+	}
+
+	epochCh, epochSub, err := w.commonWorker.Consensus.Beacon().WatchEpochs(ctx)
+	if err != nil {
+		w.logger.Error("failed to watch epochs",
+			"err", err,
+		)
+		return
+	}
+	defer epochSub.Close()
+
+	rtCh, rtSub, err := w.runtime.WatchRegistryDescriptor()
+	if err != nil {
+		w.logger.Error("failed to watch runtime descriptor",
+			"err", err,
+		)
+		return
+	}
+	defer rtSub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-epochCh:
+		case rt = <-rtCh:
+		}
+
+		if err = w.updateHostedRuntimeVersion(ctx, rt); err != nil {
+			w.logger.Error("failed to update key manager runtime version",
+				"err", err,
+			)
+		}
+	}
+}
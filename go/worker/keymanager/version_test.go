@@ -0,0 +1,95 @@
+package keymanager
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestSelectHostedRuntimeVersion(t *testing.T) {
+	require := require.New(t)
+
+	v1 := version.Version{Major: 1}
+	v2 := version.Version{Major: 2}
+	rt := &registry.Runtime{
+		Deployments: []*registry.VersionInfo{
+			{Version: v1, ValidFrom: 5},
+			{Version: v2, ValidFrom: 10},
+		},
+	}
+
+	// A single hosted version is always used.
+	v, err := selectHostedRuntimeVersion(rt, 0, []version.Version{v2})
+	require.NoError(err)
+	require.Equal(v2, v)
+
+	// Multiple hosted versions follow the deployments.
+	hostVersions := []version.Version{v1, v2}
+	_, err = selectHostedRuntimeVersion(rt, 4, hostVersions)
+	require.Error(err, "there should be no active deployment")
+	v, err = selectHostedRuntimeVersion(rt, 9, hostVersions)
+	require.NoError(err)
+	require.Equal(v1, v)
+	v, err = selectHostedRuntimeVersion(rt, 10, hostVersions)
+	require.NoError(err)
+	require.Equal(v2, v)
+	_, err = selectHostedRuntimeVersion(rt, 10, []version.Version{v1, {Major: 3}})
+	require.Error(err, "active version should be hosted")
+
+	// Previous versions.
+	require.Nil(previousHostedRuntimeVersion(rt, rt.Deployments[0], hostVersions))
+	require.Equal(&v1, previousHostedRuntimeVersion(rt, rt.Deployments[1], hostVersions))
+	require.Nil(previousHostedRuntimeVersion(rt, rt.Deployments[1], []version.Version{v2}))
+}
+
+func TestMayActivateHostedRuntimeVersion(t *testing.T) {
+	require := require.New(t)
+
+	nodes := []signature.PublicKey{
+		memorySigner.NewTestSigner("node 1").Public(),
+		memorySigner.NewTestSigner("node 2").Public(),
+		memorySigner.NewTestSigner("node 3").Public(),
+	}
+	sorted := slices.Clone(nodes)
+	slices.SortFunc(sorted, func(a, b signature.PublicKey) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	first, second, third := sorted[0], sorted[1], sorted[2]
+	outsider := memorySigner.NewTestSigner("outsider").Public()
+	upgraded := make(map[signature.PublicKey]bool)
+
+	mayActivate := func(nodeID signature.PublicKey, epoch beacon.EpochTime) bool {
+		ok, err := mayActivateHostedRuntimeVersion(nodeID, nodes, upgraded, 10, epoch)
+		require.NoError(err)
+		return ok
+	}
+
+	// Nodes without master secrets can switch immediately.
+	require.True(mayActivate(outsider, 10))
+
+	// Nodes switch one per epoch.
+	require.True(mayActivate(first, 10))
+	require.False(mayActivate(second, 10))
+	require.False(mayActivate(third, 11))
+
+	// Others wait until a node serves the new version with replicated secrets.
+	require.False(mayActivate(second, 11))
+	require.False(mayActivate(third, 12))
+	upgraded[first] = true
+	require.True(mayActivate(second, 11))
+	require.True(mayActivate(third, 12))
+
+	// A single node has nobody to replicate the secrets from, which is reported instead of
+	// postponing the switch forever.
+	ok, err := mayActivateHostedRuntimeVersion(first, []signature.PublicKey{first}, nil, 10, 10)
+	require.ErrorIs(err, errSoleKeyManagerNode)
+	require.False(ok)
+}
@@ -29,6 +29,8 @@ type kmNodeWatcher struct {
 	accessList *AccessList
 	peerTagger p2p.PeerTagger
 
+	refreshCh chan struct{}
+
 	logger *logging.Logger
 }
 
@@ -41,10 +43,19 @@ func newKmNodeWatcher(runtimeID common.Namespace, consensus consensus.Backend, p
 		peerMap:    peerMap,
 		accessList: accessList,
 		peerTagger: peerTagger,
+		refreshCh:  make(chan struct{}, 1),
 		logger:     logger,
 	}
 }
 
+// refresh requests the access list and the peer map to be rebuilt.
+func (w *kmNodeWatcher) refresh() {
+	select {
+	case w.refreshCh <- struct{}{}:
+	default:
+	}
+}
+
 func (w *kmNodeWatcher) watch(ctx context.Context) {
 	nodesCh, nodesSub, err := w.consensus.Registry().WatchNodeList(ctx)
 	if err != nil {
@@ -92,6 +103,8 @@ func (w *kmNodeWatcher) watch(ctx context.Context) {
 			if !activeNodes[watcherEv.Update.ID] {
 				continue
 			}
+		case <-w.refreshCh:
+			w.logger.Info("rebuilding key manager access list")
 		case <-ctx.Done():
 			return
 		}
@@ -193,7 +206,20 @@ func (w *kmRuntimeWatcher) watch(ctx context.Context) {
 		case rt = <-rtCh:
 		}
 
-		if rt.Kind != registry.KindCompute || rt.KeyManager == nil || !rt.KeyManager.Equal(&w.runtimeID) {
+		if rt.Kind != registry.KindCompute {
+			continue
+		}
+
+		if rt.KeyManager == nil || !rt.KeyManager.Equal(&w.runtimeID) {
+			// The runtime may have switched to a different key manager.
+			if rnw := w.removeRuntimeNodeWatcher(rt.ID); rnw != nil {
+				w.logger.Info("runtime no longer uses us as a key manager",
+					"runtime_id", rt.ID,
+					"key_manager", rt.KeyManager,
+				)
+
+				rnw.stop()
+			}
 			continue
 		}
 
@@ -227,6 +253,15 @@ func (w *kmRuntimeWatcher) addRuntimeNodeWatcher(n common.Namespace, crw *rtNode
 	w.clientRuntimes[n] = crw
 }
 
+func (w *kmRuntimeWatcher) removeRuntimeNodeWatcher(n common.Namespace) *rtNodeWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	crw := w.clientRuntimes[n]
+	delete(w.clientRuntimes, n)
+	return crw
+}
+
 func (w *kmRuntimeWatcher) getRuntimeNodeWatcher(n common.Namespace) *rtNodeWatcher {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
@@ -234,6 +269,16 @@ func (w *kmRuntimeWatcher) getRuntimeNodeWatcher(n common.Namespace) *rtNodeWatc
 	return w.clientRuntimes[n]
 }
 
+// refresh requests the access lists of all client runtimes to be rebuilt.
+func (w *kmRuntimeWatcher) refresh() {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	for _, rnw := range w.clientRuntimes {
+		rnw.refresh()
+	}
+}
+
 // Runtimes returns a list of compute client runtimes that use the specified key manager.
 func (w *kmRuntimeWatcher) Runtimes() []common.Namespace {
 	w.mu.RLock()
@@ -255,6 +300,9 @@ type rtNodeWatcher struct {
 
 	accessList *AccessList
 
+	refreshCh chan struct{}
+	stopCh    chan struct{}
+
 	logger *logging.Logger
 }
 
@@ -265,10 +313,27 @@ func newRtNodeWatcher(runtimeID common.Namespace, consensus consensus.Backend, a
 		runtimeID:  runtimeID,
 		consensus:  consensus,
 		accessList: accessList,
+		refreshCh:  make(chan struct{}, 1),
+		stopCh:     make(chan struct{}),
 		logger:     logger,
 	}
 }
 
+// refresh requests the access list to be rebuilt.
+func (w *rtNodeWatcher) refresh() {
+	select {
+	case w.refreshCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop stops the watcher and removes the runtime from the access list.
+//
+// This method must be called at most once.
+func (w *rtNodeWatcher) stop() {
+	close(w.stopCh)
+}
+
 func (w *rtNodeWatcher) watch(ctx context.Context) {
 	// Subscribe to epoch transitions to regularly update the runtime access list.
 	epoCh, epoSub, err := w.consensus.Beacon().WatchLatestEpoch(ctx)
@@ -306,62 +371,90 @@ func (w *rtNodeWatcher) watch(ctx context.Context) {
 	}
 	defer nodeSub.Close()
 
-	// And populate the list of observer nodes with the currently known set of them.
+	// fetchObserverNodes populates the list of observer nodes with the currently known set of them.
+	fetchObserverNodes := func() error {
+		nodes, err := w.consensus.Registry().GetNodes(ctx, consensus.HeightLatest)
+		if err != nil {
+			return err
+		}
+		clear(observerNodes)
+		for _, nd := range nodes {
+			if nd.HasRoles(node.RoleObserver) && nd.HasRuntime(w.runtimeID) {
+				observerNodes[nd.ID] = nd
+			}
+		}
+		return nil
+	}
+
+	// fetchComputeNodes populates the list of compute nodes with the current executor
+	// committee members.
+	fetchComputeNodes := func() {
+		// Old members need to be cleared out of the ACL even in case of errors.
+		clear(computeNodes)
+
+		// Get executor committee members.
+		cms, err := w.consensus.Scheduler().GetCommittees(ctx, &scheduler.GetCommitteesRequest{
+			Height:    consensus.HeightLatest,
+			RuntimeID: w.runtimeID,
+		})
+		if err != nil {
+			w.logger.Error("failed to fetch runtime committee",
+				"err", err,
+			)
+			return
+		}
+
+		for _, cm := range cms {
+			if cm.Kind != scheduler.KindComputeExecutor {
+				continue
+			}
+
+			for _, member := range cm.Members {
+				nd, err := w.consensus.Registry().GetNode(ctx, &registry.IDQuery{
+					ID:     member.PublicKey,
+					Height: consensus.HeightLatest,
+				})
+				if err != nil {
+					w.logger.Error("failed to fetch node descriptor for committee member",
+						"err", err,
+						"member", member.PublicKey,
+					)
+					continue
+				}
+				computeNodes[nd.ID] = nd
+			}
+		}
+	}
+
 	// epoCh will get the current epoch immediately, so no need to populate compute nodes specially.
-	nodes, err := w.consensus.Registry().GetNodes(ctx, consensus.HeightLatest)
-	if err != nil {
+	if err = fetchObserverNodes(); err != nil {
 		w.logger.Error("failed to fetch list of nodes from the registry",
 			"err", err,
 		)
 		return
 	}
-	for _, nd := range nodes {
-		if nd.HasRoles(node.RoleObserver) && nd.HasRuntime(w.runtimeID) {
-			observerNodes[nd.ID] = nd
-		}
-	}
 	w.accessList.UpdateNodes(w.runtimeID, getFlatList())
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-w.stopCh:
+			w.logger.Info("removing runtime from the access list")
+
+			w.accessList.Update(w.runtimeID, nil)
+			return
 		case <-epoCh:
-			// Old members need to be cleared out of the ACL even in case of errors.
-			clear(computeNodes)
-
-			// Get executor committee members.
-			cms, err := w.consensus.Scheduler().GetCommittees(ctx, &scheduler.GetCommitteesRequest{
-				Height:    consensus.HeightLatest,
-				RuntimeID: w.runtimeID,
-			})
-			if err != nil {
-				w.logger.Error("failed to fetch runtime committee",
+			fetchComputeNodes()
+		case <-w.refreshCh:
+			w.logger.Info("rebuilding runtime access list")
+
+			if err := fetchObserverNodes(); err != nil {
+				w.logger.Error("failed to fetch list of nodes from the registry",
 					"err", err,
 				)
-				break
-			}
-
-			for _, cm := range cms {
-				if cm.Kind != scheduler.KindComputeExecutor {
-					continue
-				}
-
-				for _, member := range cm.Members {
-					nd, err := w.consensus.Registry().GetNode(ctx, &registry.IDQuery{
-						ID:     member.PublicKey,
-						Height: consensus.HeightLatest,
-					})
-					if err != nil {
-						w.logger.Error("failed to fetch node descriptor for committee member",
-							"err", err,
-							"member", member.PublicKey,
-						)
-						continue
-					}
-					computeNodes[nd.ID] = nd
-				}
 			}
+			fetchComputeNodes()
 		case ne := <-nodeCh:
 			switch ne.IsRegistration {
 			case true:
@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core"
+
 	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -16,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -33,6 +36,10 @@ import (
 
 const (
 	rpcCallTimeout = 2 * time.Second
+
+	// maxAccessListUpdates is the maximum number of access list updates reported
+	// in the worker status.
+	maxAccessListUpdates = 10
 )
 
 // Ensure the key manager worker implements the BackgroundService interface.
@@ -71,6 +78,9 @@ type Worker struct { // nolint: maligned
 	peerMap    *PeerMap
 	accessList *AccessList
 
+	accessListUpdates []workerKeymanager.AccessListUpdate // Guarded by mutex.
+	lastCacheFlush    time.Time                           // Guarded by mutex.
+
 	commonWorker *workerCommon.Worker
 	roleProvider registration.RoleProvider
 	backend      api.Backend
@@ -90,7 +100,7 @@ func (w *Worker) Start() error {
 		return nil
 	}
 
-	go w.worker()
+	recovery.Go("worker/keymanager", w.worker)
 
 	return nil
 }
@@ -125,6 +135,41 @@ func (w *Worker) Initialized() <-chan struct{} {
 	return w.initCh
 }
 
+// watchAccessListUpdates records access list updates so that they can be reported
+// in the worker status.
+func (w *Worker) watchAccessListUpdates(ctx context.Context, ch <-chan *workerKeymanager.AccessListUpdate) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-ch:
+			w.recordAccessListUpdate(update)
+		}
+	}
+}
+
+// recordAccessListUpdate records the given access list update, keeping only the most
+// recent ones.
+func (w *Worker) recordAccessListUpdate(update *workerKeymanager.AccessListUpdate) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.accessListUpdates = append(w.accessListUpdates, *update)
+	if n := len(w.accessListUpdates); n > maxAccessListUpdates {
+		w.accessListUpdates = slices.Clone(w.accessListUpdates[n-maxAccessListUpdates:])
+	}
+}
+
+// refreshAccessLists requests the access lists to be rebuilt from the latest consensus state.
+func (w *Worker) refreshAccessLists() {
+	if w.kmNodeWatcher != nil {
+		w.kmNodeWatcher.refresh()
+	}
+	if w.kmRuntimeWatcher != nil {
+		w.kmRuntimeWatcher.refresh()
+	}
+}
+
 func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.Kind) ([]byte, error) {
 	// Peek into the frame/request data to extract the method.
 	var method string
@@ -161,7 +206,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 			return ctrl.Connect(ctx, peerID)
 		}
 		if !slices.ContainsFunc(w.accessControllers, fn) {
-			return nil, fmt.Errorf("not authorized to connect")
+			return nil, w.accessDenied(peerID, method, fmt.Errorf("not authorized to connect"))
 		}
 	default:
 		ctrl, ok := w.accessControllersByMethod[method]
@@ -169,7 +214,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 			return nil, fmt.Errorf("unsupported RPC method")
 		}
 		if err := ctrl.Authorize(ctx, method, kind, peerID); err != nil {
-			return nil, fmt.Errorf("not authorized: %w", err)
+			return nil, w.accessDenied(peerID, method, err)
 		}
 	}
 
@@ -189,8 +234,15 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 		return nil, fmt.Errorf("not initialized")
 	}
 
+	methodLabel := methodLabel(method)
+	clientLabel := w.clientRuntimeLabel(peerID)
+	enclaveRPCAuthorizedCount.WithLabelValues(w.runtimeLabel, methodLabel, clientLabel).Inc()
+
+	start := time.Now()
 	response, err := rt.Call(ctx, req)
+	enclaveRPCLatency.WithLabelValues(w.runtimeLabel, methodLabel).Observe(time.Since(start).Seconds())
 	if err != nil {
+		enclaveRPCFailures.WithLabelValues(w.runtimeLabel, methodLabel, clientLabel).Inc()
 		w.logger.Error("failed to dispatch RPC call to runtime",
 			"err", err,
 			"kind", kind,
@@ -209,6 +261,21 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 	return resp.Response, nil
 }
 
+// accessDenied records that an enclave RPC request has been denied and returns the error
+// that should be reported to the peer.
+func (w *Worker) accessDenied(peerID core.PeerID, method string, err error) error {
+	accessDeniedCount.WithLabelValues(w.runtimeLabel, methodLabel(method), w.clientRuntimeLabel(peerID)).Inc()
+
+	w.logger.Warn("enclave RPC request denied",
+		"err", err,
+		"peer_id", peerID,
+		"method", method,
+		logging.LogEvent, workerKeymanager.LogEventAccessDenied,
+	)
+
+	return fmt.Errorf("%w: %w", workerKeymanager.ErrAccessDenied, err)
+}
+
 func (w *Worker) callEnclaveLocal(ctx context.Context, method string, args interface{}, rsp interface{}) error {
 	rt := w.GetHostedRuntime()
 	if rt == nil {
@@ -265,8 +332,8 @@ func (w *Worker) runtimeEncryptionKeys(nodes []signature.PublicKey) (map[x25519.
 		}
 
 		idx := slices.IndexFunc(n.Runtimes, func(rt *node.Runtime) bool {
-			// Skipping version check as key managers are running exactly one
-			// version of the runtime.
+			// Skipping version check as key managers register exactly one
+			// version of the runtime, the active one.
 			return rt.ID.Equal(&w.runtimeID)
 		})
 		if idx == -1 {
@@ -403,22 +470,46 @@ func (w *Worker) worker() {
 	hrtNotifier.Start()
 	defer hrtNotifier.Stop()
 
-	// Key managers always need to use the enclave version given to them in the bundle
-	// as they need to make sure that replication is possible during upgrades.
-	activeVersion := w.runtime.HostVersions()[0] // Init made sure we have exactly one.
-	if err = w.SetHostedRuntimeVersion(activeVersion, nil); err != nil {
-		w.logger.Error("failed to activate key manager runtime version",
+	// Fail fast in case the node would never be able to switch between hosted versions.
+	if err = w.verifyHostedRuntimeVersions(w.ctx); err != nil {
+		w.logger.Error("invalid key manager runtime version configuration",
+			"err", err,
+		)
+		return
+	}
+
+	// Activate the runtime version that should be active at the current epoch.
+	rt, err := w.runtime.RegistryDescriptor(w.ctx)
+	if err != nil {
+		w.logger.Error("failed to fetch key manager runtime descriptor",
 			"err", err,
-			"version", activeVersion,
 		)
 		return
 	}
+	if err = w.updateHostedRuntimeVersion(w.ctx, rt); err != nil {
+		// Not fatal, as a version can become active later on, e.g. once its deployment
+		// becomes active. The version watcher will retry on epoch transitions and runtime
+		// descriptor updates.
+		w.logger.Error("failed to activate key manager runtime version",
+			"err", err,
+		)
+	}
 
 	// Always wait for the background watchers and workers to finish.
 	var wg sync.WaitGroup
 	defer wg.Wait()
 
-	wg.Add(5)
+	// Subscribe to access list updates before the watchers start populating the access lists.
+	aclCh, aclSub := w.accessList.WatchUpdates()
+	defer aclSub.Close()
+
+	wg.Add(7)
+
+	// Switch runtime versions at their activation epochs.
+	go func() {
+		defer wg.Done()
+		w.watchHostedRuntimeVersion(w.ctx, rt)
+	}()
 
 	// Need to explicitly watch for updates related to the key manager runtime
 	// itself.
@@ -434,6 +525,12 @@ func (w *Worker) worker() {
 		w.kmRuntimeWatcher.watch(w.ctx)
 	}()
 
+	// Keep track of the most recent access list updates.
+	go func() {
+		defer wg.Done()
+		w.watchAccessListUpdates(w.ctx, aclCh)
+	}()
+
 	// Serve master and ephemeral secrets.
 	go func() {
 		defer wg.Done()
@@ -0,0 +1,52 @@
+package keymanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	workerKeymanager "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
+)
+
+func TestRefreshAccessLists(t *testing.T) {
+	require := require.New(t)
+
+	kmRuntimeID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: km runtime"), 0)
+	rtID := common.NewTestNamespaceFromSeed([]byte("worker/keymanager: client runtime"), 0)
+	accessList := NewAccessList()
+
+	w := &Worker{}
+	w.refreshAccessLists() // Should not panic before the watchers are created.
+
+	w.kmNodeWatcher = newKmNodeWatcher(kmRuntimeID, nil, NewPeerMap(), accessList, nil)
+	w.kmRuntimeWatcher = newKmRuntimeWatcher(kmRuntimeID, nil, accessList)
+	rnw := newRtNodeWatcher(rtID, nil, accessList)
+	w.kmRuntimeWatcher.clientRuntimes[rtID] = rnw
+
+	// Rebuilding the access lists should refresh all watchers.
+	w.refreshAccessLists()
+	w.refreshAccessLists() // Pending refreshes should be coalesced.
+	require.Len(w.kmNodeWatcher.refreshCh, 1, "key manager node watcher should be refreshed")
+	require.Len(rnw.refreshCh, 1, "runtime node watcher should be refreshed")
+}
+
+func TestRecordAccessListUpdate(t *testing.T) {
+	require := require.New(t)
+
+	runtimeID := func(i int) common.Namespace {
+		return common.NewTestNamespaceFromSeed([]byte(fmt.Sprintf("worker/keymanager: acl runtime %d", i)), 0)
+	}
+
+	w := &Worker{}
+	for i := 0; i < maxAccessListUpdates+5; i++ {
+		w.recordAccessListUpdate(&workerKeymanager.AccessListUpdate{
+			RuntimeID: runtimeID(i),
+		})
+	}
+
+	require.Len(w.accessListUpdates, maxAccessListUpdates, "only the most recent updates should be kept")
+	require.Equal(runtimeID(5), w.accessListUpdates[0].RuntimeID, "the oldest updates should be dropped")
+	require.Equal(runtimeID(maxAccessListUpdates+4), w.accessListUpdates[maxAccessListUpdates-1].RuntimeID)
+}
@@ -15,6 +15,11 @@ type Config struct {
 
 	// EntityID to use as the node owner in registrations (public key).
 	EntityID string `yaml:"entity_id"`
+
+	// Features are the features declared in node registrations.
+	//
+	// Declared features must be enabled by the registry consensus parameters.
+	Features []string `yaml:"features,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -186,6 +186,7 @@ type Worker struct { // nolint: maligned
 
 	entityID           signature.PublicKey
 	registrationSigner signature.Signer
+	features           node.FeaturesMask
 
 	sentryAddresses []node.TLSAddress
 
@@ -857,6 +858,7 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 			ID: w.identity.VRFSigner.Public(),
 		},
 		SoftwareVersion: node.SoftwareVersion(version.SoftwareVersion),
+		Features:        w.features,
 	}
 
 	// Update the registration status on successful or failed registration.
@@ -1055,6 +1057,18 @@ func GetRegistrationSigner(identity *identity.Identity) (signature.PublicKey, si
 	}
 }
 
+func getNodeFeatures() (node.FeaturesMask, error) {
+	var features node.FeaturesMask
+	for _, name := range config.GlobalConfig.Registration.Features {
+		var f node.FeaturesMask
+		if err := f.UnmarshalText([]byte(name)); err != nil {
+			return node.FeatureEmpty, fmt.Errorf("worker/registration: malformed feature: %w", err)
+		}
+		features |= f
+	}
+	return features, nil
+}
+
 // New constructs a new worker node registration service.
 func New(
 	beacon beacon.Backend,
@@ -1076,6 +1090,11 @@ func New(
 		return nil, err
 	}
 
+	features, err := getNodeFeatures()
+	if err != nil {
+		return nil, err
+	}
+
 	var storedDeregister bool
 	err = serviceStore.GetCBOR(deregistrationRequestStoreKey, &storedDeregister)
 	if err != nil && err != persistent.ErrNotFound {
@@ -1087,6 +1106,7 @@ func New(
 		store:              serviceStore,
 		delegate:           delegate,
 		entityID:           entityID,
+		features:           features,
 		sentryAddresses:    workerCommonCfg.SentryAddresses,
 		registrationSigner: registrationSigner,
 		runtimeRegistry:    runtimeRegistry,
@@ -1,6 +1,12 @@
 // Package config implements global configuration options.
 package config
 
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
 // Config is the sentry worker configuration structure.
 type Config struct {
 	// Enable Sentry worker.
@@ -8,6 +14,9 @@ type Config struct {
 	Enabled bool `yaml:"enabled"`
 
 	Control ControlConfig `yaml:"control,omitempty"`
+
+	// Additional control endpoints, each serving a separate group of upstream nodes.
+	ControlEndpoints []ControlEndpointConfig `yaml:"control_endpoints,omitempty"`
 }
 
 // ControlConfig is the sentry worker control configuration structure.
@@ -19,8 +28,61 @@ type ControlConfig struct {
 	AuthorizedPubkeys []string `yaml:"authorized_pubkeys"`
 }
 
+// ControlEndpointConfig is the configuration structure of an additional sentry worker control
+// endpoint serving a group of upstream nodes.
+type ControlEndpointConfig struct {
+	// Name of the upstream group served by the endpoint.
+	Name string `yaml:"name"`
+
+	// Endpoint's gRPC server port.
+	Port uint16 `yaml:"port"`
+
+	// Path to the endpoint's TLS certificate and private key. If not set, the node's TLS
+	// certificate is used.
+	TLSCertFile string `yaml:"tls_cert_file,omitempty"`
+	TLSKeyFile  string `yaml:"tls_key_file,omitempty"`
+
+	// Public keys of upstream nodes in the group that are allowed to connect to the endpoint.
+	AuthorizedPubkeys []string `yaml:"authorized_pubkeys"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	ports := map[uint16]bool{
+		c.Control.Port: true,
+	}
+	names := make(map[string]bool)
+	for _, ep := range c.ControlEndpoints {
+		if ep.Name == "" {
+			return fmt.Errorf("control endpoint name must be set")
+		}
+		if names[ep.Name] {
+			return fmt.Errorf("duplicate control endpoint name: %s", ep.Name)
+		}
+		names[ep.Name] = true
+
+		if ep.Port == 0 {
+			return fmt.Errorf("control endpoint %s: port must be set", ep.Name)
+		}
+		if ports[ep.Port] {
+			return fmt.Errorf("control endpoint %s: port %d already in use", ep.Name, ep.Port)
+		}
+		ports[ep.Port] = true
+
+		if (ep.TLSCertFile == "") != (ep.TLSKeyFile == "") {
+			return fmt.Errorf("control endpoint %s: both tls_cert_file and tls_key_file must be set", ep.Name)
+		}
+
+		if len(ep.AuthorizedPubkeys) == 0 {
+			return fmt.Errorf("control endpoint %s: no authorized public keys", ep.Name)
+		}
+		for _, pubkey := range ep.AuthorizedPubkeys {
+			var pk signature.PublicKey
+			if err := pk.UnmarshalText([]byte(pubkey)); err != nil {
+				return fmt.Errorf("control endpoint %s: malformed authorized public key %s: %w", ep.Name, pubkey, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -32,5 +94,6 @@ func DefaultConfig() Config {
 			Port:              9009,
 			AuthorizedPubkeys: []string{},
 		},
+		ControlEndpoints: []ControlEndpointConfig{},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -24,7 +25,7 @@ type Worker struct {
 
 	backend api.Backend
 
-	grpcServer *grpc.Server
+	grpcServers []*grpc.Server
 
 	quitCh chan struct{}
 
@@ -44,20 +45,24 @@ func (w *Worker) Start() error {
 		return nil
 	}
 
-	// Start the sentry gRPC server.
-	if err := w.grpcServer.Start(); err != nil {
-		w.logger.Error("failed to start sentry gRPC server",
-			"err", err,
-		)
-		return err
+	// Start the sentry gRPC servers.
+	for _, grpcServer := range w.grpcServers {
+		if err := grpcServer.Start(); err != nil {
+			w.logger.Error("failed to start sentry gRPC server",
+				"err", err,
+			)
+			return err
+		}
 	}
 
-	// Stop the gRPC server when the worker quits.
+	// Stop the gRPC servers when the worker quits.
 	go func() {
 		<-w.quitCh
 
-		w.logger.Debug("sentry gRPC worker quit, stopping sentry gRPC server")
-		w.grpcServer.Stop()
+		w.logger.Debug("sentry gRPC worker quit, stopping sentry gRPC servers")
+		for _, grpcServer := range w.grpcServers {
+			grpcServer.Stop()
+		}
 	}()
 
 	return nil
@@ -89,11 +94,40 @@ func (w *Worker) Cleanup() {
 		return
 	}
 
-	w.grpcServer.Cleanup()
+	for _, grpcServer := range w.grpcServers {
+		grpcServer.Cleanup()
+	}
+}
+
+// newControlServer creates a new sentry control gRPC server that only accepts connections from
+// upstream nodes with the given public keys.
+func (w *Worker) newControlServer(name string, port uint16, id *identity.Identity, authorizedPubkeys []string) error {
+	peerPubkeyAuth := auth.NewPeerPubkeyAuthenticator()
+	for _, pubkey := range authorizedPubkeys {
+		var pk signature.PublicKey
+		if err := pk.UnmarshalText([]byte(pubkey)); err != nil {
+			return fmt.Errorf("worker/sentry: failed unmarshalling upstream public key: %s: %w", pubkey, err)
+		}
+		peerPubkeyAuth.AllowPeerPublicKey(pk)
+	}
+	grpcServer, err := grpc.NewServer(&grpc.ServerConfig{
+		Name:     name,
+		Port:     port,
+		Identity: id,
+		AuthFunc: peerPubkeyAuth.AuthFunc,
+	})
+	if err != nil {
+		return fmt.Errorf("worker/sentry: failed to create a new gRPC server: %w", err)
+	}
+	// Initialize and register the sentry gRPC service.
+	api.RegisterService(grpcServer.Server(), w.backend)
+
+	w.grpcServers = append(w.grpcServers, grpcServer)
+	return nil
 }
 
 // New creates a new sentry worker.
-func New(backend api.Backend, identity *identity.Identity) (*Worker, error) {
+func New(backend api.Backend, id *identity.Identity) (*Worker, error) {
 	w := &Worker{
 		enabled: Enabled(),
 		backend: backend,
@@ -101,27 +135,35 @@ func New(backend api.Backend, identity *identity.Identity) (*Worker, error) {
 		logger:  logging.GetLogger("worker/sentry"),
 	}
 
-	if w.enabled {
-		peerPubkeyAuth := auth.NewPeerPubkeyAuthenticator()
-		for _, pubkey := range config.GlobalConfig.Sentry.Control.AuthorizedPubkeys {
-			var pk signature.PublicKey
-			if err := pk.UnmarshalText([]byte(pubkey)); err != nil {
-				return nil, fmt.Errorf("worker/sentry: failed unmarshalling upstream public key: %s: %w", pubkey, err)
+	if !w.enabled {
+		return w, nil
+	}
+
+	cfg := config.GlobalConfig.Sentry
+	if err := w.newControlServer("sentry", cfg.Control.Port, id, cfg.Control.AuthorizedPubkeys); err != nil {
+		return nil, err
+	}
+
+	// Each additional control endpoint serves a separate group of upstream nodes, optionally using
+	// its own TLS certificate.
+	for _, ep := range cfg.ControlEndpoints {
+		epIdentity := id
+		if ep.TLSCertFile != "" {
+			cert, err := tls.Load(ep.TLSCertFile, ep.TLSKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("worker/sentry: failed to load TLS certificate for control endpoint %s: %w", ep.Name, err)
 			}
-			peerPubkeyAuth.AllowPeerPublicKey(pk)
+			epIdentity = identity.WithTLSCertificate(cert)
 		}
-		grpcServer, err := grpc.NewServer(&grpc.ServerConfig{
-			Name:     "sentry",
-			Port:     config.GlobalConfig.Sentry.Control.Port,
-			Identity: identity,
-			AuthFunc: peerPubkeyAuth.AuthFunc,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("worker/sentry: failed to create a new gRPC server: %w", err)
+
+		if err := w.newControlServer("sentry-"+ep.Name, ep.Port, epIdentity, ep.AuthorizedPubkeys); err != nil {
+			return nil, err
 		}
-		w.grpcServer = grpcServer
-		// Initialize and register the sentry gRPC service.
-		api.RegisterService(w.grpcServer.Server(), backend)
+		w.logger.Info("configured additional control endpoint",
+			"name", ep.Name,
+			"port", ep.Port,
+			"num_upstreams", len(ep.AuthorizedPubkeys),
+		)
 	}
 
 	return w, nil
@@ -10,14 +10,18 @@ import (
 	"time"
 
 	"github.com/eapache/channels"
+	"github.com/libp2p/go-libp2p/core"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/recovery"
 	"github.com/oasisprotocol/oasis-core/go/common/workerpool"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	commonFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	registryApi "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -261,13 +265,36 @@ func NewNode(
 		node:   n,
 	})
 
+	// Prepare the request queue shared by all storage services.
+	var queue *rpc.FairQueue
+	if queueCfg := config.GlobalConfig.Storage.RequestQueue; queueCfg.Enabled {
+		peerWeights := make(map[core.PeerID]int, len(queueCfg.PeerWeights))
+		for b64pk, weight := range queueCfg.PeerWeights {
+			var pk signature.PublicKey
+			if err = pk.UnmarshalText([]byte(b64pk)); err != nil {
+				return nil, fmt.Errorf("invalid request queue peer `%s`: %w", b64pk, err)
+			}
+			var peerID core.PeerID
+			if peerID, err = p2pAPI.PublicKeyToPeerID(pk); err != nil {
+				return nil, fmt.Errorf("invalid request queue peer `%s`: %w", b64pk, err)
+			}
+			peerWeights[peerID] = int(weight)
+		}
+
+		queue = rpc.NewFairQueue(rpc.FairQueueConfig{
+			MaxConcurrent:    int(queueCfg.MaxConcurrent),
+			MaxQueuedPerPeer: int(queueCfg.MaxQueuedPerPeer),
+			PeerWeights:      peerWeights,
+		})
+	}
+
 	// Register storage sync service.
-	commonNode.P2P.RegisterProtocolServer(storageSync.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
+	commonNode.P2P.RegisterProtocolServer(storageSync.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage, queue))
 	n.storageSync = storageSync.NewClient(commonNode.P2P, commonNode.ChainContext, commonNode.Runtime.ID())
 
 	// Register storage pub service if configured.
 	if rpcRoleProvider != nil {
-		commonNode.P2P.RegisterProtocolServer(storagePub.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
+		commonNode.P2P.RegisterProtocolServer(storagePub.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage, queue))
 	}
 
 	return n, nil
@@ -283,7 +310,7 @@ func (n *Node) Name() string {
 // Start causes the worker to start responding to CometBFT new block events.
 func (n *Node) Start() error {
 	go n.watchQuit()
-	go n.worker()
+	recovery.Go("worker/storage/committee", n.worker)
 	if config.GlobalConfig.Storage.Checkpointer.Enabled {
 		go n.consensusCheckpointSyncer()
 	}
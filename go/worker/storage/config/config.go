@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
 )
 
@@ -23,6 +25,12 @@ type Config struct {
 
 	// Storage checkpointer configuration.
 	Checkpointer CheckpointerConfig `yaml:"checkpointer,omitempty"`
+
+	// Storage RPC request queue configuration.
+	RequestQueue RequestQueueConfig `yaml:"request_queue,omitempty"`
+
+	// Storage encryption configuration.
+	Encryption EncryptionConfig `yaml:"encryption,omitempty"`
 }
 
 // CheckpointerConfig is the storage worker checkpointer configuration structure.
@@ -33,8 +41,60 @@ type CheckpointerConfig struct {
 	CheckInterval time.Duration `yaml:"check_interval"`
 }
 
+// RequestQueueConfig is the storage RPC request queue configuration structure.
+//
+// Requests from remote peers are queued per peer and admitted in a fair
+// manner so that a single peer cannot monopolize the storage node.
+type RequestQueueConfig struct {
+	// Enable storage RPC request queuing.
+	Enabled bool `yaml:"enabled"`
+	// Maximum number of storage RPC requests served concurrently.
+	MaxConcurrent uint `yaml:"max_concurrent"`
+	// Maximum number of storage RPC requests queued per peer.
+	MaxQueuedPerPeer uint `yaml:"max_queued_per_peer"`
+	// Weights of specific peers, keyed by their base64-encoded public keys. A
+	// peer with weight N gets up to N requests admitted in each round (and may
+	// queue N times as many requests), while other peers get one.
+	PeerWeights map[string]uint `yaml:"peer_weights,omitempty"`
+}
+
+// DefaultEncryptionKeyFile is the default name of the storage encryption master key file in the
+// node's data directory.
+const DefaultEncryptionKeyFile = "storage_encryption.key"
+
+// EncryptionConfig is the storage encryption configuration structure.
+//
+// When enabled, runtime storage databases are encrypted at rest using per-runtime keys derived
+// from a node-local master key.
+type EncryptionConfig struct {
+	// Enable encryption of runtime storage databases at rest.
+	Enabled bool `yaml:"enabled"`
+	// Path to the file holding the master key, relative to the data directory unless absolute.
+	// Defaults to DefaultEncryptionKeyFile. The key is never generated implicitly, see
+	// `oasis-node storage gen-encryption-key`. Only keys in the data directory are included
+	// in node backups.
+	KeyFile string `yaml:"key_file,omitempty"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if c.RequestQueue.Enabled {
+		if c.RequestQueue.MaxConcurrent == 0 {
+			return fmt.Errorf("request_queue.max_concurrent must be greater than zero")
+		}
+		if c.RequestQueue.MaxQueuedPerPeer == 0 {
+			return fmt.Errorf("request_queue.max_queued_per_peer must be greater than zero")
+		}
+		for b64pk, weight := range c.RequestQueue.PeerWeights {
+			var pk signature.PublicKey
+			if err := pk.UnmarshalText([]byte(b64pk)); err != nil {
+				return fmt.Errorf("request_queue.peer_weights: `%s` is not a public key: %w", b64pk, err)
+			}
+			if weight == 0 {
+				return fmt.Errorf("request_queue.peer_weights: weight of `%s` must be greater than zero", b64pk)
+			}
+		}
+	}
 	if c.Backend != "auto" {
 		_, err := db.GetBackendByName(c.Backend)
 		return err
@@ -54,5 +114,10 @@ func DefaultConfig() Config {
 			Enabled:       false,
 			CheckInterval: 1 * time.Minute,
 		},
+		RequestQueue: RequestQueueConfig{
+			Enabled:          false,
+			MaxConcurrent:    16,
+			MaxQueuedPerPeer: 8,
+		},
 	}
 }
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/tuplehash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	storageConfig "github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+const (
+	// encryptionKeySize is the size of the master and derived encryption keys (AES-256).
+	encryptionKeySize = 32
+
+	// encryptionKeyContext is the domain separation context used when deriving per-runtime
+	// encryption keys.
+	encryptionKeyContext = "oasis-core/storage: encryption key"
+)
+
+// ErrEncryptionMasterKeyNotFound is the error returned when the storage encryption master key
+// does not exist.
+var ErrEncryptionMasterKeyNotFound = errors.New("storage: encryption master key not found")
+
+// EncryptionMasterKeyPath returns the path of the storage encryption master key file.
+//
+// Relative paths are resolved against the node's data directory.
+func EncryptionMasterKeyPath() string {
+	path := config.GlobalConfig.Storage.Encryption.KeyFile
+	if path == "" {
+		path = storageConfig.DefaultEncryptionKeyFile
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(config.GlobalConfig.Common.DataDir, path)
+}
+
+// GenerateEncryptionMasterKey generates a new storage encryption master key.
+//
+// An existing key is never overwritten. Since a new key cannot be used to open any existing
+// runtime node databases, generation is also refused if any exist in the data directory,
+// unless allowPlaintext is set and all of them are unencrypted (e.g., when they are about
+// to be encrypted).
+func GenerateEncryptionMasterKey(dataDir string, allowPlaintext bool) error {
+	dirs, err := nodeDBDirs(dataDir)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if !allowPlaintext {
+			return fmt.Errorf("storage: refusing to generate encryption master key, node database '%s' already exists", dir)
+		}
+		encrypted, err := IsEncryptedNodeDB(dir)
+		if err != nil {
+			return err
+		}
+		if encrypted {
+			return fmt.Errorf("storage: refusing to generate encryption master key, node database '%s' is encrypted with another key", dir)
+		}
+	}
+
+	return writeEncryptionMasterKey(EncryptionMasterKeyPath())
+}
+
+// EncryptionKey returns the encryption key for the runtime node databases of the given
+// namespace, or nil if storage encryption is disabled.
+func EncryptionKey(namespace common.Namespace) ([]byte, error) {
+	if !config.GlobalConfig.Storage.Encryption.Enabled {
+		return nil, nil
+	}
+	masterKey, err := loadEncryptionMasterKey(EncryptionMasterKeyPath())
+	if err != nil {
+		return nil, err
+	}
+	return deriveEncryptionKey(masterKey, namespace), nil
+}
+
+// NodeDBEncryptionKey returns the key needed to open the existing node database of the given
+// namespace in the given directory, or nil if the database is not encrypted.
+func NodeDBEncryptionKey(dir string, namespace common.Namespace) ([]byte, error) {
+	encrypted, err := IsEncryptedNodeDB(dir)
+	if err != nil || !encrypted {
+		return nil, err
+	}
+	masterKey, err := loadEncryptionMasterKey(EncryptionMasterKeyPath())
+	if err != nil {
+		return nil, err
+	}
+	return deriveEncryptionKey(masterKey, namespace), nil
+}
+
+// IsEncryptedNodeDB returns true iff the node database in the given directory is encrypted.
+func IsEncryptedNodeDB(dir string) (bool, error) {
+	_, err := badger.OpenKeyRegistry(badger.KeyRegistryOptions{
+		Dir:      dir,
+		ReadOnly: true,
+	})
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, badger.ErrEncryptionKeyMismatch):
+		return true, nil
+	default:
+		return false, fmt.Errorf("storage: failed to open key registry of '%s': %w", dir, err)
+	}
+}
+
+// RekeyNodeDB re-encrypts the node database in the given directory so that it can be opened
+// with the key of the new namespace instead of the old one. Unencrypted databases are left
+// unchanged.
+func RekeyNodeDB(dir string, oldNamespace, newNamespace common.Namespace) error {
+	oldKey, err := NodeDBEncryptionKey(dir, oldNamespace)
+	if err != nil || oldKey == nil {
+		return err
+	}
+	masterKey, err := loadEncryptionMasterKey(EncryptionMasterKeyPath())
+	if err != nil {
+		return err
+	}
+
+	// Only the data keys are encrypted with the given key, so rewriting the key registry
+	// is enough.
+	opts := badger.KeyRegistryOptions{
+		Dir:           dir,
+		ReadOnly:      true,
+		EncryptionKey: oldKey,
+	}
+	kr, err := badger.OpenKeyRegistry(opts)
+	if err != nil {
+		return fmt.Errorf("storage: failed to open key registry of '%s': %w", dir, err)
+	}
+	opts.EncryptionKey = deriveEncryptionKey(masterKey, newNamespace)
+	if err = badger.WriteKeyRegistry(kr, opts); err != nil {
+		return fmt.Errorf("storage: failed to write key registry of '%s': %w", dir, err)
+	}
+	return nil
+}
+
+// checkNodeDBEncryption makes sure that the existing node databases in the given runtime
+// directory match the storage encryption configuration.
+func checkNodeDBEncryption(runtimeDir string, enabled bool) error {
+	for _, factory := range mkvsDB.Backends {
+		dir := GetLocalBackendDBDir(runtimeDir, factory.Name())
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+		encrypted, err := IsEncryptedNodeDB(dir)
+		if err != nil {
+			return err
+		}
+		switch {
+		case enabled && !encrypted:
+			return fmt.Errorf("storage: node database '%s' is not encrypted (encrypt it with `oasis-node storage encrypt`)", dir)
+		case !enabled && encrypted:
+			return fmt.Errorf("storage: node database '%s' is encrypted but storage encryption is disabled", dir)
+		}
+	}
+	return nil
+}
+
+// nodeDBDirs returns the directories of all runtime node databases in the given data directory.
+func nodeDBDirs(dataDir string) ([]string, error) {
+	pattern := filepath.Join(dataDir, registry.RuntimesDir, "*", database.DefaultFileName("*"))
+	dirs, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("storage: failed to find node databases: %w", err)
+	}
+	return dirs, nil
+}
+
+// loadEncryptionMasterKey loads the storage encryption master key from the given file.
+func loadEncryptionMasterKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("%w: '%s' (generate it with `oasis-node storage gen-encryption-key`)", ErrEncryptionMasterKeyNotFound, path)
+	default:
+		return nil, fmt.Errorf("storage: failed to read encryption master key: %w", err)
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("storage: malformed encryption master key (expected %d bytes, got %d)", encryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// writeEncryptionMasterKey generates a new random storage encryption master key and writes it
+// to the given file, which must not exist.
+func writeEncryptionMasterKey(path string) error {
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("storage: failed to generate encryption master key: %w", err)
+	}
+
+	// Make sure to never overwrite an existing key.
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("storage: encryption master key '%s' already exists", path)
+		}
+		return fmt.Errorf("storage: failed to create encryption master key: %w", err)
+	}
+	defer f.Close()
+	if _, err = f.Write(key); err != nil {
+		return fmt.Errorf("storage: failed to write encryption master key: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("storage: failed to write encryption master key: %w", err)
+	}
+	return nil
+}
+
+// deriveEncryptionKey derives the storage encryption key for the given namespace.
+func deriveEncryptionKey(masterKey []byte, namespace common.Namespace) []byte {
+	h := tuplehash.New256(encryptionKeySize, []byte(encryptionKeyContext))
+	_, _ = h.Write(masterKey)
+	_, _ = h.Write(namespace[:])
+	return h.Sum(nil)
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+)
+
+func TestEncryptionKeys(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "storage.key")
+
+	// Master keys should never be generated implicitly.
+	_, err := loadEncryptionMasterKey(path)
+	require.ErrorIs(err, ErrEncryptionMasterKeyNotFound, "loadEncryptionMasterKey should fail for missing keys")
+
+	err = writeEncryptionMasterKey(path)
+	require.NoError(err, "writeEncryptionMasterKey")
+	masterKey, err := loadEncryptionMasterKey(path)
+	require.NoError(err, "loadEncryptionMasterKey")
+	require.Len(masterKey, encryptionKeySize)
+
+	// Existing keys should never be overwritten.
+	err = writeEncryptionMasterKey(path)
+	require.Error(err, "writeEncryptionMasterKey should fail for existing keys")
+	loaded, err := loadEncryptionMasterKey(path)
+	require.NoError(err, "loadEncryptionMasterKey")
+	require.Equal(masterKey, loaded, "existing master key should be kept")
+
+	// Malformed keys should be rejected.
+	err = os.WriteFile(path, []byte("short"), 0o600)
+	require.NoError(err, "WriteFile")
+	_, err = loadEncryptionMasterKey(path)
+	require.Error(err, "loadEncryptionMasterKey should fail for malformed keys")
+
+	// Keys should be unique per namespace.
+	var ns1, ns2 common.Namespace
+	ns2[0] = 0x80
+	key1 := deriveEncryptionKey(masterKey, ns1)
+	key2 := deriveEncryptionKey(masterKey, ns2)
+	require.Len(key1, encryptionKeySize)
+	require.NotEqual(key1, key2, "keys for different namespaces should differ")
+	require.Equal(key1, deriveEncryptionKey(masterKey, ns1), "key derivation should be deterministic")
+}
+
+func TestEncryptionNodeDBs(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	config.GlobalConfig.Common.DataDir = dataDir
+	config.GlobalConfig.Storage.Encryption.Enabled = true
+	defer func() {
+		config.GlobalConfig.Common.DataDir = ""
+		config.GlobalConfig.Storage.Encryption.Enabled = false
+	}()
+
+	var ns1, ns2 common.Namespace
+	ns2[0] = 0x80
+	runtimeDir := registry.GetRuntimeStateDir(dataDir, ns1)
+	dbDir := GetLocalBackendDBDir(runtimeDir, database.BackendNameBadgerDB)
+	createNodeDB := func(key []byte) {
+		require.NoError(os.RemoveAll(dbDir), "RemoveAll")
+		ndb, err := mkvsDB.New(database.BackendNameBadgerDB, &db.Config{
+			DB:            dbDir,
+			Namespace:     ns1,
+			EncryptionKey: key,
+		})
+		require.NoError(err, "mkvsDB.New")
+		ndb.Close()
+	}
+
+	// Plaintext node databases require an explicit migration.
+	createNodeDB(nil)
+	encrypted, err := IsEncryptedNodeDB(dbDir)
+	require.NoError(err, "IsEncryptedNodeDB")
+	require.False(encrypted, "node database should not be encrypted")
+	err = checkNodeDBEncryption(runtimeDir, true)
+	require.Error(err, "checkNodeDBEncryption should fail for plaintext databases")
+	require.NoError(checkNodeDBEncryption(runtimeDir, false), "checkNodeDBEncryption")
+
+	err = GenerateEncryptionMasterKey(dataDir, false)
+	require.Error(err, "GenerateEncryptionMasterKey should fail when node databases exist")
+	err = GenerateEncryptionMasterKey(dataDir, true)
+	require.NoError(err, "GenerateEncryptionMasterKey should allow plaintext databases")
+	require.FileExists(filepath.Join(dataDir, "storage_encryption.key"))
+
+	// Encrypted node databases need the key derived for their namespace.
+	key, err := EncryptionKey(ns1)
+	require.NoError(err, "EncryptionKey")
+	createNodeDB(key)
+	encrypted, err = IsEncryptedNodeDB(dbDir)
+	require.NoError(err, "IsEncryptedNodeDB")
+	require.True(encrypted, "node database should be encrypted")
+	require.NoError(checkNodeDBEncryption(runtimeDir, true), "checkNodeDBEncryption")
+	dbKey, err := NodeDBEncryptionKey(dbDir, ns1)
+	require.NoError(err, "NodeDBEncryptionKey")
+	require.Equal(key, dbKey)
+
+	// A lost key should never be replaced while encrypted databases exist.
+	require.NoError(os.Rename(EncryptionMasterKeyPath(), EncryptionMasterKeyPath()+".bak"))
+	err = GenerateEncryptionMasterKey(dataDir, true)
+	require.Error(err, "GenerateEncryptionMasterKey should fail when encrypted databases exist")
+	require.NoError(os.Rename(EncryptionMasterKeyPath()+".bak", EncryptionMasterKeyPath()))
+
+	// Re-keying should make the database accessible under the new namespace.
+	err = RekeyNodeDB(dbDir, ns1, ns2)
+	require.NoError(err, "RekeyNodeDB")
+	newKey, err := EncryptionKey(ns2)
+	require.NoError(err, "EncryptionKey")
+	ndb, err := mkvsDB.New(database.BackendNameBadgerDB, &db.Config{
+		DB:            dbDir,
+		Namespace:     ns1,
+		EncryptionKey: newKey,
+	})
+	require.NoError(err, "mkvsDB.New with the new key")
+	ndb.Close()
+}
@@ -39,6 +39,16 @@ func NewLocalBackend(
 		NoFsync:      true, // Should be safe, storage will be re-applied on crashes.
 	}
 
+	encEnabled := config.GlobalConfig.Storage.Encryption.Enabled
+	if err := checkNodeDBEncryption(dataDir, encEnabled); err != nil {
+		return nil, err
+	}
+	encKey, err := EncryptionKey(namespace)
+	if err != nil {
+		return nil, err
+	}
+	cfg.EncryptionKey = encKey
+
 	cfg.DB = GetLocalBackendDBDir(dataDir, cfg.Backend)
 	impl, err := database.New(cfg)
 	if err != nil {
@@ -43,6 +43,12 @@ func (s *service) HandleRequest(ctx context.Context, method string, body cbor.Ra
 }
 
 // NewServer creates a new storage pub protocol server.
-func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend) rpc.Server {
-	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, StoragePubProtocolVersion), &service{backend})
+//
+// If a fair queue is given, incoming requests are admitted through it.
+func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend, queue *rpc.FairQueue) rpc.Server {
+	var srv rpc.Service = &service{backend}
+	if queue != nil {
+		srv = rpc.NewFairService(srv, queue)
+	}
+	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, StoragePubProtocolVersion), srv)
 }
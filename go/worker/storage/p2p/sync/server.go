@@ -104,6 +104,12 @@ func (s *service) handleGetCheckpointChunk(ctx context.Context, request *GetChec
 }
 
 // NewServer creates a new storage sync protocol server.
-func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend) rpc.Server {
-	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StorageSyncProtocolID, StorageSyncProtocolVersion), &service{backend})
+//
+// If a fair queue is given, incoming requests are admitted through it.
+func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend, queue *rpc.FairQueue) rpc.Server {
+	var srv rpc.Service = &service{backend}
+	if queue != nil {
+		srv = rpc.NewFairService(srv, queue)
+	}
+	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StorageSyncProtocolID, StorageSyncProtocolVersion), srv)
 }
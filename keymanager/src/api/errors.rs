@@ -47,6 +47,8 @@ pub enum KeyManagerError {
     MasterSecretNotFound(u64),
     #[error("master secret generation {0} not replicated")]
     MasterSecretNotReplicated(u64),
+    #[error("master secret generation {0} confirmed by {1} replicas, {2} required")]
+    MasterSecretReplicationQuorumNotReached(u64, usize, usize),
     #[error("master secret not published")]
     MasterSecretNotPublished,
     #[error("ephemeral secret for epoch {0} not found")]
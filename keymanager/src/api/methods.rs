@@ -21,3 +21,7 @@ pub const LOCAL_METHOD_GENERATE_EPHEMERAL_SECRET: &str = "generate_ephemeral_sec
 pub const LOCAL_METHOD_LOAD_MASTER_SECRET: &str = "load_master_secret";
 /// Name of the `load_ephemeral_secret` local method.
 pub const LOCAL_METHOD_LOAD_EPHEMERAL_SECRET: &str = "load_ephemeral_secret";
+/// Name of the `key_cache_stats` local method.
+pub const LOCAL_METHOD_KEY_CACHE_STATS: &str = "key_cache_stats";
+/// Name of the `flush_key_cache` local method.
+pub const LOCAL_METHOD_FLUSH_KEY_CACHE: &str = "flush_key_cache";
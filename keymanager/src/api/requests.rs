@@ -24,6 +24,9 @@ const INIT_RESPONSE_CONTEXT: &[u8] = b"oasis-core/keymanager: init response";
 pub struct InitRequest {
     /// Key manager status.
     pub status: Status,
+    /// Number of distinct enclaves from which each replicated master secret must be obtained.
+    #[cbor(optional)]
+    pub replication_quorum: u64,
 }
 
 /// Key manager initialization response.
@@ -151,6 +154,28 @@ pub struct LoadEphemeralSecretRequest {
     pub signed_secret: SignedEncryptedEphemeralSecret,
 }
 
+/// Key cache statistics.
+#[derive(Clone, Default, Debug, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct KeyCacheStats {
+    /// Number of cached keys.
+    pub size: u64,
+    /// Maximum number of cached keys.
+    pub capacity: u64,
+    /// Number of requests served from the cache since the enclave started.
+    pub hits: u64,
+    /// Number of requests that required key derivation since the enclave started.
+    pub misses: u64,
+}
+
+/// Key cache statistics response.
+#[derive(Clone, Default, Debug, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct KeyCacheStatsResponse {
+    /// Long-term private key cache statistics.
+    pub longterm_keys: KeyCacheStats,
+    /// Ephemeral private key cache statistics.
+    pub ephemeral_keys: KeyCacheStats,
+}
+
 /// Long-term key request for private/public key generation and retrieval.
 ///
 /// Long-term keys are runtime-scoped long-lived keys derived by the key manager
@@ -166,6 +191,12 @@ pub struct LongTermKeyRequest {
     /// Generation.
     #[cbor(optional)]
     pub generation: u64,
+    /// Key derivation context label.
+    ///
+    /// Keys derived under different contexts are independent. An empty context
+    /// refers to the default key hierarchy.
+    #[cbor(optional)]
+    pub context: String,
 }
 
 /// Ephemeral key request for private/public key generation and retrieval.
@@ -183,4 +214,10 @@ pub struct EphemeralKeyRequest {
     pub key_pair_id: KeyPairId,
     /// Epoch time.
     pub epoch: EpochTime,
+    /// Key derivation context label.
+    ///
+    /// Keys derived under different contexts are independent. An empty context
+    /// refers to the default key hierarchy.
+    #[cbor(optional)]
+    pub context: String,
 }
@@ -34,16 +34,22 @@ pub trait KeyManagerClient: Send + Sync {
     /// If the key does not yet exist, the key manager will generate one. If
     /// the key has already been cached locally, it will be retrieved from
     /// cache.
+    ///
+    /// Keys derived under different key derivation contexts are independent.
+    /// An empty context refers to the default key hierarchy, while other
+    /// contexts must be allowed by the key manager policy.
     async fn get_or_create_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<KeyPair, KeyManagerError>;
 
-    /// Get long-term public key for a key pair id.
+    /// Get long-term public key for a key pair id and key derivation context.
     async fn get_public_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<SignedPublicKey, KeyManagerError>;
 
@@ -52,16 +58,21 @@ pub trait KeyManagerClient: Send + Sync {
     /// If the key does not yet exist, the key manager will generate one. If
     /// the key has already been cached locally, it will be retrieved from
     /// cache.
+    ///
+    /// See `get_or_create_keys` for details on key derivation contexts.
     async fn get_or_create_ephemeral_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<KeyPair, KeyManagerError>;
 
-    /// Get ephemeral public key for an epoch and a key pair id.
+    /// Get ephemeral public key for an epoch, a key pair id and a key derivation
+    /// context.
     async fn get_public_ephemeral_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<SignedPublicKey, KeyManagerError>;
 
@@ -141,33 +152,37 @@ impl<T: ?Sized + KeyManagerClient> KeyManagerClient for Arc<T> {
     async fn get_or_create_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<KeyPair, KeyManagerError> {
-        KeyManagerClient::get_or_create_keys(&**self, key_pair_id, generation).await
+        KeyManagerClient::get_or_create_keys(&**self, key_pair_id, context, generation).await
     }
 
     async fn get_public_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<SignedPublicKey, KeyManagerError> {
-        KeyManagerClient::get_public_key(&**self, key_pair_id, generation).await
+        KeyManagerClient::get_public_key(&**self, key_pair_id, context, generation).await
     }
 
     async fn get_or_create_ephemeral_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<KeyPair, KeyManagerError> {
-        KeyManagerClient::get_or_create_ephemeral_keys(&**self, key_pair_id, epoch).await
+        KeyManagerClient::get_or_create_ephemeral_keys(&**self, key_pair_id, context, epoch).await
     }
 
     async fn get_public_ephemeral_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<SignedPublicKey, KeyManagerError> {
-        KeyManagerClient::get_public_ephemeral_key(&**self, key_pair_id, epoch).await
+        KeyManagerClient::get_public_ephemeral_key(&**self, key_pair_id, context, epoch).await
     }
 
     async fn replicate_master_secret(
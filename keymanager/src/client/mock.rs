@@ -22,8 +22,8 @@ use super::KeyManagerClient;
 /// Mock key manager client which stores everything locally.
 #[derive(Default)]
 pub struct MockClient {
-    longterm_keys: Mutex<HashMap<(KeyPairId, u64), KeyPair>>,
-    ephemeral_keys: Mutex<HashMap<(KeyPairId, EpochTime), KeyPair>>,
+    longterm_keys: Mutex<HashMap<(KeyPairId, String, u64), KeyPair>>,
+    ephemeral_keys: Mutex<HashMap<(KeyPairId, String, EpochTime), KeyPair>>,
 }
 
 impl MockClient {
@@ -51,11 +51,12 @@ impl KeyManagerClient for MockClient {
     async fn get_or_create_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<KeyPair, KeyManagerError> {
         let mut keys = self.longterm_keys.lock().unwrap();
         let key = keys
-            .entry((key_pair_id, generation))
+            .entry((key_pair_id, context.to_string(), generation))
             .or_insert_with(KeyPair::generate_mock)
             .clone();
 
@@ -65,9 +66,12 @@ impl KeyManagerClient for MockClient {
     async fn get_public_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<SignedPublicKey, KeyManagerError> {
-        let ck = self.get_or_create_keys(key_pair_id, generation).await?;
+        let ck = self
+            .get_or_create_keys(key_pair_id, context, generation)
+            .await?;
         Ok(SignedPublicKey {
             key: ck.input_keypair.pk,
             checksum: vec![],
@@ -79,11 +83,12 @@ impl KeyManagerClient for MockClient {
     async fn get_or_create_ephemeral_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<KeyPair, KeyManagerError> {
         let mut keys = self.ephemeral_keys.lock().unwrap();
         let key = keys
-            .entry((key_pair_id, epoch))
+            .entry((key_pair_id, context.to_string(), epoch))
             .or_insert_with(KeyPair::generate_mock)
             .clone();
 
@@ -93,10 +98,11 @@ impl KeyManagerClient for MockClient {
     async fn get_public_ephemeral_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<SignedPublicKey, KeyManagerError> {
         let ck = self
-            .get_or_create_ephemeral_keys(key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(key_pair_id, context, epoch)
             .await?;
         Ok(SignedPublicKey {
             key: ck.input_keypair.pk,
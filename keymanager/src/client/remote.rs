@@ -55,7 +55,8 @@ use crate::{
         METHOD_SHARE_REDUCTION_POINT, METHOD_VERIFICATION_MATRIX,
     },
     crypto::{
-        KeyPair, KeyPairId, Secret, SignedPublicKey, StateKey, VerifiableSecret, KEY_PAIR_ID_SIZE,
+        KeyPair, KeyPairId, Secret, SecretSource, SignedPublicKey, StateKey, VerifiableSecret,
+        KEY_PAIR_ID_SIZE,
     },
     policy::{set_trusted_signers, verify_data_and_trusted_signers, Policy, TrustedSigners},
 };
@@ -82,13 +83,13 @@ pub struct RemoteClient {
     /// Consensus verifier.
     consensus_verifier: Arc<dyn Verifier>,
     /// Local cache for the long-term private keys.
-    longterm_private_keys: RwLock<LruCache<(KeyPairId, u64), KeyPair>>,
+    longterm_private_keys: RwLock<LruCache<(KeyPairId, String, u64), KeyPair>>,
     /// Local cache for the long-term public keys.
-    longterm_public_keys: RwLock<LruCache<(KeyPairId, u64), SignedPublicKey>>,
+    longterm_public_keys: RwLock<LruCache<(KeyPairId, String, u64), SignedPublicKey>>,
     /// Local cache for the ephemeral private keys.
-    ephemeral_private_keys: RwLock<LruCache<(KeyPairId, EpochTime), KeyPair>>,
+    ephemeral_private_keys: RwLock<LruCache<(KeyPairId, String, EpochTime), KeyPair>>,
     /// Local cache for the ephemeral public keys.
-    ephemeral_public_keys: RwLock<LruCache<(KeyPairId, EpochTime), SignedPublicKey>>,
+    ephemeral_public_keys: RwLock<LruCache<(KeyPairId, String, EpochTime), SignedPublicKey>>,
     /// Local cache for the state keys.
     state_keys: RwLock<LruCache<(KeyPairId, u8), StateKey>>,
     /// Key manager runtime ID.
@@ -239,14 +240,22 @@ impl RemoteClient {
         &self,
         key: &SignedPublicKey,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: Option<EpochTime>,
         now: Option<EpochTime>,
     ) -> Result<(), KeyManagerError> {
         let pk = self.rsk.read().unwrap();
         let pk = pk.as_ref().ok_or(KeyManagerError::RSKMissing)?;
 
-        key.verify(self.runtime_id, key_pair_id, epoch, now, pk)
-            .map_err(KeyManagerError::InvalidSignature)
+        key.verify(
+            self.runtime_id,
+            key_pair_id,
+            context.as_bytes(),
+            epoch,
+            now,
+            pk,
+        )
+        .map_err(KeyManagerError::InvalidSignature)
     }
 
     async fn churp_recover_state_key<S: Suite>(
@@ -370,9 +379,10 @@ impl KeyManagerClient for RemoteClient {
     async fn get_or_create_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<KeyPair, KeyManagerError> {
-        let id = (key_pair_id, generation);
+        let id = (key_pair_id, context.to_string(), generation);
 
         // First try to fetch from cache.
         {
@@ -398,6 +408,7 @@ impl KeyManagerClient for RemoteClient {
                     runtime_id: self.runtime_id,
                     key_pair_id,
                     generation,
+                    context: context.to_string(),
                 },
                 vec![],
             )
@@ -416,15 +427,16 @@ impl KeyManagerClient for RemoteClient {
     async fn get_public_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         generation: u64,
     ) -> Result<SignedPublicKey, KeyManagerError> {
-        let id = (key_pair_id, generation);
+        let id = (key_pair_id, context.to_string(), generation);
 
         // First fetch from cache.
         {
             let mut cache = self.longterm_public_keys.write().unwrap();
             if let Some(key) = cache.get(&id) {
-                match self.verify_public_key(key, key_pair_id, None, None) {
+                match self.verify_public_key(key, key_pair_id, context, None, None) {
                     Ok(()) => return Ok(key.clone()),
                     Err(_) => {
                         cache.pop(&id);
@@ -449,6 +461,7 @@ impl KeyManagerClient for RemoteClient {
                     runtime_id: self.runtime_id,
                     key_pair_id,
                     generation,
+                    context: context.to_string(),
                 },
                 vec![],
             )
@@ -458,7 +471,7 @@ impl KeyManagerClient for RemoteClient {
             .map_err(|err| KeyManagerError::Other(err.into()))?;
 
         // Verify the signature.
-        self.verify_public_key(&key, key_pair_id, None, None)?;
+        self.verify_public_key(&key, key_pair_id, context, None, None)?;
 
         // Cache key.
         let mut cache = self.longterm_public_keys.write().unwrap();
@@ -470,9 +483,10 @@ impl KeyManagerClient for RemoteClient {
     async fn get_or_create_ephemeral_keys(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<KeyPair, KeyManagerError> {
-        let id = (key_pair_id, epoch);
+        let id = (key_pair_id, context.to_string(), epoch);
 
         // First try to fetch from cache.
         {
@@ -498,6 +512,7 @@ impl KeyManagerClient for RemoteClient {
                     runtime_id: self.runtime_id,
                     key_pair_id,
                     epoch,
+                    context: context.to_string(),
                 },
                 vec![],
             )
@@ -516,9 +531,10 @@ impl KeyManagerClient for RemoteClient {
     async fn get_public_ephemeral_key(
         &self,
         key_pair_id: KeyPairId,
+        context: &str,
         epoch: EpochTime,
     ) -> Result<SignedPublicKey, KeyManagerError> {
-        let id = (key_pair_id, epoch);
+        let id = (key_pair_id, context.to_string(), epoch);
 
         // Fetch current epoch.
         let consensus_state = self.consensus_verifier.latest_state().await?;
@@ -531,7 +547,13 @@ impl KeyManagerClient for RemoteClient {
         {
             let mut cache = self.ephemeral_public_keys.write().unwrap();
             if let Some(key) = cache.get(&id) {
-                match self.verify_public_key(key, key_pair_id, Some(epoch), Some(consensus_epoch)) {
+                match self.verify_public_key(
+                    key,
+                    key_pair_id,
+                    context,
+                    Some(epoch),
+                    Some(consensus_epoch),
+                ) {
                     Ok(()) => return Ok(key.clone()),
                     Err(_) => {
                         cache.pop(&id);
@@ -556,6 +578,7 @@ impl KeyManagerClient for RemoteClient {
                     runtime_id: self.runtime_id,
                     key_pair_id,
                     epoch,
+                    context: context.to_string(),
                 },
                 vec![],
             )
@@ -565,7 +588,13 @@ impl KeyManagerClient for RemoteClient {
             .map_err(|err| KeyManagerError::Other(err.into()))?;
 
         // Verify the signature.
-        self.verify_public_key(&key, key_pair_id, Some(epoch), Some(consensus_epoch))?;
+        self.verify_public_key(
+            &key,
+            key_pair_id,
+            context,
+            Some(epoch),
+            Some(consensus_epoch),
+        )?;
 
         // Cache key.
         let mut cache = self.ephemeral_public_keys.write().unwrap();
@@ -585,7 +614,8 @@ impl KeyManagerClient for RemoteClient {
             .await
             .map_err(|err| KeyManagerError::Other(err.into()))?;
 
-        self.rpc_client
+        let response = self
+            .rpc_client
             .secure_call(
                 METHOD_REPLICATE_MASTER_SECRET,
                 ReplicateMasterSecretRequest {
@@ -594,13 +624,23 @@ impl KeyManagerClient for RemoteClient {
                 },
                 nodes,
             )
-            .await
+            .await;
+
+        // Remember which enclave provided the secret so that replicas can be
+        // counted per enclave and not per node.
+        let source = response.session_info().map(|info| SecretSource {
+            enclave: info.verified_attestation.quote.identity.clone(),
+            rak: info.rak_binding.rak_pub(),
+        });
+
+        response
             .into_result_with_feedback()
             .await
             .map_err(|err| KeyManagerError::Other(err.into()))
             .map(|rsp: ReplicateMasterSecretResponse| VerifiableSecret {
                 secret: rsp.master_secret,
                 checksum: rsp.checksum,
+                source,
             })
     }
 
@@ -1,6 +1,6 @@
 //! Key Derivation Function.
 use std::{
-    collections::HashMap,
+    collections::{HashMap, HashSet},
     convert::TryInto,
     num::NonZeroUsize,
     sync::{Arc, RwLock},
@@ -25,7 +25,7 @@ use oasis_core_runtime::{
 };
 
 use crate::{
-    api::KeyManagerError,
+    api::{KeyCacheStats, KeyCacheStatsResponse, KeyManagerError},
     crypto::{
         pack_runtime_id_generation, unpack_encrypted_secret_nonce, KeyPair, KeyPairId, Secret,
         SignedPublicKey, StateKey,
@@ -51,6 +51,13 @@ lazy_static! {
         }
     };
 
+    static ref RUNTIME_CONTEXT_KDF_CUSTOM: &'static [u8] = {
+        match BUILD_INFO.is_secure {
+            true => b"ekiden-derive-runtime-context-secret",
+            false => b"ekiden-derive-runtime-context-secret-insecure",
+        }
+    };
+
     static ref RUNTIME_XOF_CUSTOM: &'static [u8] = {
         match BUILD_INFO.is_secure {
             true => b"ekiden-derive-contract-keys",
@@ -65,6 +72,13 @@ lazy_static! {
         }
     };
 
+    static ref EPHEMERAL_CONTEXT_KDF_CUSTOM: &'static [u8] = {
+        match BUILD_INFO.is_secure {
+            true => b"ekiden-derive-ephemeral-context-secret",
+            false => b"ekiden-derive-ephemeral-context-secret-insecure",
+        }
+    };
+
     static ref EPHEMERAL_XOF_CUSTOM: &'static [u8] = {
         match BUILD_INFO.is_secure {
             true => b"ekiden-derive-ephemeral-keys",
@@ -161,6 +175,28 @@ struct Inner {
     longterm_keys: LruCache<(Vec<u8>, u64), KeyPair>,
     /// Local cache for the ephemeral private keys.
     ephemeral_keys: LruCache<(Vec<u8>, EpochTime), KeyPair>,
+    /// Hit and miss counters of the long-term private key cache.
+    longterm_keys_counters: CacheCounters,
+    /// Hit and miss counters of the ephemeral private key cache.
+    ephemeral_keys_counters: CacheCounters,
+}
+
+/// Hit and miss counters of a key cache.
+#[derive(Default)]
+struct CacheCounters {
+    hits: u64,
+    misses: u64,
+}
+
+impl CacheCounters {
+    fn stats<K: std::hash::Hash + Eq, V>(&self, cache: &LruCache<K, V>) -> KeyCacheStats {
+        KeyCacheStats {
+            size: cache.len() as u64,
+            capacity: cache.cap().get() as u64,
+            hits: self.hits,
+            misses: self.misses,
+        }
+    }
 }
 
 impl Inner {
@@ -323,6 +359,8 @@ impl Kdf {
                 next_signing_key: None,
                 longterm_keys: LruCache::new(NonZeroUsize::new(1024).unwrap()),
                 ephemeral_keys: LruCache::new(NonZeroUsize::new(128).unwrap()),
+                longterm_keys_counters: Default::default(),
+                ephemeral_keys_counters: Default::default(),
             }),
         }
     }
@@ -339,6 +377,9 @@ impl Kdf {
     /// If this condition is not met, the internal state is reset and the KDF needs to be
     /// initialized again.
     ///
+    /// Master secrets that need to be replicated are only accepted once the given number of
+    /// distinct enclaves provided replicas matching the consensus checksum.
+    ///
     /// WARNINGS:
     /// - Once master secrets have been persisted to disk, it is intended that manual
     ///   intervention by the operator is required to remove/alter them.
@@ -353,7 +394,11 @@ impl Kdf {
         checksum: Vec<u8>,
         epoch: EpochTime,
         provider: &dyn SecretProvider,
+        replication_quorum: usize,
     ) -> Result<State> {
+        // At least one enclave always needs to provide a missing master secret.
+        let replication_quorum = replication_quorum.max(1);
+
         // If the key manager has no secrets, nothing needs to be replicated.
         if checksum.is_empty() {
             let mut inner = self.inner.write().unwrap();
@@ -462,25 +507,54 @@ impl Kdf {
                 continue;
             }
 
-            // Master secret wasn't found and needs to be fetched from another enclave.
-            // Fetched values are untrusted and need to be verified.
-            let (secret, prev_checksum) = provider
-                .master_secret_iter(generation)
-                .find_map(|vs| {
-                    let prev_checksum = if vs.checksum.is_empty() {
-                        runtime_id.0.to_vec()
-                    } else {
-                        vs.checksum
-                    };
-
-                    let next_checksum = Self::checksum_master_secret(&vs.secret, &prev_checksum);
-                    if next_checksum != last_checksum {
-                        return None;
-                    }
+            // Master secret wasn't found and needs to be fetched from other enclaves.
+            // Fetched values are untrusted and need to be verified. Keep fetching until
+            // enough distinct enclaves confirm the secret, as the same enclave may be
+            // reachable via multiple nodes.
+            let mut sources = HashSet::new();
+            let mut replica = None;
+            for vs in provider.master_secret_iter(generation) {
+                let prev_checksum = if vs.checksum.is_empty() {
+                    runtime_id.0.to_vec()
+                } else {
+                    vs.checksum
+                };
+
+                let next_checksum = Self::checksum_master_secret(&vs.secret, &prev_checksum);
+                if next_checksum != last_checksum {
+                    continue;
+                }
+
+                // Replicas from unknown enclaves cannot be attributed and don't count.
+                let source = match vs.source {
+                    Some(source) => source,
+                    None => continue,
+                };
+                if !sources.insert(source) {
+                    continue;
+                }
 
-                    Some((vs.secret, prev_checksum))
-                })
-                .ok_or(KeyManagerError::MasterSecretNotReplicated(generation))?;
+                replica.get_or_insert((vs.secret, prev_checksum));
+
+                if sources.len() >= replication_quorum {
+                    break;
+                }
+            }
+
+            let confirmations = sources.len();
+
+            let (secret, prev_checksum) = match replica {
+                Some(replica) if confirmations >= replication_quorum => replica,
+                Some(_) => {
+                    return Err(KeyManagerError::MasterSecretReplicationQuorumNotReached(
+                        generation,
+                        confirmations,
+                        replication_quorum,
+                    )
+                    .into())
+                }
+                None => return Err(KeyManagerError::MasterSecretNotReplicated(generation).into()),
+            };
 
             Self::store_master_secret(storage, &runtime_id, &secret, generation);
             Self::store_checksum(storage, prev_checksum.clone(), generation);
@@ -532,18 +606,47 @@ impl Kdf {
         inner.get_runtime_id()
     }
 
+    /// Return statistics of the local key caches.
+    pub fn cache_stats(&self) -> KeyCacheStatsResponse {
+        let inner = self.inner.read().unwrap();
+
+        KeyCacheStatsResponse {
+            longterm_keys: inner.longterm_keys_counters.stats(&inner.longterm_keys),
+            ephemeral_keys: inner.ephemeral_keys_counters.stats(&inner.ephemeral_keys),
+        }
+    }
+
+    /// Clear the local key caches.
+    ///
+    /// Cleared keys are derived again from the secrets on the next request.
+    pub fn clear_cache(&self) {
+        let mut inner = self.inner.write().unwrap();
+        inner.longterm_keys.clear();
+        inner.ephemeral_keys.clear();
+    }
+
     /// Get or create long-term keys.
+    ///
+    /// Keys derived under a non-empty key derivation context come from a separate
+    /// domain, so they can never be obtained through the default (empty) context.
     pub fn get_or_create_longterm_keys(
         &self,
         storage: &dyn KeyValue,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         generation: u64,
     ) -> Result<KeyPair> {
         // Construct a seed that must be unique for every key request.
-        // Long-term keys: seed = runtime_id || key_pair_id
+        // Long-term keys: seed = runtime_id || key_pair_id [|| len(context) || context]
         let mut seed = runtime_id.as_ref().to_vec();
         seed.extend_from_slice(key_pair_id.as_ref());
+        let kdf_custom = Self::with_context(
+            &mut seed,
+            context,
+            &RUNTIME_KDF_CUSTOM,
+            &RUNTIME_CONTEXT_KDF_CUSTOM,
+        );
 
         let mut inner = self.inner.write().unwrap();
 
@@ -556,8 +659,11 @@ impl Kdf {
         // Check to see if the cached value exists.
         let id = (seed, generation);
         if let Some(keys) = inner.longterm_keys.get(&id) {
-            return Ok(keys.clone());
+            let keys = keys.clone();
+            inner.longterm_keys_counters.hits += 1;
+            return Ok(keys);
         };
+        inner.longterm_keys_counters.misses += 1;
 
         // Make sure the secret is loaded.
         if !inner.master_secrets.contains(&generation) {
@@ -573,7 +679,7 @@ impl Kdf {
         }
 
         // Generate keys.
-        let secret = inner.derive_longterm_secret(&RUNTIME_KDF_CUSTOM, &id.0, id.1)?;
+        let secret = inner.derive_longterm_secret(kdf_custom, &id.0, id.1)?;
         // FIXME: Replace KDF custom with XOF custom when possible.
         let keys = inner.derive_keys(secret, &RUNTIME_KDF_CUSTOM)?;
 
@@ -584,16 +690,25 @@ impl Kdf {
     }
 
     /// Get or create ephemeral keys.
+    ///
+    /// See `get_or_create_longterm_keys` for how key derivation contexts are handled.
     pub fn get_or_create_ephemeral_keys(
         &self,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: EpochTime,
     ) -> Result<KeyPair> {
         // Construct a seed that must be unique for every key request.
-        // Ephemeral keys: seed = runtime_id || key_pair_id || epoch
+        // Ephemeral keys: seed = runtime_id || key_pair_id [|| len(context) || context] || epoch
         let mut seed = runtime_id.as_ref().to_vec();
         seed.extend_from_slice(key_pair_id.as_ref());
+        let kdf_custom = Self::with_context(
+            &mut seed,
+            context,
+            &EPHEMERAL_KDF_CUSTOM,
+            &EPHEMERAL_CONTEXT_KDF_CUSTOM,
+        );
         seed.extend_from_slice(epoch.to_be_bytes().as_ref()); // TODO: Remove once we transition to ephemeral secrets (how?)
 
         let mut inner = self.inner.write().unwrap();
@@ -601,11 +716,14 @@ impl Kdf {
         // Check to see if the cached value exists.
         let id = (seed, epoch);
         if let Some(keys) = inner.ephemeral_keys.get(&id) {
-            return Ok(keys.clone());
+            let keys = keys.clone();
+            inner.ephemeral_keys_counters.hits += 1;
+            return Ok(keys);
         };
+        inner.ephemeral_keys_counters.misses += 1;
 
         // Generate keys.
-        let secret = inner.derive_ephemeral_secret(&EPHEMERAL_KDF_CUSTOM, &id.0, id.1)?;
+        let secret = inner.derive_ephemeral_secret(kdf_custom, &id.0, id.1)?;
         let keys = inner.derive_keys(secret, &EPHEMERAL_XOF_CUSTOM)?;
 
         // Insert into the cache.
@@ -614,16 +732,43 @@ impl Kdf {
         Ok(keys)
     }
 
+    /// Append the key derivation context to the seed and return the matching KDF
+    /// customization string.
+    ///
+    /// The default (empty) context keeps the original derivation, while all other
+    /// contexts use a separate customization string, so that no seed from one domain
+    /// can be used to derive keys from the other.
+    fn with_context<'a>(
+        seed: &mut Vec<u8>,
+        context: &[u8],
+        default_custom: &'a [u8],
+        context_custom: &'a [u8],
+    ) -> &'a [u8] {
+        if context.is_empty() {
+            return default_custom;
+        }
+
+        seed.extend_from_slice(&(context.len() as u64).to_be_bytes());
+        seed.extend_from_slice(context);
+        context_custom
+    }
+
     /// Get the public part of the long-term key.
     pub fn get_public_longterm_key(
         &self,
         storage: &dyn KeyValue,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         generation: u64,
     ) -> Result<x25519::PublicKey> {
-        let keys =
-            self.get_or_create_longterm_keys(storage, runtime_id, key_pair_id, generation)?;
+        let keys = self.get_or_create_longterm_keys(
+            storage,
+            runtime_id,
+            key_pair_id,
+            context,
+            generation,
+        )?;
         Ok(keys.input_keypair.pk)
     }
 
@@ -632,9 +777,10 @@ impl Kdf {
         &self,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: EpochTime,
     ) -> Result<x25519::PublicKey> {
-        let keys = self.get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch)?;
+        let keys = self.get_or_create_ephemeral_keys(runtime_id, key_pair_id, context, epoch)?;
         Ok(keys.input_keypair.pk)
     }
 
@@ -644,6 +790,7 @@ impl Kdf {
         key: x25519::PublicKey,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: Option<EpochTime>,
     ) -> Result<SignedPublicKey> {
         let inner = self.inner.read().unwrap();
@@ -653,7 +800,15 @@ impl Kdf {
             .as_ref()
             .ok_or(KeyManagerError::NotInitialized)?;
 
-        SignedPublicKey::new(key, checksum, runtime_id, key_pair_id, epoch, signer)
+        SignedPublicKey::new(
+            key,
+            checksum,
+            runtime_id,
+            key_pair_id,
+            context,
+            epoch,
+            signer,
+        )
     }
 
     /// Replicate master secret.
@@ -992,18 +1147,10 @@ mod tests {
     };
 
     use super::{
-        Inner, Kdf, EPHEMERAL_KDF_CUSTOM, EPHEMERAL_XOF_CUSTOM, RUNTIME_KDF_CUSTOM,
-        RUNTIME_XOF_CUSTOM,
+        Inner, Kdf, EPHEMERAL_CONTEXT_KDF_CUSTOM, EPHEMERAL_KDF_CUSTOM, EPHEMERAL_XOF_CUSTOM,
+        RUNTIME_CONTEXT_KDF_CUSTOM, RUNTIME_KDF_CUSTOM, RUNTIME_XOF_CUSTOM,
     };
 
-    impl Kdf {
-        fn clear_cache(&self) {
-            let mut inner = self.inner.write().unwrap();
-            inner.longterm_keys.clear();
-            inner.ephemeral_keys.clear();
-        }
-    }
-
     impl Default for Kdf {
         fn default() -> Self {
             let mut master_secrets = LruCache::new(NonZeroUsize::new(10).unwrap());
@@ -1028,6 +1175,8 @@ mod tests {
                     next_signing_key: None,
                     longterm_keys: LruCache::new(NonZeroUsize::new(1).unwrap()),
                     ephemeral_keys: LruCache::new(NonZeroUsize::new(1).unwrap()),
+                    longterm_keys_counters: Default::default(),
+                    ephemeral_keys_counters: Default::default(),
                 }),
             }
         }
@@ -1042,7 +1191,7 @@ mod tests {
         let provider = MockSecretProvider::new(runtime_id, false);
 
         // No secrets.
-        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider);
+        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider, 1);
         assert!(result.is_ok());
 
         let state = result.unwrap();
@@ -1059,6 +1208,7 @@ mod tests {
                 checksum.clone(),
                 epoch,
                 &provider,
+                1,
             );
             assert!(result.is_ok());
 
@@ -1081,6 +1231,7 @@ mod tests {
                 checksum.clone(),
                 epoch,
                 &provider,
+                1,
             );
             assert!(result.is_ok());
 
@@ -1092,6 +1243,120 @@ mod tests {
         }
     }
 
+    #[test]
+    fn init_replication_quorum() {
+        let storage = UntrustedInMemoryStorage::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let epoch = 0;
+        let provider = MockSecretProvider::new(runtime_id, false).with_replicas(2);
+
+        // Not enough enclaves confirmed the secrets.
+        let generation = 2;
+        let checksum = provider.checksum_master_secret(generation);
+
+        let kdf = Kdf::new();
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+            3,
+        );
+        assert!(result.is_err());
+        assert_eq!(
+            result.unwrap_err().to_string(),
+            KeyManagerError::MasterSecretReplicationQuorumNotReached(generation, 2, 3).to_string()
+        );
+
+        // Enough enclaves confirmed the secrets.
+        let kdf = Kdf::new();
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+            2,
+        );
+        assert!(result.is_ok());
+        assert_eq!(result.unwrap().checksum, checksum);
+
+        // Secrets stored locally don't need to be confirmed again.
+        let kdf = Kdf::new();
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+            3,
+        );
+        assert!(result.is_ok());
+
+        // New secrets need to be confirmed.
+        let generation = 3;
+        let checksum = provider.checksum_master_secret(generation);
+
+        let result = kdf.init(
+            &storage, runtime_id, generation, checksum, epoch, &provider, 3,
+        );
+        assert!(result.is_err());
+        assert_eq!(
+            result.unwrap_err().to_string(),
+            KeyManagerError::MasterSecretReplicationQuorumNotReached(generation, 2, 3).to_string()
+        );
+    }
+
+    #[test]
+    fn init_replication_quorum_distinct_enclaves() {
+        let storage = UntrustedInMemoryStorage::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let epoch = 0;
+        let generation = 2;
+
+        // Replicas served by the same enclave via multiple nodes count only once.
+        let provider = MockSecretProvider::new(runtime_id, false)
+            .with_replicas(4)
+            .with_enclaves(2);
+        let checksum = provider.checksum_master_secret(generation);
+
+        let kdf = Kdf::new();
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+            3,
+        );
+        assert!(result.is_err());
+        assert_eq!(
+            result.unwrap_err().to_string(),
+            KeyManagerError::MasterSecretReplicationQuorumNotReached(generation, 2, 3).to_string()
+        );
+
+        // Replicas from distinct enclaves reach the quorum.
+        let provider = provider.with_enclaves(3);
+
+        let kdf = Kdf::new();
+        let result = kdf.init(
+            &storage,
+            runtime_id,
+            generation,
+            checksum.clone(),
+            epoch,
+            &provider,
+            3,
+        );
+        assert!(result.is_ok());
+        assert_eq!(result.unwrap().checksum, checksum);
+    }
+
     #[test]
     fn init_rotation() {
         let kdf = Kdf::new();
@@ -1101,7 +1366,7 @@ mod tests {
         let provider = MockSecretProvider::new(runtime_id, true);
 
         // KDF needs to be initialized.
-        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider);
+        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider, 1);
         assert!(result.is_ok());
 
         // Rotate master secrets.
@@ -1124,6 +1389,7 @@ mod tests {
                 checksum.clone(),
                 epoch,
                 &provider,
+                1,
             );
             assert!(result.is_ok());
 
@@ -1142,7 +1408,9 @@ mod tests {
         assert!(result.is_ok());
 
         let checksum = provider.checksum_master_secret(generation);
-        let result = kdf.init(&storage, runtime_id, generation, checksum, epoch, &provider);
+        let result = kdf.init(
+            &storage, runtime_id, generation, checksum, epoch, &provider, 1,
+        );
         assert!(result.is_err());
         assert_eq!(
             result.unwrap_err().to_string(),
@@ -1165,6 +1433,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_ok());
 
@@ -1191,6 +1460,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_ok());
 
@@ -1211,6 +1481,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_err());
         assert_eq!(
@@ -1238,6 +1509,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_ok());
 
@@ -1259,6 +1531,7 @@ mod tests {
                 checksum.clone(),
                 epoch,
                 &provider,
+                1,
             )
         });
         assert!(result.is_err());
@@ -1283,6 +1556,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_ok());
 
@@ -1297,6 +1571,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_err());
         assert_eq!(
@@ -1315,10 +1590,10 @@ mod tests {
         let provider = MockSecretProvider::new(runtime_id, false);
 
         // No secrets.
-        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider);
+        let result = kdf.init(&storage, runtime_id, 0, vec![], epoch, &provider, 1);
         assert!(result.is_ok());
 
-        let result = kdf.init(&storage, invalid_runtime_id, 0, vec![], epoch, &provider);
+        let result = kdf.init(&storage, invalid_runtime_id, 0, vec![], epoch, &provider, 1);
         assert!(result.is_err());
         assert_eq!(
             result.unwrap_err().to_string(),
@@ -1336,6 +1611,7 @@ mod tests {
             checksum.clone(),
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_ok());
 
@@ -1346,6 +1622,7 @@ mod tests {
             checksum,
             epoch,
             &provider,
+            1,
         );
         assert!(result.is_err());
         assert_eq!(
@@ -1354,6 +1631,40 @@ mod tests {
         );
     }
 
+    #[test]
+    fn key_cache_stats() {
+        let kdf = Kdf::default();
+        let storage = UntrustedInMemoryStorage::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let key_pair_id = KeyPairId::from(vec![2u8; 32]);
+
+        // Miss, hit.
+        for _ in 0..2 {
+            kdf.get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", 0)
+                .expect("private key should be created");
+        }
+        // Miss.
+        kdf.get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", 1)
+            .expect("private key should be created");
+
+        let stats = kdf.cache_stats();
+        assert_eq!(stats.longterm_keys.size, 1);
+        assert_eq!(stats.longterm_keys.capacity, 1);
+        assert_eq!(stats.longterm_keys.hits, 1);
+        assert_eq!(stats.longterm_keys.misses, 1);
+        assert_eq!(stats.ephemeral_keys.size, 1);
+        assert_eq!(stats.ephemeral_keys.hits, 0);
+        assert_eq!(stats.ephemeral_keys.misses, 1);
+
+        // Clearing the cache doesn't reset the counters.
+        kdf.clear_cache();
+        let stats = kdf.cache_stats();
+        assert_eq!(stats.longterm_keys.size, 0);
+        assert_eq!(stats.longterm_keys.hits, 1);
+        assert_eq!(stats.ephemeral_keys.size, 0);
+        assert_eq!(stats.ephemeral_keys.misses, 1);
+    }
+
     #[test]
     fn key_generation_is_deterministic() {
         let kdf = Kdf::default();
@@ -1366,12 +1677,12 @@ mod tests {
         // Long-term keys.
         kdf.clear_cache();
         let sk1 = kdf
-            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, generation)
+            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", generation)
             .expect("private key should be created");
 
         kdf.clear_cache();
         let sk2 = kdf
-            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, generation)
+            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", generation)
             .expect("private key should be created");
 
         assert_eq!(
@@ -1383,12 +1694,12 @@ mod tests {
         // Ephemeral keys.
         kdf.clear_cache();
         let sk1 = kdf
-            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", epoch)
             .expect("private key should be created");
 
         kdf.clear_cache();
         let sk2 = kdf
-            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", epoch)
             .expect("private key should be created");
 
         assert_eq!(
@@ -1410,27 +1721,39 @@ mod tests {
 
         // Long-terms keys should depend on runtime_id and key_pair_id.
         let sk1 = kdf
-            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, generation)
+            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", generation)
             .expect("private key should be created");
         let sk2 = kdf
-            .get_or_create_longterm_keys(&storage, vec![2u8; 32].into(), key_pair_id, generation)
+            .get_or_create_longterm_keys(
+                &storage,
+                vec![2u8; 32].into(),
+                key_pair_id,
+                b"",
+                generation,
+            )
             .expect("private key should be created");
         let sk3 = kdf
-            .get_or_create_longterm_keys(&storage, runtime_id, vec![3u8; 32].into(), generation)
+            .get_or_create_longterm_keys(
+                &storage,
+                runtime_id,
+                vec![3u8; 32].into(),
+                b"",
+                generation,
+            )
             .expect("private key should be created");
 
         // Ephemeral keys should depend on runtime_id, key_pair_id and epoch.
         let sk4 = kdf
-            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", epoch)
             .expect("private key should be created");
         let sk5 = kdf
-            .get_or_create_ephemeral_keys(vec![2u8; 32].into(), key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(vec![2u8; 32].into(), key_pair_id, b"", epoch)
             .expect("private key should be created");
         let sk6 = kdf
-            .get_or_create_ephemeral_keys(runtime_id, vec![3u8; 32].into(), epoch)
+            .get_or_create_ephemeral_keys(runtime_id, vec![3u8; 32].into(), b"", epoch)
             .expect("private key should be created");
         let sk7 = kdf
-            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch + 1)
+            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", epoch + 1)
             .expect("private key should be created");
 
         let keys = HashSet::from(
@@ -1439,6 +1762,78 @@ mod tests {
         assert_eq!(7, keys.len());
     }
 
+    #[test]
+    fn context_keys_are_isolated() {
+        // Default values.
+        let kdf = Kdf::default();
+        let storage = UntrustedInMemoryStorage::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let key_pair_id = KeyPairId::from(vec![1u8; 32]);
+        let generation = 0;
+        let epoch = 1;
+
+        let longterm = |key_pair_id: KeyPairId, context: &[u8]| {
+            kdf.get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, context, generation)
+                .expect("private key should be created")
+                .input_keypair
+                .sk
+                .0
+                .to_bytes()
+        };
+        let ephemeral = |key_pair_id: KeyPairId, context: &[u8]| {
+            kdf.get_or_create_ephemeral_keys(runtime_id, key_pair_id, context, epoch)
+                .expect("private key should be created")
+                .input_keypair
+                .sk
+                .0
+                .to_bytes()
+        };
+
+        // Keys should depend on the context, and contexts should not be ambiguous.
+        let keys = HashSet::from([
+            longterm(key_pair_id, b""),
+            longterm(key_pair_id, b"a"),
+            longterm(key_pair_id, b"b"),
+            longterm(key_pair_id, b"ab"),
+            ephemeral(key_pair_id, b""),
+            ephemeral(key_pair_id, b"a"),
+            ephemeral(key_pair_id, b"b"),
+            ephemeral(key_pair_id, b"ab"),
+        ]);
+        assert_eq!(8, keys.len());
+
+        // Keys derived under a context should be unreachable from the default context,
+        // no matter which key pair identifier is requested. Contextual seeds are longer
+        // than default ones and use a separate customization string, so even identifiers
+        // taken from the contextual seed don't help.
+        let context = b"a";
+        let mut seed = runtime_id.as_ref().to_vec();
+        seed.extend_from_slice(key_pair_id.as_ref());
+        Kdf::with_context(
+            &mut seed,
+            context,
+            &RUNTIME_KDF_CUSTOM,
+            &RUNTIME_CONTEXT_KDF_CUSTOM,
+        );
+        let candidates = [
+            key_pair_id,
+            KeyPairId::from(seed[seed.len() - 32..].to_vec()),
+        ];
+        let lk = longterm(key_pair_id, context);
+        let ek = ephemeral(key_pair_id, context);
+        for id in candidates {
+            assert_ne!(lk, longterm(id, b""));
+            assert_ne!(ek, ephemeral(id, b""));
+        }
+
+        // The same seed should derive different secrets in each domain.
+        let secret = Secret([1u8; SECRET_SIZE]);
+        assert_ne!(
+            Inner::derive_secret(&secret, &RUNTIME_KDF_CUSTOM, &seed).0,
+            Inner::derive_secret(&secret, &RUNTIME_CONTEXT_KDF_CUSTOM, &seed).0,
+        );
+    }
+
     #[test]
     fn private_and_public_key_match() {
         let kdf = Kdf::default();
@@ -1450,20 +1845,20 @@ mod tests {
 
         // Long-term keys.
         let sk = kdf
-            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, generation)
+            .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", generation)
             .expect("private key should be created");
         let pk = kdf
-            .get_public_longterm_key(&storage, runtime_id, key_pair_id, generation)
+            .get_public_longterm_key(&storage, runtime_id, key_pair_id, b"", generation)
             .unwrap();
 
         assert_eq!(sk.input_keypair.pk, pk);
 
         // Ephemeral keys.
         let sk = kdf
-            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, epoch)
+            .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", epoch)
             .expect("private key should be created");
         let pk = kdf
-            .get_public_ephemeral_key(runtime_id, key_pair_id, epoch)
+            .get_public_ephemeral_key(runtime_id, key_pair_id, b"", epoch)
             .unwrap();
 
         assert_eq!(sk.input_keypair.pk, pk);
@@ -1480,7 +1875,7 @@ mod tests {
         let now = Some(15);
 
         let sig = kdf
-            .sign_public_key(pk, runtime_id, key_pair_id, epoch)
+            .sign_public_key(pk, runtime_id, key_pair_id, b"", epoch)
             .expect("public key should be signed");
 
         let mut body = pk.0.to_bytes().to_vec();
@@ -1633,8 +2028,10 @@ mod tests {
         let customs: Vec<&[u8]> = vec![
             &CHECKSUM_CUSTOM,
             &RUNTIME_KDF_CUSTOM,
+            &RUNTIME_CONTEXT_KDF_CUSTOM,
             &RUNTIME_XOF_CUSTOM,
             &EPHEMERAL_KDF_CUSTOM,
+            &EPHEMERAL_CONTEXT_KDF_CUSTOM,
             &EPHEMERAL_XOF_CUSTOM,
             &CHECKSUM_MASTER_SECRET_CUSTOM,
             &CHECKSUM_EPHEMERAL_SECRET_CUSTOM,
@@ -1794,6 +2191,8 @@ mod tests {
                     next_signing_key: None,
                     longterm_keys: LruCache::new(NonZeroUsize::new(1).unwrap()),
                     ephemeral_keys: LruCache::new(NonZeroUsize::new(1).unwrap()),
+                    longterm_keys_counters: Default::default(),
+                    ephemeral_keys_counters: Default::default(),
                 }),
             };
             let storage = UntrustedInMemoryStorage::new();
@@ -1801,11 +2200,11 @@ mod tests {
             let key_pair_id = KeyPairId::from(v.key_pair_id);
 
             let lk = kdf
-                .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, v.generation)
+                .get_or_create_longterm_keys(&storage, runtime_id, key_pair_id, b"", v.generation)
                 .expect("private key should be created");
 
             let ek = kdf
-                .get_or_create_ephemeral_keys(runtime_id, key_pair_id, v.epoch)
+                .get_or_create_ephemeral_keys(runtime_id, key_pair_id, b"", v.epoch)
                 .expect("private key should be created");
 
             assert_eq!(lk.input_keypair.sk.0.to_bytes().to_hex::<String>(), v.lk_sk);
@@ -12,6 +12,7 @@ use oasis_core_runtime::{
             x25519,
         },
         namespace::Namespace,
+        sgx::EnclaveIdentity,
     },
     consensus::beacon::EpochTime,
     impl_bytes,
@@ -20,6 +21,9 @@ use oasis_core_runtime::{
 /// Context used for the public key signature.
 const PUBLIC_KEY_SIGNATURE_CONTEXT: &[u8] = b"oasis-core/keymanager: pk signature";
 
+/// Context used for the signature of public keys derived under a key derivation context.
+const PUBLIC_KEY_CONTEXT_SIGNATURE_CONTEXT: &[u8] = b"oasis-core/keymanager: context pk signature";
+
 /// Maximum age of a signed ephemeral public key in the number of epochs.
 const MAX_SIGNED_EPHEMERAL_PUBLIC_KEY_AGE: EpochTime = 10;
 
@@ -73,6 +77,15 @@ impl AsRef<[u8]> for Secret {
     }
 }
 
+/// Identity of the key manager enclave that provided a secret.
+#[derive(Clone, Debug, Default, Hash, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct SecretSource {
+    /// Enclave identity.
+    pub enclave: EnclaveIdentity,
+    /// Runtime attestation key.
+    pub rak: signature::PublicKey,
+}
+
 /// A secret with a checksum of the preceding secret.
 #[derive(Clone, Default, cbor::Encode, cbor::Decode)]
 pub struct VerifiableSecret {
@@ -80,6 +93,9 @@ pub struct VerifiableSecret {
     pub secret: Secret,
     /// Checksum of the preceding secret.
     pub checksum: Vec<u8>,
+    /// Enclave that provided the secret, if known.
+    #[cbor(optional)]
+    pub source: Option<SecretSource>,
 }
 
 /// A key pair managed by the key manager.
@@ -163,6 +179,9 @@ pub struct SignedPublicKey {
     pub checksum: Vec<u8>,
     /// Sign(sk, (key || checksum || runtime id || key pair id || epoch || expiration epoch)) from
     /// the key manager.
+    ///
+    /// Keys derived under a non-empty key derivation context are signed under a separate
+    /// signature context, with len(context) || context following the key pair id.
     pub signature: Signature,
     /// Expiration epoch.
     #[cbor(optional)]
@@ -176,6 +195,7 @@ impl SignedPublicKey {
         checksum: Vec<u8>,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: Option<EpochTime>,
         signer: &Arc<dyn Signer>,
     ) -> Result<Self> {
@@ -184,8 +204,16 @@ impl SignedPublicKey {
         }
 
         let expiration = epoch.map(|epoch| epoch + MAX_SIGNED_EPHEMERAL_PUBLIC_KEY_AGE);
-        let body = Self::body(key, &checksum, runtime_id, key_pair_id, epoch, expiration);
-        let signature = signer.sign(PUBLIC_KEY_SIGNATURE_CONTEXT, &body)?;
+        let body = Self::body(
+            key,
+            &checksum,
+            runtime_id,
+            key_pair_id,
+            context,
+            epoch,
+            expiration,
+        );
+        let signature = signer.sign(Self::signature_context(context), &body)?;
 
         Ok(SignedPublicKey {
             key,
@@ -200,6 +228,7 @@ impl SignedPublicKey {
         &self,
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: Option<EpochTime>,
         now: Option<EpochTime>,
         pk: &signature::PublicKey,
@@ -228,12 +257,20 @@ impl SignedPublicKey {
             &self.checksum,
             runtime_id,
             key_pair_id,
+            context,
             epoch,
             self.expiration,
         );
 
         self.signature
-            .verify(pk, PUBLIC_KEY_SIGNATURE_CONTEXT, &body)
+            .verify(pk, Self::signature_context(context), &body)
+    }
+
+    fn signature_context(context: &[u8]) -> &'static [u8] {
+        match context.is_empty() {
+            true => PUBLIC_KEY_SIGNATURE_CONTEXT,
+            false => PUBLIC_KEY_CONTEXT_SIGNATURE_CONTEXT,
+        }
     }
 
     fn body(
@@ -241,6 +278,7 @@ impl SignedPublicKey {
         checksum: &[u8],
         runtime_id: Namespace,
         key_pair_id: KeyPairId,
+        context: &[u8],
         epoch: Option<EpochTime>,
         expiration: Option<EpochTime>,
     ) -> Vec<u8> {
@@ -248,6 +286,10 @@ impl SignedPublicKey {
         body.extend_from_slice(checksum);
         body.extend_from_slice(runtime_id.as_ref());
         body.extend_from_slice(key_pair_id.as_ref());
+        if !context.is_empty() {
+            body.extend_from_slice(&(context.len() as u64).to_be_bytes());
+            body.extend_from_slice(context);
+        }
         if let Some(epoch) = epoch {
             body.extend_from_slice(&epoch.to_be_bytes());
         }
@@ -304,6 +346,7 @@ mod test {
             [2u8; 30].to_vec(),
             runtime_id,
             key_pair_id,
+            b"",
             epoch,
             &signer,
         );
@@ -314,16 +357,24 @@ mod test {
         assert_eq!(result.unwrap_err().to_string(), "invalid checksum");
 
         // Create a signature.
-        let result = SignedPublicKey::new(key, checksum, runtime_id, key_pair_id, epoch, &signer);
+        let result =
+            SignedPublicKey::new(key, checksum, runtime_id, key_pair_id, b"", epoch, &signer);
         assert!(result.is_ok(), "signing public key should work");
         let signed_pk = result.unwrap();
 
         // Verify the signature.
-        let result = signed_pk.verify(runtime_id, key_pair_id, epoch, now, &pk);
+        let result = signed_pk.verify(runtime_id, key_pair_id, b"", epoch, now, &pk);
         assert!(result.is_ok(), "verification should succeed");
 
         // Verify the signature with different runtime id.
-        let result = signed_pk.verify(Namespace::from(vec![2u8; 32]), key_pair_id, epoch, now, &pk);
+        let result = signed_pk.verify(
+            Namespace::from(vec![2u8; 32]),
+            key_pair_id,
+            b"",
+            epoch,
+            now,
+            &pk,
+        );
         assert!(
             result.is_err(),
             "verification with different runtime id should fail"
@@ -331,18 +382,57 @@ mod test {
         assert_eq!(result.unwrap_err().to_string(), "invalid signature");
 
         // Verify the signature with different key pair id.
-        let result = signed_pk.verify(runtime_id, KeyPairId::from(vec![2u8; 32]), epoch, now, &pk);
+        let result = signed_pk.verify(
+            runtime_id,
+            KeyPairId::from(vec![2u8; 32]),
+            b"",
+            epoch,
+            now,
+            &pk,
+        );
         assert!(
             result.is_err(),
             "verification with different key pair id should fail"
         );
         assert_eq!(result.unwrap_err().to_string(), "invalid signature");
 
+        // Verify the signature with a key derivation context.
+        let result = signed_pk.verify(runtime_id, key_pair_id, b"context", epoch, now, &pk);
+        assert!(
+            result.is_err(),
+            "verification with a key derivation context should fail"
+        );
+        assert_eq!(result.unwrap_err().to_string(), "invalid signature");
+
+        // Verify a signature created under a key derivation context.
+        let context_signed_pk = SignedPublicKey::new(
+            key,
+            signed_pk.checksum.clone(),
+            runtime_id,
+            key_pair_id,
+            b"context",
+            epoch,
+            &signer,
+        )
+        .expect("signing public key should work");
+        let result = context_signed_pk.verify(runtime_id, key_pair_id, b"context", epoch, now, &pk);
+        assert!(result.is_ok(), "verification should succeed");
+        for context in [&b""[..], b"other", b"contex"] {
+            let result =
+                context_signed_pk.verify(runtime_id, key_pair_id, context, epoch, now, &pk);
+            assert!(
+                result.is_err(),
+                "verification with different context should fail"
+            );
+            assert_eq!(result.unwrap_err().to_string(), "invalid signature");
+        }
+
         // Verify the signature with different values of epoch.
         match epoch {
             Some(epoch) => {
                 // Verify the signature with different epoch.
-                let result = signed_pk.verify(runtime_id, key_pair_id, Some(epoch + 1), now, &pk);
+                let result =
+                    signed_pk.verify(runtime_id, key_pair_id, b"", Some(epoch + 1), now, &pk);
                 assert!(
                     result.is_err(),
                     "verification with different epoch should fail"
@@ -353,6 +443,7 @@ mod test {
                 let result = signed_pk.verify(
                     runtime_id,
                     key_pair_id,
+                    b"",
                     Some(epoch),
                     Some(epoch + MAX_SIGNED_EPHEMERAL_PUBLIC_KEY_AGE + 1),
                     &pk,
@@ -364,8 +455,14 @@ mod test {
                 assert_eq!(result.unwrap_err().to_string(), "signature expired");
 
                 // Verify the signature with epoch from the future.
-                let result =
-                    signed_pk.verify(runtime_id, key_pair_id, Some(epoch), Some(epoch - 1), &pk);
+                let result = signed_pk.verify(
+                    runtime_id,
+                    key_pair_id,
+                    b"",
+                    Some(epoch),
+                    Some(epoch - 1),
+                    &pk,
+                );
                 assert!(
                     result.is_err(),
                     "verification with epoch from the future should fail"
@@ -373,12 +470,12 @@ mod test {
                 assert_eq!(result.unwrap_err().to_string(), "signature from the future");
 
                 // Verify the signature without epoch.
-                let result = signed_pk.verify(runtime_id, key_pair_id, None, now, &pk);
+                let result = signed_pk.verify(runtime_id, key_pair_id, b"", None, now, &pk);
                 assert!(result.is_err(), "verification without epoch should fail");
                 assert_eq!(result.unwrap_err().to_string(), "invalid signature");
 
                 // Verify the signature without current epoch.
-                let result = signed_pk.verify(runtime_id, key_pair_id, Some(epoch), None, &pk);
+                let result = signed_pk.verify(runtime_id, key_pair_id, b"", Some(epoch), None, &pk);
                 assert!(
                     result.is_err(),
                     "verification without current epoch should fail"
@@ -387,7 +484,7 @@ mod test {
             }
             None => {
                 // Verify the signature with epoch.
-                let result = signed_pk.verify(runtime_id, key_pair_id, Some(1), Some(1), &pk);
+                let result = signed_pk.verify(runtime_id, key_pair_id, b"", Some(1), Some(1), &pk);
                 assert!(result.is_err(), "verification with an epoch should fail");
                 assert_eq!(result.unwrap_err().to_string(), "invalid signature");
             }
@@ -400,7 +497,7 @@ mod test {
             signature: signed_pk.signature.clone(),
             expiration: signed_pk.expiration,
         };
-        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, epoch, now, &pk);
+        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, b"", epoch, now, &pk);
         assert!(
             result.is_err(),
             "verification with different key should fail"
@@ -414,7 +511,7 @@ mod test {
             signature: signed_pk.signature.clone(),
             expiration: signed_pk.expiration,
         };
-        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, epoch, now, &pk);
+        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, b"", epoch, now, &pk);
         assert!(
             result.is_err(),
             "verification with different checksum should fail"
@@ -428,7 +525,7 @@ mod test {
             signature: signed_pk.signature.clone(),
             expiration: signed_pk.expiration,
         };
-        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, epoch, now, &pk);
+        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, b"", epoch, now, &pk);
         assert!(
             result.is_err(),
             "verification with invalid checksum should fail"
@@ -442,7 +539,7 @@ mod test {
             signature: signed_pk.signature.clone(),
             expiration: Some(100),
         };
-        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, epoch, Some(15), &pk);
+        let result = invalid_signed_pk.verify(runtime_id, key_pair_id, b"", epoch, Some(15), &pk);
         assert!(
             result.is_err(),
             "verification with different expiration epoch should fail"
@@ -32,6 +32,8 @@ lazy_static! {
 
 const POLICY_STORAGE_KEY: &[u8] = b"keymanager_policy";
 const POLICY_SEAL_CONTEXT: &[u8] = b"oasis-core/keymanager: policy seal";
+const POLICY_SERIAL_STORAGE_KEY_PREFIX: &[u8] = b"keymanager_policy_serial";
+const POLICY_SERIAL_SEAL_CONTEXT: &[u8] = b"oasis-core/keymanager: policy serial seal";
 
 /// Policy, which manages the key manager policy.
 pub struct Policy {
@@ -80,6 +82,12 @@ impl Policy {
         // Lock as late as possible.
         let mut inner = self.inner.write().unwrap();
 
+        // Refuse policies older than the highest policy applied so far, even if the
+        // persisted policy went missing or was replaced with an older one.
+        let serial = new_policy.serial;
+        let runtime_id = new_policy.runtime_id;
+        let highest_serial = Self::verify_serial(storage, &runtime_id, serial)?;
+
         // If there is no existing policy, attempt to load from local storage.
         let old_policy = inner
             .policy
@@ -89,15 +97,15 @@ impl Policy {
 
         // Compare the new serial number with the old serial number, ensure
         // it is greater.
-        match old_policy.serial.cmp(&new_policy.serial) {
-            Ordering::Greater => Err(KeyManagerError::PolicyRollback.into()),
+        let checksum = match old_policy.serial.cmp(&new_policy.serial) {
+            Ordering::Greater => return Err(KeyManagerError::PolicyRollback.into()),
             Ordering::Equal if old_policy.checksum != new_policy.checksum => {
                 // Policy should be identical.
-                Err(KeyManagerError::PolicyChanged.into())
+                return Err(KeyManagerError::PolicyChanged.into());
             }
             Ordering::Equal => {
                 inner.policy = Some(old_policy.clone());
-                Ok(old_policy.checksum)
+                old_policy.checksum
             }
             Ordering::Less => {
                 // Persist then apply the new policy.
@@ -105,10 +113,17 @@ impl Policy {
                 let new_checksum = new_policy.checksum.clone();
                 inner.policy = Some(new_policy);
 
-                // Return the checksum of the newly applied policy.
-                Ok(new_checksum)
+                new_checksum
             }
+        };
+
+        // Persist the highest serial applied so far.
+        if serial > highest_serial {
+            Self::save_highest_serial(storage, &runtime_id, serial);
         }
+
+        // Return the checksum of the applied policy.
+        Ok(checksum)
     }
 
     /// Check if the MRSIGNER/MRENCLAVE may query keys for the given
@@ -130,6 +145,26 @@ impl Policy {
         }
     }
 
+    /// Check if the MRSIGNER/MRENCLAVE may query keys derived under
+    /// the given key derivation context.
+    pub fn may_use_context(&self, remote_enclave: &EnclaveIdentity, context: &str) -> Result<()> {
+        // The default context is always allowed.
+        if context.is_empty() {
+            return Ok(());
+        }
+
+        let inner = self.inner.read().unwrap();
+        let policy = inner
+            .policy
+            .as_ref()
+            .ok_or(KeyManagerError::NotAuthorized)?;
+
+        match policy.may_use_context(remote_enclave, context) {
+            true => Ok(()),
+            false => Err(KeyManagerError::NotAuthorized.into()),
+        }
+    }
+
     /// Check if the MRENCLAVE/MRSIGNER may replicate.
     pub fn may_replicate_secret(&self, remote_enclave: &EnclaveIdentity) -> Result<()> {
         // Always allow replication to ourselves, if it is possible to do so in
@@ -192,6 +227,53 @@ impl Policy {
             .insert(POLICY_STORAGE_KEY.to_vec(), ciphertext)
             .expect("failed to persist policy");
     }
+
+    /// Ensure that the given policy serial is not lower than the highest serial applied
+    /// so far for the given runtime, and return the highest serial.
+    fn verify_serial(storage: &dyn KeyValue, runtime_id: &Namespace, serial: u32) -> Result<u32> {
+        let highest_serial = Self::load_highest_serial(storage, runtime_id);
+        if serial < highest_serial {
+            return Err(KeyManagerError::PolicyRollback.into());
+        }
+        Ok(highest_serial)
+    }
+
+    fn load_highest_serial(storage: &dyn KeyValue, runtime_id: &Namespace) -> u32 {
+        let key = Self::highest_serial_storage_key(runtime_id);
+        let ciphertext = storage.get(key).unwrap();
+
+        unseal(
+            Keypolicy::MRENCLAVE,
+            POLICY_SERIAL_SEAL_CONTEXT,
+            &ciphertext,
+        )
+        .unwrap()
+        .map(|plaintext| {
+            // Deserialization failures are fatal, because it is state corruption.
+            cbor::from_slice(&plaintext).expect("failed to deserialize persisted policy serial")
+        })
+        .unwrap_or_default()
+    }
+
+    fn save_highest_serial(storage: &dyn KeyValue, runtime_id: &Namespace, serial: u32) {
+        let key = Self::highest_serial_storage_key(runtime_id);
+        let ciphertext = seal(
+            Keypolicy::MRENCLAVE,
+            POLICY_SERIAL_SEAL_CONTEXT,
+            &cbor::to_vec(serial),
+        );
+
+        // Persist the encrypted serial.
+        storage
+            .insert(key, ciphertext)
+            .expect("failed to persist policy serial");
+    }
+
+    fn highest_serial_storage_key(runtime_id: &Namespace) -> Vec<u8> {
+        let mut key = POLICY_SERIAL_STORAGE_KEY_PREFIX.to_vec();
+        key.extend_from_slice(runtime_id.as_ref());
+        key
+    }
 }
 
 #[derive(Clone, Default, Debug)]
@@ -202,6 +284,7 @@ struct CachedPolicy {
     pub may_query: HashMap<Namespace, HashSet<EnclaveIdentity>>,
     pub may_replicate: HashSet<EnclaveIdentity>,
     pub may_replicate_from: HashSet<EnclaveIdentity>,
+    pub may_query_contexts: HashMap<EnclaveIdentity, HashSet<String>>,
     pub master_secret_rotation_interval: EpochTime,
     pub max_ephemeral_secret_age: EpochTime,
 }
@@ -237,6 +320,11 @@ impl CachedPolicy {
             }
             cached_policy.may_query.insert(*rt_id, query_ids);
         }
+        for (e_id, contexts) in &enclave_policy.may_query_contexts {
+            cached_policy
+                .may_query_contexts
+                .insert(e_id.clone(), contexts.iter().cloned().collect());
+        }
         for e_id in &enclave_policy.may_replicate {
             cached_policy.may_replicate.insert(e_id.clone());
         }
@@ -264,6 +352,14 @@ impl CachedPolicy {
         may_query.contains(remote_enclave)
     }
 
+    fn may_use_context(&self, remote_enclave: &EnclaveIdentity, context: &str) -> bool {
+        let contexts = match self.may_query_contexts.get(remote_enclave) {
+            Some(contexts) => contexts,
+            None => return false,
+        };
+        contexts.contains(context)
+    }
+
     fn may_replicate_secret(&self, remote_enclave: &EnclaveIdentity) -> bool {
         self.may_replicate.contains(remote_enclave)
     }
@@ -276,3 +372,35 @@ impl CachedPolicy {
         k.to_vec()
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use oasis_core_runtime::{common::namespace::Namespace, storage::UntrustedInMemoryStorage};
+
+    use super::Policy;
+
+    #[test]
+    fn test_highest_serial() {
+        let storage = UntrustedInMemoryStorage::new();
+        let runtime_id = Namespace::from(vec![1u8; 32]);
+        let other_runtime_id = Namespace::from(vec![2u8; 32]);
+
+        // Any serial is accepted when nothing has been persisted.
+        assert_eq!(Policy::load_highest_serial(&storage, &runtime_id), 0);
+        Policy::verify_serial(&storage, &runtime_id, 0).expect("serial should be accepted");
+
+        // Lower serials are refused once a higher one has been persisted.
+        Policy::save_highest_serial(&storage, &runtime_id, 5);
+        assert_eq!(Policy::load_highest_serial(&storage, &runtime_id), 5);
+        Policy::verify_serial(&storage, &runtime_id, 4).expect_err("rollback should be refused");
+        let highest = Policy::verify_serial(&storage, &runtime_id, 5)
+            .expect("the same serial should be accepted");
+        assert_eq!(highest, 5);
+        Policy::verify_serial(&storage, &runtime_id, 6).expect("higher serial should be accepted");
+
+        // Serials are persisted per runtime.
+        assert_eq!(Policy::load_highest_serial(&storage, &other_runtime_id), 0);
+        Policy::verify_serial(&storage, &other_runtime_id, 1)
+            .expect("serials of other runtimes should be independent");
+    }
+}
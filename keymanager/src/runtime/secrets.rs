@@ -49,14 +49,15 @@ use crate::{
     api::{
         EphemeralKeyRequest, GenerateEphemeralSecretRequest, GenerateEphemeralSecretResponse,
         GenerateMasterSecretRequest, GenerateMasterSecretResponse, InitRequest, InitResponse,
-        KeyManagerError, LoadEphemeralSecretRequest, LoadMasterSecretRequest, LongTermKeyRequest,
-        ReplicateEphemeralSecretRequest, ReplicateEphemeralSecretResponse,
-        ReplicateMasterSecretRequest, ReplicateMasterSecretResponse, SignedInitResponse,
+        KeyCacheStatsResponse, KeyManagerError, LoadEphemeralSecretRequest,
+        LoadMasterSecretRequest, LongTermKeyRequest, ReplicateEphemeralSecretRequest,
+        ReplicateEphemeralSecretResponse, ReplicateMasterSecretRequest,
+        ReplicateMasterSecretResponse, SignedInitResponse, LOCAL_METHOD_FLUSH_KEY_CACHE,
         LOCAL_METHOD_GENERATE_EPHEMERAL_SECRET, LOCAL_METHOD_GENERATE_MASTER_SECRET,
-        LOCAL_METHOD_INIT, LOCAL_METHOD_LOAD_EPHEMERAL_SECRET, LOCAL_METHOD_LOAD_MASTER_SECRET,
-        METHOD_GET_OR_CREATE_EPHEMERAL_KEYS, METHOD_GET_OR_CREATE_KEYS,
-        METHOD_GET_PUBLIC_EPHEMERAL_KEY, METHOD_GET_PUBLIC_KEY, METHOD_REPLICATE_EPHEMERAL_SECRET,
-        METHOD_REPLICATE_MASTER_SECRET,
+        LOCAL_METHOD_INIT, LOCAL_METHOD_KEY_CACHE_STATS, LOCAL_METHOD_LOAD_EPHEMERAL_SECRET,
+        LOCAL_METHOD_LOAD_MASTER_SECRET, METHOD_GET_OR_CREATE_EPHEMERAL_KEYS,
+        METHOD_GET_OR_CREATE_KEYS, METHOD_GET_PUBLIC_EPHEMERAL_KEY, METHOD_GET_PUBLIC_KEY,
+        METHOD_REPLICATE_EPHEMERAL_SECRET, METHOD_REPLICATE_MASTER_SECRET,
     },
     client::RemoteClient,
     crypto::{
@@ -133,6 +134,7 @@ impl Secrets {
             checksum,
             epoch,
             &provider,
+            req.replication_quorum as usize,
         )?;
 
         // State is up-to-date, build the response and sign it with the RAK.
@@ -145,13 +147,14 @@ impl Secrets {
         ctx: &RpcContext,
         req: &LongTermKeyRequest,
     ) -> Result<KeyPair> {
-        Self::authorize_private_key_generation(ctx, &req.runtime_id)?;
+        Self::authorize_private_key_generation(ctx, &req.runtime_id, &req.context)?;
         self.validate_height_freshness(req.height)?;
 
         Kdf::global().get_or_create_longterm_keys(
             &self.storage,
             req.runtime_id,
             req.key_pair_id,
+            req.context.as_bytes(),
             req.generation,
         )
     }
@@ -161,14 +164,16 @@ impl Secrets {
         // No authentication or authorization.
         // Absolutely anyone is allowed to query public long-term keys.
 
+        let context = req.context.as_bytes();
         let kdf = Kdf::global();
         let pk = kdf.get_public_longterm_key(
             &self.storage,
             req.runtime_id,
             req.key_pair_id,
+            context,
             req.generation,
         )?;
-        let sig = kdf.sign_public_key(pk, req.runtime_id, req.key_pair_id, None)?;
+        let sig = kdf.sign_public_key(pk, req.runtime_id, req.key_pair_id, context, None)?;
         Ok(sig)
     }
 
@@ -178,11 +183,16 @@ impl Secrets {
         ctx: &RpcContext,
         req: &EphemeralKeyRequest,
     ) -> Result<KeyPair> {
-        Self::authorize_private_key_generation(ctx, &req.runtime_id)?;
+        Self::authorize_private_key_generation(ctx, &req.runtime_id, &req.context)?;
         self.validate_ephemeral_key_epoch(req.epoch)?;
         self.validate_height_freshness(req.height)?;
 
-        Kdf::global().get_or_create_ephemeral_keys(req.runtime_id, req.key_pair_id, req.epoch)
+        Kdf::global().get_or_create_ephemeral_keys(
+            req.runtime_id,
+            req.key_pair_id,
+            req.context.as_bytes(),
+            req.epoch,
+        )
     }
 
     /// See `Kdf::get_public_ephemeral_key`.
@@ -191,9 +201,17 @@ impl Secrets {
         // Absolutely anyone is allowed to query public ephemeral keys.
         self.validate_ephemeral_key_epoch(req.epoch)?;
 
+        let context = req.context.as_bytes();
         let kdf = Kdf::global();
-        let pk = kdf.get_public_ephemeral_key(req.runtime_id, req.key_pair_id, req.epoch)?;
-        let sig = kdf.sign_public_key(pk, req.runtime_id, req.key_pair_id, Some(req.epoch))?;
+        let pk =
+            kdf.get_public_ephemeral_key(req.runtime_id, req.key_pair_id, context, req.epoch)?;
+        let sig = kdf.sign_public_key(
+            pk,
+            req.runtime_id,
+            req.key_pair_id,
+            context,
+            Some(req.epoch),
+        )?;
 
         Ok(sig)
     }
@@ -339,6 +357,17 @@ impl Secrets {
         })
     }
 
+    /// Return statistics of the local key caches.
+    pub fn key_cache_stats(&self) -> Result<KeyCacheStatsResponse> {
+        Ok(Kdf::global().cache_stats())
+    }
+
+    /// Clear the local key caches.
+    pub fn flush_key_cache(&self) -> Result<()> {
+        Kdf::global().clear_cache();
+        Ok(())
+    }
+
     /// Decrypt and store a proposal for the next master secret.
     pub fn load_master_secret(&self, req: &LoadMasterSecretRequest) -> Result<()> {
         let signed_secret = self.validate_signed_master_secret(&req.signed_secret)?;
@@ -490,12 +519,18 @@ impl Secrets {
     }
 
     /// Authorize the remote enclave so that the private keys are never released to an incorrect enclave.
-    fn authorize_private_key_generation(ctx: &RpcContext, runtime_id: &Namespace) -> Result<()> {
+    fn authorize_private_key_generation(
+        ctx: &RpcContext,
+        runtime_id: &Namespace,
+        context: &str,
+    ) -> Result<()> {
         if Policy::unsafe_skip() {
             return Ok(()); // Authorize unsafe builds always.
         }
         let remote_enclave = Self::authenticate(ctx)?;
-        Policy::global().may_get_or_create_keys(remote_enclave, runtime_id)
+        let policy = Policy::global();
+        policy.may_get_or_create_keys(remote_enclave, runtime_id)?;
+        policy.may_use_context(remote_enclave, context)
     }
 
     /// Authorize the remote enclave so that the master and ephemeral secrets are never replicated
@@ -740,6 +775,20 @@ impl Handler for Secrets {
                 },
                 move |_ctx: &_, req: &_| self.load_ephemeral_secret(req),
             ),
+            RpcMethod::new(
+                RpcMethodDescriptor {
+                    name: LOCAL_METHOD_KEY_CACHE_STATS.to_string(),
+                    kind: RpcKind::LocalQuery,
+                },
+                move |_ctx: &_, _req: &()| self.key_cache_stats(),
+            ),
+            RpcMethod::new(
+                RpcMethodDescriptor {
+                    name: LOCAL_METHOD_FLUSH_KEY_CACHE.to_string(),
+                    kind: RpcKind::LocalQuery,
+                },
+                move |_ctx: &_, _req: &()| self.flush_key_cache(),
+            ),
         ]
     }
 }
@@ -1,6 +1,9 @@
-use oasis_core_runtime::{common::namespace::Namespace, consensus::beacon::EpochTime};
+use oasis_core_runtime::{
+    common::{crypto::signature::PublicKey, namespace::Namespace, sgx::EnclaveIdentity},
+    consensus::beacon::EpochTime,
+};
 
-use crate::crypto::{kdf::Kdf, Secret, VerifiableSecret, SECRET_SIZE};
+use crate::crypto::{kdf::Kdf, Secret, SecretSource, VerifiableSecret, SECRET_SIZE};
 
 use super::SecretProvider;
 
@@ -9,6 +12,8 @@ use super::SecretProvider;
 pub struct MockSecretProvider {
     runtime_id: Namespace,
     disabled: bool,
+    replicas: usize,
+    enclaves: Option<usize>,
 }
 
 impl MockSecretProvider {
@@ -19,6 +24,35 @@ impl MockSecretProvider {
         Self {
             runtime_id,
             disabled,
+            replicas: 1,
+            enclaves: None,
+        }
+    }
+
+    /// Set the number of replicas of each master secret.
+    ///
+    /// Unless configured otherwise, every replica is provided by a distinct enclave.
+    pub fn with_replicas(mut self, replicas: usize) -> Self {
+        self.replicas = replicas;
+        self
+    }
+
+    /// Set the number of distinct enclaves that provide the replicas.
+    pub fn with_enclaves(mut self, enclaves: usize) -> Self {
+        self.enclaves = Some(enclaves);
+        self
+    }
+
+    /// Get the identity of the enclave that provides the given replica.
+    fn replica_source(&self, replica: usize) -> SecretSource {
+        let enclave = match self.enclaves {
+            Some(enclaves) => replica % enclaves.max(1),
+            None => replica,
+        };
+
+        SecretSource {
+            enclave: EnclaveIdentity::default(),
+            rak: PublicKey::from(vec![enclave as u8; 32]),
         }
     }
 
@@ -56,13 +90,13 @@ impl SecretProvider for MockSecretProvider {
         } else {
             self.checksum_master_secret(generation - 1)
         };
-        let mut result = Some(VerifiableSecret { secret, checksum });
+        let replicas = if self.disabled { 0 } else { self.replicas };
 
-        if self.disabled {
-            result = None;
-        }
-
-        Box::new(std::iter::from_fn(move || result.take()))
+        Box::new((0..replicas).map(move |replica| VerifiableSecret {
+            secret: secret.clone(),
+            checksum: checksum.clone(),
+            source: Some(self.replica_source(replica)),
+        }))
     }
 
     fn ephemeral_secret_iter(&self, epoch: EpochTime) -> Box<dyn Iterator<Item = Secret> + '_> {
@@ -7,7 +7,9 @@
 use std::collections::BTreeMap;
 
 use crate::{
-    common::{quantity::Quantity, version::ProtocolVersions},
+    common::{
+        crypto::hash::Hash, namespace::Namespace, quantity::Quantity, version::ProtocolVersions,
+    },
     consensus::beacon::EpochTime,
 };
 
@@ -60,6 +62,22 @@ pub struct ChangeParametersProposal {
     pub changes: Option<cbor::Value>,
 }
 
+/// Signal proposal content.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct SignalProposal {
+    pub description_hash: Hash,
+    #[cbor(optional)]
+    pub metadata_url: String,
+}
+
+/// Runtime pause proposal content.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct PauseRuntimeProposal {
+    pub runtime_id: Namespace,
+    #[cbor(optional)]
+    pub resume: bool,
+}
+
 /// Consensus layer governance proposal content.
 #[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
 pub struct ProposalContent {
@@ -69,6 +87,10 @@ pub struct ProposalContent {
     pub cancel_upgrade: Option<CancelUpgradeProposal>,
     #[cbor(optional)]
     pub change_parameters: Option<ChangeParametersProposal>,
+    #[cbor(optional)]
+    pub signal: Option<SignalProposal>,
+    #[cbor(optional)]
+    pub pause_runtime: Option<PauseRuntimeProposal>,
 }
 
 // Allowed governance consensus parameter changes.
@@ -83,11 +105,17 @@ pub struct ConsensusParameterChanges {
     #[cbor(optional)]
     pub stake_threshold: Option<u8>,
     #[cbor(optional)]
+    pub stake_thresholds: Option<BTreeMap<String, u8>>,
+    #[cbor(optional)]
     pub upgrade_min_epoch_diff: Option<EpochTime>,
     #[cbor(optional)]
     pub upgrade_cancel_min_epoch_diff: Option<EpochTime>,
     #[cbor(optional)]
     pub enable_change_parameters_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub enable_signal_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub enable_pause_runtime_proposal: Option<bool>,
 }
 
 #[cfg(test)]
@@ -175,6 +203,40 @@ mod tests {
                     ..Default::default()
                 }
             ),
+            (
+                "oWZzaWduYWyibG1ldGFkYXRhX3VybHghaHR0cHM6Ly9leGFtcGxlLmNvbS9wcm9wb3NhbC5qc29ucGRlc2NyaXB0aW9uX2hhc2hYIJTiVDiHkZ9jS7WEGJ5e5Zgx6PMQGgeG73D5g2gToQW2",
+                ProposalContent {
+                    signal: Some(SignalProposal {
+                        description_hash: Hash::digest_bytes(b"description"),
+                        metadata_url: "https://example.com/proposal.json".into(),
+                    }),
+                    ..Default::default()
+                },
+            ),
+            (
+                "oW1wYXVzZV9ydW50aW1loWpydW50aW1lX2lkWCCAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==",
+                ProposalContent {
+                    pause_runtime: Some(PauseRuntimeProposal {
+                        runtime_id: Namespace::from(
+                            "8000000000000000000000000000000000000000000000000000000000000000",
+                        ),
+                        resume: false,
+                    }),
+                    ..Default::default()
+                },
+            ),
+            (
+                "oW1wYXVzZV9ydW50aW1lomZyZXN1bWX1anJ1bnRpbWVfaWRYIIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+                ProposalContent {
+                    pause_runtime: Some(PauseRuntimeProposal {
+                        runtime_id: Namespace::from(
+                            "8000000000000000000000000000000000000000000000000000000000000000",
+                        ),
+                        resume: true,
+                    }),
+                    ..Default::default()
+                },
+            ),
         ];
         for (encoded_base64, content) in tcs {
             let dec: ProposalContent =
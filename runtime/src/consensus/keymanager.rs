@@ -5,7 +5,7 @@ use thiserror::Error;
 
 use crate::common::{
     crypto::{
-        signature::{Signature, SignatureBundle, Signer},
+        signature::{PublicKey, Signature, SignatureBundle, Signer},
         x25519,
     },
     namespace::Namespace,
@@ -44,6 +44,8 @@ pub struct PolicySGX {
     pub master_secret_rotation_interval: EpochTime,
     #[cbor(optional)]
     pub max_ephemeral_secret_age: EpochTime,
+    #[cbor(optional)]
+    pub master_secret_generators: Vec<PublicKey>,
 }
 
 /// Per enclave key manager access control policy.
@@ -58,6 +60,14 @@ pub struct EnclavePolicySGX {
     /// NOTE: Each enclave ID may always implicitly replicate from other
     /// instances of itself.
     pub may_replicate: Vec<EnclaveIdentity>,
+
+    /// A map of enclave IDs to the vector of key derivation context labels
+    /// under which they may query private key material.
+    ///
+    /// NOTE: Each enclave ID may always query keys under the default (empty)
+    /// context.
+    #[cbor(optional)]
+    pub may_query_contexts: HashMap<EnclaveIdentity, Vec<String>>,
 }
 
 /// Signed key manager access control policy.
@@ -130,6 +130,46 @@ pub struct CapabilityTEE {
 
     /// Attestation.
     pub attestation: Vec<u8>,
+
+    /// Structured TEE hardware details.
+    #[cbor(optional)]
+    pub details: Option<TEEDetails>,
+}
+
+/// Structured TEE hardware details reported by the node.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct TEEDetails {
+    /// SGX platform details.
+    #[cbor(optional)]
+    pub sgx: Option<SGXDetails>,
+}
+
+/// SGX attestation type.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+#[repr(u8)]
+pub enum SGXAttestationType {
+    /// Invalid attestation type.
+    #[default]
+    Invalid = 0,
+    /// EPID-based attestation via IAS.
+    EPID = 1,
+    /// DCAP-based (ECDSA) attestation.
+    DCAP = 2,
+}
+
+/// SGX platform details.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct SGXDetails {
+    /// Attestation type supported by the platform.
+    pub attestation_type: SGXAttestationType,
+
+    /// Whether the platform supports Flexible Launch Control.
+    #[cbor(optional)]
+    pub flc: bool,
+
+    /// Total size of the Enclave Page Cache in bytes.
+    #[cbor(optional)]
+    pub epc_size: u64,
 }
 
 impl CapabilityTEE {
@@ -260,6 +300,20 @@ pub struct Capabilities {
     /// Is the capability of a node executing batches in a TEE.
     #[cbor(optional)]
     pub tee: Option<CapabilityTEE>,
+
+    /// Additional TEE capabilities of the node (e.g., attestations using a different attestation
+    /// scheme or for a different enclave identity).
+    #[cbor(optional)]
+    pub additional_tees: Vec<CapabilityTEE>,
+}
+
+impl Capabilities {
+    /// Returns all TEE capabilities, starting with the primary one.
+    pub fn tees(&self) -> impl Iterator<Item = &CapabilityTEE> {
+        self.tee
+            .iter()
+            .chain(self.tee.iter().flat_map(|_| self.additional_tees.iter()))
+    }
 }
 
 /// Represents the runtimes supported by a given Oasis node.
@@ -334,6 +388,27 @@ impl std::ops::BitOr for RolesMask {
     }
 }
 
+/// Oasis node declared features bitmask.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+#[cbor(transparent)]
+pub struct FeaturesMask(pub u32);
+
+impl FeaturesMask {
+    /// Empty features mask.
+    pub const FEATURE_EMPTY: FeaturesMask = FeaturesMask(0);
+    /// Serving storage checkpoints to peers.
+    pub const FEATURE_CHECKPOINT_SERVING: FeaturesMask = FeaturesMask(1 << 0);
+    /// Producing DCAP-based SGX attestations.
+    pub const FEATURE_DCAP: FeaturesMask = FeaturesMask(1 << 1);
+    /// Retaining the full history.
+    pub const FEATURE_ARCHIVAL: FeaturesMask = FeaturesMask(1 << 2);
+
+    /// Whether the features mask contains all of the specified features.
+    pub fn contains(&self, features: FeaturesMask) -> bool {
+        (self.0 & features.0) == features.0
+    }
+}
+
 impl PartialOrd for RolesMask {
     fn partial_cmp(&self, other: &Self) -> Option<std::cmp::Ordering> {
         Some(self.cmp(other))
@@ -388,6 +463,10 @@ pub struct Node {
     /// Node's oasis-node software version.
     #[cbor(optional)]
     pub software_version: Option<String>,
+
+    /// Bitmask representing the node declared features.
+    #[cbor(optional)]
+    pub features: FeaturesMask,
 }
 
 impl Node {
@@ -396,6 +475,11 @@ impl Node {
         self.roles.contains(roles)
     }
 
+    /// Checks whether the node declares all of the specified features.
+    pub fn has_features(&self, features: FeaturesMask) -> bool {
+        self.features.contains(features)
+    }
+
     /// Checks whether the node has the provided TEE identity configured.
     pub fn has_tee(&self, identity: &Identity, runtime_id: &Namespace, version: &Version) -> bool {
         if let Some(rts) = &self.runtimes {
@@ -406,10 +490,8 @@ impl Node {
                 if version != &rt.version {
                     continue;
                 }
-                if let Some(tee) = &rt.capabilities.tee {
-                    if tee.matches(identity) {
-                        return true;
-                    }
+                if rt.capabilities.tees().any(|tee| tee.matches(identity)) {
+                    return true;
                 }
             }
         }
@@ -478,6 +560,19 @@ pub struct ExecutorParameters {
     /// node. Zero means unlimited.
     #[cbor(optional)]
     pub max_liveness_fails: u8,
+    /// Size of the standby group. Standby workers do not participate in rounds, but keep their
+    /// state warm so that they can replace failing workers mid-epoch.
+    #[cbor(optional)]
+    pub group_standby_size: u16,
+    /// Number of proposals a worker can miss in an epoch before it is replaced by a standby
+    /// worker for the rest of the epoch.
+    #[cbor(optional)]
+    pub standby_promotion_threshold: u16,
+    /// Percentage of each committee group that is rotated on every election, while the
+    /// remaining members that are still eligible are retained. Zero means that the whole
+    /// committee is re-elected every epoch.
+    #[cbor(optional)]
+    pub rotation_percent: u8,
 }
 
 /// Parameters for the runtime transaction scheduler.
@@ -510,6 +605,15 @@ pub struct StorageParameters {
     pub checkpoint_num_kept: u64,
     /// Chunk size parameter for checkpoint creation.
     pub checkpoint_chunk_size: u64,
+    /// Maximum size of state keys (in bytes) the runtime may write. Zero means no limit.
+    #[cbor(optional)]
+    pub max_key_size: u64,
+    /// Maximum size of state values (in bytes) the runtime may write. Zero means no limit.
+    #[cbor(optional)]
+    pub max_value_size: u64,
+    /// State key prefixes that the runtime may not write to.
+    #[cbor(optional)]
+    pub reserved_key_prefixes: Vec<Vec<u8>>,
 }
 
 /// The node scheduling constraints.
@@ -525,6 +629,9 @@ pub struct SchedulingConstraints {
 
     #[cbor(optional)]
     pub min_pool_size: Option<MinPoolSizeConstraint>,
+
+    #[cbor(optional)]
+    pub features: Option<FeaturesConstraint>,
 }
 
 /// A constraint which specifies that the entity must have a node that is part of the validator set.
@@ -544,6 +651,12 @@ pub struct MinPoolSizeConstraint {
     pub limit: u16,
 }
 
+/// A constraint which specifies that the node must declare support for all of the given features.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct FeaturesConstraint {
+    pub required: FeaturesMask,
+}
+
 /// Stake-related parameters for a runtime.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct RuntimeStakingParameters {
@@ -854,6 +967,15 @@ pub enum TEEHardware {
 /// versions may be rejected.
 pub const LATEST_RUNTIME_DESCRIPTOR_VERSION: u16 = 3;
 
+/// Scheduled replacement of the key manager runtime used by a compute runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct KeyManagerChange {
+    /// Runtime ID of the new key manager.
+    pub key_manager: Namespace,
+    /// Epoch at which the runtime switches to the new key manager.
+    pub epoch: EpochTime,
+}
+
 /// Runtime.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct Runtime {
@@ -875,6 +997,9 @@ pub struct Runtime {
     /// Key manager runtime ID for this runtime.
     #[cbor(optional)]
     pub key_manager: Option<Namespace>,
+    /// Scheduled replacement of the key manager runtime.
+    #[cbor(optional)]
+    pub key_manager_change: Option<KeyManagerChange>,
     /// Parameters of the executor committee.
     #[cbor(optional)]
     pub executor: ExecutorParameters,
@@ -897,6 +1022,23 @@ pub struct Runtime {
     pub governance_model: RuntimeGovernanceModel,
 }
 
+/// Partial update of a runtime descriptor that can be performed by runtimes using the runtime
+/// governance model. Fields that are not set are left unchanged.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct RuntimeParametersUpdate {
+    /// Identifier of the runtime being updated.
+    pub id: Namespace,
+    /// New runtime deployments.
+    #[cbor(optional)]
+    pub deployments: Option<Vec<VersionInfo>>,
+    /// New executor committee parameters.
+    #[cbor(optional)]
+    pub executor: Option<ExecutorParameters>,
+    /// New transaction scheduling parameters.
+    #[cbor(optional)]
+    pub txn_scheduler: Option<TxnSchedulerParameters>,
+}
+
 fn staking_params_are_empty(p: &RuntimeStakingParameters) -> bool {
     p.thresholds.is_empty()
         && p.slashing.is_empty()
@@ -1039,6 +1181,7 @@ mod tests {
                     key_manager: Some(Namespace::from(
                         "8000000000000000000000000000000000000000000000000000000000000001",
                     )),
+                    key_manager_change: None,
                     executor: ExecutorParameters {
                         group_size: 9,
                         group_backup_size: 8,
@@ -1049,6 +1192,9 @@ mod tests {
                         max_missed_proposals_percent: 3,
                         min_live_rounds_eval: 2,
                         max_liveness_fails: 1,
+                        group_standby_size: 0,
+                        standby_promotion_threshold: 0,
+                        rotation_percent: 0,
                     },
                     txn_scheduler: TxnSchedulerParameters {
                         batch_flush_timeout: 1_000_000_000, // 1 second.
@@ -1061,6 +1207,7 @@ mod tests {
                         checkpoint_interval: 33,
                         checkpoint_num_kept: 6,
                         checkpoint_chunk_size: 101,
+                        ..Default::default()
                     },
                     admission_policy: RuntimeAdmissionPolicy {
                         entity_whitelist: Some(EntityWhitelistRuntimeAdmissionPolicy {
@@ -1089,6 +1236,7 @@ mod tests {
                                     }
                                 ),
                                 validator_set: Some(ValidatorSetConstraint{}),
+                                features: None,
                             },
                         }
                     },
@@ -1159,6 +1307,7 @@ mod tests {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                }),
+                               ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -1284,6 +1433,7 @@ mod tests {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                 }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -1333,6 +1483,7 @@ mod tests {
                                     rek: Some(x25519::PublicKey::from([0;32])),
                                     attestation: vec![0, 1,2,3,4,5],
                                 }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
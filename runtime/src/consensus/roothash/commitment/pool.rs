@@ -162,15 +162,15 @@ impl Pool {
                     Error::NotInCommittee,
                 )?;
 
-                let tee = rt.capabilities.tee.ok_or(
-                    // This should never happen as we prevent this elsewhere.
-                    Error::RakSigInvalid,
-                )?;
-
-                commit
-                    .header
-                    .verify_rak(tee.rak)
-                    .map_err(|_| Error::RakSigInvalid)?;
+                // The commitment may be signed by the RAK of any of the node's TEE capabilities.
+                let rak_valid = rt
+                    .capabilities
+                    .tees()
+                    .any(|tee| commit.header.verify_rak(tee.rak).is_ok());
+                if !rak_valid {
+                    // A missing TEE capability should never happen as we prevent this elsewhere.
+                    return Err(Error::RakSigInvalid.into());
+                }
             }
 
             // Check emitted runtime messages.
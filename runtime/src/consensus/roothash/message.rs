@@ -1,4 +1,4 @@
-use anyhow::Result;
+use anyhow::{anyhow, Result};
 
 use crate::{
     common::{crypto::hash::Hash, quantity::Quantity, versioned::Versioned},
@@ -90,6 +90,8 @@ impl StakingMessage {
 pub enum RegistryMessage {
     #[cbor(rename = "update_runtime")]
     UpdateRuntime(registry::Runtime),
+    #[cbor(rename = "update_runtime_parameters")]
+    UpdateRuntimeParameters(registry::RuntimeParametersUpdate),
 }
 
 impl RegistryMessage {
@@ -102,6 +104,15 @@ impl RegistryMessage {
                 // to do any validation here.
                 Ok(())
             }
+            RegistryMessage::UpdateRuntimeParameters(update) => {
+                if update.deployments.is_none()
+                    && update.executor.is_none()
+                    && update.txn_scheduler.is_none()
+                {
+                    return Err(anyhow!("registry runtime message has no fields set"));
+                }
+                Ok(())
+            }
         }
     }
 }
@@ -208,6 +219,7 @@ mod tests {
             tee_hardware: registry::TEEHardware::TEEHardwareInvalid,
             deployments: vec![registry::VersionInfo::default()],
             key_manager: None,
+            key_manager_change: None,
             executor: registry::ExecutorParameters {
                 group_size: 3,
                 group_backup_size: 5,
@@ -227,6 +239,7 @@ mod tests {
                 checkpoint_interval: 0,
                 checkpoint_num_kept: 0,
                 checkpoint_chunk_size: 0,
+                ..Default::default()
             },
             admission_policy: registry::RuntimeAdmissionPolicy {
                 entity_whitelist: Some(registry::EntityWhitelistRuntimeAdmissionPolicy {
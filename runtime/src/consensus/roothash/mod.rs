@@ -145,6 +145,9 @@ pub struct RuntimeState {
 
     /// Liveness statistics for the current epoch.
     pub liveness_stats: Option<LivenessStatistics>,
+    /// Executor workers that have been replaced by standby workers in the current epoch.
+    #[cbor(optional)]
+    pub demoted_workers: Vec<PublicKey>,
 }
 
 /// Per-epoch liveness statistics for nodes.
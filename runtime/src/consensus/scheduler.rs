@@ -19,6 +19,8 @@ pub enum Role {
     Worker = 1,
     /// Indicates the node is a backup worker.
     BackupWorker = 2,
+    /// Indicates the node is a standby worker.
+    StandbyWorker = 3,
 }
 
 /// A node participating in a committee.
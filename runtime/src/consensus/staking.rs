@@ -78,6 +78,28 @@ pub struct GeneralAccount {
 
     #[cbor(optional)]
     pub allowances: BTreeMap<Address, Quantity>,
+
+    #[cbor(optional)]
+    pub vesting: Option<VestingSchedule>,
+
+    #[cbor(optional)]
+    pub vesting_allowances: BTreeMap<Address, Quantity>,
+}
+
+/// A step in a vesting schedule.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct VestingStep {
+    pub epoch: EpochTime,
+    pub amount: Quantity,
+}
+
+/// A vesting schedule of locked balance in a general account.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct VestingSchedule {
+    pub total: Quantity,
+
+    #[cbor(optional)]
+    pub steps: Vec<VestingStep>,
 }
 
 /// Escrow account.
@@ -245,10 +245,12 @@ mod test {
                             EnclavePolicySGX {
                                 may_query: HashMap::from([(runtime, vec![runtime_enclave])]),
                                 may_replicate: vec![keymanager_enclave2],
+                                may_query_contexts: HashMap::new(),
                             },
                         )]),
                         master_secret_rotation_interval: 0,
                         max_ephemeral_secret_age: 10,
+                        master_secret_generators: vec![],
                     },
                     signatures: vec![
                         SignatureBundle {
@@ -193,6 +193,7 @@ mod test {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                }),
+                               ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -22,7 +22,10 @@ use crate::{
         sgx::{EnclaveIdentity, QuotePolicy},
         time::insecure_posix_time,
     },
-    enclave_rpc::{session::Builder, types},
+    enclave_rpc::{
+        session::{Builder, SessionInfo},
+        types,
+    },
     protocol::Protocol,
 };
 
@@ -66,6 +69,7 @@ pub enum RpcClientError {
 pub struct Response<'a, T> {
     transport: &'a dyn Transport,
     request_id: Option<u64>,
+    session_info: Option<Arc<SessionInfo>>,
     inner: Result<T, RpcClientError>,
 }
 
@@ -91,6 +95,13 @@ impl<'a, T> Response<'a, T> {
         self.inner
     }
 
+    /// Information about the authenticated remote enclave that produced the response.
+    ///
+    /// This is only available for calls made over a Noise session.
+    pub fn session_info(&self) -> Option<Arc<SessionInfo>> {
+        self.session_info.clone()
+    }
+
     /// Report success as peer feedback.
     pub async fn success(&mut self) {
         self.send_peer_feedback(types::PeerFeedback::Success).await;
@@ -262,22 +273,26 @@ impl RpcClient {
         })
         .await;
 
-        let (request_id, inner) = match result {
-            Ok((request_id, response)) => match response.body {
+        let (request_id, session_info, inner) = match result {
+            Ok((request_id, session_info, response)) => match response.body {
                 types::Body::Success(value) => (
                     Some(request_id),
+                    session_info,
                     cbor::from_value(value).map_err(Into::into),
                 ),
-                types::Body::Error(error) => {
-                    (Some(request_id), Err(RpcClientError::CallFailed(error)))
-                }
+                types::Body::Error(error) => (
+                    Some(request_id),
+                    session_info,
+                    Err(RpcClientError::CallFailed(error)),
+                ),
             },
-            Err(err) => (None, Err(err)),
+            Err(err) => (None, None, Err(err)),
         };
 
         Response {
             transport: &*self.transport,
             request_id,
+            session_info,
             inner,
         }
     }
@@ -287,7 +302,7 @@ impl RpcClient {
         request: types::Request,
         kind: types::Kind,
         nodes: Vec<signature::PublicKey>,
-    ) -> Result<(u64, types::Response), RpcClientError> {
+    ) -> Result<(u64, Option<Arc<SessionInfo>>, types::Response), RpcClientError> {
         match kind {
             types::Kind::NoiseSession => {
                 // Attempt to establish a connection. This will not do anything in case the
@@ -306,11 +321,13 @@ impl RpcClient {
                     sessions.remove(&session);
                 }
 
-                result
+                let (request_id, response) = result?;
+                Ok((request_id, session.info(), response))
             }
             types::Kind::InsecureQuery => {
                 // Perform the call.
-                self.insecure_call_raw(request, nodes).await
+                let (request_id, response) = self.insecure_call_raw(request, nodes).await?;
+                Ok((request_id, None, response))
             }
             _ => Err(RpcClientError::UnsupportedRpcKind),
         }
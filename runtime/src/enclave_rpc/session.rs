@@ -336,7 +336,7 @@ impl Session {
             .unwrap_or_default()
             .iter()
             .filter(|rt| rt.id == runtime_id)
-            .flat_map(|rt| &rt.capabilities.tee)
+            .flat_map(|rt| rt.capabilities.tees())
             .any(|tee| tee.rak == rak);
 
         if !verified {
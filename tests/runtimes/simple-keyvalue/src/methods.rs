@@ -266,7 +266,7 @@ impl Methods {
         let future = ctx
             .parent
             .key_manager
-            .get_or_create_keys(key_pair_id, generation);
+            .get_or_create_keys(key_pair_id, "", generation);
         let key = block_on(future).map_err(|err| err.to_string())?;
 
         Ok(key.state_key)
@@ -432,7 +432,7 @@ impl Methods {
         let future = ctx
             .parent
             .key_manager
-            .get_public_ephemeral_key(key_pair_id, args.epoch);
+            .get_public_ephemeral_key(key_pair_id, "", args.epoch);
         let long_term_pk = block_on(future).map_err(|err| err.to_string())?;
 
         // Generate ephemeral key. Not secure, but good enough for testing purposes.
@@ -467,10 +467,10 @@ impl Methods {
         let key_pair_id = KeyPairId::from(hash.as_ref());
 
         // Fetch private key.
-        let future = ctx
-            .parent
-            .key_manager
-            .get_or_create_ephemeral_keys(key_pair_id, args.epoch);
+        let future =
+            ctx.parent
+                .key_manager
+                .get_or_create_ephemeral_keys(key_pair_id, "", args.epoch);
         let long_term_sk = block_on(future)
             .map_err(|err| format!("private ephemeral key not available: {err}"))?;
 